@@ -32,17 +32,33 @@ import (
 	appStoreDiscover "github.com/devtron-labs/devtron/api/appStore/discover"
 	appStoreValues "github.com/devtron-labs/devtron/api/appStore/values"
 	"github.com/devtron-labs/devtron/api/argoApplication"
+	"github.com/devtron-labs/devtron/api/artifactPromotion"
+	"github.com/devtron-labs/devtron/api/artifactProvenance"
+	"github.com/devtron-labs/devtron/api/auth/jitAccess"
+	"github.com/devtron-labs/devtron/api/auth/scim"
 	"github.com/devtron-labs/devtron/api/auth/sso"
 	"github.com/devtron-labs/devtron/api/auth/user"
+	"github.com/devtron-labs/devtron/api/autoRollback"
+	"github.com/devtron-labs/devtron/api/canaryAnalysis"
+	"github.com/devtron-labs/devtron/api/cdSchedule"
 	chartRepo "github.com/devtron-labs/devtron/api/chartRepo"
 	"github.com/devtron-labs/devtron/api/cluster"
+	"github.com/devtron-labs/devtron/api/configDraft"
 	"github.com/devtron-labs/devtron/api/connector"
+	"github.com/devtron-labs/devtron/api/cveException"
 	"github.com/devtron-labs/devtron/api/dashboardEvent"
 	"github.com/devtron-labs/devtron/api/deployment"
+	"github.com/devtron-labs/devtron/api/deploymentApproval"
+	"github.com/devtron-labs/devtron/api/deploymentWindow"
 	"github.com/devtron-labs/devtron/api/devtronResource"
 	"github.com/devtron-labs/devtron/api/externalLink"
 	fluxApplication "github.com/devtron-labs/devtron/api/fluxApplication"
+	"github.com/devtron-labs/devtron/api/gitOpsDrift"
+	"github.com/devtron-labs/devtron/api/gitOpsMonorepo"
 	client "github.com/devtron-labs/devtron/api/helm-app"
+	"github.com/devtron-labs/devtron/api/hibernationPolicy"
+	"github.com/devtron-labs/devtron/api/imageRetention"
+	"github.com/devtron-labs/devtron/api/imageSignature"
 	"github.com/devtron-labs/devtron/api/infraConfig"
 	"github.com/devtron-labs/devtron/api/k8s"
 	"github.com/devtron-labs/devtron/api/module"
@@ -118,8 +134,12 @@ import (
 	"github.com/devtron-labs/devtron/pkg/appStore/installedApp/service/FullMode/resource"
 	"github.com/devtron-labs/devtron/pkg/appWorkflow"
 	"github.com/devtron-labs/devtron/pkg/argoRepositoryCreds"
+	artifactPromotion2 "github.com/devtron-labs/devtron/pkg/artifactPromotion"
+	artifactProvenance2 "github.com/devtron-labs/devtron/pkg/artifactProvenance"
 	"github.com/devtron-labs/devtron/pkg/asyncProvider"
 	"github.com/devtron-labs/devtron/pkg/attributes"
+	jitAccess2 "github.com/devtron-labs/devtron/pkg/auth/jitAccess"
+	scim2 "github.com/devtron-labs/devtron/pkg/auth/scim"
 	"github.com/devtron-labs/devtron/pkg/build"
 	"github.com/devtron-labs/devtron/pkg/bulkAction"
 	"github.com/devtron-labs/devtron/pkg/chart"
@@ -127,6 +147,7 @@ import (
 	chartRepoRepository "github.com/devtron-labs/devtron/pkg/chartRepo/repository"
 	"github.com/devtron-labs/devtron/pkg/commonService"
 	"github.com/devtron-labs/devtron/pkg/configDiff"
+	cveException2 "github.com/devtron-labs/devtron/pkg/cveException"
 	delete2 "github.com/devtron-labs/devtron/pkg/delete"
 	deployment2 "github.com/devtron-labs/devtron/pkg/deployment"
 	"github.com/devtron-labs/devtron/pkg/deployment/common"
@@ -138,11 +159,15 @@ import (
 	"github.com/devtron-labs/devtron/pkg/generateManifest"
 	"github.com/devtron-labs/devtron/pkg/git"
 	"github.com/devtron-labs/devtron/pkg/gitops"
+	hibernationPolicy2 "github.com/devtron-labs/devtron/pkg/hibernationPolicy"
 	"github.com/devtron-labs/devtron/pkg/imageDigestPolicy"
+	imageRetention2 "github.com/devtron-labs/devtron/pkg/imageRetention"
+	imageSignature2 "github.com/devtron-labs/devtron/pkg/imageSignature"
 	infraConfigService "github.com/devtron-labs/devtron/pkg/infraConfig"
 	"github.com/devtron-labs/devtron/pkg/infraConfig/units"
 	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs"
 	repository7 "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/repository"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
 	"github.com/devtron-labs/devtron/pkg/notifier"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
 	"github.com/devtron-labs/devtron/pkg/pipeline/executors"
@@ -157,6 +182,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
 	"github.com/devtron-labs/devtron/pkg/security"
 	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording"
 	util3 "github.com/devtron-labs/devtron/pkg/util"
 	"github.com/devtron-labs/devtron/pkg/variables"
 	"github.com/devtron-labs/devtron/pkg/variables/parsers"
@@ -208,6 +234,33 @@ func InitializeApp() (*App, error) {
 		workflow3.WorkflowWireSet,
 
 		devtronResource.DevtronResourceWireSet,
+		deploymentWindow.DeploymentWindowWireSet,
+		canaryAnalysis.CanaryAnalysisWireSet,
+		autoRollback.AutoRollbackPolicyWireSet,
+		deploymentApproval.DeploymentApprovalWireSet,
+		configDraft.ConfigDraftWireSet,
+		leaderElection.LeaderElectionWireSet,
+		cdSchedule.CdTriggerScheduleWireSet,
+		hibernationPolicy.HibernationPolicyWireSet,
+		hibernationPolicy2.HibernationPolicyWireSet,
+		gitOpsMonorepo.GitOpsMonorepoWireSet,
+		gitOpsDrift.GitOpsDriftWireSet,
+		imageRetention.ImageRetentionWireSet,
+		imageRetention2.ImageRetentionWireSet,
+		artifactPromotion.ArtifactPromotionWireSet,
+		artifactPromotion2.ArtifactPromotionWireSet,
+		artifactProvenance.ArtifactProvenanceWireSet,
+		artifactProvenance2.ArtifactProvenanceWireSet,
+		imageSignature.ImageSignatureWireSet,
+		imageSignature2.ImageSignatureWireSet,
+		cveException.CveExceptionWireSet,
+		cveException2.CveExceptionWireSet,
+		terminalRecording.TerminalRecordingWireSet,
+		jitAccess.JitAccessWireSet,
+		jitAccess2.JitAccessWireSet,
+		scim.ScimWireSet,
+		scim2.ScimWireSet,
+		user.SsoGroupMappingWireSet,
 
 		// -------wireset end ----------
 		// -------
@@ -475,6 +528,23 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(notifier.WebhookNotificationService), new(*notifier.WebhookNotificationServiceImpl)),
 		repository.NewWebhookNotificationRepositoryImpl,
 		wire.Bind(new(repository.WebhookNotificationRepository), new(*repository.WebhookNotificationRepositoryImpl)),
+		notifier.NewTeamsNotificationServiceImpl,
+		wire.Bind(new(notifier.TeamsNotificationService), new(*notifier.TeamsNotificationServiceImpl)),
+		repository.NewTeamsNotificationRepositoryImpl,
+		wire.Bind(new(repository.TeamsNotificationRepository), new(*repository.TeamsNotificationRepositoryImpl)),
+		notifier.NewDiscordNotificationServiceImpl,
+		wire.Bind(new(notifier.DiscordNotificationService), new(*notifier.DiscordNotificationServiceImpl)),
+		repository.NewDiscordNotificationRepositoryImpl,
+		wire.Bind(new(repository.DiscordNotificationRepository), new(*repository.DiscordNotificationRepositoryImpl)),
+		notifier.NewPagerDutyNotificationServiceImpl,
+		wire.Bind(new(notifier.PagerDutyNotificationService), new(*notifier.PagerDutyNotificationServiceImpl)),
+		repository.NewPagerDutyNotificationRepositoryImpl,
+		wire.Bind(new(repository.PagerDutyNotificationRepository), new(*repository.PagerDutyNotificationRepositoryImpl)),
+		notifier.NewChannelNotificationSenderImpl,
+		wire.Bind(new(eClient.ChannelNotificationSender), new(*notifier.ChannelNotificationSenderImpl)),
+
+		repository.NewNotificationDigestRepositoryImpl,
+		wire.Bind(new(repository.NotificationDigestRepository), new(*repository.NotificationDigestRepositoryImpl)),
 
 		notifier.NewNotificationConfigServiceImpl,
 		wire.Bind(new(notifier.NotificationConfigService), new(*notifier.NotificationConfigServiceImpl)),
@@ -876,6 +946,41 @@ func InitializeApp() (*App, error) {
 		cron.GetCiTriggerCronConfig,
 		cron.NewCiTriggerCronImpl,
 		wire.Bind(new(cron.CiTriggerCron), new(*cron.CiTriggerCronImpl)),
+		cron.GetNotificationDigestCronConfig,
+		cron.NewNotificationDigestCronImpl,
+		wire.Bind(new(cron.NotificationDigestCron), new(*cron.NotificationDigestCronImpl)),
+		cron.GetCdTriggerScheduleCronConfig,
+		cron.NewCdTriggerScheduleCronImpl,
+		wire.Bind(new(cron.CdTriggerScheduleCron), new(*cron.CdTriggerScheduleCronImpl)),
+		cron.GetHibernationPolicyCronConfig,
+		cron.NewHibernationPolicyCronImpl,
+		wire.Bind(new(cron.HibernationPolicyCron), new(*cron.HibernationPolicyCronImpl)),
+		cron.GetGitOpsPullRequestCronConfig,
+		cron.NewGitOpsPullRequestCronImpl,
+		wire.Bind(new(cron.GitOpsPullRequestCron), new(*cron.GitOpsPullRequestCronImpl)),
+
+		cron.GetGitOpsDriftCronConfig,
+		cron.NewGitOpsDriftCronImpl,
+		wire.Bind(new(cron.GitOpsDriftCron), new(*cron.GitOpsDriftCronImpl)),
+		cron.GetCanaryAnalysisCronConfig,
+		cron.NewCanaryAnalysisCronImpl,
+		wire.Bind(new(cron.CanaryAnalysisCron), new(*cron.CanaryAnalysisCronImpl)),
+
+		cron.GetImageRetentionCronConfig,
+		cron.NewImageRetentionCronImpl,
+		wire.Bind(new(cron.ImageRetentionCron), new(*cron.ImageRetentionCronImpl)),
+		cron.GetCveExceptionCronConfig,
+		cron.NewCveExceptionCronImpl,
+		wire.Bind(new(cron.CveExceptionCron), new(*cron.CveExceptionCronImpl)),
+		cron.GetTerminalRecordingCronConfig,
+		cron.NewTerminalRecordingCronImpl,
+		wire.Bind(new(cron.TerminalRecordingCron), new(*cron.TerminalRecordingCronImpl)),
+		cron.GetJitAccessCronConfig,
+		cron.NewJitAccessCronImpl,
+		wire.Bind(new(cron.JitAccessCron), new(*cron.JitAccessCronImpl)),
+		cron.GetApiTokenExpiryCronConfig,
+		cron.NewApiTokenExpiryCronImpl,
+		wire.Bind(new(cron.ApiTokenExpiryCron), new(*cron.ApiTokenExpiryCronImpl)),
 
 		status2.NewPipelineStatusTimelineRestHandlerImpl,
 		wire.Bind(new(status2.PipelineStatusTimelineRestHandler), new(*status2.PipelineStatusTimelineRestHandlerImpl)),
@@ -24,6 +24,11 @@ import (
 var ApiTokenWireSet = wire.NewSet(
 	apiToken.NewApiTokenRepositoryImpl,
 	wire.Bind(new(apiToken.ApiTokenRepository), new(*apiToken.ApiTokenRepositoryImpl)),
+	apiToken.NewApiTokenScopeRepositoryImpl,
+	wire.Bind(new(apiToken.ApiTokenScopeRepository), new(*apiToken.ApiTokenScopeRepositoryImpl)),
+	apiToken.GetApiTokenConfig,
+	apiToken.NewApiTokenScopeServiceImpl,
+	wire.Bind(new(apiToken.ApiTokenScopeService), new(*apiToken.ApiTokenScopeServiceImpl)),
 	apiToken.NewApiTokenServiceImpl,
 	wire.Bind(new(apiToken.ApiTokenService), new(*apiToken.ApiTokenServiceImpl)),
 	NewApiTokenRestHandlerImpl,
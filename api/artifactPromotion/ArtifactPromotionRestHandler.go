@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactPromotion
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/artifactPromotion"
+	"github.com/devtron-labs/devtron/pkg/artifactPromotion/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type ArtifactPromotionRestHandler interface {
+	Promote(w http.ResponseWriter, r *http.Request)
+	SavePolicy(w http.ResponseWriter, r *http.Request)
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	GetPromotionHistory(w http.ResponseWriter, r *http.Request)
+}
+
+type ArtifactPromotionRestHandlerImpl struct {
+	logger                   *zap.SugaredLogger
+	artifactPromotionService artifactPromotion.ArtifactPromotionService
+	userService              user.UserService
+	enforcer                 casbin.Enforcer
+	enforcerUtil             rbac.EnforcerUtil
+	validator                *validator.Validate
+}
+
+func NewArtifactPromotionRestHandlerImpl(logger *zap.SugaredLogger, artifactPromotionService artifactPromotion.ArtifactPromotionService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate) *ArtifactPromotionRestHandlerImpl {
+	return &ArtifactPromotionRestHandlerImpl{
+		logger:                   logger,
+		artifactPromotionService: artifactPromotionService,
+		userService:              userService,
+		enforcer:                 enforcer,
+		enforcerUtil:             enforcerUtil,
+		validator:                validator,
+	}
+}
+
+func (handler *ArtifactPromotionRestHandlerImpl) Promote(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	request := &bean.ArtifactPromotionRequest{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, Promote", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, Promote", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionTrigger, request.AppId, request.TargetPipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	request.UserId = userId
+	resp, err := handler.artifactPromotionService.Promote(r.Context(), request)
+	if err != nil {
+		handler.logger.Errorw("service err, Promote", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactPromotionRestHandlerImpl) SavePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	policy := &bean.ArtifactPromotionPolicyDto{}
+	err = json.NewDecoder(r.Body).Decode(policy)
+	if err != nil {
+		handler.logger.Errorw("request err, SavePolicy", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(policy)
+	if err != nil {
+		handler.logger.Errorw("validation err, SavePolicy", "payload", policy, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionUpdate, policy.AppId, policy.PipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	policy.UserId = userId
+	resp, err := handler.artifactPromotionService.SavePolicy(policy)
+	if err != nil {
+		handler.logger.Errorw("service err, SavePolicy", "payload", policy, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactPromotionRestHandlerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParam(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionGet, appId, pipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.artifactPromotionService.GetPolicy(appId, pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactPromotionRestHandlerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParam(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionUpdate, appId, pipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	err = handler.artifactPromotionService.DeletePolicy(appId, pipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeletePolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, pipelineId, http.StatusOK)
+}
+
+func (handler *ArtifactPromotionRestHandlerImpl) GetPromotionHistory(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return
+	}
+	ciArtifactId, err := common.ExtractIntPathParam(w, r, "ciArtifactId")
+	if err != nil {
+		return
+	}
+	object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceApplications, casbin.ActionGet, object); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.artifactPromotionService.GetPromotionHistory(appId, ciArtifactId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPromotionHistory", "ciArtifactId", ciArtifactId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactPromotionRestHandlerImpl) isAuthorised(token string, action string, appId, pipelineId int) bool {
+	object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, object); !ok {
+		return false
+	}
+	object = handler.enforcerUtil.GetAppRBACByAppIdAndPipelineId(appId, pipelineId)
+	return handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, object)
+}
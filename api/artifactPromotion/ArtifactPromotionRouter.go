@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactPromotion
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type ArtifactPromotionRouter interface {
+	InitArtifactPromotionRouter(artifactPromotionRouter *mux.Router)
+}
+
+type ArtifactPromotionRouterImpl struct {
+	artifactPromotionRestHandler ArtifactPromotionRestHandler
+}
+
+func NewArtifactPromotionRouterImpl(artifactPromotionRestHandler ArtifactPromotionRestHandler) *ArtifactPromotionRouterImpl {
+	return &ArtifactPromotionRouterImpl{
+		artifactPromotionRestHandler: artifactPromotionRestHandler,
+	}
+}
+
+func (impl *ArtifactPromotionRouterImpl) InitArtifactPromotionRouter(artifactPromotionRouter *mux.Router) {
+	artifactPromotionRouter.Path("").
+		HandlerFunc(impl.artifactPromotionRestHandler.Promote).Methods("POST")
+	artifactPromotionRouter.Path("/policy").
+		HandlerFunc(impl.artifactPromotionRestHandler.SavePolicy).Methods("POST")
+	artifactPromotionRouter.Path("/policy/{appId}/{pipelineId}").
+		HandlerFunc(impl.artifactPromotionRestHandler.GetPolicy).Methods("GET")
+	artifactPromotionRouter.Path("/policy/{appId}/{pipelineId}").
+		HandlerFunc(impl.artifactPromotionRestHandler.DeletePolicy).Methods("DELETE")
+	artifactPromotionRouter.Path("/history/{appId}/{ciArtifactId}").
+		HandlerFunc(impl.artifactPromotionRestHandler.GetPromotionHistory).Methods("GET")
+}
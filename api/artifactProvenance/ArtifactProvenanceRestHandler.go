@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactProvenance
+
+import (
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type ArtifactProvenanceRestHandler interface {
+	GetAttestations(w http.ResponseWriter, r *http.Request)
+	DownloadAttestation(w http.ResponseWriter, r *http.Request)
+	UploadSbom(w http.ResponseWriter, r *http.Request)
+	SearchComponents(w http.ResponseWriter, r *http.Request)
+}
+
+type ArtifactProvenanceRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	artifactProvenanceService artifactProvenance.ArtifactProvenanceService
+	userService               user.UserService
+	enforcer                  casbin.Enforcer
+	enforcerUtil              rbac.EnforcerUtil
+}
+
+func NewArtifactProvenanceRestHandlerImpl(logger *zap.SugaredLogger, artifactProvenanceService artifactProvenance.ArtifactProvenanceService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil) *ArtifactProvenanceRestHandlerImpl {
+	return &ArtifactProvenanceRestHandlerImpl{
+		logger:                    logger,
+		artifactProvenanceService: artifactProvenanceService,
+		userService:               userService,
+		enforcer:                  enforcer,
+		enforcerUtil:              enforcerUtil,
+	}
+}
+
+func (handler *ArtifactProvenanceRestHandlerImpl) GetAttestations(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return
+	}
+	ciArtifactId, err := common.ExtractIntPathParam(w, r, "ciArtifactId")
+	if err != nil {
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionGet, appId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.artifactProvenanceService.GetAttestations(appId, ciArtifactId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetAttestations", "ciArtifactId", ciArtifactId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactProvenanceRestHandlerImpl) DownloadAttestation(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return
+	}
+	ciArtifactId, err := common.ExtractIntPathParam(w, r, "ciArtifactId")
+	if err != nil {
+		return
+	}
+	attestationType := bean.AttestationType(r.URL.Query().Get("type"))
+	if attestationType != bean.AttestationTypeSbom && attestationType != bean.AttestationTypeProvenance {
+		common.WriteJsonResp(w, fmt.Errorf("invalid attestation type %q", attestationType), nil, http.StatusBadRequest)
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionGet, appId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	attestation, content, err := handler.artifactProvenanceService.GetAttestationContent(appId, ciArtifactId, attestationType)
+	if err != nil {
+		handler.logger.Errorw("service err, DownloadAttestation", "ciArtifactId", ciArtifactId, "type", attestationType, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	fileName := fmt.Sprintf("artifact-%d-%s.%s.json", ciArtifactId, attestation.Type, attestation.Format)
+	w.Header().Set(common.CONTENT_TYPE, common.APPLICATION_JSON)
+	w.Header().Set(common.CONTENT_DISPOSITION, "attachment; filename="+fileName)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(content)
+	if err != nil {
+		handler.logger.Errorw("error in writing attestation", "ciArtifactId", ciArtifactId, "err", err)
+	}
+}
+
+func (handler *ArtifactProvenanceRestHandlerImpl) UploadSbom(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return
+	}
+	ciArtifactId, err := common.ExtractIntPathParam(w, r, "ciArtifactId")
+	if err != nil {
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionUpdate, appId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		handler.logger.Errorw("request err, UploadSbom", "ciArtifactId", ciArtifactId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	resp, err := handler.artifactProvenanceService.UploadSbom(appId, ciArtifactId, content, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, UploadSbom", "ciArtifactId", ciArtifactId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactProvenanceRestHandlerImpl) SearchComponents(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	query := r.URL.Query()
+	request := &bean.ComponentSearchRequest{
+		Name:    query.Get("name"),
+		Version: query.Get("version"),
+	}
+	if deployedOnly := query.Get("deployedOnly"); len(deployedOnly) > 0 {
+		request.DeployedOnly, err = strconv.ParseBool(deployedOnly)
+		if err != nil {
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		request.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
+	// the search is narrowed to the viewable apps up front, filtering the limited results would hide matches
+	request.AppIds = handler.getAuthorisedAppIds(r.Header.Get("token"))
+	results, err := handler.artifactProvenanceService.SearchComponents(request)
+	if err != nil {
+		handler.logger.Errorw("service err, SearchComponents", "request", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, results, http.StatusOK)
+}
+
+// getAuthorisedAppIds returns the apps the user can view
+func (handler *ArtifactProvenanceRestHandlerImpl) getAuthorisedAppIds(token string) []int {
+	rbacObjectsByAppId := handler.enforcerUtil.GetRbacObjectsForAllApps(helper.CustomApp)
+	rbacObjects := make([]string, 0, len(rbacObjectsByAppId))
+	for _, object := range rbacObjectsByAppId {
+		rbacObjects = append(rbacObjects, object)
+	}
+	enforced := handler.enforcer.EnforceInBatch(token, casbin.ResourceApplications, casbin.ActionGet, rbacObjects)
+	appIds := make([]int, 0, len(rbacObjectsByAppId))
+	for appId, object := range rbacObjectsByAppId {
+		if enforced[object] {
+			appIds = append(appIds, appId)
+		}
+	}
+	return appIds
+}
+
+func (handler *ArtifactProvenanceRestHandlerImpl) isAuthorised(token string, action string, appId int) bool {
+	object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	return handler.enforcer.Enforce(token, casbin.ResourceApplications, action, object)
+}
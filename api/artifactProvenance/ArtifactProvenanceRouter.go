@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactProvenance
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type ArtifactProvenanceRouter interface {
+	InitArtifactProvenanceRouter(artifactProvenanceRouter *mux.Router)
+}
+
+type ArtifactProvenanceRouterImpl struct {
+	artifactProvenanceRestHandler ArtifactProvenanceRestHandler
+}
+
+func NewArtifactProvenanceRouterImpl(artifactProvenanceRestHandler ArtifactProvenanceRestHandler) *ArtifactProvenanceRouterImpl {
+	return &ArtifactProvenanceRouterImpl{
+		artifactProvenanceRestHandler: artifactProvenanceRestHandler,
+	}
+}
+
+func (impl *ArtifactProvenanceRouterImpl) InitArtifactProvenanceRouter(artifactProvenanceRouter *mux.Router) {
+	artifactProvenanceRouter.Path("/components").
+		HandlerFunc(impl.artifactProvenanceRestHandler.SearchComponents).Methods("GET")
+	artifactProvenanceRouter.Path("/{appId}/{ciArtifactId}").
+		HandlerFunc(impl.artifactProvenanceRestHandler.GetAttestations).Methods("GET")
+	artifactProvenanceRouter.Path("/{appId}/{ciArtifactId}/download").
+		HandlerFunc(impl.artifactProvenanceRestHandler.DownloadAttestation).Methods("GET")
+	artifactProvenanceRouter.Path("/{appId}/{ciArtifactId}/sbom").
+		HandlerFunc(impl.artifactProvenanceRestHandler.UploadSbom).Methods("POST")
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jitAccess
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type JitAccessRestHandler interface {
+	CreateRequest(w http.ResponseWriter, r *http.Request)
+	GetRequests(w http.ResponseWriter, r *http.Request)
+	GetRequest(w http.ResponseWriter, r *http.Request)
+	ApproveRequest(w http.ResponseWriter, r *http.Request)
+	RejectRequest(w http.ResponseWriter, r *http.Request)
+	RevokeRequest(w http.ResponseWriter, r *http.Request)
+}
+
+type JitAccessRestHandlerImpl struct {
+	logger           *zap.SugaredLogger
+	jitAccessService jitAccess.JitAccessService
+	userService      user.UserService
+	validator        *validator.Validate
+}
+
+func NewJitAccessRestHandlerImpl(logger *zap.SugaredLogger, jitAccessService jitAccess.JitAccessService,
+	userService user.UserService, validator *validator.Validate) *JitAccessRestHandlerImpl {
+	return &JitAccessRestHandlerImpl{
+		logger:           logger,
+		jitAccessService: jitAccessService,
+		userService:      userService,
+		validator:        validator,
+	}
+}
+
+func (handler *JitAccessRestHandlerImpl) CreateRequest(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	request := &bean.JitAccessRequestDto{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, CreateRequest", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, CreateRequest", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// access is always requested for the logged-in user
+	request.UserId = userId
+	resp, err := handler.jitAccessService.CreateRequest(request)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateRequest", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *JitAccessRestHandlerImpl) GetRequests(w http.ResponseWriter, r *http.Request) {
+	userId, isApprover, ok := handler.getLoggedInUser(w, r)
+	if !ok {
+		return
+	}
+	requestedBy, err := common.ExtractIntQueryParam(w, r, "requestedBy", 0)
+	if err != nil {
+		return
+	}
+	filter := &bean.AccessRequestFilter{
+		RequestedBy: int32(requestedBy),
+		Status:      bean.AccessStatus(r.URL.Query().Get("status")),
+	}
+	// approvers see every request, others only their own
+	if !isApprover {
+		filter.RequestedBy = userId
+	}
+	requests, err := handler.jitAccessService.GetRequests(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRequests", "filter", filter, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, requests, http.StatusOK)
+}
+
+func (handler *JitAccessRestHandlerImpl) GetRequest(w http.ResponseWriter, r *http.Request) {
+	userId, isApprover, ok := handler.getLoggedInUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	resp, err := handler.jitAccessService.GetRequest(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRequest", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if !isApprover && resp.RequestedBy != userId {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *JitAccessRestHandlerImpl) ApproveRequest(w http.ResponseWriter, r *http.Request) {
+	review, ok := handler.decodeReviewRequest(w, r)
+	if !ok {
+		return
+	}
+	// the service checks that the reviewer is an approver
+	resp, err := handler.jitAccessService.ApproveRequest(review)
+	if err != nil {
+		handler.logger.Errorw("service err, ApproveRequest", "payload", review, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *JitAccessRestHandlerImpl) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	review, ok := handler.decodeReviewRequest(w, r)
+	if !ok {
+		return
+	}
+	resp, err := handler.jitAccessService.RejectRequest(review)
+	if err != nil {
+		handler.logger.Errorw("service err, RejectRequest", "payload", review, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *JitAccessRestHandlerImpl) RevokeRequest(w http.ResponseWriter, r *http.Request) {
+	review, ok := handler.decodeReviewRequest(w, r)
+	if !ok {
+		return
+	}
+	request, err := handler.jitAccessService.GetRequest(review.Id)
+	if err != nil {
+		handler.logger.Errorw("service err, RevokeRequest", "id", review.Id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	// a request can be revoked by the approvers or by the user who requested it
+	if request.RequestedBy != review.UserId {
+		isApprover, err := handler.jitAccessService.IsApprover(review.UserId)
+		if err != nil {
+			handler.logger.Errorw("service err, RevokeRequest", "userId", review.UserId, "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+			return
+		}
+		if !isApprover {
+			common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+			return
+		}
+	}
+	resp, err := handler.jitAccessService.RevokeRequest(review)
+	if err != nil {
+		handler.logger.Errorw("service err, RevokeRequest", "payload", review, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *JitAccessRestHandlerImpl) getLoggedInUser(w http.ResponseWriter, r *http.Request) (int32, bool, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return 0, false, false
+	}
+	isApprover, err := handler.jitAccessService.IsApprover(userId)
+	if err != nil {
+		handler.logger.Errorw("service err, IsApprover", "userId", userId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return 0, false, false
+	}
+	return userId, isApprover, true
+}
+
+func (handler *JitAccessRestHandlerImpl) decodeReviewRequest(w http.ResponseWriter, r *http.Request) (*bean.AccessReviewRequest, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return nil, false
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return nil, false
+	}
+	review := &bean.AccessReviewRequest{}
+	err = json.NewDecoder(r.Body).Decode(review)
+	if err != nil {
+		handler.logger.Errorw("request err, decodeReviewRequest", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	err = handler.validator.Struct(review)
+	if err != nil {
+		handler.logger.Errorw("validation err, decodeReviewRequest", "payload", review, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	review.Id = id
+	review.UserId = userId
+	return review, true
+}
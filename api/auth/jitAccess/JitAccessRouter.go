@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jitAccess
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type JitAccessRouter interface {
+	InitJitAccessRouter(jitAccessRouter *mux.Router)
+}
+
+type JitAccessRouterImpl struct {
+	jitAccessRestHandler JitAccessRestHandler
+}
+
+func NewJitAccessRouterImpl(jitAccessRestHandler JitAccessRestHandler) *JitAccessRouterImpl {
+	return &JitAccessRouterImpl{
+		jitAccessRestHandler: jitAccessRestHandler,
+	}
+}
+
+func (impl *JitAccessRouterImpl) InitJitAccessRouter(jitAccessRouter *mux.Router) {
+	jitAccessRouter.Path("").
+		HandlerFunc(impl.jitAccessRestHandler.CreateRequest).Methods("POST")
+	jitAccessRouter.Path("").
+		HandlerFunc(impl.jitAccessRestHandler.GetRequests).Methods("GET")
+	jitAccessRouter.Path("/{id}").
+		HandlerFunc(impl.jitAccessRestHandler.GetRequest).Methods("GET")
+	jitAccessRouter.Path("/{id}/approve").
+		HandlerFunc(impl.jitAccessRestHandler.ApproveRequest).Methods("PUT")
+	jitAccessRouter.Path("/{id}/reject").
+		HandlerFunc(impl.jitAccessRestHandler.RejectRequest).Methods("PUT")
+	jitAccessRouter.Path("/{id}/revoke").
+		HandlerFunc(impl.jitAccessRestHandler.RevokeRequest).Methods("PUT")
+}
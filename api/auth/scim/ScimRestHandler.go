@@ -0,0 +1,382 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/scim"
+	"github.com/devtron-labs/devtron/pkg/auth/scim/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const bearerPrefix = "bearer "
+
+type ScimRestHandler interface {
+	ListUsers(w http.ResponseWriter, r *http.Request)
+	GetUser(w http.ResponseWriter, r *http.Request)
+	CreateUser(w http.ResponseWriter, r *http.Request)
+	ReplaceUser(w http.ResponseWriter, r *http.Request)
+	PatchUser(w http.ResponseWriter, r *http.Request)
+	DeleteUser(w http.ResponseWriter, r *http.Request)
+
+	ListGroups(w http.ResponseWriter, r *http.Request)
+	GetGroup(w http.ResponseWriter, r *http.Request)
+	CreateGroup(w http.ResponseWriter, r *http.Request)
+	ReplaceGroup(w http.ResponseWriter, r *http.Request)
+	PatchGroup(w http.ResponseWriter, r *http.Request)
+	DeleteGroup(w http.ResponseWriter, r *http.Request)
+}
+
+type ScimRestHandlerImpl struct {
+	logger      *zap.SugaredLogger
+	scimService scim.ScimService
+	userService user.UserService
+	enforcer    casbin.Enforcer
+}
+
+func NewScimRestHandlerImpl(logger *zap.SugaredLogger, scimService scim.ScimService,
+	userService user.UserService, enforcer casbin.Enforcer) *ScimRestHandlerImpl {
+	return &ScimRestHandlerImpl{
+		logger:      logger,
+		scimService: scimService,
+		userService: userService,
+		enforcer:    enforcer,
+	}
+}
+
+func (handler *ScimRestHandlerImpl) ListUsers(w http.ResponseWriter, r *http.Request) {
+	_, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	request, ok := handler.getListRequest(w, r)
+	if !ok {
+		return
+	}
+	resp, err := handler.scimService.ListUsers(request)
+	if err != nil {
+		handler.logger.Errorw("service err, ListUsers", "request", request, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusOK)
+}
+
+func (handler *ScimRestHandlerImpl) GetUser(w http.ResponseWriter, r *http.Request) {
+	_, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	resp, err := handler.scimService.GetUser(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetUser", "id", id, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusOK)
+}
+
+func (handler *ScimRestHandlerImpl) CreateUser(w http.ResponseWriter, r *http.Request) {
+	userId, token, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	request := &bean.User{}
+	if !handler.decodeRequest(w, r, request) {
+		return
+	}
+	resp, err := handler.scimService.CreateUser(request, userId, token, handler.checkManagerAuth)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateUser", "userName", request.UserName, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusCreated)
+}
+
+func (handler *ScimRestHandlerImpl) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	userId, token, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	request := &bean.User{}
+	if !handler.decodeRequest(w, r, request) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	resp, err := handler.scimService.ReplaceUser(id, request, userId, token, handler.checkManagerAuth)
+	if err != nil {
+		handler.logger.Errorw("service err, ReplaceUser", "id", id, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusOK)
+}
+
+func (handler *ScimRestHandlerImpl) PatchUser(w http.ResponseWriter, r *http.Request) {
+	userId, token, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	request := &bean.PatchRequest{}
+	if !handler.decodeRequest(w, r, request) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	resp, err := handler.scimService.PatchUser(id, request, userId, token, handler.checkManagerAuth)
+	if err != nil {
+		handler.logger.Errorw("service err, PatchUser", "id", id, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusOK)
+}
+
+func (handler *ScimRestHandlerImpl) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userId, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	err := handler.scimService.DeleteUser(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteUser", "id", id, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *ScimRestHandlerImpl) ListGroups(w http.ResponseWriter, r *http.Request) {
+	_, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	request, ok := handler.getListRequest(w, r)
+	if !ok {
+		return
+	}
+	resp, err := handler.scimService.ListGroups(request)
+	if err != nil {
+		handler.logger.Errorw("service err, ListGroups", "request", request, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusOK)
+}
+
+func (handler *ScimRestHandlerImpl) GetGroup(w http.ResponseWriter, r *http.Request) {
+	_, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	resp, err := handler.scimService.GetGroup(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetGroup", "id", id, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusOK)
+}
+
+func (handler *ScimRestHandlerImpl) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	userId, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	request := &bean.Group{}
+	if !handler.decodeRequest(w, r, request) {
+		return
+	}
+	resp, err := handler.scimService.CreateGroup(request, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateGroup", "displayName", request.DisplayName, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusCreated)
+}
+
+func (handler *ScimRestHandlerImpl) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	userId, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	request := &bean.Group{}
+	if !handler.decodeRequest(w, r, request) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	resp, err := handler.scimService.ReplaceGroup(id, request, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, ReplaceGroup", "id", id, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusOK)
+}
+
+func (handler *ScimRestHandlerImpl) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	userId, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	request := &bean.PatchRequest{}
+	if !handler.decodeRequest(w, r, request) {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	resp, err := handler.scimService.PatchGroup(id, request, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, PatchGroup", "id", id, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	handler.writeResponse(w, resp, http.StatusOK)
+}
+
+func (handler *ScimRestHandlerImpl) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	userId, _, ok := handler.authenticate(w, r)
+	if !ok {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	err := handler.scimService.DeleteGroup(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteGroup", "id", id, "err", err)
+		handler.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticate verifies the bearer token sent by the identity provider, scim routes are skipped by the
+// auth middleware as it only reads the token header. Only super admins can provision users and groups.
+func (handler *ScimRestHandlerImpl) authenticate(w http.ResponseWriter, r *http.Request) (userId int32, token string, ok bool) {
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) <= len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		handler.writeError(w, util.NewApiError().WithHttpStatusCode(http.StatusUnauthorized).WithUserMessage(bean.UnauthorizedMsg))
+		return 0, "", false
+	}
+	token = strings.TrimSpace(authorization[len(bearerPrefix):])
+	userId, _, err := handler.userService.GetUserByToken(r.Context(), token)
+	if err != nil || userId == 0 {
+		handler.logger.Errorw("invalid scim bearer token", "err", err)
+		handler.writeError(w, util.NewApiError().WithHttpStatusCode(http.StatusUnauthorized).WithUserMessage(bean.UnauthorizedMsg))
+		return 0, "", false
+	}
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		handler.writeError(w, util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(bean.ForbiddenMsg))
+		return 0, "", false
+	}
+	return userId, token, true
+}
+
+func (handler *ScimRestHandlerImpl) checkManagerAuth(resource, token string, object string) bool {
+	return handler.enforcer.Enforce(token, resource, casbin.ActionUpdate, object)
+}
+
+func (handler *ScimRestHandlerImpl) getListRequest(w http.ResponseWriter, r *http.Request) (*bean.ListRequest, bool) {
+	query := r.URL.Query()
+	request := &bean.ListRequest{
+		Filter: query.Get("filter"),
+	}
+	var err error
+	if request.StartIndex, err = getIntQueryParam(r, "startIndex", bean.DefaultStartIndex); err != nil {
+		handler.writeError(w, err)
+		return nil, false
+	}
+	if request.Count, err = getIntQueryParam(r, "count", bean.DefaultPageSize); err != nil {
+		handler.writeError(w, err)
+		return nil, false
+	}
+	for _, attribute := range strings.Split(query.Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attribute), bean.AttributeMembers) {
+			request.ExcludeMembers = true
+		}
+	}
+	return request, true
+}
+
+func getIntQueryParam(r *http.Request, param string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(param)
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+	parsedValue, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithCode(bean.ScimTypeInvalidValue).
+			WithUserMessage(fmt.Sprintf("invalid %s", param))
+	}
+	return parsedValue, nil
+}
+
+func (handler *ScimRestHandlerImpl) decodeRequest(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, scim", "path", r.URL.Path, "err", err)
+		handler.writeError(w, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithCode(bean.ScimTypeInvalidSyntax).WithUserMessage(err.Error()))
+		return false
+	}
+	return true
+}
+
+func (handler *ScimRestHandlerImpl) writeResponse(w http.ResponseWriter, resp interface{}, statusCode int) {
+	w.Header().Set("Content-Type", bean.ContentType)
+	w.WriteHeader(statusCode)
+	err := json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		handler.logger.Errorw("error in writing scim response", "err", err)
+	}
+}
+
+// writeError writes the error in the scim error format, the scim type is carried in the api error code while
+// errors of the user and role group services carry devtron error codes
+func (handler *ScimRestHandlerImpl) writeError(w http.ResponseWriter, err error) {
+	resp := &bean.ErrorResponse{
+		Schemas: []string{bean.ErrorSchema},
+		Status:  strconv.Itoa(http.StatusInternalServerError),
+		Detail:  err.Error(),
+	}
+	apiErr := &util.ApiError{}
+	if errors.As(err, &apiErr) {
+		statusCode := apiErr.HttpStatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusInternalServerError
+		}
+		resp.Status = strconv.Itoa(statusCode)
+		if bean.IsScimType(apiErr.Code) {
+			resp.ScimType = apiErr.Code
+		}
+		if userMessage, ok := apiErr.UserMessage.(string); ok && len(userMessage) > 0 {
+			resp.Detail = userMessage
+		}
+	}
+	statusCode, _ := strconv.Atoi(resp.Status)
+	handler.writeResponse(w, resp, statusCode)
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	user2 "github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type SsoGroupMappingRestHandler interface {
+	GetAllRules(w http.ResponseWriter, r *http.Request)
+	CreateRule(w http.ResponseWriter, r *http.Request)
+	UpdateRule(w http.ResponseWriter, r *http.Request)
+	DeleteRule(w http.ResponseWriter, r *http.Request)
+	PreviewRules(w http.ResponseWriter, r *http.Request)
+}
+
+type SsoGroupMappingRestHandlerImpl struct {
+	logger                 *zap.SugaredLogger
+	ssoGroupMappingService user2.SsoGroupMappingService
+	userService            user2.UserService
+	enforcer               casbin.Enforcer
+	validator              *validator.Validate
+}
+
+func NewSsoGroupMappingRestHandlerImpl(logger *zap.SugaredLogger, ssoGroupMappingService user2.SsoGroupMappingService,
+	userService user2.UserService, enforcer casbin.Enforcer, validator *validator.Validate) *SsoGroupMappingRestHandlerImpl {
+	return &SsoGroupMappingRestHandlerImpl{
+		logger:                 logger,
+		ssoGroupMappingService: ssoGroupMappingService,
+		userService:            userService,
+		enforcer:               enforcer,
+		validator:              validator,
+	}
+}
+
+func (handler *SsoGroupMappingRestHandlerImpl) GetAllRules(w http.ResponseWriter, r *http.Request) {
+	_, ok := handler.checkSuperAdmin(w, r)
+	if !ok {
+		return
+	}
+	rules, err := handler.ssoGroupMappingService.GetAllRules()
+	if err != nil {
+		handler.logger.Errorw("service err, GetAllRules", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, rules, http.StatusOK)
+}
+
+func (handler *SsoGroupMappingRestHandlerImpl) CreateRule(w http.ResponseWriter, r *http.Request) {
+	rule, ok := handler.decodeRule(w, r)
+	if !ok {
+		return
+	}
+	resp, err := handler.ssoGroupMappingService.CreateRule(rule)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateRule", "payload", rule, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *SsoGroupMappingRestHandlerImpl) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	rule, ok := handler.decodeRule(w, r)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	rule.Id = id
+	resp, err := handler.ssoGroupMappingService.UpdateRule(rule)
+	if err != nil {
+		handler.logger.Errorw("service err, UpdateRule", "payload", rule, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *SsoGroupMappingRestHandlerImpl) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	userId, ok := handler.checkSuperAdmin(w, r)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = handler.ssoGroupMappingService.DeleteRule(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteRule", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, true, http.StatusOK)
+}
+
+// PreviewRules is a dry run of the rules in the request, or of the saved rules when the request has none
+func (handler *SsoGroupMappingRestHandlerImpl) PreviewRules(w http.ResponseWriter, r *http.Request) {
+	_, ok := handler.checkSuperAdmin(w, r)
+	if !ok {
+		return
+	}
+	request := &bean.SsoGroupMappingPreviewRequest{}
+	if r.ContentLength != 0 {
+		err := json.NewDecoder(r.Body).Decode(request)
+		if err != nil {
+			handler.logger.Errorw("request err, PreviewRules", "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+		err = handler.validator.Struct(request)
+		if err != nil {
+			handler.logger.Errorw("validation err, PreviewRules", "payload", request, "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
+	resp, err := handler.ssoGroupMappingService.PreviewRules(request)
+	if err != nil {
+		handler.logger.Errorw("service err, PreviewRules", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *SsoGroupMappingRestHandlerImpl) decodeRule(w http.ResponseWriter, r *http.Request) (*bean.SsoGroupMappingRuleDto, bool) {
+	userId, ok := handler.checkSuperAdmin(w, r)
+	if !ok {
+		return nil, false
+	}
+	rule := &bean.SsoGroupMappingRuleDto{}
+	err := json.NewDecoder(r.Body).Decode(rule)
+	if err != nil {
+		handler.logger.Errorw("request err, sso group mapping rule", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	err = handler.validator.Struct(rule)
+	if err != nil {
+		handler.logger.Errorw("validation err, sso group mapping rule", "payload", rule, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	rule.UserId = userId
+	return rule, true
+}
+
+// checkSuperAdmin allows only super admins to manage the mapping rules, as they grant role groups on login
+func (handler *SsoGroupMappingRestHandlerImpl) checkSuperAdmin(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return 0, false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return 0, false
+	}
+	return userId, true
+}
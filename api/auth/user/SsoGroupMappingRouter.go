@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type SsoGroupMappingRouter interface {
+	InitSsoGroupMappingRouter(ssoGroupMappingRouter *mux.Router)
+}
+
+type SsoGroupMappingRouterImpl struct {
+	ssoGroupMappingRestHandler SsoGroupMappingRestHandler
+}
+
+func NewSsoGroupMappingRouterImpl(ssoGroupMappingRestHandler SsoGroupMappingRestHandler) *SsoGroupMappingRouterImpl {
+	return &SsoGroupMappingRouterImpl{
+		ssoGroupMappingRestHandler: ssoGroupMappingRestHandler,
+	}
+}
+
+func (router SsoGroupMappingRouterImpl) InitSsoGroupMappingRouter(ssoGroupMappingRouter *mux.Router) {
+	ssoGroupMappingRouter.Path("").
+		HandlerFunc(router.ssoGroupMappingRestHandler.GetAllRules).Methods("GET")
+	ssoGroupMappingRouter.Path("").
+		HandlerFunc(router.ssoGroupMappingRestHandler.CreateRule).Methods("POST")
+	ssoGroupMappingRouter.Path("/preview").
+		HandlerFunc(router.ssoGroupMappingRestHandler.PreviewRules).Methods("POST")
+	ssoGroupMappingRouter.Path("/{id}").
+		HandlerFunc(router.ssoGroupMappingRestHandler.UpdateRule).Methods("PUT")
+	ssoGroupMappingRouter.Path("/{id}").
+		HandlerFunc(router.ssoGroupMappingRestHandler.DeleteRule).Methods("DELETE")
+}
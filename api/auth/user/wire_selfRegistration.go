@@ -29,6 +29,11 @@ var SelfRegistrationWireSet = wire.NewSet(
 	repository.NewSelfRegistrationRolesRepositoryImpl,
 	wire.Bind(new(repository.SelfRegistrationRolesRepository), new(*repository.SelfRegistrationRolesRepositoryImpl)),
 
+	repository.NewSsoGroupMappingRepositoryImpl,
+	wire.Bind(new(repository.SsoGroupMappingRepository), new(*repository.SsoGroupMappingRepositoryImpl)),
+	user.NewSsoGroupMappingServiceImpl,
+	wire.Bind(new(user.SsoGroupMappingService), new(*user.SsoGroupMappingServiceImpl)),
+
 	user.NewUserSelfRegistrationServiceImpl,
 	wire.Bind(new(user.UserSelfRegistrationService), new(*user.UserSelfRegistrationServiceImpl)),
 )
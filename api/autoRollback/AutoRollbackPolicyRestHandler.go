@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoRollback
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/rollback"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type AutoRollbackPolicyRestHandler interface {
+	SavePolicy(w http.ResponseWriter, r *http.Request)
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+}
+
+type AutoRollbackPolicyRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	deploymentRollbackService rollback.DeploymentRollbackService
+	userService               user.UserService
+	enforcer                  casbin.Enforcer
+	enforcerUtil              rbac.EnforcerUtil
+	validator                 *validator.Validate
+}
+
+func NewAutoRollbackPolicyRestHandlerImpl(logger *zap.SugaredLogger, deploymentRollbackService rollback.DeploymentRollbackService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate) *AutoRollbackPolicyRestHandlerImpl {
+	return &AutoRollbackPolicyRestHandlerImpl{
+		logger:                    logger,
+		deploymentRollbackService: deploymentRollbackService,
+		userService:               userService,
+		enforcer:                  enforcer,
+		enforcerUtil:              enforcerUtil,
+		validator:                 validator,
+	}
+}
+
+func (handler *AutoRollbackPolicyRestHandlerImpl) SavePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	policy := &rollback.AutoRollbackPolicyDto{}
+	err = json.NewDecoder(r.Body).Decode(policy)
+	if err != nil {
+		handler.logger.Errorw("request err, SavePolicy", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(policy)
+	if err != nil {
+		handler.logger.Errorw("validation err, SavePolicy", "payload", policy, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionUpdate, policy.AppId, policy.PipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	policy.UserId = userId
+	resp, err := handler.deploymentRollbackService.SaveAutoRollbackPolicy(policy)
+	if err != nil {
+		handler.logger.Errorw("service err, SavePolicy", "payload", policy, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *AutoRollbackPolicyRestHandlerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParam(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionGet, appId, pipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.deploymentRollbackService.GetAutoRollbackPolicy(appId, pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *AutoRollbackPolicyRestHandlerImpl) isAuthorised(token string, action string, appId, pipelineId int) bool {
+	object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, object); !ok {
+		return false
+	}
+	object = handler.enforcerUtil.GetAppRBACByAppIdAndPipelineId(appId, pipelineId)
+	return handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, object)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoRollback
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type AutoRollbackPolicyRouter interface {
+	InitAutoRollbackPolicyRouter(autoRollbackPolicyRouter *mux.Router)
+}
+
+type AutoRollbackPolicyRouterImpl struct {
+	autoRollbackPolicyRestHandler AutoRollbackPolicyRestHandler
+}
+
+func NewAutoRollbackPolicyRouterImpl(autoRollbackPolicyRestHandler AutoRollbackPolicyRestHandler) *AutoRollbackPolicyRouterImpl {
+	return &AutoRollbackPolicyRouterImpl{
+		autoRollbackPolicyRestHandler: autoRollbackPolicyRestHandler,
+	}
+}
+
+func (impl *AutoRollbackPolicyRouterImpl) InitAutoRollbackPolicyRouter(autoRollbackPolicyRouter *mux.Router) {
+	autoRollbackPolicyRouter.Path("").
+		HandlerFunc(impl.autoRollbackPolicyRestHandler.SavePolicy).Methods("POST")
+	autoRollbackPolicyRouter.Path("/{appId}/{pipelineId}").
+		HandlerFunc(impl.autoRollbackPolicyRestHandler.GetPolicy).Methods("GET")
+}
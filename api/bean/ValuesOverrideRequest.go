@@ -82,6 +82,12 @@ type ValuesOverrideRequest struct {
 	Namespace                             string                      `json:"-"`
 	ReleaseName                           string                      `json:"-"`
 	Image                                 string                      `json:"-"`
+	// DeploymentWindowOverrideReason is required to deploy while a deployment window blocks deployments
+	DeploymentWindowOverrideReason string `json:"deploymentWindowOverrideReason,omitempty"`
+	// IsDeploymentWindowOverrideAllowed is set by the trigger handler only for super admins
+	IsDeploymentWindowOverrideAllowed bool `json:"-"`
+	// TriggerType is recorded on the deploy runner, set only for system triggered rollbacks, schedules and promotions
+	TriggerType string `json:"-"`
 }
 
 type BulkCdDeployEvent struct {
@@ -23,19 +23,21 @@ import (
 
 type GitOpsConfigDto struct {
 	Id                    int             `json:"id,omitempty"`
-	Provider              string          `json:"provider" validate:"oneof=GITLAB GITHUB AZURE_DEVOPS BITBUCKET_CLOUD"`
+	Provider              string          `json:"provider" validate:"oneof=GITLAB GITHUB AZURE_DEVOPS BITBUCKET_CLOUD GITEA GOGS BITBUCKET_SERVER"`
 	Username              string          `json:"username"`
 	Token                 string          `json:"token"`
 	GitLabGroupId         string          `json:"gitLabGroupId"`
-	GitHubOrgId           string          `json:"gitHubOrgId"`
+	GitHubOrgId           string          `json:"gitHubOrgId"` // also the owner organization for GITEA and GOGS
 	Host                  string          `json:"host"`
 	Active                bool            `json:"active"`
 	AzureProjectName      string          `json:"azureProjectName"`
 	BitBucketWorkspaceId  string          `json:"bitBucketWorkspaceId"`
-	BitBucketProjectKey   string          `json:"bitBucketProjectKey"`
+	BitBucketProjectKey   string          `json:"bitBucketProjectKey"` // also the project key for BITBUCKET_SERVER
 	AllowCustomRepository bool            `json:"allowCustomRepository"`
 	EnableTLSVerification bool            `json:"enableTLSVerification"`
 	TLSConfig             *bean.TLSConfig `json:"tlsConfig"`
+	CommitStrategy        string          `json:"commitStrategy,omitempty" validate:"omitempty,oneof=DIRECT PULL_REQUEST"`
+	AutoMergePullRequest  bool            `json:"autoMergePullRequest"`
 
 	IsCADataPresent      bool `json:"isCADataPresent"`
 	IsTLSCertDataPresent bool `json:"isTLSCertDataPresent"`
@@ -75,6 +77,17 @@ const (
 	GIT_REPO_NOT_CONFIGURED = "NOT_CONFIGURED" // The value of the constant has been used in the migration script for `custom_gitops_repo_url`; Need to add another migration script if the value is updated.
 )
 
+const (
+	// COMMIT_STRATEGY_DIRECT pushes the deployment values straight to the default branch of the GitOps repo
+	COMMIT_STRATEGY_DIRECT = "DIRECT"
+	// COMMIT_STRATEGY_PULL_REQUEST commits the deployment values on a new branch and raises a pull request against the default branch
+	COMMIT_STRATEGY_PULL_REQUEST = "PULL_REQUEST"
+)
+
+func (dto *GitOpsConfigDto) IsPullRequestCommitStrategy() bool {
+	return dto.CommitStrategy == COMMIT_STRATEGY_PULL_REQUEST
+}
+
 func IsGitOpsRepoNotConfigured(gitRepoUrl string) bool {
 	return len(gitRepoUrl) == 0 || gitRepoUrl == GIT_REPO_NOT_CONFIGURED
 }
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canaryAnalysis
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/canary"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type CanaryAnalysisRestHandler interface {
+	SaveConfig(w http.ResponseWriter, r *http.Request)
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	DeleteConfig(w http.ResponseWriter, r *http.Request)
+}
+
+type CanaryAnalysisRestHandlerImpl struct {
+	logger                *zap.SugaredLogger
+	canaryAnalysisService canary.CanaryAnalysisService
+	userService           user.UserService
+	enforcer              casbin.Enforcer
+	enforcerUtil          rbac.EnforcerUtil
+	validator             *validator.Validate
+}
+
+func NewCanaryAnalysisRestHandlerImpl(logger *zap.SugaredLogger, canaryAnalysisService canary.CanaryAnalysisService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate) *CanaryAnalysisRestHandlerImpl {
+	return &CanaryAnalysisRestHandlerImpl{
+		logger:                logger,
+		canaryAnalysisService: canaryAnalysisService,
+		userService:           userService,
+		enforcer:              enforcer,
+		enforcerUtil:          enforcerUtil,
+		validator:             validator,
+	}
+}
+
+func (handler *CanaryAnalysisRestHandlerImpl) SaveConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	config := &canary.CanaryAnalysisConfigDto{}
+	err = json.NewDecoder(r.Body).Decode(config)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveConfig", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(config)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveConfig", "payload", config, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionUpdate, config.AppId, config.PipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	config.UserId = userId
+	resp, err := handler.canaryAnalysisService.SaveConfig(config)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveConfig", "payload", config, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CanaryAnalysisRestHandlerImpl) GetConfig(w http.ResponseWriter, r *http.Request) {
+	appId, pipelineId, ok := handler.extractAppAndPipelineId(w, r)
+	if !ok {
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionGet, appId, pipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.canaryAnalysisService.GetConfig(appId, pipelineId)
+	if util.IsErrNoRows(err) {
+		common.WriteJsonResp(w, nil, nil, http.StatusOK)
+		return
+	} else if err != nil {
+		handler.logger.Errorw("service err, GetConfig", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CanaryAnalysisRestHandlerImpl) DeleteConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	appId, pipelineId, ok := handler.extractAppAndPipelineId(w, r)
+	if !ok {
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), casbin.ActionUpdate, appId, pipelineId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	err = handler.canaryAnalysisService.DeleteConfig(pipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteConfig", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, pipelineId, http.StatusOK)
+}
+
+func (handler *CanaryAnalysisRestHandlerImpl) extractAppAndPipelineId(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return 0, 0, false
+	}
+	pipelineId, err := common.ExtractIntPathParam(w, r, "pipelineId")
+	if err != nil {
+		return 0, 0, false
+	}
+	return appId, pipelineId, true
+}
+
+func (handler *CanaryAnalysisRestHandlerImpl) isAuthorised(token string, action string, appId, pipelineId int) bool {
+	object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, object); !ok {
+		return false
+	}
+	object = handler.enforcerUtil.GetAppRBACByAppIdAndPipelineId(appId, pipelineId)
+	return handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, object)
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canaryAnalysis
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type CanaryAnalysisRouter interface {
+	InitCanaryAnalysisRouter(canaryAnalysisRouter *mux.Router)
+}
+
+type CanaryAnalysisRouterImpl struct {
+	canaryAnalysisRestHandler CanaryAnalysisRestHandler
+}
+
+func NewCanaryAnalysisRouterImpl(canaryAnalysisRestHandler CanaryAnalysisRestHandler) *CanaryAnalysisRouterImpl {
+	return &CanaryAnalysisRouterImpl{
+		canaryAnalysisRestHandler: canaryAnalysisRestHandler,
+	}
+}
+
+func (impl *CanaryAnalysisRouterImpl) InitCanaryAnalysisRouter(canaryAnalysisRouter *mux.Router) {
+	canaryAnalysisRouter.Path("").
+		HandlerFunc(impl.canaryAnalysisRestHandler.SaveConfig).Methods("POST")
+	canaryAnalysisRouter.Path("/{appId}/{pipelineId}").
+		HandlerFunc(impl.canaryAnalysisRestHandler.GetConfig).Methods("GET")
+	canaryAnalysisRouter.Path("/{appId}/{pipelineId}").
+		HandlerFunc(impl.canaryAnalysisRestHandler.DeleteConfig).Methods("DELETE")
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cdSchedule
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/schedule"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type CdTriggerScheduleRestHandler interface {
+	SaveSchedule(w http.ResponseWriter, r *http.Request)
+	GetSchedules(w http.ResponseWriter, r *http.Request)
+	DeleteSchedule(w http.ResponseWriter, r *http.Request)
+}
+
+type CdTriggerScheduleRestHandlerImpl struct {
+	logger                   *zap.SugaredLogger
+	cdTriggerScheduleService schedule.CdTriggerScheduleService
+	userService              user.UserService
+	enforcer                 casbin.Enforcer
+	enforcerUtil             rbac.EnforcerUtil
+	validator                *validator.Validate
+}
+
+func NewCdTriggerScheduleRestHandlerImpl(logger *zap.SugaredLogger, cdTriggerScheduleService schedule.CdTriggerScheduleService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate) *CdTriggerScheduleRestHandlerImpl {
+	return &CdTriggerScheduleRestHandlerImpl{
+		logger:                   logger,
+		cdTriggerScheduleService: cdTriggerScheduleService,
+		userService:              userService,
+		enforcer:                 enforcer,
+		enforcerUtil:             enforcerUtil,
+		validator:                validator,
+	}
+}
+
+func (handler *CdTriggerScheduleRestHandlerImpl) SaveSchedule(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	request := &schedule.CdTriggerScheduleDto{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveSchedule", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveSchedule", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// a schedule triggers deployments on behalf of the user who last saved it, so that user must be able to trigger as well
+	if !handler.isAuthorised(r.Header.Get("token"), request.AppId, request.PipelineId, casbin.ActionUpdate, casbin.ActionTrigger) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	request.UserId = userId
+	resp, err := handler.cdTriggerScheduleService.SaveSchedule(request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveSchedule", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CdTriggerScheduleRestHandlerImpl) GetSchedules(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntPathParam(w, r, "appId")
+	if err != nil {
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParam(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), appId, pipelineId, casbin.ActionGet) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.cdTriggerScheduleService.GetSchedules(appId, pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetSchedules", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CdTriggerScheduleRestHandlerImpl) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	existing, err := handler.cdTriggerScheduleService.GetSchedule(id)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteSchedule", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if !handler.isAuthorised(r.Header.Get("token"), existing.AppId, existing.PipelineId, casbin.ActionUpdate, casbin.ActionTrigger) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	err = handler.cdTriggerScheduleService.DeleteSchedule(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteSchedule", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, id, http.StatusOK)
+}
+
+func (handler *CdTriggerScheduleRestHandlerImpl) isAuthorised(token string, appId, pipelineId int, actions ...string) bool {
+	appObject := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	envObject := handler.enforcerUtil.GetAppRBACByAppIdAndPipelineId(appId, pipelineId)
+	for _, action := range actions {
+		if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, appObject); !ok {
+			return false
+		}
+		if ok := handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, envObject); !ok {
+			return false
+		}
+	}
+	return true
+}
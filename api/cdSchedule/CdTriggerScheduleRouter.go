@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cdSchedule
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type CdTriggerScheduleRouter interface {
+	InitCdTriggerScheduleRouter(cdTriggerScheduleRouter *mux.Router)
+}
+
+type CdTriggerScheduleRouterImpl struct {
+	cdTriggerScheduleRestHandler CdTriggerScheduleRestHandler
+}
+
+func NewCdTriggerScheduleRouterImpl(cdTriggerScheduleRestHandler CdTriggerScheduleRestHandler) *CdTriggerScheduleRouterImpl {
+	return &CdTriggerScheduleRouterImpl{
+		cdTriggerScheduleRestHandler: cdTriggerScheduleRestHandler,
+	}
+}
+
+func (impl *CdTriggerScheduleRouterImpl) InitCdTriggerScheduleRouter(cdTriggerScheduleRouter *mux.Router) {
+	cdTriggerScheduleRouter.Path("").
+		HandlerFunc(impl.cdTriggerScheduleRestHandler.SaveSchedule).Methods("POST")
+	cdTriggerScheduleRouter.Path("/{appId}/{pipelineId}").
+		HandlerFunc(impl.cdTriggerScheduleRestHandler.GetSchedules).Methods("GET")
+	cdTriggerScheduleRouter.Path("/{id}").
+		HandlerFunc(impl.cdTriggerScheduleRestHandler.DeleteSchedule).Methods("DELETE")
+}
@@ -0,0 +1,305 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configDraft
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/configDraft"
+	"github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type ConfigDraftRestHandler interface {
+	GetAllProtections(w http.ResponseWriter, r *http.Request)
+	SetProtection(w http.ResponseWriter, r *http.Request)
+	GetDrafts(w http.ResponseWriter, r *http.Request)
+	GetDraftHistory(w http.ResponseWriter, r *http.Request)
+	GetDraftDiff(w http.ResponseWriter, r *http.Request)
+	SubmitForReview(w http.ResponseWriter, r *http.Request)
+	ApproveAndPublish(w http.ResponseWriter, r *http.Request)
+	Discard(w http.ResponseWriter, r *http.Request)
+	AddComment(w http.ResponseWriter, r *http.Request)
+}
+
+type ConfigDraftRestHandlerImpl struct {
+	logger             *zap.SugaredLogger
+	configDraftService configDraft.ConfigDraftService
+	userService        user.UserService
+	enforcer           casbin.Enforcer
+	enforcerUtil       rbac.EnforcerUtil
+	validator          *validator.Validate
+}
+
+func NewConfigDraftRestHandlerImpl(logger *zap.SugaredLogger, configDraftService configDraft.ConfigDraftService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate) *ConfigDraftRestHandlerImpl {
+	return &ConfigDraftRestHandlerImpl{
+		logger:             logger,
+		configDraftService: configDraftService,
+		userService:        userService,
+		enforcer:           enforcer,
+		enforcerUtil:       enforcerUtil,
+		validator:          validator,
+	}
+}
+
+func (handler *ConfigDraftRestHandlerImpl) GetAllProtections(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.configDraftService.GetAllProtections()
+	if err != nil {
+		handler.logger.Errorw("service err, GetAllProtections", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ConfigDraftRestHandlerImpl) SetProtection(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionUpdate, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	protection := &bean.ConfigProtectionDto{}
+	err = json.NewDecoder(r.Body).Decode(protection)
+	if err != nil {
+		handler.logger.Errorw("request err, decode config protection", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(protection)
+	if err != nil {
+		handler.logger.Errorw("validation err, config protection", "payload", protection, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	protection.UserId = userId
+	err = handler.configDraftService.SetProtection(protection)
+	if err != nil {
+		handler.logger.Errorw("service err, SetProtection", "payload", protection, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, protection, http.StatusOK)
+}
+
+func (handler *ConfigDraftRestHandlerImpl) GetDrafts(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntQueryParam(w, r, "appId", 0)
+	if err != nil {
+		return
+	}
+	envId, err := common.ExtractIntQueryParam(w, r, "envId", 0)
+	if err != nil {
+		return
+	}
+	activeOnly, err := common.ExtractBoolQueryParam(r, "activeOnly")
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforceAppEnvAccess(token, appId, envId, casbin.ActionGet); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.configDraftService.GetDrafts(appId, envId, activeOnly)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDrafts", "appId", appId, "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ConfigDraftRestHandlerImpl) GetDraftHistory(w http.ResponseWriter, r *http.Request) {
+	draftId, ok := handler.authoriseDraftAccess(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	resp, err := handler.configDraftService.GetDraftHistory(draftId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDraftHistory", "draftId", draftId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ConfigDraftRestHandlerImpl) GetDraftDiff(w http.ResponseWriter, r *http.Request) {
+	draftId, ok := handler.authoriseDraftAccess(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	resp, err := handler.configDraftService.GetDraftDiff(r.Context(), draftId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDraftDiff", "draftId", draftId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ConfigDraftRestHandlerImpl) SubmitForReview(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	draftId, ok := handler.authoriseDraftAccess(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	resp, err := handler.configDraftService.SubmitForReview(draftId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, SubmitForReview", "draftId", draftId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ConfigDraftRestHandlerImpl) ApproveAndPublish(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	// approving needs more than the update access which lets users author drafts
+	draftId, ok := handler.authoriseDraftAccess(w, r, casbin.ActionApprove)
+	if !ok {
+		return
+	}
+	request := &bean.PublishDraftRequest{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, decode publish draft request", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, publish draft request", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.DraftId = draftId
+	request.UserId = userId
+	resp, err := handler.configDraftService.ApproveAndPublish(request)
+	if err != nil {
+		handler.logger.Errorw("service err, ApproveAndPublish", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ConfigDraftRestHandlerImpl) Discard(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	draftId, ok := handler.authoriseDraftAccess(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	err = handler.configDraftService.Discard(draftId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, Discard", "draftId", draftId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, draftId, http.StatusOK)
+}
+
+func (handler *ConfigDraftRestHandlerImpl) AddComment(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	draftId, ok := handler.authoriseDraftAccess(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	comment := &bean.DraftCommentDto{}
+	err = json.NewDecoder(r.Body).Decode(comment)
+	if err != nil {
+		handler.logger.Errorw("request err, decode draft comment", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(comment)
+	if err != nil {
+		handler.logger.Errorw("validation err, draft comment", "payload", comment, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	comment.DraftId = draftId
+	comment.UserId = userId
+	resp, err := handler.configDraftService.AddComment(comment)
+	if err != nil {
+		handler.logger.Errorw("service err, AddComment", "payload", comment, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+// authoriseDraftAccess extracts the draft id from the path and checks the user's access on the app and environment of the draft
+func (handler *ConfigDraftRestHandlerImpl) authoriseDraftAccess(w http.ResponseWriter, r *http.Request, action string) (int, bool) {
+	draftId, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return 0, false
+	}
+	draft, err := handler.configDraftService.GetDraft(draftId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDraft", "draftId", draftId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return 0, false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforceAppEnvAccess(token, draft.AppId, draft.EnvId, action); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return 0, false
+	}
+	return draftId, true
+}
+
+func (handler *ConfigDraftRestHandlerImpl) enforceAppEnvAccess(token string, appId, envId int, action string) bool {
+	object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, object); !ok {
+		return false
+	}
+	object = handler.enforcerUtil.GetEnvRBACNameByAppId(appId, envId)
+	return handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, object)
+}
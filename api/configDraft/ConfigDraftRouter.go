@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configDraft
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type ConfigDraftRouter interface {
+	InitConfigDraftRouter(configDraftRouter *mux.Router)
+}
+
+type ConfigDraftRouterImpl struct {
+	configDraftRestHandler ConfigDraftRestHandler
+}
+
+func NewConfigDraftRouterImpl(configDraftRestHandler ConfigDraftRestHandler) *ConfigDraftRouterImpl {
+	return &ConfigDraftRouterImpl{
+		configDraftRestHandler: configDraftRestHandler,
+	}
+}
+
+func (impl *ConfigDraftRouterImpl) InitConfigDraftRouter(configDraftRouter *mux.Router) {
+	configDraftRouter.Path("/protection").
+		HandlerFunc(impl.configDraftRestHandler.GetAllProtections).Methods("GET")
+	configDraftRouter.Path("/protection").
+		HandlerFunc(impl.configDraftRestHandler.SetProtection).Methods("PUT")
+	configDraftRouter.Path("").
+		HandlerFunc(impl.configDraftRestHandler.GetDrafts).Methods("GET")
+	configDraftRouter.Path("/{id}").
+		HandlerFunc(impl.configDraftRestHandler.GetDraftHistory).Methods("GET")
+	configDraftRouter.Path("/{id}/diff").
+		HandlerFunc(impl.configDraftRestHandler.GetDraftDiff).Methods("GET")
+	configDraftRouter.Path("/{id}/submit").
+		HandlerFunc(impl.configDraftRestHandler.SubmitForReview).Methods("PUT")
+	configDraftRouter.Path("/{id}/publish").
+		HandlerFunc(impl.configDraftRestHandler.ApproveAndPublish).Methods("PUT")
+	configDraftRouter.Path("/{id}/discard").
+		HandlerFunc(impl.configDraftRestHandler.Discard).Methods("PUT")
+	configDraftRouter.Path("/{id}/comment").
+		HandlerFunc(impl.configDraftRestHandler.AddComment).Methods("POST")
+}
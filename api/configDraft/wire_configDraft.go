@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configDraft
+
+import (
+	"github.com/devtron-labs/devtron/pkg/configDraft"
+	"github.com/devtron-labs/devtron/pkg/configDraft/protection"
+	"github.com/devtron-labs/devtron/pkg/configDraft/repository"
+	"github.com/google/wire"
+)
+
+var ConfigDraftWireSet = wire.NewSet(
+	repository.NewConfigDraftRepositoryImpl,
+	wire.Bind(new(repository.ConfigDraftRepository), new(*repository.ConfigDraftRepositoryImpl)),
+
+	protection.NewConfigProtectionServiceImpl,
+	wire.Bind(new(protection.ConfigProtectionService), new(*protection.ConfigProtectionServiceImpl)),
+
+	configDraft.NewConfigDraftServiceImpl,
+	wire.Bind(new(configDraft.ConfigDraftService), new(*configDraft.ConfigDraftServiceImpl)),
+
+	NewConfigDraftRestHandlerImpl,
+	wire.Bind(new(ConfigDraftRestHandler), new(*ConfigDraftRestHandlerImpl)),
+
+	NewConfigDraftRouterImpl,
+	wire.Bind(new(ConfigDraftRouter), new(*ConfigDraftRouterImpl)),
+)
@@ -0,0 +1,271 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cveException
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/cveException"
+	"github.com/devtron-labs/devtron/pkg/cveException/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type CveExceptionRestHandler interface {
+	CreateException(w http.ResponseWriter, r *http.Request)
+	GetExceptions(w http.ResponseWriter, r *http.Request)
+	GetException(w http.ResponseWriter, r *http.Request)
+	GetExpiringExceptions(w http.ResponseWriter, r *http.Request)
+	ApproveException(w http.ResponseWriter, r *http.Request)
+	RejectException(w http.ResponseWriter, r *http.Request)
+	RevokeException(w http.ResponseWriter, r *http.Request)
+}
+
+type CveExceptionRestHandlerImpl struct {
+	logger              *zap.SugaredLogger
+	cveExceptionService cveException.CveExceptionService
+	userService         user.UserService
+	enforcer            casbin.Enforcer
+	enforcerUtil        rbac.EnforcerUtil
+	validator           *validator.Validate
+}
+
+func NewCveExceptionRestHandlerImpl(logger *zap.SugaredLogger, cveExceptionService cveException.CveExceptionService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil,
+	validator *validator.Validate) *CveExceptionRestHandlerImpl {
+	return &CveExceptionRestHandlerImpl{
+		logger:              logger,
+		cveExceptionService: cveExceptionService,
+		userService:         userService,
+		enforcer:            enforcer,
+		enforcerUtil:        enforcerUtil,
+		validator:           validator,
+	}
+}
+
+func (handler *CveExceptionRestHandlerImpl) CreateException(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	request := &bean.CveExceptionDto{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, CreateException", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, CreateException", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if !handler.enforceScopeAccess(r.Header.Get("token"), request.AppId, request.EnvId, casbin.ActionCreate) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	request.UserId = userId
+	resp, err := handler.cveExceptionService.CreateException(request)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateException", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CveExceptionRestHandlerImpl) GetExceptions(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntQueryParam(w, r, "appId", 0)
+	if err != nil {
+		return
+	}
+	envId, err := common.ExtractIntQueryParam(w, r, "envId", 0)
+	if err != nil {
+		return
+	}
+	filter := &bean.ExceptionFilter{
+		CveName: r.URL.Query().Get("cveName"),
+		AppId:   appId,
+		EnvId:   envId,
+		Status:  bean.ExceptionStatus(r.URL.Query().Get("status")),
+	}
+	exceptions, err := handler.cveExceptionService.GetExceptions(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetExceptions", "filter", filter, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, handler.filterAuthorised(r.Header.Get("token"), exceptions), http.StatusOK)
+}
+
+func (handler *CveExceptionRestHandlerImpl) GetException(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	resp, err := handler.cveExceptionService.GetException(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetException", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if !handler.enforceScopeAccess(r.Header.Get("token"), resp.AppId, resp.EnvId, casbin.ActionGet) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CveExceptionRestHandlerImpl) GetExpiringExceptions(w http.ResponseWriter, r *http.Request) {
+	days, err := common.ExtractIntQueryParam(w, r, "days", 0)
+	if err != nil {
+		return
+	}
+	exceptions, err := handler.cveExceptionService.GetExpiringExceptions(days)
+	if err != nil {
+		handler.logger.Errorw("service err, GetExpiringExceptions", "days", days, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, handler.filterAuthorised(r.Header.Get("token"), exceptions), http.StatusOK)
+}
+
+func (handler *CveExceptionRestHandlerImpl) ApproveException(w http.ResponseWriter, r *http.Request) {
+	request, ok := handler.decodeReviewRequest(w, r)
+	if !ok {
+		return
+	}
+	// approvers review exceptions of every scope, they need global access
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceGlobal, casbin.ActionUpdate, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.cveExceptionService.ApproveException(request)
+	if err != nil {
+		handler.logger.Errorw("service err, ApproveException", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CveExceptionRestHandlerImpl) RejectException(w http.ResponseWriter, r *http.Request) {
+	request, ok := handler.decodeReviewRequest(w, r)
+	if !ok {
+		return
+	}
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceGlobal, casbin.ActionUpdate, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.cveExceptionService.RejectException(request)
+	if err != nil {
+		handler.logger.Errorw("service err, RejectException", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CveExceptionRestHandlerImpl) RevokeException(w http.ResponseWriter, r *http.Request) {
+	request, ok := handler.decodeReviewRequest(w, r)
+	if !ok {
+		return
+	}
+	exception, err := handler.cveExceptionService.GetException(request.Id)
+	if err != nil {
+		handler.logger.Errorw("service err, RevokeException", "id", request.Id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	// an exception can be revoked by the approvers or by whoever can request it
+	token := r.Header.Get("token")
+	if !handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionUpdate, "*") &&
+		!handler.enforceScopeAccess(token, exception.AppId, exception.EnvId, casbin.ActionCreate) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.cveExceptionService.RevokeException(request)
+	if err != nil {
+		handler.logger.Errorw("service err, RevokeException", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CveExceptionRestHandlerImpl) decodeReviewRequest(w http.ResponseWriter, r *http.Request) (*bean.ExceptionReviewRequest, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return nil, false
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return nil, false
+	}
+	request := &bean.ExceptionReviewRequest{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, decodeReviewRequest", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, decodeReviewRequest", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	request.Id = id
+	request.UserId = userId
+	return request, true
+}
+
+// enforceScopeAccess checks access on the app and environment of an exception the way the vulnerability policies of
+// the same level are checked
+func (handler *CveExceptionRestHandlerImpl) enforceScopeAccess(token string, appId, envId int, action string) bool {
+	if appId > 0 {
+		object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+		if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, object); !ok {
+			return false
+		}
+		if envId > 0 {
+			object = handler.enforcerUtil.GetEnvRBACNameByAppId(appId, envId)
+			return handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, object)
+		}
+		return true
+	}
+	return handler.enforcer.Enforce(token, casbin.ResourceGlobalEnvironment, action, "*")
+}
+
+func (handler *CveExceptionRestHandlerImpl) filterAuthorised(token string, exceptions []*bean.CveExceptionDto) []*bean.CveExceptionDto {
+	result := make([]*bean.CveExceptionDto, 0, len(exceptions))
+	for _, exception := range exceptions {
+		if handler.enforceScopeAccess(token, exception.AppId, exception.EnvId, casbin.ActionGet) {
+			result = append(result, exception)
+		}
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cveException
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type CveExceptionRouter interface {
+	InitCveExceptionRouter(cveExceptionRouter *mux.Router)
+}
+
+type CveExceptionRouterImpl struct {
+	cveExceptionRestHandler CveExceptionRestHandler
+}
+
+func NewCveExceptionRouterImpl(cveExceptionRestHandler CveExceptionRestHandler) *CveExceptionRouterImpl {
+	return &CveExceptionRouterImpl{
+		cveExceptionRestHandler: cveExceptionRestHandler,
+	}
+}
+
+func (impl *CveExceptionRouterImpl) InitCveExceptionRouter(cveExceptionRouter *mux.Router) {
+	cveExceptionRouter.Path("").
+		HandlerFunc(impl.cveExceptionRestHandler.CreateException).Methods("POST")
+	cveExceptionRouter.Path("").
+		HandlerFunc(impl.cveExceptionRestHandler.GetExceptions).Methods("GET")
+	cveExceptionRouter.Path("/expiring").
+		HandlerFunc(impl.cveExceptionRestHandler.GetExpiringExceptions).Methods("GET")
+	cveExceptionRouter.Path("/{id}").
+		HandlerFunc(impl.cveExceptionRestHandler.GetException).Methods("GET")
+	cveExceptionRouter.Path("/{id}/approve").
+		HandlerFunc(impl.cveExceptionRestHandler.ApproveException).Methods("PUT")
+	cveExceptionRouter.Path("/{id}/reject").
+		HandlerFunc(impl.cveExceptionRestHandler.RejectException).Methods("PUT")
+	cveExceptionRouter.Path("/{id}/revoke").
+		HandlerFunc(impl.cveExceptionRestHandler.RevokeException).Methods("PUT")
+}
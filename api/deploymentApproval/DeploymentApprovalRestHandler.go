@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentApproval
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type DeploymentApprovalRestHandler interface {
+	CreatePolicy(w http.ResponseWriter, r *http.Request)
+	UpdatePolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	GetAllPolicies(w http.ResponseWriter, r *http.Request)
+	RaiseApprovalRequest(w http.ResponseWriter, r *http.Request)
+	ApproveRequest(w http.ResponseWriter, r *http.Request)
+	RejectRequest(w http.ResponseWriter, r *http.Request)
+}
+
+type DeploymentApprovalRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	deploymentApprovalService deploymentApproval.DeploymentApprovalService
+	userService               user.UserService
+	enforcer                  casbin.Enforcer
+	enforcerUtil              rbac.EnforcerUtil
+	validator                 *validator.Validate
+}
+
+func NewDeploymentApprovalRestHandlerImpl(logger *zap.SugaredLogger, deploymentApprovalService deploymentApproval.DeploymentApprovalService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate) *DeploymentApprovalRestHandlerImpl {
+	return &DeploymentApprovalRestHandlerImpl{
+		logger:                    logger,
+		deploymentApprovalService: deploymentApprovalService,
+		userService:               userService,
+		enforcer:                  enforcer,
+		enforcerUtil:              enforcerUtil,
+		validator:                 validator,
+	}
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	policy, ok := handler.decodeAndAuthorisePolicy(w, r)
+	if !ok {
+		return
+	}
+	resp, err := handler.deploymentApprovalService.CreatePolicy(policy)
+	if err != nil {
+		handler.logger.Errorw("service err, CreatePolicy", "payload", policy, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	policy, ok := handler.decodeAndAuthorisePolicy(w, r)
+	if !ok {
+		return
+	}
+	if policy.Id == 0 {
+		common.WriteJsonResp(w, errors.New(bean.InvalidPolicyId), nil, http.StatusBadRequest)
+		return
+	}
+	resp, err := handler.deploymentApprovalService.UpdatePolicy(policy)
+	if err != nil {
+		handler.logger.Errorw("service err, UpdatePolicy", "payload", policy, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionDelete, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = handler.deploymentApprovalService.DeletePolicy(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeletePolicy", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, id, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) GetAllPolicies(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.deploymentApprovalService.GetAllPolicies()
+	if err != nil {
+		handler.logger.Errorw("service err, GetAllPolicies", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) RaiseApprovalRequest(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	request := &bean.ApprovalRequestDto{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, decode approval request", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, approval request", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// only users who can deploy on the pipeline can ask for approvals on it
+	token := r.Header.Get("token")
+	if ok := handler.enforcePipelineAccess(token, request.PipelineId, casbin.ActionTrigger); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	request.UserId = userId
+	resp, err := handler.deploymentApprovalService.RaiseApprovalRequest(request)
+	if err != nil {
+		handler.logger.Errorw("service err, RaiseApprovalRequest", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) ApproveRequest(w http.ResponseWriter, r *http.Request) {
+	handler.actOnApprovalRequest(w, r, bean.ActionApprove)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	handler.actOnApprovalRequest(w, r, bean.ActionReject)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) actOnApprovalRequest(w http.ResponseWriter, r *http.Request, approvalAction bean.ApprovalAction) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	action := &bean.ApprovalActionDto{}
+	if r.ContentLength > 0 {
+		err = json.NewDecoder(r.Body).Decode(action)
+		if err != nil {
+			handler.logger.Errorw("request err, decode approval action", "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
+	request, err := handler.deploymentApprovalService.GetApprovalRequest(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetApprovalRequest", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	// eligibility to approve is governed by the approver user groups of the policy, app access is needed to see the request
+	token := r.Header.Get("token")
+	if ok := handler.enforcePipelineAccess(token, request.PipelineId, casbin.ActionGet); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	action.ApprovalRequestId = id
+	action.Action = approvalAction
+	action.UserId = userId
+	resp, err := handler.deploymentApprovalService.ActOnApprovalRequest(action)
+	if err != nil {
+		handler.logger.Errorw("service err, ActOnApprovalRequest", "payload", action, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) enforcePipelineAccess(token string, pipelineId int, action string) bool {
+	objects, ok := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds([]int{pipelineId})[pipelineId]
+	if !ok || len(objects) < 2 {
+		return false
+	}
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, objects[0]); !ok {
+		return false
+	}
+	return handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, objects[1])
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) decodeAndAuthorisePolicy(w http.ResponseWriter, r *http.Request) (*bean.ApprovalPolicyDto, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return nil, false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionUpdate, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return nil, false
+	}
+	policy := &bean.ApprovalPolicyDto{}
+	err = json.NewDecoder(r.Body).Decode(policy)
+	if err != nil {
+		handler.logger.Errorw("request err, decode approval policy", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	err = handler.validator.Struct(policy)
+	if err != nil {
+		handler.logger.Errorw("validation err, approval policy", "payload", policy, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	policy.UserId = userId
+	return policy, true
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentApproval
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type DeploymentApprovalRouter interface {
+	InitDeploymentApprovalRouter(deploymentApprovalRouter *mux.Router)
+}
+
+type DeploymentApprovalRouterImpl struct {
+	deploymentApprovalRestHandler DeploymentApprovalRestHandler
+}
+
+func NewDeploymentApprovalRouterImpl(deploymentApprovalRestHandler DeploymentApprovalRestHandler) *DeploymentApprovalRouterImpl {
+	return &DeploymentApprovalRouterImpl{
+		deploymentApprovalRestHandler: deploymentApprovalRestHandler,
+	}
+}
+
+func (impl *DeploymentApprovalRouterImpl) InitDeploymentApprovalRouter(deploymentApprovalRouter *mux.Router) {
+	deploymentApprovalRouter.Path("/policy").
+		HandlerFunc(impl.deploymentApprovalRestHandler.GetAllPolicies).Methods("GET")
+	deploymentApprovalRouter.Path("/policy").
+		HandlerFunc(impl.deploymentApprovalRestHandler.CreatePolicy).Methods("POST")
+	deploymentApprovalRouter.Path("/policy").
+		HandlerFunc(impl.deploymentApprovalRestHandler.UpdatePolicy).Methods("PUT")
+	deploymentApprovalRouter.Path("/policy/{id}").
+		HandlerFunc(impl.deploymentApprovalRestHandler.DeletePolicy).Methods("DELETE")
+	deploymentApprovalRouter.Path("/request").
+		HandlerFunc(impl.deploymentApprovalRestHandler.RaiseApprovalRequest).Methods("POST")
+	deploymentApprovalRouter.Path("/request/{id}/approve").
+		HandlerFunc(impl.deploymentApprovalRestHandler.ApproveRequest).Methods("PUT")
+	deploymentApprovalRouter.Path("/request/{id}/reject").
+		HandlerFunc(impl.deploymentApprovalRestHandler.RejectRequest).Methods("PUT")
+}
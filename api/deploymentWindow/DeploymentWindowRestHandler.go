@@ -0,0 +1,263 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentWindow
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deploymentWindow"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+	"time"
+)
+
+type DeploymentWindowRestHandler interface {
+	CreateWindow(w http.ResponseWriter, r *http.Request)
+	UpdateWindow(w http.ResponseWriter, r *http.Request)
+	DeleteWindow(w http.ResponseWriter, r *http.Request)
+	GetWindow(w http.ResponseWriter, r *http.Request)
+	GetAllWindows(w http.ResponseWriter, r *http.Request)
+	GetDeploymentWindowState(w http.ResponseWriter, r *http.Request)
+	GetOverrideAudits(w http.ResponseWriter, r *http.Request)
+}
+
+type DeploymentWindowRestHandlerImpl struct {
+	logger                  *zap.SugaredLogger
+	deploymentWindowService deploymentWindow.DeploymentWindowService
+	userService             user.UserService
+	enforcer                casbin.Enforcer
+	enforcerUtil            rbac.EnforcerUtil
+	validator               *validator.Validate
+	pipelineRepository      pipelineConfig.PipelineRepository
+}
+
+func NewDeploymentWindowRestHandlerImpl(logger *zap.SugaredLogger, deploymentWindowService deploymentWindow.DeploymentWindowService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	pipelineRepository pipelineConfig.PipelineRepository) *DeploymentWindowRestHandlerImpl {
+	return &DeploymentWindowRestHandlerImpl{
+		logger:                  logger,
+		deploymentWindowService: deploymentWindowService,
+		userService:             userService,
+		enforcer:                enforcer,
+		enforcerUtil:            enforcerUtil,
+		validator:               validator,
+		pipelineRepository:      pipelineRepository,
+	}
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) CreateWindow(w http.ResponseWriter, r *http.Request) {
+	window, ok := handler.decodeAndAuthorise(w, r)
+	if !ok {
+		return
+	}
+	resp, err := handler.deploymentWindowService.CreateWindow(window)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateWindow", "payload", window, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) UpdateWindow(w http.ResponseWriter, r *http.Request) {
+	window, ok := handler.decodeAndAuthorise(w, r)
+	if !ok {
+		return
+	}
+	if window.Id == 0 {
+		common.WriteJsonResp(w, errors.New(deploymentWindow.InvalidWindowId), nil, http.StatusBadRequest)
+		return
+	}
+	resp, err := handler.deploymentWindowService.UpdateWindow(window)
+	if err != nil {
+		handler.logger.Errorw("service err, UpdateWindow", "payload", window, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) DeleteWindow(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionDelete, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = handler.deploymentWindowService.DeleteWindow(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteWindow", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, id, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) GetWindow(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	resp, err := handler.deploymentWindowService.GetWindow(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetWindow", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) GetAllWindows(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.deploymentWindowService.GetAllWindows()
+	if err != nil {
+		handler.logger.Errorw("service err, GetAllWindows", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) GetDeploymentWindowState(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntQueryParam(w, r, "appId", 0)
+	if err != nil {
+		return
+	}
+	envId, err := common.ExtractIntQueryParam(w, r, "envId", 0)
+	if err != nil {
+		return
+	}
+	if appId == 0 || envId == 0 {
+		common.WriteJsonResp(w, errors.New("appId and envId are required"), nil, http.StatusBadRequest)
+		return
+	}
+	// rbac is enforced on appId, so the env must be one the app is deployed on
+	pipelines, err := handler.pipelineRepository.FindActiveByAppIdAndEnvironmentId(appId, envId)
+	if err != nil && err != pg.ErrNoRows {
+		handler.logger.Errorw("error in fetching pipelines", "appId", appId, "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if len(pipelines) == 0 {
+		common.WriteJsonResp(w, errors.New("environment does not belong to the app"), nil, http.StatusForbidden)
+		return
+	}
+	token := r.Header.Get("token")
+	object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, object); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.deploymentWindowService.GetDeploymentWindowState(appId, envId, time.Now())
+	if err != nil {
+		handler.logger.Errorw("service err, GetDeploymentWindowState", "appId", appId, "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) GetOverrideAudits(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntQueryParam(w, r, "appId", 0)
+	if err != nil {
+		return
+	}
+	pipelineId, err := common.ExtractIntQueryParam(w, r, "pipelineId", 0)
+	if err != nil {
+		return
+	}
+	if appId == 0 || pipelineId == 0 {
+		common.WriteJsonResp(w, errors.New("appId and pipelineId are required"), nil, http.StatusBadRequest)
+		return
+	}
+	// rbac is enforced on appId, so the pipeline must belong to it
+	pipeline, err := handler.pipelineRepository.FindById(pipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		handler.logger.Errorw("error in fetching pipeline", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if pipeline == nil || pipeline.Id == 0 || pipeline.AppId != appId {
+		common.WriteJsonResp(w, errors.New("pipeline does not belong to the app"), nil, http.StatusForbidden)
+		return
+	}
+	token := r.Header.Get("token")
+	object := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, object); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.deploymentWindowService.GetOverrideAudits(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetOverrideAudits", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) decodeAndAuthorise(w http.ResponseWriter, r *http.Request) (*deploymentWindow.DeploymentWindowDto, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return nil, false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionUpdate, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return nil, false
+	}
+	window := &deploymentWindow.DeploymentWindowDto{}
+	err = json.NewDecoder(r.Body).Decode(window)
+	if err != nil {
+		handler.logger.Errorw("request err, decode deployment window", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	err = handler.validator.Struct(window)
+	if err != nil {
+		handler.logger.Errorw("validation err, deployment window", "payload", window, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	window.UserId = userId
+	return window, true
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentWindow
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type DeploymentWindowRouter interface {
+	InitDeploymentWindowRouter(deploymentWindowRouter *mux.Router)
+}
+
+type DeploymentWindowRouterImpl struct {
+	deploymentWindowRestHandler DeploymentWindowRestHandler
+}
+
+func NewDeploymentWindowRouterImpl(deploymentWindowRestHandler DeploymentWindowRestHandler) *DeploymentWindowRouterImpl {
+	return &DeploymentWindowRouterImpl{
+		deploymentWindowRestHandler: deploymentWindowRestHandler,
+	}
+}
+
+func (impl *DeploymentWindowRouterImpl) InitDeploymentWindowRouter(deploymentWindowRouter *mux.Router) {
+	deploymentWindowRouter.Path("").
+		HandlerFunc(impl.deploymentWindowRestHandler.GetAllWindows).Methods("GET")
+	deploymentWindowRouter.Path("").
+		HandlerFunc(impl.deploymentWindowRestHandler.CreateWindow).Methods("POST")
+	deploymentWindowRouter.Path("").
+		HandlerFunc(impl.deploymentWindowRestHandler.UpdateWindow).Methods("PUT")
+	deploymentWindowRouter.Path("/state").
+		HandlerFunc(impl.deploymentWindowRestHandler.GetDeploymentWindowState).Methods("GET")
+	deploymentWindowRouter.Path("/override/audit").
+		HandlerFunc(impl.deploymentWindowRestHandler.GetOverrideAudits).Methods("GET")
+	deploymentWindowRouter.Path("/{id}").
+		HandlerFunc(impl.deploymentWindowRestHandler.GetWindow).Methods("GET")
+	deploymentWindowRouter.Path("/{id}").
+		HandlerFunc(impl.deploymentWindowRestHandler.DeleteWindow).Methods("DELETE")
+}
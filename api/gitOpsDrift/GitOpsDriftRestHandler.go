@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gitOpsDrift
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type GitOpsDriftRestHandler interface {
+	GetDriftReport(w http.ResponseWriter, r *http.Request)
+	CheckDrift(w http.ResponseWriter, r *http.Request)
+	ReconcileDrift(w http.ResponseWriter, r *http.Request)
+}
+
+type GitOpsDriftRestHandlerImpl struct {
+	logger             *zap.SugaredLogger
+	gitOpsDriftService drift.GitOpsDriftService
+	userService        user.UserService
+	enforcer           casbin.Enforcer
+	enforcerUtil       rbac.EnforcerUtil
+	validator          *validator.Validate
+}
+
+func NewGitOpsDriftRestHandlerImpl(logger *zap.SugaredLogger, gitOpsDriftService drift.GitOpsDriftService,
+	userService user.UserService, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate) *GitOpsDriftRestHandlerImpl {
+	return &GitOpsDriftRestHandlerImpl{
+		logger:             logger,
+		gitOpsDriftService: gitOpsDriftService,
+		userService:        userService,
+		enforcer:           enforcer,
+		enforcerUtil:       enforcerUtil,
+		validator:          validator,
+	}
+}
+
+func (handler *GitOpsDriftRestHandlerImpl) GetDriftReport(w http.ResponseWriter, r *http.Request) {
+	appId, err := common.ExtractIntQueryParam(w, r, "appId", 0)
+	if err != nil {
+		return
+	}
+	envId, err := common.ExtractIntQueryParam(w, r, "envId", 0)
+	if err != nil {
+		return
+	}
+	report, err := handler.gitOpsDriftService.GetReport(appId, envId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDriftReport", "appId", appId, "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	// the report only lists the pipelines the user can see
+	pipelineIds := make([]int, 0, len(report))
+	for _, item := range report {
+		pipelineIds = append(pipelineIds, item.PipelineId)
+	}
+	objectsByPipelineId := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds(pipelineIds)
+	token := r.Header.Get("token")
+	filteredReport := make([]*bean.GitOpsDriftDto, 0, len(report))
+	for _, item := range report {
+		objects, ok := objectsByPipelineId[item.PipelineId]
+		if !ok || len(objects) < 2 {
+			continue
+		}
+		if handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, objects[0]) &&
+			handler.enforcer.Enforce(token, casbin.ResourceEnvironment, casbin.ActionGet, objects[1]) {
+			filteredReport = append(filteredReport, item)
+		}
+	}
+	common.WriteJsonResp(w, nil, filteredReport, http.StatusOK)
+}
+
+func (handler *GitOpsDriftRestHandlerImpl) CheckDrift(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParam(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := handler.enforcePipelineAccess(r.Header.Get("token"), pipelineId, casbin.ActionGet, casbin.ActionGet); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.gitOpsDriftService.CheckPipeline(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, CheckDrift", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *GitOpsDriftRestHandlerImpl) ReconcileDrift(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParam(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	request := &bean.ReconcileRequest{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, ReconcileDrift", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, ReconcileDrift", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// reconciling either changes the deployment template or redeploys, both need edit and deploy access
+	if ok := handler.enforcePipelineAccess(r.Header.Get("token"), pipelineId, casbin.ActionUpdate, casbin.ActionTrigger); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	request.PipelineId = pipelineId
+	request.UserId = userId
+	resp, draft, err := handler.gitOpsDriftService.Reconcile(request)
+	if err != nil {
+		handler.logger.Errorw("service err, ReconcileDrift", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if draft != nil {
+		// the deployment template is protected, the adopted changes are saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *GitOpsDriftRestHandlerImpl) enforcePipelineAccess(token string, pipelineId int, appAction, envAction string) bool {
+	objects, ok := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds([]int{pipelineId})[pipelineId]
+	if !ok || len(objects) < 2 {
+		return false
+	}
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, appAction, objects[0]); !ok {
+		return false
+	}
+	return handler.enforcer.Enforce(token, casbin.ResourceEnvironment, envAction, objects[1])
+}
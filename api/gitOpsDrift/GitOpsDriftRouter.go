@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gitOpsDrift
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type GitOpsDriftRouter interface {
+	InitGitOpsDriftRouter(gitOpsDriftRouter *mux.Router)
+}
+
+type GitOpsDriftRouterImpl struct {
+	gitOpsDriftRestHandler GitOpsDriftRestHandler
+}
+
+func NewGitOpsDriftRouterImpl(gitOpsDriftRestHandler GitOpsDriftRestHandler) *GitOpsDriftRouterImpl {
+	return &GitOpsDriftRouterImpl{
+		gitOpsDriftRestHandler: gitOpsDriftRestHandler,
+	}
+}
+
+func (impl *GitOpsDriftRouterImpl) InitGitOpsDriftRouter(gitOpsDriftRouter *mux.Router) {
+	gitOpsDriftRouter.Path("").
+		HandlerFunc(impl.gitOpsDriftRestHandler.GetDriftReport).Methods("GET")
+	gitOpsDriftRouter.Path("/pipeline/{pipelineId}/check").
+		HandlerFunc(impl.gitOpsDriftRestHandler.CheckDrift).Methods("POST")
+	gitOpsDriftRouter.Path("/pipeline/{pipelineId}/reconcile").
+		HandlerFunc(impl.gitOpsDriftRestHandler.ReconcileDrift).Methods("POST")
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gitOpsMonorepo
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo/bean"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+type GitOpsMonorepoRestHandler interface {
+	SaveMonorepo(w http.ResponseWriter, r *http.Request)
+	GetMonorepos(w http.ResponseWriter, r *http.Request)
+	GetMonorepo(w http.ResponseWriter, r *http.Request)
+	DeleteMonorepo(w http.ResponseWriter, r *http.Request)
+	MigrateApps(w http.ResponseWriter, r *http.Request)
+}
+
+type GitOpsMonorepoRestHandlerImpl struct {
+	logger                *zap.SugaredLogger
+	gitOpsMonorepoService monorepo.GitOpsMonorepoService
+	userService           user.UserService
+	enforcer              casbin.Enforcer
+	validator             *validator.Validate
+}
+
+func NewGitOpsMonorepoRestHandlerImpl(logger *zap.SugaredLogger, gitOpsMonorepoService monorepo.GitOpsMonorepoService,
+	userService user.UserService, enforcer casbin.Enforcer, validator *validator.Validate) *GitOpsMonorepoRestHandlerImpl {
+	return &GitOpsMonorepoRestHandlerImpl{
+		logger:                logger,
+		gitOpsMonorepoService: gitOpsMonorepoService,
+		userService:           userService,
+		enforcer:              enforcer,
+		validator:             validator,
+	}
+}
+
+func (handler *GitOpsMonorepoRestHandlerImpl) SaveMonorepo(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	request := &bean.GitOpsMonorepoDto{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveMonorepo", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveMonorepo", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	action := casbin.ActionCreate
+	if request.Id > 0 {
+		action = casbin.ActionUpdate
+	}
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceGlobal, action, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	request.UserId = userId
+	resp, err := handler.gitOpsMonorepoService.Save(request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveMonorepo", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *GitOpsMonorepoRestHandlerImpl) GetMonorepos(w http.ResponseWriter, r *http.Request) {
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.gitOpsMonorepoService.GetAll()
+	if err != nil {
+		handler.logger.Errorw("service err, GetMonorepos", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *GitOpsMonorepoRestHandlerImpl) GetMonorepo(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.gitOpsMonorepoService.GetById(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetMonorepo", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *GitOpsMonorepoRestHandlerImpl) DeleteMonorepo(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceGlobal, casbin.ActionDelete, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	err = handler.gitOpsMonorepoService.Delete(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteMonorepo", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, id, http.StatusOK)
+}
+
+func (handler *GitOpsMonorepoRestHandlerImpl) MigrateApps(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	request := &bean.MigrateAppsRequest{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, MigrateApps", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceGlobal, casbin.ActionUpdate, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	request.MonorepoId = id
+	request.UserId = userId
+	resp, err := handler.gitOpsMonorepoService.MigrateApps(request)
+	if err != nil {
+		handler.logger.Errorw("service err, MigrateApps", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
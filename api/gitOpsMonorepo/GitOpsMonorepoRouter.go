@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gitOpsMonorepo
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type GitOpsMonorepoRouter interface {
+	InitGitOpsMonorepoRouter(gitOpsMonorepoRouter *mux.Router)
+}
+
+type GitOpsMonorepoRouterImpl struct {
+	gitOpsMonorepoRestHandler GitOpsMonorepoRestHandler
+}
+
+func NewGitOpsMonorepoRouterImpl(gitOpsMonorepoRestHandler GitOpsMonorepoRestHandler) *GitOpsMonorepoRouterImpl {
+	return &GitOpsMonorepoRouterImpl{
+		gitOpsMonorepoRestHandler: gitOpsMonorepoRestHandler,
+	}
+}
+
+func (impl *GitOpsMonorepoRouterImpl) InitGitOpsMonorepoRouter(gitOpsMonorepoRouter *mux.Router) {
+	gitOpsMonorepoRouter.Path("").
+		HandlerFunc(impl.gitOpsMonorepoRestHandler.SaveMonorepo).Methods("POST")
+	gitOpsMonorepoRouter.Path("").
+		HandlerFunc(impl.gitOpsMonorepoRestHandler.GetMonorepos).Methods("GET")
+	gitOpsMonorepoRouter.Path("/{id}").
+		HandlerFunc(impl.gitOpsMonorepoRestHandler.GetMonorepo).Methods("GET")
+	gitOpsMonorepoRouter.Path("/{id}").
+		HandlerFunc(impl.gitOpsMonorepoRestHandler.DeleteMonorepo).Methods("DELETE")
+	gitOpsMonorepoRouter.Path("/{id}/migrate").
+		HandlerFunc(impl.gitOpsMonorepoRestHandler.MigrateApps).Methods("POST")
+}
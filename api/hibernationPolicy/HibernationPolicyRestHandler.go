@@ -0,0 +1,255 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hibernationPolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/cluster"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type HibernationPolicyRestHandler interface {
+	SavePolicy(w http.ResponseWriter, r *http.Request)
+	GetPolicies(w http.ResponseWriter, r *http.Request)
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	KeepAwake(w http.ResponseWriter, r *http.Request)
+	GetSavingsReport(w http.ResponseWriter, r *http.Request)
+}
+
+type HibernationPolicyRestHandlerImpl struct {
+	logger                   *zap.SugaredLogger
+	hibernationPolicyService hibernationPolicy.HibernationPolicyService
+	environmentService       cluster.EnvironmentService
+	userService              user.UserService
+	enforcer                 casbin.Enforcer
+	enforcerUtil             rbac.EnforcerUtil
+	validator                *validator.Validate
+}
+
+func NewHibernationPolicyRestHandlerImpl(logger *zap.SugaredLogger, hibernationPolicyService hibernationPolicy.HibernationPolicyService,
+	environmentService cluster.EnvironmentService, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate) *HibernationPolicyRestHandlerImpl {
+	return &HibernationPolicyRestHandlerImpl{
+		logger:                   logger,
+		hibernationPolicyService: hibernationPolicyService,
+		environmentService:       environmentService,
+		userService:              userService,
+		enforcer:                 enforcer,
+		enforcerUtil:             enforcerUtil,
+		validator:                validator,
+	}
+}
+
+func (handler *HibernationPolicyRestHandlerImpl) SavePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	request := &bean.HibernationPolicyDto{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, SavePolicy", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SavePolicy", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if request.Id > 0 {
+		// the environment of an existing policy is checked as well, so that it cannot be moved out of one
+		existing, err := handler.hibernationPolicyService.GetPolicy(request.Id)
+		if err != nil {
+			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+			return
+		}
+		if !handler.isAuthorisedForEnv(token, casbin.ActionUpdate, existing.EnvId) {
+			common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+			return
+		}
+	}
+	if !handler.isAuthorisedForEnv(token, casbin.ActionUpdate, request.EnvId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	request.UserId = userId
+	resp, err := handler.hibernationPolicyService.SavePolicy(request)
+	if err != nil {
+		handler.logger.Errorw("service err, SavePolicy", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *HibernationPolicyRestHandlerImpl) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	envId, err := common.ExtractIntQueryParam(w, r, "envId", 0)
+	if err != nil {
+		return
+	}
+	if !handler.isAuthorisedForEnv(r.Header.Get("token"), casbin.ActionGet, envId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	resp, err := handler.hibernationPolicyService.GetPoliciesByEnvId(envId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicies", "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *HibernationPolicyRestHandlerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, ok := handler.getAuthorisedPolicy(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	common.WriteJsonResp(w, nil, policy, http.StatusOK)
+}
+
+func (handler *HibernationPolicyRestHandlerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	policy, ok := handler.getAuthorisedPolicy(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	err = handler.hibernationPolicyService.DeletePolicy(policy.Id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeletePolicy", "id", policy.Id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, policy.Id, http.StatusOK)
+}
+
+// KeepAwake is open to anyone who can hibernate all the applications of the policy themselves
+func (handler *HibernationPolicyRestHandlerImpl) KeepAwake(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	request := &bean.KeepAwakeRequest{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, KeepAwake", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	policy, err := handler.hibernationPolicyService.GetPolicy(id)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	appIds, err := handler.hibernationPolicyService.GetPolicyAppIds(id)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	token := r.Header.Get("token")
+	for _, appId := range appIds {
+		appObject := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+		envObject := handler.enforcerUtil.GetEnvRBACNameByAppId(appId, policy.EnvId)
+		if !handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionTrigger, strings.ToLower(appObject)) ||
+			!handler.enforcer.Enforce(token, casbin.ResourceEnvironment, casbin.ActionTrigger, strings.ToLower(envObject)) {
+			common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+			return
+		}
+	}
+	request.PolicyId = id
+	request.UserId = userId
+	resp, err := handler.hibernationPolicyService.SetKeepAwake(request)
+	if err != nil {
+		handler.logger.Errorw("service err, KeepAwake", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *HibernationPolicyRestHandlerImpl) GetSavingsReport(w http.ResponseWriter, r *http.Request) {
+	policy, ok := handler.getAuthorisedPolicy(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	days, err := common.ExtractIntQueryParam(w, r, "days", bean.DefaultReportDays)
+	if err != nil {
+		return
+	}
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+	resp, err := handler.hibernationPolicyService.GetSavingsReport(policy.Id, from, to)
+	if err != nil {
+		handler.logger.Errorw("service err, GetSavingsReport", "id", policy.Id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+// getAuthorisedPolicy writes the error response itself when the policy cannot be served
+func (handler *HibernationPolicyRestHandlerImpl) getAuthorisedPolicy(w http.ResponseWriter, r *http.Request, action string) (*bean.HibernationPolicyDto, bool) {
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return nil, false
+	}
+	policy, err := handler.hibernationPolicyService.GetPolicy(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicy", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return nil, false
+	}
+	if !handler.isAuthorisedForEnv(r.Header.Get("token"), action, policy.EnvId) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return nil, false
+	}
+	return policy, true
+}
+
+func (handler *HibernationPolicyRestHandlerImpl) isAuthorisedForEnv(token string, action string, envId int) bool {
+	environment, err := handler.environmentService.FindById(envId)
+	if err != nil {
+		handler.logger.Errorw("error in fetching environment", "envId", envId, "err", err)
+		return false
+	}
+	return handler.enforcer.Enforce(token, casbin.ResourceGlobalEnvironment, action, environment.EnvironmentIdentifier)
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hibernationPolicy
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type HibernationPolicyRouter interface {
+	InitHibernationPolicyRouter(hibernationPolicyRouter *mux.Router)
+}
+
+type HibernationPolicyRouterImpl struct {
+	hibernationPolicyRestHandler HibernationPolicyRestHandler
+}
+
+func NewHibernationPolicyRouterImpl(hibernationPolicyRestHandler HibernationPolicyRestHandler) *HibernationPolicyRouterImpl {
+	return &HibernationPolicyRouterImpl{
+		hibernationPolicyRestHandler: hibernationPolicyRestHandler,
+	}
+}
+
+func (impl *HibernationPolicyRouterImpl) InitHibernationPolicyRouter(hibernationPolicyRouter *mux.Router) {
+	hibernationPolicyRouter.Path("").
+		HandlerFunc(impl.hibernationPolicyRestHandler.SavePolicy).Methods("POST")
+	hibernationPolicyRouter.Path("").
+		HandlerFunc(impl.hibernationPolicyRestHandler.GetPolicies).Queries("envId", "{envId}").Methods("GET")
+	hibernationPolicyRouter.Path("/{id}").
+		HandlerFunc(impl.hibernationPolicyRestHandler.GetPolicy).Methods("GET")
+	hibernationPolicyRouter.Path("/{id}").
+		HandlerFunc(impl.hibernationPolicyRestHandler.DeletePolicy).Methods("DELETE")
+	hibernationPolicyRouter.Path("/{id}/keep-awake").
+		HandlerFunc(impl.hibernationPolicyRestHandler.KeepAwake).Methods("PUT")
+	hibernationPolicyRouter.Path("/{id}/report").
+		HandlerFunc(impl.hibernationPolicyRestHandler.GetSavingsReport).Methods("GET")
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageRetention
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/imageRetention"
+	"github.com/devtron-labs/devtron/pkg/imageRetention/bean"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"net/http"
+)
+
+const defaultDeletionsLimit = 100
+
+type ImageRetentionRestHandler interface {
+	SavePolicy(w http.ResponseWriter, r *http.Request)
+	GetPolicies(w http.ResponseWriter, r *http.Request)
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	GetRetentionPlan(w http.ResponseWriter, r *http.Request)
+	ApplyPolicy(w http.ResponseWriter, r *http.Request)
+	GetDeletions(w http.ResponseWriter, r *http.Request)
+}
+
+type ImageRetentionRestHandlerImpl struct {
+	logger                *zap.SugaredLogger
+	imageRetentionService imageRetention.ImageRetentionService
+	userService           user.UserService
+	enforcer              casbin.Enforcer
+	validator             *validator.Validate
+}
+
+func NewImageRetentionRestHandlerImpl(logger *zap.SugaredLogger, imageRetentionService imageRetention.ImageRetentionService,
+	userService user.UserService, enforcer casbin.Enforcer, validator *validator.Validate) *ImageRetentionRestHandlerImpl {
+	return &ImageRetentionRestHandlerImpl{
+		logger:                logger,
+		imageRetentionService: imageRetentionService,
+		userService:           userService,
+		enforcer:              enforcer,
+		validator:             validator,
+	}
+}
+
+func (handler *ImageRetentionRestHandlerImpl) SavePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	request := &bean.ImageRetentionPolicyDto{}
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		handler.logger.Errorw("request err, SavePolicy", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SavePolicy", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// policies delete images from the registry, they need edit access on it
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceDocker, casbin.ActionUpdate, request.DockerRegistryId); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	if request.Id > 0 && !handler.enforcePolicyAccess(w, r, request.Id, casbin.ActionUpdate) {
+		return
+	}
+	request.UserId = userId
+	resp, err := handler.imageRetentionService.SavePolicy(request)
+	if err != nil {
+		handler.logger.Errorw("service err, SavePolicy", "payload", request, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ImageRetentionRestHandlerImpl) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := handler.imageRetentionService.GetPolicies(r.URL.Query().Get("dockerRegistryId"))
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicies", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	token := r.Header.Get("token")
+	result := make([]*bean.ImageRetentionPolicyDto, 0, len(policies))
+	for _, policy := range policies {
+		if ok := handler.enforcer.Enforce(token, casbin.ResourceDocker, casbin.ActionGet, policy.DockerRegistryId); ok {
+			result = append(result, policy)
+		}
+	}
+	common.WriteJsonResp(w, nil, result, http.StatusOK)
+}
+
+func (handler *ImageRetentionRestHandlerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	if !handler.enforcePolicyAccess(w, r, id, casbin.ActionGet) {
+		return
+	}
+	resp, err := handler.imageRetentionService.GetPolicy(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicy", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ImageRetentionRestHandlerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	if !handler.enforcePolicyAccess(w, r, id, casbin.ActionUpdate) {
+		return
+	}
+	err = handler.imageRetentionService.DeletePolicy(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeletePolicy", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, id, http.StatusOK)
+}
+
+func (handler *ImageRetentionRestHandlerImpl) GetRetentionPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	if !handler.enforcePolicyAccess(w, r, id, casbin.ActionGet) {
+		return
+	}
+	resp, err := handler.imageRetentionService.GetRetentionPlan(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRetentionPlan", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ImageRetentionRestHandlerImpl) ApplyPolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	if !handler.enforcePolicyAccess(w, r, id, casbin.ActionUpdate) {
+		return
+	}
+	resp, err := handler.imageRetentionService.ApplyPolicy(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, ApplyPolicy", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ImageRetentionRestHandlerImpl) GetDeletions(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	limit, err := common.ExtractIntQueryParam(w, r, "limit", defaultDeletionsLimit)
+	if err != nil {
+		return
+	}
+	if !handler.enforcePolicyAccess(w, r, id, casbin.ActionGet) {
+		return
+	}
+	resp, err := handler.imageRetentionService.GetDeletions(id, limit)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDeletions", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+// enforcePolicyAccess checks access on the registry of the saved policy, writing the response when denied
+func (handler *ImageRetentionRestHandlerImpl) enforcePolicyAccess(w http.ResponseWriter, r *http.Request, policyId int, action string) bool {
+	policy, err := handler.imageRetentionService.GetPolicy(policyId)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return false
+	}
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceDocker, action, policy.DockerRegistryId); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return false
+	}
+	return true
+}
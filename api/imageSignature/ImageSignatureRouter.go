@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageSignature
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type ImageSignatureRouter interface {
+	InitImageSignatureRouter(imageSignatureRouter *mux.Router)
+}
+
+type ImageSignatureRouterImpl struct {
+	imageSignatureRestHandler ImageSignatureRestHandler
+}
+
+func NewImageSignatureRouterImpl(imageSignatureRestHandler ImageSignatureRestHandler) *ImageSignatureRouterImpl {
+	return &ImageSignatureRouterImpl{
+		imageSignatureRestHandler: imageSignatureRestHandler,
+	}
+}
+
+func (impl *ImageSignatureRouterImpl) InitImageSignatureRouter(imageSignatureRouter *mux.Router) {
+	imageSignatureRouter.Path("/policy").
+		HandlerFunc(impl.imageSignatureRestHandler.GetAllPolicies).Methods("GET")
+	imageSignatureRouter.Path("/policy").
+		HandlerFunc(impl.imageSignatureRestHandler.CreatePolicy).Methods("POST")
+	imageSignatureRouter.Path("/policy").
+		HandlerFunc(impl.imageSignatureRestHandler.UpdatePolicy).Methods("PUT")
+	imageSignatureRouter.Path("/policy/{id}").
+		HandlerFunc(impl.imageSignatureRestHandler.GetPolicy).Methods("GET")
+	imageSignatureRouter.Path("/policy/{id}").
+		HandlerFunc(impl.imageSignatureRestHandler.DeletePolicy).Methods("DELETE")
+	imageSignatureRouter.Path("/verify").
+		HandlerFunc(impl.imageSignatureRestHandler.VerifyArtifact).Methods("POST")
+	imageSignatureRouter.Path("/verification/{appId}/{ciArtifactId}").
+		HandlerFunc(impl.imageSignatureRestHandler.GetArtifactVerifications).Methods("GET")
+}
@@ -34,8 +34,12 @@ type ApiToken struct {
 	LastUsedAt *string `json:"lastUsedAt,omitempty"`
 	// token last used by IP
 	LastUsedByIp *string `json:"lastUsedByIp,omitempty"`
+	// Number of requests made using this token
+	RequestCount *int64 `json:"requestCount,omitempty"`
 	// token last updatedAt
 	UpdatedAt *string `json:"updatedAt,omitempty"`
+	// Scopes restricting this token, empty if the token is not restricted
+	Scopes *[]ApiTokenScope `json:"scopes,omitempty"`
 }
 
 // NewApiToken instantiates a new ApiToken object
@@ -375,6 +379,70 @@ func (o *ApiToken) SetUpdatedAt(v string) {
 	o.UpdatedAt = &v
 }
 
+// GetRequestCount returns the RequestCount field value if set, zero value otherwise.
+func (o *ApiToken) GetRequestCount() int64 {
+	if o == nil || o.RequestCount == nil {
+		var ret int64
+		return ret
+	}
+	return *o.RequestCount
+}
+
+// GetRequestCountOk returns a tuple with the RequestCount field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ApiToken) GetRequestCountOk() (*int64, bool) {
+	if o == nil || o.RequestCount == nil {
+		return nil, false
+	}
+	return o.RequestCount, true
+}
+
+// HasRequestCount returns a boolean if a field has been set.
+func (o *ApiToken) HasRequestCount() bool {
+	if o != nil && o.RequestCount != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetRequestCount gets a reference to the given int64 and assigns it to the RequestCount field.
+func (o *ApiToken) SetRequestCount(v int64) {
+	o.RequestCount = &v
+}
+
+// GetScopes returns the Scopes field value if set, zero value otherwise.
+func (o *ApiToken) GetScopes() []ApiTokenScope {
+	if o == nil || o.Scopes == nil {
+		var ret []ApiTokenScope
+		return ret
+	}
+	return *o.Scopes
+}
+
+// GetScopesOk returns a tuple with the Scopes field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ApiToken) GetScopesOk() (*[]ApiTokenScope, bool) {
+	if o == nil || o.Scopes == nil {
+		return nil, false
+	}
+	return o.Scopes, true
+}
+
+// HasScopes returns a boolean if a field has been set.
+func (o *ApiToken) HasScopes() bool {
+	if o != nil && o.Scopes != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetScopes gets a reference to the given []ApiTokenScope and assigns it to the Scopes field.
+func (o *ApiToken) SetScopes(v []ApiTokenScope) {
+	o.Scopes = &v
+}
+
 func (o ApiToken) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
 	if o.Id != nil {
@@ -407,6 +475,12 @@ func (o ApiToken) MarshalJSON() ([]byte, error) {
 	if o.UpdatedAt != nil {
 		toSerialize["updatedAt"] = o.UpdatedAt
 	}
+	if o.RequestCount != nil {
+		toSerialize["requestCount"] = o.RequestCount
+	}
+	if o.Scopes != nil {
+		toSerialize["scopes"] = o.Scopes
+	}
 	return json.Marshal(toSerialize)
 }
 
@@ -163,12 +163,17 @@ func (handler ConfigMapRestHandlerImpl) CMEnvironmentAddUpdate(w http.ResponseWr
 	}
 	//RBAC END
 
-	res, err := handler.configMapService.CMEnvironmentAddUpdate(&configMapRequest)
+	res, draft, err := handler.configMapService.CMEnvironmentAddUpdate(&configMapRequest)
 	if err != nil {
 		handler.Logger.Errorw("service err, CMEnvironmentAddUpdate", "err", err, "payload", configMapRequest)
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	if draft != nil {
+		// the config is protected, the change is saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
 	common.WriteJsonResp(w, err, res, http.StatusOK)
 }
 
@@ -405,12 +410,17 @@ func (handler ConfigMapRestHandlerImpl) CSEnvironmentAddUpdate(w http.ResponseWr
 	}
 	//RBAC END
 
-	res, err := handler.configMapService.CSEnvironmentAddUpdate(&configMapRequest)
+	res, draft, err := handler.configMapService.CSEnvironmentAddUpdate(&configMapRequest)
 	if err != nil {
 		handler.Logger.Errorw("service err, CSEnvironmentAddUpdate", "err", err, "payload", configMapRequest)
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	if draft != nil {
+		// the config is protected, the change is saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
 	common.WriteJsonResp(w, err, res, http.StatusOK)
 }
 
@@ -573,12 +583,17 @@ func (handler ConfigMapRestHandlerImpl) CMEnvironmentDelete(w http.ResponseWrite
 	}
 	//RBAC END
 
-	res, err := handler.configMapService.CMEnvironmentDelete(name, id, userId)
+	res, draft, err := handler.configMapService.CMEnvironmentDelete(name, id, userId)
 	if err != nil {
 		handler.Logger.Errorw("service err, CMEnvironmentDelete", "err", err, "appId", appId, "envId", envId, "id", id)
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	if draft != nil {
+		// the config is protected, the change is saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
 	common.WriteJsonResp(w, err, res, http.StatusOK)
 }
 
@@ -669,12 +684,17 @@ func (handler ConfigMapRestHandlerImpl) CSEnvironmentDelete(w http.ResponseWrite
 	}
 	//RBAC END
 
-	res, err := handler.configMapService.CSEnvironmentDelete(name, id, userId)
+	res, draft, err := handler.configMapService.CSEnvironmentDelete(name, id, userId)
 	if err != nil {
 		handler.Logger.Errorw("service err, CSEnvironmentDelete", "err", err, "appId", appId, "envId", envId, "id", id)
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	if draft != nil {
+		// the config is protected, the change is saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
 	common.WriteJsonResp(w, err, res, http.StatusOK)
 }
 
@@ -1883,10 +1883,12 @@ func (handler CoreAppRestHandlerImpl) createEnvDeploymentTemplate(appId int, use
 	//updating env template override
 	envConfigProperties.Id = env.EnvironmentConfig.Id
 	envConfigProperties.Namespace = env.Namespace
-	_, err = handler.propertiesConfigService.UpdateEnvironmentProperties(appId, envConfigProperties, userId)
+	_, draft, err := handler.propertiesConfigService.UpdateEnvironmentProperties(appId, envConfigProperties, userId)
 	if err != nil {
 		handler.logger.Errorw("service err, EnvConfigOverrideUpdate", "err", err, "appId", appId, "envId", envId)
 		return err
+	} else if draft != nil {
+		handler.logger.Infow("env override saved as a draft as the configs are protected", "appId", appId, "envId", envId, "draftId", draft.Id)
 	}
 	return nil
 }
@@ -1942,10 +1944,12 @@ func (handler CoreAppRestHandlerImpl) createEnvCM(appId int, userId int32, envId
 			ConfigData:    configDataRequest,
 		}
 
-		_, err = handler.configMapService.CMEnvironmentAddUpdate(cmEnvRequest)
+		_, draft, err := handler.configMapService.CMEnvironmentAddUpdate(cmEnvRequest)
 		if err != nil {
 			handler.logger.Errorw("service err, CMEnvironmentAddUpdate in CreateEnvCM", "err", err, "payload", cmEnvRequest)
 			return err
+		} else if draft != nil {
+			handler.logger.Infow("config map override saved as a draft as the configs are protected", "appId", appId, "envId", envId, "draftId", draft.Id)
 		}
 	}
 
@@ -2004,10 +2008,12 @@ func (handler CoreAppRestHandlerImpl) createEnvSecret(appId int, userId int32, e
 			Id:            envLevelId,
 			ConfigData:    secretDataRequest,
 		}
-		_, err = handler.configMapService.CSEnvironmentAddUpdate(secretEnvRequest)
+		_, draft, err := handler.configMapService.CSEnvironmentAddUpdate(secretEnvRequest)
 		if err != nil {
 			handler.logger.Errorw("service err, CSEnvironmentAddUpdate", "err", err, "appId", appId, "envId", envId)
 			return err
+		} else if draft != nil {
+			handler.logger.Infow("secret override saved as a draft as the configs are protected", "appId", appId, "envId", envId, "draftId", draft.Id)
 		}
 	}
 
@@ -18,6 +18,7 @@ package restHandler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -28,6 +29,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
 	deleteService "github.com/devtron-labs/devtron/pkg/delete"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry/registryClient"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
 	util2 "github.com/devtron-labs/devtron/util"
 	"k8s.io/utils/strings/slices"
@@ -56,6 +58,8 @@ type DockerRegRestHandler interface {
 	FetchAllDockerRegistryForAutocomplete(w http.ResponseWriter, r *http.Request)
 	IsDockerRegConfigured(w http.ResponseWriter, r *http.Request)
 	DeleteDockerRegistryConfig(w http.ResponseWriter, r *http.Request)
+	ListRegistryRepositories(w http.ResponseWriter, r *http.Request)
+	ListRegistryRepositoryTags(w http.ResponseWriter, r *http.Request)
 }
 
 type DockerRegRestHandlerExtendedImpl struct {
@@ -147,6 +151,10 @@ func ValidateDockerArtifactStoreRequestBean(bean types.DockerArtifactStoreBean)
 			return fmt.Errorf("Invalid payload! 'ociRegistryConfig[CHART]' has invalid value '%s'.", chartStorageActionType)
 		}
 	}
+	// validating credentials issued by the registry provider
+	if err := registryClient.ValidateCredentials(registryClient.GetRegistryConfig(&bean)); err != nil {
+		return fmt.Errorf("Invalid payload! %s", err.Error())
+	}
 	// validating secure connection configs
 	if (bean.Connection == secureWithCert && bean.Cert == "") ||
 		(bean.Connection != secureWithCert && bean.Cert != "") {
@@ -670,3 +678,52 @@ func (impl DockerRegRestHandlerExtendedImpl) DeleteDockerRegistryConfig(w http.R
 	}
 	common.WriteJsonResp(w, err, REG_DELETE_SUCCESS_RESP, http.StatusOK)
 }
+
+func (impl DockerRegRestHandlerImpl) ListRegistryRepositories(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id := mux.Vars(r)["id"]
+	// registries are picked in the ci template by anyone who can see them, same as the autocomplete
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceDocker, casbin.ActionGet, id); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	res, err := impl.dockerRegistryConfig.ListRepositories(id, r.URL.Query().Get("searchKey"))
+	if err != nil {
+		impl.logger.Errorw("service err, ListRegistryRepositories", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (impl DockerRegRestHandlerImpl) ListRegistryRepositoryTags(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	id := mux.Vars(r)["id"]
+	// repository names contain slashes, they are passed as a query param
+	repositoryName := r.URL.Query().Get("repository")
+	if len(repositoryName) == 0 {
+		common.WriteJsonResp(w, errors.New("repository is required"), nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceDocker, casbin.ActionGet, id); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	res, err := impl.dockerRegistryConfig.ListRepositoryTags(id, repositoryName)
+	if err != nil {
+		impl.logger.Errorw("service err, ListRegistryRepositoryTags", "err", err, "id", id, "repository", repositoryName)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"github.com/devtron-labs/devtron/api/restHandler/common"
 	"github.com/devtron-labs/devtron/internal/sql/repository"
+	util2 "github.com/devtron-labs/devtron/internal/util"
 	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
 	"github.com/devtron-labs/devtron/pkg/cluster"
@@ -43,10 +44,13 @@ import (
 )
 
 const (
-	SLACK_CONFIG_DELETE_SUCCESS_RESP   = "Slack config deleted successfully."
-	WEBHOOK_CONFIG_DELETE_SUCCESS_RESP = "Webhook config deleted successfully."
-	SES_CONFIG_DELETE_SUCCESS_RESP     = "SES config deleted successfully."
-	SMTP_CONFIG_DELETE_SUCCESS_RESP    = "SMTP config deleted successfully."
+	SLACK_CONFIG_DELETE_SUCCESS_RESP      = "Slack config deleted successfully."
+	WEBHOOK_CONFIG_DELETE_SUCCESS_RESP    = "Webhook config deleted successfully."
+	SES_CONFIG_DELETE_SUCCESS_RESP        = "SES config deleted successfully."
+	SMTP_CONFIG_DELETE_SUCCESS_RESP       = "SMTP config deleted successfully."
+	TEAMS_CONFIG_DELETE_SUCCESS_RESP      = "Teams config deleted successfully."
+	DISCORD_CONFIG_DELETE_SUCCESS_RESP    = "Discord config deleted successfully."
+	PAGER_DUTY_CONFIG_DELETE_SUCCESS_RESP = "PagerDuty config deleted successfully."
 )
 
 type NotificationRestHandler interface {
@@ -58,6 +62,9 @@ type NotificationRestHandler interface {
 	FindSMTPConfig(w http.ResponseWriter, r *http.Request)
 	FindWebhookConfig(w http.ResponseWriter, r *http.Request)
 	GetWebhookVariables(w http.ResponseWriter, r *http.Request)
+	FindTeamsConfig(w http.ResponseWriter, r *http.Request)
+	FindDiscordConfig(w http.ResponseWriter, r *http.Request)
+	FindPagerDutyConfig(w http.ResponseWriter, r *http.Request)
 	FindAllNotificationConfig(w http.ResponseWriter, r *http.Request)
 	GetAllNotificationSettings(w http.ResponseWriter, r *http.Request)
 	DeleteNotificationSettings(w http.ResponseWriter, r *http.Request)
@@ -78,6 +85,9 @@ type NotificationRestHandlerImpl struct {
 	webhookService       notifier.WebhookNotificationService
 	sesService           notifier.SESNotificationService
 	smtpService          notifier.SMTPNotificationService
+	teamsService         notifier.TeamsNotificationService
+	discordService       notifier.DiscordNotificationService
+	pagerDutyService     notifier.PagerDutyNotificationService
 	enforcer             casbin.Enforcer
 	teamService          team.TeamService
 	environmentService   cluster.EnvironmentService
@@ -94,6 +104,7 @@ func NewNotificationRestHandlerImpl(dockerRegistryConfig pipeline.DockerRegistry
 	userAuthService user.UserService,
 	validator *validator.Validate, notificationService notifier.NotificationConfigService,
 	slackService notifier.SlackNotificationService, webhookService notifier.WebhookNotificationService, sesService notifier.SESNotificationService, smtpService notifier.SMTPNotificationService,
+	teamsService notifier.TeamsNotificationService, discordService notifier.DiscordNotificationService, pagerDutyService notifier.PagerDutyNotificationService,
 	enforcer casbin.Enforcer, teamService team.TeamService, environmentService cluster.EnvironmentService, pipelineBuilder pipeline.PipelineBuilder,
 	enforcerUtil rbac.EnforcerUtil) *NotificationRestHandlerImpl {
 	return &NotificationRestHandlerImpl{
@@ -107,6 +118,9 @@ func NewNotificationRestHandlerImpl(dockerRegistryConfig pipeline.DockerRegistry
 		webhookService:       webhookService,
 		sesService:           sesService,
 		smtpService:          smtpService,
+		teamsService:         teamsService,
+		discordService:       discordService,
+		pagerDutyService:     pagerDutyService,
 		enforcer:             enforcer,
 		teamService:          teamService,
 		environmentService:   environmentService,
@@ -147,6 +161,10 @@ func (impl NotificationRestHandlerImpl) SaveNotificationSettings(w http.Response
 	res, err := impl.notificationService.CreateOrUpdateNotificationSettings(&notificationSetting, userId)
 	if err != nil {
 		impl.logger.Errorw("service err, SaveNotificationSettings", "err", err, "payload", notificationSetting)
+		if apiErr, ok := err.(*util2.ApiError); ok {
+			common.WriteJsonResp(w, err, nil, apiErr.HttpStatusCode)
+			return
+		}
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
@@ -186,6 +204,10 @@ func (impl NotificationRestHandlerImpl) UpdateNotificationSettings(w http.Respon
 	res, err := impl.notificationService.UpdateNotificationSettings(&notificationSetting, userId)
 	if err != nil {
 		impl.logger.Errorw("service err, UpdateNotificationSettings", "err", err, "payload", notificationSetting)
+		if apiErr, ok := err.(*util2.ApiError); ok {
+			common.WriteJsonResp(w, err, nil, apiErr.HttpStatusCode)
+			return
+		}
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
@@ -415,14 +437,120 @@ func (impl NotificationRestHandlerImpl) SaveNotificationChannelConfig(w http.Res
 		}
 		w.Header().Set("Content-Type", "application/json")
 		common.WriteJsonResp(w, nil, res, http.StatusOK)
+	} else if util.Teams == channelReq.Channel {
+		var teamsReq *beans.TeamsChannelConfig
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&teamsReq)
+		if err != nil {
+			impl.logger.Errorw("request err, SaveNotificationChannelConfig", "err", err, "teamsReq", teamsReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(teamsReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, SaveNotificationChannelConfig", "err", err, "teamsReq", teamsReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		token := r.Header.Get("token")
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		res, cErr := impl.teamsService.SaveOrEditNotificationConfig(teamsReq.TeamsConfigDtos, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, SaveNotificationChannelConfig", "err", cErr, "teamsReq", teamsReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		common.WriteJsonResp(w, nil, res, http.StatusOK)
+	} else if util.Discord == channelReq.Channel {
+		var discordReq *beans.DiscordChannelConfig
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&discordReq)
+		if err != nil {
+			impl.logger.Errorw("request err, SaveNotificationChannelConfig", "err", err, "discordReq", discordReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(discordReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, SaveNotificationChannelConfig", "err", err, "discordReq", discordReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+		for _, config := range discordReq.DiscordConfigDtos {
+			if !beans.IsDiscordWebhookUrl(config.WebhookUrl) {
+				impl.logger.Errorw("validation err, SaveNotificationChannelConfig", "configName", config.ConfigName, "webhookUrl", config.WebhookUrl)
+				common.WriteJsonResp(w, fmt.Errorf("invalid discord webhook url, expected it to start with %s", beans.DISCORD_WEBHOOK_URL), nil, http.StatusBadRequest)
+				return
+			}
+		}
+
+		// RBAC enforcer applying
+		token := r.Header.Get("token")
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		res, cErr := impl.discordService.SaveOrEditNotificationConfig(discordReq.DiscordConfigDtos, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, SaveNotificationChannelConfig", "err", cErr, "discordReq", discordReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		common.WriteJsonResp(w, nil, res, http.StatusOK)
+	} else if util.PagerDuty == channelReq.Channel {
+		var pagerDutyReq *beans.PagerDutyChannelConfig
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&pagerDutyReq)
+		if err != nil {
+			impl.logger.Errorw("request err, SaveNotificationChannelConfig", "err", err, "pagerDutyReq", pagerDutyReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(pagerDutyReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, SaveNotificationChannelConfig", "err", err, "pagerDutyReq", pagerDutyReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		token := r.Header.Get("token")
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		res, cErr := impl.pagerDutyService.SaveOrEditNotificationConfig(pagerDutyReq.PagerDutyConfigDtos, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, SaveNotificationChannelConfig", "err", cErr, "pagerDutyReq", pagerDutyReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		common.WriteJsonResp(w, nil, res, http.StatusOK)
 	}
 }
 
 type ChannelResponseDTO struct {
-	SlackConfigs   []*beans.SlackConfigDto   `json:"slackConfigs"`
-	WebhookConfigs []*beans.WebhookConfigDto `json:"webhookConfigs"`
-	SESConfigs     []*beans.SESConfigDto     `json:"sesConfigs"`
-	SMTPConfigs    []*beans.SMTPConfigDto    `json:"smtpConfigs"`
+	SlackConfigs     []*beans.SlackConfigDto     `json:"slackConfigs"`
+	WebhookConfigs   []*beans.WebhookConfigDto   `json:"webhookConfigs"`
+	SESConfigs       []*beans.SESConfigDto       `json:"sesConfigs"`
+	SMTPConfigs      []*beans.SMTPConfigDto      `json:"smtpConfigs"`
+	TeamsConfigs     []*beans.TeamsConfigDto     `json:"teamsConfigs"`
+	DiscordConfigs   []*beans.DiscordConfigDto   `json:"discordConfigs"`
+	PagerDutyConfigs []*beans.PagerDutyConfigDto `json:"pagerDutyConfigs"`
 }
 
 func (impl NotificationRestHandlerImpl) FindAllNotificationConfig(w http.ResponseWriter, r *http.Request) {
@@ -509,6 +637,33 @@ func (impl NotificationRestHandlerImpl) FindAllNotificationConfig(w http.Respons
 	if pass {
 		channelsResponse.SMTPConfigs = smtpConfigs
 	}
+	teamsConfigs, fErr := impl.teamsService.FetchAllTeamsNotificationConfig()
+	if fErr != nil && fErr != pg.ErrNoRows {
+		impl.logger.Errorw("service err, FindAllNotificationConfig", "err", fErr)
+		common.WriteJsonResp(w, fErr, nil, http.StatusInternalServerError)
+		return
+	}
+	if pass {
+		channelsResponse.TeamsConfigs = teamsConfigs
+	}
+	discordConfigs, fErr := impl.discordService.FetchAllDiscordNotificationConfig()
+	if fErr != nil && fErr != pg.ErrNoRows {
+		impl.logger.Errorw("service err, FindAllNotificationConfig", "err", fErr)
+		common.WriteJsonResp(w, fErr, nil, http.StatusInternalServerError)
+		return
+	}
+	if pass {
+		channelsResponse.DiscordConfigs = discordConfigs
+	}
+	pagerDutyConfigs, fErr := impl.pagerDutyService.FetchAllPagerDutyNotificationConfig()
+	if fErr != nil && fErr != pg.ErrNoRows {
+		impl.logger.Errorw("service err, FindAllNotificationConfig", "err", fErr)
+		common.WriteJsonResp(w, fErr, nil, http.StatusInternalServerError)
+		return
+	}
+	if pass {
+		channelsResponse.PagerDutyConfigs = pagerDutyConfigs
+	}
 	w.Header().Set("Content-Type", "application/json")
 	common.WriteJsonResp(w, fErr, channelsResponse, http.StatusOK)
 }
@@ -618,6 +773,108 @@ func (impl NotificationRestHandlerImpl) FindWebhookConfig(w http.ResponseWriter,
 	w.Header().Set("Content-Type", "application/json")
 	common.WriteJsonResp(w, fErr, webhookConfig, http.StatusOK)
 }
+func (impl NotificationRestHandlerImpl) FindTeamsConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		impl.logger.Errorw("request err, FindTeamsConfig", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionGet, "*"); !ok {
+		response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+		return
+	}
+
+	teamsConfig, fErr := impl.teamsService.FetchTeamsNotificationConfigById(id)
+	if fErr != nil {
+		impl.logger.Errorw("service err, FindTeamsConfig, cannot find teams config", "err", fErr, "id", id)
+		if fErr == pg.ErrNoRows {
+			common.WriteJsonResp(w, fErr, nil, http.StatusNotFound)
+			return
+		}
+		common.WriteJsonResp(w, fErr, nil, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	common.WriteJsonResp(w, nil, teamsConfig, http.StatusOK)
+}
+
+func (impl NotificationRestHandlerImpl) FindDiscordConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		impl.logger.Errorw("request err, FindDiscordConfig", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionGet, "*"); !ok {
+		response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+		return
+	}
+
+	discordConfig, fErr := impl.discordService.FetchDiscordNotificationConfigById(id)
+	if fErr != nil {
+		impl.logger.Errorw("service err, FindDiscordConfig, cannot find discord config", "err", fErr, "id", id)
+		if fErr == pg.ErrNoRows {
+			common.WriteJsonResp(w, fErr, nil, http.StatusNotFound)
+			return
+		}
+		common.WriteJsonResp(w, fErr, nil, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	common.WriteJsonResp(w, nil, discordConfig, http.StatusOK)
+}
+
+func (impl NotificationRestHandlerImpl) FindPagerDutyConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		impl.logger.Errorw("request err, FindPagerDutyConfig", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionGet, "*"); !ok {
+		response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+		return
+	}
+
+	pagerDutyConfig, fErr := impl.pagerDutyService.FetchPagerDutyNotificationConfigById(id)
+	if fErr != nil {
+		impl.logger.Errorw("service err, FindPagerDutyConfig, cannot find pager duty config", "err", fErr, "id", id)
+		if fErr == pg.ErrNoRows {
+			common.WriteJsonResp(w, fErr, nil, http.StatusNotFound)
+			return
+		}
+		common.WriteJsonResp(w, fErr, nil, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	common.WriteJsonResp(w, nil, pagerDutyConfig, http.StatusOK)
+}
+
 func (impl NotificationRestHandlerImpl) GetWebhookVariables(w http.ResponseWriter, r *http.Request) {
 	userId, err := impl.userAuthService.GetLoggedInUser(r)
 	if userId == 0 || err != nil {
@@ -734,6 +991,27 @@ func (impl NotificationRestHandlerImpl) FindAllNotificationConfigAutocomplete(w
 			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 			return
 		}
+	} else if cType == string(util.Teams) {
+		channelsResponse, err = impl.teamsService.FetchAllTeamsNotificationConfigAutocomplete()
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("service err, FindAllNotificationConfigAutocomplete", "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+			return
+		}
+	} else if cType == string(util.Discord) {
+		channelsResponse, err = impl.discordService.FetchAllDiscordNotificationConfigAutocomplete()
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("service err, FindAllNotificationConfigAutocomplete", "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+			return
+		}
+	} else if cType == string(util.PagerDuty) {
+		channelsResponse, err = impl.pagerDutyService.FetchAllPagerDutyNotificationConfigAutocomplete()
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("service err, FindAllNotificationConfigAutocomplete", "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+			return
+		}
 	}
 	if channelsResponse == nil {
 		channelsResponse = make([]*beans.NotificationChannelAutoResponse, 0)
@@ -856,6 +1134,99 @@ func (impl NotificationRestHandlerImpl) DeleteNotificationChannelConfig(w http.R
 			return
 		}
 		common.WriteJsonResp(w, nil, WEBHOOK_CONFIG_DELETE_SUCCESS_RESP, http.StatusOK)
+	} else if util.Teams == channelReq.Channel {
+		var deleteReq *beans.TeamsConfigDto
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&deleteReq)
+		if err != nil {
+			impl.logger.Errorw("request err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(deleteReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		token := r.Header.Get("token")
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		cErr := impl.teamsService.DeleteNotificationConfig(deleteReq, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, DeleteNotificationChannelConfig", "err", cErr, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		common.WriteJsonResp(w, nil, TEAMS_CONFIG_DELETE_SUCCESS_RESP, http.StatusOK)
+	} else if util.Discord == channelReq.Channel {
+		var deleteReq *beans.DiscordConfigDto
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&deleteReq)
+		if err != nil {
+			impl.logger.Errorw("request err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(deleteReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		token := r.Header.Get("token")
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		cErr := impl.discordService.DeleteNotificationConfig(deleteReq, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, DeleteNotificationChannelConfig", "err", cErr, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		common.WriteJsonResp(w, nil, DISCORD_CONFIG_DELETE_SUCCESS_RESP, http.StatusOK)
+	} else if util.PagerDuty == channelReq.Channel {
+		var deleteReq *beans.PagerDutyConfigDto
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&deleteReq)
+		if err != nil {
+			impl.logger.Errorw("request err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(deleteReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		token := r.Header.Get("token")
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		cErr := impl.pagerDutyService.DeleteNotificationConfig(deleteReq, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, DeleteNotificationChannelConfig", "err", cErr, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		common.WriteJsonResp(w, nil, PAGER_DUTY_CONFIG_DELETE_SUCCESS_RESP, http.StatusOK)
 	} else if util.SES == channelReq.Channel {
 		var deleteReq *beans.SESConfigDto
 		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&deleteReq)
@@ -638,16 +638,21 @@ func (handler *PipelineConfigRestHandlerImpl) ChangeChartRef(w http.ResponseWrit
 	envConfigPropertiesOld, err := handler.propertiesConfigService.FetchEnvProperties(request.AppId, request.EnvId, request.TargetChartRefId)
 	if err == nil {
 		envConfigProperties.Id = envConfigPropertiesOld.Id
-		createResp, err := handler.propertiesConfigService.UpdateEnvironmentProperties(request.AppId, envConfigProperties, userId)
+		createResp, draft, err := handler.propertiesConfigService.UpdateEnvironmentProperties(request.AppId, envConfigProperties, userId)
 		if err != nil {
 			handler.Logger.Errorw("service err, EnvConfigOverrideUpdate", "err", err, "payload", envConfigProperties)
 			common.WriteJsonResp(w, err, createResp, http.StatusInternalServerError)
 			return
 		}
+		if draft != nil {
+			// the config is protected, the change is saved as a draft to be reviewed
+			common.WriteJsonResp(w, nil, draft, http.StatusOK)
+			return
+		}
 		common.WriteJsonResp(w, err, createResp, http.StatusOK)
 		return
 	}
-	createResp, err := handler.propertiesConfigService.CreateEnvironmentProperties(request.AppId, envConfigProperties)
+	createResp, draft, err := handler.propertiesConfigService.CreateEnvironmentProperties(request.AppId, envConfigProperties)
 
 	if err != nil {
 		if err.Error() == bean2.NOCHARTEXIST {
@@ -686,20 +691,23 @@ func (handler *PipelineConfigRestHandlerImpl) ChangeChartRef(w http.ResponseWrit
 				common.WriteJsonResp(w, err, "could not create chart from env override", http.StatusInternalServerError)
 				return
 			}
-			createResp, err = handler.propertiesConfigService.CreateEnvironmentProperties(request.AppId, envConfigProperties)
+			createResp, draft, err = handler.propertiesConfigService.CreateEnvironmentProperties(request.AppId, envConfigProperties)
 			if err != nil {
 				handler.Logger.Errorw("service err, CreateEnvironmentProperties", "err", err, "payload", request)
 				common.WriteJsonResp(w, err, "could not create env properties", http.StatusInternalServerError)
 				return
 			}
-			common.WriteJsonResp(w, err, createResp, http.StatusOK)
-			return
 		} else {
 			handler.Logger.Errorw("service err, EnvConfigOverrideCreate", "err", err, "payload", request)
 			common.WriteJsonResp(w, err, "service err, EnvConfigOverrideCreate", http.StatusInternalServerError)
 			return
 		}
 	}
+	if draft != nil {
+		// the config is protected, the change is saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
 	common.WriteJsonResp(w, err, createResp, http.StatusOK)
 }
 
@@ -757,7 +765,7 @@ func (handler *PipelineConfigRestHandlerImpl) EnvConfigOverrideCreate(w http.Res
 		return
 	}
 
-	createResp, err := handler.propertiesConfigService.CreateEnvironmentProperties(appId, &envConfigProperties)
+	createResp, draft, err := handler.propertiesConfigService.CreateEnvironmentProperties(appId, &envConfigProperties)
 	if err != nil {
 		if err.Error() == bean2.NOCHARTEXIST {
 			ctx, cancel := context.WithCancel(r.Context())
@@ -795,7 +803,7 @@ func (handler *PipelineConfigRestHandlerImpl) EnvConfigOverrideCreate(w http.Res
 				common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 				return
 			}
-			createResp, err = handler.propertiesConfigService.CreateEnvironmentProperties(appId, &envConfigProperties)
+			createResp, draft, err = handler.propertiesConfigService.CreateEnvironmentProperties(appId, &envConfigProperties)
 			if err != nil {
 				handler.Logger.Errorw("service err, EnvConfigOverrideCreate", "err", err, "payload", envConfigProperties)
 				common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
@@ -807,6 +815,11 @@ func (handler *PipelineConfigRestHandlerImpl) EnvConfigOverrideCreate(w http.Res
 			return
 		}
 	}
+	if draft != nil {
+		// the config is protected, the change is saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
 	common.WriteJsonResp(w, err, createResp, http.StatusOK)
 }
 
@@ -866,12 +879,17 @@ func (handler *PipelineConfigRestHandlerImpl) EnvConfigOverrideUpdate(w http.Res
 		return
 	}
 
-	createResp, err := handler.propertiesConfigService.UpdateEnvironmentProperties(appId, &envConfigProperties, userId)
+	createResp, draft, err := handler.propertiesConfigService.UpdateEnvironmentProperties(appId, &envConfigProperties, userId)
 	if err != nil {
 		handler.Logger.Errorw("service err, EnvConfigOverrideUpdate", "err", err, "payload", envConfigProperties)
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	if draft != nil {
+		// the config is protected, the change is saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
 	common.WriteJsonResp(w, err, createResp, http.StatusOK)
 }
 
@@ -1658,12 +1676,17 @@ func (handler *PipelineConfigRestHandlerImpl) EnvConfigOverrideReset(w http.Resp
 		common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
 		return
 	}
-	isSuccess, err := handler.propertiesConfigService.ResetEnvironmentProperties(id)
+	isSuccess, draft, err := handler.propertiesConfigService.ResetEnvironmentProperties(id, userId)
 	if err != nil {
 		handler.Logger.Errorw("service err, EnvConfigOverrideReset", "err", err, "appId", appId, "environmentId", environmentId)
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	if draft != nil {
+		// the config is protected, the reset is saved as a draft to be reviewed
+		common.WriteJsonResp(w, nil, draft, http.StatusOK)
+		return
+	}
 	common.WriteJsonResp(w, err, isSuccess, http.StatusOK)
 }
 
@@ -133,6 +133,10 @@ func (handler PipelineTriggerRestHandlerImpl) OverrideConfig(w http.ResponseWrit
 		return
 	}
 	//rback block ends here
+	if len(overrideRequest.DeploymentWindowOverrideReason) > 0 {
+		// only super admins can deploy through an active blackout or outside maintenance windows
+		overrideRequest.IsDeploymentWindowOverrideAllowed = handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionUpdate, "*")
+	}
 	acdToken, err := handler.argoUserService.GetLatestDevtronArgoCdUserToken()
 	if err != nil {
 		handler.logger.Errorw("error in getting acd token", "err", err)
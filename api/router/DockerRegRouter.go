@@ -47,6 +47,12 @@ func (impl DockerRegRouterImpl) InitDockerRegRouter(configRouter *mux.Router) {
 	configRouter.Path("/registry/autocomplete").
 		HandlerFunc(impl.dockerRestHandler.FetchAllDockerRegistryForAutocomplete).
 		Methods("GET")
+	configRouter.Path("/registry/{id}/repositories").
+		HandlerFunc(impl.dockerRestHandler.ListRegistryRepositories).
+		Methods("GET")
+	configRouter.Path("/registry/{id}/repositories/tags").
+		HandlerFunc(impl.dockerRestHandler.ListRegistryRepositoryTags).
+		Methods("GET")
 	configRouter.Path("/registry/{id}").
 		HandlerFunc(impl.dockerRestHandler.FetchOneDockerAccounts).
 		Methods("GET")
@@ -65,6 +65,15 @@ func (impl NotificationRouterImpl) InitNotificationRegRouter(configRouter *mux.R
 	configRouter.Path("/channel/webhook/{id}").
 		HandlerFunc(impl.notificationRestHandler.FindWebhookConfig).
 		Methods("GET")
+	configRouter.Path("/channel/teams/{id}").
+		HandlerFunc(impl.notificationRestHandler.FindTeamsConfig).
+		Methods("GET")
+	configRouter.Path("/channel/discord/{id}").
+		HandlerFunc(impl.notificationRestHandler.FindDiscordConfig).
+		Methods("GET")
+	configRouter.Path("/channel/pagerduty/{id}").
+		HandlerFunc(impl.notificationRestHandler.FindPagerDutyConfig).
+		Methods("GET")
 	configRouter.Path("/variables").
 		HandlerFunc(impl.notificationRestHandler.GetWebhookVariables).
 		Methods("GET")
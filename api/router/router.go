@@ -23,16 +23,32 @@ import (
 	"github.com/devtron-labs/devtron/api/appStore/chartGroup"
 	appStoreDeployment "github.com/devtron-labs/devtron/api/appStore/deployment"
 	"github.com/devtron-labs/devtron/api/argoApplication"
+	"github.com/devtron-labs/devtron/api/artifactPromotion"
+	"github.com/devtron-labs/devtron/api/artifactProvenance"
+	"github.com/devtron-labs/devtron/api/auth/jitAccess"
+	"github.com/devtron-labs/devtron/api/auth/scim"
 	"github.com/devtron-labs/devtron/api/auth/sso"
 	"github.com/devtron-labs/devtron/api/auth/user"
+	"github.com/devtron-labs/devtron/api/autoRollback"
+	"github.com/devtron-labs/devtron/api/canaryAnalysis"
+	"github.com/devtron-labs/devtron/api/cdSchedule"
 	"github.com/devtron-labs/devtron/api/chartRepo"
 	"github.com/devtron-labs/devtron/api/cluster"
+	"github.com/devtron-labs/devtron/api/configDraft"
+	"github.com/devtron-labs/devtron/api/cveException"
 	"github.com/devtron-labs/devtron/api/dashboardEvent"
 	"github.com/devtron-labs/devtron/api/deployment"
+	"github.com/devtron-labs/devtron/api/deploymentApproval"
+	"github.com/devtron-labs/devtron/api/deploymentWindow"
 	"github.com/devtron-labs/devtron/api/devtronResource"
 	"github.com/devtron-labs/devtron/api/externalLink"
 	fluxApplication2 "github.com/devtron-labs/devtron/api/fluxApplication"
+	"github.com/devtron-labs/devtron/api/gitOpsDrift"
+	"github.com/devtron-labs/devtron/api/gitOpsMonorepo"
 	client "github.com/devtron-labs/devtron/api/helm-app"
+	"github.com/devtron-labs/devtron/api/hibernationPolicy"
+	"github.com/devtron-labs/devtron/api/imageRetention"
+	"github.com/devtron-labs/devtron/api/imageSignature"
 	"github.com/devtron-labs/devtron/api/infraConfig"
 	"github.com/devtron-labs/devtron/api/k8s/application"
 	"github.com/devtron-labs/devtron/api/k8s/capacity"
@@ -119,6 +135,35 @@ type MuxRouter struct {
 	argoApplicationRouter              argoApplication.ArgoApplicationRouter
 	fluxApplicationRouter              fluxApplication2.FluxApplicationRouter
 	devtronResourceRouter              devtronResource.DevtronResourceRouter
+	deploymentWindowRouter             deploymentWindow.DeploymentWindowRouter
+	canaryAnalysisRouter               canaryAnalysis.CanaryAnalysisRouter
+	autoRollbackPolicyRouter           autoRollback.AutoRollbackPolicyRouter
+	notificationDigestCron             cron.NotificationDigestCron
+	cdTriggerScheduleCron              cron.CdTriggerScheduleCron
+	hibernationPolicyCron              cron.HibernationPolicyCron
+	gitOpsPullRequestCron              cron.GitOpsPullRequestCron
+	gitOpsDriftCron                    cron.GitOpsDriftCron
+	canaryAnalysisCron                 cron.CanaryAnalysisCron
+	imageRetentionCron                 cron.ImageRetentionCron
+	cveExceptionCron                   cron.CveExceptionCron
+	terminalRecordingCron              cron.TerminalRecordingCron
+	deploymentApprovalRouter           deploymentApproval.DeploymentApprovalRouter
+	configDraftRouter                  configDraft.ConfigDraftRouter
+	cdTriggerScheduleRouter            cdSchedule.CdTriggerScheduleRouter
+	hibernationPolicyRouter            hibernationPolicy.HibernationPolicyRouter
+	gitOpsMonorepoRouter               gitOpsMonorepo.GitOpsMonorepoRouter
+	gitOpsDriftRouter                  gitOpsDrift.GitOpsDriftRouter
+	imageRetentionRouter               imageRetention.ImageRetentionRouter
+	artifactPromotionRouter            artifactPromotion.ArtifactPromotionRouter
+	artifactProvenanceRouter           artifactProvenance.ArtifactProvenanceRouter
+	imageSignatureRouter               imageSignature.ImageSignatureRouter
+	cveExceptionRouter                 cveException.CveExceptionRouter
+	terminalRecordingRouter            terminal2.TerminalRecordingRouter
+	jitAccessRouter                    jitAccess.JitAccessRouter
+	jitAccessCron                      cron.JitAccessCron
+	scimRouter                         scim.ScimRouter
+	ssoGroupMappingRouter              user.SsoGroupMappingRouter
+	apiTokenExpiryCron                 cron.ApiTokenExpiryCron
 }
 
 func NewMuxRouter(logger *zap.SugaredLogger,
@@ -152,7 +197,36 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 	argoApplicationRouter argoApplication.ArgoApplicationRouter,
 	devtronResourceRouter devtronResource.DevtronResourceRouter,
 	fluxApplicationRouter fluxApplication2.FluxApplicationRouter,
-	) *MuxRouter {
+	deploymentWindowRouter deploymentWindow.DeploymentWindowRouter,
+	canaryAnalysisRouter canaryAnalysis.CanaryAnalysisRouter,
+	autoRollbackPolicyRouter autoRollback.AutoRollbackPolicyRouter,
+	notificationDigestCron cron.NotificationDigestCron,
+	cdTriggerScheduleCron cron.CdTriggerScheduleCron,
+	hibernationPolicyCron cron.HibernationPolicyCron,
+	gitOpsPullRequestCron cron.GitOpsPullRequestCron,
+	gitOpsDriftCron cron.GitOpsDriftCron,
+	canaryAnalysisCron cron.CanaryAnalysisCron,
+	imageRetentionCron cron.ImageRetentionCron,
+	cveExceptionCron cron.CveExceptionCron,
+	terminalRecordingCron cron.TerminalRecordingCron,
+	deploymentApprovalRouter deploymentApproval.DeploymentApprovalRouter,
+	configDraftRouter configDraft.ConfigDraftRouter,
+	cdTriggerScheduleRouter cdSchedule.CdTriggerScheduleRouter,
+	hibernationPolicyRouter hibernationPolicy.HibernationPolicyRouter,
+	gitOpsMonorepoRouter gitOpsMonorepo.GitOpsMonorepoRouter,
+	gitOpsDriftRouter gitOpsDrift.GitOpsDriftRouter,
+	imageRetentionRouter imageRetention.ImageRetentionRouter,
+	artifactPromotionRouter artifactPromotion.ArtifactPromotionRouter,
+	artifactProvenanceRouter artifactProvenance.ArtifactProvenanceRouter,
+	imageSignatureRouter imageSignature.ImageSignatureRouter,
+	cveExceptionRouter cveException.CveExceptionRouter,
+	terminalRecordingRouter terminal2.TerminalRecordingRouter,
+	jitAccessRouter jitAccess.JitAccessRouter,
+	jitAccessCron cron.JitAccessCron,
+	scimRouter scim.ScimRouter,
+	ssoGroupMappingRouter user.SsoGroupMappingRouter,
+	apiTokenExpiryCron cron.ApiTokenExpiryCron,
+) *MuxRouter {
 	r := &MuxRouter{
 		Router:                             mux.NewRouter(),
 		EnvironmentClusterMappingsRouter:   EnvironmentClusterMappingsRouter,
@@ -217,6 +291,35 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 		argoApplicationRouter:              argoApplicationRouter,
 		devtronResourceRouter:              devtronResourceRouter,
 		fluxApplicationRouter:              fluxApplicationRouter,
+		deploymentWindowRouter:             deploymentWindowRouter,
+		canaryAnalysisRouter:               canaryAnalysisRouter,
+		autoRollbackPolicyRouter:           autoRollbackPolicyRouter,
+		notificationDigestCron:             notificationDigestCron,
+		cdTriggerScheduleCron:              cdTriggerScheduleCron,
+		hibernationPolicyCron:              hibernationPolicyCron,
+		gitOpsPullRequestCron:              gitOpsPullRequestCron,
+		gitOpsDriftCron:                    gitOpsDriftCron,
+		canaryAnalysisCron:                 canaryAnalysisCron,
+		imageRetentionCron:                 imageRetentionCron,
+		cveExceptionCron:                   cveExceptionCron,
+		terminalRecordingCron:              terminalRecordingCron,
+		deploymentApprovalRouter:           deploymentApprovalRouter,
+		configDraftRouter:                  configDraftRouter,
+		cdTriggerScheduleRouter:            cdTriggerScheduleRouter,
+		hibernationPolicyRouter:            hibernationPolicyRouter,
+		gitOpsMonorepoRouter:               gitOpsMonorepoRouter,
+		gitOpsDriftRouter:                  gitOpsDriftRouter,
+		imageRetentionRouter:               imageRetentionRouter,
+		artifactPromotionRouter:            artifactPromotionRouter,
+		artifactProvenanceRouter:           artifactProvenanceRouter,
+		imageSignatureRouter:               imageSignatureRouter,
+		cveExceptionRouter:                 cveExceptionRouter,
+		terminalRecordingRouter:            terminalRecordingRouter,
+		jitAccessRouter:                    jitAccessRouter,
+		jitAccessCron:                      jitAccessCron,
+		scimRouter:                         scimRouter,
+		ssoGroupMappingRouter:              ssoGroupMappingRouter,
+		apiTokenExpiryCron:                 apiTokenExpiryCron,
 	}
 	return r
 }
@@ -428,4 +531,58 @@ func (r MuxRouter) Init() {
 
 	fluxApplicationRouter := r.Router.PathPrefix("/orchestrator/flux-application").Subrouter()
 	r.fluxApplicationRouter.InitFluxApplicationRouter(fluxApplicationRouter)
+
+	deploymentWindowRouter := r.Router.PathPrefix("/orchestrator/deployment-window").Subrouter()
+	r.deploymentWindowRouter.InitDeploymentWindowRouter(deploymentWindowRouter)
+
+	canaryAnalysisRouter := r.Router.PathPrefix("/orchestrator/canary-analysis").Subrouter()
+	r.canaryAnalysisRouter.InitCanaryAnalysisRouter(canaryAnalysisRouter)
+
+	autoRollbackPolicyRouter := r.Router.PathPrefix("/orchestrator/auto-rollback-policy").Subrouter()
+	r.autoRollbackPolicyRouter.InitAutoRollbackPolicyRouter(autoRollbackPolicyRouter)
+
+	deploymentApprovalRouter := r.Router.PathPrefix("/orchestrator/deployment-approval").Subrouter()
+	r.deploymentApprovalRouter.InitDeploymentApprovalRouter(deploymentApprovalRouter)
+
+	configDraftRouter := r.Router.PathPrefix("/orchestrator/config-draft").Subrouter()
+	r.configDraftRouter.InitConfigDraftRouter(configDraftRouter)
+
+	cdTriggerScheduleRouter := r.Router.PathPrefix("/orchestrator/cd-schedule").Subrouter()
+	r.cdTriggerScheduleRouter.InitCdTriggerScheduleRouter(cdTriggerScheduleRouter)
+
+	hibernationPolicyRouter := r.Router.PathPrefix("/orchestrator/hibernation-policy").Subrouter()
+	r.hibernationPolicyRouter.InitHibernationPolicyRouter(hibernationPolicyRouter)
+
+	gitOpsMonorepoRouter := r.Router.PathPrefix("/orchestrator/gitops-monorepo").Subrouter()
+	r.gitOpsMonorepoRouter.InitGitOpsMonorepoRouter(gitOpsMonorepoRouter)
+
+	gitOpsDriftRouter := r.Router.PathPrefix("/orchestrator/gitops-drift").Subrouter()
+	r.gitOpsDriftRouter.InitGitOpsDriftRouter(gitOpsDriftRouter)
+
+	imageRetentionRouter := r.Router.PathPrefix("/orchestrator/image-retention").Subrouter()
+	r.imageRetentionRouter.InitImageRetentionRouter(imageRetentionRouter)
+
+	artifactPromotionRouter := r.Router.PathPrefix("/orchestrator/artifact-promotion").Subrouter()
+	r.artifactPromotionRouter.InitArtifactPromotionRouter(artifactPromotionRouter)
+
+	artifactProvenanceRouter := r.Router.PathPrefix("/orchestrator/artifact-provenance").Subrouter()
+	r.artifactProvenanceRouter.InitArtifactProvenanceRouter(artifactProvenanceRouter)
+
+	imageSignatureRouter := r.Router.PathPrefix("/orchestrator/image-signature").Subrouter()
+	r.imageSignatureRouter.InitImageSignatureRouter(imageSignatureRouter)
+
+	cveExceptionRouter := r.Router.PathPrefix("/orchestrator/security/cve-exception").Subrouter()
+	r.cveExceptionRouter.InitCveExceptionRouter(cveExceptionRouter)
+
+	terminalRecordingRouter := r.Router.PathPrefix("/orchestrator/terminal/recording").Subrouter()
+	r.terminalRecordingRouter.InitTerminalRecordingRouter(terminalRecordingRouter)
+
+	jitAccessRouter := r.Router.PathPrefix("/orchestrator/jit-access").Subrouter()
+	r.jitAccessRouter.InitJitAccessRouter(jitAccessRouter)
+
+	scimRouter := r.Router.PathPrefix("/orchestrator/scim/v2").Subrouter()
+	r.scimRouter.InitScimRouter(scimRouter)
+
+	ssoGroupMappingRouter := r.Router.PathPrefix("/orchestrator/sso-group-mapping").Subrouter()
+	r.ssoGroupMappingRouter.InitSsoGroupMappingRouter(ssoGroupMappingRouter)
 }
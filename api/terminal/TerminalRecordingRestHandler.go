@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/bean"
+	"go.uber.org/zap"
+)
+
+const defaultRecordingsPageSize = 20
+
+type TerminalRecordingRestHandler interface {
+	GetRecordings(w http.ResponseWriter, r *http.Request)
+	GetRecording(w http.ResponseWriter, r *http.Request)
+	DownloadRecording(w http.ResponseWriter, r *http.Request)
+	GetReplay(w http.ResponseWriter, r *http.Request)
+}
+
+type TerminalRecordingRestHandlerImpl struct {
+	logger                   *zap.SugaredLogger
+	terminalRecordingService terminalRecording.TerminalRecordingService
+	userService              user.UserService
+	enforcer                 casbin.Enforcer
+}
+
+func NewTerminalRecordingRestHandlerImpl(logger *zap.SugaredLogger, terminalRecordingService terminalRecording.TerminalRecordingService,
+	userService user.UserService, enforcer casbin.Enforcer) *TerminalRecordingRestHandlerImpl {
+	return &TerminalRecordingRestHandlerImpl{
+		logger:                   logger,
+		terminalRecordingService: terminalRecordingService,
+		userService:              userService,
+		enforcer:                 enforcer,
+	}
+}
+
+func (handler *TerminalRecordingRestHandlerImpl) GetRecordings(w http.ResponseWriter, r *http.Request) {
+	if !handler.enforceAccess(w, r) {
+		return
+	}
+	userId, err := common.ExtractIntQueryParam(w, r, "userId", 0)
+	if err != nil {
+		return
+	}
+	clusterId, err := common.ExtractIntQueryParam(w, r, "clusterId", 0)
+	if err != nil {
+		return
+	}
+	offset, err := common.ExtractIntQueryParam(w, r, "offset", 0)
+	if err != nil {
+		return
+	}
+	size, err := common.ExtractIntQueryParam(w, r, "size", defaultRecordingsPageSize)
+	if err != nil {
+		return
+	}
+	from, err := parseTimeQueryParam(r, "from")
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	to, err := parseTimeQueryParam(r, "to")
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	filter := &bean.RecordingFilter{
+		UserId:    int32(userId),
+		ClusterId: clusterId,
+		Namespace: r.URL.Query().Get("namespace"),
+		PodName:   r.URL.Query().Get("podName"),
+		From:      from,
+		To:        to,
+		Offset:    offset,
+		Size:      size,
+	}
+	resp, err := handler.terminalRecordingService.GetRecordings(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRecordings", "filter", filter, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *TerminalRecordingRestHandlerImpl) GetRecording(w http.ResponseWriter, r *http.Request) {
+	if !handler.enforceAccess(w, r) {
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	resp, err := handler.terminalRecordingService.GetRecording(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRecording", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *TerminalRecordingRestHandlerImpl) DownloadRecording(w http.ResponseWriter, r *http.Request) {
+	if !handler.enforceAccess(w, r) {
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	recording, err := handler.terminalRecordingService.GetRecording(id)
+	if err != nil {
+		handler.logger.Errorw("service err, DownloadRecording", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	file, err := handler.terminalRecordingService.DownloadRecording(id)
+	if err != nil {
+		handler.logger.Errorw("service err, DownloadRecording", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+	w.Header().Set("Content-Disposition", "attachment; filename="+fmt.Sprintf(bean.CastFileFormat, recording.SessionId))
+	w.Header().Set("Content-Type", bean.CastContentType)
+	_, err = io.Copy(w, file)
+	if err != nil {
+		handler.logger.Errorw("service err, DownloadRecording", "id", id, "err", err)
+	}
+}
+
+func (handler *TerminalRecordingRestHandlerImpl) GetReplay(w http.ResponseWriter, r *http.Request) {
+	if !handler.enforceAccess(w, r) {
+		return
+	}
+	id, err := common.ExtractIntPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	resp, err := handler.terminalRecordingService.GetReplay(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetReplay", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+// enforceAccess allows the recordings to super admins only as they span the terminal sessions of all clusters, writing the response when denied
+func (handler *TerminalRecordingRestHandlerImpl) enforceAccess(w http.ResponseWriter, r *http.Request) bool {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return false
+	}
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func parseTimeQueryParam(r *http.Request, paramName string) (time.Time, error) {
+	value := r.URL.Query().Get(paramName)
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s, expected RFC3339 time", paramName)
+	}
+	return parsed, nil
+}
@@ -32,4 +32,9 @@ var TerminalWireSet = wire.NewSet(
 	wire.Bind(new(clusterTerminalAccess.UserTerminalAccessService), new(*clusterTerminalAccess.UserTerminalAccessServiceImpl)),
 	repository.NewTerminalAccessRepositoryImpl,
 	wire.Bind(new(repository.TerminalAccessRepository), new(*repository.TerminalAccessRepositoryImpl)),
+
+	NewTerminalRecordingRouterImpl,
+	wire.Bind(new(TerminalRecordingRouter), new(*TerminalRecordingRouterImpl)),
+	NewTerminalRecordingRestHandlerImpl,
+	wire.Bind(new(TerminalRecordingRestHandler), new(*TerminalRecordingRestHandlerImpl)),
 )
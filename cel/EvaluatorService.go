@@ -99,6 +99,8 @@ func getDeclarationType(paramType ParamValuesType) (*expr.Type, error) {
 		return decls.NewListType(decls.String), nil
 	case ParamTypeMapStringToAny:
 		return decls.NewMapType(decls.String, decls.Dyn), nil
+	case ParamTypeTimestamp:
+		return decls.Timestamp, nil
 	default:
 		return nil, fmt.Errorf("unsupported parameter type: %s", paramType)
 	}
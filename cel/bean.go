@@ -9,6 +9,7 @@ const (
 	ParamTypeList           ParamValuesType = "list"
 	ParamTypeBool           ParamValuesType = "bool"
 	ParamTypeMapStringToAny ParamValuesType = "mapStringToAny"
+	ParamTypeTimestamp      ParamValuesType = "timestamp"
 )
 
 type ParamName string
@@ -25,6 +26,15 @@ const ContainerRepo ParamName = "containerRepository"
 const ContainerImage ParamName = "containerImage"
 const ContainerImageTag ParamName = "containerImageTag"
 const ImageLabels ParamName = "imageLabels"
+const EventType ParamName = "eventType"
+const PipelineType ParamName = "pipelineType"
+const PipelineName ParamName = "pipelineName"
+const Stage ParamName = "stage"
+const Branches ParamName = "branches"
+const TriggeredBy ParamName = "triggeredBy"
+const DurationSeconds ParamName = "durationSeconds"
+const FailureReason ParamName = "failureReason"
+const EventTime ParamName = "eventTime"
 
 type Request struct {
 	Expression         string             `json:"expression"`
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/pkg/apiToken"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	util "github.com/devtron-labs/devtron/util/event"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const apiTokenExpiryNotificationLease = "api-token-expiry-notification"
+
+type ApiTokenExpiryCron interface {
+	NotifyExpiringApiTokens()
+}
+
+type ApiTokenExpiryCronImpl struct {
+	logger                    *zap.SugaredLogger
+	cron                      *cron.Cron
+	cfg                       *ApiTokenExpiryCronConfig
+	apiTokenService           apiToken.ApiTokenService
+	leaderElectionService     leaderElection.LeaderElectionService
+	channelNotificationSender client.ChannelNotificationSender
+	channels                  []*apiTokenExpiryChannel
+}
+
+// apiTokenExpiryChannel is a notification channel config the expiry notifications are sent to
+type apiTokenExpiryChannel struct {
+	destination util.Channel
+	configId    int
+}
+
+func NewApiTokenExpiryCronImpl(logger *zap.SugaredLogger, cfg *ApiTokenExpiryCronConfig,
+	apiTokenService apiToken.ApiTokenService, leaderElectionService leaderElection.LeaderElectionService,
+	channelNotificationSender client.ChannelNotificationSender, cronLogger *cron2.CronLoggerImpl) *ApiTokenExpiryCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &ApiTokenExpiryCronImpl{
+		logger:                    logger,
+		cron:                      cron,
+		cfg:                       cfg,
+		apiTokenService:           apiTokenService,
+		leaderElectionService:     leaderElectionService,
+		channelNotificationSender: channelNotificationSender,
+		channels:                  parseApiTokenExpiryChannels(logger, cfg.ApiTokenExpiryNotificationChannels),
+	}
+	if len(impl.channels) == 0 {
+		logger.Infow("no notification channel configured for api token expiry, skipping expiry notifications")
+		return impl
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.ApiTokenExpiryCronTime), impl.NotifyExpiringApiTokens)
+	if err != nil {
+		logger.Errorw("error while configure cron job for api token expiry notification", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type ApiTokenExpiryCronConfig struct {
+	ApiTokenExpiryCronTime int `env:"API_TOKEN_EXPIRY_NOTIFICATION_CRON_TIME" envDefault:"60"`
+	// ApiTokenExpiryNotificationChannels are the notification channel configs to notify as <channel>:<configId>, e.g. slack:1,teams:4
+	ApiTokenExpiryNotificationChannels []string `env:"API_TOKEN_EXPIRY_NOTIFICATION_CHANNELS" envSeparator:","`
+}
+
+func GetApiTokenExpiryCronConfig() (*ApiTokenExpiryCronConfig, error) {
+	cfg := &ApiTokenExpiryCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse api token expiry cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *ApiTokenExpiryCronImpl) NotifyExpiringApiTokens() {
+	leaseDuration := 2 * time.Duration(impl.cfg.ApiTokenExpiryCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(apiTokenExpiryNotificationLease, leaseDuration) {
+		return
+	}
+	notifications, err := impl.apiTokenService.GetExpiringApiTokens()
+	if err != nil {
+		return
+	}
+	notifiedIds := make([]int, 0, len(notifications))
+	for _, notification := range notifications {
+		impl.logger.Warnw("api token is about to expire", "apiTokenId", notification.ApiTokenId, "name", notification.Name, "owner", notification.Owner, "expireAtInMs", notification.ExpireAtInMs)
+		if impl.sendExpiryNotification(notification) {
+			notifiedIds = append(notifiedIds, notification.ApiTokenId)
+		}
+	}
+	if len(notifiedIds) == 0 {
+		return
+	}
+	_ = impl.apiTokenService.MarkExpiryNotified(notifiedIds)
+}
+
+// sendExpiryNotification returns true when the notification reached at least one channel, the token is notified
+// again on the next run only when no channel accepted it
+func (impl *ApiTokenExpiryCronImpl) sendExpiryNotification(notification *apiToken.ApiTokenExpiryNotification) bool {
+	message := &beans.ChannelMessage{
+		Title: fmt.Sprintf("API token expiring: %s", notification.Name),
+		Text:  notification.Text,
+		Level: beans.ChannelMessageLevelWarning,
+		Facts: []beans.ChannelMessageFact{
+			{Name: "Owner", Value: notification.Owner},
+			{Name: "Expires at", Value: time.UnixMilli(notification.ExpireAtInMs).UTC().Format(time.RFC1123)},
+		},
+		DedupKey: fmt.Sprintf("devtron/api-token-expiry/%d", notification.ApiTokenId),
+	}
+	sent := false
+	for _, channel := range impl.channels {
+		delivered, err := impl.channelNotificationSender.SendNotification(channel.destination, channel.configId, message)
+		if err != nil {
+			impl.logger.Errorw("error while sending api token expiry notification", "apiTokenId", notification.ApiTokenId, "dest", channel.destination, "configId", channel.configId, "err", err)
+			continue
+		}
+		sent = sent || delivered
+	}
+	return sent
+}
+
+func parseApiTokenExpiryChannels(logger *zap.SugaredLogger, channels []string) []*apiTokenExpiryChannel {
+	expiryChannels := make([]*apiTokenExpiryChannel, 0, len(channels))
+	for _, channel := range channels {
+		destination, configId, found := strings.Cut(strings.TrimSpace(channel), ":")
+		id, err := strconv.Atoi(configId)
+		if !found || err != nil || len(destination) == 0 {
+			logger.Errorw("invalid api token expiry notification channel, expected <channel>:<configId>", "channel", channel)
+			continue
+		}
+		expiryChannels = append(expiryChannels, &apiTokenExpiryChannel{destination: util.Channel(destination), configId: id})
+	}
+	return expiryChannels
+}
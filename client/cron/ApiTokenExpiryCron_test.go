@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"errors"
+	"github.com/devtron-labs/devtron/pkg/apiToken"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	util "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+)
+
+type fakeChannelNotificationSender struct {
+	sent     map[util.Channel]bool
+	err      map[util.Channel]error
+	messages []*beans.ChannelMessage
+}
+
+func (f *fakeChannelNotificationSender) SendNotification(destination util.Channel, configId int, message *beans.ChannelMessage) (bool, error) {
+	f.messages = append(f.messages, message)
+	return f.sent[destination], f.err[destination]
+}
+
+func Test_parseApiTokenExpiryChannels(t *testing.T) {
+	channels := parseApiTokenExpiryChannels(zap.NewNop().Sugar(), []string{"slack:1", " teams:4", "discord", "webhook:abc", ":2"})
+	assert.Equal(t, []*apiTokenExpiryChannel{{destination: util.Slack, configId: 1}, {destination: util.Teams, configId: 4}}, channels)
+}
+
+func TestApiTokenExpiryCronImpl_sendExpiryNotification(t *testing.T) {
+	notification := &apiToken.ApiTokenExpiryNotification{ApiTokenId: 7, Name: "ci-token", Owner: "admin@example.com", ExpireAtInMs: 1700000000000}
+	tests := []struct {
+		name   string
+		sender *fakeChannelNotificationSender
+		sent   bool
+	}{
+		{"delivered to one channel", &fakeChannelNotificationSender{
+			sent: map[util.Channel]bool{util.Slack: true, util.Teams: true},
+			err:  map[util.Channel]error{util.Slack: errors.New("rejected")},
+		}, true},
+		{"nothing to deliver", &fakeChannelNotificationSender{}, false},
+		{"all channels failed", &fakeChannelNotificationSender{
+			err: map[util.Channel]error{util.Slack: errors.New("rejected"), util.Teams: errors.New("rejected")},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl := &ApiTokenExpiryCronImpl{
+				logger:                    zap.NewNop().Sugar(),
+				channelNotificationSender: tt.sender,
+				channels:                  []*apiTokenExpiryChannel{{destination: util.Slack, configId: 1}, {destination: util.Teams, configId: 2}},
+			}
+			assert.Equal(t, tt.sent, impl.sendExpiryNotification(notification))
+			assert.Len(t, tt.sender.messages, 2)
+			assert.Equal(t, "devtron/api-token-expiry/7", tt.sender.messages[0].DedupKey)
+			assert.Equal(t, beans.ChannelMessageLevelWarning, tt.sender.messages[0].Level)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/canary"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	"github.com/devtron-labs/devtron/pkg/workflow/dag"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const canaryAnalysisLease = "canary-analysis"
+
+type CanaryAnalysisCron interface {
+	ProcessAnalyses()
+}
+
+type CanaryAnalysisCronImpl struct {
+	logger                *zap.SugaredLogger
+	cron                  *cron.Cron
+	cfg                   *CanaryAnalysisCronConfig
+	canaryAnalysisService canary.CanaryAnalysisService
+	workflowDagExecutor   dag.WorkflowDagExecutor
+	leaderElectionService leaderElection.LeaderElectionService
+}
+
+func NewCanaryAnalysisCronImpl(logger *zap.SugaredLogger, cfg *CanaryAnalysisCronConfig,
+	canaryAnalysisService canary.CanaryAnalysisService, workflowDagExecutor dag.WorkflowDagExecutor,
+	leaderElectionService leaderElection.LeaderElectionService, cronLogger *cron2.CronLoggerImpl) *CanaryAnalysisCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &CanaryAnalysisCronImpl{
+		logger:                logger,
+		cron:                  cron,
+		cfg:                   cfg,
+		canaryAnalysisService: canaryAnalysisService,
+		workflowDagExecutor:   workflowDagExecutor,
+		leaderElectionService: leaderElectionService,
+	}
+
+	// analyses are persisted after every step, the ones running when the orchestrator stopped resume on the first run
+	_, err := cron.AddFunc(fmt.Sprintf("@every %ds", cfg.CanaryAnalysisCronTime), impl.ProcessAnalyses)
+	if err != nil {
+		logger.Errorw("error while configure cron job for canary analysis", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type CanaryAnalysisCronConfig struct {
+	// CanaryAnalysisCronTime is the interval in seconds at which canary steps are evaluated
+	CanaryAnalysisCronTime int `env:"CANARY_ANALYSIS_CRON_TIME" envDefault:"30"`
+}
+
+func GetCanaryAnalysisCronConfig() (*CanaryAnalysisCronConfig, error) {
+	cfg := &CanaryAnalysisCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse canary analysis cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *CanaryAnalysisCronImpl) ProcessAnalyses() {
+	leaseDuration := 2 * time.Duration(impl.cfg.CanaryAnalysisCronTime) * time.Second
+	if !impl.leaderElectionService.IsLeader(canaryAnalysisLease, leaseDuration) {
+		return
+	}
+	promotedReleases := impl.canaryAnalysisService.ProcessAnalyses()
+	for _, pipelineOverride := range promotedReleases {
+		err := impl.workflowDagExecutor.HandleDeploymentSuccessEvent(triggerBean.TriggerContext{}, pipelineOverride)
+		if err != nil {
+			impl.logger.Errorw("error in handling deployment success of promoted canary release", "pipelineOverrideId", pipelineOverride.Id, "err", err)
+		}
+	}
+}
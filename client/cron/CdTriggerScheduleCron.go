@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/schedule"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const cdTriggerScheduleLease = "cd-trigger-schedule"
+
+type CdTriggerScheduleCron interface {
+	TriggerDueSchedules()
+}
+
+type CdTriggerScheduleCronImpl struct {
+	logger                   *zap.SugaredLogger
+	cron                     *cron.Cron
+	cfg                      *CdTriggerScheduleCronConfig
+	cdTriggerScheduleService schedule.CdTriggerScheduleService
+	leaderElectionService    leaderElection.LeaderElectionService
+}
+
+func NewCdTriggerScheduleCronImpl(logger *zap.SugaredLogger, cfg *CdTriggerScheduleCronConfig,
+	cdTriggerScheduleService schedule.CdTriggerScheduleService, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *CdTriggerScheduleCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &CdTriggerScheduleCronImpl{
+		logger:                   logger,
+		cron:                     cron,
+		cfg:                      cfg,
+		cdTriggerScheduleService: cdTriggerScheduleService,
+		leaderElectionService:    leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.CdTriggerScheduleCronTime), impl.TriggerDueSchedules)
+	if err != nil {
+		logger.Errorw("error while configure cron job for cd trigger schedules", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type CdTriggerScheduleCronConfig struct {
+	CdTriggerScheduleCronTime int `env:"CD_TRIGGER_SCHEDULE_CRON_TIME" envDefault:"1"`
+}
+
+func GetCdTriggerScheduleCronConfig() (*CdTriggerScheduleCronConfig, error) {
+	cfg := &CdTriggerScheduleCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse cd trigger schedule cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// TriggerDueSchedules runs only on the replica holding the lease, the lease outlives two ticks so that
+// a leader missing a single tick does not hand over
+func (impl *CdTriggerScheduleCronImpl) TriggerDueSchedules() {
+	leaseDuration := 2 * time.Duration(impl.cfg.CdTriggerScheduleCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(cdTriggerScheduleLease, leaseDuration) {
+		return
+	}
+	impl.cdTriggerScheduleService.TriggerDueSchedules()
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/cveException"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const cveExceptionLease = "cve-exception-expiry"
+
+type CveExceptionCron interface {
+	ExpireExceptions()
+}
+
+type CveExceptionCronImpl struct {
+	logger                *zap.SugaredLogger
+	cron                  *cron.Cron
+	cfg                   *CveExceptionCronConfig
+	cveExceptionService   cveException.CveExceptionService
+	leaderElectionService leaderElection.LeaderElectionService
+}
+
+func NewCveExceptionCronImpl(logger *zap.SugaredLogger, cfg *CveExceptionCronConfig,
+	cveExceptionService cveException.CveExceptionService, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *CveExceptionCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &CveExceptionCronImpl{
+		logger:                logger,
+		cron:                  cron,
+		cfg:                   cfg,
+		cveExceptionService:   cveExceptionService,
+		leaderElectionService: leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.CveExceptionCronTime), impl.ExpireExceptions)
+	if err != nil {
+		logger.Errorw("error while configure cron job for cve exception expiry", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type CveExceptionCronConfig struct {
+	CveExceptionCronTime int `env:"CVE_EXCEPTION_EXPIRY_CRON_TIME" envDefault:"60"`
+}
+
+func GetCveExceptionCronConfig() (*CveExceptionCronConfig, error) {
+	cfg := &CveExceptionCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse cve exception cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *CveExceptionCronImpl) ExpireExceptions() {
+	leaseDuration := 2 * time.Duration(impl.cfg.CveExceptionCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(cveExceptionLease, leaseDuration) {
+		return
+	}
+	impl.cveExceptionService.ExpireExceptions()
+}
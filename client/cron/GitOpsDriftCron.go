@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const gitOpsDriftLease = "gitops-drift"
+
+type GitOpsDriftCron interface {
+	CheckDrift()
+}
+
+type GitOpsDriftCronImpl struct {
+	logger                *zap.SugaredLogger
+	cron                  *cron.Cron
+	cfg                   *GitOpsDriftCronConfig
+	gitOpsDriftService    drift.GitOpsDriftService
+	leaderElectionService leaderElection.LeaderElectionService
+}
+
+func NewGitOpsDriftCronImpl(logger *zap.SugaredLogger, cfg *GitOpsDriftCronConfig,
+	gitOpsDriftService drift.GitOpsDriftService, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *GitOpsDriftCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &GitOpsDriftCronImpl{
+		logger:                logger,
+		cron:                  cron,
+		cfg:                   cfg,
+		gitOpsDriftService:    gitOpsDriftService,
+		leaderElectionService: leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.GitOpsDriftCronTime), impl.CheckDrift)
+	if err != nil {
+		logger.Errorw("error while configure cron job for gitops drift check", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type GitOpsDriftCronConfig struct {
+	GitOpsDriftCronTime int `env:"GITOPS_DRIFT_CRON_TIME" envDefault:"30"`
+	// GitOpsDriftAutoRecommit recommits the values last deployed by devtron when drift is found
+	GitOpsDriftAutoRecommit bool `env:"GITOPS_DRIFT_AUTO_RECOMMIT" envDefault:"false"`
+}
+
+func GetGitOpsDriftCronConfig() (*GitOpsDriftCronConfig, error) {
+	cfg := &GitOpsDriftCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse gitops drift cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *GitOpsDriftCronImpl) CheckDrift() {
+	leaseDuration := 2 * time.Duration(impl.cfg.GitOpsDriftCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(gitOpsDriftLease, leaseDuration) {
+		return
+	}
+	impl.gitOpsDriftService.CheckAll(impl.cfg.GitOpsDriftAutoRecommit)
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const gitOpsPullRequestLease = "gitops-pull-request"
+
+type GitOpsPullRequestCron interface {
+	ProcessOpenPullRequests()
+}
+
+type GitOpsPullRequestCronImpl struct {
+	logger                   *zap.SugaredLogger
+	cron                     *cron.Cron
+	cfg                      *GitOpsPullRequestCronConfig
+	gitOpsPullRequestService pullRequest.GitOpsPullRequestService
+	leaderElectionService    leaderElection.LeaderElectionService
+}
+
+func NewGitOpsPullRequestCronImpl(logger *zap.SugaredLogger, cfg *GitOpsPullRequestCronConfig,
+	gitOpsPullRequestService pullRequest.GitOpsPullRequestService, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *GitOpsPullRequestCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &GitOpsPullRequestCronImpl{
+		logger:                   logger,
+		cron:                     cron,
+		cfg:                      cfg,
+		gitOpsPullRequestService: gitOpsPullRequestService,
+		leaderElectionService:    leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.GitOpsPullRequestCronTime), impl.ProcessOpenPullRequests)
+	if err != nil {
+		logger.Errorw("error while configure cron job for gitops pull requests", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type GitOpsPullRequestCronConfig struct {
+	GitOpsPullRequestCronTime int `env:"GITOPS_PULL_REQUEST_CRON_TIME" envDefault:"2"`
+}
+
+func GetGitOpsPullRequestCronConfig() (*GitOpsPullRequestCronConfig, error) {
+	cfg := &GitOpsPullRequestCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse gitops pull request cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *GitOpsPullRequestCronImpl) ProcessOpenPullRequests() {
+	leaseDuration := 2 * time.Duration(impl.cfg.GitOpsPullRequestCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(gitOpsPullRequestLease, leaseDuration) {
+		return
+	}
+	impl.gitOpsPullRequestService.ProcessOpenPullRequests()
+}
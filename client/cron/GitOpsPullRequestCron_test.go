@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type fakeLeaderElectionService struct {
+	isLeader bool
+	leases   map[string]time.Duration
+}
+
+func (fake *fakeLeaderElectionService) IsLeader(leaseName string, leaseDuration time.Duration) bool {
+	fake.leases[leaseName] = leaseDuration
+	return fake.isLeader
+}
+
+type fakeGitOpsPullRequestService struct {
+	processed int
+}
+
+func (fake *fakeGitOpsPullRequestService) ProcessOpenPullRequests() {
+	fake.processed++
+}
+
+func TestGitOpsPullRequestCronImpl_ProcessOpenPullRequests(t *testing.T) {
+	tests := []struct {
+		name      string
+		isLeader  bool
+		processed int
+	}{
+		{"leader processes the open pull requests", true, 1},
+		{"other replicas skip", false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaderElectionService := &fakeLeaderElectionService{isLeader: tt.isLeader, leases: map[string]time.Duration{}}
+			pullRequestService := &fakeGitOpsPullRequestService{}
+			impl := &GitOpsPullRequestCronImpl{
+				logger:                   zap.NewNop().Sugar(),
+				cfg:                      &GitOpsPullRequestCronConfig{GitOpsPullRequestCronTime: 2},
+				gitOpsPullRequestService: pullRequestService,
+				leaderElectionService:    leaderElectionService,
+			}
+			impl.ProcessOpenPullRequests()
+			assert.Equal(t, tt.processed, pullRequestService.processed)
+			assert.Equal(t, map[string]time.Duration{gitOpsPullRequestLease: 4 * time.Minute}, leaderElectionService.leases)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const hibernationPolicyLease = "hibernation-policy"
+
+type HibernationPolicyCron interface {
+	ApplyHibernationPolicies()
+}
+
+type HibernationPolicyCronImpl struct {
+	logger                   *zap.SugaredLogger
+	cron                     *cron.Cron
+	cfg                      *HibernationPolicyCronConfig
+	hibernationPolicyService hibernationPolicy.HibernationPolicyService
+	leaderElectionService    leaderElection.LeaderElectionService
+}
+
+func NewHibernationPolicyCronImpl(logger *zap.SugaredLogger, cfg *HibernationPolicyCronConfig,
+	hibernationPolicyService hibernationPolicy.HibernationPolicyService, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *HibernationPolicyCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &HibernationPolicyCronImpl{
+		logger:                   logger,
+		cron:                     cron,
+		cfg:                      cfg,
+		hibernationPolicyService: hibernationPolicyService,
+		leaderElectionService:    leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.HibernationPolicyCronTime), impl.ApplyHibernationPolicies)
+	if err != nil {
+		logger.Errorw("error while configure cron job for hibernation policies", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type HibernationPolicyCronConfig struct {
+	HibernationPolicyCronTime int `env:"HIBERNATION_POLICY_CRON_TIME" envDefault:"5"`
+}
+
+func GetHibernationPolicyCronConfig() (*HibernationPolicyCronConfig, error) {
+	cfg := &HibernationPolicyCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse hibernation policy cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *HibernationPolicyCronImpl) ApplyHibernationPolicies() {
+	leaseDuration := 2 * time.Duration(impl.cfg.HibernationPolicyCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(hibernationPolicyLease, leaseDuration) {
+		return
+	}
+	impl.hibernationPolicyService.ApplyPolicies()
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/imageRetention"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const imageRetentionLease = "image-retention"
+
+type ImageRetentionCron interface {
+	ApplyRetentionPolicies()
+}
+
+type ImageRetentionCronImpl struct {
+	logger                *zap.SugaredLogger
+	cron                  *cron.Cron
+	cfg                   *ImageRetentionCronConfig
+	imageRetentionService imageRetention.ImageRetentionService
+	leaderElectionService leaderElection.LeaderElectionService
+}
+
+func NewImageRetentionCronImpl(logger *zap.SugaredLogger, cfg *ImageRetentionCronConfig,
+	imageRetentionService imageRetention.ImageRetentionService, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *ImageRetentionCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &ImageRetentionCronImpl{
+		logger:                logger,
+		cron:                  cron,
+		cfg:                   cfg,
+		imageRetentionService: imageRetentionService,
+		leaderElectionService: leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.ImageRetentionCronTime), impl.ApplyRetentionPolicies)
+	if err != nil {
+		logger.Errorw("error while configure cron job for image retention", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type ImageRetentionCronConfig struct {
+	ImageRetentionCronTime int `env:"IMAGE_RETENTION_CRON_TIME" envDefault:"720"`
+}
+
+func GetImageRetentionCronConfig() (*ImageRetentionCronConfig, error) {
+	cfg := &ImageRetentionCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse image retention cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *ImageRetentionCronImpl) ApplyRetentionPolicies() {
+	leaseDuration := 2 * time.Duration(impl.cfg.ImageRetentionCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(imageRetentionLease, leaseDuration) {
+		return
+	}
+	impl.imageRetentionService.ApplyAllPolicies()
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const jitAccessLease = "jit-access-expiry"
+
+type JitAccessCron interface {
+	ExpireGrants()
+}
+
+type JitAccessCronImpl struct {
+	logger                *zap.SugaredLogger
+	cron                  *cron.Cron
+	cfg                   *JitAccessCronConfig
+	jitAccessService      jitAccess.JitAccessService
+	leaderElectionService leaderElection.LeaderElectionService
+}
+
+func NewJitAccessCronImpl(logger *zap.SugaredLogger, cfg *JitAccessCronConfig,
+	jitAccessService jitAccess.JitAccessService, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *JitAccessCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &JitAccessCronImpl{
+		logger:                logger,
+		cron:                  cron,
+		cfg:                   cfg,
+		jitAccessService:      jitAccessService,
+		leaderElectionService: leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.JitAccessCronTime), impl.ExpireGrants)
+	if err != nil {
+		logger.Errorw("error while configure cron job for jit access expiry", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type JitAccessCronConfig struct {
+	JitAccessCronTime int `env:"JIT_ACCESS_EXPIRY_CRON_TIME" envDefault:"5"`
+}
+
+func GetJitAccessCronConfig() (*JitAccessCronConfig, error) {
+	cfg := &JitAccessCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse jit access cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *JitAccessCronImpl) ExpireGrants() {
+	leaseDuration := 2 * time.Duration(impl.cfg.JitAccessCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(jitAccessLease, leaseDuration) {
+		return
+	}
+	impl.jitAccessService.ExpireGrants()
+}
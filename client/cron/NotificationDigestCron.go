@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const notificationDigestLease = "notification-digest"
+
+type NotificationDigestCron interface {
+	SendNotificationDigests()
+}
+
+type NotificationDigestCronImpl struct {
+	logger                *zap.SugaredLogger
+	cron                  *cron.Cron
+	cfg                   *NotificationDigestCronConfig
+	eventClient           client.EventClient
+	leaderElectionService leaderElection.LeaderElectionService
+}
+
+func NewNotificationDigestCronImpl(logger *zap.SugaredLogger, cfg *NotificationDigestCronConfig,
+	eventClient client.EventClient, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *NotificationDigestCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &NotificationDigestCronImpl{
+		logger:                logger,
+		cron:                  cron,
+		cfg:                   cfg,
+		eventClient:           eventClient,
+		leaderElectionService: leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.NotificationDigestCronTime), impl.SendNotificationDigests)
+	if err != nil {
+		logger.Errorw("error while configure cron job for notification digest", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type NotificationDigestCronConfig struct {
+	NotificationDigestCronTime int `env:"NOTIFICATION_DIGEST_CRON_TIME" envDefault:"1"`
+}
+
+func GetNotificationDigestCronConfig() (*NotificationDigestCronConfig, error) {
+	cfg := &NotificationDigestCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse notification digest cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// SendNotificationDigests runs on the leader only, replicas sending the same digest would deliver it twice
+func (impl *NotificationDigestCronImpl) SendNotificationDigests() {
+	leaseDuration := 2 * time.Duration(impl.cfg.NotificationDigestCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(notificationDigestLease, leaseDuration) {
+		return
+	}
+	impl.eventClient.SendNotificationDigests()
+}
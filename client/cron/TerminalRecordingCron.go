@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+const terminalRecordingLease = "terminal-recording-retention"
+
+type TerminalRecordingCron interface {
+	DeleteExpiredRecordings()
+}
+
+type TerminalRecordingCronImpl struct {
+	logger                   *zap.SugaredLogger
+	cron                     *cron.Cron
+	cfg                      *TerminalRecordingCronConfig
+	terminalRecordingService terminalRecording.TerminalRecordingService
+	leaderElectionService    leaderElection.LeaderElectionService
+}
+
+func NewTerminalRecordingCronImpl(logger *zap.SugaredLogger, cfg *TerminalRecordingCronConfig,
+	terminalRecordingService terminalRecording.TerminalRecordingService, leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) *TerminalRecordingCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger), cron.SkipIfStillRunning(cronLogger)))
+	cron.Start()
+	impl := &TerminalRecordingCronImpl{
+		logger:                   logger,
+		cron:                     cron,
+		cfg:                      cfg,
+		terminalRecordingService: terminalRecordingService,
+		leaderElectionService:    leaderElectionService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.TerminalRecordingCronTime), impl.DeleteExpiredRecordings)
+	if err != nil {
+		logger.Errorw("error while configure cron job for terminal recording retention", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type TerminalRecordingCronConfig struct {
+	TerminalRecordingCronTime int `env:"TERMINAL_RECORDING_RETENTION_CRON_TIME" envDefault:"1440"`
+}
+
+func GetTerminalRecordingCronConfig() (*TerminalRecordingCronConfig, error) {
+	cfg := &TerminalRecordingCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse terminal recording cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *TerminalRecordingCronImpl) DeleteExpiredRecordings() {
+	leaseDuration := 2 * time.Duration(impl.cfg.TerminalRecordingCronTime) * time.Minute
+	if !impl.leaderElectionService.IsLeader(terminalRecordingLease, leaseDuration) {
+		return
+	}
+	impl.terminalRecordingService.DeleteExpiredRecordings()
+}
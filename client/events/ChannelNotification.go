@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	util "github.com/devtron-labs/devtron/util/event"
+	"strings"
+)
+
+// ChannelNotificationSender delivers notifications to the channels the notifier service does not support,
+// it is implemented in pkg/notifier which depends on this package
+type ChannelNotificationSender interface {
+	SendNotification(destination util.Channel, configId int, message *beans.ChannelMessage) (bool, error)
+}
+
+// channelDestinations are delivered by the orchestrator, the notifier service ignores providers it does not know
+var channelDestinations = []util.Channel{util.Teams, util.Discord, util.PagerDuty}
+
+// sendToChannels delivers the event to the teams, discord and pager duty providers of the applicable notification settings,
+// a failing channel is logged and does not stop the delivery to the others
+func (impl *EventRESTClientImpl) sendToChannels(event Event) {
+	destinations := make([]string, 0, len(channelDestinations))
+	for _, destination := range channelDestinations {
+		destinations = append(destinations, string(destination))
+	}
+	notificationSettings, err := impl.notificationSettingsRepository.FindNotificationSettingsByDestinations(event.EventTypeId, event.PipelineType, destinations)
+	if err != nil {
+		impl.logger.Errorw("error in fetching notification settings for channels", "eventTypeId", event.EventTypeId, "pipelineType", event.PipelineType, "err", err)
+		return
+	}
+	skipped := make(map[int]bool, len(event.SkippedNotificationSettingIds))
+	for _, id := range event.SkippedNotificationSettingIds {
+		skipped[id] = true
+	}
+	addressed := make(map[int]bool, len(event.NotificationSettingIds))
+	for _, id := range event.NotificationSettingIds {
+		addressed[id] = true
+	}
+	message := buildChannelMessage(event)
+	for _, notificationSetting := range notificationSettings {
+		if skipped[notificationSetting.Id] || (len(addressed) > 0 && !addressed[notificationSetting.Id]) ||
+			!isNotificationSettingApplicable(notificationSetting, event) {
+			continue
+		}
+		var providers []beans.Provider
+		err = json.Unmarshal([]byte(notificationSetting.Config), &providers)
+		if err != nil {
+			impl.logger.Errorw("error in parsing notification setting providers", "notificationSettingId", notificationSetting.Id, "err", err)
+			continue
+		}
+		for _, provider := range providers {
+			if !IsChannelDestination(provider.Destination) {
+				continue
+			}
+			_, err = impl.channelNotificationSender.SendNotification(provider.Destination, provider.ConfigId, message)
+			if err != nil {
+				impl.logger.Errorw("error in sending channel notification", "notificationSettingId", notificationSetting.Id, "dest", provider.Destination, "configId", provider.ConfigId, "err", err)
+			}
+		}
+	}
+}
+
+// IsChannelDestination reports whether the orchestrator delivers to the destination itself,
+// the options evaluated by the orchestrator can not be applied to the destinations the notifier service delivers
+func IsChannelDestination(destination util.Channel) bool {
+	for _, channelDestination := range channelDestinations {
+		if channelDestination == destination {
+			return true
+		}
+	}
+	return false
+}
+
+func buildChannelMessage(event Event) *beans.ChannelMessage {
+	payload := event.Payload
+	if payload == nil {
+		payload = &Payload{}
+	}
+	stage := "Build"
+	link := payload.BuildHistoryLink
+	if event.PipelineType == string(util.CD) {
+		link = payload.DeploymentHistoryLink
+		switch event.CdWorkflowType {
+		case bean.CD_WORKFLOW_TYPE_PRE:
+			stage = "Pre-deployment"
+		case bean.CD_WORKFLOW_TYPE_POST:
+			stage = "Post-deployment"
+		default:
+			stage = "Deployment"
+		}
+	}
+	outcome, level := "triggered", beans.ChannelMessageLevelInfo
+	switch util.EventType(event.EventTypeId) {
+	case util.Success:
+		outcome, level = "succeeded", beans.ChannelMessageLevelSuccess
+	case util.Fail:
+		outcome, level = "failed", beans.ChannelMessageLevelFailure
+	}
+	target := payload.AppName
+	if len(payload.EnvName) > 0 {
+		target = fmt.Sprintf("%s / %s", payload.AppName, payload.EnvName)
+	}
+	text := payload.FailureReason
+	if payload.DigestCount > 0 {
+		text = strings.TrimSpace(fmt.Sprintf("%d times since %s. %s", payload.DigestCount, payload.DigestSince, payload.FailureReason))
+	}
+	message := &beans.ChannelMessage{
+		Title: fmt.Sprintf("%s %s: %s", stage, outcome, target),
+		Text:  text,
+		Level: level,
+		Facts: []beans.ChannelMessageFact{
+			{Name: "Application", Value: payload.AppName},
+			{Name: "Environment", Value: payload.EnvName},
+			{Name: "Pipeline", Value: payload.PipelineName},
+			{Name: "Triggered by", Value: payload.TriggeredBy},
+			{Name: "Image", Value: payload.DockerImageUrl},
+		},
+		DedupKey: fmt.Sprintf("devtron/%s/%d/%s", event.PipelineType, event.PipelineId, event.CdWorkflowType),
+	}
+	if len(link) > 0 && len(event.BaseUrl) > 0 {
+		message.Link = strings.TrimSuffix(event.BaseUrl, "/") + link
+	}
+	return message
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	util2 "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildChannelMessage(t *testing.T) {
+	t.Run("failed deployment", func(t *testing.T) {
+		message := buildChannelMessage(Event{
+			EventTypeId:    int(util2.Fail),
+			PipelineType:   string(util2.CD),
+			PipelineId:     3,
+			CdWorkflowType: bean.CD_WORKFLOW_TYPE_DEPLOY,
+			BaseUrl:        "https://devtron.example.com/",
+			Payload: &Payload{AppName: "payments", EnvName: "prod", FailureReason: "pods are crash looping",
+				DeploymentHistoryLink: "/dashboard/app/1/cd-details/2/3/4/source-code"},
+		})
+		assert.Equal(t, "Deployment failed: payments / prod", message.Title)
+		assert.Equal(t, "pods are crash looping", message.Text)
+		assert.Equal(t, beans.ChannelMessageLevelFailure, message.Level)
+		assert.Equal(t, "https://devtron.example.com/dashboard/app/1/cd-details/2/3/4/source-code", message.Link)
+		assert.Equal(t, "devtron/CD/3/DEPLOY", message.DedupKey)
+	})
+	t.Run("build digest", func(t *testing.T) {
+		message := buildChannelMessage(Event{
+			EventTypeId:  int(util2.Success),
+			PipelineType: string(util2.CI),
+			PipelineId:   5,
+			Payload:      &Payload{AppName: "payments", DigestCount: 3, DigestSince: "2024-01-01T00:00:00Z"},
+		})
+		assert.Equal(t, "Build succeeded: payments", message.Title)
+		assert.Equal(t, "3 times since 2024-01-01T00:00:00Z.", message.Text)
+		assert.Equal(t, beans.ChannelMessageLevelSuccess, message.Level)
+		assert.Empty(t, message.Link)
+	})
+}
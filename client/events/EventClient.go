@@ -29,6 +29,7 @@ import (
 	"github.com/caarlos0/env"
 	pubsub "github.com/devtron-labs/common-lib/pubsub-lib"
 	"github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/cel"
 	"github.com/devtron-labs/devtron/client/gitSensor"
 	"github.com/devtron-labs/devtron/internal/sql/repository"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
@@ -56,6 +57,7 @@ func GetEventClientConfig() (*EventClientConfig, error) {
 type EventClient interface {
 	WriteNotificationEvent(event Event) (bool, error)
 	WriteNatsEvent(channel string, payload interface{}) error
+	SendNotificationDigests()
 }
 
 type Event struct {
@@ -77,6 +79,10 @@ type Event struct {
 	CiArtifactId       int               `json:"ciArtifactId"`
 	BaseUrl            string            `json:"baseUrl"`
 	UserId             int               `json:"-"`
+	// SkippedNotificationSettingIds holds the notification settings whose condition did not match this event
+	SkippedNotificationSettingIds []int `json:"skippedNotificationSettingIds,omitempty"`
+	// NotificationSettingIds restricts delivery to the given notification settings, set on digests
+	NotificationSettingIds []int `json:"notificationSettingIds,omitempty"`
 }
 
 type Payload struct {
@@ -93,6 +99,8 @@ type Payload struct {
 	BuildHistoryLink      string               `json:"buildHistoryLink"`
 	MaterialTriggerInfo   *MaterialTriggerInfo `json:"material"`
 	FailureReason         string               `json:"failureReason"`
+	DigestCount           int                  `json:"digestCount,omitempty"`
+	DigestSince           string               `json:"digestSince,omitempty"`
 }
 
 type CiPipelineMaterialResponse struct {
@@ -118,22 +126,34 @@ type MaterialTriggerInfo struct {
 }
 
 type EventRESTClientImpl struct {
-	logger               *zap.SugaredLogger
-	client               *http.Client
-	config               *EventClientConfig
-	pubsubClient         *pubsub.PubSubClientServiceImpl
-	ciPipelineRepository pipelineConfig.CiPipelineRepository
-	pipelineRepository   pipelineConfig.PipelineRepository
-	attributesRepository repository.AttributesRepository
-	moduleService        module.ModuleService
+	logger                         *zap.SugaredLogger
+	client                         *http.Client
+	config                         *EventClientConfig
+	pubsubClient                   *pubsub.PubSubClientServiceImpl
+	ciPipelineRepository           pipelineConfig.CiPipelineRepository
+	pipelineRepository             pipelineConfig.PipelineRepository
+	attributesRepository           repository.AttributesRepository
+	moduleService                  module.ModuleService
+	notificationSettingsRepository repository.NotificationSettingsRepository
+	cdWorkflowRepository           pipelineConfig.CdWorkflowRepository
+	ciWorkflowRepository           pipelineConfig.CiWorkflowRepository
+	celEvaluatorService            cel.EvaluatorService
+	notificationDigestRepository   repository.NotificationDigestRepository
+	channelNotificationSender      ChannelNotificationSender
 }
 
 func NewEventRESTClientImpl(logger *zap.SugaredLogger, client *http.Client, config *EventClientConfig, pubsubClient *pubsub.PubSubClientServiceImpl,
 	ciPipelineRepository pipelineConfig.CiPipelineRepository, pipelineRepository pipelineConfig.PipelineRepository,
-	attributesRepository repository.AttributesRepository, moduleService module.ModuleService) *EventRESTClientImpl {
+	attributesRepository repository.AttributesRepository, moduleService module.ModuleService,
+	notificationSettingsRepository repository.NotificationSettingsRepository, cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository, celEvaluatorService cel.EvaluatorService,
+	notificationDigestRepository repository.NotificationDigestRepository, channelNotificationSender ChannelNotificationSender) *EventRESTClientImpl {
 	return &EventRESTClientImpl{logger: logger, client: client, config: config, pubsubClient: pubsubClient,
 		ciPipelineRepository: ciPipelineRepository, pipelineRepository: pipelineRepository,
-		attributesRepository: attributesRepository, moduleService: moduleService}
+		attributesRepository: attributesRepository, moduleService: moduleService,
+		notificationSettingsRepository: notificationSettingsRepository, cdWorkflowRepository: cdWorkflowRepository,
+		ciWorkflowRepository: ciWorkflowRepository, celEvaluatorService: celEvaluatorService,
+		notificationDigestRepository: notificationDigestRepository, channelNotificationSender: channelNotificationSender}
 }
 
 func (impl *EventRESTClientImpl) buildFinalPayload(event Event, cdPipeline *pipelineConfig.Pipeline, ciPipeline *pipelineConfig.CiPipeline) *Payload {
@@ -219,13 +239,14 @@ func (impl *EventRESTClientImpl) WriteNotificationEvent(event Event) (bool, erro
 	if attribute != nil {
 		event.BaseUrl = attribute.Value
 	}
+	event.SkippedNotificationSettingIds = impl.getSkippedNotificationSettingIds(event)
 	if event.CdWorkflowType == "" {
-		_, err = impl.sendEvent(event)
+		_, err = impl.sendNotificationEvent(event)
 	} else if event.CdWorkflowType == bean.CD_WORKFLOW_TYPE_PRE {
 		if event.EventTypeId == int(util.Success) {
 			impl.logger.Debug("skip - will send from deployment or post stage")
 		} else {
-			_, err = impl.sendEvent(event)
+			_, err = impl.sendNotificationEvent(event)
 		}
 	} else if event.CdWorkflowType == bean.CD_WORKFLOW_TYPE_DEPLOY {
 		if isPreStageExist && event.EventTypeId == int(util.Trigger) {
@@ -233,17 +254,79 @@ func (impl *EventRESTClientImpl) WriteNotificationEvent(event Event) (bool, erro
 		} else if isPostStageExist && event.EventTypeId == int(util.Success) {
 			impl.logger.Debug("skip - will send from post stage")
 		} else {
-			_, err = impl.sendEvent(event)
+			_, err = impl.sendNotificationEvent(event)
 		}
 	} else if event.CdWorkflowType == bean.CD_WORKFLOW_TYPE_POST {
 		if event.EventTypeId == int(util.Trigger) {
 			impl.logger.Debug("skip - already sent from pre or deployment stage")
 		} else {
-			_, err = impl.sendEvent(event)
+			_, err = impl.sendNotificationEvent(event)
 		}
 	}
 	return true, err
 }
+
+// sendNotificationEvent holds the event back from notification settings collecting a digest or in cooldown before sending it
+func (impl *EventRESTClientImpl) sendNotificationEvent(event Event) (bool, error) {
+	event.SkippedNotificationSettingIds = append(event.SkippedNotificationSettingIds, impl.holdBackForDigestOrCooldown(event, event.SkippedNotificationSettingIds)...)
+	return impl.sendEvent(event)
+}
+
+// getSkippedNotificationSettingIds evaluates the conditions configured on notification settings for this event type,
+// settings whose condition does not hold are skipped. A condition that fails to evaluate does not suppress the notification.
+func (impl *EventRESTClientImpl) getSkippedNotificationSettingIds(event Event) []int {
+	notificationSettings, err := impl.notificationSettingsRepository.FindNotificationSettingsWithCondition(event.EventTypeId, event.PipelineType)
+	if err != nil {
+		impl.logger.Errorw("error in fetching notification settings with condition", "eventTypeId", event.EventTypeId, "pipelineType", event.PipelineType, "err", err)
+		return nil
+	}
+	if len(notificationSettings) == 0 {
+		return nil
+	}
+	params := buildNotificationConditionParams(event, impl.getWorkflowDuration(event))
+	var skippedIds []int
+	for _, notificationSetting := range notificationSettings {
+		matched, err := impl.celEvaluatorService.EvaluateCELRequest(cel.Request{
+			Expression:         notificationSetting.Condition,
+			ExpressionMetadata: cel.ExpressionMetadata{Params: params},
+		})
+		if err != nil {
+			impl.logger.Errorw("error in evaluating notification condition", "notificationSettingId", notificationSetting.Id, "condition", notificationSetting.Condition, "err", err)
+			continue
+		}
+		if !matched {
+			skippedIds = append(skippedIds, notificationSetting.Id)
+		}
+	}
+	return skippedIds
+}
+
+func (impl *EventRESTClientImpl) getWorkflowDuration(event Event) time.Duration {
+	var startedOn, finishedOn time.Time
+	if event.PipelineType == string(util.CD) && event.CdWorkflowRunnerId > 0 {
+		runner, err := impl.cdWorkflowRepository.FindWorkflowRunnerById(event.CdWorkflowRunnerId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching cd workflow runner", "cdWorkflowRunnerId", event.CdWorkflowRunnerId, "err", err)
+			return 0
+		}
+		startedOn, finishedOn = runner.StartedOn, runner.FinishedOn
+	} else if event.PipelineType == string(util.CI) && event.CiWorkflowRunnerId > 0 {
+		workflow, err := impl.ciWorkflowRepository.FindById(event.CiWorkflowRunnerId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching ci workflow", "ciWorkflowId", event.CiWorkflowRunnerId, "err", err)
+			return 0
+		}
+		startedOn, finishedOn = workflow.StartedOn, workflow.FinishedOn
+	}
+	if startedOn.IsZero() {
+		return 0
+	}
+	if finishedOn.IsZero() {
+		finishedOn = time.Now()
+	}
+	return finishedOn.Sub(startedOn)
+}
+
 func (impl *EventRESTClientImpl) sendEventsOnNats(body []byte) error {
 
 	err := impl.pubsubClient.Publish(pubsub.NOTIFICATION_EVENT_TOPIC, string(body))
@@ -258,6 +341,7 @@ func (impl *EventRESTClientImpl) sendEventsOnNats(body []byte) error {
 // do not call this method if notification module is not installed
 func (impl *EventRESTClientImpl) sendEvent(event Event) (bool, error) {
 	impl.logger.Debugw("event before send", "event", event)
+	impl.sendToChannels(event)
 	body, err := json.Marshal(event)
 	if err != nil {
 		impl.logger.Errorw("error while marshaling event request ", "err", err)
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"github.com/devtron-labs/devtron/cel"
+	util "github.com/devtron-labs/devtron/util/event"
+	"strings"
+	"time"
+)
+
+var eventTypeNames = map[int]string{
+	int(util.Trigger): "trigger",
+	int(util.Success): "success",
+	int(util.Fail):    "fail",
+}
+
+// notificationConditionParamTypes declares the event fields a notification setting condition can refer to
+var notificationConditionParamTypes = []struct {
+	name      cel.ParamName
+	paramType cel.ParamValuesType
+}{
+	{cel.EventType, cel.ParamTypeString},
+	{cel.PipelineType, cel.ParamTypeString},
+	{cel.AppName, cel.ParamTypeString},
+	{cel.EnvName, cel.ParamTypeString},
+	{cel.IsProdEnv, cel.ParamTypeBool},
+	{cel.PipelineName, cel.ParamTypeString},
+	{cel.Stage, cel.ParamTypeString},
+	{cel.Branches, cel.ParamTypeList},
+	{cel.ContainerImage, cel.ParamTypeString},
+	{cel.ContainerImageTag, cel.ParamTypeString},
+	{cel.TriggeredBy, cel.ParamTypeString},
+	{cel.DurationSeconds, cel.ParamTypeInteger},
+	{cel.FailureReason, cel.ParamTypeString},
+	{cel.EventTime, cel.ParamTypeTimestamp},
+}
+
+// GetNotificationConditionRequest returns a CEL request with every notification condition
+// param declared and left empty, it is only meant for validating the expression.
+func GetNotificationConditionRequest(condition string) cel.Request {
+	params := make([]cel.ExpressionParam, 0, len(notificationConditionParamTypes))
+	for _, param := range notificationConditionParamTypes {
+		params = append(params, cel.ExpressionParam{ParamName: param.name, Type: param.paramType})
+	}
+	return cel.Request{
+		Expression:         condition,
+		ExpressionMetadata: cel.ExpressionMetadata{Params: params},
+	}
+}
+
+func buildNotificationConditionParams(event Event, duration time.Duration) []cel.ExpressionParam {
+	payload := event.Payload
+	if payload == nil {
+		payload = &Payload{}
+	}
+	eventTime, err := time.Parse(time.RFC3339, event.EventTime)
+	if err != nil {
+		eventTime = time.Now()
+	}
+	branches := make([]string, 0)
+	if payload.MaterialTriggerInfo != nil {
+		for _, gitCommit := range payload.MaterialTriggerInfo.GitTriggers {
+			if len(gitCommit.CiConfigureSourceValue) > 0 {
+				branches = append(branches, gitCommit.CiConfigureSourceValue)
+			}
+		}
+	}
+	values := map[cel.ParamName]interface{}{
+		cel.EventType:         eventTypeNames[event.EventTypeId],
+		cel.PipelineType:      event.PipelineType,
+		cel.AppName:           payload.AppName,
+		cel.EnvName:           payload.EnvName,
+		cel.IsProdEnv:         event.IsProdEnv,
+		cel.PipelineName:      payload.PipelineName,
+		cel.Stage:             payload.Stage,
+		cel.Branches:          branches,
+		cel.ContainerImage:    payload.DockerImageUrl,
+		cel.ContainerImageTag: getImageTag(payload.DockerImageUrl),
+		cel.TriggeredBy:       payload.TriggeredBy,
+		cel.DurationSeconds:   int64(duration.Seconds()),
+		cel.FailureReason:     payload.FailureReason,
+		cel.EventTime:         eventTime,
+	}
+	params := make([]cel.ExpressionParam, 0, len(notificationConditionParamTypes))
+	for _, param := range notificationConditionParamTypes {
+		params = append(params, cel.ExpressionParam{ParamName: param.name, Type: param.paramType, Value: values[param.name]})
+	}
+	return params
+}
+
+func getImageTag(image string) string {
+	// the last colon after the last slash separates the tag, registry host may carry a port
+	lastSlash := strings.LastIndex(image, "/")
+	lastColon := strings.LastIndex(image, ":")
+	if lastColon <= lastSlash {
+		return ""
+	}
+	return image[lastColon+1:]
+}
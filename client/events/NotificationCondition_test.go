@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"github.com/devtron-labs/devtron/cel"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	util2 "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNotificationCondition(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	evaluator := cel.NewCELServiceImpl(logger)
+	event := Event{
+		EventTypeId:  int(util2.Fail),
+		PipelineType: string(util2.CD),
+		IsProdEnv:    true,
+		EventTime:    "2024-10-15T21:30:00Z",
+		Payload: &Payload{
+			AppName:        "payments",
+			EnvName:        "prod",
+			DockerImageUrl: "registry.local:5000/payments/api:v1.4.2",
+			TriggeredBy:    "admin@example.com",
+			FailureReason:  "health check failed",
+			MaterialTriggerInfo: &MaterialTriggerInfo{
+				GitTriggers: map[int]pipelineConfig.GitCommit{1: {CiConfigureSourceValue: "main"}},
+			},
+		},
+	}
+	params := buildNotificationConditionParams(event, 90*time.Second)
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{`isProdEnv && eventType == "fail" && (eventTime.getHours("UTC") < 9 || eventTime.getHours("UTC") >= 18)`, true},
+		{`eventType == "success"`, false},
+		{`"main" in branches && containerImageTag == "v1.4.2"`, true},
+		{`durationSeconds > 120`, false},
+		{`failureReason.contains("health") && triggeredBy.endsWith("@example.com")`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			_, _, err := evaluator.Validate(GetNotificationConditionRequest(tt.condition))
+			assert.Nil(t, err)
+			matched, err := evaluator.EvaluateCELRequest(cel.Request{
+				Expression:         tt.condition,
+				ExpressionMetadata: cel.ExpressionMetadata{Params: params},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, matched)
+		})
+	}
+}
+
+func TestNotificationConditionValidation(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	evaluator := cel.NewCELServiceImpl(logger)
+	_, _, err = evaluator.Validate(GetNotificationConditionRequest(`unknownField == "x"`))
+	assert.NotNil(t, err)
+	_, _, err = evaluator.Validate(GetNotificationConditionRequest(`durationSeconds > "10"`))
+	assert.NotNil(t, err)
+}
+
+func TestGetImageTag(t *testing.T) {
+	assert.Equal(t, "v1", getImageTag("devtron/app:v1"))
+	assert.Equal(t, "", getImageTag("registry.local:5000/devtron/app"))
+	assert.Equal(t, "abc", getImageTag("registry.local:5000/devtron/app:abc"))
+}
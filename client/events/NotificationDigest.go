@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/module"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+// holdBackForDigestOrCooldown returns the ids of notification settings which should not be notified for this event right away,
+// either because they collect events into a periodic digest or because an identical event was delivered within their cooldown.
+func (impl *EventRESTClientImpl) holdBackForDigestOrCooldown(event Event, skippedIds []int) []int {
+	if event.PipelineId == 0 {
+		return nil
+	}
+	notificationSettings, err := impl.notificationSettingsRepository.FindNotificationSettingsWithDigestOrCooldown(event.EventTypeId, event.PipelineType)
+	if err != nil {
+		impl.logger.Errorw("error in fetching notification settings with digest or cooldown", "eventTypeId", event.EventTypeId, "pipelineType", event.PipelineType, "err", err)
+		return nil
+	}
+	if len(notificationSettings) == 0 {
+		return nil
+	}
+	skipped := make(map[int]bool, len(skippedIds))
+	for _, id := range skippedIds {
+		skipped[id] = true
+	}
+	eventJson, err := json.Marshal(event)
+	if err != nil {
+		impl.logger.Errorw("error in marshaling event for digest", "err", err)
+		return nil
+	}
+	now := time.Now()
+	var heldBackIds []int
+	for _, notificationSetting := range notificationSettings {
+		if skipped[notificationSetting.Id] || !isNotificationSettingApplicable(notificationSetting, event) {
+			continue
+		}
+		if notificationSetting.DigestIntervalMins > 0 {
+			digest := newNotificationDigest(notificationSetting.Id, event)
+			holdBackNotification(notificationSetting, digest, event, string(eventJson), now)
+			digest.UpdatedOn = now
+			err = impl.notificationDigestRepository.AddPendingEvent(digest)
+			if err != nil {
+				impl.logger.Errorw("error in adding event to notification digest", "notificationSettingId", notificationSetting.Id, "pipelineId", event.PipelineId, "err", err)
+				continue
+			}
+			heldBackIds = append(heldBackIds, notificationSetting.Id)
+			continue
+		}
+		digest, err := impl.notificationDigestRepository.FindBySettingAndPipeline(notificationSetting.Id, event.PipelineId, event.PipelineType, event.EventTypeId)
+		if err != nil && !errors.Is(err, pg.ErrNoRows) {
+			impl.logger.Errorw("error in fetching notification digest", "notificationSettingId", notificationSetting.Id, "pipelineId", event.PipelineId, "err", err)
+			continue
+		}
+		if digest.Id == 0 {
+			digest = newNotificationDigest(notificationSetting.Id, event)
+		}
+		heldBack := holdBackNotification(notificationSetting, digest, event, string(eventJson), now)
+		digest.UpdatedOn = now
+		if digest.Id == 0 {
+			err = impl.notificationDigestRepository.Save(digest)
+		} else {
+			err = impl.notificationDigestRepository.Update(digest)
+		}
+		if err != nil {
+			impl.logger.Errorw("error in saving notification digest", "notificationSettingId", notificationSetting.Id, "pipelineId", event.PipelineId, "err", err)
+			continue
+		}
+		if heldBack {
+			heldBackIds = append(heldBackIds, notificationSetting.Id)
+		}
+	}
+	return heldBackIds
+}
+
+// SendNotificationDigests delivers the digests whose interval has elapsed, each digest carries the number of
+// collected events and the latest failure reason and is addressed only to the notification setting it was collected for.
+// Digests are delivered only to the channels of the orchestrator, the notifier service would send them to every setting of the pipeline.
+func (impl *EventRESTClientImpl) SendNotificationDigests() {
+	moduleInfo, err := impl.moduleService.GetModuleInfo(module.ModuleNameNotification)
+	if err != nil || moduleInfo.Status != module.ModuleStatusInstalled {
+		return
+	}
+	digests, err := impl.notificationDigestRepository.FindAllPending()
+	if err != nil {
+		impl.logger.Errorw("error in fetching pending notification digests", "err", err)
+		return
+	}
+	if len(digests) == 0 {
+		return
+	}
+	settingIds := make([]int, 0, len(digests))
+	for _, digest := range digests {
+		settingIds = append(settingIds, digest.NotificationSettingId)
+	}
+	notificationSettings, err := impl.notificationSettingsRepository.FindNotificationSettingsByIds(settingIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching notification settings for digests", "notificationSettingIds", settingIds, "err", err)
+		return
+	}
+	digestIntervals := make(map[int]int, len(notificationSettings))
+	for _, notificationSetting := range notificationSettings {
+		digestIntervals[notificationSetting.Id] = notificationSetting.DigestIntervalMins
+	}
+	now := time.Now()
+	for _, digest := range digests {
+		interval, ok := digestIntervals[digest.NotificationSettingId]
+		if ok && now.Before(digest.WindowStart.Add(time.Duration(interval)*time.Minute)) {
+			continue
+		}
+		if ok {
+			event, err := buildDigestEvent(digest, now)
+			if err != nil {
+				impl.logger.Errorw("error in building digest event", "digestId", digest.Id, "err", err)
+				continue
+			}
+			impl.sendToChannels(event)
+			digest.LastSentOn = now
+			digest.LastSentFingerprint = getEventFingerprint(event)
+		}
+		// notification setting is gone, the collected events are dropped along with it
+		digest.UpdatedOn = now
+		err = impl.notificationDigestRepository.MarkPendingSent(digest)
+		if err != nil {
+			impl.logger.Errorw("error in updating notification digest", "digestId", digest.Id, "err", err)
+		}
+	}
+}
+
+// holdBackNotification updates the digest state of the setting with the event and reports whether
+// the event has to be held back from immediate delivery
+func holdBackNotification(notificationSetting *repository.NotificationSettings, digest *repository.NotificationDigest, event Event, eventJson string, now time.Time) bool {
+	if notificationSetting.DigestIntervalMins > 0 {
+		if digest.PendingCount == 0 {
+			digest.WindowStart = now
+		}
+		digest.PendingCount++
+		digest.LatestEvent = eventJson
+		if event.Payload != nil {
+			digest.LatestFailureReason = event.Payload.FailureReason
+		}
+		return true
+	}
+	fingerprint := getEventFingerprint(event)
+	cooldown := time.Duration(notificationSetting.CooldownMins) * time.Minute
+	if digest.LastSentFingerprint == fingerprint && now.Sub(digest.LastSentOn) < cooldown {
+		return true
+	}
+	digest.LastSentOn = now
+	digest.LastSentFingerprint = fingerprint
+	return false
+}
+
+func newNotificationDigest(notificationSettingId int, event Event) *repository.NotificationDigest {
+	return &repository.NotificationDigest{
+		NotificationSettingId: notificationSettingId,
+		PipelineId:            event.PipelineId,
+		PipelineType:          event.PipelineType,
+		EventTypeId:           event.EventTypeId,
+		AuditLog:              sql.NewDefaultAuditLog(userBean.SystemUserId),
+	}
+}
+
+func buildDigestEvent(digest *repository.NotificationDigest, now time.Time) (Event, error) {
+	event := Event{}
+	err := json.Unmarshal([]byte(digest.LatestEvent), &event)
+	if err != nil {
+		return event, err
+	}
+	if event.Payload == nil {
+		event.Payload = &Payload{}
+	}
+	event.NotificationSettingIds = []int{digest.NotificationSettingId}
+	event.SkippedNotificationSettingIds = nil
+	event.EventTime = now.Format(time.RFC3339)
+	event.Payload.FailureReason = digest.LatestFailureReason
+	event.Payload.DigestCount = digest.PendingCount
+	event.Payload.DigestSince = digest.WindowStart.Format(time.RFC3339)
+	return event, nil
+}
+
+// getEventFingerprint identifies repeats of the same event, the stage and failure reason are
+// what tell two failures of a pipeline apart
+func getEventFingerprint(event Event) string {
+	failureReason := ""
+	if event.Payload != nil {
+		failureReason = event.Payload.FailureReason
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d/%s/%d/%s/%s", event.PipelineId, event.PipelineType, event.EventTypeId, event.CdWorkflowType, failureReason)))
+	return hex.EncodeToString(sum[:])
+}
+
+func isNotificationSettingApplicable(notificationSetting *repository.NotificationSettings, event Event) bool {
+	if notificationSetting.PipelineId != nil && *notificationSetting.PipelineId != event.PipelineId {
+		return false
+	}
+	if notificationSetting.TeamId != nil && *notificationSetting.TeamId != event.TeamId {
+		return false
+	}
+	if notificationSetting.AppId != nil && *notificationSetting.AppId != event.AppId {
+		return false
+	}
+	if notificationSetting.ClusterId != nil && *notificationSetting.ClusterId != event.ClusterId {
+		return false
+	}
+	if notificationSetting.EnvId != nil {
+		switch *notificationSetting.EnvId {
+		case resourceQualifiers.AllExistingAndFutureProdEnvsInt:
+			return event.IsProdEnv
+		case resourceQualifiers.AllExistingAndFutureNonProdEnvsInt:
+			return !event.IsProdEnv
+		default:
+			return *notificationSetting.EnvId == event.EnvId
+		}
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/module"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	util2 "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeModuleService struct {
+	module.ModuleService
+}
+
+func (impl *fakeModuleService) GetModuleInfo(name string) (*module.ModuleInfoDto, error) {
+	return &module.ModuleInfoDto{Name: name, Status: module.ModuleStatusInstalled}, nil
+}
+
+type fakeNotificationSettingsRepository struct {
+	repository.NotificationSettingsRepository
+	notificationSettings []*repository.NotificationSettings
+}
+
+func (impl *fakeNotificationSettingsRepository) FindNotificationSettingsByIds(ids []int) ([]*repository.NotificationSettings, error) {
+	return impl.notificationSettings, nil
+}
+
+func (impl *fakeNotificationSettingsRepository) FindNotificationSettingsByDestinations(eventTypeId int, pipelineType string, destinations []string) ([]*repository.NotificationSettings, error) {
+	return impl.notificationSettings, nil
+}
+
+type fakeNotificationDigestRepository struct {
+	repository.NotificationDigestRepository
+	digests []*repository.NotificationDigest
+	sent    []*repository.NotificationDigest
+}
+
+func (impl *fakeNotificationDigestRepository) FindAllPending() ([]*repository.NotificationDigest, error) {
+	return impl.digests, nil
+}
+
+func (impl *fakeNotificationDigestRepository) MarkPendingSent(digest *repository.NotificationDigest) error {
+	impl.sent = append(impl.sent, digest)
+	return nil
+}
+
+type fakeChannelNotificationSender struct {
+	destinations []util2.Channel
+}
+
+func (impl *fakeChannelNotificationSender) SendNotification(destination util2.Channel, configId int, message *beans.ChannelMessage) (bool, error) {
+	impl.destinations = append(impl.destinations, destination)
+	return true, nil
+}
+
+func TestHoldBackNotification(t *testing.T) {
+	now := time.Now()
+	failure := func(reason string) Event {
+		return Event{PipelineId: 7, PipelineType: string(util2.CI), EventTypeId: int(util2.Fail), Payload: &Payload{FailureReason: reason}}
+	}
+	t.Run("digest collects events", func(t *testing.T) {
+		setting := &repository.NotificationSettings{DigestIntervalMins: 60}
+		digest := &repository.NotificationDigest{}
+		assert.True(t, holdBackNotification(setting, digest, failure("exit code 1"), "{}", now))
+		assert.True(t, holdBackNotification(setting, digest, failure("exit code 2"), "{}", now.Add(time.Minute)))
+		assert.Equal(t, 2, digest.PendingCount)
+		assert.Equal(t, now, digest.WindowStart)
+		assert.Equal(t, "exit code 2", digest.LatestFailureReason)
+
+		event, err := buildDigestEvent(digest, now.Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, []int{digest.NotificationSettingId}, event.NotificationSettingIds)
+		assert.Equal(t, 2, event.Payload.DigestCount)
+		assert.Equal(t, "exit code 2", event.Payload.FailureReason)
+	})
+	t.Run("cooldown suppresses identical failures", func(t *testing.T) {
+		setting := &repository.NotificationSettings{CooldownMins: 30}
+		digest := &repository.NotificationDigest{}
+		assert.False(t, holdBackNotification(setting, digest, failure("exit code 1"), "{}", now))
+		assert.True(t, holdBackNotification(setting, digest, failure("exit code 1"), "{}", now.Add(10*time.Minute)))
+		assert.False(t, holdBackNotification(setting, digest, failure("exit code 2"), "{}", now.Add(11*time.Minute)))
+		assert.False(t, holdBackNotification(setting, digest, failure("exit code 2"), "{}", now.Add(45*time.Minute)))
+		assert.Equal(t, 0, digest.PendingCount)
+	})
+}
+
+func TestIsNotificationSettingApplicable(t *testing.T) {
+	appId, otherAppId, envId := 3, 4, 5
+	prodEnvs, nonProdEnvs := resourceQualifiers.AllExistingAndFutureProdEnvsInt, resourceQualifiers.AllExistingAndFutureNonProdEnvsInt
+	event := Event{AppId: appId, EnvId: envId, IsProdEnv: true}
+	assert.True(t, isNotificationSettingApplicable(&repository.NotificationSettings{AppId: &appId}, event))
+	assert.False(t, isNotificationSettingApplicable(&repository.NotificationSettings{AppId: &otherAppId}, event))
+	assert.True(t, isNotificationSettingApplicable(&repository.NotificationSettings{EnvId: &envId}, event))
+	assert.True(t, isNotificationSettingApplicable(&repository.NotificationSettings{EnvId: &prodEnvs}, event))
+	assert.False(t, isNotificationSettingApplicable(&repository.NotificationSettings{EnvId: &nonProdEnvs}, event))
+}
+
+func TestEventRESTClientImpl_SendNotificationDigests(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	var notifierRequests int32
+	notifier := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&notifierRequests, 1)
+	}))
+	defer notifier.Close()
+
+	pipelineId := 7
+	providers, err := json.Marshal([]beans.Provider{
+		{Destination: util2.Teams, ConfigId: 1},
+		{Destination: util2.Slack, ConfigId: 2},
+		{Destination: util2.SMTP, Recipient: "oncall@example.com"},
+	})
+	assert.Nil(t, err)
+	notificationSetting := &repository.NotificationSettings{Id: 3, PipelineId: &pipelineId, EventTypeId: int(util2.Fail),
+		Config: string(providers), DigestIntervalMins: 30}
+	latestEvent, err := json.Marshal(Event{PipelineId: pipelineId, PipelineType: string(util2.CI), EventTypeId: int(util2.Fail),
+		Payload: &Payload{AppName: "payments"}})
+	assert.Nil(t, err)
+	digestRepository := &fakeNotificationDigestRepository{digests: []*repository.NotificationDigest{{
+		Id: 1, NotificationSettingId: notificationSetting.Id, PipelineId: pipelineId, PipelineType: string(util2.CI),
+		EventTypeId: int(util2.Fail), PendingCount: 4, WindowStart: time.Now().Add(-time.Hour), LatestEvent: string(latestEvent),
+	}}}
+	channelSender := &fakeChannelNotificationSender{}
+	impl := &EventRESTClientImpl{
+		logger:                         logger,
+		client:                         http.DefaultClient,
+		config:                         &EventClientConfig{DestinationURL: notifier.URL},
+		moduleService:                  &fakeModuleService{},
+		notificationSettingsRepository: &fakeNotificationSettingsRepository{notificationSettings: []*repository.NotificationSettings{notificationSetting}},
+		notificationDigestRepository:   digestRepository,
+		channelNotificationSender:      channelSender,
+	}
+	impl.SendNotificationDigests()
+
+	// slack and smtp are notified of every event by the notifier service, the digest must not reach them a second time
+	assert.Equal(t, int32(0), atomic.LoadInt32(&notifierRequests))
+	assert.Equal(t, []util2.Channel{util2.Teams}, channelSender.destinations)
+	assert.Len(t, digestRepository.sent, 1)
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+type DiscordNotificationRepository interface {
+	FindOne(id int) (*DiscordConfig, error)
+	UpdateDiscordConfig(discordConfig *DiscordConfig) (*DiscordConfig, error)
+	SaveDiscordConfig(discordConfig *DiscordConfig) (*DiscordConfig, error)
+	FindAll() ([]DiscordConfig, error)
+	FindByName(value string) ([]DiscordConfig, error)
+	FindByIds(ids []*int) ([]*DiscordConfig, error)
+	MarkDiscordConfigDeleted(discordConfig *DiscordConfig) error
+}
+
+type DiscordNotificationRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewDiscordNotificationRepositoryImpl(dbConnection *pg.DB) *DiscordNotificationRepositoryImpl {
+	return &DiscordNotificationRepositoryImpl{dbConnection: dbConnection}
+}
+
+type DiscordConfig struct {
+	tableName   struct{} `sql:"discord_config" pg:",discard_unknown_columns"`
+	Id          int      `sql:"id,pk"`
+	WebHookUrl  string   `sql:"web_hook_url"`
+	ConfigName  string   `sql:"config_name"`
+	Description string   `sql:"description"`
+	OwnerId     int32    `sql:"owner_id"`
+	Deleted     bool     `sql:"deleted,notnull"`
+	sql.AuditLog
+}
+
+func (impl *DiscordNotificationRepositoryImpl) FindOne(id int) (*DiscordConfig, error) {
+	details := &DiscordConfig{}
+	err := impl.dbConnection.Model(details).Where("id = ?", id).
+		Where("deleted = ?", false).Select()
+	return details, err
+}
+
+func (impl *DiscordNotificationRepositoryImpl) FindAll() ([]DiscordConfig, error) {
+	var configs []DiscordConfig
+	err := impl.dbConnection.Model(&configs).
+		Where("deleted = ?", false).Select()
+	return configs, err
+}
+
+func (impl *DiscordNotificationRepositoryImpl) UpdateDiscordConfig(discordConfig *DiscordConfig) (*DiscordConfig, error) {
+	return discordConfig, impl.dbConnection.Update(discordConfig)
+}
+
+func (impl *DiscordNotificationRepositoryImpl) SaveDiscordConfig(discordConfig *DiscordConfig) (*DiscordConfig, error) {
+	return discordConfig, impl.dbConnection.Insert(discordConfig)
+}
+
+func (impl *DiscordNotificationRepositoryImpl) FindByName(value string) ([]DiscordConfig, error) {
+	var configs []DiscordConfig
+	err := impl.dbConnection.Model(&configs).Where(`config_name like ?`, "%"+value+"%").
+		Where("deleted = ?", false).Select()
+	return configs, err
+}
+
+func (impl *DiscordNotificationRepositoryImpl) FindByIds(ids []*int) ([]*DiscordConfig, error) {
+	var objects []*DiscordConfig
+	err := impl.dbConnection.Model(&objects).Where("id in (?)", pg.In(ids)).
+		Where("deleted = ?", false).Select()
+	return objects, err
+}
+
+func (impl *DiscordNotificationRepositoryImpl) MarkDiscordConfigDeleted(discordConfig *DiscordConfig) error {
+	discordConfig.Deleted = true
+	return impl.dbConnection.Update(discordConfig)
+}
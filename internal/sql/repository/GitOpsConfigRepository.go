@@ -58,6 +58,8 @@ type GitOpsConfig struct {
 	TlsCert               string   `sql:"tls_cert"`
 	TlsKey                string   `sql:"tls_key"`
 	CaCert                string   `sql:"ca_cert"`
+	CommitStrategy        string   `sql:"commit_strategy"`
+	AutoMergePullRequest  bool     `sql:"auto_merge_pull_request,notnull"`
 	sql.AuditLog
 }
 
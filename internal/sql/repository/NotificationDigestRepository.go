@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+// NotificationDigest keeps the delivery state of one notification setting for one pipeline and event type,
+// events held back for the next digest are counted here along with the last delivered event.
+type NotificationDigest struct {
+	tableName             struct{}  `sql:"notification_digest" pg:",discard_unknown_columns"`
+	Id                    int       `sql:"id,pk"`
+	NotificationSettingId int       `sql:"notification_setting_id"`
+	PipelineId            int       `sql:"pipeline_id"`
+	PipelineType          string    `sql:"pipeline_type"`
+	EventTypeId           int       `sql:"event_type_id"`
+	PendingCount          int       `sql:"pending_count,notnull"`
+	WindowStart           time.Time `sql:"window_start"`
+	LatestFailureReason   string    `sql:"latest_failure_reason"`
+	LatestEvent           string    `sql:"latest_event"`
+	LastSentOn            time.Time `sql:"last_sent_on"`
+	LastSentFingerprint   string    `sql:"last_sent_fingerprint"`
+	sql.AuditLog
+}
+
+type NotificationDigestRepository interface {
+	Save(digest *NotificationDigest) error
+	Update(digest *NotificationDigest) error
+	FindBySettingAndPipeline(notificationSettingId, pipelineId int, pipelineType string, eventTypeId int) (*NotificationDigest, error)
+	FindAllPending() ([]*NotificationDigest, error)
+	// AddPendingEvent counts one more held back event into the digest, creating it on the first event
+	AddPendingEvent(digest *NotificationDigest) error
+	// MarkPendingSent takes the delivered events off the digest, events counted while it was being sent stay pending
+	MarkPendingSent(digest *NotificationDigest) error
+}
+
+type NotificationDigestRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewNotificationDigestRepositoryImpl(dbConnection *pg.DB) *NotificationDigestRepositoryImpl {
+	return &NotificationDigestRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *NotificationDigestRepositoryImpl) Save(digest *NotificationDigest) error {
+	return impl.dbConnection.Insert(digest)
+}
+
+func (impl *NotificationDigestRepositoryImpl) Update(digest *NotificationDigest) error {
+	return impl.dbConnection.Update(digest)
+}
+
+func (impl *NotificationDigestRepositoryImpl) FindBySettingAndPipeline(notificationSettingId, pipelineId int, pipelineType string, eventTypeId int) (*NotificationDigest, error) {
+	digest := &NotificationDigest{}
+	err := impl.dbConnection.Model(digest).
+		Where("notification_setting_id = ?", notificationSettingId).
+		Where("pipeline_id = ?", pipelineId).
+		Where("pipeline_type = ?", pipelineType).
+		Where("event_type_id = ?", eventTypeId).
+		Select()
+	return digest, err
+}
+
+func (impl *NotificationDigestRepositoryImpl) FindAllPending() ([]*NotificationDigest, error) {
+	var digests []*NotificationDigest
+	err := impl.dbConnection.Model(&digests).
+		Where("pending_count > 0").
+		Select()
+	return digests, err
+}
+
+// AddPendingEvent increments the count in the database instead of writing back a count read earlier,
+// so events held back concurrently on other replicas are not lost
+func (impl *NotificationDigestRepositoryImpl) AddPendingEvent(digest *NotificationDigest) error {
+	_, err := impl.dbConnection.Model(digest).
+		OnConflict("(notification_setting_id, pipeline_id, pipeline_type, event_type_id) DO UPDATE").
+		Set("pending_count = notification_digest.pending_count + 1").
+		Set("window_start = CASE WHEN notification_digest.pending_count = 0 THEN EXCLUDED.window_start ELSE notification_digest.window_start END").
+		Set("latest_event = EXCLUDED.latest_event").
+		Set("latest_failure_reason = EXCLUDED.latest_failure_reason").
+		Set("updated_on = EXCLUDED.updated_on").
+		Set("updated_by = EXCLUDED.updated_by").
+		Insert()
+	return err
+}
+
+func (impl *NotificationDigestRepositoryImpl) MarkPendingSent(digest *NotificationDigest) error {
+	_, err := impl.dbConnection.Model(digest).
+		Set("pending_count = pending_count - ?", digest.PendingCount).
+		Set("window_start = CASE WHEN pending_count = ? THEN NULL ELSE ? END", digest.PendingCount, digest.UpdatedOn).
+		Set("last_sent_on = ?", digest.LastSentOn).
+		Set("last_sent_fingerprint = ?", digest.LastSentFingerprint).
+		Set("updated_on = ?", digest.UpdatedOn).
+		Set("updated_by = ?", digest.UpdatedBy).
+		WherePK().
+		Update()
+	return err
+}
@@ -44,6 +44,10 @@ type NotificationSettingsRepository interface {
 	FindNotificationSettingBuildOptions(settingRequest *SearchRequest) ([]*SettingOptionDTO, error)
 	FetchNotificationSettingGroupBy(viewId int) ([]NotificationSettings, error)
 	FindNotificationSettingsByConfigIdAndConfigType(configId int, configType string) ([]*NotificationSettings, error)
+	FindNotificationSettingsWithCondition(eventTypeId int, pipelineType string) ([]*NotificationSettings, error)
+	FindNotificationSettingsWithDigestOrCooldown(eventTypeId int, pipelineType string) ([]*NotificationSettings, error)
+	FindNotificationSettingsByIds(ids []int) ([]*NotificationSettings, error)
+	FindNotificationSettingsByDestinations(eventTypeId int, pipelineType string, destinations []string) ([]*NotificationSettings, error)
 }
 
 type NotificationSettingsRepositoryImpl struct {
@@ -87,6 +91,9 @@ type NotificationSettings struct {
 	NotificationRuleId   int      `sql:"notification_rule_id"`
 	AdditionalConfigJson string   `sql:"additional_config_json"` // user defined config json;
 	ClusterId            *int     `sql:"cluster_id"`
+	Condition            string   `sql:"condition"` // CEL expression evaluated against the event, empty matches every event
+	DigestIntervalMins   int      `sql:"digest_interval_mins"`
+	CooldownMins         int      `sql:"cooldown_mins"`
 }
 
 type SettingOptionDTO struct {
@@ -355,3 +362,67 @@ func (impl *NotificationSettingsRepositoryImpl) FindNotificationSettingsByConfig
 	}
 	return notificationSettings, nil
 }
+
+func (impl *NotificationSettingsRepositoryImpl) FindNotificationSettingsWithCondition(eventTypeId int, pipelineType string) ([]*NotificationSettings, error) {
+	var notificationSettings []*NotificationSettings
+	err := impl.dbConnection.Model(&notificationSettings).
+		Where("event_type_id = ?", eventTypeId).
+		Where("pipeline_type = ?", pipelineType).
+		Where("condition IS NOT NULL").
+		Where("condition <> ''").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return notificationSettings, nil
+}
+
+func (impl *NotificationSettingsRepositoryImpl) FindNotificationSettingsWithDigestOrCooldown(eventTypeId int, pipelineType string) ([]*NotificationSettings, error) {
+	var notificationSettings []*NotificationSettings
+	err := impl.dbConnection.Model(&notificationSettings).
+		Where("event_type_id = ?", eventTypeId).
+		Where("pipeline_type = ?", pipelineType).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereOr("digest_interval_mins > 0").
+				WhereOr("cooldown_mins > 0")
+			return q, nil
+		}).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return notificationSettings, nil
+}
+
+func (impl *NotificationSettingsRepositoryImpl) FindNotificationSettingsByIds(ids []int) ([]*NotificationSettings, error) {
+	var notificationSettings []*NotificationSettings
+	if len(ids) == 0 {
+		return notificationSettings, nil
+	}
+	err := impl.dbConnection.Model(&notificationSettings).Where("id in (?)", pg.In(ids)).Select()
+	if err != nil {
+		return nil, err
+	}
+	return notificationSettings, nil
+}
+
+func (impl *NotificationSettingsRepositoryImpl) FindNotificationSettingsByDestinations(eventTypeId int, pipelineType string, destinations []string) ([]*NotificationSettings, error) {
+	var notificationSettings []*NotificationSettings
+	if len(destinations) == 0 {
+		return notificationSettings, nil
+	}
+	err := impl.dbConnection.Model(&notificationSettings).
+		Where("event_type_id = ?", eventTypeId).
+		Where("pipeline_type = ?", pipelineType).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			for _, destination := range destinations {
+				q = q.WhereOr("config::text like ?", "%dest\":\""+destination+"\"%")
+			}
+			return q, nil
+		}).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return notificationSettings, nil
+}
@@ -76,6 +76,7 @@ type PipelineOverrideRepository interface {
 	GetLatestReleaseDeploymentType(pipelineIds []int) ([]*PipelineOverride, error)
 	FindLatestByAppIdAndEnvId(appId, environmentId int, deploymentAppType string) (pipelineOverrides *PipelineOverride, err error)
 	FindLatestByCdWorkflowId(cdWorkflowId int) (pipelineOverride *PipelineOverride, err error)
+	FindLatestByPipelineId(pipelineId int) (pipelineOverride *PipelineOverride, err error)
 }
 
 type PipelineOverrideRepositoryImpl struct {
@@ -321,3 +322,13 @@ func (impl PipelineOverrideRepositoryImpl) FindLatestByCdWorkflowId(cdWorkflowId
 		Select()
 	return &override, err
 }
+
+func (impl PipelineOverrideRepositoryImpl) FindLatestByPipelineId(pipelineId int) (*PipelineOverride, error) {
+	var override PipelineOverride
+	err := impl.dbConnection.Model(&override).
+		Column("pipeline_override.*").
+		Where("pipeline_id = ?", pipelineId).
+		Order("id DESC").Limit(1).
+		Select()
+	return &override, err
+}
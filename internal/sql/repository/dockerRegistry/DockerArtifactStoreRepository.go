@@ -33,6 +33,10 @@ const (
 	REGISTRYTYPE_ARTIFACT_REGISTRY           = "artifact-registry"
 	REGISTRYTYPE_OTHER                       = "other"
 	REGISTRYTYPE_DOCKER_HUB                  = "docker-hub"
+	REGISTRYTYPE_HARBOR                      = "harbor"
+	REGISTRYTYPE_QUAY                        = "quay"
+	REGISTRYTYPE_ACR                         = "acr"
+	REGISTRYTYPE_GHCR                        = "ghcr"
 	JSON_KEY_USERNAME                 string = "_json_key"
 	STORAGE_ACTION_TYPE_PULL                 = "PULL"
 	STORAGE_ACTION_TYPE_PUSH                 = "PUSH"
@@ -43,24 +47,36 @@ const (
 
 type RegistryType string
 
+// RegistryCredentialType is the kind of credential issued by the registry provider, stored in username and password
+type RegistryCredentialType string
+
+const (
+	REGISTRY_CREDENTIAL_TYPE_USERNAME_PASSWORD     RegistryCredentialType = "USERNAME_PASSWORD"
+	REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT         RegistryCredentialType = "ROBOT_ACCOUNT"
+	REGISTRY_CREDENTIAL_TYPE_SERVICE_PRINCIPAL     RegistryCredentialType = "SERVICE_PRINCIPAL"
+	REGISTRY_CREDENTIAL_TYPE_TOKEN                 RegistryCredentialType = "TOKEN"
+	REGISTRY_CREDENTIAL_TYPE_PERSONAL_ACCESS_TOKEN RegistryCredentialType = "PERSONAL_ACCESS_TOKEN"
+)
+
 var OCI_REGISRTY_REPO_TYPE_LIST = []string{OCI_REGISRTY_REPO_TYPE_CONTAINER, OCI_REGISRTY_REPO_TYPE_CHART}
 
 type DockerArtifactStore struct {
-	tableName              struct{}     `sql:"docker_artifact_store" json:",omitempty"  pg:",discard_unknown_columns"`
-	Id                     string       `sql:"id,pk" json:"id,,omitempty"`
-	PluginId               string       `sql:"plugin_id,notnull" json:"pluginId,omitempty"`
-	RegistryURL            string       `sql:"registry_url" json:"registryUrl,omitempty"`
-	RegistryType           RegistryType `sql:"registry_type,notnull" json:"registryType,omitempty"`
-	IsOCICompliantRegistry bool         `sql:"is_oci_compliant_registry,notnull" json:"isOCICompliantRegistry,omitempty"`
-	AWSAccessKeyId         string       `sql:"aws_accesskey_id" json:"awsAccessKeyId,omitempty" `
-	AWSSecretAccessKey     string       `sql:"aws_secret_accesskey" json:"awsSecretAccessKey,omitempty"`
-	AWSRegion              string       `sql:"aws_region" json:"awsRegion,omitempty"`
-	Username               string       `sql:"username" json:"username,omitempty"`
-	Password               string       `sql:"password" json:"password,omitempty"`
-	IsDefault              bool         `sql:"is_default,notnull" json:"isDefault"`
-	Connection             string       `sql:"connection" json:"connection,omitempty"`
-	Cert                   string       `sql:"cert" json:"cert,omitempty"`
-	Active                 bool         `sql:"active,notnull" json:"active"`
+	tableName              struct{}               `sql:"docker_artifact_store" json:",omitempty"  pg:",discard_unknown_columns"`
+	Id                     string                 `sql:"id,pk" json:"id,,omitempty"`
+	PluginId               string                 `sql:"plugin_id,notnull" json:"pluginId,omitempty"`
+	RegistryURL            string                 `sql:"registry_url" json:"registryUrl,omitempty"`
+	RegistryType           RegistryType           `sql:"registry_type,notnull" json:"registryType,omitempty"`
+	IsOCICompliantRegistry bool                   `sql:"is_oci_compliant_registry,notnull" json:"isOCICompliantRegistry,omitempty"`
+	AWSAccessKeyId         string                 `sql:"aws_accesskey_id" json:"awsAccessKeyId,omitempty" `
+	AWSSecretAccessKey     string                 `sql:"aws_secret_accesskey" json:"awsSecretAccessKey,omitempty"`
+	AWSRegion              string                 `sql:"aws_region" json:"awsRegion,omitempty"`
+	Username               string                 `sql:"username" json:"username,omitempty"`
+	Password               string                 `sql:"password" json:"password,omitempty"`
+	CredentialType         RegistryCredentialType `sql:"credential_type" json:"credentialType,omitempty"`
+	IsDefault              bool                   `sql:"is_default,notnull" json:"isDefault"`
+	Connection             string                 `sql:"connection" json:"connection,omitempty"`
+	Cert                   string                 `sql:"cert" json:"cert,omitempty"`
+	Active                 bool                   `sql:"active,notnull" json:"active"`
 	IpsConfig              *DockerRegistryIpsConfig
 	OCIRegistryConfig      []*OCIRegistryConfig
 	sql.AuditLog
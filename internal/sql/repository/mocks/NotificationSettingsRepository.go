@@ -216,6 +216,110 @@ func (_m *NotificationSettingsRepository) FindNotificationSettingsByConfigIdAndC
 	return r0, r1
 }
 
+// FindNotificationSettingsWithCondition provides a mock function with given fields: eventTypeId, pipelineType
+func (_m *NotificationSettingsRepository) FindNotificationSettingsWithCondition(eventTypeId int, pipelineType string) ([]*repository.NotificationSettings, error) {
+	ret := _m.Called(eventTypeId, pipelineType)
+
+	var r0 []*repository.NotificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) ([]*repository.NotificationSettings, error)); ok {
+		return rf(eventTypeId, pipelineType)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) []*repository.NotificationSettings); ok {
+		r0 = rf(eventTypeId, pipelineType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.NotificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(eventTypeId, pipelineType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindNotificationSettingsWithDigestOrCooldown provides a mock function with given fields: eventTypeId, pipelineType
+func (_m *NotificationSettingsRepository) FindNotificationSettingsWithDigestOrCooldown(eventTypeId int, pipelineType string) ([]*repository.NotificationSettings, error) {
+	ret := _m.Called(eventTypeId, pipelineType)
+
+	var r0 []*repository.NotificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) ([]*repository.NotificationSettings, error)); ok {
+		return rf(eventTypeId, pipelineType)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) []*repository.NotificationSettings); ok {
+		r0 = rf(eventTypeId, pipelineType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.NotificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(eventTypeId, pipelineType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindNotificationSettingsByDestinations provides a mock function with given fields: eventTypeId, pipelineType, destinations
+func (_m *NotificationSettingsRepository) FindNotificationSettingsByDestinations(eventTypeId int, pipelineType string, destinations []string) ([]*repository.NotificationSettings, error) {
+	ret := _m.Called(eventTypeId, pipelineType, destinations)
+
+	var r0 []*repository.NotificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, []string) ([]*repository.NotificationSettings, error)); ok {
+		return rf(eventTypeId, pipelineType, destinations)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, []string) []*repository.NotificationSettings); ok {
+		r0 = rf(eventTypeId, pipelineType, destinations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.NotificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, []string) error); ok {
+		r1 = rf(eventTypeId, pipelineType, destinations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindNotificationSettingsByIds provides a mock function with given fields: ids
+func (_m *NotificationSettingsRepository) FindNotificationSettingsByIds(ids []int) ([]*repository.NotificationSettings, error) {
+	ret := _m.Called(ids)
+
+	var r0 []*repository.NotificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]*repository.NotificationSettings, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []*repository.NotificationSettings); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.NotificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindNotificationSettingsByViewId provides a mock function with given fields: viewId
 func (_m *NotificationSettingsRepository) FindNotificationSettingsByViewId(viewId int) ([]repository.NotificationSettings, error) {
 	ret := _m.Called(viewId)
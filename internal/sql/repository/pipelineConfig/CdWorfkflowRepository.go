@@ -44,6 +44,7 @@ type CdWorkflowRepository interface {
 	SaveWorkFlowRunner(wfr *CdWorkflowRunner) (*CdWorkflowRunner, error)
 	UpdateWorkFlowRunner(wfr *CdWorkflowRunner) error
 	UpdateIsArtifactUploaded(wfrId int, isArtifactUploaded workflow.ArtifactUploadedType) error
+	UpdateGitOpsPullRequestUrl(wfrId int, pullRequestUrl string) error
 	GetPreviousQueuedRunners(cdWfrId, pipelineId int) ([]*CdWorkflowRunner, error)
 	UpdateRunnerStatusToFailedForIds(errMsg string, triggeredBy int32, cdWfrIds ...int) error
 	UpdateWorkFlowRunnersWithTxn(wfrs []*CdWorkflowRunner, tx *pg.Tx) error
@@ -56,6 +57,8 @@ type CdWorkflowRepository interface {
 	FindLatestWfrByAppIdAndEnvironmentId(appId int, environmentId int) (*CdWorkflowRunner, error)
 	FindLastUnFailedProcessedRunner(appId int, environmentId int) (*CdWorkflowRunner, error)
 	IsLatestCDWfr(pipelineId, wfrId int) (bool, error)
+	// MarkRollbackTriggered claims the auto rollback of an unhealthy runner, it returns false when the rollback was already claimed
+	MarkRollbackTriggered(wfrId int) (bool, error)
 	FindLatestCdWorkflowRunnerByEnvironmentIdAndRunnerType(appId int, environmentId int, runnerType apiBean.WorkflowType) (CdWorkflowRunner, error)
 	FindAllTriggeredWorkflowCountInLast24Hour() (cdWorkflowCount int, err error)
 	GetConnection() *pg.DB
@@ -65,6 +68,7 @@ type CdWorkflowRepository interface {
 
 	FindByWorkflowIdAndRunnerType(ctx context.Context, wfId int, runnerType apiBean.WorkflowType) (CdWorkflowRunner, error)
 	FindLatestByPipelineIdAndRunnerType(pipelineId int, runnerType apiBean.WorkflowType) (CdWorkflowRunner, error)
+	FindLastHealthyDeployRunnerBeforeId(pipelineId int, wfrId int) (*CdWorkflowRunner, error)
 	SaveWorkFlows(wfs ...*CdWorkflow) error
 	IsLatestWf(pipelineId int, wfId int) (bool, error)
 	FindLatestCdWorkflowByPipelineId(pipelineIds []int) (*CdWorkflow, error)
@@ -125,10 +129,16 @@ type CdWorkflowRunner struct {
 	RefCdWorkflowRunnerId   int                             `sql:"ref_cd_workflow_runner_id,notnull"`
 	ImagePathReservationIds []int                           `sql:"image_path_reservation_ids" pg:",array,notnull"`
 	ReferenceId             *string                         `sql:"reference_id"`
+	TriggerType             string                          `sql:"trigger_type"`            // empty for user and pipeline triggered runners
+	GitOpsPullRequestUrl    string                          `sql:"gitops_pull_request_url"` // set when the values are committed through a pull request
 	CdWorkflow              *CdWorkflow
 	sql.AuditLog
 }
 
+func (c *CdWorkflowRunner) IsAutoRollback() bool {
+	return c.TriggerType == cdWorkflow.TriggerTypeAutoRollback
+}
+
 func (c *CdWorkflowRunner) GetIsArtifactUploaded() (isArtifactUploaded bool, isMigrationRequired bool) {
 	return workflow.IsArtifactUploaded(c.IsArtifactUploaded)
 }
@@ -430,6 +440,16 @@ func (impl *CdWorkflowRepositoryImpl) IsLatestCDWfr(pipelineId, wfrId int) (bool
 	return !ifAnySuccessorWfrExists, err
 }
 
+// MarkRollbackTriggered does not map the flag on CdWorkflowRunner, so that updates of a runner read earlier can not reset the claim
+func (impl *CdWorkflowRepositoryImpl) MarkRollbackTriggered(wfrId int) (bool, error) {
+	result, err := impl.dbConnection.
+		Exec("UPDATE cd_workflow_runner SET rollback_triggered = true WHERE id = ? AND rollback_triggered = false", wfrId)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
 func (impl *CdWorkflowRepositoryImpl) FindLastPreOrPostTriggeredByEnvironmentId(appId int, environmentId int) (CdWorkflowRunner, error) {
 	wfr := CdWorkflowRunner{}
 	err := impl.dbConnection.
@@ -469,6 +489,14 @@ func (impl *CdWorkflowRepositoryImpl) UpdateIsArtifactUploaded(wfrId int, isArti
 	return err
 }
 
+func (impl *CdWorkflowRepositoryImpl) UpdateGitOpsPullRequestUrl(wfrId int, pullRequestUrl string) error {
+	_, err := impl.dbConnection.Model((*CdWorkflowRunner)(nil)).
+		Set("gitops_pull_request_url = ?", pullRequestUrl).
+		Where("id = ?", wfrId).
+		Update()
+	return err
+}
+
 func (impl *CdWorkflowRepositoryImpl) GetPreviousQueuedRunners(cdWfrId, pipelineId int) ([]*CdWorkflowRunner, error) {
 	var cdWfrs []*CdWorkflowRunner
 	err := impl.dbConnection.Model(&cdWfrs).
@@ -576,6 +604,22 @@ func (impl *CdWorkflowRepositoryImpl) FindLatestByPipelineIdAndRunnerType(pipeli
 	return wfr, err
 }
 
+// FindLastHealthyDeployRunnerBeforeId returns the latest deploy runner of the pipeline, older than wfrId, which reached a healthy state
+func (impl *CdWorkflowRepositoryImpl) FindLastHealthyDeployRunnerBeforeId(pipelineId int, wfrId int) (*CdWorkflowRunner, error) {
+	wfr := &CdWorkflowRunner{}
+	err := impl.dbConnection.
+		Model(wfr).
+		Column("cd_workflow_runner.*", "CdWorkflow").
+		Where("cd_workflow.pipeline_id = ?", pipelineId).
+		Where("cd_workflow_runner.id < ?", wfrId).
+		Where("cd_workflow_runner.workflow_type = ?", apiBean.CD_WORKFLOW_TYPE_DEPLOY).
+		Where("cd_workflow_runner.status in (?)", pg.In(cdWorkflow.WfrHealthyStatusList)).
+		Order("cd_workflow_runner.id DESC").
+		Limit(1).
+		Select()
+	return wfr, err
+}
+
 func (impl *CdWorkflowRepositoryImpl) IsLatestWf(pipelineId int, wfId int) (bool, error) {
 	exists, err := impl.dbConnection.Model(&CdWorkflow{}).
 		Where("pipeline_id =?", pipelineId).
@@ -37,8 +37,11 @@ const (
 	TIMELINE_STATUS_DEPLOYMENT_REQUEST_VALIDATED TimelineStatus = "DEPLOYMENT_REQUEST_VALIDATED"
 	TIMELINE_STATUS_GIT_COMMIT                   TimelineStatus = "GIT_COMMIT"
 	TIMELINE_STATUS_GIT_COMMIT_FAILED            TimelineStatus = "GIT_COMMIT_FAILED"
-	TIMELINE_STATUS_ARGOCD_SYNC_INITIATED        TimelineStatus = "ARGOCD_SYNC_INITIATED"
-	TIMELINE_STATUS_ARGOCD_SYNC_COMPLETED        TimelineStatus = "ARGOCD_SYNC_COMPLETED"
+	// TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED - is not a terminal status.
+	// It indicates that the values commit is waiting on a pull request to be merged in the GitOps repository.
+	TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED TimelineStatus = "GIT_PULL_REQUEST_RAISED"
+	TIMELINE_STATUS_ARGOCD_SYNC_INITIATED   TimelineStatus = "ARGOCD_SYNC_INITIATED"
+	TIMELINE_STATUS_ARGOCD_SYNC_COMPLETED   TimelineStatus = "ARGOCD_SYNC_COMPLETED"
 	// TIMELINE_STATUS_DEPLOYMENT_TRIGGERED - is not a terminal status.
 	// It indicates that the deployment request has been served to Kubernetes CD agents (helm/ ArgoCD).
 	TIMELINE_STATUS_DEPLOYMENT_TRIGGERED TimelineStatus = "DEPLOYMENT_TRIGGERED"
@@ -51,6 +54,13 @@ const (
 	TIMELINE_STATUS_UNABLE_TO_FETCH_STATUS TimelineStatus = "UNABLE_TO_FETCH_STATUS"
 	TIMELINE_STATUS_DEPLOYMENT_SUPERSEDED  TimelineStatus = "DEPLOYMENT_SUPERSEDED"
 	TIMELINE_STATUS_MANIFEST_GENERATED     TimelineStatus = "HELM_PACKAGE_GENERATED" // TODO: remove as this deployment type is not supported
+
+	TIMELINE_STATUS_CANARY_ANALYSIS_STARTED TimelineStatus = "CANARY_ANALYSIS_STARTED"
+	TIMELINE_STATUS_CANARY_STEP_STARTED     TimelineStatus = "CANARY_STEP_STARTED"
+	TIMELINE_STATUS_CANARY_STEP_PASSED      TimelineStatus = "CANARY_STEP_PASSED"
+	TIMELINE_STATUS_CANARY_PROMOTED         TimelineStatus = "CANARY_PROMOTED"
+	TIMELINE_STATUS_CANARY_ANALYSIS_FAILED  TimelineStatus = "CANARY_ANALYSIS_FAILED"
+	TIMELINE_STATUS_CANARY_ROLLED_BACK      TimelineStatus = "CANARY_ROLLED_BACK"
 )
 
 const (
@@ -58,8 +68,12 @@ const (
 	TIMELINE_DESCRIPTION_VULNERABLE_IMAGE             string = "Deployment failed: Vulnerability policy violated."
 	TIMELINE_DESCRIPTION_DEPLOYMENT_REQUEST_VALIDATED string = "Deployment trigger request has been validated successfully."
 	TIMELINE_DESCRIPTION_ARGOCD_GIT_COMMIT            string = "Git commit done successfully."
+	TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_RAISED      string = "Pull request raised in GitOps repository. Waiting for it to be merged..."
+	TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_CLOSED      string = "Deployment failed: GitOps pull request was closed without merging."
 	TIMELINE_DESCRIPTION_ARGOCD_SYNC_INITIATED        string = "ArgoCD sync initiated."
 	TIMELINE_DESCRIPTION_ARGOCD_SYNC_COMPLETED        string = "ArgoCD sync completed."
 	TIMELINE_DESCRIPTION_DEPLOYMENT_COMPLETED         string = "Deployment has been performed successfully. Waiting for application to be healthy..."
 	TIMELINE_DESCRIPTION_DEPLOYMENT_SUPERSEDED        string = "This deployment is superseded."
+	TIMELINE_DESCRIPTION_CANARY_ANALYSIS_STARTED      string = "Canary analysis started."
+	TIMELINE_DESCRIPTION_CANARY_PROMOTED              string = "Canary analysis passed, release promoted."
 )
@@ -8,6 +8,12 @@ import (
 
 var WfrTerminalStatusList = []string{WorkflowAborted, WorkflowFailed, WorkflowSucceeded, bean.HIBERNATING, string(health.HealthStatusHealthy), string(health.HealthStatusDegraded)}
 
+// WfrHealthyStatusList are the runner statuses of a deployment which can be used as a rollback target
+var WfrHealthyStatusList = []string{WorkflowSucceeded, string(health.HealthStatusHealthy)}
+
+// WfrUnhealthyStatusList are the terminal runner statuses of a deployment which did not become healthy
+var WfrUnhealthyStatusList = []string{WorkflowFailed, WorkflowTimedOut, string(health.HealthStatusDegraded)}
+
 type WorkflowStatus int
 
 const (
@@ -42,6 +48,15 @@ func (a WorkflowStatus) String() string {
 
 var ErrorDeploymentSuperseded = errors.New(NEW_DEPLOYMENT_INITIATED)
 
+// TriggerTypeAutoRollback marks the deploy runners created by the system to roll back an unhealthy deployment
+const TriggerTypeAutoRollback = "auto-rollback"
+
+// TriggerTypeScheduled marks the deploy runners created by the cd trigger schedules of a pipeline
+const TriggerTypeScheduled = "SCHEDULED"
+
+// TriggerTypePromotion marks the deploy runners created by promoting an artifact from another environment
+const TriggerTypePromotion = "PROMOTION"
+
 const (
 	WORKFLOW_EXECUTOR_TYPE_AWF    = "AWF"
 	WORKFLOW_EXECUTOR_TYPE_SYSTEM = "SYSTEM"
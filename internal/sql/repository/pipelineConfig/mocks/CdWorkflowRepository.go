@@ -487,6 +487,36 @@ func (_m *CdWorkflowRepository) FindLastUnFailedProcessedRunner(appId int, envir
 	return r0, r1
 }
 
+// FindLastHealthyDeployRunnerBeforeId provides a mock function with given fields: pipelineId, wfrId
+func (_m *CdWorkflowRepository) FindLastHealthyDeployRunnerBeforeId(pipelineId int, wfrId int) (*pipelineConfig.CdWorkflowRunner, error) {
+	ret := _m.Called(pipelineId, wfrId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLastHealthyDeployRunnerBeforeId")
+	}
+
+	var r0 *pipelineConfig.CdWorkflowRunner
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*pipelineConfig.CdWorkflowRunner, error)); ok {
+		return rf(pipelineId, wfrId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) *pipelineConfig.CdWorkflowRunner); ok {
+		r0 = rf(pipelineId, wfrId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipelineConfig.CdWorkflowRunner)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(pipelineId, wfrId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindLatestByPipelineIdAndRunnerType provides a mock function with given fields: pipelineId, runnerType
 func (_m *CdWorkflowRepository) FindLatestByPipelineIdAndRunnerType(pipelineId int, runnerType bean.WorkflowType) (pipelineConfig.CdWorkflowRunner, error) {
 	ret := _m.Called(pipelineId, runnerType)
@@ -861,6 +891,34 @@ func (_m *CdWorkflowRepository) GetPreviousQueuedRunners(cdWfrId int, pipelineId
 	return r0, r1
 }
 
+// MarkRollbackTriggered provides a mock function with given fields: wfrId
+func (_m *CdWorkflowRepository) MarkRollbackTriggered(wfrId int) (bool, error) {
+	ret := _m.Called(wfrId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRollbackTriggered")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (bool, error)); ok {
+		return rf(wfrId)
+	}
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(wfrId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(wfrId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsLatestCDWfr provides a mock function with given fields: pipelineId, wfrId
 func (_m *CdWorkflowRepository) IsLatestCDWfr(pipelineId int, wfrId int) (bool, error) {
 	ret := _m.Called(pipelineId, wfrId)
@@ -1007,6 +1065,24 @@ func (_m *CdWorkflowRepository) UpdateIsArtifactUploaded(wfrId int, isArtifactUp
 	return r0
 }
 
+// UpdateGitOpsPullRequestUrl provides a mock function with given fields: wfrId, pullRequestUrl
+func (_m *CdWorkflowRepository) UpdateGitOpsPullRequestUrl(wfrId int, pullRequestUrl string) error {
+	ret := _m.Called(wfrId, pullRequestUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGitOpsPullRequestUrl")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(wfrId, pullRequestUrl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRunnerStatusToFailedForIds provides a mock function with given fields: errMsg, triggeredBy, cdWfrIds
 func (_m *CdWorkflowRepository) UpdateRunnerStatusToFailedForIds(errMsg string, triggeredBy int32, cdWfrIds ...int) error {
 	_va := make([]interface{}, len(cdWfrIds))
@@ -16,10 +16,52 @@
 
 package apiToken
 
-import "github.com/golang-jwt/jwt/v4"
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/golang-jwt/jwt/v4"
+)
 
 type ApiTokenCustomClaims struct {
 	Email   string `json:"email"`
 	Version string `json:"version"`
 	jwt.RegisteredClaims
 }
+
+// ApiTokenScopeWildcard matches any resource, action or object in an api-token scope
+const ApiTokenScopeWildcard = "*"
+
+type ApiTokenConfig struct {
+	// ScopeCacheExpirationInSecs is the max time for which a scope change on one instance can go unnoticed on the others
+	ScopeCacheExpirationInSecs int `env:"API_TOKEN_SCOPE_CACHE_EXPIRATION_IN_SEC" envDefault:"60"`
+	ExpiryNotifyBeforeDays     int `env:"API_TOKEN_EXPIRY_NOTIFY_BEFORE_DAYS" envDefault:"7"`
+}
+
+func GetApiTokenConfig() (*ApiTokenConfig, error) {
+	cfg := &ApiTokenConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse api token config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+type BulkRevokeApiTokenRequest struct {
+	Ids []int `json:"ids" validate:"required,min=1"`
+}
+
+type BulkRevokeApiTokenResponse struct {
+	Id      int    `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ApiTokenExpiryNotification describes an api-token nearing its expiry, it is sent to the configured notification channels
+type ApiTokenExpiryNotification struct {
+	Text         string `json:"text"`
+	ApiTokenId   int    `json:"apiTokenId"`
+	Name         string `json:"name"`
+	Owner        string `json:"owner"`
+	ExpireAtInMs int64  `json:"expireAtInMs"`
+}
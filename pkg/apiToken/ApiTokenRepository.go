@@ -22,18 +22,23 @@ import (
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"github.com/go-pg/pg"
 	"github.com/go-pg/pg/orm"
+	"time"
 )
 
 type ApiToken struct {
-	tableName    struct{} `sql:"api_token"`
-	Id           int      `sql:"id,pk"`
-	UserId       int32    `sql:"user_id, notnull"`
-	Name         string   `sql:"name, notnull"`
-	Version      int      `sql:"version, notnull"`
-	Description  string   `sql:"description, notnull"`
-	ExpireAtInMs int64    `sql:"expire_at_in_ms"`
-	Token        string   `sql:"token, notnull"`
-	User         *repository.UserModel
+	tableName        struct{}  `sql:"api_token"`
+	Id               int       `sql:"id,pk"`
+	UserId           int32     `sql:"user_id, notnull"`
+	Name             string    `sql:"name, notnull"`
+	Version          int       `sql:"version, notnull"`
+	Description      string    `sql:"description, notnull"`
+	ExpireAtInMs     int64     `sql:"expire_at_in_ms"`
+	Token            string    `sql:"token, notnull"`
+	LastUsedAt       time.Time `sql:"last_used_at"`
+	LastUsedByIp     string    `sql:"last_used_by_ip"`
+	RequestCount     int64     `sql:"request_count,notnull"`
+	ExpiryNotifiedAt time.Time `sql:"expiry_notified_at"`
+	User             *repository.UserModel
 	sql.AuditLog
 }
 
@@ -44,6 +49,9 @@ type ApiTokenRepository interface {
 	FindActiveById(id int) (*ApiToken, error)
 	FindByName(name string) (*ApiToken, error)
 	UpdateIf(apiToken *ApiToken, previousTokenVersion int) error
+	FindActiveByIds(ids []int) ([]*ApiToken, error)
+	FindAllActiveExpiringBetween(fromInMs int64, toInMs int64) ([]*ApiToken, error)
+	MarkExpiryNotified(ids []int, notifiedAt time.Time) error
 }
 
 type ApiTokenRepositoryImpl struct {
@@ -107,3 +115,45 @@ func (impl ApiTokenRepositoryImpl) FindByName(name string) (*ApiToken, error) {
 		Select()
 	return apiToken, err
 }
+
+func (impl ApiTokenRepositoryImpl) FindActiveByIds(ids []int) ([]*ApiToken, error) {
+	var apiTokens []*ApiToken
+	if len(ids) == 0 {
+		return apiTokens, nil
+	}
+	err := impl.dbConnection.Model(&apiTokens).
+		Column("api_token.*", "User").
+		Relation("User", func(q *orm.Query) (query *orm.Query, err error) {
+			return q.Where("active IS TRUE"), nil
+		}).
+		Where("api_token.id in (?)", pg.In(ids)).
+		Select()
+	return apiTokens, err
+}
+
+// FindAllActiveExpiringBetween returns the active api-tokens expiring in (fromInMs, toInMs] for which
+// expiry notification is not yet sent
+func (impl ApiTokenRepositoryImpl) FindAllActiveExpiringBetween(fromInMs int64, toInMs int64) ([]*ApiToken, error) {
+	var apiTokens []*ApiToken
+	err := impl.dbConnection.Model(&apiTokens).
+		Column("api_token.*", "User").
+		Relation("User", func(q *orm.Query) (query *orm.Query, err error) {
+			return q.Where("active IS TRUE"), nil
+		}).
+		Where("api_token.expire_at_in_ms > ?", fromInMs).
+		Where("api_token.expire_at_in_ms <= ?", toInMs).
+		Where("api_token.expiry_notified_at IS NULL").
+		Select()
+	return apiTokens, err
+}
+
+func (impl ApiTokenRepositoryImpl) MarkExpiryNotified(ids []int, notifiedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := impl.dbConnection.Model((*ApiToken)(nil)).
+		Set("expiry_notified_at = ?", notifiedAt).
+		Where("id in (?)", pg.In(ids)).
+		Update()
+	return err
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiToken
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+// ApiTokenScope restricts an api-token to the given resource/action/object, an api-token without scopes
+// keeps every permission of the roles assigned to it
+type ApiTokenScope struct {
+	tableName  struct{} `sql:"api_token_scope" pg:",discard_unknown_columns"`
+	Id         int      `sql:"id,pk"`
+	ApiTokenId int      `sql:"api_token_id,notnull"`
+	Resource   string   `sql:"resource,notnull"`
+	Action     string   `sql:"action,notnull"`
+	Object     string   `sql:"object,notnull"`
+	sql.AuditLog
+}
+
+type ApiTokenScopeRepository interface {
+	FindByApiTokenId(apiTokenId int) ([]*ApiTokenScope, error)
+	FindByApiTokenIds(apiTokenIds []int) ([]*ApiTokenScope, error)
+	FindByApiTokenName(name string) ([]*ApiTokenScope, error)
+	ReplaceForApiToken(apiTokenId int, scopes []*ApiTokenScope) error
+}
+
+type ApiTokenScopeRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewApiTokenScopeRepositoryImpl(dbConnection *pg.DB) *ApiTokenScopeRepositoryImpl {
+	return &ApiTokenScopeRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl ApiTokenScopeRepositoryImpl) FindByApiTokenId(apiTokenId int) ([]*ApiTokenScope, error) {
+	var scopes []*ApiTokenScope
+	err := impl.dbConnection.Model(&scopes).
+		Where("api_token_id = ?", apiTokenId).
+		Order("id").
+		Select()
+	return scopes, err
+}
+
+func (impl ApiTokenScopeRepositoryImpl) FindByApiTokenIds(apiTokenIds []int) ([]*ApiTokenScope, error) {
+	var scopes []*ApiTokenScope
+	if len(apiTokenIds) == 0 {
+		return scopes, nil
+	}
+	err := impl.dbConnection.Model(&scopes).
+		Where("api_token_id in (?)", pg.In(apiTokenIds)).
+		Order("id").
+		Select()
+	return scopes, err
+}
+
+// FindByApiTokenName returns the scopes of the api-token with the given name, names are matched
+// case-insensitively as the enforcer works on lower-cased emails
+func (impl ApiTokenScopeRepositoryImpl) FindByApiTokenName(name string) ([]*ApiTokenScope, error) {
+	var scopes []*ApiTokenScope
+	err := impl.dbConnection.Model(&scopes).
+		Join("INNER JOIN api_token at ON at.id = api_token_scope.api_token_id").
+		Where("LOWER(at.name) = LOWER(?)", name).
+		Order("api_token_scope.id").
+		Select()
+	return scopes, err
+}
+
+func (impl ApiTokenScopeRepositoryImpl) ReplaceForApiToken(apiTokenId int, scopes []*ApiTokenScope) error {
+	return impl.dbConnection.RunInTransaction(func(tx *pg.Tx) error {
+		_, err := tx.Model((*ApiTokenScope)(nil)).
+			Where("api_token_id = ?", apiTokenId).
+			Delete()
+		if err != nil {
+			return err
+		}
+		if len(scopes) == 0 {
+			return nil
+		}
+		return tx.Insert(&scopes)
+	})
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiToken
+
+import (
+	openapi "github.com/devtron-labs/devtron/api/openapi/openapiClient"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+type ApiTokenScopeService interface {
+	casbin.ApiTokenScopeChecker
+	GetScopesByApiTokenIds(apiTokenIds []int) (map[int][]openapi.ApiTokenScope, error)
+	SaveScopes(apiTokenId int, apiTokenName string, scopes []openapi.ApiTokenScope, userId int32) error
+}
+
+type ApiTokenScopeServiceImpl struct {
+	logger                  *zap.SugaredLogger
+	apiTokenScopeRepository ApiTokenScopeRepository
+	// scopeCache holds scopes by lower-cased api-token name, enforcement runs for every request
+	// and should not hit the database each time
+	scopeCache *cache.Cache
+}
+
+func NewApiTokenScopeServiceImpl(logger *zap.SugaredLogger, apiTokenScopeRepository ApiTokenScopeRepository,
+	enforcer casbin.Enforcer, apiTokenConfig *ApiTokenConfig) *ApiTokenScopeServiceImpl {
+	cacheExpiration := time.Duration(apiTokenConfig.ScopeCacheExpirationInSecs) * time.Second
+	impl := &ApiTokenScopeServiceImpl{
+		logger:                  logger,
+		apiTokenScopeRepository: apiTokenScopeRepository,
+		scopeCache:              cache.New(cacheExpiration, 5*time.Minute),
+	}
+	enforcer.SetApiTokenScopeChecker(impl)
+	return impl
+}
+
+func (impl *ApiTokenScopeServiceImpl) IsAllowedByScope(emailId string, resource string, action string, resourceItem string) bool {
+	apiTokenName, isApiToken := getApiTokenNameFromEmail(emailId)
+	if !isApiToken {
+		return true
+	}
+	scopes, err := impl.getScopesByApiTokenName(apiTokenName)
+	if err != nil {
+		// failing closed, a scoped token must never get the complete permissions of its roles
+		impl.logger.Errorw("error in getting api-token scopes, denying request", "apiTokenName", apiTokenName, "err", err)
+		return false
+	}
+	return isAllowedByScopes(scopes, resource, action, resourceItem)
+}
+
+func (impl *ApiTokenScopeServiceImpl) GetScopesByApiTokenIds(apiTokenIds []int) (map[int][]openapi.ApiTokenScope, error) {
+	scopeModels, err := impl.apiTokenScopeRepository.FindByApiTokenIds(apiTokenIds)
+	if err != nil {
+		impl.logger.Errorw("error in getting api-token scopes", "apiTokenIds", apiTokenIds, "err", err)
+		return nil, err
+	}
+	scopeModelsByApiTokenId := make(map[int][]*ApiTokenScope)
+	for _, scopeModel := range scopeModels {
+		scopeModelsByApiTokenId[scopeModel.ApiTokenId] = append(scopeModelsByApiTokenId[scopeModel.ApiTokenId], scopeModel)
+	}
+	scopesByApiTokenId := make(map[int][]openapi.ApiTokenScope, len(scopeModelsByApiTokenId))
+	for apiTokenId, models := range scopeModelsByApiTokenId {
+		scopesByApiTokenId[apiTokenId] = toScopeDtos(models)
+	}
+	return scopesByApiTokenId, nil
+}
+
+// SaveScopes replaces the scopes of the api-token, an empty list removes every restriction from the token
+func (impl *ApiTokenScopeServiceImpl) SaveScopes(apiTokenId int, apiTokenName string, scopes []openapi.ApiTokenScope, userId int32) error {
+	err := impl.apiTokenScopeRepository.ReplaceForApiToken(apiTokenId, toScopeModels(apiTokenId, scopes, userId))
+	if err != nil {
+		impl.logger.Errorw("error in saving api-token scopes", "apiTokenId", apiTokenId, "err", err)
+		return err
+	}
+	impl.scopeCache.Delete(strings.ToLower(apiTokenName))
+	return nil
+}
+
+func (impl *ApiTokenScopeServiceImpl) getScopesByApiTokenName(apiTokenName string) ([]*ApiTokenScope, error) {
+	cacheKey := strings.ToLower(apiTokenName)
+	if cachedScopes, found := impl.scopeCache.Get(cacheKey); found {
+		return cachedScopes.([]*ApiTokenScope), nil
+	}
+	scopes, err := impl.apiTokenScopeRepository.FindByApiTokenName(apiTokenName)
+	if err != nil {
+		return nil, err
+	}
+	impl.scopeCache.SetDefault(cacheKey, scopes)
+	return scopes, nil
+}
@@ -41,6 +41,9 @@ type ApiTokenService interface {
 	UpdateApiToken(apiTokenId int, request *openapi.UpdateApiTokenRequest, updatedBy int32) (*openapi.UpdateApiTokenResponse, error)
 	DeleteApiToken(apiTokenId int, deletedBy int32) (*openapi.ActionResponse, error)
 	GetAllApiTokensForWebhook(projectName string, environmentName string, appName string, auth func(token string, projectObject string, envObject string) bool) ([]*openapi.ApiToken, error)
+	BulkDeleteApiTokens(apiTokenIds []int, deletedBy int32) []*BulkRevokeApiTokenResponse
+	GetExpiringApiTokens() ([]*ApiTokenExpiryNotification, error)
+	MarkExpiryNotified(apiTokenIds []int) error
 }
 
 type ApiTokenServiceImpl struct {
@@ -49,16 +52,20 @@ type ApiTokenServiceImpl struct {
 	userService           user2.UserService
 	userAuditService      user2.UserAuditService
 	apiTokenRepository    ApiTokenRepository
+	apiTokenScopeService  ApiTokenScopeService
+	apiTokenConfig        *ApiTokenConfig
 }
 
 func NewApiTokenServiceImpl(logger *zap.SugaredLogger, apiTokenSecretService ApiTokenSecretService, userService user2.UserService, userAuditService user2.UserAuditService,
-	apiTokenRepository ApiTokenRepository) *ApiTokenServiceImpl {
+	apiTokenRepository ApiTokenRepository, apiTokenScopeService ApiTokenScopeService, apiTokenConfig *ApiTokenConfig) *ApiTokenServiceImpl {
 	return &ApiTokenServiceImpl{
 		logger:                logger,
 		apiTokenSecretService: apiTokenSecretService,
 		userService:           userService,
 		userAuditService:      userAuditService,
 		apiTokenRepository:    apiTokenRepository,
+		apiTokenScopeService:  apiTokenScopeService,
+		apiTokenConfig:        apiTokenConfig,
 	}
 }
 
@@ -123,15 +130,18 @@ func (impl ApiTokenServiceImpl) GetAllActiveApiTokens() ([]*openapi.ApiToken, er
 		return nil, err
 	}
 
+	apiTokenIds := make([]int, 0, len(apiTokensFromDb))
+	for _, apiTokenFromDb := range apiTokensFromDb {
+		apiTokenIds = append(apiTokenIds, apiTokenFromDb.Id)
+	}
+	scopesByApiTokenId, err := impl.apiTokenScopeService.GetScopesByApiTokenIds(apiTokenIds)
+	if err != nil {
+		return nil, err
+	}
+
 	var apiTokens []*openapi.ApiToken
 	for _, apiTokenFromDb := range apiTokensFromDb {
 		userId := apiTokenFromDb.User.Id
-		latestAuditLog, err := impl.userAuditService.GetLatestByUserId(userId)
-		if err != nil {
-			impl.logger.Errorw("error while getting latest audit log", "error", err)
-			return nil, err
-		}
-
 		apiTokenIdI32 := int32(apiTokenFromDb.Id)
 		updatedAtStr := apiTokenFromDb.UpdatedOn.String()
 		apiToken := &openapi.ApiToken{
@@ -143,11 +153,27 @@ func (impl ApiTokenServiceImpl) GetAllActiveApiTokens() ([]*openapi.ApiToken, er
 			ExpireAtInMs:   &apiTokenFromDb.ExpireAtInMs,
 			Token:          &apiTokenFromDb.Token,
 			UpdatedAt:      &updatedAtStr,
+			RequestCount:   &apiTokenFromDb.RequestCount,
+		}
+		if scopes, ok := scopesByApiTokenId[apiTokenFromDb.Id]; ok {
+			apiToken.SetScopes(scopes)
 		}
-		if latestAuditLog != nil {
-			lastUsedAtStr := latestAuditLog.CreatedOn.String()
+		if !apiTokenFromDb.LastUsedAt.IsZero() {
+			lastUsedAtStr := apiTokenFromDb.LastUsedAt.String()
 			apiToken.LastUsedAt = &lastUsedAtStr
-			apiToken.LastUsedByIp = &latestAuditLog.ClientIp
+			apiToken.LastUsedByIp = &apiTokenFromDb.LastUsedByIp
+		} else {
+			// usage of tokens used before usage tracking was added on api_token is only found in user audit
+			latestAuditLog, err := impl.userAuditService.GetLatestByUserId(userId)
+			if err != nil {
+				impl.logger.Errorw("error while getting latest audit log", "error", err)
+				return nil, err
+			}
+			if latestAuditLog != nil {
+				lastUsedAtStr := latestAuditLog.CreatedOn.String()
+				apiToken.LastUsedAt = &lastUsedAtStr
+				apiToken.LastUsedByIp = &latestAuditLog.ClientIp
+			}
 		}
 		apiTokens = append(apiTokens, apiToken)
 	}
@@ -163,6 +189,9 @@ func (impl ApiTokenServiceImpl) CreateApiToken(request *openapi.CreateApiTokenRe
 	if invalidCharsInApiTokenName.MatchString(name) {
 		return nil, errors.New(fmt.Sprintf("name '%s' contains either white-space or comma, which is not allowed", name))
 	}
+	if err := validateScopes(request.GetScopes()); err != nil {
+		return nil, err
+	}
 
 	// step-1 - check if the name exists, if exists with active user - throw error
 	apiToken, err := impl.apiTokenRepository.FindByName(name)
@@ -257,6 +286,12 @@ func (impl ApiTokenServiceImpl) CreateApiToken(request *openapi.CreateApiTokenRe
 		return nil, err
 	}
 
+	// step-6 - Save scopes, replacing the ones of the previous token with the same name
+	err = impl.apiTokenScopeService.SaveScopes(apiTokenSaveRequest.Id, name, request.GetScopes(), createdBy)
+	if err != nil {
+		return nil, err
+	}
+
 	success := true
 	return &openapi.CreateApiTokenResponse{
 		Success:        &success,
@@ -268,6 +303,9 @@ func (impl ApiTokenServiceImpl) CreateApiToken(request *openapi.CreateApiTokenRe
 
 func (impl ApiTokenServiceImpl) UpdateApiToken(apiTokenId int, request *openapi.UpdateApiTokenRequest, updatedBy int32) (*openapi.UpdateApiTokenResponse, error) {
 	impl.logger.Infow("Updating API token", "request", request, "updatedBy", updatedBy, "apiTokenId", apiTokenId)
+	if err := validateScopes(request.GetScopes()); err != nil {
+		return nil, err
+	}
 
 	// step-1 - check if the api-token exists, if not exists - throw error
 	apiToken, err := impl.apiTokenRepository.FindActiveById(apiTokenId)
@@ -291,6 +329,8 @@ func (impl ApiTokenServiceImpl) UpdateApiToken(apiTokenId int, request *openapi.
 		}
 		apiToken.Token = token
 		apiToken.Version = tokenVersion
+		// notify again for the new expiry
+		apiToken.ExpiryNotifiedAt = time.Time{}
 	}
 
 	// step-3 - update in DB
@@ -306,6 +346,14 @@ func (impl ApiTokenServiceImpl) UpdateApiToken(apiTokenId int, request *openapi.
 		return nil, err
 	}
 
+	// step-4 - update scopes, existing scopes are kept if not present in request
+	if request.HasScopes() {
+		err = impl.apiTokenScopeService.SaveScopes(apiToken.Id, apiToken.Name, request.GetScopes(), updatedBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	success := true
 	return &openapi.UpdateApiTokenResponse{
 		Success: &success,
@@ -353,6 +401,67 @@ func (impl ApiTokenServiceImpl) DeleteApiToken(apiTokenId int, deletedBy int32)
 
 }
 
+func (impl ApiTokenServiceImpl) BulkDeleteApiTokens(apiTokenIds []int, deletedBy int32) []*BulkRevokeApiTokenResponse {
+	impl.logger.Infow("Bulk deleting API tokens", "deletedBy", deletedBy, "apiTokenIds", apiTokenIds)
+	responses := make([]*BulkRevokeApiTokenResponse, 0, len(apiTokenIds))
+	processedIds := make(map[int]bool, len(apiTokenIds))
+	for _, apiTokenId := range apiTokenIds {
+		if processedIds[apiTokenId] {
+			continue
+		}
+		processedIds[apiTokenId] = true
+		response := &BulkRevokeApiTokenResponse{Id: apiTokenId}
+		// revoking every token independently, one failure should not leave the remaining tokens active
+		_, err := impl.DeleteApiToken(apiTokenId, deletedBy)
+		if err != nil {
+			response.Error = err.Error()
+		} else {
+			response.Success = true
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// GetExpiringApiTokens returns the api-tokens of active users expiring within the configured days which were not notified yet
+func (impl ApiTokenServiceImpl) GetExpiringApiTokens() ([]*ApiTokenExpiryNotification, error) {
+	now := time.Now()
+	notifyTill := now.AddDate(0, 0, impl.apiTokenConfig.ExpiryNotifyBeforeDays)
+	apiTokens, err := impl.apiTokenRepository.FindAllActiveExpiringBetween(now.UnixMilli(), notifyTill.UnixMilli())
+	if err != nil {
+		impl.logger.Errorw("error while getting expiring api tokens", "error", err)
+		return nil, err
+	}
+	notifications := make([]*ApiTokenExpiryNotification, 0, len(apiTokens))
+	for _, apiToken := range apiTokens {
+		if apiToken.User == nil || !apiToken.User.Active {
+			continue
+		}
+		owner, err := impl.userService.GetEmailById(apiToken.CreatedBy)
+		if err != nil {
+			impl.logger.Errorw("error while getting api token owner", "apiTokenId", apiToken.Id, "createdBy", apiToken.CreatedBy, "error", err)
+			continue
+		}
+		notifications = append(notifications, &ApiTokenExpiryNotification{
+			Text:         getExpiryNotificationText(apiToken.Name, owner, apiToken.ExpireAtInMs),
+			ApiTokenId:   apiToken.Id,
+			Name:         apiToken.Name,
+			Owner:        owner,
+			ExpireAtInMs: apiToken.ExpireAtInMs,
+		})
+	}
+	return notifications, nil
+}
+
+// MarkExpiryNotified stops the expiry notifications of the api-tokens until their expiry is changed
+func (impl ApiTokenServiceImpl) MarkExpiryNotified(apiTokenIds []int) error {
+	err := impl.apiTokenRepository.MarkExpiryNotified(apiTokenIds, time.Now())
+	if err != nil {
+		impl.logger.Errorw("error while marking api tokens as notified", "apiTokenIds", apiTokenIds, "error", err)
+	}
+	return err
+}
+
 func (impl ApiTokenServiceImpl) createApiJwtToken(email string, tokenVersion int, expireAtInMs int64) (string, error) {
 	registeredClaims, secretByteArr, err := impl.setRegisteredClaims(expireAtInMs)
 	if err != nil {
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiToken
+
+import (
+	"fmt"
+	openapi "github.com/devtron-labs/devtron/api/openapi/openapiClient"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"strings"
+	"time"
+)
+
+func validateScopes(scopes []openapi.ApiTokenScope) error {
+	for index, scope := range scopes {
+		if len(strings.TrimSpace(scope.GetResource())) == 0 || len(strings.TrimSpace(scope.GetAction())) == 0 {
+			return fmt.Errorf("resource and action are required in scope at index %d", index)
+		}
+		object := scope.GetObject()
+		if len(object) > 0 && object != ApiTokenScopeWildcard && strings.Contains(object, "//") {
+			return fmt.Errorf("object '%s' in scope at index %d has an empty part", object, index)
+		}
+	}
+	return nil
+}
+
+func toScopeModels(apiTokenId int, scopes []openapi.ApiTokenScope, userId int32) []*ApiTokenScope {
+	scopeModels := make([]*ApiTokenScope, 0, len(scopes))
+	for _, scope := range scopes {
+		object := strings.ToLower(strings.TrimSpace(scope.GetObject()))
+		if len(object) == 0 {
+			object = ApiTokenScopeWildcard
+		}
+		scopeModels = append(scopeModels, &ApiTokenScope{
+			ApiTokenId: apiTokenId,
+			Resource:   strings.ToLower(strings.TrimSpace(scope.GetResource())),
+			Action:     strings.ToLower(strings.TrimSpace(scope.GetAction())),
+			Object:     object,
+			AuditLog:   sql.NewDefaultAuditLog(userId),
+		})
+	}
+	return scopeModels
+}
+
+func toScopeDtos(scopeModels []*ApiTokenScope) []openapi.ApiTokenScope {
+	scopes := make([]openapi.ApiTokenScope, 0, len(scopeModels))
+	for _, scopeModel := range scopeModels {
+		scope := openapi.ApiTokenScope{}
+		scope.SetResource(scopeModel.Resource)
+		scope.SetAction(scopeModel.Action)
+		scope.SetObject(scopeModel.Object)
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// isAllowedByScopes checks if any of the scopes allows the action on resourceItem, an empty scope list allows everything
+func isAllowedByScopes(scopes []*ApiTokenScope, resource string, action string, resourceItem string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		if !matchesScopeValue(scope.Resource, resource) || !matchesScopeValue(scope.Action, action) {
+			continue
+		}
+		if casbin.MatchKeyByPart(strings.ToLower(resourceItem), scope.Object) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesScopeValue(scopeValue string, value string) bool {
+	return scopeValue == ApiTokenScopeWildcard || strings.EqualFold(scopeValue, value)
+}
+
+// getApiTokenNameFromEmail returns the api-token name for api-token user emails
+func getApiTokenNameFromEmail(emailId string) (string, bool) {
+	prefix := strings.ToLower(userBean.API_TOKEN_USER_EMAIL_PREFIX)
+	if !strings.HasPrefix(strings.ToLower(emailId), prefix) {
+		return "", false
+	}
+	return emailId[len(prefix):], true
+}
+
+func getExpiryNotificationText(name string, owner string, expireAtInMs int64) string {
+	expireAt := time.UnixMilli(expireAtInMs).UTC().Format(time.RFC1123)
+	return fmt.Sprintf("api-token '%s' created by %s expires on %s, please rotate it before it expires", name, owner, expireAt)
+}
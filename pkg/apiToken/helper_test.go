@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiToken
+
+import (
+	openapi "github.com/devtron-labs/devtron/api/openapi/openapiClient"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newScope(resource, action, object string) openapi.ApiTokenScope {
+	scope := openapi.ApiTokenScope{}
+	scope.SetResource(resource)
+	scope.SetAction(action)
+	scope.SetObject(object)
+	return scope
+}
+
+func TestIsAllowedByScopes(t *testing.T) {
+	// trigger CD of my-app in staging only
+	scopes := toScopeModels(1, []openapi.ApiTokenScope{
+		newScope("applications", "trigger", "*/My-App"),
+		newScope("environment", "trigger", "staging/my-app"),
+	}, 1)
+
+	assert.True(t, isAllowedByScopes(scopes, "applications", "trigger", "team-a/my-app"))
+	assert.True(t, isAllowedByScopes(scopes, "environment", "trigger", "staging/my-app"))
+	assert.False(t, isAllowedByScopes(scopes, "environment", "trigger", "prod/my-app"))
+	assert.False(t, isAllowedByScopes(scopes, "applications", "trigger", "team-a/other-app"))
+	assert.False(t, isAllowedByScopes(scopes, "applications", "update", "team-a/my-app"))
+	assert.False(t, isAllowedByScopes(scopes, "global", "update", "*"))
+
+	wildcardScopes := toScopeModels(1, []openapi.ApiTokenScope{newScope("*", "get", "")}, 1)
+	assert.True(t, isAllowedByScopes(wildcardScopes, "cluster", "get", "default_cluster"))
+	assert.False(t, isAllowedByScopes(wildcardScopes, "cluster", "delete", "default_cluster"))
+
+	assert.True(t, isAllowedByScopes(nil, "global", "update", "*"))
+}
+
+func TestValidateScopes(t *testing.T) {
+	assert.NoError(t, validateScopes([]openapi.ApiTokenScope{newScope("applications", "trigger", "")}))
+	assert.Error(t, validateScopes([]openapi.ApiTokenScope{newScope("applications", " ", "*")}))
+	assert.Error(t, validateScopes([]openapi.ApiTokenScope{newScope("environment", "trigger", "staging//app")}))
+}
+
+func TestGetApiTokenNameFromEmail(t *testing.T) {
+	name, ok := getApiTokenNameFromEmail("api-token:ci:deployer")
+	assert.True(t, ok)
+	assert.Equal(t, "ci:deployer", name)
+
+	_, ok = getApiTokenNameFromEmail("admin@example.com")
+	assert.False(t, ok)
+}
@@ -32,6 +32,7 @@ import (
 	commonBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/common/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate"
 	bean4 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
 	"io/ioutil"
@@ -125,6 +126,7 @@ type AppServiceImpl struct {
 	deploymentTemplateService              deploymentTemplate.DeploymentTemplateService
 	appListingService                      AppListingService
 	deploymentConfigService                common2.DeploymentConfigService
+	gitOpsMonorepoService                  monorepo.GitOpsMonorepoService
 }
 
 type AppService interface {
@@ -166,7 +168,8 @@ func NewAppService(
 	gitOpsConfigReadService config.GitOpsConfigReadService, gitOperationService git.GitOperationService,
 	deploymentTemplateService deploymentTemplate.DeploymentTemplateService,
 	appListingService AppListingService,
-	deploymentConfigService common2.DeploymentConfigService) *AppServiceImpl {
+	deploymentConfigService common2.DeploymentConfigService,
+	gitOpsMonorepoService monorepo.GitOpsMonorepoService) *AppServiceImpl {
 	appServiceImpl := &AppServiceImpl{
 		environmentConfigRepository:            environmentConfigRepository,
 		mergeUtil:                              mergeUtil,
@@ -198,6 +201,7 @@ func NewAppService(
 		deploymentTemplateService:              deploymentTemplateService,
 		appListingService:                      appListingService,
 		deploymentConfigService:                deploymentConfigService,
+		gitOpsMonorepoService:                  gitOpsMonorepoService,
 	}
 	return appServiceImpl
 }
@@ -517,6 +521,10 @@ func (impl *AppServiceImpl) CheckIfPipelineUpdateEventIsValid(argoAppName, gitHa
 		impl.logger.Errorw("error in getting latest pipelineOverride by appId and envId", "err", err, "appId", pipeline.AppId, "envId", pipeline.EnvironmentId)
 		return isValid, pipeline, cdWfr, pipelineOverride, err
 	}
+	if len(pipelineOverride.GitHash) == 0 {
+		// values of the latest release are not committed yet (i.e. GitOps pull request is not merged), so we will drop this event
+		return isValid, pipeline, cdWfr, pipelineOverride, nil
+	}
 	if gitHash != "" && pipelineOverride.GitHash != gitHash {
 		pipelineOverrideByHash, err := impl.pipelineOverrideRepository.FindByPipelineTriggerGitHash(gitHash)
 		if err != nil {
@@ -900,6 +908,19 @@ func (impl *AppServiceImpl) CreateGitOpsRepo(app *app.App, userId int32) (gitops
 	if err != nil && pg.ErrNoRows != err {
 		return "", nil, err
 	}
+	sharedRepo, err := impl.gitOpsMonorepoService.FindForApp(app.Id)
+	if err != nil {
+		impl.logger.Errorw("error in getting shared gitops repository for app", "appId", app.Id, "err", err)
+		return "", nil, err
+	}
+	if sharedRepo != nil {
+		// the directory of the app in the shared repository is resolved per environment on deployment
+		chartGitAttr = &commonBean.ChartGitAttribute{
+			RepoUrl:       sharedRepo.RepoUrl,
+			ChartLocation: filepath.Join(chart.ReferenceTemplate, chart.ChartVersion),
+		}
+		return sharedRepo.RepoName, chartGitAttr, nil
+	}
 	gitOpsRepoName := impl.gitOpsConfigReadService.GetGitOpsRepoName(app.AppName)
 	chartGitAttr, err = impl.gitOperationService.CreateGitRepositoryForDevtronApp(context.Background(), gitOpsRepoName, userId)
 	if err != nil {
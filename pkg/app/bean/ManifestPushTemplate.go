@@ -24,6 +24,7 @@ const Workflows = "workflows"
 type ManifestPushTemplate struct {
 	WorkflowRunnerId       int
 	AppId                  int
+	PipelineId             int
 	ChartRefId             int
 	EnvironmentId          int
 	EnvironmentName        string
@@ -46,13 +47,19 @@ type ManifestPushResponse struct {
 	NewGitRepoUrl string
 	CommitHash    string
 	CommitTime    time.Time
-	Error         error
+	// PullRequestUrl is set when the values are committed through a pull request, CommitHash is empty until it is merged
+	PullRequestUrl string
+	Error          error
 }
 
 func (m ManifestPushResponse) IsNewGitRepoConfigured() bool {
 	return len(m.NewGitRepoUrl) != 0
 }
 
+func (m ManifestPushResponse) IsPullRequestRaised() bool {
+	return len(m.PullRequestUrl) != 0
+}
+
 type HelmRepositoryConfig struct {
 	repositoryName        string
 	containerRegistryName string
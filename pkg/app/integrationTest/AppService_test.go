@@ -155,7 +155,7 @@ func InitAppService() *app2.AppServiceImpl {
 	helmAppService := client.NewHelmAppServiceImpl(logger, clusterService, helmAppClient, nil, nil, nil, serverEnvConfig, nil, nil, nil, nil, nil, nil, nil, nil)
 	moduleService := module.NewModuleServiceImpl(logger, serverEnvConfig, moduleRepositoryImpl, moduleActionAuditLogRepository, helmAppService, nil, nil, nil, nil, nil, nil, nil)
 	eventClient := client1.NewEventRESTClientImpl(logger, httpClient, eventClientConfig, pubSubClient, ciPipelineRepositoryImpl,
-		pipelineRepository, attributesRepositoryImpl, moduleService)
+		pipelineRepository, attributesRepositoryImpl, moduleService, nil, nil, nil, nil, nil, nil)
 	cdWorkflowRepository := pipelineConfig.NewCdWorkflowRepositoryImpl(dbConnection, logger)
 	ciWorkflowRepository := pipelineConfig.NewCiWorkflowRepositoryImpl(dbConnection, logger)
 	ciPipelineMaterialRepository := pipelineConfig.NewCiPipelineMaterialRepositoryImpl(dbConnection, logger)
@@ -31,6 +31,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/attributes"
 	"github.com/devtron-labs/devtron/pkg/bean"
 	"github.com/devtron-labs/devtron/pkg/chart"
+	draftBean "github.com/devtron-labs/devtron/pkg/configDraft/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
 	bean3 "github.com/devtron-labs/devtron/pkg/pipeline/bean"
@@ -418,9 +419,12 @@ func (impl *AppCloneServiceImpl) CreateEnvCm(ctx context.Context, oldAppId, newA
 				UserId:        userId,
 				Id:            thisCm.Id,
 			}
-			thisCm, err = impl.configMapService.CMEnvironmentAddUpdate(newCm)
+			var draft *draftBean.ConfigDraftDto
+			thisCm, draft, err = impl.configMapService.CMEnvironmentAddUpdate(newCm)
 			if err != nil {
 				return nil, err
+			} else if draft != nil {
+				impl.logger.Infow("cloned config map saved as a draft as the configs are protected", "appId", newAppId, "envId", refEnv.EnvironmentId, "draftId", draft.Id)
 			}
 		}
 	}
@@ -464,9 +468,12 @@ func (impl *AppCloneServiceImpl) CreateEnvSecret(ctx context.Context, oldAppId,
 				UserId:        userId,
 				Id:            thisCm.Id,
 			}
-			thisCm, err = impl.configMapService.CSEnvironmentAddUpdate(newCm)
+			var draft *draftBean.ConfigDraftDto
+			thisCm, draft, err = impl.configMapService.CSEnvironmentAddUpdate(newCm)
 			if err != nil {
 				return nil, err
+			} else if draft != nil {
+				impl.logger.Infow("cloned secret saved as a draft as the configs are protected", "appId", newAppId, "envId", refEnv.EnvironmentId, "draftId", draft.Id)
 			}
 		}
 	}
@@ -513,7 +520,7 @@ func (impl *AppCloneServiceImpl) createEnvOverride(oldAppId, newAppId int, userI
 			IsBasicViewLocked: refEnvProperties.EnvironmentConfig.IsBasicViewLocked,
 			CurrentViewEditor: refEnvProperties.EnvironmentConfig.CurrentViewEditor,
 		}
-		createResp, err := impl.propertiesConfigService.CreateEnvironmentProperties(newAppId, envPropertiesReq)
+		createResp, draft, err := impl.propertiesConfigService.CreateEnvironmentProperties(newAppId, envPropertiesReq)
 		if err != nil {
 			if err.Error() == bean2.NOCHARTEXIST {
 				templateRequest := chart.TemplateRequest{
@@ -529,10 +536,13 @@ func (impl *AppCloneServiceImpl) createEnvOverride(oldAppId, newAppId int, userI
 					impl.logger.Error(err)
 					return nil, nil
 				}
-				createResp, err = impl.propertiesConfigService.CreateEnvironmentProperties(newAppId, envPropertiesReq)
+				createResp, draft, err = impl.propertiesConfigService.CreateEnvironmentProperties(newAppId, envPropertiesReq)
 
 			}
 		}
+		if draft != nil {
+			impl.logger.Infow("cloned env override saved as a draft as the configs are protected", "appId", newAppId, "envId", refEnv.EnvironmentId, "draftId", draft.Id)
+		}
 		impl.logger.Debugw("env override create res", "createRes", createResp)
 		//create object
 		//save object
@@ -23,6 +23,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/apis/devtron/v1"
 	"github.com/devtron-labs/devtron/pkg/cluster"
 	bean2 "github.com/devtron-labs/devtron/pkg/cluster/repository/bean"
+	draftBean "github.com/devtron-labs/devtron/pkg/configDraft/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
 	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
 	"github.com/devtron-labs/devtron/util"
@@ -146,9 +147,11 @@ func executeDataHolderClone(impl DataHolderActionImpl, holder *v1.DataHolder, da
 
 	if strings.ToLower(dataType) == v1.ConfigMap {
 		if envDest != nil {
-			if configData, err = impl.configMapService.CMEnvironmentAddUpdate(configData); err != nil {
+			var draft *draftBean.ConfigDraftDto
+			if configData, draft, err = impl.configMapService.CMEnvironmentAddUpdate(configData); err != nil {
 				return err
 			}
+			impl.logSavedDraft(draft, dataType)
 		} else {
 			if configData, err = impl.configMapService.CMGlobalAddUpdate(configData); err != nil {
 				return err
@@ -156,9 +159,11 @@ func executeDataHolderClone(impl DataHolderActionImpl, holder *v1.DataHolder, da
 		}
 	} else {
 		if envDest != nil {
-			if configData, err = impl.configMapService.CSEnvironmentAddUpdate(configData); err != nil {
+			var draft *draftBean.ConfigDraftDto
+			if configData, draft, err = impl.configMapService.CSEnvironmentAddUpdate(configData); err != nil {
 				return err
 			}
+			impl.logSavedDraft(draft, dataType)
 		} else {
 			if configData, err = impl.configMapService.CSGlobalAddUpdate(configData); err != nil {
 				return err
@@ -228,16 +233,21 @@ func executeDataHolderDelete(impl DataHolderActionImpl, holder *v1.DataHolder, d
 			if len(holder.Data) > 0 {
 				err = deleteKeys(func() (request *bean.ConfigDataRequest, err error) {
 					return impl.configMapService.CMEnvironmentFetch(app.Id, env.Id)
-				}, impl.configMapService.CMEnvironmentAddUpdate, holder, dataType)
+				}, func(request *bean.ConfigDataRequest) (*bean.ConfigDataRequest, error) {
+					configData, draft, err := impl.configMapService.CMEnvironmentAddUpdate(request)
+					impl.logSavedDraft(draft, dataType)
+					return configData, err
+				}, holder, dataType)
 				if err != nil {
 					return err
 				}
 			} else {
-				deleted, err := impl.configMapService.CMEnvironmentDeleteByAppIdAndEnvId(*holder.Destination.ConfigMap, app.Id, env.Id, 1)
+				deleted, draft, err := impl.configMapService.CMEnvironmentDeleteByAppIdAndEnvId(*holder.Destination.ConfigMap, app.Id, env.Id, 1)
 				if err != nil {
 					return err
 				}
-				if !deleted {
+				impl.logSavedDraft(draft, dataType)
+				if !deleted && draft == nil {
 					return fmt.Errorf("unable to delete %s named %s", v1.ConfigMap, *holder.Destination.ConfigMap)
 				}
 			}
@@ -245,16 +255,21 @@ func executeDataHolderDelete(impl DataHolderActionImpl, holder *v1.DataHolder, d
 			if len(holder.Data) > 0 {
 				err = deleteKeys(func() (request *bean.ConfigDataRequest, err error) {
 					return impl.configMapService.CSEnvironmentFetch(app.Id, env.Id)
-				}, impl.configMapService.CSEnvironmentAddUpdate, holder, dataType)
+				}, func(request *bean.ConfigDataRequest) (*bean.ConfigDataRequest, error) {
+					configData, draft, err := impl.configMapService.CSEnvironmentAddUpdate(request)
+					impl.logSavedDraft(draft, dataType)
+					return configData, err
+				}, holder, dataType)
 				if err != nil {
 					return err
 				}
 			} else {
-				deleted, err := impl.configMapService.CSEnvironmentDeleteByAppIdAndEnvId(*holder.Destination.Secret, app.Id, env.Id, 1)
+				deleted, draft, err := impl.configMapService.CSEnvironmentDeleteByAppIdAndEnvId(*holder.Destination.Secret, app.Id, env.Id, 1)
 				if err != nil {
 					return err
 				}
-				if !deleted {
+				impl.logSavedDraft(draft, dataType)
+				if !deleted && draft == nil {
 					return fmt.Errorf("unable to delete %s named %s", v1.Secret, *holder.Destination.Secret)
 				}
 			}
@@ -320,6 +335,14 @@ func deleteKeys(fetch func() (*bean.ConfigDataRequest, error), save func(request
 	return nil
 }
 
+// logSavedDraft logs the draft the change has been saved as when the configs of the destination are protected
+func (impl DataHolderActionImpl) logSavedDraft(draft *draftBean.ConfigDraftDto, dataType string) {
+	if draft == nil {
+		return
+	}
+	impl.logger.Infow("change saved as a draft as the configs are protected", "dataType", dataType, "appId", draft.AppId, "envId", draft.EnvId, "draftId", draft.Id)
+}
+
 func deleteDataKeys(dataType string, holder *v1.DataHolder, configData *bean.ConfigDataRequest) error {
 	var name string
 	if dataType == v1.ConfigMap {
@@ -417,9 +440,11 @@ func executeDataHolderCreate(impl DataHolderActionImpl, holder *v1.DataHolder, d
 			if err == nil {
 				configData.Id = d.Id
 			}
-			if configData, err = impl.configMapService.CMEnvironmentAddUpdate(configData); err != nil {
+			var draft *draftBean.ConfigDraftDto
+			if configData, draft, err = impl.configMapService.CMEnvironmentAddUpdate(configData); err != nil {
 				return fmt.Errorf("error `%s` creating %s name %s", err.Error(), dataType, name)
 			}
+			impl.logSavedDraft(draft, dataType)
 		} else {
 			d, err := impl.configMapService.CMGlobalFetch(app.Id)
 			if err == nil {
@@ -435,9 +460,11 @@ func executeDataHolderCreate(impl DataHolderActionImpl, holder *v1.DataHolder, d
 			if err == nil {
 				configData.Id = d.Id
 			}
-			if configData, err = impl.configMapService.CSEnvironmentAddUpdate(configData); err != nil {
+			var draft *draftBean.ConfigDraftDto
+			if configData, draft, err = impl.configMapService.CSEnvironmentAddUpdate(configData); err != nil {
 				return fmt.Errorf("error `%s` creating %s name %s", err.Error(), dataType, name)
 			}
+			impl.logSavedDraft(draft, dataType)
 		} else {
 			d, err := impl.configMapService.CSGlobalFetch(app.Id)
 			if err == nil {
@@ -27,6 +27,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/bean"
 	"github.com/devtron-labs/devtron/pkg/cluster"
 	bean3 "github.com/devtron-labs/devtron/pkg/cluster/repository/bean"
+	draftBean "github.com/devtron-labs/devtron/pkg/configDraft/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
 	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
 	"go.uber.org/zap"
@@ -87,7 +88,7 @@ func (impl ConfigMapServiceMock) CMGlobalFetch(appId int) (*pipelineBean.ConfigD
 	panic("implement me")
 }
 
-func (impl ConfigMapServiceMock) CMEnvironmentAddUpdate(configMapRequest *pipelineBean.ConfigDataRequest) (*pipelineBean.ConfigDataRequest, error) {
+func (impl ConfigMapServiceMock) CMEnvironmentAddUpdate(configMapRequest *pipelineBean.ConfigDataRequest) (*pipelineBean.ConfigDataRequest, *draftBean.ConfigDraftDto, error) {
 	panic("implement me")
 }
 
@@ -105,7 +106,7 @@ func (impl ConfigMapServiceMock) CSGlobalFetch(appId int) (*pipelineBean.ConfigD
 	panic("implement me")
 }
 
-func (impl ConfigMapServiceMock) CSEnvironmentAddUpdate(configMapRequest *pipelineBean.ConfigDataRequest) (*pipelineBean.ConfigDataRequest, error) {
+func (impl ConfigMapServiceMock) CSEnvironmentAddUpdate(configMapRequest *pipelineBean.ConfigDataRequest) (*pipelineBean.ConfigDataRequest, *draftBean.ConfigDraftDto, error) {
 	panic("implement me")
 }
 
@@ -117,7 +118,7 @@ func (impl ConfigMapServiceMock) CMGlobalDelete(name string, id int, userId int3
 	panic("implement me")
 }
 
-func (impl ConfigMapServiceMock) CMEnvironmentDelete(name string, id int, userId int32) (bool, error) {
+func (impl ConfigMapServiceMock) CMEnvironmentDelete(name string, id int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
 	panic("implement me")
 }
 
@@ -125,7 +126,7 @@ func (impl ConfigMapServiceMock) CSGlobalDelete(name string, id int, userId int3
 	panic("implement me")
 }
 
-func (impl ConfigMapServiceMock) CSEnvironmentDelete(name string, id int, userId int32) (bool, error) {
+func (impl ConfigMapServiceMock) CSEnvironmentDelete(name string, id int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
 	panic("implement me")
 }
 
@@ -133,7 +134,7 @@ func (impl ConfigMapServiceMock) CMGlobalDeleteByAppId(name string, appId int, u
 	panic("implement me")
 }
 
-func (impl ConfigMapServiceMock) CMEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, error) {
+func (impl ConfigMapServiceMock) CMEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
 	panic("implement me")
 }
 
@@ -141,7 +142,7 @@ func (impl ConfigMapServiceMock) CSGlobalDeleteByAppId(name string, appId int, u
 	panic("implement me")
 }
 
-func (impl ConfigMapServiceMock) CSEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, error) {
+func (impl ConfigMapServiceMock) CSEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
 	panic("implement me")
 }
 
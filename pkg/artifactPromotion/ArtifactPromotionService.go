@@ -0,0 +1,498 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactPromotion
+
+import (
+	"context"
+	"fmt"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	dockerRegistryRepository "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/artifactPromotion/bean"
+	"github.com/devtron-labs/devtron/pkg/artifactPromotion/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry/registryClient"
+	"github.com/devtron-labs/devtron/pkg/pipeline"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"github.com/devtron-labs/devtron/pkg/security"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/util/argo"
+	"github.com/juju/errors"
+	"go.uber.org/zap"
+	"net/http"
+	"slices"
+	"time"
+)
+
+type ArtifactPromotionService interface {
+	SavePolicy(policy *bean.ArtifactPromotionPolicyDto) (*bean.ArtifactPromotionPolicyDto, error)
+	GetPolicy(appId, pipelineId int) (*bean.ArtifactPromotionPolicyDto, error)
+	DeletePolicy(appId, pipelineId int, userId int32) error
+	// Promote deploys the artifact to the target pipeline, without rebuilding it, once it meets the promotion policy of
+	// the pipeline. The image is copied by digest to the target registry of the policy first
+	Promote(ctx context.Context, request *bean.ArtifactPromotionRequest) (*bean.ArtifactPromotionDto, error)
+	// GetPromotionHistory returns the promotions of the artifact, and of the artifacts promoted from it, into the app
+	GetPromotionHistory(appId, ciArtifactId int) ([]*bean.ArtifactPromotionDto, error)
+}
+
+type ArtifactPromotionServiceImpl struct {
+	logger                      *zap.SugaredLogger
+	artifactPromotionRepository repository.ArtifactPromotionRepository
+	ciArtifactRepository        repository2.CiArtifactRepository
+	pipelineRepository          pipelineConfig.PipelineRepository
+	ciPipelineRepository        pipelineConfig.CiPipelineRepository
+	dockerRegistryConfig        pipeline.DockerRegistryConfig
+	imageScanService            security.ImageScanService
+	cdTriggerService            devtronApps.TriggerService
+	argoUserService             argo.ArgoUserService
+}
+
+func NewArtifactPromotionServiceImpl(logger *zap.SugaredLogger,
+	artifactPromotionRepository repository.ArtifactPromotionRepository,
+	ciArtifactRepository repository2.CiArtifactRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	ciPipelineRepository pipelineConfig.CiPipelineRepository,
+	dockerRegistryConfig pipeline.DockerRegistryConfig,
+	imageScanService security.ImageScanService,
+	cdTriggerService devtronApps.TriggerService,
+	argoUserService argo.ArgoUserService) *ArtifactPromotionServiceImpl {
+	return &ArtifactPromotionServiceImpl{
+		logger:                      logger,
+		artifactPromotionRepository: artifactPromotionRepository,
+		ciArtifactRepository:        ciArtifactRepository,
+		pipelineRepository:          pipelineRepository,
+		ciPipelineRepository:        ciPipelineRepository,
+		dockerRegistryConfig:        dockerRegistryConfig,
+		imageScanService:            imageScanService,
+		cdTriggerService:            cdTriggerService,
+		argoUserService:             argoUserService,
+	}
+}
+
+func (impl *ArtifactPromotionServiceImpl) SavePolicy(policy *bean.ArtifactPromotionPolicyDto) (*bean.ArtifactPromotionPolicyDto, error) {
+	cdPipeline, err := impl.getAppPipeline(policy.AppId, policy.PipelineId)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(policy.RequiredEnvironmentIds, cdPipeline.EnvironmentId) {
+		errMsg := "environment of the pipeline cannot be required for promoting into it"
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	if len(policy.TargetDockerRegistryId) > 0 {
+		_, err = impl.dockerRegistryConfig.FetchOneDockerAccount(policy.TargetDockerRegistryId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching target docker registry", "dockerRegistryId", policy.TargetDockerRegistryId, "err", err)
+			return nil, err
+		}
+	}
+	existing, err := impl.artifactPromotionRepository.FindPolicyByPipelineId(policy.PipelineId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching artifact promotion policy", "pipelineId", policy.PipelineId, "err", err)
+		return nil, err
+	}
+	dbObject := adaptToPolicyModel(policy)
+	if existing != nil && existing.Id > 0 {
+		dbObject.Id = existing.Id
+		dbObject.CreatedOn = existing.CreatedOn
+		dbObject.CreatedBy = existing.CreatedBy
+		err = impl.artifactPromotionRepository.UpdatePolicy(dbObject)
+	} else {
+		err = impl.artifactPromotionRepository.SavePolicy(dbObject)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving artifact promotion policy", "pipelineId", policy.PipelineId, "err", err)
+		return nil, err
+	}
+	return adaptToPolicyDto(policy.AppId, dbObject), nil
+}
+
+func (impl *ArtifactPromotionServiceImpl) GetPolicy(appId, pipelineId int) (*bean.ArtifactPromotionPolicyDto, error) {
+	policy, err := impl.artifactPromotionRepository.FindPolicyByPipelineId(pipelineId)
+	if util.IsErrNoRows(err) {
+		return &bean.ArtifactPromotionPolicyDto{AppId: appId, PipelineId: pipelineId, RequiredEnvironmentIds: make([]int, 0)}, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching artifact promotion policy", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return adaptToPolicyDto(appId, policy), nil
+}
+
+func (impl *ArtifactPromotionServiceImpl) DeletePolicy(appId, pipelineId int, userId int32) error {
+	if _, err := impl.getAppPipeline(appId, pipelineId); err != nil {
+		return err
+	}
+	policy, err := impl.artifactPromotionRepository.FindPolicyByPipelineId(pipelineId)
+	if util.IsErrNoRows(err) {
+		return nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching artifact promotion policy", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	policy.Active = false
+	policy.UpdateAuditLog(userId)
+	err = impl.artifactPromotionRepository.UpdatePolicy(policy)
+	if err != nil {
+		impl.logger.Errorw("error in deleting artifact promotion policy", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *ArtifactPromotionServiceImpl) getAppPipeline(appId, pipelineId int) (*pipelineConfig.Pipeline, error) {
+	cdPipeline, err := impl.pipelineRepository.FindById(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	if cdPipeline.AppId != appId {
+		errMsg := fmt.Sprintf("pipeline %d does not belong to app %d", pipelineId, appId)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	return cdPipeline, nil
+}
+
+func (impl *ArtifactPromotionServiceImpl) Promote(ctx context.Context, request *bean.ArtifactPromotionRequest) (*bean.ArtifactPromotionDto, error) {
+	targetPipeline, err := impl.getAppPipeline(request.AppId, request.TargetPipelineId)
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := impl.ciArtifactRepository.Get(request.CiArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci artifact", "ciArtifactId", request.CiArtifactId, "err", err)
+		return nil, err
+	}
+	artifactAppId, err := impl.getArtifactAppId(artifact)
+	if err != nil {
+		return nil, err
+	}
+	if artifactAppId != request.AppId {
+		errMsg := fmt.Sprintf("artifact %d does not belong to app %d", artifact.Id, request.AppId)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	policy, err := impl.GetPolicy(request.AppId, request.TargetPipelineId)
+	if err != nil {
+		return nil, err
+	}
+	promotion := &repository.ArtifactPromotion{
+		SourceCiArtifactId:  artifact.Id,
+		TargetPipelineId:    targetPipeline.Id,
+		TargetEnvironmentId: targetPipeline.EnvironmentId,
+		SourceImage:         artifact.Image,
+		ImageDigest:         artifact.ImageDigest,
+		Comment:             request.Comment,
+		PromotedOn:          time.Now(),
+		PromotedBy:          request.UserId,
+	}
+	blockedReason, err := impl.evaluatePolicy(ctx, policy, targetPipeline, artifact)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockedReason) > 0 {
+		impl.savePromotion(promotion, bean.PromotionStatusBlocked, blockedReason)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(blockedReason).WithInternalMessage(blockedReason)
+	}
+	promotedArtifact, err := impl.getPromotedArtifact(ctx, policy, targetPipeline, artifact, request.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in promoting artifact image", "ciArtifactId", artifact.Id, "pipelineId", targetPipeline.Id, "err", err)
+		impl.savePromotion(promotion, bean.PromotionStatusFailed, err.Error())
+		return nil, err
+	}
+	promotion.PromotedCiArtifactId = promotedArtifact.Id
+	promotion.PromotedImage = promotedArtifact.Image
+	releaseId, err := impl.triggerDeployment(targetPipeline.Id, promotedArtifact.Id, request.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in triggering promotion deployment", "ciArtifactId", promotedArtifact.Id, "pipelineId", targetPipeline.Id, "err", err)
+		impl.savePromotion(promotion, bean.PromotionStatusFailed, err.Error())
+		return nil, err
+	}
+	promotion.ReleaseId = releaseId
+	err = impl.savePromotion(promotion, bean.PromotionStatusPromoted, "")
+	if err != nil {
+		return nil, err
+	}
+	return adaptToPromotionDto(promotion), nil
+}
+
+// maxArtifactParentDepth bounds the walk up the parent chain of an artifact, chains are expected to be short
+const maxArtifactParentDepth = 10
+
+// getArtifactAppId returns the app of the ci pipeline, or the external ci pipeline, the artifact was built by.
+// Artifacts without a pipeline of their own are resolved through their parent artifacts
+func (impl *ArtifactPromotionServiceImpl) getArtifactAppId(artifact *repository2.CiArtifact) (int, error) {
+	for depth := 0; depth < maxArtifactParentDepth; depth++ {
+		if artifact.PipelineId > 0 {
+			ciPipeline, err := impl.ciPipelineRepository.FindByIdIncludingInActive(artifact.PipelineId)
+			if err != nil {
+				impl.logger.Errorw("error in fetching ci pipeline of artifact", "ciArtifactId", artifact.Id, "ciPipelineId", artifact.PipelineId, "err", err)
+				return 0, err
+			}
+			return ciPipeline.AppId, nil
+		}
+		if artifact.ExternalCiPipelineId > 0 {
+			externalCiPipeline, err := impl.ciPipelineRepository.FindExternalCiById(artifact.ExternalCiPipelineId)
+			if err != nil {
+				impl.logger.Errorw("error in fetching external ci pipeline of artifact", "ciArtifactId", artifact.Id, "externalCiPipelineId", artifact.ExternalCiPipelineId, "err", err)
+				return 0, err
+			}
+			return externalCiPipeline.AppId, nil
+		}
+		if artifact.ParentCiArtifact == 0 {
+			break
+		}
+		parent, err := impl.ciArtifactRepository.Get(artifact.ParentCiArtifact)
+		if err != nil {
+			impl.logger.Errorw("error in fetching parent ci artifact", "ciArtifactId", artifact.Id, "parentCiArtifactId", artifact.ParentCiArtifact, "err", err)
+			return 0, err
+		}
+		artifact = parent
+	}
+	errMsg := fmt.Sprintf("pipeline of artifact %d could not be resolved", artifact.Id)
+	return 0, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+}
+
+func (impl *ArtifactPromotionServiceImpl) evaluatePolicy(ctx context.Context, policy *bean.ArtifactPromotionPolicyDto,
+	targetPipeline *pipelineConfig.Pipeline, artifact *repository2.CiArtifact) (string, error) {
+	succeededEnvIds, err := impl.artifactPromotionRepository.FindSucceededEnvironmentIds(targetPipeline.AppId, policy.RequiredEnvironmentIds,
+		artifact.Image, artifact.ImageDigest)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environments the artifact succeeded in", "ciArtifactId", artifact.Id, "err", err)
+		return "", err
+	}
+	vulnerable := false
+	if policy.RequireScanPassed && artifact.Scanned {
+		vulnerable, err = impl.imageScanService.GetArtifactVulnerabilityStatus(ctx, &triggerBean.VulnerabilityCheckRequest{
+			CdPipeline:  targetPipeline,
+			ImageDigest: artifact.ImageDigest,
+		})
+		if err != nil {
+			impl.logger.Errorw("error in getting artifact vulnerability status", "ciArtifactId", artifact.Id, "err", err)
+			return "", err
+		}
+	}
+	return getBlockedReason(policy, succeededEnvIds, artifact.Scanned, vulnerable), nil
+}
+
+// getPromotedArtifact returns the artifact to deploy on the target pipeline. The source artifact is deployed as is
+// unless its image is copied to another registry or it was built by the ci pipeline of another workflow, in which case
+// an artifact of the target workflow is created from it, or reused from an earlier promotion
+func (impl *ArtifactPromotionServiceImpl) getPromotedArtifact(ctx context.Context, policy *bean.ArtifactPromotionPolicyDto,
+	targetPipeline *pipelineConfig.Pipeline, artifact *repository2.CiArtifact, userId int32) (*repository2.CiArtifact, error) {
+	sourceRegistryId := ""
+	if artifact.IsRegistryCredentialMapped() {
+		sourceRegistryId = artifact.CredentialSourceValue
+	}
+	targetRegistryId := sourceRegistryId
+	if len(policy.TargetDockerRegistryId) > 0 {
+		targetRegistryId = policy.TargetDockerRegistryId
+	}
+	copyRequired := targetRegistryId != sourceRegistryId || len(policy.TargetDockerRepository) > 0
+	otherWorkflow := targetPipeline.CiPipelineId > 0 && artifact.PipelineId != targetPipeline.CiPipelineId
+	if !copyRequired && !otherWorkflow {
+		return artifact, nil
+	}
+	ciPipelineId := artifact.PipelineId
+	if otherWorkflow {
+		ciPipelineId = targetPipeline.CiPipelineId
+	}
+	promotedArtifact, err := impl.findPromotedArtifact(artifact.Id, ciPipelineId, targetRegistryId)
+	if err != nil || promotedArtifact != nil {
+		return promotedArtifact, err
+	}
+	promotedArtifact = &repository2.CiArtifact{
+		PipelineId:            ciPipelineId,
+		Image:                 artifact.Image,
+		ImageDigest:           artifact.ImageDigest,
+		MaterialInfo:          artifact.MaterialInfo,
+		DataSource:            artifact.DataSource,
+		WorkflowId:            artifact.WorkflowId,
+		ParentCiArtifact:      artifact.Id,
+		ScanEnabled:           artifact.ScanEnabled,
+		Scanned:               artifact.Scanned,
+		ComponentId:           artifact.ComponentId,
+		CredentialsSourceType: artifact.CredentialsSourceType,
+		CredentialSourceValue: artifact.CredentialSourceValue,
+		AuditLog:              sql.NewDefaultAuditLog(userId),
+	}
+	if copyRequired {
+		if len(sourceRegistryId) == 0 {
+			errMsg := "registry of the artifact is not known, its image cannot be copied"
+			return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+		}
+		copied, err := impl.copyImage(ctx, sourceRegistryId, artifact.Image, targetRegistryId, policy.TargetDockerRepository)
+		if err != nil {
+			return nil, err
+		}
+		promotedArtifact.Image = copied.Image
+		promotedArtifact.ImageDigest = copied.Digest
+		promotedArtifact.CredentialsSourceType = repository2.GLOBAL_CONTAINER_REGISTRY
+		promotedArtifact.CredentialSourceValue = targetRegistryId
+	}
+	err = impl.ciArtifactRepository.Save(promotedArtifact)
+	if err != nil {
+		impl.logger.Errorw("error in saving promoted artifact", "ciArtifactId", artifact.Id, "err", err)
+		return nil, err
+	}
+	return promotedArtifact, nil
+}
+
+// findPromotedArtifact returns the artifact created for the ci pipeline and the registry by an earlier promotion
+func (impl *ArtifactPromotionServiceImpl) findPromotedArtifact(ciArtifactId int, ciPipelineId int, registryId string) (*repository2.CiArtifact, error) {
+	promotions, err := impl.artifactPromotionRepository.FindPromotionsByArtifactId(ciArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact promotions", "ciArtifactId", ciArtifactId, "err", err)
+		return nil, err
+	}
+	for _, promotion := range promotions {
+		if promotion.SourceCiArtifactId != ciArtifactId || promotion.PromotedCiArtifactId == 0 || promotion.PromotedCiArtifactId == ciArtifactId {
+			continue
+		}
+		promotedArtifact, err := impl.ciArtifactRepository.Get(promotion.PromotedCiArtifactId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching promoted artifact", "ciArtifactId", promotion.PromotedCiArtifactId, "err", err)
+			return nil, err
+		}
+		if promotedArtifact.PipelineId == ciPipelineId && promotedArtifact.CredentialSourceValue == registryId {
+			return promotedArtifact, nil
+		}
+	}
+	return nil, nil
+}
+
+func (impl *ArtifactPromotionServiceImpl) copyImage(ctx context.Context, sourceRegistryId string, image string,
+	targetRegistryId string, targetRepository string) (*registryClient.CopyImageResponse, error) {
+	sourceStore, err := impl.dockerRegistryConfig.FetchOneDockerAccount(sourceRegistryId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching source docker registry", "dockerRegistryId", sourceRegistryId, "err", err)
+		return nil, err
+	}
+	targetStore, err := impl.dockerRegistryConfig.FetchOneDockerAccount(targetRegistryId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching target docker registry", "dockerRegistryId", targetRegistryId, "err", err)
+		return nil, err
+	}
+	sourceConfig, err := impl.getRegistryConfig(sourceStore)
+	if err != nil {
+		return nil, err
+	}
+	targetConfig, err := impl.getRegistryConfig(targetStore)
+	if err != nil {
+		return nil, err
+	}
+	targetRepository, err = registryClient.GetTargetRepository(sourceConfig, image, targetConfig, targetRepository)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	if targetStore.RegistryType == dockerRegistryRepository.REGISTRYTYPE_ECR {
+		// ecr needs the repository to exist before pushing to it
+		repositoryName := targetRepository
+		err = util.CreateEcrRepo(repositoryName, targetStore.AWSRegion, targetStore.AWSAccessKeyId, targetStore.AWSSecretAccessKey)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			impl.logger.Errorw("error in creating ecr repository", "repository", repositoryName, "err", err)
+			return nil, err
+		}
+	}
+	copied, err := registryClient.CopyImage(ctx, sourceConfig, image, targetConfig, targetRepository)
+	if err != nil {
+		impl.logger.Errorw("error in copying image", "image", image, "targetDockerRegistryId", targetRegistryId, "err", err)
+		return nil, err
+	}
+	return copied, nil
+}
+
+// getRegistryConfig exchanges the aws keys of ecr registries for a registry token
+func (impl *ArtifactPromotionServiceImpl) getRegistryConfig(store *types.DockerArtifactStoreBean) (*registryClient.RegistryConfig, error) {
+	config := registryClient.GetRegistryConfig(store)
+	if store.RegistryType == dockerRegistryRepository.REGISTRYTYPE_ECR {
+		username, password, err := dockerRegistry.CreateCredentialForEcr(store.AWSRegion, store.AWSAccessKeyId, store.AWSSecretAccessKey)
+		if err != nil {
+			impl.logger.Errorw("error in creating ecr credentials", "dockerRegistryId", store.Id, "err", err)
+			return nil, err
+		}
+		config.Username = username
+		config.Password = password
+	}
+	return config, nil
+}
+
+func (impl *ArtifactPromotionServiceImpl) triggerDeployment(pipelineId int, ciArtifactId int, userId int32) (int, error) {
+	acdToken, err := impl.argoUserService.GetLatestDevtronArgoCdUserToken()
+	if err != nil {
+		impl.logger.Errorw("error in getting acd token", "err", err)
+		return 0, err
+	}
+	overrideRequest := &apiBean.ValuesOverrideRequest{
+		PipelineId:     pipelineId,
+		CiArtifactId:   ciArtifactId,
+		CdWorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentType: models.DEPLOYMENTTYPE_DEPLOY,
+		UserId:         userId,
+		TriggerType:    cdWorkflow.TriggerTypePromotion,
+	}
+	triggerContext := triggerBean.TriggerContext{
+		Context: context.WithValue(context.Background(), "token", acdToken),
+	}
+	return impl.cdTriggerService.ManualCdTrigger(triggerContext, overrideRequest)
+}
+
+func (impl *ArtifactPromotionServiceImpl) savePromotion(promotion *repository.ArtifactPromotion, status bean.PromotionStatus, message string) error {
+	promotion.Status = string(status)
+	promotion.Message = message
+	err := impl.artifactPromotionRepository.SavePromotion(promotion)
+	if err != nil {
+		impl.logger.Errorw("error in saving artifact promotion", "ciArtifactId", promotion.SourceCiArtifactId, "status", status, "err", err)
+	}
+	return err
+}
+
+func (impl *ArtifactPromotionServiceImpl) GetPromotionHistory(appId, ciArtifactId int) ([]*bean.ArtifactPromotionDto, error) {
+	promotions, err := impl.artifactPromotionRepository.FindPromotionsByArtifactId(ciArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact promotions", "ciArtifactId", ciArtifactId, "err", err)
+		return nil, err
+	}
+	history := make([]*bean.ArtifactPromotionDto, 0, len(promotions))
+	if len(promotions) == 0 {
+		return history, nil
+	}
+	pipelineIds := make([]int, 0, len(promotions))
+	for _, promotion := range promotions {
+		pipelineIds = append(pipelineIds, promotion.TargetPipelineId)
+	}
+	pipelines, err := impl.pipelineRepository.FindByIdsIn(pipelineIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipelines", "pipelineIds", pipelineIds, "err", err)
+		return nil, err
+	}
+	appPipelineIds := make(map[int]bool, len(pipelines))
+	for _, cdPipeline := range pipelines {
+		if cdPipeline.AppId == appId {
+			appPipelineIds[cdPipeline.Id] = true
+		}
+	}
+	for _, promotion := range promotions {
+		if appPipelineIds[promotion.TargetPipelineId] {
+			history = append(history, adaptToPromotionDto(promotion))
+		}
+	}
+	return history, nil
+}
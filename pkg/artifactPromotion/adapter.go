@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactPromotion
+
+import (
+	"github.com/devtron-labs/devtron/pkg/artifactPromotion/bean"
+	"github.com/devtron-labs/devtron/pkg/artifactPromotion/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func adaptToPolicyModel(policy *bean.ArtifactPromotionPolicyDto) *repository.ArtifactPromotionPolicy {
+	return &repository.ArtifactPromotionPolicy{
+		Id:                     policy.Id,
+		PipelineId:             policy.PipelineId,
+		RequiredEnvironmentIds: policy.RequiredEnvironmentIds,
+		RequireScanPassed:      policy.RequireScanPassed,
+		TargetDockerRegistryId: policy.TargetDockerRegistryId,
+		TargetDockerRepository: policy.TargetDockerRepository,
+		Active:                 true,
+		AuditLog:               sql.NewDefaultAuditLog(policy.UserId),
+	}
+}
+
+func adaptToPolicyDto(appId int, policy *repository.ArtifactPromotionPolicy) *bean.ArtifactPromotionPolicyDto {
+	requiredEnvIds := policy.RequiredEnvironmentIds
+	if requiredEnvIds == nil {
+		requiredEnvIds = make([]int, 0)
+	}
+	return &bean.ArtifactPromotionPolicyDto{
+		Id:                     policy.Id,
+		AppId:                  appId,
+		PipelineId:             policy.PipelineId,
+		RequiredEnvironmentIds: requiredEnvIds,
+		RequireScanPassed:      policy.RequireScanPassed,
+		TargetDockerRegistryId: policy.TargetDockerRegistryId,
+		TargetDockerRepository: policy.TargetDockerRepository,
+	}
+}
+
+func adaptToPromotionDto(promotion *repository.ArtifactPromotion) *bean.ArtifactPromotionDto {
+	return &bean.ArtifactPromotionDto{
+		Id:                   promotion.Id,
+		SourceCiArtifactId:   promotion.SourceCiArtifactId,
+		PromotedCiArtifactId: promotion.PromotedCiArtifactId,
+		TargetPipelineId:     promotion.TargetPipelineId,
+		TargetEnvironmentId:  promotion.TargetEnvironmentId,
+		SourceImage:          promotion.SourceImage,
+		PromotedImage:        promotion.PromotedImage,
+		ImageDigest:          promotion.ImageDigest,
+		ReleaseId:            promotion.ReleaseId,
+		Status:               bean.PromotionStatus(promotion.Status),
+		Message:              promotion.Message,
+		Comment:              promotion.Comment,
+		PromotedOn:           promotion.PromotedOn,
+		PromotedBy:           promotion.PromotedBy,
+	}
+}
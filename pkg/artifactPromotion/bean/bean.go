@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type PromotionStatus string
+
+const (
+	// PromotionStatusBlocked is recorded when the artifact does not meet the promotion policy of the target pipeline
+	PromotionStatusBlocked  PromotionStatus = "BLOCKED"
+	PromotionStatusFailed   PromotionStatus = "FAILED"
+	PromotionStatusPromoted PromotionStatus = "PROMOTED"
+)
+
+const (
+	NotDeployedInEnvironments = "artifact has not been deployed successfully in the required environments %v"
+	ScanNotPassed             = "artifact has not passed the image scan"
+	ImageCopyNotSupported     = "image cannot be copied from %s registry to %s registry"
+)
+
+// ArtifactPromotionPolicyDto gates the promotion of artifacts into a cd pipeline. Promoted images are copied to the
+// target registry, keeping their digest, when it is set
+type ArtifactPromotionPolicyDto struct {
+	Id                     int    `json:"id"`
+	AppId                  int    `json:"appId" validate:"required,number,gt=0"`
+	PipelineId             int    `json:"pipelineId" validate:"required,number,gt=0"`
+	RequiredEnvironmentIds []int  `json:"requiredEnvironmentIds"`
+	RequireScanPassed      bool   `json:"requireScanPassed"`
+	TargetDockerRegistryId string `json:"targetDockerRegistryId,omitempty"`
+	TargetDockerRepository string `json:"targetDockerRepository,omitempty"`
+	UserId                 int32  `json:"-"`
+}
+
+type ArtifactPromotionRequest struct {
+	AppId            int    `json:"appId" validate:"required,number,gt=0"`
+	CiArtifactId     int    `json:"ciArtifactId" validate:"required,number,gt=0"`
+	TargetPipelineId int    `json:"targetPipelineId" validate:"required,number,gt=0"`
+	Comment          string `json:"comment" validate:"max=250"`
+	UserId           int32  `json:"-"`
+}
+
+// ArtifactPromotionDto is the history entry of a promotion request, PromotedCiArtifactId is the artifact deployed
+// to the target pipeline which is a copy of the source artifact when its image is copied or its workflow differs
+type ArtifactPromotionDto struct {
+	Id                   int             `json:"id"`
+	SourceCiArtifactId   int             `json:"sourceCiArtifactId"`
+	PromotedCiArtifactId int             `json:"promotedCiArtifactId,omitempty"`
+	TargetPipelineId     int             `json:"targetPipelineId"`
+	TargetEnvironmentId  int             `json:"targetEnvironmentId"`
+	SourceImage          string          `json:"sourceImage"`
+	PromotedImage        string          `json:"promotedImage,omitempty"`
+	ImageDigest          string          `json:"imageDigest,omitempty"`
+	ReleaseId            int             `json:"releaseId,omitempty"`
+	Status               PromotionStatus `json:"status"`
+	Message              string          `json:"message,omitempty"`
+	Comment              string          `json:"comment,omitempty"`
+	PromotedOn           time.Time       `json:"promotedOn"`
+	PromotedBy           int32           `json:"promotedBy"`
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactPromotion
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/artifactPromotion/bean"
+	"sort"
+)
+
+// missingEnvironmentIds returns the required environments in which the artifact has not succeeded
+func missingEnvironmentIds(requiredEnvIds []int, succeededEnvIds []int) []int {
+	succeeded := make(map[int]bool, len(succeededEnvIds))
+	for _, envId := range succeededEnvIds {
+		succeeded[envId] = true
+	}
+	missing := make([]int, 0)
+	for _, envId := range requiredEnvIds {
+		if !succeeded[envId] {
+			missing = append(missing, envId)
+		}
+	}
+	sort.Ints(missing)
+	return missing
+}
+
+// getBlockedReason returns why the policy blocks the promotion, empty when the artifact can be promoted
+func getBlockedReason(policy *bean.ArtifactPromotionPolicyDto, succeededEnvIds []int, scanned bool, vulnerable bool) string {
+	if policy == nil {
+		return ""
+	}
+	if missing := missingEnvironmentIds(policy.RequiredEnvironmentIds, succeededEnvIds); len(missing) > 0 {
+		return fmt.Sprintf(bean.NotDeployedInEnvironments, missing)
+	}
+	if policy.RequireScanPassed && (!scanned || vulnerable) {
+		return bean.ScanNotPassed
+	}
+	return ""
+}
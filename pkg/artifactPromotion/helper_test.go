@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactPromotion
+
+import (
+	"github.com/devtron-labs/devtron/pkg/artifactPromotion/bean"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetBlockedReason(t *testing.T) {
+	policy := &bean.ArtifactPromotionPolicyDto{RequiredEnvironmentIds: []int{3, 1, 2}, RequireScanPassed: true}
+
+	t.Run("no policy", func(t *testing.T) {
+		assert.Equal(t, "", getBlockedReason(nil, nil, false, true))
+	})
+
+	t.Run("not deployed in every required environment", func(t *testing.T) {
+		assert.Equal(t, "artifact has not been deployed successfully in the required environments [1 3]",
+			getBlockedReason(policy, []int{2, 4}, true, false))
+	})
+
+	t.Run("not scanned", func(t *testing.T) {
+		assert.Equal(t, bean.ScanNotPassed, getBlockedReason(policy, []int{1, 2, 3}, false, false))
+	})
+
+	t.Run("vulnerable", func(t *testing.T) {
+		assert.Equal(t, bean.ScanNotPassed, getBlockedReason(policy, []int{1, 2, 3}, true, true))
+	})
+
+	t.Run("scan not required", func(t *testing.T) {
+		policy := &bean.ArtifactPromotionPolicyDto{RequiredEnvironmentIds: []int{1}}
+		assert.Equal(t, "", getBlockedReason(policy, []int{1}, false, true))
+	})
+
+	t.Run("passes the policy", func(t *testing.T) {
+		assert.Equal(t, "", getBlockedReason(policy, []int{1, 2, 3}, true, false))
+	})
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type ArtifactPromotionPolicy struct {
+	tableName              struct{} `sql:"artifact_promotion_policy" pg:",discard_unknown_columns"`
+	Id                     int      `sql:"id,pk"`
+	PipelineId             int      `sql:"pipeline_id,notnull"`
+	RequiredEnvironmentIds []int    `sql:"required_environment_ids" pg:",array"`
+	RequireScanPassed      bool     `sql:"require_scan_passed,notnull"`
+	TargetDockerRegistryId string   `sql:"target_docker_registry_id"`
+	TargetDockerRepository string   `sql:"target_docker_repository"`
+	Active                 bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// ArtifactPromotion is the history of the promotion requests of an artifact, including the blocked and failed ones
+type ArtifactPromotion struct {
+	tableName            struct{}  `sql:"artifact_promotion" pg:",discard_unknown_columns"`
+	Id                   int       `sql:"id,pk"`
+	SourceCiArtifactId   int       `sql:"source_ci_artifact_id,notnull"`
+	PromotedCiArtifactId int       `sql:"promoted_ci_artifact_id"`
+	TargetPipelineId     int       `sql:"target_pipeline_id,notnull"`
+	TargetEnvironmentId  int       `sql:"target_environment_id,notnull"`
+	SourceImage          string    `sql:"source_image,notnull"`
+	PromotedImage        string    `sql:"promoted_image"`
+	ImageDigest          string    `sql:"image_digest"`
+	ReleaseId            int       `sql:"release_id"`
+	Status               string    `sql:"status,notnull"`
+	Message              string    `sql:"message"`
+	Comment              string    `sql:"comment"`
+	PromotedOn           time.Time `sql:"promoted_on,notnull"`
+	PromotedBy           int32     `sql:"promoted_by,notnull"`
+}
+
+type ArtifactPromotionRepository interface {
+	SavePolicy(policy *ArtifactPromotionPolicy) error
+	UpdatePolicy(policy *ArtifactPromotionPolicy) error
+	FindPolicyByPipelineId(pipelineId int) (*ArtifactPromotionPolicy, error)
+
+	SavePromotion(promotion *ArtifactPromotion) error
+	// FindPromotionsByArtifactId returns the promotions of the artifact and of the artifacts promoted from it
+	FindPromotionsByArtifactId(ciArtifactId int) ([]*ArtifactPromotion, error)
+
+	// FindSucceededEnvironmentIds returns the environments among envIds in which an image of the app with the digest,
+	// or with the image path when the digest is not known, has been deployed successfully
+	FindSucceededEnvironmentIds(appId int, envIds []int, image string, imageDigest string) ([]int, error)
+}
+
+type ArtifactPromotionRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewArtifactPromotionRepositoryImpl(dbConnection *pg.DB) *ArtifactPromotionRepositoryImpl {
+	return &ArtifactPromotionRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *ArtifactPromotionRepositoryImpl) SavePolicy(policy *ArtifactPromotionPolicy) error {
+	return impl.dbConnection.Insert(policy)
+}
+
+func (impl *ArtifactPromotionRepositoryImpl) UpdatePolicy(policy *ArtifactPromotionPolicy) error {
+	return impl.dbConnection.Update(policy)
+}
+
+func (impl *ArtifactPromotionRepositoryImpl) FindPolicyByPipelineId(pipelineId int) (*ArtifactPromotionPolicy, error) {
+	policy := &ArtifactPromotionPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl *ArtifactPromotionRepositoryImpl) SavePromotion(promotion *ArtifactPromotion) error {
+	return impl.dbConnection.Insert(promotion)
+}
+
+func (impl *ArtifactPromotionRepositoryImpl) FindPromotionsByArtifactId(ciArtifactId int) ([]*ArtifactPromotion, error) {
+	promotions := make([]*ArtifactPromotion, 0)
+	err := impl.dbConnection.Model(&promotions).
+		WhereOr("source_ci_artifact_id = ?", ciArtifactId).
+		WhereOr("promoted_ci_artifact_id = ?", ciArtifactId).
+		Order("id DESC").
+		Select()
+	return promotions, err
+}
+
+func (impl *ArtifactPromotionRepositoryImpl) FindSucceededEnvironmentIds(appId int, envIds []int, image string, imageDigest string) ([]int, error) {
+	succeededEnvIds := make([]int, 0)
+	if len(envIds) == 0 {
+		return succeededEnvIds, nil
+	}
+	query := "SELECT DISTINCT p.environment_id" +
+		" FROM cd_workflow_runner cwr" +
+		" INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id" +
+		" INNER JOIN pipeline p ON p.id = cw.pipeline_id AND p.deleted = false" +
+		" INNER JOIN ci_artifact cia ON cia.id = cw.ci_artifact_id" +
+		" WHERE cwr.workflow_type = ? AND cwr.status IN (?) AND p.app_id = ? AND p.environment_id IN (?)"
+	params := []interface{}{apiBean.CD_WORKFLOW_TYPE_DEPLOY, pg.In(cdWorkflow.WfrHealthyStatusList), appId, pg.In(envIds)}
+	// promoted copies of an image in other registries share its digest
+	if len(imageDigest) > 0 {
+		query += " AND cia.image_digest = ?"
+		params = append(params, imageDigest)
+	} else {
+		query += " AND cia.image = ?"
+		params = append(params, image)
+	}
+	_, err := impl.dbConnection.Query(&succeededEnvIds, query, params...)
+	return succeededEnvIds, err
+}
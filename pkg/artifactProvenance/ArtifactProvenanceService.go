@@ -0,0 +1,300 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactProvenance
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/caarlos0/env"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/bean"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type ArtifactProvenanceService interface {
+	// RecordBuildAttestations stores the slsa provenance of the artifact built by ci, and the sbom generated by the ci
+	// runner when there is one. An image without digest cannot be attested
+	RecordBuildAttestations(ciArtifactId int, sbom json.RawMessage, userId int32)
+	// UploadSbom stores the sbom of an artifact built outside devtron, it replaces the sbom of the artifact
+	UploadSbom(appId, ciArtifactId int, content []byte, userId int32) (*bean.AttestationDto, error)
+	GetAttestations(appId, ciArtifactId int) ([]*bean.AttestationDto, error)
+	// GetAttestationContent returns the document of the attestation for download
+	GetAttestationContent(appId, ciArtifactId int, attestationType bean.AttestationType) (*bean.AttestationDto, []byte, error)
+	// SearchComponents finds the built images containing a component, with the environments currently running them
+	SearchComponents(request *bean.ComponentSearchRequest) ([]*bean.ComponentSearchResult, error)
+}
+
+type ArtifactProvenanceServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	artifactProvenanceRepository repository.ArtifactProvenanceRepository
+	ciArtifactRepository         repository2.CiArtifactRepository
+	ciPipelineRepository         pipelineConfig.CiPipelineRepository
+	ciWorkflowRepository         pipelineConfig.CiWorkflowRepository
+	config                       *bean.ArtifactProvenanceConfig
+}
+
+func NewArtifactProvenanceServiceImpl(logger *zap.SugaredLogger,
+	artifactProvenanceRepository repository.ArtifactProvenanceRepository,
+	ciArtifactRepository repository2.CiArtifactRepository,
+	ciPipelineRepository pipelineConfig.CiPipelineRepository,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository) (*ArtifactProvenanceServiceImpl, error) {
+	config := &bean.ArtifactProvenanceConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing artifact provenance config", "err", err)
+		return nil, err
+	}
+	return &ArtifactProvenanceServiceImpl{
+		logger:                       logger,
+		artifactProvenanceRepository: artifactProvenanceRepository,
+		ciArtifactRepository:         ciArtifactRepository,
+		ciPipelineRepository:         ciPipelineRepository,
+		ciWorkflowRepository:         ciWorkflowRepository,
+		config:                       config,
+	}, nil
+}
+
+func (impl *ArtifactProvenanceServiceImpl) RecordBuildAttestations(ciArtifactId int, sbom json.RawMessage, userId int32) {
+	artifact, err := impl.ciArtifactRepository.Get(ciArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return
+	}
+	if len(artifact.ImageDigest) == 0 {
+		impl.logger.Warnw("skipping attestations of artifact without image digest", "ciArtifactId", ciArtifactId)
+		return
+	}
+	err = impl.saveProvenance(artifact, userId)
+	if err != nil {
+		impl.logger.Errorw("error in saving provenance of artifact", "ciArtifactId", ciArtifactId, "err", err)
+	}
+	if len(sbom) > 0 {
+		_, err = impl.saveSbom(artifact.Id, sbom, userId)
+		if err != nil {
+			impl.logger.Errorw("error in saving sbom of artifact", "ciArtifactId", ciArtifactId, "err", err)
+		}
+	}
+}
+
+func (impl *ArtifactProvenanceServiceImpl) saveProvenance(artifact *repository2.CiArtifact, userId int32) error {
+	ciPipeline, err := impl.ciPipelineRepository.FindByCiAndAppDetailsById(artifact.PipelineId)
+	if err != nil {
+		return err
+	}
+	request := &bean.BuildProvenanceRequest{
+		Image:          artifact.Image,
+		ImageDigest:    artifact.ImageDigest,
+		AppName:        ciPipeline.App.AppName,
+		CiPipelineId:   ciPipeline.Id,
+		CiPipelineName: ciPipeline.Name,
+	}
+	if artifact.WorkflowId != nil {
+		ciWorkflow, err := impl.ciWorkflowRepository.FindById(*artifact.WorkflowId)
+		if err != nil {
+			return err
+		}
+		request.CiWorkflowId = ciWorkflow.Id
+		request.StartedOn = ciWorkflow.StartedOn
+		request.FinishedOn = ciWorkflow.FinishedOn
+	}
+	materials, err := repository2.GetCiMaterialInfo(artifact.MaterialInfo, artifact.DataSource)
+	if err != nil {
+		return err
+	}
+	for _, material := range materials {
+		if len(material.Modifications) == 0 {
+			continue
+		}
+		request.Materials = append(request.Materials, bean.BuildMaterial{
+			GitUrl: material.Material.GitConfiguration.URL,
+			Branch: material.Modifications[0].Branch,
+			Commit: material.Modifications[0].Revision,
+		})
+	}
+	statement, err := BuildProvenanceStatement(impl.config.ArtifactProvenanceBuilderId, request)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(statement)
+	if err != nil {
+		return err
+	}
+	attestation := newAttestation(artifact.Id, bean.AttestationTypeProvenance, bean.AttestationFormatInToto, content, userId)
+	tx, err := impl.artifactProvenanceRepository.GetConnection().Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	err = impl.artifactProvenanceRepository.SaveAttestation(tx, attestation)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (impl *ArtifactProvenanceServiceImpl) saveSbom(ciArtifactId int, content []byte, userId int32) (*bean.AttestationDto, error) {
+	format, components, err := ParseSbom(content)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	attestation := newAttestation(ciArtifactId, bean.AttestationTypeSbom, format, content, userId)
+	componentModels := make([]*repository.ArtifactSbomComponent, 0, len(components))
+	for _, component := range components {
+		componentModels = append(componentModels, &repository.ArtifactSbomComponent{
+			CiArtifactId: ciArtifactId,
+			Name:         component.Name,
+			Version:      component.Version,
+			Purl:         component.Purl,
+			Type:         component.Type,
+		})
+	}
+	tx, err := impl.artifactProvenanceRepository.GetConnection().Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	err = impl.artifactProvenanceRepository.SaveAttestation(tx, attestation)
+	if err != nil {
+		return nil, err
+	}
+	err = impl.artifactProvenanceRepository.SaveComponents(tx, ciArtifactId, componentModels)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	dto := adaptToAttestationDto(attestation)
+	dto.Components = len(componentModels)
+	return dto, nil
+}
+
+func newAttestation(ciArtifactId int, attestationType bean.AttestationType, format bean.AttestationFormat, content []byte, userId int32) *repository.ArtifactAttestation {
+	return &repository.ArtifactAttestation{
+		CiArtifactId: ciArtifactId,
+		Type:         string(attestationType),
+		Format:       string(format),
+		Content:      string(content),
+		Digest:       fmt.Sprintf("sha256:%x", sha256.Sum256(content)),
+		Active:       true,
+		AuditLog:     sql.NewDefaultAuditLog(userId),
+	}
+}
+
+func (impl *ArtifactProvenanceServiceImpl) UploadSbom(appId, ciArtifactId int, content []byte, userId int32) (*bean.AttestationDto, error) {
+	if err := impl.validateAppArtifact(appId, ciArtifactId); err != nil {
+		return nil, err
+	}
+	attestation, err := impl.saveSbom(ciArtifactId, content, userId)
+	if err != nil {
+		impl.logger.Errorw("error in saving sbom of artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return nil, err
+	}
+	return attestation, nil
+}
+
+func (impl *ArtifactProvenanceServiceImpl) GetAttestations(appId, ciArtifactId int) ([]*bean.AttestationDto, error) {
+	if err := impl.validateAppArtifact(appId, ciArtifactId); err != nil {
+		return nil, err
+	}
+	attestations, err := impl.artifactProvenanceRepository.FindAttestations(ciArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching attestations of artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return nil, err
+	}
+	result := make([]*bean.AttestationDto, 0, len(attestations))
+	for _, attestation := range attestations {
+		dto := adaptToAttestationDto(attestation)
+		if attestation.Type == string(bean.AttestationTypeSbom) {
+			dto.Components, err = impl.artifactProvenanceRepository.CountComponents(ciArtifactId)
+			if err != nil {
+				impl.logger.Errorw("error in counting sbom components of artifact", "ciArtifactId", ciArtifactId, "err", err)
+				return nil, err
+			}
+		}
+		result = append(result, dto)
+	}
+	return result, nil
+}
+
+func (impl *ArtifactProvenanceServiceImpl) GetAttestationContent(appId, ciArtifactId int, attestationType bean.AttestationType) (*bean.AttestationDto, []byte, error) {
+	if err := impl.validateAppArtifact(appId, ciArtifactId); err != nil {
+		return nil, nil, err
+	}
+	attestation, err := impl.artifactProvenanceRepository.FindAttestation(ciArtifactId, string(attestationType))
+	if util.IsErrNoRows(err) {
+		errMsg := fmt.Sprintf(bean.AttestationNotFound, attestationType, ciArtifactId)
+		return nil, nil, util.NewApiError().WithHttpStatusCode(http.StatusNotFound).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching attestation of artifact", "ciArtifactId", ciArtifactId, "type", attestationType, "err", err)
+		return nil, nil, err
+	}
+	return adaptToAttestationDto(attestation), []byte(attestation.Content), nil
+}
+
+func (impl *ArtifactProvenanceServiceImpl) validateAppArtifact(appId, ciArtifactId int) error {
+	artifact, err := impl.ciArtifactRepository.Get(ciArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return err
+	}
+	ciPipeline, err := impl.ciPipelineRepository.FindByIdIncludingInActive(artifact.PipelineId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching ci pipeline", "ciPipelineId", artifact.PipelineId, "err", err)
+		return err
+	}
+	if util.IsErrNoRows(err) || ciPipeline.AppId != appId {
+		errMsg := fmt.Sprintf(bean.ArtifactNotInApp, ciArtifactId, appId)
+		return util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	return nil
+}
+
+func (impl *ArtifactProvenanceServiceImpl) SearchComponents(request *bean.ComponentSearchRequest) ([]*bean.ComponentSearchResult, error) {
+	if len(request.Name) == 0 {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.MissingComponentName).WithInternalMessage(bean.MissingComponentName)
+	}
+	if request.Limit <= 0 {
+		request.Limit = bean.DefaultComponentSearchLimit
+	} else if request.Limit > bean.MaxComponentSearchLimit {
+		request.Limit = bean.MaxComponentSearchLimit
+	}
+	matches, err := impl.artifactProvenanceRepository.FindComponents(request.Name, request.Version, request.AppIds, request.DeployedOnly, request.Limit)
+	if err != nil {
+		impl.logger.Errorw("error in searching sbom components", "request", request, "err", err)
+		return nil, err
+	}
+	digests := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if len(match.ImageDigest) > 0 {
+			digests = append(digests, match.ImageDigest)
+		}
+	}
+	deployments, err := impl.artifactProvenanceRepository.FindRunningDeployments(digests)
+	if err != nil {
+		impl.logger.Errorw("error in fetching running deployments of images", "err", err)
+		return nil, err
+	}
+	return buildSearchResults(matches, deployments, request.DeployedOnly), nil
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactProvenance
+
+import (
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/bean"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/repository"
+)
+
+func adaptToAttestationDto(attestation *repository.ArtifactAttestation) *bean.AttestationDto {
+	return &bean.AttestationDto{
+		Id:           attestation.Id,
+		CiArtifactId: attestation.CiArtifactId,
+		Type:         bean.AttestationType(attestation.Type),
+		Format:       bean.AttestationFormat(attestation.Format),
+		Digest:       attestation.Digest,
+		CreatedOn:    attestation.CreatedOn,
+		CreatedBy:    attestation.CreatedBy,
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type AttestationType string
+
+const (
+	AttestationTypeSbom       AttestationType = "SBOM"
+	AttestationTypeProvenance AttestationType = "PROVENANCE"
+)
+
+type AttestationFormat string
+
+const (
+	AttestationFormatSpdx      AttestationFormat = "spdx-json"
+	AttestationFormatCycloneDx AttestationFormat = "cyclonedx-json"
+	AttestationFormatInToto    AttestationFormat = "in-toto"
+)
+
+const (
+	InTotoStatementType     = "https://in-toto.io/Statement/v1"
+	SlsaProvenancePredicate = "https://slsa.dev/provenance/v1"
+	DevtronCiBuildType      = "https://devtron.ai/ci/build/v1"
+	UnsupportedSbomFormat   = "unsupported sbom, expected a spdx or cyclonedx json document"
+	ArtifactNotInApp        = "artifact %d does not belong to app %d"
+	AttestationNotFound     = "%s attestation not found for artifact %d"
+	MissingComponentName    = "component name is required"
+
+	DefaultComponentSearchLimit = 100
+	MaxComponentSearchLimit     = 500
+)
+
+// AttestationDto describes an attestation stored for an artifact, the document is downloaded separately
+type AttestationDto struct {
+	Id           int               `json:"id"`
+	CiArtifactId int               `json:"ciArtifactId"`
+	Type         AttestationType   `json:"type"`
+	Format       AttestationFormat `json:"format"`
+	Digest       string            `json:"digest"`
+	Components   int               `json:"components,omitempty"`
+	CreatedOn    time.Time         `json:"createdOn"`
+	CreatedBy    int32             `json:"createdBy"`
+}
+
+type SbomComponent struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Purl    string `json:"purl,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// ComponentSearchRequest finds the images containing a component, the version matches the version or its patch
+// releases, eg. 2.14 matches 2.14.1
+type ComponentSearchRequest struct {
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	DeployedOnly bool   `json:"deployedOnly"`
+	Limit        int    `json:"limit"`
+	// AppIds are the apps the user can view, the search is restricted to them
+	AppIds []int `json:"-"`
+}
+
+type DeployedEnvironment struct {
+	PipelineId      int    `json:"pipelineId"`
+	EnvironmentId   int    `json:"environmentId"`
+	EnvironmentName string `json:"environmentName"`
+}
+
+type ComponentSearchResult struct {
+	CiArtifactId int                    `json:"ciArtifactId"`
+	AppId        int                    `json:"appId"`
+	AppName      string                 `json:"appName"`
+	Image        string                 `json:"image"`
+	ImageDigest  string                 `json:"imageDigest"`
+	Component    *SbomComponent         `json:"component"`
+	DeployedIn   []*DeployedEnvironment `json:"deployedIn"`
+}
+
+// InTotoStatement is the in-toto attestation statement, unsigned
+type InTotoStatement struct {
+	Type          string                `json:"_type"`
+	Subject       []InTotoSubject       `json:"subject"`
+	PredicateType string                `json:"predicateType"`
+	Predicate     SlsaProvenancePayload `json:"predicate"`
+}
+
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// SlsaProvenancePayload is the slsa v1 provenance predicate
+type SlsaProvenancePayload struct {
+	BuildDefinition SlsaBuildDefinition `json:"buildDefinition"`
+	RunDetails      SlsaRunDetails      `json:"runDetails"`
+}
+
+type SlsaBuildDefinition struct {
+	BuildType            string                   `json:"buildType"`
+	ExternalParameters   map[string]interface{}   `json:"externalParameters"`
+	InternalParameters   map[string]interface{}   `json:"internalParameters,omitempty"`
+	ResolvedDependencies []SlsaResourceDescriptor `json:"resolvedDependencies"`
+}
+
+type SlsaResourceDescriptor struct {
+	Uri    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+type SlsaRunDetails struct {
+	Builder  SlsaBuilder       `json:"builder"`
+	Metadata SlsaBuildMetadata `json:"metadata"`
+}
+
+type SlsaBuilder struct {
+	Id string `json:"id"`
+}
+
+type SlsaBuildMetadata struct {
+	InvocationId string     `json:"invocationId"`
+	StartedOn    *time.Time `json:"startedOn,omitempty"`
+	FinishedOn   *time.Time `json:"finishedOn,omitempty"`
+}
+
+// BuildProvenanceRequest has what the provenance of a ci build is made of
+type BuildProvenanceRequest struct {
+	Image          string
+	ImageDigest    string
+	AppName        string
+	CiPipelineId   int
+	CiPipelineName string
+	CiWorkflowId   int
+	Materials      []BuildMaterial
+	StartedOn      time.Time
+	FinishedOn     time.Time
+}
+
+type BuildMaterial struct {
+	GitUrl string
+	Branch string
+	Commit string
+}
+
+type ArtifactProvenanceConfig struct {
+	// ArtifactProvenanceBuilderId identifies the ci system in the provenance of builds
+	ArtifactProvenanceBuilderId string `env:"ARTIFACT_PROVENANCE_BUILDER_ID" envDefault:"https://devtron.ai/ci-runner"`
+}
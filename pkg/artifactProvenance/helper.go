@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactProvenance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/bean"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/repository"
+	"strings"
+)
+
+type cycloneDxComponent struct {
+	Name       string               `json:"name"`
+	Version    string               `json:"version"`
+	Purl       string               `json:"purl"`
+	Type       string               `json:"type"`
+	Components []cycloneDxComponent `json:"components"`
+}
+
+type cycloneDxDocument struct {
+	BomFormat  string               `json:"bomFormat"`
+	Components []cycloneDxComponent `json:"components"`
+}
+
+type spdxDocument struct {
+	SpdxVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		Name         string `json:"name"`
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// ParseSbom detects whether the sbom is a spdx or a cyclonedx json document and returns its components
+func ParseSbom(content []byte) (bean.AttestationFormat, []*bean.SbomComponent, error) {
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return "", nil, errors.New(bean.UnsupportedSbomFormat)
+	}
+	if _, ok := document["bomFormat"]; ok {
+		cycloneDx := &cycloneDxDocument{}
+		if err := json.Unmarshal(content, cycloneDx); err != nil || cycloneDx.BomFormat != "CycloneDX" {
+			return "", nil, errors.New(bean.UnsupportedSbomFormat)
+		}
+		components := make([]*bean.SbomComponent, 0)
+		flattenCycloneDxComponents(cycloneDx.Components, &components)
+		return bean.AttestationFormatCycloneDx, components, nil
+	}
+	if _, ok := document["spdxVersion"]; ok {
+		spdx := &spdxDocument{}
+		if err := json.Unmarshal(content, spdx); err != nil {
+			return "", nil, errors.New(bean.UnsupportedSbomFormat)
+		}
+		components := make([]*bean.SbomComponent, 0, len(spdx.Packages))
+		for _, pkg := range spdx.Packages {
+			component := &bean.SbomComponent{Name: pkg.Name, Version: pkg.VersionInfo}
+			for _, ref := range pkg.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					component.Purl = ref.ReferenceLocator
+					component.Type = purlType(ref.ReferenceLocator)
+					break
+				}
+			}
+			components = append(components, component)
+		}
+		return bean.AttestationFormatSpdx, components, nil
+	}
+	return "", nil, errors.New(bean.UnsupportedSbomFormat)
+}
+
+// flattenCycloneDxComponents collects nested components too, eg. the jars bundled in a fat jar
+func flattenCycloneDxComponents(cycloneDxComponents []cycloneDxComponent, components *[]*bean.SbomComponent) {
+	for _, component := range cycloneDxComponents {
+		*components = append(*components, &bean.SbomComponent{
+			Name:    component.Name,
+			Version: component.Version,
+			Purl:    component.Purl,
+			Type:    component.Type,
+		})
+		flattenCycloneDxComponents(component.Components, components)
+	}
+}
+
+// purlType returns the package type of a package url like pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1
+func purlType(purl string) string {
+	purlType, _, _ := strings.Cut(strings.TrimPrefix(purl, "pkg:"), "/")
+	return purlType
+}
+
+// BuildProvenanceStatement returns the slsa provenance of the image, its subject is the digest of the image and its
+// dependencies are the git commits it was built from
+func BuildProvenanceStatement(builderId string, request *bean.BuildProvenanceRequest) (*bean.InTotoStatement, error) {
+	algorithm, digest, ok := strings.Cut(request.ImageDigest, ":")
+	if !ok || len(digest) == 0 {
+		return nil, fmt.Errorf("invalid image digest %q", request.ImageDigest)
+	}
+	dependencies := make([]bean.SlsaResourceDescriptor, 0, len(request.Materials))
+	for _, material := range request.Materials {
+		uri := "git+" + material.GitUrl
+		if len(material.Branch) > 0 {
+			uri += "@refs/heads/" + material.Branch
+		}
+		dependencies = append(dependencies, bean.SlsaResourceDescriptor{
+			Uri:    uri,
+			Digest: map[string]string{"gitCommit": material.Commit},
+		})
+	}
+	metadata := bean.SlsaBuildMetadata{InvocationId: fmt.Sprintf("%d", request.CiWorkflowId)}
+	if !request.StartedOn.IsZero() {
+		metadata.StartedOn = &request.StartedOn
+	}
+	if !request.FinishedOn.IsZero() {
+		metadata.FinishedOn = &request.FinishedOn
+	}
+	return &bean.InTotoStatement{
+		Type: bean.InTotoStatementType,
+		Subject: []bean.InTotoSubject{{
+			Name:   imageName(request.Image),
+			Digest: map[string]string{algorithm: digest},
+		}},
+		PredicateType: bean.SlsaProvenancePredicate,
+		Predicate: bean.SlsaProvenancePayload{
+			BuildDefinition: bean.SlsaBuildDefinition{
+				BuildType: bean.DevtronCiBuildType,
+				ExternalParameters: map[string]interface{}{
+					"appName":        request.AppName,
+					"ciPipelineId":   request.CiPipelineId,
+					"ciPipelineName": request.CiPipelineName,
+				},
+				InternalParameters: map[string]interface{}{
+					"ciWorkflowId": request.CiWorkflowId,
+				},
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: bean.SlsaRunDetails{
+				Builder:  bean.SlsaBuilder{Id: builderId},
+				Metadata: metadata,
+			},
+		},
+	}, nil
+}
+
+// imageName strips the tag and the digest of the image
+func imageName(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if index := strings.LastIndex(image, ":"); index > strings.LastIndex(image, "/") {
+		return image[:index]
+	}
+	return image
+}
+
+// buildSearchResults attaches the environments running each matched image, images running nowhere are left out when
+// deployedOnly is set
+func buildSearchResults(matches []*repository.ComponentMatch, deployments []*repository.ImageDeployment, deployedOnly bool) []*bean.ComponentSearchResult {
+	deploymentsByDigest := make(map[string][]*bean.DeployedEnvironment)
+	for _, deployment := range deployments {
+		deploymentsByDigest[deployment.ImageDigest] = append(deploymentsByDigest[deployment.ImageDigest], &bean.DeployedEnvironment{
+			PipelineId:      deployment.PipelineId,
+			EnvironmentId:   deployment.EnvironmentId,
+			EnvironmentName: deployment.EnvironmentName,
+		})
+	}
+	results := make([]*bean.ComponentSearchResult, 0, len(matches))
+	for _, match := range matches {
+		deployedIn := deploymentsByDigest[match.ImageDigest]
+		if len(match.ImageDigest) == 0 {
+			deployedIn = nil
+		}
+		if deployedOnly && len(deployedIn) == 0 {
+			continue
+		}
+		if deployedIn == nil {
+			deployedIn = make([]*bean.DeployedEnvironment, 0)
+		}
+		results = append(results, &bean.ComponentSearchResult{
+			CiArtifactId: match.CiArtifactId,
+			AppId:        match.AppId,
+			AppName:      match.AppName,
+			Image:        match.Image,
+			ImageDigest:  match.ImageDigest,
+			Component: &bean.SbomComponent{
+				Name:    match.Name,
+				Version: match.Version,
+				Purl:    match.Purl,
+				Type:    match.Type,
+			},
+			DeployedIn: deployedIn,
+		})
+	}
+	return results
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package artifactProvenance
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/bean"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseSbom(t *testing.T) {
+	t.Run("cyclonedx with nested components", func(t *testing.T) {
+		sbom := `{"bomFormat":"CycloneDX","specVersion":"1.5","components":[
+			{"type":"library","name":"app.jar","version":"1.0","components":[
+				{"type":"library","name":"log4j-core","version":"2.14.1","purl":"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}]},
+			{"type":"library","name":"openssl","version":"3.0.2","purl":"pkg:deb/ubuntu/openssl@3.0.2"}]}`
+		format, components, err := ParseSbom([]byte(sbom))
+		assert.Nil(t, err)
+		assert.Equal(t, bean.AttestationFormatCycloneDx, format)
+		assert.Equal(t, []*bean.SbomComponent{
+			{Name: "app.jar", Version: "1.0", Type: "library"},
+			{Name: "log4j-core", Version: "2.14.1", Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", Type: "library"},
+			{Name: "openssl", Version: "3.0.2", Purl: "pkg:deb/ubuntu/openssl@3.0.2", Type: "library"},
+		}, components)
+	})
+
+	t.Run("spdx", func(t *testing.T) {
+		sbom := `{"spdxVersion":"SPDX-2.3","packages":[
+			{"name":"log4j-core","versionInfo":"2.14.1","externalRefs":[
+				{"referenceCategory":"SECURITY","referenceType":"cpe23Type","referenceLocator":"cpe:2.3:a:apache:log4j:2.14.1"},
+				{"referenceCategory":"PACKAGE-MANAGER","referenceType":"purl","referenceLocator":"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}]},
+			{"name":"busybox","versionInfo":"1.36.1"}]}`
+		format, components, err := ParseSbom([]byte(sbom))
+		assert.Nil(t, err)
+		assert.Equal(t, bean.AttestationFormatSpdx, format)
+		assert.Equal(t, []*bean.SbomComponent{
+			{Name: "log4j-core", Version: "2.14.1", Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", Type: "maven"},
+			{Name: "busybox", Version: "1.36.1"},
+		}, components)
+	})
+
+	t.Run("unsupported documents", func(t *testing.T) {
+		for _, sbom := range []string{`not json`, `{"packages":[]}`, `{"bomFormat":"Other"}`} {
+			_, _, err := ParseSbom([]byte(sbom))
+			assert.EqualError(t, err, bean.UnsupportedSbomFormat)
+		}
+	})
+}
+
+func TestBuildProvenanceStatement(t *testing.T) {
+	startedOn := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	request := &bean.BuildProvenanceRequest{
+		Image:          "registry.io/team/api:a1b2c3-12",
+		ImageDigest:    "sha256:abc123",
+		AppName:        "api",
+		CiPipelineId:   4,
+		CiPipelineName: "ci-4-api",
+		CiWorkflowId:   12,
+		Materials: []bean.BuildMaterial{
+			{GitUrl: "https://github.com/team/api.git", Branch: "main", Commit: "a1b2c3"},
+			{GitUrl: "https://github.com/team/lib.git", Commit: "d4e5f6"},
+		},
+		StartedOn: startedOn,
+	}
+	statement, err := BuildProvenanceStatement("https://devtron.ai/ci-runner", request)
+	assert.Nil(t, err)
+	content, err := json.Marshal(statement)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"_type": "https://in-toto.io/Statement/v1",
+		"subject": [{"name": "registry.io/team/api", "digest": {"sha256": "abc123"}}],
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"predicate": {
+			"buildDefinition": {
+				"buildType": "https://devtron.ai/ci/build/v1",
+				"externalParameters": {"appName": "api", "ciPipelineId": 4, "ciPipelineName": "ci-4-api"},
+				"internalParameters": {"ciWorkflowId": 12},
+				"resolvedDependencies": [
+					{"uri": "git+https://github.com/team/api.git@refs/heads/main", "digest": {"gitCommit": "a1b2c3"}},
+					{"uri": "git+https://github.com/team/lib.git", "digest": {"gitCommit": "d4e5f6"}}
+				]
+			},
+			"runDetails": {
+				"builder": {"id": "https://devtron.ai/ci-runner"},
+				"metadata": {"invocationId": "12", "startedOn": "2024-05-01T10:00:00Z"}
+			}
+		}
+	}`, string(content))
+
+	_, err = BuildProvenanceStatement("https://devtron.ai/ci-runner", &bean.BuildProvenanceRequest{Image: "registry.io/team/api:v1"})
+	assert.NotNil(t, err)
+}
+
+func TestBuildSearchResults(t *testing.T) {
+	matches := []*repository.ComponentMatch{
+		{CiArtifactId: 3, AppId: 1, Image: "registry.io/api:v2", ImageDigest: "sha256:b", Name: "log4j-core", Version: "2.14.1"},
+		{CiArtifactId: 2, AppId: 1, Image: "registry.io/api:v1", ImageDigest: "sha256:a", Name: "log4j-core", Version: "2.14.0"},
+		{CiArtifactId: 1, AppId: 2, Image: "registry.io/web:v1", Name: "log4j-core", Version: "2.14.0"},
+	}
+	deployments := []*repository.ImageDeployment{
+		{ImageDigest: "sha256:b", PipelineId: 7, EnvironmentId: 5, EnvironmentName: "prod"},
+		{ImageDigest: "sha256:b", PipelineId: 8, EnvironmentId: 6, EnvironmentName: "staging"},
+	}
+
+	results := buildSearchResults(matches, deployments, false)
+	assert.Len(t, results, 3)
+	assert.Len(t, results[0].DeployedIn, 2)
+	assert.Empty(t, results[1].DeployedIn)
+	assert.NotNil(t, results[2].DeployedIn)
+
+	results = buildSearchResults(matches, deployments, true)
+	assert.Len(t, results, 1)
+	assert.Equal(t, 3, results[0].CiArtifactId)
+	assert.Equal(t, "prod", results[0].DeployedIn[0].EnvironmentName)
+}
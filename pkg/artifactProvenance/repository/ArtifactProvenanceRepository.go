@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+// ArtifactAttestation is a sbom or a provenance document of an artifact, one of each type is kept per artifact
+type ArtifactAttestation struct {
+	tableName    struct{} `sql:"artifact_attestation" pg:",discard_unknown_columns"`
+	Id           int      `sql:"id,pk"`
+	CiArtifactId int      `sql:"ci_artifact_id,notnull"`
+	Type         string   `sql:"type,notnull"`
+	Format       string   `sql:"format,notnull"`
+	Content      string   `sql:"content,notnull"`
+	Digest       string   `sql:"digest,notnull"`
+	Active       bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type ArtifactSbomComponent struct {
+	tableName    struct{} `sql:"artifact_sbom_component" pg:",discard_unknown_columns"`
+	Id           int      `sql:"id,pk"`
+	CiArtifactId int      `sql:"ci_artifact_id,notnull"`
+	Name         string   `sql:"name,notnull"`
+	Version      string   `sql:"version"`
+	Purl         string   `sql:"purl"`
+	Type         string   `sql:"type"`
+}
+
+type ComponentMatch struct {
+	CiArtifactId int    `sql:"ci_artifact_id"`
+	AppId        int    `sql:"app_id"`
+	AppName      string `sql:"app_name"`
+	Image        string `sql:"image"`
+	ImageDigest  string `sql:"image_digest"`
+	Name         string `sql:"name"`
+	Version      string `sql:"version"`
+	Purl         string `sql:"purl"`
+	Type         string `sql:"type"`
+}
+
+type ImageDeployment struct {
+	ImageDigest     string `sql:"image_digest"`
+	PipelineId      int    `sql:"pipeline_id"`
+	EnvironmentId   int    `sql:"environment_id"`
+	EnvironmentName string `sql:"environment_name"`
+}
+
+type ArtifactProvenanceRepository interface {
+	GetConnection() *pg.DB
+	// SaveAttestation replaces the attestation of the same type of the artifact
+	SaveAttestation(tx *pg.Tx, attestation *ArtifactAttestation) error
+	FindAttestations(ciArtifactId int) ([]*ArtifactAttestation, error)
+	FindAttestation(ciArtifactId int, attestationType string) (*ArtifactAttestation, error)
+	// SaveComponents replaces the sbom components of the artifact
+	SaveComponents(tx *pg.Tx, ciArtifactId int, components []*ArtifactSbomComponent) error
+	CountComponents(ciArtifactId int) (int, error)
+	// FindComponents returns the components whose name contains name and whose version is version or one of its
+	// patch releases, across the built artifacts of the given apps. With deployedOnly only the images some pipeline
+	// is running are matched, the filters apply before the limit.
+	FindComponents(name string, version string, appIds []int, deployedOnly bool, limit int) ([]*ComponentMatch, error)
+	// FindRunningDeployments returns the pipelines whose last successful deployment runs one of the image digests
+	FindRunningDeployments(imageDigests []string) ([]*ImageDeployment, error)
+}
+
+type ArtifactProvenanceRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewArtifactProvenanceRepositoryImpl(dbConnection *pg.DB) *ArtifactProvenanceRepositoryImpl {
+	return &ArtifactProvenanceRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *ArtifactProvenanceRepositoryImpl) GetConnection() *pg.DB {
+	return impl.dbConnection
+}
+
+func (impl *ArtifactProvenanceRepositoryImpl) SaveAttestation(tx *pg.Tx, attestation *ArtifactAttestation) error {
+	_, err := tx.Model((*ArtifactAttestation)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", attestation.UpdatedOn).
+		Set("updated_by = ?", attestation.UpdatedBy).
+		Where("ci_artifact_id = ?", attestation.CiArtifactId).
+		Where("type = ?", attestation.Type).
+		Where("active = ?", true).
+		Update()
+	if err != nil {
+		return err
+	}
+	return tx.Insert(attestation)
+}
+
+func (impl *ArtifactProvenanceRepositoryImpl) FindAttestations(ciArtifactId int) ([]*ArtifactAttestation, error) {
+	attestations := make([]*ArtifactAttestation, 0)
+	err := impl.dbConnection.Model(&attestations).
+		Column("id", "ci_artifact_id", "type", "format", "digest", "created_on", "created_by", "updated_on", "updated_by").
+		Where("ci_artifact_id = ?", ciArtifactId).
+		Where("active = ?", true).
+		Order("type ASC").
+		Select()
+	return attestations, err
+}
+
+func (impl *ArtifactProvenanceRepositoryImpl) FindAttestation(ciArtifactId int, attestationType string) (*ArtifactAttestation, error) {
+	attestation := &ArtifactAttestation{}
+	err := impl.dbConnection.Model(attestation).
+		Where("ci_artifact_id = ?", ciArtifactId).
+		Where("type = ?", attestationType).
+		Where("active = ?", true).
+		Select()
+	return attestation, err
+}
+
+func (impl *ArtifactProvenanceRepositoryImpl) SaveComponents(tx *pg.Tx, ciArtifactId int, components []*ArtifactSbomComponent) error {
+	_, err := tx.Model((*ArtifactSbomComponent)(nil)).
+		Where("ci_artifact_id = ?", ciArtifactId).
+		Delete()
+	if err != nil || len(components) == 0 {
+		return err
+	}
+	_, err = tx.Model(&components).Insert()
+	return err
+}
+
+func (impl *ArtifactProvenanceRepositoryImpl) CountComponents(ciArtifactId int) (int, error) {
+	return impl.dbConnection.Model((*ArtifactSbomComponent)(nil)).
+		Where("ci_artifact_id = ?", ciArtifactId).
+		Count()
+}
+
+func (impl *ArtifactProvenanceRepositoryImpl) FindComponents(name string, version string, appIds []int, deployedOnly bool, limit int) ([]*ComponentMatch, error) {
+	matches := make([]*ComponentMatch, 0)
+	if len(appIds) == 0 {
+		return matches, nil
+	}
+	query := "SELECT sc.ci_artifact_id, cp.app_id, a.app_name, cia.image, cia.image_digest, sc.name, sc.version, sc.purl, sc.type" +
+		" FROM artifact_sbom_component sc" +
+		" INNER JOIN ci_artifact cia ON cia.id = sc.ci_artifact_id" +
+		" INNER JOIN ci_pipeline cp ON cp.id = cia.pipeline_id" +
+		" INNER JOIN app a ON a.id = cp.app_id AND a.active = true" +
+		" WHERE sc.name ILIKE ? AND cp.app_id IN (?)"
+	params := []interface{}{"%" + name + "%", pg.In(appIds)}
+	if len(version) > 0 {
+		query += " AND (sc.version = ? OR sc.version LIKE ?)"
+		params = append(params, version, version+".%")
+	}
+	if deployedOnly {
+		// digests of the artifacts last deployed successfully by each pipeline, as in FindRunningDeployments
+		query += " AND cia.image_digest IN (SELECT cia2.image_digest" +
+			" FROM (SELECT DISTINCT ON (cw.pipeline_id) cw.pipeline_id, cw.ci_artifact_id" +
+			" FROM cd_workflow_runner cwr" +
+			" INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id" +
+			" WHERE cwr.workflow_type = ? AND cwr.status IN (?)" +
+			" ORDER BY cw.pipeline_id, cwr.id DESC) latest" +
+			" INNER JOIN pipeline p ON p.id = latest.pipeline_id AND p.deleted = false" +
+			" INNER JOIN ci_artifact cia2 ON cia2.id = latest.ci_artifact_id" +
+			" WHERE cia2.image_digest <> '')"
+		params = append(params, apiBean.CD_WORKFLOW_TYPE_DEPLOY, pg.In(cdWorkflow.WfrHealthyStatusList))
+	}
+	query += " ORDER BY sc.ci_artifact_id DESC, sc.name ASC LIMIT ?"
+	params = append(params, limit)
+	_, err := impl.dbConnection.Query(&matches, query, params...)
+	return matches, err
+}
+
+func (impl *ArtifactProvenanceRepositoryImpl) FindRunningDeployments(imageDigests []string) ([]*ImageDeployment, error) {
+	deployments := make([]*ImageDeployment, 0)
+	if len(imageDigests) == 0 {
+		return deployments, nil
+	}
+	query := "SELECT cia.image_digest, latest.pipeline_id, e.id AS environment_id, e.environment_name" +
+		" FROM (SELECT DISTINCT ON (cw.pipeline_id) cw.pipeline_id, cw.ci_artifact_id" +
+		" FROM cd_workflow_runner cwr" +
+		" INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id" +
+		" WHERE cwr.workflow_type = ? AND cwr.status IN (?)" +
+		// only the pipelines which ever deployed one of the images can be running it
+		" AND cw.pipeline_id IN (SELECT cw2.pipeline_id FROM cd_workflow cw2 INNER JOIN ci_artifact cia2 ON cia2.id = cw2.ci_artifact_id WHERE cia2.image_digest IN (?))" +
+		" ORDER BY cw.pipeline_id, cwr.id DESC) latest" +
+		" INNER JOIN pipeline p ON p.id = latest.pipeline_id AND p.deleted = false" +
+		" INNER JOIN environment e ON e.id = p.environment_id" +
+		" INNER JOIN ci_artifact cia ON cia.id = latest.ci_artifact_id" +
+		" WHERE cia.image_digest IN (?)"
+	_, err := impl.dbConnection.Query(&deployments, query, apiBean.CD_WORKFLOW_TYPE_DEPLOY, pg.In(cdWorkflow.WfrHealthyStatusList),
+		pg.In(imageDigests), pg.In(imageDigests))
+	return deployments, err
+}
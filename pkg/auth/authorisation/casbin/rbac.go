@@ -41,12 +41,19 @@ type Enforcer interface {
 	Enforce(token string, resource string, action string, resourceItem string) bool
 	//EnforceErr(emailId string, resource string, action string, resourceItem string) error
 	EnforceInBatch(token string, resource string, action string, vals []string) map[string]bool
-	//EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool
+	EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool
 	//EnforceByEmailInBatch(emailId string, resource string, action string, vals []string) map[string]bool
 	InvalidateCache(emailId string) bool
 	InvalidateCompleteCache()
 	ReloadPolicy() error
 	GetCacheDump() string
+	SetApiTokenScopeChecker(checker ApiTokenScopeChecker)
+}
+
+// ApiTokenScopeChecker narrows down the permissions of scoped api-tokens, on top of the roles assigned to them
+type ApiTokenScopeChecker interface {
+	// IsAllowedByScope returns false only when emailId belongs to a scoped api-token and none of its scopes allow the request
+	IsAllowedByScope(emailId string, resource string, action string, resourceItem string) bool
 }
 
 func NewEnforcerImpl(
@@ -115,6 +122,8 @@ type EnforcerImpl struct {
 	logger         *zap.SugaredLogger
 	enforcerConfig *EnforcerConfig
 	enforcerRWLock *sync.RWMutex
+
+	apiTokenScopeChecker ApiTokenScopeChecker
 }
 
 // Enforce is a wrapper around casbin.Enforce to additionally enforce a default role and a custom
@@ -124,13 +133,42 @@ func (e *EnforcerImpl) Enforce(token string, resource string, action string, res
 }
 
 func (e *EnforcerImpl) EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool {
-	return e.enforceByEmail(strings.ToLower(emailId), resource, action, strings.ToLower(resourceItem))
+	emailId, resourceItem = strings.ToLower(emailId), strings.ToLower(resourceItem)
+	if !e.isAllowedByApiTokenScope(emailId, resource, action, resourceItem) {
+		return false
+	}
+	return e.enforceByEmail(emailId, resource, action, resourceItem)
 }
 
 func (e *EnforcerImpl) EnforceInBatch(token string, resource string, action string, vals []string) map[string]bool {
 	return e.enforceInBatch(token, resource, action, vals)
 }
 
+// SetApiTokenScopeChecker registers the checker consulted for every enforcement, before casbin policies are evaluated
+func (e *EnforcerImpl) SetApiTokenScopeChecker(checker ApiTokenScopeChecker) {
+	e.apiTokenScopeChecker = checker
+}
+
+func (e *EnforcerImpl) isAllowedByApiTokenScope(emailId string, resource string, action string, resourceItem string) bool {
+	if e.apiTokenScopeChecker == nil {
+		return true
+	}
+	return e.apiTokenScopeChecker.IsAllowedByScope(emailId, resource, action, resourceItem)
+}
+
+// filterByApiTokenScope returns a copy of result with the items not allowed by api-token scopes denied,
+// result itself is not modified as it is shared with the enforcer cache
+func (e *EnforcerImpl) filterByApiTokenScope(emailId string, resource string, action string, result map[string]bool) map[string]bool {
+	if e.apiTokenScopeChecker == nil {
+		return result
+	}
+	filteredResult := make(map[string]bool, len(result))
+	for resourceItem, allowed := range result {
+		filteredResult[resourceItem] = allowed && e.apiTokenScopeChecker.IsAllowedByScope(emailId, resource, action, resourceItem)
+	}
+	return filteredResult
+}
+
 func (e *EnforcerImpl) ReloadPolicy() error {
 	//e.enforcerRWLock.Lock()
 	//defer e.enforcerRWLock.Unlock()
@@ -184,6 +222,10 @@ func (e *EnforcerImpl) enforceByEmailInBatchSync(wg *sync.WaitGroup, mutex *sync
 
 func (e *EnforcerImpl) EnforceByEmailInBatch(emailId string, resource string, action string, vals []string) map[string]bool {
 	emailId = strings.ToLower(emailId)
+	return e.filterByApiTokenScope(emailId, resource, action, e.enforceByEmailInBatch(emailId, resource, action, vals))
+}
+
+func (e *EnforcerImpl) enforceByEmailInBatch(emailId string, resource string, action string, vals []string) map[string]bool {
 	var totalTimeGap int64 = 0
 	var maxTimegap int64 = 0
 	var minTimegap int64 = math.MaxInt64
@@ -22,12 +22,16 @@ import (
 	"github.com/devtron-labs/devtron/util"
 	"math/rand"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/casbin/casbin"
 	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestEnforcerCache(t *testing.T) {
@@ -137,3 +141,60 @@ func getEnforcerCacheLock_123(lock map[string]*CacheData, emailId string) *Cache
 func enforce(randomeKey string) bool {
 	return len(randomeKey)%2 == 0
 }
+
+const testAuthModel = `
+[request_definition]
+r = sub, res, act, obj
+
+[policy_definition]
+p = sub, res, act, obj, eft
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[role_definition]
+g = _, _
+
+[matchers]
+m = g(r.sub, p.sub) && matchKeyByPart(r.res, p.res) && matchKeyByPart(r.act, p.act) && matchKeyByPart(r.obj, p.obj)
+`
+
+// stagingOnlyScopeChecker scopes the api-token:ci token down to the staging objects
+type stagingOnlyScopeChecker struct{}
+
+func (c stagingOnlyScopeChecker) IsAllowedByScope(emailId string, resource string, action string, resourceItem string) bool {
+	return emailId != "api-token:ci" || strings.HasPrefix(resourceItem, "staging/")
+}
+
+func newTestEnforcerImpl() *EnforcerImpl {
+	syncedEnforcer := casbin.NewSyncedEnforcer(casbin.NewModel(testAuthModel))
+	syncedEnforcer.AddFunction("matchKeyByPart", MatchKeyByPartFunc)
+	syncedEnforcer.AddPolicy("role:super-admin", "*", "*", "*", "allow")
+	syncedEnforcer.AddGroupingPolicy("api-token:ci", "role:super-admin")
+	syncedEnforcer.AddGroupingPolicy("admin@example.com", "role:super-admin")
+	enforcer := &EnforcerImpl{
+		lockCacheData:    make(map[string]*CacheData),
+		batchRequestLock: make(map[string]*sync.Mutex),
+		enforcerRWLock:   &sync.RWMutex{},
+		enforcerConfig:   &EnforcerConfig{EnforcerBatchSize: 1},
+		Enforcer:         syncedEnforcer,
+		logger:           zap.NewNop().Sugar(),
+	}
+	enforcer.SetApiTokenScopeChecker(stagingOnlyScopeChecker{})
+	return enforcer
+}
+
+func TestEnforcerImpl_EnforceByEmail_ApiTokenScope(t *testing.T) {
+	enforcer := newTestEnforcerImpl()
+	assert.True(t, enforcer.EnforceByEmail("api-token:ci", "applications", "trigger", "staging/payments"))
+	assert.False(t, enforcer.EnforceByEmail("api-token:ci", "applications", "trigger", "prod/payments"))
+	assert.True(t, enforcer.EnforceByEmail("admin@example.com", "applications", "trigger", "prod/payments"))
+}
+
+func TestEnforcerImpl_EnforceByEmailInBatch_ApiTokenScope(t *testing.T) {
+	enforcer := newTestEnforcerImpl()
+	result := enforcer.EnforceByEmailInBatch("api-token:ci", "applications", "trigger", []string{"staging/payments", "prod/payments"})
+	assert.Equal(t, map[string]bool{"staging/payments": true, "prod/payments": false}, result)
+	result = enforcer.EnforceByEmailInBatch("admin@example.com", "applications", "trigger", []string{"staging/payments", "prod/payments"})
+	assert.Equal(t, map[string]bool{"staging/payments": true, "prod/payments": true}, result)
+}
@@ -0,0 +1,477 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jitAccess
+
+import (
+	"encoding/json"
+	"github.com/caarlos0/env"
+	bean2 "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/repository"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	repository2 "github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type JitAccessService interface {
+	// CreateRequest requests the roles of a role filter for the logged-in user, it is pending until approved
+	CreateRequest(request *bean.JitAccessRequestDto) (*bean.JitAccessRequestDto, error)
+	// ApproveRequest grants the roles of the request in casbin until the request expires
+	ApproveRequest(review *bean.AccessReviewRequest) (*bean.JitAccessRequestDto, error)
+	RejectRequest(review *bean.AccessReviewRequest) (*bean.JitAccessRequestDto, error)
+	// RevokeRequest withdraws a pending request or revokes the roles granted by an approved one
+	RevokeRequest(review *bean.AccessReviewRequest) (*bean.JitAccessRequestDto, error)
+	GetRequest(id int) (*bean.JitAccessRequestDetailDto, error)
+	GetRequests(filter *bean.AccessRequestFilter) ([]*bean.JitAccessRequestDto, error)
+	// IsApprover checks if the user is a super admin or a member of one of the approver role groups
+	IsApprover(userId int32) (bool, error)
+	// ExpireGrants revokes the roles of the approved requests past their expiry and syncs casbin
+	ExpireGrants()
+}
+
+type JitAccessServiceImpl struct {
+	logger              *zap.SugaredLogger
+	jitAccessRepository repository.JitAccessRepository
+	userService         user.UserService
+	roleGroupService    user.RoleGroupService
+	userCommonService   user.UserCommonService
+	userAuthRepository  repository2.UserAuthRepository
+	config              *bean.JitAccessConfig
+}
+
+func NewJitAccessServiceImpl(logger *zap.SugaredLogger,
+	jitAccessRepository repository.JitAccessRepository,
+	userService user.UserService,
+	roleGroupService user.RoleGroupService,
+	userCommonService user.UserCommonService,
+	userAuthRepository repository2.UserAuthRepository) (*JitAccessServiceImpl, error) {
+	config := &bean.JitAccessConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing jit access config", "err", err)
+		return nil, err
+	}
+	return &JitAccessServiceImpl{
+		logger:              logger,
+		jitAccessRepository: jitAccessRepository,
+		userService:         userService,
+		roleGroupService:    roleGroupService,
+		userCommonService:   userCommonService,
+		userAuthRepository:  userAuthRepository,
+		config:              config,
+	}, nil
+}
+
+func (impl *JitAccessServiceImpl) CreateRequest(request *bean.JitAccessRequestDto) (*bean.JitAccessRequestDto, error) {
+	err := validateAccessRequest(request, impl.config.JitAccessMaxDurationMinutes)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	dbObject, err := toAccessRequestDbObject(request)
+	if err != nil {
+		impl.logger.Errorw("error in marshalling role filter", "roleFilter", request.RoleFilter, "err", err)
+		return nil, err
+	}
+	tx, err := impl.jitAccessRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.jitAccessRepository.RollbackTx(tx)
+	err = impl.jitAccessRepository.Save(tx, dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in saving jit access request", "userId", request.UserId, "err", err)
+		return nil, err
+	}
+	err = impl.jitAccessRepository.SaveAudit(tx, toAuditDbObject(dbObject.Id, bean.AccessActionRequested, request.Reason, request.UserId))
+	if err != nil {
+		impl.logger.Errorw("error in saving jit access request audit", "requestId", dbObject.Id, "err", err)
+		return nil, err
+	}
+	err = impl.jitAccessRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	return impl.toAccessRequestDto(dbObject)
+}
+
+func (impl *JitAccessServiceImpl) ApproveRequest(review *bean.AccessReviewRequest) (*bean.JitAccessRequestDto, error) {
+	request, err := impl.getReviewableRequest(review)
+	if err != nil {
+		return nil, err
+	}
+	requester, err := impl.userService.GetById(request.RequestedBy)
+	if err != nil {
+		impl.logger.Errorw("error in fetching requester of jit access request", "id", request.Id, "userId", request.RequestedBy, "err", err)
+		return nil, err
+	}
+	roleFilter := bean2.RoleFilter{}
+	err = json.Unmarshal([]byte(request.RoleFilter), &roleFilter)
+	if err != nil {
+		impl.logger.Errorw("error in unmarshalling role filter", "id", request.Id, "err", err)
+		return nil, err
+	}
+	roles, policies, err := impl.getOrCreateRoles(roleFilter, review.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in getting roles of jit access request", "id", request.Id, "roleFilter", roleFilter, "err", err)
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.NoRoleFoundForFilter).WithInternalMessage(bean.NoRoleFoundForFilter)
+	}
+	now := time.Now()
+	expiresOn := now.Add(time.Duration(request.DurationMinutes) * time.Minute)
+	request.Status = bean.AccessStatusApproved
+	request.GrantedRoles = roles
+	request.ReviewedBy = review.UserId
+	request.ReviewedOn = &now
+	request.ExpiresOn = &expiresOn
+	err = impl.updateRequest(request, bean.AccessActionApproved, review.Comment, review.UserId)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		policies = append(policies, casbin.Policy{Type: "g", Sub: casbin.Subject(requester.EmailId), Obj: casbin.Object(role)})
+	}
+	// policies already present, like a role the user holds permanently, are reported as failed and need no action
+	failed := casbin.AddPolicy(policies)
+	impl.logger.Debugw("granted jit access", "id", request.Id, "roles", roles, "failedPolicies", failed)
+	return impl.toAccessRequestDto(request)
+}
+
+func (impl *JitAccessServiceImpl) RejectRequest(review *bean.AccessReviewRequest) (*bean.JitAccessRequestDto, error) {
+	request, err := impl.getReviewableRequest(review)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	request.Status = bean.AccessStatusRejected
+	request.ReviewedBy = review.UserId
+	request.ReviewedOn = &now
+	err = impl.updateRequest(request, bean.AccessActionRejected, review.Comment, review.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return impl.toAccessRequestDto(request)
+}
+
+// getReviewableRequest returns the request if it is pending and the reviewer can review it
+func (impl *JitAccessServiceImpl) getReviewableRequest(review *bean.AccessReviewRequest) (*repository.JitAccessRequest, error) {
+	request, err := impl.jitAccessRepository.FindById(review.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching jit access request", "id", review.Id, "err", err)
+		return nil, err
+	}
+	err = validateReview(request, review.UserId)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	isApprover, err := impl.IsApprover(review.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if !isApprover {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(bean.NotAnApprover).WithInternalMessage(bean.NotAnApprover)
+	}
+	return request, nil
+}
+
+func (impl *JitAccessServiceImpl) RevokeRequest(review *bean.AccessReviewRequest) (*bean.JitAccessRequestDto, error) {
+	request, err := impl.jitAccessRepository.FindById(review.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching jit access request", "id", review.Id, "err", err)
+		return nil, err
+	}
+	err = validateRevoke(request)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	err = impl.revokeRequest(request, bean.AccessStatusRevoked, bean.AccessActionRevoked, review.Comment, review.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return impl.toAccessRequestDto(request)
+}
+
+// revokeRequest removes the roles granted by the request from casbin before marking it, so a failed update is retried
+// by the sweeper without leaving the roles granted
+func (impl *JitAccessServiceImpl) revokeRequest(request *repository.JitAccessRequest, status bean.AccessStatus, action bean.AccessAction, comment string, userId int32) error {
+	if request.Status == bean.AccessStatusApproved {
+		err := impl.removeGrantedRoles(request)
+		if err != nil {
+			return err
+		}
+	}
+	request.Status = status
+	return impl.updateRequest(request, action, comment, userId)
+}
+
+func (impl *JitAccessServiceImpl) removeGrantedRoles(request *repository.JitAccessRequest) error {
+	requester, err := impl.userService.GetByIdIncludeDeleted(request.RequestedBy)
+	if err != nil {
+		impl.logger.Errorw("error in fetching requester of jit access request", "id", request.Id, "userId", request.RequestedBy, "err", err)
+		return err
+	}
+	retainedRoles := make(map[string]bool)
+	userRoles, err := impl.userAuthRepository.GetRolesByUserId(request.RequestedBy)
+	if err != nil {
+		impl.logger.Errorw("error in fetching roles of user", "userId", request.RequestedBy, "err", err)
+		return err
+	}
+	for _, role := range userRoles {
+		retainedRoles[role.Role] = true
+	}
+	activeGrants, err := impl.jitAccessRepository.FindActiveGrantsByUserId(request.RequestedBy, time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in fetching active jit access grants of user", "userId", request.RequestedBy, "err", err)
+		return err
+	}
+	for _, grant := range activeGrants {
+		if grant.Id == request.Id {
+			continue
+		}
+		for _, role := range grant.GrantedRoles {
+			retainedRoles[role] = true
+		}
+	}
+	roles := getRolesToRevoke(request.GrantedRoles, retainedRoles)
+	if len(roles) == 0 {
+		return nil
+	}
+	policies := make([]casbin.Policy, 0, len(roles))
+	for _, role := range roles {
+		policies = append(policies, casbin.Policy{Type: "g", Sub: casbin.Subject(requester.EmailId), Obj: casbin.Object(role)})
+	}
+	// policies already removed, like on deleting the user, are reported as failed and need no action
+	failed := casbin.RemovePolicy(policies)
+	impl.logger.Debugw("revoked jit access", "id", request.Id, "roles", roles, "failedPolicies", failed)
+	return nil
+}
+
+// updateRequest saves the request along with the audit of the action
+func (impl *JitAccessServiceImpl) updateRequest(request *repository.JitAccessRequest, action bean.AccessAction, comment string, userId int32) error {
+	tx, err := impl.jitAccessRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.jitAccessRepository.RollbackTx(tx)
+	request.UpdateAuditLog(userId)
+	err = impl.jitAccessRepository.Update(tx, request)
+	if err != nil {
+		impl.logger.Errorw("error in updating jit access request", "id", request.Id, "err", err)
+		return err
+	}
+	err = impl.jitAccessRepository.SaveAudit(tx, toAuditDbObject(request.Id, action, comment, userId))
+	if err != nil {
+		impl.logger.Errorw("error in saving jit access request audit", "requestId", request.Id, "err", err)
+		return err
+	}
+	err = impl.jitAccessRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *JitAccessServiceImpl) GetRequest(id int) (*bean.JitAccessRequestDetailDto, error) {
+	request, err := impl.jitAccessRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching jit access request", "id", id, "err", err)
+		return nil, err
+	}
+	audits, err := impl.jitAccessRepository.FindAuditsByRequestId(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching jit access request audits", "id", id, "err", err)
+		return nil, err
+	}
+	dto, err := impl.toAccessRequestDto(request)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*bean.AccessAuditDto, 0, len(audits))
+	for _, audit := range audits {
+		history = append(history, toAuditDto(audit))
+	}
+	grantedRoles := request.GrantedRoles
+	if grantedRoles == nil {
+		grantedRoles = make([]string, 0)
+	}
+	return &bean.JitAccessRequestDetailDto{
+		JitAccessRequestDto: dto,
+		GrantedRoles:        grantedRoles,
+		History:             history,
+	}, nil
+}
+
+func (impl *JitAccessServiceImpl) GetRequests(filter *bean.AccessRequestFilter) ([]*bean.JitAccessRequestDto, error) {
+	requests, err := impl.jitAccessRepository.FindAll(filter)
+	if err != nil {
+		impl.logger.Errorw("error in fetching jit access requests", "filter", filter, "err", err)
+		return nil, err
+	}
+	return impl.toAccessRequestDtos(requests)
+}
+
+func (impl *JitAccessServiceImpl) IsApprover(userId int32) (bool, error) {
+	userInfo, err := impl.userService.GetById(userId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching user", "userId", userId, "err", err)
+		return false, err
+	}
+	if userInfo.SuperAdmin {
+		return true, nil
+	}
+	approverGroupIds, err := impl.getApproverRoleGroupIds()
+	if err != nil {
+		return false, err
+	}
+	for _, userRoleGroup := range userInfo.UserRoleGroup {
+		if userRoleGroup.RoleGroup != nil && approverGroupIds[userRoleGroup.RoleGroup.Id] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (impl *JitAccessServiceImpl) getApproverRoleGroupIds() (map[int32]bool, error) {
+	groupIds := make(map[int32]bool)
+	for _, name := range impl.config.JitAccessApproverRoleGroups {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+		roleGroups, err := impl.roleGroupService.FetchRoleGroupsByName(name)
+		if err != nil {
+			impl.logger.Errorw("error in fetching approver role group", "name", name, "err", err)
+			return nil, err
+		}
+		// the search matches names partially, only the group with the configured name is an approver group
+		for _, roleGroup := range roleGroups {
+			if strings.EqualFold(roleGroup.Name, name) {
+				groupIds[roleGroup.Id] = true
+			}
+		}
+	}
+	return groupIds, nil
+}
+
+func (impl *JitAccessServiceImpl) ExpireGrants() {
+	requests, err := impl.jitAccessRepository.FindExpiredGrants(time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in fetching expired jit access grants", "err", err)
+		return
+	}
+	if len(requests) == 0 {
+		return
+	}
+	for _, request := range requests {
+		err = impl.revokeRequest(request, bean.AccessStatusExpired, bean.AccessActionExpired, "", userBean.SystemUserId)
+		if err != nil {
+			impl.logger.Errorw("error in expiring jit access grant", "id", request.Id, "err", err)
+		}
+	}
+	// reloads the policies so the enforcer holds none of the expired grants
+	_, err = impl.userService.SyncOrchestratorToCasbin()
+	if err != nil {
+		impl.logger.Errorw("error in syncing orchestrator to casbin after expiring jit access grants", "err", err)
+	}
+}
+
+// getOrCreateRoles returns the casbin roles of the role filter along with the policies of the roles it had to create,
+// the roles are created the way they are on assigning the role filter to a user
+func (impl *JitAccessServiceImpl) getOrCreateRoles(roleFilter bean2.RoleFilter, userId int32) ([]string, []casbin.Policy, error) {
+	roles := make([]string, 0)
+	policies := make([]casbin.Policy, 0)
+	entity, actionType, accessType := roleFilter.Entity, roleFilter.Action, roleFilter.AccessType
+	act := actionType
+	if entity == userBean.CLUSTER_ENTITIY {
+		act = ""
+	}
+	for _, key := range getRoleKeys(roleFilter) {
+		roleModel, err := impl.userAuthRepository.GetRoleByFilterForAllTypes(entity, key.team, key.entityName, key.env, act, accessType, key.cluster, key.namespace, key.group, key.kind, key.resource, actionType, false, key.workflow)
+		if err != nil {
+			return nil, nil, err
+		}
+		if roleModel.Id == 0 {
+			flag, err, policiesAdded := impl.userCommonService.CreateDefaultPoliciesForAllTypes(key.team, key.entityName, key.env, entity, key.cluster, key.namespace, key.group, key.kind, key.resource, actionType, accessType, key.workflow, userId)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !flag {
+				continue
+			}
+			policies = append(policies, policiesAdded...)
+			roleModel, err = impl.userAuthRepository.GetRoleByFilterForAllTypes(entity, key.team, key.entityName, key.env, act, accessType, key.cluster, key.namespace, key.group, key.kind, key.resource, actionType, false, key.workflow)
+			if err != nil {
+				return nil, nil, err
+			}
+			if roleModel.Id == 0 {
+				continue
+			}
+		}
+		roles = append(roles, roleModel.Role)
+	}
+	return roles, policies, nil
+}
+
+func (impl *JitAccessServiceImpl) toAccessRequestDto(request *repository.JitAccessRequest) (*bean.JitAccessRequestDto, error) {
+	dtos, err := impl.toAccessRequestDtos([]*repository.JitAccessRequest{request})
+	if err != nil {
+		return nil, err
+	}
+	return dtos[0], nil
+}
+
+// toAccessRequestDtos converts the requests, filling the emails of their requesters
+func (impl *JitAccessServiceImpl) toAccessRequestDtos(requests []*repository.JitAccessRequest) ([]*bean.JitAccessRequestDto, error) {
+	now := time.Now()
+	userIds := make([]int32, 0, len(requests))
+	for _, request := range requests {
+		userIds = append(userIds, request.RequestedBy)
+	}
+	emails := make(map[int32]string)
+	if len(userIds) > 0 {
+		users, err := impl.userService.GetByIds(userIds)
+		if err != nil {
+			// emails are informational, the requests are returned without them
+			impl.logger.Errorw("error in fetching requesters of jit access requests", "err", err)
+		}
+		for _, item := range users {
+			emails[item.Id] = item.EmailId
+		}
+	}
+	dtos := make([]*bean.JitAccessRequestDto, 0, len(requests))
+	for _, request := range requests {
+		dto, err := toAccessRequestDto(request, now)
+		if err != nil {
+			impl.logger.Errorw("error in converting jit access request", "id", request.Id, "err", err)
+			return nil, err
+		}
+		dto.RequestedByName = emails[request.RequestedBy]
+		dtos = append(dtos, dto)
+	}
+	return dtos, nil
+}
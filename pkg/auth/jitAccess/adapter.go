@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jitAccess
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"time"
+)
+
+func toAccessRequestDbObject(dto *bean.JitAccessRequestDto) (*repository.JitAccessRequest, error) {
+	roleFilter, err := json.Marshal(dto.RoleFilter)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.JitAccessRequest{
+		RequestedBy:     dto.UserId,
+		RoleFilter:      string(roleFilter),
+		DurationMinutes: dto.DurationMinutes,
+		Reason:          dto.Reason,
+		Status:          bean.AccessStatusPending,
+		Active:          true,
+		AuditLog:        sql.NewDefaultAuditLog(dto.UserId),
+	}, nil
+}
+
+func toAccessRequestDto(request *repository.JitAccessRequest, now time.Time) (*bean.JitAccessRequestDto, error) {
+	dto := &bean.JitAccessRequestDto{
+		Id:              request.Id,
+		DurationMinutes: request.DurationMinutes,
+		Reason:          request.Reason,
+		Status:          getEffectiveStatus(request, now),
+		RequestedBy:     request.RequestedBy,
+		RequestedOn:     request.CreatedOn,
+		ReviewedBy:      request.ReviewedBy,
+		ReviewedOn:      request.ReviewedOn,
+		ExpiresOn:       request.ExpiresOn,
+	}
+	err := json.Unmarshal([]byte(request.RoleFilter), &dto.RoleFilter)
+	if err != nil {
+		return nil, err
+	}
+	return dto, nil
+}
+
+func toAuditDbObject(requestId int, action bean.AccessAction, comment string, userId int32) *repository.JitAccessRequestAudit {
+	return &repository.JitAccessRequestAudit{
+		RequestId: requestId,
+		Action:    action,
+		Comment:   comment,
+		ActionBy:  userId,
+		ActionOn:  time.Now(),
+	}
+}
+
+func toAuditDto(audit *repository.JitAccessRequestAudit) *bean.AccessAuditDto {
+	return &bean.AccessAuditDto{
+		Action:   audit.Action,
+		Comment:  audit.Comment,
+		ActionBy: audit.ActionBy,
+		ActionOn: audit.ActionOn,
+	}
+}
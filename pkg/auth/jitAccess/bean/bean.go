@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"github.com/devtron-labs/devtron/api/bean"
+	"time"
+)
+
+type AccessStatus string
+
+const (
+	AccessStatusPending  AccessStatus = "PENDING"
+	AccessStatusApproved AccessStatus = "APPROVED"
+	AccessStatusRejected AccessStatus = "REJECTED"
+	AccessStatusRevoked  AccessStatus = "REVOKED"
+	AccessStatusExpired  AccessStatus = "EXPIRED"
+)
+
+type AccessAction string
+
+const (
+	AccessActionRequested AccessAction = "REQUESTED"
+	AccessActionApproved  AccessAction = "APPROVED"
+	AccessActionRejected  AccessAction = "REJECTED"
+	AccessActionRevoked   AccessAction = "REVOKED"
+	AccessActionExpired   AccessAction = "EXPIRED"
+)
+
+const (
+	InvalidRoleFilter      = "a role filter needs an entity, or a team and an action"
+	SuperAdminNotAllowed   = "super admin access cannot be requested"
+	InvalidDuration        = "duration must be between 1 and %d minutes"
+	RequestNotPending      = "access request %d is %s, only pending requests can be approved or rejected"
+	RequestNotRevocable    = "access request %d is %s, only pending or approved requests can be revoked"
+	SelfApprovalNotAllowed = "an access request cannot be approved or rejected by the user who requested it"
+	NotAnApprover          = "only super admins or members of the approver role groups can review access requests"
+	NoRoleFoundForFilter   = "no role found for the requested role filter"
+)
+
+// JitAccessRequestDto asks for the roles of a role filter for a duration. Once approved the roles are granted to the
+// requester in casbin until ExpiresOn, when they are revoked, roles the user holds otherwise are left untouched
+type JitAccessRequestDto struct {
+	Id              int             `json:"id"`
+	RoleFilter      bean.RoleFilter `json:"roleFilter"`
+	DurationMinutes int             `json:"durationMinutes" validate:"required,min=1"`
+	Reason          string          `json:"reason" validate:"required,max=500"`
+	Status          AccessStatus    `json:"status"`
+	RequestedBy     int32           `json:"requestedBy"`
+	RequestedByName string          `json:"requestedByName,omitempty"`
+	RequestedOn     time.Time       `json:"requestedOn"`
+	ReviewedBy      int32           `json:"reviewedBy,omitempty"`
+	ReviewedOn      *time.Time      `json:"reviewedOn,omitempty"`
+	ExpiresOn       *time.Time      `json:"expiresOn,omitempty"`
+	UserId          int32           `json:"-"`
+}
+
+// AccessReviewRequest approves, rejects or revokes an access request
+type AccessReviewRequest struct {
+	Id      int    `json:"-"`
+	Comment string `json:"comment" validate:"max=500"`
+	UserId  int32  `json:"-"`
+}
+
+type AccessRequestFilter struct {
+	RequestedBy int32
+	Status      AccessStatus
+}
+
+type AccessAuditDto struct {
+	Action   AccessAction `json:"action"`
+	Comment  string       `json:"comment,omitempty"`
+	ActionBy int32        `json:"actionBy"`
+	ActionOn time.Time    `json:"actionOn"`
+}
+
+type JitAccessRequestDetailDto struct {
+	*JitAccessRequestDto
+	GrantedRoles []string          `json:"grantedRoles"`
+	History      []*AccessAuditDto `json:"history"`
+}
+
+type JitAccessConfig struct {
+	// JitAccessMaxDurationMinutes caps the duration an access can be requested for
+	JitAccessMaxDurationMinutes int `env:"JIT_ACCESS_MAX_DURATION_MINUTES" envDefault:"480"`
+	// JitAccessApproverRoleGroups are the names of the role groups whose members can review access requests, super
+	// admins can always review them
+	JitAccessApproverRoleGroups []string `env:"JIT_ACCESS_APPROVER_ROLE_GROUPS"`
+}
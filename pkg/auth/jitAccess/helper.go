@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jitAccess
+
+import (
+	"errors"
+	"fmt"
+	bean2 "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/repository"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"strings"
+	"time"
+)
+
+// roleKey identifies a single role of a role filter, a role filter holds a role for every combination of its comma
+// separated values
+type roleKey struct {
+	team       string
+	entityName string
+	env        string
+	cluster    string
+	namespace  string
+	group      string
+	kind       string
+	resource   string
+	workflow   string
+}
+
+func validateAccessRequest(request *bean.JitAccessRequestDto, maxDurationMinutes int) error {
+	roleFilter := request.RoleFilter
+	if len(roleFilter.Entity) == 0 && (len(roleFilter.Team) == 0 || len(roleFilter.Action) == 0) {
+		return errors.New(bean.InvalidRoleFilter)
+	}
+	if roleFilter.Action == bean2.ACTION_SUPERADMIN || roleFilter.Action == userBean.SUPER_ADMIN {
+		return errors.New(bean.SuperAdminNotAllowed)
+	}
+	if request.DurationMinutes <= 0 || request.DurationMinutes > maxDurationMinutes {
+		return fmt.Errorf(bean.InvalidDuration, maxDurationMinutes)
+	}
+	return nil
+}
+
+// validateReview checks that a pending request can be approved or rejected by the reviewer
+func validateReview(request *repository.JitAccessRequest, reviewerId int32) error {
+	if request.Status != bean.AccessStatusPending {
+		return fmt.Errorf(bean.RequestNotPending, request.Id, request.Status)
+	}
+	if request.RequestedBy == reviewerId {
+		return errors.New(bean.SelfApprovalNotAllowed)
+	}
+	return nil
+}
+
+func validateRevoke(request *repository.JitAccessRequest) error {
+	if request.Status != bean.AccessStatusPending && request.Status != bean.AccessStatusApproved {
+		return fmt.Errorf(bean.RequestNotRevocable, request.Id, request.Status)
+	}
+	return nil
+}
+
+// getEffectiveStatus reports approved requests past their expiry as expired before the sweeper revokes them
+func getEffectiveStatus(request *repository.JitAccessRequest, now time.Time) bean.AccessStatus {
+	if request.Status == bean.AccessStatusApproved && request.ExpiresOn != nil && !request.ExpiresOn.After(now) {
+		return bean.AccessStatusExpired
+	}
+	return request.Status
+}
+
+// getRoleKeys expands a role filter into its roles the way the user role filters are expanded
+func getRoleKeys(roleFilter bean2.RoleFilter) []roleKey {
+	keys := make([]roleKey, 0)
+	if roleFilter.Entity == userBean.CLUSTER_ENTITIY {
+		for _, namespace := range strings.Split(roleFilter.Namespace, ",") {
+			for _, group := range strings.Split(roleFilter.Group, ",") {
+				for _, kind := range strings.Split(roleFilter.Kind, ",") {
+					for _, resource := range strings.Split(roleFilter.Resource, ",") {
+						keys = append(keys, roleKey{cluster: roleFilter.Cluster, namespace: namespace, group: group, kind: kind, resource: resource})
+					}
+				}
+			}
+		}
+		return keys
+	}
+	workflows := []string{""}
+	if roleFilter.Entity == userBean.EntityJobs {
+		workflows = strings.Split(roleFilter.Workflow, ",")
+	}
+	for _, env := range strings.Split(roleFilter.Environment, ",") {
+		for _, entityName := range strings.Split(roleFilter.EntityName, ",") {
+			for _, workflow := range workflows {
+				keys = append(keys, roleKey{team: roleFilter.Team, entityName: entityName, env: env, workflow: workflow})
+			}
+		}
+	}
+	return keys
+}
+
+// getRolesToRevoke returns the granted roles which are not retained, roles the user holds permanently or through
+// another active grant are retained
+func getRolesToRevoke(grantedRoles []string, retainedRoles map[string]bool) []string {
+	roles := make([]string, 0, len(grantedRoles))
+	for _, role := range grantedRoles {
+		if !retainedRoles[role] {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
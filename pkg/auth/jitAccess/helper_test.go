@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jitAccess
+
+import (
+	bean2 "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestValidateAccessRequest(t *testing.T) {
+	request := &bean.JitAccessRequestDto{RoleFilter: bean2.RoleFilter{Entity: "apps", Team: "devtron-demo", Action: "trigger"}, DurationMinutes: 60}
+	assert.Nil(t, validateAccessRequest(request, 480))
+
+	noScope := &bean.JitAccessRequestDto{RoleFilter: bean2.RoleFilter{Team: "devtron-demo"}, DurationMinutes: 60}
+	assert.EqualError(t, validateAccessRequest(noScope, 480), bean.InvalidRoleFilter)
+
+	superAdmin := &bean.JitAccessRequestDto{RoleFilter: bean2.RoleFilter{Entity: "apps", Action: "super-admin"}, DurationMinutes: 60}
+	assert.EqualError(t, validateAccessRequest(superAdmin, 480), bean.SuperAdminNotAllowed)
+
+	tooLong := &bean.JitAccessRequestDto{RoleFilter: bean2.RoleFilter{Entity: "apps", Team: "devtron-demo", Action: "trigger"}, DurationMinutes: 481}
+	assert.NotNil(t, validateAccessRequest(tooLong, 480))
+}
+
+func TestValidateReviewAndRevoke(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	request := &repository.JitAccessRequest{Id: 1, RequestedBy: 5, Status: bean.AccessStatusPending}
+	assert.Nil(t, validateReview(request, 6))
+	assert.EqualError(t, validateReview(request, 5), bean.SelfApprovalNotAllowed)
+	assert.Nil(t, validateRevoke(request))
+
+	expiresOn := now.Add(time.Hour)
+	request.Status = bean.AccessStatusApproved
+	request.ExpiresOn = &expiresOn
+	assert.NotNil(t, validateReview(request, 6))
+	assert.Nil(t, validateRevoke(request))
+	assert.Equal(t, bean.AccessStatusApproved, getEffectiveStatus(request, now))
+	assert.Equal(t, bean.AccessStatusExpired, getEffectiveStatus(request, now.Add(time.Hour)))
+
+	request.Status = bean.AccessStatusExpired
+	assert.NotNil(t, validateRevoke(request))
+}
+
+func TestGetRoleKeys(t *testing.T) {
+	apps := getRoleKeys(bean2.RoleFilter{Entity: "apps", Team: "devtron-demo", EntityName: "app-1,app-2", Environment: "prod", Action: "trigger"})
+	assert.Equal(t, []roleKey{
+		{team: "devtron-demo", entityName: "app-1", env: "prod"},
+		{team: "devtron-demo", entityName: "app-2", env: "prod"},
+	}, apps)
+
+	jobs := getRoleKeys(bean2.RoleFilter{Entity: "jobs", Team: "devtron-demo", EntityName: "job-1", Environment: "dev", Workflow: "wf-1,wf-2"})
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, "wf-2", jobs[1].workflow)
+
+	cluster := getRoleKeys(bean2.RoleFilter{Entity: "cluster", Cluster: "default", Namespace: "ns-1,ns-2", Group: "apps", Kind: "Deployment", Resource: ""})
+	assert.Equal(t, []roleKey{
+		{cluster: "default", namespace: "ns-1", group: "apps", kind: "Deployment"},
+		{cluster: "default", namespace: "ns-2", group: "apps", kind: "Deployment"},
+	}, cluster)
+}
+
+func TestGetRolesToRevoke(t *testing.T) {
+	granted := []string{"role:trigger_devtron-demo_prod_app-1", "role:trigger_devtron-demo_prod_app-2"}
+	assert.Equal(t, granted, getRolesToRevoke(granted, map[string]bool{}))
+	// a role the user holds otherwise stays granted
+	assert.Equal(t, []string{"role:trigger_devtron-demo_prod_app-2"}, getRolesToRevoke(granted, map[string]bool{"role:trigger_devtron-demo_prod_app-1": true}))
+}
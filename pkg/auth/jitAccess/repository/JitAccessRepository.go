@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/auth/jitAccess/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type JitAccessRequest struct {
+	tableName       struct{}          `sql:"jit_access_request" pg:",discard_unknown_columns"`
+	Id              int               `sql:"id,pk"`
+	RequestedBy     int32             `sql:"requested_by,notnull"`
+	RoleFilter      string            `sql:"role_filter,notnull"`
+	DurationMinutes int               `sql:"duration_minutes,notnull"`
+	Reason          string            `sql:"reason,notnull"`
+	Status          bean.AccessStatus `sql:"status,notnull"`
+	GrantedRoles    []string          `sql:"granted_roles" pg:",array"`
+	ReviewedBy      int32             `sql:"reviewed_by"`
+	ReviewedOn      *time.Time        `sql:"reviewed_on"`
+	ExpiresOn       *time.Time        `sql:"expires_on"`
+	Active          bool              `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// JitAccessRequestAudit is the trail of the request, review, grant and revocation of an access
+type JitAccessRequestAudit struct {
+	tableName struct{}          `sql:"jit_access_request_audit" pg:",discard_unknown_columns"`
+	Id        int               `sql:"id,pk"`
+	RequestId int               `sql:"request_id,notnull"`
+	Action    bean.AccessAction `sql:"action,notnull"`
+	Comment   string            `sql:"comment"`
+	ActionBy  int32             `sql:"action_by,notnull"`
+	ActionOn  time.Time         `sql:"action_on,notnull"`
+}
+
+type JitAccessRepository interface {
+	//transaction util funcs
+	sql.TransactionWrapper
+	Save(tx *pg.Tx, request *JitAccessRequest) error
+	Update(tx *pg.Tx, request *JitAccessRequest) error
+	FindById(id int) (*JitAccessRequest, error)
+	FindAll(filter *bean.AccessRequestFilter) ([]*JitAccessRequest, error)
+	// FindActiveGrantsByUserId returns the approved requests of the user not expired at the time
+	FindActiveGrantsByUserId(userId int32, at time.Time) ([]*JitAccessRequest, error)
+	// FindExpiredGrants returns the approved requests expired at the time, the earliest first
+	FindExpiredGrants(at time.Time) ([]*JitAccessRequest, error)
+	SaveAudit(tx *pg.Tx, audit *JitAccessRequestAudit) error
+	FindAuditsByRequestId(requestId int) ([]*JitAccessRequestAudit, error)
+}
+
+type JitAccessRepositoryImpl struct {
+	*sql.TransactionUtilImpl
+	dbConnection *pg.DB
+}
+
+func NewJitAccessRepositoryImpl(dbConnection *pg.DB, TransactionUtilImpl *sql.TransactionUtilImpl) *JitAccessRepositoryImpl {
+	return &JitAccessRepositoryImpl{
+		dbConnection:        dbConnection,
+		TransactionUtilImpl: TransactionUtilImpl,
+	}
+}
+
+func (impl JitAccessRepositoryImpl) Save(tx *pg.Tx, request *JitAccessRequest) error {
+	return tx.Insert(request)
+}
+
+func (impl JitAccessRepositoryImpl) Update(tx *pg.Tx, request *JitAccessRequest) error {
+	return tx.Update(request)
+}
+
+func (impl JitAccessRepositoryImpl) FindById(id int) (*JitAccessRequest, error) {
+	request := &JitAccessRequest{}
+	err := impl.dbConnection.Model(request).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return request, err
+}
+
+func (impl JitAccessRepositoryImpl) FindAll(filter *bean.AccessRequestFilter) ([]*JitAccessRequest, error) {
+	requests := make([]*JitAccessRequest, 0)
+	query := impl.dbConnection.Model(&requests).
+		Where("active = ?", true)
+	if filter.RequestedBy > 0 {
+		query = query.Where("requested_by = ?", filter.RequestedBy)
+	}
+	if len(filter.Status) > 0 {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("id DESC").Select()
+	return requests, err
+}
+
+func (impl JitAccessRepositoryImpl) FindActiveGrantsByUserId(userId int32, at time.Time) ([]*JitAccessRequest, error) {
+	requests := make([]*JitAccessRequest, 0)
+	err := impl.dbConnection.Model(&requests).
+		Where("active = ?", true).
+		Where("requested_by = ?", userId).
+		Where("status = ?", bean.AccessStatusApproved).
+		Where("expires_on > ?", at).
+		Select()
+	return requests, err
+}
+
+func (impl JitAccessRepositoryImpl) FindExpiredGrants(at time.Time) ([]*JitAccessRequest, error) {
+	requests := make([]*JitAccessRequest, 0)
+	err := impl.dbConnection.Model(&requests).
+		Where("active = ?", true).
+		Where("status = ?", bean.AccessStatusApproved).
+		Where("expires_on <= ?", at).
+		Order("expires_on ASC").
+		Select()
+	return requests, err
+}
+
+func (impl JitAccessRepositoryImpl) SaveAudit(tx *pg.Tx, audit *JitAccessRequestAudit) error {
+	return tx.Insert(audit)
+}
+
+func (impl JitAccessRepositoryImpl) FindAuditsByRequestId(requestId int) ([]*JitAccessRequestAudit, error) {
+	audits := make([]*JitAccessRequestAudit, 0)
+	err := impl.dbConnection.Model(&audits).
+		Where("request_id = ?", requestId).
+		Order("id ASC").
+		Select()
+	return audits, err
+}
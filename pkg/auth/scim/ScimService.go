@@ -0,0 +1,584 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scim
+
+import (
+	"context"
+	bean2 "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/apiToken"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/scim/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	userHelper "github.com/devtron-labs/devtron/pkg/auth/user/helper"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository/helper"
+	"github.com/devtron-labs/devtron/pkg/clusterTerminalAccess"
+	"github.com/devtron-labs/devtron/pkg/terminal"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+type ScimService interface {
+	ListUsers(request *bean.ListRequest) (*bean.ListResponse, error)
+	GetUser(id string) (*bean.User, error)
+	// CreateUser provisions the user, a deactivated user with the same userName is reactivated
+	CreateUser(user *bean.User, userId int32, token string, managerAuth func(resource, token, object string) bool) (*bean.User, error)
+	ReplaceUser(id string, user *bean.User, userId int32, token string, managerAuth func(resource, token, object string) bool) (*bean.User, error)
+	PatchUser(id string, patch *bean.PatchRequest, userId int32, token string, managerAuth func(resource, token, object string) bool) (*bean.User, error)
+	// DeleteUser deprovisions the user, devtron keeps deactivated users so it is returned with active false afterwards
+	DeleteUser(id string, userId int32) error
+
+	ListGroups(request *bean.ListRequest) (*bean.ListResponse, error)
+	GetGroup(id string) (*bean.Group, error)
+	CreateGroup(group *bean.Group, userId int32) (*bean.Group, error)
+	ReplaceGroup(id string, group *bean.Group, userId int32) (*bean.Group, error)
+	PatchGroup(id string, patch *bean.PatchRequest, userId int32) (*bean.Group, error)
+	DeleteGroup(id string, userId int32) error
+}
+
+type ScimServiceImpl struct {
+	logger                    *zap.SugaredLogger
+	userService               user.UserService
+	userRepository            repository.UserRepository
+	roleGroupService          user.RoleGroupService
+	roleGroupRepository       repository.RoleGroupRepository
+	apiTokenService           apiToken.ApiTokenService
+	apiTokenRepository        apiToken.ApiTokenRepository
+	userTerminalAccessService clusterTerminalAccess.UserTerminalAccessService
+	terminalSessionHandler    terminal.TerminalSessionHandler
+}
+
+func NewScimServiceImpl(logger *zap.SugaredLogger,
+	userService user.UserService,
+	userRepository repository.UserRepository,
+	roleGroupService user.RoleGroupService,
+	roleGroupRepository repository.RoleGroupRepository,
+	apiTokenService apiToken.ApiTokenService,
+	apiTokenRepository apiToken.ApiTokenRepository,
+	userTerminalAccessService clusterTerminalAccess.UserTerminalAccessService,
+	terminalSessionHandler terminal.TerminalSessionHandler) *ScimServiceImpl {
+	return &ScimServiceImpl{
+		logger:                    logger,
+		userService:               userService,
+		userRepository:            userRepository,
+		roleGroupService:          roleGroupService,
+		roleGroupRepository:       roleGroupRepository,
+		apiTokenService:           apiTokenService,
+		apiTokenRepository:        apiTokenRepository,
+		userTerminalAccessService: userTerminalAccessService,
+		terminalSessionHandler:    terminalSessionHandler,
+	}
+}
+
+func (impl *ScimServiceImpl) ListUsers(request *bean.ListRequest) (*bean.ListResponse, error) {
+	filter, err := parseFilter(request.Filter, []string{bean.AttributeId, bean.AttributeUserName, bean.AttributeEmailValue, bean.AttributeExternalId})
+	if err != nil {
+		return nil, err
+	}
+	var models []*repository.UserModel
+	var totalResults int
+	if filter != nil {
+		models, err = impl.findUsersByFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		totalResults = len(models)
+		models = paginate(models, request)
+	} else {
+		query, queryParams := helper.GetQueryForUserListingIncludingInactive(0, 0, true)
+		totalResults, err = impl.userRepository.GetCountExecutingQuery(query, queryParams)
+		if err != nil {
+			impl.logger.Errorw("error in getting users count", "err", err)
+			return nil, err
+		}
+		offset, limit := getPage(request)
+		query, queryParams = helper.GetQueryForUserListingIncludingInactive(limit, offset, false)
+		userModels, err := impl.userRepository.GetAllExecutingQuery(query, queryParams)
+		if err != nil {
+			impl.logger.Errorw("error in getting users", "offset", offset, "limit", limit, "err", err)
+			return nil, err
+		}
+		for i := range userModels {
+			models = append(models, &userModels[i])
+		}
+	}
+	groupRefs, err := impl.getGroupRefsForUsers(models)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		resources = append(resources, toScimUser(model, groupRefs[model.Id]))
+	}
+	return toListResponse(resources, totalResults, request.StartIndex), nil
+}
+
+// findUsersByFilter matches at most one user, externalId is not persisted so it never matches
+func (impl *ScimServiceImpl) findUsersByFilter(filter *bean.Filter) ([]*repository.UserModel, error) {
+	var model *repository.UserModel
+	var err error
+	switch filter.Attribute {
+	case bean.AttributeId:
+		id, ok := parseId(filter.Value)
+		if !ok {
+			return nil, nil
+		}
+		model, err = impl.userRepository.GetByIdIncludeDeleted(id)
+	case bean.AttributeUserName, bean.AttributeEmailValue:
+		model, err = impl.userRepository.FetchActiveOrDeletedUserByEmail(filter.Value)
+	default:
+		return nil, nil
+	}
+	if err == pg.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in finding user by filter", "filter", filter, "err", err)
+		return nil, err
+	}
+	if model.UserType == bean2.USER_TYPE_API_TOKEN {
+		return nil, nil
+	}
+	return []*repository.UserModel{model}, nil
+}
+
+func (impl *ScimServiceImpl) GetUser(id string) (*bean.User, error) {
+	model, err := impl.getUserModel(id)
+	if err != nil {
+		return nil, err
+	}
+	groupRefs, err := impl.getGroupRefsForUsers([]*repository.UserModel{model})
+	if err != nil {
+		return nil, err
+	}
+	return toScimUser(model, groupRefs[model.Id]), nil
+}
+
+func (impl *ScimServiceImpl) CreateUser(user *bean.User, userId int32, token string, managerAuth func(resource, token, object string) bool) (*bean.User, error) {
+	userName := strings.TrimSpace(user.UserName)
+	if len(userName) == 0 || strings.Contains(userName, ",") {
+		return nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.MissingUserNameMsg)
+	}
+	existingUser, err := impl.userRepository.FetchActiveOrDeletedUserByEmail(userName)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching user by email", "emailId", userName, "err", err)
+		return nil, err
+	}
+	if err == nil && (existingUser.Active || existingUser.UserType == bean2.USER_TYPE_API_TOKEN) {
+		return nil, newScimError(http.StatusConflict, bean.ScimTypeUniqueness, bean.UserAlreadyExistsMsg)
+	}
+	_, err = impl.userService.CreateUser(&bean2.UserInfo{EmailId: userName, UserId: userId}, token, managerAuth)
+	if err != nil {
+		impl.logger.Errorw("error in creating user", "emailId", userName, "err", err)
+		return nil, err
+	}
+	model, err := impl.userRepository.FetchActiveOrDeletedUserByEmail(userName)
+	if err != nil {
+		impl.logger.Errorw("error in fetching created user", "emailId", userName, "err", err)
+		return nil, err
+	}
+	if !isActive(user) {
+		err = impl.deprovisionUser(model, userId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return impl.GetUser(toUserRef(model).Value)
+}
+
+func (impl *ScimServiceImpl) ReplaceUser(id string, user *bean.User, userId int32, token string, managerAuth func(resource, token, object string) bool) (*bean.User, error) {
+	model, err := impl.getUserModel(id)
+	if err != nil {
+		return nil, err
+	}
+	err = impl.syncUser(model, user, userId, token, managerAuth)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetUser(id)
+}
+
+func (impl *ScimServiceImpl) PatchUser(id string, patch *bean.PatchRequest, userId int32, token string, managerAuth func(resource, token, object string) bool) (*bean.User, error) {
+	model, err := impl.getUserModel(id)
+	if err != nil {
+		return nil, err
+	}
+	user := toScimUser(model, nil)
+	err = applyUserPatch(user, patch.Operations)
+	if err != nil {
+		return nil, err
+	}
+	err = impl.syncUser(model, user, userId, token, managerAuth)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetUser(id)
+}
+
+func (impl *ScimServiceImpl) DeleteUser(id string, userId int32) error {
+	model, err := impl.getUserModel(id)
+	if err != nil {
+		return err
+	}
+	if !model.Active {
+		return nil
+	}
+	return impl.deprovisionUser(model, userId)
+}
+
+// syncUser activates or deactivates the user, the userName is the email of the user and cannot be changed
+func (impl *ScimServiceImpl) syncUser(model *repository.UserModel, user *bean.User, userId int32, token string, managerAuth func(resource, token, object string) bool) error {
+	if len(user.UserName) > 0 && !strings.EqualFold(strings.TrimSpace(user.UserName), model.EmailId) {
+		return newScimError(http.StatusBadRequest, bean.ScimTypeMutability, bean.UserNameImmutableMsg)
+	}
+	active := isActive(user)
+	if active && !model.Active {
+		_, err := impl.userService.CreateUser(&bean2.UserInfo{EmailId: model.EmailId, UserId: userId}, token, managerAuth)
+		if err != nil {
+			impl.logger.Errorw("error in reactivating user", "id", model.Id, "err", err)
+			return err
+		}
+	} else if !active && model.Active {
+		return impl.deprovisionUser(model, userId)
+	}
+	return nil
+}
+
+// deprovisionUser revokes the api tokens and terminal sessions of the user before deactivating it,
+// so a failure is retried by the identity provider while the user is still active. Only the terminal sessions
+// served by this replica are closed right away, other replicas close theirs on their next check of inactive users
+func (impl *ScimServiceImpl) deprovisionUser(model *repository.UserModel, userId int32) error {
+	err := impl.revokeApiTokens(model.Id, userId)
+	if err != nil {
+		return err
+	}
+	impl.userTerminalAccessService.DisconnectAllSessionsForUser(context.Background(), model.Id)
+	closedSessions := impl.terminalSessionHandler.CloseSessionsForUser(model.Id, bean.DeprovisionedTerminalMsg)
+	_, err = impl.userService.DeleteUser(&bean2.UserInfo{Id: model.Id, UserId: userId})
+	if err != nil {
+		impl.logger.Errorw("error in deactivating user", "id", model.Id, "err", err)
+		return err
+	}
+	impl.logger.Infow("deprovisioned user", "id", model.Id, "closedTerminalSessions", closedSessions)
+	return nil
+}
+
+// revokeApiTokens deletes the api tokens created by the user
+func (impl *ScimServiceImpl) revokeApiTokens(createdBy int32, userId int32) error {
+	apiTokens, err := impl.apiTokenRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in getting active api tokens", "err", err)
+		return err
+	}
+	for _, activeApiToken := range apiTokens {
+		if activeApiToken.CreatedBy != createdBy {
+			continue
+		}
+		_, err = impl.apiTokenService.DeleteApiToken(activeApiToken.Id, userId)
+		if err != nil {
+			impl.logger.Errorw("error in revoking api token of deprovisioned user", "apiTokenId", activeApiToken.Id, "createdBy", createdBy, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *ScimServiceImpl) getUserModel(id string) (*repository.UserModel, error) {
+	userId, ok := parseId(id)
+	if !ok {
+		return nil, newScimError(http.StatusNotFound, "", bean.UserNotFoundMsg)
+	}
+	model, err := impl.userRepository.GetByIdIncludeDeleted(userId)
+	if err == pg.ErrNoRows || (err == nil && model.UserType == bean2.USER_TYPE_API_TOKEN) {
+		return nil, newScimError(http.StatusNotFound, "", bean.UserNotFoundMsg)
+	} else if err != nil {
+		impl.logger.Errorw("error in getting user by id", "id", userId, "err", err)
+		return nil, err
+	}
+	return model, nil
+}
+
+// getGroupRefsForUsers resolves the role groups of the users from their casbin roles
+func (impl *ScimServiceImpl) getGroupRefsForUsers(models []*repository.UserModel) (map[int32][]bean.ResourceRef, error) {
+	casbinNamesByUser := make(map[int32][]string, len(models))
+	var casbinNames []string
+	for _, model := range models {
+		if !model.Active {
+			continue
+		}
+		roles, err := casbin.GetRolesForUser(model.EmailId)
+		if err != nil {
+			impl.logger.Warnw("no roles found for user", "id", model.Id, "err", err)
+			continue
+		}
+		for _, role := range roles {
+			if strings.HasPrefix(role, bean.RoleGroupCasbinPrefix) {
+				casbinNamesByUser[model.Id] = append(casbinNamesByUser[model.Id], role)
+				casbinNames = append(casbinNames, role)
+			}
+		}
+	}
+	groupRefs := make(map[int32][]bean.ResourceRef, len(casbinNamesByUser))
+	if len(casbinNames) == 0 {
+		return groupRefs, nil
+	}
+	roleGroups, err := impl.roleGroupRepository.GetRoleGroupListByCasbinNames(casbinNames)
+	if err != nil {
+		impl.logger.Errorw("error in getting role groups by casbin names", "casbinNames", casbinNames, "err", err)
+		return nil, err
+	}
+	roleGroupByCasbinName := make(map[string]*repository.RoleGroup, len(roleGroups))
+	for _, roleGroup := range roleGroups {
+		roleGroupByCasbinName[roleGroup.CasbinName] = roleGroup
+	}
+	for id, userCasbinNames := range casbinNamesByUser {
+		for _, casbinName := range userCasbinNames {
+			if roleGroup, ok := roleGroupByCasbinName[casbinName]; ok {
+				groupRefs[id] = append(groupRefs[id], toGroupRef(roleGroup))
+			}
+		}
+	}
+	return groupRefs, nil
+}
+
+func (impl *ScimServiceImpl) ListGroups(request *bean.ListRequest) (*bean.ListResponse, error) {
+	filter, err := parseFilter(request.Filter, []string{bean.AttributeId, bean.AttributeDisplayName, bean.AttributeExternalId})
+	if err != nil {
+		return nil, err
+	}
+	roleGroups, err := impl.roleGroupRepository.GetAllRoleGroup()
+	if err != nil {
+		impl.logger.Errorw("error in getting role groups", "err", err)
+		return nil, err
+	}
+	if filter != nil {
+		roleGroups = filterRoleGroups(roleGroups, filter)
+	}
+	totalResults := len(roleGroups)
+	roleGroups = paginate(roleGroups, request)
+	resources := make([]interface{}, 0, len(roleGroups))
+	for _, roleGroup := range roleGroups {
+		var members []repository.UserModel
+		if !request.ExcludeMembers {
+			members, err = impl.getGroupMembers(roleGroup)
+			if err != nil {
+				return nil, err
+			}
+		}
+		resources = append(resources, toScimGroup(roleGroup, members))
+	}
+	return toListResponse(resources, totalResults, request.StartIndex), nil
+}
+
+func filterRoleGroups(roleGroups []*repository.RoleGroup, filter *bean.Filter) []*repository.RoleGroup {
+	filtered := make([]*repository.RoleGroup, 0)
+	for _, roleGroup := range roleGroups {
+		switch filter.Attribute {
+		case bean.AttributeId:
+			if toGroupRef(roleGroup).Value == filter.Value {
+				filtered = append(filtered, roleGroup)
+			}
+		case bean.AttributeDisplayName:
+			if strings.EqualFold(roleGroup.Name, filter.Value) {
+				filtered = append(filtered, roleGroup)
+			}
+		}
+	}
+	return filtered
+}
+
+func (impl *ScimServiceImpl) GetGroup(id string) (*bean.Group, error) {
+	roleGroup, err := impl.getRoleGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := impl.getGroupMembers(roleGroup)
+	if err != nil {
+		return nil, err
+	}
+	return toScimGroup(roleGroup, members), nil
+}
+
+func (impl *ScimServiceImpl) CreateGroup(group *bean.Group, userId int32) (*bean.Group, error) {
+	name := strings.TrimSpace(group.DisplayName)
+	if len(name) == 0 {
+		return nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.MissingDisplayNameMsg)
+	} else if strings.Contains(name, ",") {
+		return nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.InvalidGroupNameMsg)
+	}
+	exists, err := impl.roleGroupRepository.CheckRoleGroupExistByCasbinName(userHelper.GetCasbinNameFromRoleGroupName(name))
+	if err != nil {
+		impl.logger.Errorw("error in checking role group by name", "name", name, "err", err)
+		return nil, err
+	} else if exists {
+		return nil, newScimError(http.StatusConflict, bean.ScimTypeUniqueness, bean.GroupAlreadyExistsMsg)
+	}
+	createdGroup, err := impl.roleGroupService.CreateRoleGroup(&bean2.RoleGroup{Name: name, UserId: userId})
+	if err != nil {
+		impl.logger.Errorw("error in creating role group", "name", name, "err", err)
+		return nil, err
+	}
+	roleGroup, err := impl.roleGroupRepository.GetRoleGroupById(createdGroup.Id)
+	if err != nil {
+		impl.logger.Errorw("error in getting created role group", "id", createdGroup.Id, "err", err)
+		return nil, err
+	}
+	err = impl.syncMembers(roleGroup, nil, group.Members)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetGroup(toGroupRef(roleGroup).Value)
+}
+
+func (impl *ScimServiceImpl) ReplaceGroup(id string, group *bean.Group, userId int32) (*bean.Group, error) {
+	roleGroup, err := impl.getRoleGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := impl.getGroupMembers(roleGroup)
+	if err != nil {
+		return nil, err
+	}
+	err = impl.syncGroup(roleGroup, members, group)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetGroup(id)
+}
+
+func (impl *ScimServiceImpl) PatchGroup(id string, patch *bean.PatchRequest, userId int32) (*bean.Group, error) {
+	roleGroup, err := impl.getRoleGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := impl.getGroupMembers(roleGroup)
+	if err != nil {
+		return nil, err
+	}
+	group := toScimGroup(roleGroup, members)
+	err = applyGroupPatch(group, patch.Operations)
+	if err != nil {
+		return nil, err
+	}
+	err = impl.syncGroup(roleGroup, members, group)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetGroup(id)
+}
+
+func (impl *ScimServiceImpl) DeleteGroup(id string, userId int32) error {
+	roleGroup, err := impl.getRoleGroup(id)
+	if err != nil {
+		return err
+	}
+	_, err = impl.roleGroupService.DeleteRoleGroup(&bean2.RoleGroup{Id: roleGroup.Id, UserId: userId})
+	if err != nil {
+		impl.logger.Errorw("error in deleting role group", "id", roleGroup.Id, "err", err)
+		return err
+	}
+	return nil
+}
+
+// syncGroup syncs the members of the role group, role groups cannot be renamed as the casbin name is derived from the name
+func (impl *ScimServiceImpl) syncGroup(roleGroup *repository.RoleGroup, currentMembers []repository.UserModel, group *bean.Group) error {
+	if len(group.DisplayName) > 0 && strings.TrimSpace(group.DisplayName) != roleGroup.Name {
+		return newScimError(http.StatusBadRequest, bean.ScimTypeMutability, bean.GroupNameImmutableMsg)
+	}
+	return impl.syncMembers(roleGroup, currentMembers, group.Members)
+}
+
+// syncMembers adds and removes the casbin group mappings of the users to match the desired members
+func (impl *ScimServiceImpl) syncMembers(roleGroup *repository.RoleGroup, currentMembers []repository.UserModel, desiredMembers []bean.ResourceRef) error {
+	currentMemberIds := make([]int32, 0, len(currentMembers))
+	emailById := make(map[int32]string, len(currentMembers))
+	for _, member := range currentMembers {
+		currentMemberIds = append(currentMemberIds, member.Id)
+		emailById[member.Id] = member.EmailId
+	}
+	toAdd, toRemove, err := getMemberIdsDiff(currentMemberIds, desiredMembers)
+	if err != nil {
+		return err
+	}
+	if len(toAdd) > 0 {
+		users, err := impl.userRepository.GetByIds(toAdd)
+		if err != nil {
+			impl.logger.Errorw("error in getting users to add to role group", "userIds", toAdd, "err", err)
+			return err
+		}
+		if len(users) != len(toAdd) {
+			return newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.InvalidMemberMsg)
+		}
+		policies := make([]casbin.Policy, 0, len(users))
+		for _, member := range users {
+			policies = append(policies, casbin.Policy{Type: "g", Sub: casbin.Subject(member.EmailId), Obj: casbin.Object(roleGroup.CasbinName)})
+		}
+		if failed := casbin.AddPolicy(policies); len(failed) > 0 {
+			impl.logger.Errorw("error in adding users to role group", "roleGroupId", roleGroup.Id, "failed", failed)
+			return newScimError(http.StatusInternalServerError, "", "failed to add members to the group")
+		}
+	}
+	if len(toRemove) > 0 {
+		policies := make([]casbin.Policy, 0, len(toRemove))
+		for _, memberId := range toRemove {
+			policies = append(policies, casbin.Policy{Type: "g", Sub: casbin.Subject(emailById[memberId]), Obj: casbin.Object(roleGroup.CasbinName)})
+		}
+		if failed := casbin.RemovePolicy(policies); len(failed) > 0 {
+			impl.logger.Errorw("error in removing users from role group", "roleGroupId", roleGroup.Id, "failed", failed)
+			return newScimError(http.StatusInternalServerError, "", "failed to remove members from the group")
+		}
+	}
+	return nil
+}
+
+func (impl *ScimServiceImpl) getRoleGroup(id string) (*repository.RoleGroup, error) {
+	roleGroupId, ok := parseId(id)
+	if !ok {
+		return nil, newScimError(http.StatusNotFound, "", bean.GroupNotFoundMsg)
+	}
+	roleGroup, err := impl.roleGroupRepository.GetRoleGroupById(roleGroupId)
+	if err == pg.ErrNoRows {
+		return nil, newScimError(http.StatusNotFound, "", bean.GroupNotFoundMsg)
+	} else if err != nil {
+		impl.logger.Errorw("error in getting role group by id", "id", roleGroupId, "err", err)
+		return nil, err
+	}
+	return roleGroup, nil
+}
+
+// getGroupMembers resolves the active users mapped to the role group in casbin
+func (impl *ScimServiceImpl) getGroupMembers(roleGroup *repository.RoleGroup) ([]repository.UserModel, error) {
+	emailIds, err := casbin.GetUserByRole(roleGroup.CasbinName)
+	if err != nil {
+		impl.logger.Errorw("error in getting users of role group", "casbinName", roleGroup.CasbinName, "err", err)
+		return nil, err
+	}
+	if len(emailIds) == 0 {
+		return nil, nil
+	}
+	for i, emailId := range emailIds {
+		emailIds[i] = strings.ToLower(emailId)
+	}
+	query, queryParams := helper.GetQueryForActiveUsersByEmailIds(emailIds)
+	members, err := impl.userRepository.GetAllExecutingQuery(query, queryParams)
+	if err != nil {
+		impl.logger.Errorw("error in getting members of role group", "casbinName", roleGroup.CasbinName, "err", err)
+		return nil, err
+	}
+	return members, nil
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scim
+
+import (
+	"github.com/devtron-labs/devtron/pkg/auth/scim/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"strconv"
+	"time"
+)
+
+func toScimUser(model *repository.UserModel, groups []bean.ResourceRef) *bean.User {
+	active := model.Active
+	return &bean.User{
+		Schemas:  []string{bean.UserSchema},
+		Id:       strconv.Itoa(int(model.Id)),
+		UserName: model.EmailId,
+		Emails:   []bean.Email{{Value: model.EmailId, Primary: true}},
+		Active:   &active,
+		Groups:   groups,
+		Meta:     toMeta(bean.ResourceTypeUser, model.CreatedOn, model.UpdatedOn),
+	}
+}
+
+func toScimGroup(model *repository.RoleGroup, members []repository.UserModel) *bean.Group {
+	scimMembers := make([]bean.ResourceRef, 0, len(members))
+	for _, member := range members {
+		scimMembers = append(scimMembers, toUserRef(&member))
+	}
+	return &bean.Group{
+		Schemas:     []string{bean.GroupSchema},
+		Id:          strconv.Itoa(int(model.Id)),
+		DisplayName: model.Name,
+		Members:     scimMembers,
+		Meta:        toMeta(bean.ResourceTypeGroup, model.CreatedOn, model.UpdatedOn),
+	}
+}
+
+func toUserRef(model *repository.UserModel) bean.ResourceRef {
+	return bean.ResourceRef{Value: strconv.Itoa(int(model.Id)), Display: model.EmailId}
+}
+
+func toGroupRef(model *repository.RoleGroup) bean.ResourceRef {
+	return bean.ResourceRef{Value: strconv.Itoa(int(model.Id)), Display: model.Name}
+}
+
+func toMeta(resourceType string, createdOn, updatedOn time.Time) *bean.Meta {
+	return &bean.Meta{ResourceType: resourceType, Created: &createdOn, LastModified: &updatedOn}
+}
+
+func toListResponse(resources []interface{}, totalResults int, startIndex int) *bean.ListResponse {
+	if startIndex < bean.DefaultStartIndex {
+		startIndex = bean.DefaultStartIndex
+	}
+	return &bean.ListResponse{
+		Schemas:      []string{bean.ListResponseSchema},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	UserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	ContentType = "application/scim+json"
+
+	ResourceTypeUser  = "User"
+	ResourceTypeGroup = "Group"
+)
+
+// ScimType is the detail error keyword of a scim error response, see RFC 7644 section 3.12
+type ScimType = string
+
+const (
+	ScimTypeInvalidFilter ScimType = "invalidFilter"
+	ScimTypeInvalidSyntax ScimType = "invalidSyntax"
+	ScimTypeInvalidValue  ScimType = "invalidValue"
+	ScimTypeInvalidPath   ScimType = "invalidPath"
+	ScimTypeNoTarget      ScimType = "noTarget"
+	ScimTypeUniqueness    ScimType = "uniqueness"
+	ScimTypeMutability    ScimType = "mutability"
+)
+
+func IsScimType(code string) bool {
+	switch code {
+	case ScimTypeInvalidFilter, ScimTypeInvalidSyntax, ScimTypeInvalidValue, ScimTypeInvalidPath,
+		ScimTypeNoTarget, ScimTypeUniqueness, ScimTypeMutability:
+		return true
+	}
+	return false
+}
+
+type PatchOp = string
+
+const (
+	PatchOpAdd     PatchOp = "add"
+	PatchOpRemove  PatchOp = "remove"
+	PatchOpReplace PatchOp = "replace"
+)
+
+const (
+	AttributeId          = "id"
+	AttributeUserName    = "userName"
+	AttributeEmailValue  = "emails.value"
+	AttributeActive      = "active"
+	AttributeDisplayName = "displayName"
+	AttributeMembers     = "members"
+	AttributeExternalId  = "externalId"
+
+	FilterOperatorEq = "eq"
+
+	RoleGroupCasbinPrefix = "group:"
+)
+
+const (
+	DefaultStartIndex = 1
+	DefaultPageSize   = 100
+	MaxPageSize       = 500
+)
+
+const (
+	UnauthorizedMsg          = "a valid bearer token is required"
+	ForbiddenMsg             = "only super admins can provision users and groups"
+	UserNotFoundMsg          = "user not found"
+	GroupNotFoundMsg         = "group not found"
+	UserAlreadyExistsMsg     = "user already exists"
+	GroupAlreadyExistsMsg    = "group already exists"
+	UserNameImmutableMsg     = "userName cannot be changed"
+	GroupNameImmutableMsg    = "displayName of a group cannot be changed"
+	InvalidGroupNameMsg      = "displayName of a group cannot contain comma"
+	InvalidMemberMsg         = "member is not an active user"
+	DeprovisionedTerminalMsg = "user has been deprovisioned"
+	UnsupportedFilterMsg     = "only filters of the form 'attribute eq \"value\"' are supported"
+	UnsupportedPatchOpMsg    = "unsupported patch operation"
+	UnsupportedPatchPathMsg  = "unsupported patch path"
+	InvalidPatchValueMsg     = "invalid value for patch operation"
+	MissingUserNameMsg       = "userName is required"
+	MissingDisplayNameMsg    = "displayName is required"
+)
+
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// ResourceRef references a user from a group or a group from a user
+type ResourceRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User is the scim core user resource, devtron only stores the email of a user so
+// name and externalId are accepted but not persisted
+type User struct {
+	Schemas     []string `json:"schemas"`
+	Id          string   `json:"id,omitempty"`
+	ExternalId  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *Name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Emails      []Email  `json:"emails,omitempty"`
+	// Active is nil when the attribute is not sent, which is treated as active
+	Active *bool         `json:"active,omitempty"`
+	Groups []ResourceRef `json:"groups,omitempty"`
+	Meta   *Meta         `json:"meta,omitempty"`
+}
+
+// Group is the scim core group resource mapped to a devtron role group
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	Id          string        `json:"id,omitempty"`
+	ExternalId  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []ResourceRef `json:"members"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+type ListRequest struct {
+	Filter     string
+	StartIndex int
+	Count      int
+	// ExcludeMembers skips resolving group members, identity providers exclude them while looking up groups
+	ExcludeMembers bool
+}
+
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation value is kept raw as its shape depends on the path, e.g. azure sends booleans as strings
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type ErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// Filter is a parsed scim filter of the form 'attribute eq "value"'
+type Filter struct {
+	Attribute string
+	Value     string
+}
@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scim
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/scim/bean"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filterRegex matches 'attribute operator "value"', logical and complex filters are not supported
+var filterRegex = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+([A-Za-z]{2})\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// memberPathRegex matches the value filter path sent by identity providers to remove a single member
+var memberPathRegex = regexp.MustCompile(`^members\[(.+)\]$`)
+
+func newScimError(statusCode int, scimType bean.ScimType, msg string) *util.ApiError {
+	return util.NewApiError().WithHttpStatusCode(statusCode).WithCode(scimType).WithUserMessage(msg).WithInternalMessage(msg)
+}
+
+// parseFilter parses the filter against the supported attributes, the returned attribute is in its canonical case
+func parseFilter(filter string, supportedAttributes []string) (*bean.Filter, error) {
+	if len(strings.TrimSpace(filter)) == 0 {
+		return nil, nil
+	}
+	matches := filterRegex.FindStringSubmatch(filter)
+	if matches == nil || !strings.EqualFold(matches[2], bean.FilterOperatorEq) {
+		return nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidFilter, bean.UnsupportedFilterMsg)
+	}
+	var value string
+	if err := json.Unmarshal([]byte(matches[3]), &value); err != nil {
+		return nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidFilter, bean.UnsupportedFilterMsg)
+	}
+	for _, attribute := range supportedAttributes {
+		if strings.EqualFold(attribute, matches[1]) {
+			return &bean.Filter{Attribute: attribute, Value: value}, nil
+		}
+	}
+	return nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidFilter, bean.UnsupportedFilterMsg)
+}
+
+// getPage returns the offset and limit for the 1-based scim start index and count
+func getPage(request *bean.ListRequest) (offset int, limit int) {
+	offset = request.StartIndex - 1
+	if offset < 0 {
+		offset = 0
+	}
+	limit = request.Count
+	if limit < 0 {
+		limit = 0
+	} else if limit > bean.MaxPageSize {
+		limit = bean.MaxPageSize
+	}
+	return offset, limit
+}
+
+// paginate slices the already filtered resources of a list request
+func paginate[T any](items []T, request *bean.ListRequest) []T {
+	offset, limit := getPage(request)
+	if offset >= len(items) {
+		return []T{}
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+func parseId(id string) (int32, bool) {
+	parsedId, err := strconv.ParseInt(id, 10, 32)
+	if err != nil || parsedId <= 0 {
+		return 0, false
+	}
+	return int32(parsedId), true
+}
+
+// parseBoolValue accepts json booleans as well as the "True"/"False" strings sent by azure ad
+func parseBoolValue(value json.RawMessage) (bool, error) {
+	var boolValue bool
+	if err := json.Unmarshal(value, &boolValue); err == nil {
+		return boolValue, nil
+	}
+	var stringValue string
+	if err := json.Unmarshal(value, &stringValue); err == nil {
+		if parsedValue, err := strconv.ParseBool(strings.ToLower(stringValue)); err == nil {
+			return parsedValue, nil
+		}
+	}
+	return false, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.InvalidPatchValueMsg)
+}
+
+func parseStringValue(value json.RawMessage) (string, error) {
+	var stringValue string
+	if err := json.Unmarshal(value, &stringValue); err != nil {
+		return "", newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.InvalidPatchValueMsg)
+	}
+	return stringValue, nil
+}
+
+func parseMembersValue(value json.RawMessage) ([]bean.ResourceRef, error) {
+	var members []bean.ResourceRef
+	if err := json.Unmarshal(value, &members); err != nil {
+		return nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.InvalidPatchValueMsg)
+	}
+	return members, nil
+}
+
+// parseAttributesValue parses the value of an operation without path, its keys are lower cased
+func parseAttributesValue(value json.RawMessage) (map[string]json.RawMessage, error) {
+	attributes := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(value, &attributes); err != nil {
+		return nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.InvalidPatchValueMsg)
+	}
+	lowerCasedAttributes := make(map[string]json.RawMessage, len(attributes))
+	for attribute, attributeValue := range attributes {
+		lowerCasedAttributes[strings.ToLower(attribute)] = attributeValue
+	}
+	return lowerCasedAttributes, nil
+}
+
+func getPatchOp(operation bean.PatchOperation) (bean.PatchOp, error) {
+	op := strings.ToLower(operation.Op)
+	switch op {
+	case bean.PatchOpAdd, bean.PatchOpReplace, bean.PatchOpRemove:
+		return op, nil
+	}
+	return "", newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.UnsupportedPatchOpMsg)
+}
+
+// applyUserPatch applies the operations on the user, only userName and active are persisted so
+// the remaining attributes are ignored
+func applyUserPatch(user *bean.User, operations []bean.PatchOperation) error {
+	for _, operation := range operations {
+		op, err := getPatchOp(operation)
+		if err != nil {
+			return err
+		}
+		attributes := make(map[string]json.RawMessage)
+		if len(operation.Path) == 0 {
+			if op == bean.PatchOpRemove {
+				return newScimError(http.StatusBadRequest, bean.ScimTypeNoTarget, bean.UnsupportedPatchPathMsg)
+			}
+			attributes, err = parseAttributesValue(operation.Value)
+			if err != nil {
+				return err
+			}
+		} else {
+			attributes[strings.ToLower(operation.Path)] = operation.Value
+		}
+		for attribute, value := range attributes {
+			switch attribute {
+			case strings.ToLower(bean.AttributeActive):
+				if op == bean.PatchOpRemove {
+					return newScimError(http.StatusBadRequest, bean.ScimTypeMutability, bean.InvalidPatchValueMsg)
+				}
+				active, err := parseBoolValue(value)
+				if err != nil {
+					return err
+				}
+				user.Active = &active
+			case strings.ToLower(bean.AttributeUserName):
+				if op == bean.PatchOpRemove {
+					return newScimError(http.StatusBadRequest, bean.ScimTypeMutability, bean.MissingUserNameMsg)
+				}
+				userName, err := parseStringValue(value)
+				if err != nil {
+					return err
+				}
+				user.UserName = userName
+			}
+		}
+	}
+	return nil
+}
+
+// applyGroupPatch applies the operations on the displayName and members of the group
+func applyGroupPatch(group *bean.Group, operations []bean.PatchOperation) error {
+	for _, operation := range operations {
+		op, err := getPatchOp(operation)
+		if err != nil {
+			return err
+		}
+		path := strings.ToLower(operation.Path)
+		switch {
+		case len(path) == 0:
+			if op == bean.PatchOpRemove {
+				return newScimError(http.StatusBadRequest, bean.ScimTypeNoTarget, bean.UnsupportedPatchPathMsg)
+			}
+			attributes, err := parseAttributesValue(operation.Value)
+			if err != nil {
+				return err
+			}
+			for attribute, value := range attributes {
+				switch attribute {
+				case strings.ToLower(bean.AttributeDisplayName):
+					if group.DisplayName, err = parseStringValue(value); err != nil {
+						return err
+					}
+				case strings.ToLower(bean.AttributeMembers):
+					if err = applyMembersOperation(group, op, value); err != nil {
+						return err
+					}
+				}
+			}
+		case path == strings.ToLower(bean.AttributeDisplayName):
+			if op == bean.PatchOpRemove {
+				return newScimError(http.StatusBadRequest, bean.ScimTypeMutability, bean.MissingDisplayNameMsg)
+			}
+			if group.DisplayName, err = parseStringValue(operation.Value); err != nil {
+				return err
+			}
+		case path == strings.ToLower(bean.AttributeMembers):
+			if err = applyMembersOperation(group, op, operation.Value); err != nil {
+				return err
+			}
+		case memberPathRegex.MatchString(path) && op == bean.PatchOpRemove:
+			filter, err := parseFilter(memberPathRegex.FindStringSubmatch(operation.Path)[1], []string{"value"})
+			if err != nil {
+				return newScimError(http.StatusBadRequest, bean.ScimTypeInvalidPath, bean.UnsupportedPatchPathMsg)
+			}
+			group.Members = removeMembers(group.Members, []bean.ResourceRef{{Value: filter.Value}})
+		default:
+			return newScimError(http.StatusBadRequest, bean.ScimTypeInvalidPath, bean.UnsupportedPatchPathMsg)
+		}
+	}
+	return nil
+}
+
+// applyMembersOperation applies an operation on the members attribute, a remove without value removes every member
+func applyMembersOperation(group *bean.Group, op bean.PatchOp, value json.RawMessage) error {
+	var members []bean.ResourceRef
+	if len(value) > 0 && string(value) != "null" {
+		var err error
+		if members, err = parseMembersValue(value); err != nil {
+			return err
+		}
+	}
+	switch op {
+	case bean.PatchOpAdd:
+		group.Members = addMembers(group.Members, members)
+	case bean.PatchOpReplace:
+		group.Members = addMembers(nil, members)
+	case bean.PatchOpRemove:
+		if len(value) == 0 || string(value) == "null" {
+			group.Members = nil
+		} else {
+			group.Members = removeMembers(group.Members, members)
+		}
+	}
+	return nil
+}
+
+func addMembers(members []bean.ResourceRef, membersToAdd []bean.ResourceRef) []bean.ResourceRef {
+	existing := make(map[string]bool, len(members))
+	for _, member := range members {
+		existing[member.Value] = true
+	}
+	for _, member := range membersToAdd {
+		if !existing[member.Value] {
+			existing[member.Value] = true
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func removeMembers(members []bean.ResourceRef, membersToRemove []bean.ResourceRef) []bean.ResourceRef {
+	toRemove := make(map[string]bool, len(membersToRemove))
+	for _, member := range membersToRemove {
+		toRemove[member.Value] = true
+	}
+	remaining := make([]bean.ResourceRef, 0, len(members))
+	for _, member := range members {
+		if !toRemove[member.Value] {
+			remaining = append(remaining, member)
+		}
+	}
+	return remaining
+}
+
+// getMemberIdsDiff returns the user ids to add to and remove from the group for the desired members
+func getMemberIdsDiff(currentMemberIds []int32, desiredMembers []bean.ResourceRef) (toAdd []int32, toRemove []int32, err error) {
+	current := make(map[int32]bool, len(currentMemberIds))
+	for _, memberId := range currentMemberIds {
+		current[memberId] = true
+	}
+	desired := make(map[int32]bool, len(desiredMembers))
+	for _, member := range desiredMembers {
+		memberId, ok := parseId(member.Value)
+		if !ok {
+			return nil, nil, newScimError(http.StatusBadRequest, bean.ScimTypeInvalidValue, bean.InvalidMemberMsg)
+		}
+		if !current[memberId] && !desired[memberId] {
+			toAdd = append(toAdd, memberId)
+		}
+		desired[memberId] = true
+	}
+	for _, memberId := range currentMemberIds {
+		if !desired[memberId] {
+			toRemove = append(toRemove, memberId)
+		}
+	}
+	return toAdd, toRemove, nil
+}
+
+func isActive(user *bean.User) bool {
+	return user.Active == nil || *user.Active
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scim
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/scim/bean"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	supportedAttributes := []string{bean.AttributeUserName, bean.AttributeEmailValue}
+	filter, err := parseFilter(`UserName Eq "john.doe@example.com"`, supportedAttributes)
+	assert.Nil(t, err)
+	assert.Equal(t, &bean.Filter{Attribute: bean.AttributeUserName, Value: "john.doe@example.com"}, filter)
+
+	filter, err = parseFilter(`emails.value eq "a\"b"`, supportedAttributes)
+	assert.Nil(t, err)
+	assert.Equal(t, `a"b`, filter.Value)
+
+	filter, err = parseFilter("  ", supportedAttributes)
+	assert.Nil(t, err)
+	assert.Nil(t, filter)
+
+	for _, invalidFilter := range []string{`userName co "john"`, `displayName eq "john"`, `userName eq "a" and active eq "true"`, `userName eq john`} {
+		_, err = parseFilter(invalidFilter, supportedAttributes)
+		apiErr, ok := err.(*util.ApiError)
+		assert.True(t, ok, invalidFilter)
+		assert.Equal(t, bean.ScimTypeInvalidFilter, apiErr.Code)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, []int{1, 2}, paginate(items, &bean.ListRequest{StartIndex: 1, Count: 2}))
+	assert.Equal(t, []int{4, 5}, paginate(items, &bean.ListRequest{StartIndex: 4, Count: 10}))
+	assert.Equal(t, []int{1}, paginate(items, &bean.ListRequest{StartIndex: 0, Count: 1}))
+	assert.Equal(t, []int{}, paginate(items, &bean.ListRequest{StartIndex: 6, Count: 1}))
+	assert.Equal(t, []int{}, paginate(items, &bean.ListRequest{StartIndex: 1, Count: 0}))
+}
+
+func TestApplyUserPatch(t *testing.T) {
+	active := true
+	user := &bean.User{UserName: "john.doe@example.com", Active: &active}
+	operations := []bean.PatchOperation{
+		// okta
+		{Op: "replace", Value: json.RawMessage(`{"active":false}`)},
+	}
+	assert.Nil(t, applyUserPatch(user, operations))
+	assert.False(t, isActive(user))
+
+	// azure ad sends capitalised ops and booleans as strings
+	operations = []bean.PatchOperation{
+		{Op: "Replace", Path: "active", Value: json.RawMessage(`"True"`)},
+		{Op: "Add", Path: "name.givenName", Value: json.RawMessage(`"John"`)},
+	}
+	assert.Nil(t, applyUserPatch(user, operations))
+	assert.True(t, isActive(user))
+	assert.Equal(t, "john.doe@example.com", user.UserName)
+
+	operations = []bean.PatchOperation{{Op: "replace", Path: "active", Value: json.RawMessage(`"maybe"`)}}
+	assert.NotNil(t, applyUserPatch(user, operations))
+
+	operations = []bean.PatchOperation{{Op: "move", Path: "active", Value: json.RawMessage(`true`)}}
+	assert.NotNil(t, applyUserPatch(user, operations))
+}
+
+func TestApplyGroupPatch(t *testing.T) {
+	group := &bean.Group{DisplayName: "developers", Members: []bean.ResourceRef{{Value: "1"}, {Value: "2"}}}
+	operations := []bean.PatchOperation{
+		{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"2"},{"value":"3"}]`)},
+		{Op: "remove", Path: `members[value eq "1"]`},
+	}
+	assert.Nil(t, applyGroupPatch(group, operations))
+	assert.Equal(t, []bean.ResourceRef{{Value: "2"}, {Value: "3"}}, group.Members)
+
+	operations = []bean.PatchOperation{
+		{Op: "remove", Path: "members", Value: json.RawMessage(`[{"value":"3"}]`)},
+		{Op: "replace", Value: json.RawMessage(`{"id":"7","displayName":"developers"}`)},
+	}
+	assert.Nil(t, applyGroupPatch(group, operations))
+	assert.Equal(t, []bean.ResourceRef{{Value: "2"}}, group.Members)
+	assert.Equal(t, "developers", group.DisplayName)
+
+	operations = []bean.PatchOperation{{Op: "replace", Path: "members", Value: json.RawMessage(`[{"value":"5"}]`)}}
+	assert.Nil(t, applyGroupPatch(group, operations))
+	assert.Equal(t, []bean.ResourceRef{{Value: "5"}}, group.Members)
+
+	operations = []bean.PatchOperation{{Op: "remove", Path: "members"}}
+	assert.Nil(t, applyGroupPatch(group, operations))
+	assert.Empty(t, group.Members)
+
+	operations = []bean.PatchOperation{{Op: "replace", Path: "externalId", Value: json.RawMessage(`"x"`)}}
+	assert.NotNil(t, applyGroupPatch(group, operations))
+}
+
+func TestGetMemberIdsDiff(t *testing.T) {
+	toAdd, toRemove, err := getMemberIdsDiff([]int32{1, 2}, []bean.ResourceRef{{Value: "2"}, {Value: "3"}, {Value: "3"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []int32{3}, toAdd)
+	assert.Equal(t, []int32{1}, toRemove)
+
+	_, _, err = getMemberIdsDiff(nil, []bean.ResourceRef{{Value: "abc"}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,347 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"github.com/devtron-labs/devtron/internal/util"
+	casbin2 "github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user/helper"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+const roleGroupCasbinPrefix = "group:"
+
+type SsoGroupMappingService interface {
+	GetAllRules() ([]*bean2.SsoGroupMappingRuleDto, error)
+	CreateRule(rule *bean2.SsoGroupMappingRuleDto) (*bean2.SsoGroupMappingRuleDto, error)
+	UpdateRule(rule *bean2.SsoGroupMappingRuleDto) (*bean2.SsoGroupMappingRuleDto, error)
+	DeleteRule(id int, userId int32) error
+	// SyncUserRoleGroups records the sso groups of the user and adds or removes the role groups managed by the rules
+	SyncUserRoleGroups(emailId string, ssoGroups []string) error
+	// PreviewRules evaluates the rules against the sso groups of the last login of every active user without applying them
+	PreviewRules(request *bean2.SsoGroupMappingPreviewRequest) (*bean2.SsoGroupMappingPreviewResponse, error)
+}
+
+type SsoGroupMappingServiceImpl struct {
+	logger                    *zap.SugaredLogger
+	ssoGroupMappingRepository repository.SsoGroupMappingRepository
+	userRepository            repository.UserRepository
+	roleGroupRepository       repository.RoleGroupRepository
+}
+
+func NewSsoGroupMappingServiceImpl(logger *zap.SugaredLogger,
+	ssoGroupMappingRepository repository.SsoGroupMappingRepository,
+	userRepository repository.UserRepository,
+	roleGroupRepository repository.RoleGroupRepository) *SsoGroupMappingServiceImpl {
+	return &SsoGroupMappingServiceImpl{
+		logger:                    logger,
+		ssoGroupMappingRepository: ssoGroupMappingRepository,
+		userRepository:            userRepository,
+		roleGroupRepository:       roleGroupRepository,
+	}
+}
+
+func (impl *SsoGroupMappingServiceImpl) GetAllRules() ([]*bean2.SsoGroupMappingRuleDto, error) {
+	roleGroupsById, err := impl.getRoleGroupsById()
+	if err != nil {
+		return nil, err
+	}
+	return impl.getActiveRules(roleGroupsById)
+}
+
+func (impl *SsoGroupMappingServiceImpl) CreateRule(rule *bean2.SsoGroupMappingRuleDto) (*bean2.SsoGroupMappingRuleDto, error) {
+	roleGroup, err := impl.validateRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	model := &repository.SsoGroupMappingRule{
+		SsoGroup:    rule.SsoGroup,
+		MatchType:   string(rule.MatchType),
+		RoleGroupId: rule.RoleGroupId,
+		Active:      true,
+		AuditLog:    sql.NewDefaultAuditLog(rule.UserId),
+	}
+	err = impl.ssoGroupMappingRepository.SaveRule(model)
+	if err != nil {
+		impl.logger.Errorw("error in saving sso group mapping rule", "rule", rule, "err", err)
+		return nil, err
+	}
+	return toSsoGroupMappingRuleDto(model, roleGroup), nil
+}
+
+func (impl *SsoGroupMappingServiceImpl) UpdateRule(rule *bean2.SsoGroupMappingRuleDto) (*bean2.SsoGroupMappingRuleDto, error) {
+	model, err := impl.getActiveRule(rule.Id)
+	if err != nil {
+		return nil, err
+	}
+	roleGroup, err := impl.validateRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	model.SsoGroup = rule.SsoGroup
+	model.MatchType = string(rule.MatchType)
+	model.RoleGroupId = rule.RoleGroupId
+	model.UpdateAuditLog(rule.UserId)
+	err = impl.ssoGroupMappingRepository.UpdateRule(model)
+	if err != nil {
+		impl.logger.Errorw("error in updating sso group mapping rule", "rule", rule, "err", err)
+		return nil, err
+	}
+	return toSsoGroupMappingRuleDto(model, roleGroup), nil
+}
+
+// DeleteRule stops managing the role group through the rule, existing memberships are kept
+func (impl *SsoGroupMappingServiceImpl) DeleteRule(id int, userId int32) error {
+	model, err := impl.getActiveRule(id)
+	if err != nil {
+		return err
+	}
+	model.Active = false
+	model.UpdateAuditLog(userId)
+	err = impl.ssoGroupMappingRepository.UpdateRule(model)
+	if err != nil {
+		impl.logger.Errorw("error in deleting sso group mapping rule", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *SsoGroupMappingServiceImpl) SyncUserRoleGroups(emailId string, ssoGroups []string) error {
+	user, err := impl.userRepository.FetchActiveUserByEmail(emailId)
+	if err == pg.ErrNoRows {
+		return nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching user by email", "emailId", emailId, "err", err)
+		return err
+	} else if user.Id == 0 {
+		return nil
+	}
+	err = impl.ssoGroupMappingRepository.SaveUserSsoGroups(&repository.UserSsoGroups{
+		UserId:    user.Id,
+		SsoGroups: ssoGroups,
+		AuditLog:  sql.NewDefaultAuditLog(user.Id),
+	})
+	if err != nil {
+		impl.logger.Errorw("error in saving sso groups of user", "userId", user.Id, "err", err)
+		return err
+	}
+	roleGroupsById, err := impl.getRoleGroupsById()
+	if err != nil {
+		return err
+	}
+	rules, err := impl.getActiveRules(roleGroupsById)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	desired, managed := helper.EvaluateSsoGroupMappingRules(rules, ssoGroups)
+	toAdd, toRemove := helper.GetRoleGroupChanges(impl.getUserRoleGroupCasbinNames(emailId), desired, managed, roleGroupsById)
+	if len(toAdd) > 0 {
+		policies := make([]casbin2.Policy, 0, len(toAdd))
+		for _, roleGroup := range toAdd {
+			policies = append(policies, casbin2.Policy{Type: "g", Sub: casbin2.Subject(emailId), Obj: casbin2.Object(roleGroup.CasbinName)})
+		}
+		if failed := casbin2.AddPolicy(policies); len(failed) > 0 {
+			impl.logger.Errorw("error in adding sso mapped role groups to user", "emailId", emailId, "failed", failed)
+		}
+	}
+	if len(toRemove) > 0 {
+		policies := make([]casbin2.Policy, 0, len(toRemove))
+		for _, roleGroup := range toRemove {
+			policies = append(policies, casbin2.Policy{Type: "g", Sub: casbin2.Subject(emailId), Obj: casbin2.Object(roleGroup.CasbinName)})
+		}
+		if failed := casbin2.RemovePolicy(policies); len(failed) > 0 {
+			impl.logger.Errorw("error in removing sso mapped role groups from user", "emailId", emailId, "failed", failed)
+		}
+	}
+	impl.logger.Infow("synced sso mapped role groups of user", "emailId", emailId, "added", len(toAdd), "removed", len(toRemove))
+	return nil
+}
+
+func (impl *SsoGroupMappingServiceImpl) PreviewRules(request *bean2.SsoGroupMappingPreviewRequest) (*bean2.SsoGroupMappingPreviewResponse, error) {
+	roleGroupsById, err := impl.getRoleGroupsById()
+	if err != nil {
+		return nil, err
+	}
+	rules := request.Rules
+	if rules == nil {
+		rules, err = impl.getActiveRules(roleGroupsById)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, rule := range rules {
+		if err = helper.ValidateSsoGroupMappingRule(rule); err != nil {
+			return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+		}
+	}
+	userSsoGroups, err := impl.ssoGroupMappingRepository.FindAllUserSsoGroups()
+	if err != nil {
+		impl.logger.Errorw("error in getting sso groups of users", "err", err)
+		return nil, err
+	}
+	response := &bean2.SsoGroupMappingPreviewResponse{Changes: make([]*bean2.SsoGroupMappingUserChange, 0)}
+	if len(userSsoGroups) == 0 {
+		return response, nil
+	}
+	userIds := make([]int32, 0, len(userSsoGroups))
+	for _, userSsoGroup := range userSsoGroups {
+		userIds = append(userIds, userSsoGroup.UserId)
+	}
+	users, err := impl.userRepository.GetByIds(userIds)
+	if err != nil {
+		impl.logger.Errorw("error in getting users", "userIds", userIds, "err", err)
+		return nil, err
+	}
+	emailByUserId := make(map[int32]string, len(users))
+	for _, user := range users {
+		emailByUserId[user.Id] = user.EmailId
+	}
+	rolesByCasbinName := make(map[string][]string)
+	for _, userSsoGroup := range userSsoGroups {
+		emailId, ok := emailByUserId[userSsoGroup.UserId]
+		if !ok {
+			continue
+		}
+		response.UsersEvaluated++
+		currentCasbinNames := impl.getUserRoleGroupCasbinNames(emailId)
+		desired, managed := helper.EvaluateSsoGroupMappingRules(rules, userSsoGroup.SsoGroups)
+		toAdd, toRemove := helper.GetRoleGroupChanges(currentCasbinNames, desired, managed, roleGroupsById)
+		if len(toAdd) == 0 && len(toRemove) == 0 {
+			continue
+		}
+		change := &bean2.SsoGroupMappingUserChange{UserId: userSsoGroup.UserId, EmailId: emailId, SsoGroups: userSsoGroup.SsoGroups}
+		removed := make(map[string]bool, len(toRemove))
+		for _, roleGroup := range toRemove {
+			removed[roleGroup.CasbinName] = true
+			change.RoleGroupsRemoved = append(change.RoleGroupsRemoved, roleGroup.Name)
+		}
+		var rolesBefore, rolesAfter []string
+		for _, casbinName := range currentCasbinNames {
+			roles := impl.getRoleGroupRoles(casbinName, rolesByCasbinName)
+			rolesBefore = append(rolesBefore, roles...)
+			if !removed[casbinName] {
+				rolesAfter = append(rolesAfter, roles...)
+			}
+		}
+		for _, roleGroup := range toAdd {
+			change.RoleGroupsAdded = append(change.RoleGroupsAdded, roleGroup.Name)
+			rolesAfter = append(rolesAfter, impl.getRoleGroupRoles(roleGroup.CasbinName, rolesByCasbinName)...)
+		}
+		change.RolesGained, change.RolesLost = helper.GetRolesDiff(rolesBefore, rolesAfter)
+		response.Changes = append(response.Changes, change)
+	}
+	return response, nil
+}
+
+// validateRule checks the expression of the rule and returns the role group it maps to
+func (impl *SsoGroupMappingServiceImpl) validateRule(rule *bean2.SsoGroupMappingRuleDto) (*repository.RoleGroup, error) {
+	err := helper.ValidateSsoGroupMappingRule(rule)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	roleGroup, err := impl.roleGroupRepository.GetRoleGroupById(rule.RoleGroupId)
+	if err == pg.ErrNoRows {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean2.RoleGroupNotFoundMsg).WithInternalMessage(bean2.RoleGroupNotFoundMsg)
+	} else if err != nil {
+		impl.logger.Errorw("error in getting role group", "roleGroupId", rule.RoleGroupId, "err", err)
+		return nil, err
+	}
+	return roleGroup, nil
+}
+
+func (impl *SsoGroupMappingServiceImpl) getActiveRule(id int) (*repository.SsoGroupMappingRule, error) {
+	model, err := impl.ssoGroupMappingRepository.FindActiveRuleById(id)
+	if err == pg.ErrNoRows {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusNotFound).WithUserMessage(bean2.SsoGroupMappingRuleNotFoundMsg).WithInternalMessage(bean2.SsoGroupMappingRuleNotFoundMsg)
+	} else if err != nil {
+		impl.logger.Errorw("error in getting sso group mapping rule", "id", id, "err", err)
+		return nil, err
+	}
+	return model, nil
+}
+
+// getActiveRules returns the rules of the active role groups
+func (impl *SsoGroupMappingServiceImpl) getActiveRules(roleGroupsById map[int32]*repository.RoleGroup) ([]*bean2.SsoGroupMappingRuleDto, error) {
+	models, err := impl.ssoGroupMappingRepository.FindAllActiveRules()
+	if err != nil {
+		impl.logger.Errorw("error in getting sso group mapping rules", "err", err)
+		return nil, err
+	}
+	rules := make([]*bean2.SsoGroupMappingRuleDto, 0, len(models))
+	for _, model := range models {
+		if roleGroup, ok := roleGroupsById[model.RoleGroupId]; ok {
+			rules = append(rules, toSsoGroupMappingRuleDto(model, roleGroup))
+		}
+	}
+	return rules, nil
+}
+
+func (impl *SsoGroupMappingServiceImpl) getRoleGroupsById() (map[int32]*repository.RoleGroup, error) {
+	roleGroups, err := impl.roleGroupRepository.GetAllRoleGroup()
+	if err != nil {
+		impl.logger.Errorw("error in getting role groups", "err", err)
+		return nil, err
+	}
+	roleGroupsById := make(map[int32]*repository.RoleGroup, len(roleGroups))
+	for _, roleGroup := range roleGroups {
+		roleGroupsById[roleGroup.Id] = roleGroup
+	}
+	return roleGroupsById, nil
+}
+
+func (impl *SsoGroupMappingServiceImpl) getUserRoleGroupCasbinNames(emailId string) []string {
+	roles, err := casbin2.GetRolesForUser(emailId)
+	if err != nil {
+		impl.logger.Warnw("no roles found for user", "emailId", emailId, "err", err)
+		return nil
+	}
+	casbinNames := make([]string, 0)
+	for _, role := range roles {
+		if strings.HasPrefix(role, roleGroupCasbinPrefix) {
+			casbinNames = append(casbinNames, role)
+		}
+	}
+	return casbinNames
+}
+
+func (impl *SsoGroupMappingServiceImpl) getRoleGroupRoles(casbinName string, rolesByCasbinName map[string][]string) []string {
+	if roles, ok := rolesByCasbinName[casbinName]; ok {
+		return roles
+	}
+	roles, err := casbin2.GetRolesForUser(casbinName)
+	if err != nil {
+		impl.logger.Warnw("no roles found for role group", "casbinName", casbinName, "err", err)
+	}
+	rolesByCasbinName[casbinName] = roles
+	return roles
+}
+
+func toSsoGroupMappingRuleDto(model *repository.SsoGroupMappingRule, roleGroup *repository.RoleGroup) *bean2.SsoGroupMappingRuleDto {
+	return &bean2.SsoGroupMappingRuleDto{
+		Id:            model.Id,
+		SsoGroup:      model.SsoGroup,
+		MatchType:     bean2.SsoGroupMatchType(model.MatchType),
+		RoleGroupId:   model.RoleGroupId,
+		RoleGroupName: roleGroup.Name,
+	}
+}
@@ -401,6 +401,8 @@ func WhitelistChecker(url string) bool {
 		"/orchestrator/auth/login",
 		"/dashboard",
 		"/orchestrator/webhook/git",
+		// scim requests are authenticated by the scim handler from the bearer token
+		"/orchestrator/scim/v2",
 	}
 	for _, a := range prefixUrls {
 		if strings.Contains(url, a) {
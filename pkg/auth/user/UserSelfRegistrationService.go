@@ -20,6 +20,8 @@ import (
 	"fmt"
 	jwt2 "github.com/devtron-labs/authenticator/jwt"
 	"github.com/devtron-labs/devtron/api/bean"
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user/helper"
 	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
 	"github.com/golang-jwt/jwt/v4"
 	"go.uber.org/zap"
@@ -35,14 +37,17 @@ type UserSelfRegistrationServiceImpl struct {
 	logger                          *zap.SugaredLogger
 	selfRegistrationRolesRepository repository.SelfRegistrationRolesRepository
 	userService                     UserService
+	ssoGroupMappingService          SsoGroupMappingService
 }
 
 func NewUserSelfRegistrationServiceImpl(logger *zap.SugaredLogger,
-	selfRegistrationRolesRepository repository.SelfRegistrationRolesRepository, userService UserService) *UserSelfRegistrationServiceImpl {
+	selfRegistrationRolesRepository repository.SelfRegistrationRolesRepository, userService UserService,
+	ssoGroupMappingService SsoGroupMappingService) *UserSelfRegistrationServiceImpl {
 	return &UserSelfRegistrationServiceImpl{
 		logger:                          logger,
 		selfRegistrationRolesRepository: selfRegistrationRolesRepository,
 		userService:                     userService,
+		ssoGroupMappingService:          ssoGroupMappingService,
 	}
 }
 
@@ -138,7 +143,23 @@ func (impl *UserSelfRegistrationServiceImpl) CheckAndCreateUserIfConfigured(clai
 	}
 	if exists {
 		impl.userService.SaveLoginAudit(emailId, "localhost", id)
+		impl.syncSsoGroupMappings(emailId, claims)
 	}
 	impl.logger.Infow("user status", "email", emailId, "status", exists)
 	return exists
 }
+
+// syncSsoGroupMappings matches the role groups of the user to the groups claim, a failure does not fail the login
+func (impl *UserSelfRegistrationServiceImpl) syncSsoGroupMappings(emailId string, claims jwt.MapClaims) {
+	if emailId == bean2.AdminUser {
+		return
+	}
+	ssoGroups, ok := helper.GetSsoGroupsFromClaims(claims)
+	if !ok {
+		return
+	}
+	err := impl.ssoGroupMappingService.SyncUserRoleGroups(emailId, ssoGroups)
+	if err != nil {
+		impl.logger.Errorw("error in syncing sso group mappings of user", "email", emailId, "err", err)
+	}
+}
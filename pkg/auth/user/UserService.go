@@ -1274,7 +1274,7 @@ func (impl *UserServiceImpl) GetUserByToken(context context.Context, token strin
 func (impl *UserServiceImpl) CheckIfTokenIsValid(email string, version string) error {
 	tokenName := userHelper.ExtractTokenNameFromEmail(email)
 	embeddedTokenVersion, _ := strconv.Atoi(version)
-	isProvidedTokenValid, err := impl.userRepository.CheckIfTokenExistsByTokenNameAndVersion(tokenName, embeddedTokenVersion)
+	isProvidedTokenValid, err := impl.userRepository.CheckIfUnexpiredTokenExistsByTokenNameAndVersion(tokenName, embeddedTokenVersion)
 	if err != nil || !isProvidedTokenValid {
 		err := &util.ApiError{
 			HttpStatusCode:  http.StatusUnauthorized,
@@ -1684,6 +1684,10 @@ func (impl *UserServiceImpl) saveUserAudit(r *http.Request, userId int32) {
 		UpdatedOn: time.Now(),
 	}
 	impl.userAuditService.Save(userAudit)
+	err := impl.userRepository.UpdateApiTokenUsageByUserId(userId, clientIp, userAudit.CreatedOn)
+	if err != nil {
+		impl.logger.Errorw("error in updating api-token usage", "userId", userId, "err", err)
+	}
 }
 
 func (impl *UserServiceImpl) checkGroupAuth(groupName string, token string, managerAuth func(resource, token string, object string) bool, isActionUserSuperAdmin bool) (bool, bool) {
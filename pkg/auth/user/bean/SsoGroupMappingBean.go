@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+type SsoGroupMatchType string
+
+const (
+	SsoGroupMatchTypeExact SsoGroupMatchType = "EXACT"
+	SsoGroupMatchTypeRegex SsoGroupMatchType = "REGEX"
+)
+
+// SsoGroupsClaim is the claim carrying the groups of the user in the id token issued by dex
+const SsoGroupsClaim = "groups"
+
+const (
+	InvalidSsoGroupRegexMsg        = "ssoGroup is not a valid regular expression"
+	SsoGroupMappingRuleNotFoundMsg = "sso group mapping rule not found"
+	RoleGroupNotFoundMsg           = "role group not found"
+)
+
+// SsoGroupMappingRuleDto maps the sso groups matching SsoGroup to the role group, the rule is a full match of
+// the group name for REGEX and a case-insensitive comparison for EXACT
+type SsoGroupMappingRuleDto struct {
+	Id            int               `json:"id"`
+	SsoGroup      string            `json:"ssoGroup" validate:"required"`
+	MatchType     SsoGroupMatchType `json:"matchType" validate:"oneof=EXACT REGEX"`
+	RoleGroupId   int32             `json:"roleGroupId" validate:"required,min=1"`
+	RoleGroupName string            `json:"roleGroupName,omitempty"`
+	UserId        int32             `json:"-"`
+}
+
+// SsoGroupMappingPreviewRequest previews the given rules, the saved rules are previewed when Rules is nil
+type SsoGroupMappingPreviewRequest struct {
+	Rules []*SsoGroupMappingRuleDto `json:"rules" validate:"dive"`
+}
+
+// SsoGroupMappingUserChange is the change in role groups and the roles granted by them for a user
+type SsoGroupMappingUserChange struct {
+	UserId            int32    `json:"userId"`
+	EmailId           string   `json:"emailId"`
+	SsoGroups         []string `json:"ssoGroups"`
+	RoleGroupsAdded   []string `json:"roleGroupsAdded"`
+	RoleGroupsRemoved []string `json:"roleGroupsRemoved"`
+	RolesGained       []string `json:"rolesGained"`
+	RolesLost         []string `json:"rolesLost"`
+}
+
+type SsoGroupMappingPreviewResponse struct {
+	// UsersEvaluated is the count of users whose groups claim is known from their last login
+	UsersEvaluated int                          `json:"usersEvaluated"`
+	Changes        []*SsoGroupMappingUserChange `json:"changes"`
+}
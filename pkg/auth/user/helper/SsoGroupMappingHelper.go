@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"errors"
+	"github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/golang-jwt/jwt/v4"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func ValidateSsoGroupMappingRule(rule *bean.SsoGroupMappingRuleDto) error {
+	if rule.MatchType == bean.SsoGroupMatchTypeRegex {
+		if _, err := compileSsoGroupRegex(rule.SsoGroup); err != nil {
+			return errors.New(bean.InvalidSsoGroupRegexMsg)
+		}
+	}
+	return nil
+}
+
+// compileSsoGroupRegex anchors the expression so that it matches the complete group name
+func compileSsoGroupRegex(expression string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + expression + ")$")
+}
+
+// GetSsoGroupsFromClaims returns false when the groups claim is not sent, so memberships are left untouched
+// for identity providers not configured to send groups
+func GetSsoGroupsFromClaims(claims jwt.MapClaims) ([]string, bool) {
+	claim, ok := claims[bean.SsoGroupsClaim]
+	if !ok || claim == nil {
+		return nil, false
+	}
+	ssoGroups := make([]string, 0)
+	switch groups := claim.(type) {
+	case []interface{}:
+		for _, group := range groups {
+			if groupName, ok := group.(string); ok && len(groupName) > 0 {
+				ssoGroups = append(ssoGroups, groupName)
+			}
+		}
+	case []string:
+		ssoGroups = append(ssoGroups, groups...)
+	case string:
+		ssoGroups = append(ssoGroups, groups)
+	default:
+		return nil, false
+	}
+	return ssoGroups, true
+}
+
+// EvaluateSsoGroupMappingRules returns the role groups the sso groups map to and all the role groups managed by
+// the rules, membership of role groups not managed by any rule is never changed on login
+func EvaluateSsoGroupMappingRules(rules []*bean.SsoGroupMappingRuleDto, ssoGroups []string) (desired map[int32]bool, managed map[int32]bool) {
+	desired = make(map[int32]bool)
+	managed = make(map[int32]bool)
+	for _, rule := range rules {
+		managed[rule.RoleGroupId] = true
+		if desired[rule.RoleGroupId] {
+			continue
+		}
+		var expression *regexp.Regexp
+		if rule.MatchType == bean.SsoGroupMatchTypeRegex {
+			var err error
+			if expression, err = compileSsoGroupRegex(rule.SsoGroup); err != nil {
+				// invalid expressions are rejected on save
+				continue
+			}
+		}
+		for _, ssoGroup := range ssoGroups {
+			if (expression != nil && expression.MatchString(ssoGroup)) || (expression == nil && strings.EqualFold(rule.SsoGroup, ssoGroup)) {
+				desired[rule.RoleGroupId] = true
+				break
+			}
+		}
+	}
+	return desired, managed
+}
+
+// GetRoleGroupChanges returns the managed role groups to add the user to and remove the user from, given the
+// casbin names of the role groups the user currently belongs to
+func GetRoleGroupChanges(currentCasbinNames []string, desired, managed map[int32]bool,
+	roleGroupsById map[int32]*repository.RoleGroup) (toAdd []*repository.RoleGroup, toRemove []*repository.RoleGroup) {
+	current := make(map[string]bool, len(currentCasbinNames))
+	for _, casbinName := range currentCasbinNames {
+		current[casbinName] = true
+	}
+	for _, roleGroupId := range getSortedIds(managed) {
+		roleGroup, ok := roleGroupsById[roleGroupId]
+		if !ok {
+			continue
+		}
+		if desired[roleGroupId] && !current[roleGroup.CasbinName] {
+			toAdd = append(toAdd, roleGroup)
+		} else if !desired[roleGroupId] && current[roleGroup.CasbinName] {
+			toRemove = append(toRemove, roleGroup)
+		}
+	}
+	return toAdd, toRemove
+}
+
+// GetRolesDiff returns the roles present only after and only before a change, sorted
+func GetRolesDiff(before []string, after []string) (gained []string, lost []string) {
+	beforeSet := make(map[string]bool, len(before))
+	for _, role := range before {
+		beforeSet[role] = true
+	}
+	afterSet := make(map[string]bool, len(after))
+	for _, role := range after {
+		if afterSet[role] {
+			continue
+		}
+		afterSet[role] = true
+		if !beforeSet[role] {
+			gained = append(gained, role)
+		}
+	}
+	for role := range beforeSet {
+		if !afterSet[role] {
+			lost = append(lost, role)
+		}
+	}
+	sort.Strings(gained)
+	sort.Strings(lost)
+	return gained, lost
+}
+
+func getSortedIds(ids map[int32]bool) []int32 {
+	sortedIds := make([]int32, 0, len(ids))
+	for id := range ids {
+		sortedIds = append(sortedIds, id)
+	}
+	sort.Slice(sortedIds, func(i, j int) bool { return sortedIds[i] < sortedIds[j] })
+	return sortedIds
+}
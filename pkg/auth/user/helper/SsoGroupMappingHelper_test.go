@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetSsoGroupsFromClaims(t *testing.T) {
+	ssoGroups, ok := GetSsoGroupsFromClaims(jwt.MapClaims{"groups": []interface{}{"devs", 1, "ops"}})
+	assert.True(t, ok)
+	assert.Equal(t, []string{"devs", "ops"}, ssoGroups)
+
+	ssoGroups, ok = GetSsoGroupsFromClaims(jwt.MapClaims{"groups": []interface{}{}})
+	assert.True(t, ok)
+	assert.Empty(t, ssoGroups)
+
+	_, ok = GetSsoGroupsFromClaims(jwt.MapClaims{"email": "john.doe@example.com"})
+	assert.False(t, ok)
+}
+
+func TestEvaluateSsoGroupMappingRules(t *testing.T) {
+	rules := []*bean.SsoGroupMappingRuleDto{
+		{SsoGroup: "Platform-Admins", MatchType: bean.SsoGroupMatchTypeExact, RoleGroupId: 1},
+		{SsoGroup: "team-.*-dev", MatchType: bean.SsoGroupMatchTypeRegex, RoleGroupId: 2},
+		{SsoGroup: "auditors", MatchType: bean.SsoGroupMatchTypeExact, RoleGroupId: 3},
+	}
+	desired, managed := EvaluateSsoGroupMappingRules(rules, []string{"platform-admins", "team-payments-dev", "my-team-x-dev-old"})
+	assert.Equal(t, map[int32]bool{1: true, 2: true}, desired)
+	assert.Equal(t, map[int32]bool{1: true, 2: true, 3: true}, managed)
+
+	assert.NotNil(t, ValidateSsoGroupMappingRule(&bean.SsoGroupMappingRuleDto{SsoGroup: "team-(", MatchType: bean.SsoGroupMatchTypeRegex}))
+	assert.Nil(t, ValidateSsoGroupMappingRule(&bean.SsoGroupMappingRuleDto{SsoGroup: "team-(", MatchType: bean.SsoGroupMatchTypeExact}))
+}
+
+func TestGetRoleGroupChanges(t *testing.T) {
+	roleGroupsById := map[int32]*repository.RoleGroup{
+		1: {Id: 1, Name: "admins", CasbinName: "group:admins"},
+		2: {Id: 2, Name: "developers", CasbinName: "group:developers"},
+		3: {Id: 3, Name: "auditors", CasbinName: "group:auditors"},
+	}
+	desired := map[int32]bool{1: true, 2: true}
+	managed := map[int32]bool{1: true, 2: true, 3: true}
+	// group:manual is not managed by any rule and is kept
+	toAdd, toRemove := GetRoleGroupChanges([]string{"group:developers", "group:auditors", "group:manual"}, desired, managed, roleGroupsById)
+	assert.Equal(t, []*repository.RoleGroup{roleGroupsById[1]}, toAdd)
+	assert.Equal(t, []*repository.RoleGroup{roleGroupsById[3]}, toRemove)
+}
+
+func TestGetRolesDiff(t *testing.T) {
+	gained, lost := GetRolesDiff([]string{"role:a", "role:b"}, []string{"role:b", "role:c", "role:c"})
+	assert.Equal(t, []string{"role:c"}, gained)
+	assert.Equal(t, []string{"role:a"}, lost)
+}
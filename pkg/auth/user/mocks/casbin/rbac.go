@@ -7,6 +7,7 @@ package mock_casbin
 import (
 	reflect "reflect"
 
+	casbin "github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -129,6 +130,18 @@ func (mr *MockEnforcerMockRecorder) InvalidateCompleteCache() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateCompleteCache", reflect.TypeOf((*MockEnforcer)(nil).InvalidateCompleteCache))
 }
 
+// SetApiTokenScopeChecker mocks base method.
+func (m *MockEnforcer) SetApiTokenScopeChecker(checker casbin.ApiTokenScopeChecker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetApiTokenScopeChecker", checker)
+}
+
+// SetApiTokenScopeChecker indicates an expected call of SetApiTokenScopeChecker.
+func (mr *MockEnforcerMockRecorder) SetApiTokenScopeChecker(checker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetApiTokenScopeChecker", reflect.TypeOf((*MockEnforcer)(nil).SetApiTokenScopeChecker), checker)
+}
+
 // ReloadPolicy mocks base method.
 func (m *MockEnforcer) ReloadPolicy() error {
 	m.ctrl.T.Helper()
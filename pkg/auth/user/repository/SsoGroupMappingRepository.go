@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type SsoGroupMappingRepository interface {
+	SaveRule(model *SsoGroupMappingRule) error
+	UpdateRule(model *SsoGroupMappingRule) error
+	FindActiveRuleById(id int) (*SsoGroupMappingRule, error)
+	FindAllActiveRules() ([]*SsoGroupMappingRule, error)
+	SaveUserSsoGroups(model *UserSsoGroups) error
+	FindAllUserSsoGroups() ([]*UserSsoGroups, error)
+}
+
+type SsoGroupMappingRule struct {
+	TableName   struct{} `sql:"sso_group_mapping_rule" pg:",discard_unknown_columns"`
+	Id          int      `sql:"id,pk"`
+	SsoGroup    string   `sql:"sso_group,notnull"`
+	MatchType   string   `sql:"match_type,notnull"`
+	RoleGroupId int32    `sql:"role_group_id,notnull"`
+	Active      bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// UserSsoGroups is the groups claim received on the last login of the user
+type UserSsoGroups struct {
+	TableName struct{} `sql:"user_sso_groups" pg:",discard_unknown_columns"`
+	UserId    int32    `sql:"user_id,pk"`
+	SsoGroups []string `sql:"sso_groups" pg:",array"`
+	sql.AuditLog
+}
+
+type SsoGroupMappingRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewSsoGroupMappingRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *SsoGroupMappingRepositoryImpl {
+	return &SsoGroupMappingRepositoryImpl{dbConnection: dbConnection, logger: logger}
+}
+
+func (impl *SsoGroupMappingRepositoryImpl) SaveRule(model *SsoGroupMappingRule) error {
+	return impl.dbConnection.Insert(model)
+}
+
+func (impl *SsoGroupMappingRepositoryImpl) UpdateRule(model *SsoGroupMappingRule) error {
+	return impl.dbConnection.Update(model)
+}
+
+func (impl *SsoGroupMappingRepositoryImpl) FindActiveRuleById(id int) (*SsoGroupMappingRule, error) {
+	model := &SsoGroupMappingRule{}
+	err := impl.dbConnection.Model(model).Where("id = ?", id).Where("active = ?", true).Select()
+	return model, err
+}
+
+func (impl *SsoGroupMappingRepositoryImpl) FindAllActiveRules() ([]*SsoGroupMappingRule, error) {
+	var models []*SsoGroupMappingRule
+	err := impl.dbConnection.Model(&models).Where("active = ?", true).Order("id").Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return models, nil
+}
+
+// SaveUserSsoGroups inserts or replaces the groups of the user
+func (impl *SsoGroupMappingRepositoryImpl) SaveUserSsoGroups(model *UserSsoGroups) error {
+	_, err := impl.dbConnection.Model(model).
+		OnConflict("(user_id) DO UPDATE").
+		Set("sso_groups = EXCLUDED.sso_groups").
+		Set("updated_on = EXCLUDED.updated_on").
+		Set("updated_by = EXCLUDED.updated_by").
+		Insert()
+	return err
+}
+
+func (impl *SsoGroupMappingRepositoryImpl) FindAllUserSsoGroups() ([]*UserSsoGroups, error) {
+	var models []*UserSsoGroups
+	err := impl.dbConnection.Model(&models).Order("user_id").Select()
+	if err != nil && err != pg.ErrNoRows {
+		return nil, err
+	}
+	return models, nil
+}
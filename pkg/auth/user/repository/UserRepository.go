@@ -49,7 +49,8 @@ type UserRepository interface {
 	FetchActiveOrDeletedUserByEmail(email string) (*UserModel, error)
 	UpdateRoleIdForUserRolesMappings(roleId int, newRoleId int) (*UserRoleModel, error)
 	GetCountExecutingQuery(query string, queryParams []interface{}) (int, error)
-	CheckIfTokenExistsByTokenNameAndVersion(tokenName string, tokenVersion int) (bool, error)
+	CheckIfUnexpiredTokenExistsByTokenNameAndVersion(tokenName string, tokenVersion int) (bool, error)
+	UpdateApiTokenUsageByUserId(userId int32, clientIp string, usedAt time.Time) error
 }
 
 type UserRepositoryImpl struct {
@@ -268,12 +269,25 @@ func (impl UserRepositoryImpl) GetCountExecutingQuery(query string, queryParams
 
 // below method does operation on api_token table,
 // we are writing this method here instead of ApiTokenRepository to avoid cyclic import
-func (impl UserRepositoryImpl) CheckIfTokenExistsByTokenNameAndVersion(tokenName string, tokenVersion int) (bool, error) {
+func (impl UserRepositoryImpl) CheckIfUnexpiredTokenExistsByTokenNameAndVersion(tokenName string, tokenVersion int) (bool, error) {
+	// expire_at_in_ms is 0 for tokens which never expire
 	query := impl.dbConnection.Model().
 		Table(userBean.ApiTokenTableName).
 		Where("name = ?", tokenName).
-		Where("version = ?", tokenVersion)
+		Where("version = ?", tokenVersion).
+		Where("(expire_at_in_ms IS NULL OR expire_at_in_ms <= 0 OR expire_at_in_ms > ?)", time.Now().UnixMilli())
 
 	exists, err := query.Exists()
 	return exists, err
 }
+
+func (impl UserRepositoryImpl) UpdateApiTokenUsageByUserId(userId int32, clientIp string, usedAt time.Time) error {
+	_, err := impl.dbConnection.Model().
+		Table(userBean.ApiTokenTableName).
+		Set("last_used_at = ?", usedAt).
+		Set("last_used_by_ip = ?", clientIp).
+		Set("request_count = request_count + 1").
+		Where("user_id = ?", userId).
+		Update()
+	return err
+}
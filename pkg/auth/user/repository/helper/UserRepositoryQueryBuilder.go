@@ -21,6 +21,7 @@ import (
 	"github.com/devtron-labs/devtron/api/bean"
 	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
 	"github.com/devtron-labs/devtron/util"
+	"github.com/go-pg/pg"
 )
 
 func GetQueryForUserListingWithFilters(req *bean.ListingRequest) (string, []interface{}) {
@@ -73,6 +74,24 @@ func GetQueryForAllUserWithAudit() string {
 	return query
 }
 
+// GetQueryForUserListingIncludingInactive lists active as well as deactivated users, excluding api token users
+func GetQueryForUserListingIncludingInactive(limit, offset int, countCheck bool) (string, []interface{}) {
+	whereCondition := fmt.Sprintf("where (user_type is NULL or user_type != '%s') ", bean.USER_TYPE_API_TOKEN)
+	if countCheck {
+		return fmt.Sprintf(`select count(*) from users AS user_model %s;`, whereCondition), nil
+	}
+	queryParams := []interface{}{limit, offset}
+	query := fmt.Sprintf(`SELECT user_model.* FROM users AS user_model %s order by user_model.id limit ? offset ?;`, whereCondition)
+	return query, queryParams
+}
+
+// GetQueryForActiveUsersByEmailIds matches the email ids case-insensitively, they are expected in lower case
+func GetQueryForActiveUsersByEmailIds(emailIds []string) (string, []interface{}) {
+	whereCondition := fmt.Sprintf("where active = %t AND (user_type is NULL or user_type != '%s') AND LOWER(email_id) in (?) ", true, bean.USER_TYPE_API_TOKEN)
+	query := fmt.Sprintf(`SELECT user_model.* FROM users AS user_model %s order by user_model.id;`, whereCondition)
+	return query, []interface{}{pg.In(emailIds)}
+}
+
 func GetQueryForGroupListingWithFilters(req *bean.ListingRequest) (string, []interface{}) {
 	var queryParams []interface{}
 	whereCondition := " where active = ? "
@@ -26,6 +26,8 @@ import (
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	"github.com/devtron-labs/devtron/pkg/chartRepo/repository"
 	bean3 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	approvalBean "github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	sigBean "github.com/devtron-labs/devtron/pkg/imageSignature/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
 	CiPipeline2 "github.com/devtron-labs/devtron/pkg/pipeline/bean/CiPipeline"
 	"github.com/devtron-labs/devtron/pkg/pipeline/repository"
@@ -794,34 +796,36 @@ type Rollback struct {
 }
 
 type CiArtifactBean struct {
-	Id                            int                       `json:"id"`
-	Image                         string                    `json:"image,notnull"`
-	ImageDigest                   string                    `json:"image_digest,notnull"`
-	MaterialInfo                  json.RawMessage           `json:"material_info"` //git material metadata json array string
-	DataSource                    string                    `json:"data_source,notnull"`
-	DeployedTime                  string                    `json:"deployed_time"`
-	Deployed                      bool                      `json:"deployed,notnull"`
-	Latest                        bool                      `json:"latest,notnull"`
-	LastSuccessfulTriggerOnParent bool                      `json:"lastSuccessfulTriggerOnParent,notnull"`
-	RunningOnParentCd             bool                      `json:"runningOnParentCd,omitempty"`
-	IsVulnerable                  bool                      `json:"vulnerable,notnull"`
-	ScanEnabled                   bool                      `json:"scanEnabled,notnull"`
-	Scanned                       bool                      `json:"scanned,notnull"`
-	WfrId                         int                       `json:"wfrId"`
-	DeployedBy                    string                    `json:"deployedBy"`
-	CiConfigureSourceType         pipelineConfig.SourceType `json:"ciConfigureSourceType"`
-	CiConfigureSourceValue        string                    `json:"ciConfigureSourceValue"`
-	ImageReleaseTags              []*repository2.ImageTag   `json:"imageReleaseTags"`
-	ImageComment                  *repository2.ImageComment `json:"imageComment"`
-	CreatedTime                   string                    `json:"createdTime"`
-	ExternalCiPipelineId          int                       `json:"-"`
-	ParentCiArtifact              int                       `json:"-"`
-	CiWorkflowId                  int                       `json:"-"`
-	RegistryType                  string                    `json:"registryType"`
-	RegistryName                  string                    `json:"registryName"`
-	CiPipelineId                  int                       `json:"-"`
-	CredentialsSourceType         string                    `json:"-"`
-	CredentialsSourceValue        string                    `json:"-"`
+	Id                            int                                `json:"id"`
+	Image                         string                             `json:"image,notnull"`
+	ImageDigest                   string                             `json:"image_digest,notnull"`
+	MaterialInfo                  json.RawMessage                    `json:"material_info"` //git material metadata json array string
+	DataSource                    string                             `json:"data_source,notnull"`
+	DeployedTime                  string                             `json:"deployed_time"`
+	Deployed                      bool                               `json:"deployed,notnull"`
+	Latest                        bool                               `json:"latest,notnull"`
+	LastSuccessfulTriggerOnParent bool                               `json:"lastSuccessfulTriggerOnParent,notnull"`
+	RunningOnParentCd             bool                               `json:"runningOnParentCd,omitempty"`
+	IsVulnerable                  bool                               `json:"vulnerable,notnull"`
+	ScanEnabled                   bool                               `json:"scanEnabled,notnull"`
+	Scanned                       bool                               `json:"scanned,notnull"`
+	WfrId                         int                                `json:"wfrId"`
+	DeployedBy                    string                             `json:"deployedBy"`
+	CiConfigureSourceType         pipelineConfig.SourceType          `json:"ciConfigureSourceType"`
+	CiConfigureSourceValue        string                             `json:"ciConfigureSourceValue"`
+	ImageReleaseTags              []*repository2.ImageTag            `json:"imageReleaseTags"`
+	ImageComment                  *repository2.ImageComment          `json:"imageComment"`
+	CreatedTime                   string                             `json:"createdTime"`
+	ExternalCiPipelineId          int                                `json:"-"`
+	ParentCiArtifact              int                                `json:"-"`
+	CiWorkflowId                  int                                `json:"-"`
+	RegistryType                  string                             `json:"registryType"`
+	RegistryName                  string                             `json:"registryName"`
+	CiPipelineId                  int                                `json:"-"`
+	CredentialsSourceType         string                             `json:"-"`
+	CredentialsSourceValue        string                             `json:"-"`
+	ApprovalInfo                  *approvalBean.ArtifactApprovalInfo `json:"approvalInfo,omitempty"`
+	SignatureInfo                 *sigBean.ArtifactSignatureInfo     `json:"signatureInfo,omitempty"`
 }
 
 type CiArtifactResponse struct {
@@ -834,6 +838,8 @@ type CiArtifactResponse struct {
 	AppReleaseTagNames         []string         `json:"appReleaseTagNames"` //unique list of tags exists in the app
 	HideImageTaggingHardDelete bool             `json:"hideImageTaggingHardDelete"`
 	TotalCount                 int              `json:"totalCount"`
+	// ApprovalPolicy is set when deploying on the pipeline needs approvals
+	ApprovalPolicy *approvalBean.ApprovalPolicyDto `json:"approvalPolicy,omitempty"`
 }
 
 type AppLabelsDto struct {
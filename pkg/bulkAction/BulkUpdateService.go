@@ -37,6 +37,8 @@ import (
 	bean2 "github.com/devtron-labs/devtron/pkg/bean"
 	chartRepoRepository "github.com/devtron-labs/devtron/pkg/chartRepo/repository"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	draftBean "github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/pkg/configDraft/protection"
 	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
 	bean5 "github.com/devtron-labs/devtron/pkg/deployment/deployedApp/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deployedAppMetrics"
@@ -44,6 +46,7 @@ import (
 	bean3 "github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/chartRef/bean"
 	"github.com/devtron-labs/devtron/pkg/eventProcessor/out"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/history"
 	repository4 "github.com/devtron-labs/devtron/pkg/pipeline/history/repository"
 	"github.com/devtron-labs/devtron/pkg/variables"
@@ -97,6 +100,7 @@ type BulkUpdateServiceImpl struct {
 	chartRefService                  chartRef.ChartRefService
 	deployedAppService               deployedApp.DeployedAppService
 	cdPipelineEventPublishService    out.CDPipelineEventPublishService
+	configProtectionService          protection.ConfigProtectionService
 }
 
 func NewBulkUpdateServiceImpl(bulkUpdateRepository bulkUpdate.BulkUpdateRepository,
@@ -116,7 +120,8 @@ func NewBulkUpdateServiceImpl(bulkUpdateRepository bulkUpdate.BulkUpdateReposito
 	deployedAppMetricsService deployedAppMetrics.DeployedAppMetricsService,
 	chartRefService chartRef.ChartRefService,
 	deployedAppService deployedApp.DeployedAppService,
-	cdPipelineEventPublishService out.CDPipelineEventPublishService) *BulkUpdateServiceImpl {
+	cdPipelineEventPublishService out.CDPipelineEventPublishService,
+	configProtectionService protection.ConfigProtectionService) *BulkUpdateServiceImpl {
 	return &BulkUpdateServiceImpl{
 		bulkUpdateRepository:             bulkUpdateRepository,
 		logger:                           logger,
@@ -136,6 +141,7 @@ func NewBulkUpdateServiceImpl(bulkUpdateRepository bulkUpdate.BulkUpdateReposito
 		chartRefService:                  chartRefService,
 		deployedAppService:               deployedAppService,
 		cdPipelineEventPublishService:    cdPipelineEventPublishService,
+		configProtectionService:          configProtectionService,
 	}
 
 }
@@ -478,6 +484,19 @@ func (impl BulkUpdateServiceImpl) BulkUpdateDeploymentTemplate(bulkUpdatePayload
 							Message: fmt.Sprintf("Error in applying JSON patch : %s", err.Error()),
 						}
 						deploymentTemplateBulkUpdateResponse.Failure = append(deploymentTemplateBulkUpdateResponse.Failure, bulkUpdateFailedResponse)
+					} else if isDraft, err := impl.saveDeploymentTemplateDraftIfProtected(chartEnv, modified, bulkUpdatePayload.UserId); err != nil || isDraft {
+						bulkUpdateDraftResponse := &DeploymentTemplateBulkUpdateResponseForOneApp{
+							AppId:   appDetailsByChart.Id,
+							AppName: appDetailsByChart.AppName,
+							EnvId:   envId,
+							Message: draftBean.ChangeSavedAsDraft,
+						}
+						if err != nil {
+							bulkUpdateDraftResponse.Message = fmt.Sprintf("Error in saving draft : %s", err.Error())
+							deploymentTemplateBulkUpdateResponse.Failure = append(deploymentTemplateBulkUpdateResponse.Failure, bulkUpdateDraftResponse)
+						} else {
+							deploymentTemplateBulkUpdateResponse.Successful = append(deploymentTemplateBulkUpdateResponse.Successful, bulkUpdateDraftResponse)
+						}
 					} else {
 						err = impl.bulkUpdateRepository.BulkUpdateChartsEnvYamlOverrideById(chartEnv.Id, modified)
 						if err != nil {
@@ -685,6 +704,17 @@ func (impl BulkUpdateServiceImpl) BulkUpdateConfigMap(bulkUpdatePayload *BulkUpd
 							}
 						}
 					}
+					if updatedNames, ok := messageCmNamesMap["Updated Successfully"]; ok {
+						isDraft, err := impl.saveCmCsDraftsIfProtected(configMapEnvModel, updatedNames, pipelineBean.CM, bulkUpdatePayload.UserId)
+						if err != nil {
+							impl.logger.Errorw("error in saving drafts of protected configs", "err", err)
+							messageCmNamesMap[fmt.Sprintf("Error in saving draft : %s", err.Error())] = updatedNames
+							delete(messageCmNamesMap, "Updated Successfully")
+						} else if isDraft {
+							messageCmNamesMap[draftBean.ChangeSavedAsDraft] = updatedNames
+							delete(messageCmNamesMap, "Updated Successfully")
+						}
+					}
 					if _, ok := messageCmNamesMap["Updated Successfully"]; ok {
 						err := impl.bulkUpdateRepository.BulkUpdateConfigMapDataForEnvById(configMapEnvModel.Id, configMapEnvModel.ConfigMapData)
 						if err != nil {
@@ -701,7 +731,7 @@ func (impl BulkUpdateServiceImpl) BulkUpdateConfigMap(bulkUpdatePayload *BulkUpd
 					if len(messageCmNamesMap) != 0 {
 						appDetailsById, _ := impl.appRepository.FindById(configMapEnvModel.AppId)
 						for key, value := range messageCmNamesMap {
-							if key == "Updated Successfully" {
+							if key == "Updated Successfully" || key == draftBean.ChangeSavedAsDraft {
 								bulkUpdateSuccessResponse := &CmAndSecretBulkUpdateResponseForOneApp{
 									AppId:   appDetailsById.Id,
 									AppName: appDetailsById.AppName,
@@ -902,6 +932,17 @@ func (impl BulkUpdateServiceImpl) BulkUpdateSecret(bulkUpdatePayload *BulkUpdate
 							}
 						}
 					}
+					if updatedNames, ok := messageSecretNamesMap["Updated Successfully"]; ok {
+						isDraft, err := impl.saveCmCsDraftsIfProtected(secretEnvModel, updatedNames, pipelineBean.CS, bulkUpdatePayload.UserId)
+						if err != nil {
+							impl.logger.Errorw("error in saving drafts of protected configs", "err", err)
+							messageSecretNamesMap[fmt.Sprintf("Error in saving draft : %s", err.Error())] = updatedNames
+							delete(messageSecretNamesMap, "Updated Successfully")
+						} else if isDraft {
+							messageSecretNamesMap[draftBean.ChangeSavedAsDraft] = updatedNames
+							delete(messageSecretNamesMap, "Updated Successfully")
+						}
+					}
 					if _, ok := messageSecretNamesMap["Updated Successfully"]; ok {
 						err := impl.bulkUpdateRepository.BulkUpdateSecretDataForEnvById(secretEnvModel.Id, secretEnvModel.SecretData)
 						if err != nil {
@@ -918,7 +959,7 @@ func (impl BulkUpdateServiceImpl) BulkUpdateSecret(bulkUpdatePayload *BulkUpdate
 					if len(messageSecretNamesMap) != 0 {
 						appDetailsById, _ := impl.appRepository.FindById(secretEnvModel.AppId)
 						for key, value := range messageSecretNamesMap {
-							if key == "Updated Successfully" {
+							if key == "Updated Successfully" || key == draftBean.ChangeSavedAsDraft {
 								bulkUpdateSuccessResponse := &CmAndSecretBulkUpdateResponseForOneApp{
 									AppId:   appDetailsById.Id,
 									AppName: appDetailsById.AppName,
@@ -1033,6 +1074,7 @@ func (impl BulkUpdateServiceImpl) BulkHibernate(request *BulkApplicationForEnvir
 			EnvironmentId: pipeline.EnvironmentId,
 			UserId:        request.UserId,
 			RequestType:   bean5.STOP,
+			TriggerType:   request.TriggerType,
 		}
 		_, hibernateReqError = impl.deployedAppService.StopStartApp(ctx, stopRequest)
 		if hibernateReqError != nil {
@@ -1189,6 +1231,7 @@ func (impl BulkUpdateServiceImpl) BulkUnHibernate(request *BulkApplicationForEnv
 			EnvironmentId: pipeline.EnvironmentId,
 			UserId:        request.UserId,
 			RequestType:   bean5.START,
+			TriggerType:   request.TriggerType,
 		}
 		_, hibernateReqError = impl.deployedAppService.StopStartApp(ctx, stopRequest)
 		if hibernateReqError != nil {
@@ -1343,6 +1386,14 @@ func (impl BulkUpdateServiceImpl) BulkDeploy(request *BulkApplicationForEnvironm
 			continue
 		}
 		artifact := artifacts[0]
+		if artifactResponse.ApprovalPolicy != nil && (artifact.ApprovalInfo == nil || !artifact.ApprovalInfo.IsApproved) {
+			//latest artifact is not approved for deployment on this pipeline, skip cd trigger
+			impl.logger.Infow("skipping bulk deploy of artifact pending approval", "pipelineId", pipeline.Id, "artifactId", artifact.Id)
+			pipelineResponse := response[appKey]
+			pipelineResponse[pipelineKey] = false
+			response[appKey] = pipelineResponse
+			continue
+		}
 		err = impl.cdPipelineEventPublishService.PublishBulkTriggerTopicEvent(pipeline.Id, pipeline.AppId, artifact.Id, request.UserId)
 		if err != nil {
 			impl.logger.Errorw("error, PublishBulkTriggerTopicEvent", "err", err, "pipeline", pipeline)
@@ -1655,3 +1706,78 @@ func (impl BulkUpdateServiceImpl) PerformBulkDeleteActionOnCdPipelines(impactedP
 	return respDto, nil
 
 }
+
+// saveDeploymentTemplateDraftIfProtected saves the patched override as a draft when the configs of the app on the
+// environment are protected, returns true if it has been saved as a draft instead of being updated
+func (impl BulkUpdateServiceImpl) saveDeploymentTemplateDraftIfProtected(chartEnv *chartConfig.EnvConfigOverride, modified string, userId int32) (bool, error) {
+	isProtected, err := impl.configProtectionService.IsConfigProtected(chartEnv.Chart.AppId, chartEnv.TargetEnvironment)
+	if err != nil || !isProtected {
+		return false, err
+	}
+	isAppMetricsEnabled, err := impl.deployedAppMetricsService.GetMetricsFlagForAPipelineByAppIdAndEnvId(chartEnv.Chart.AppId, chartEnv.TargetEnvironment)
+	if err != nil {
+		impl.logger.Errorw("error, GetMetricsFlagForAPipelineByAppIdAndEnvId", "err", err, "appId", chartEnv.Chart.AppId, "envId", chartEnv.TargetEnvironment)
+		return false, err
+	}
+	draftRequest := &pipelineBean.EnvironmentProperties{
+		Id:                chartEnv.Id,
+		EnvOverrideValues: json.RawMessage(modified),
+		Status:            chartEnv.Status,
+		ManualReviewed:    chartEnv.ManualReviewed,
+		Active:            chartEnv.Active,
+		Namespace:         chartEnv.Namespace,
+		EnvironmentId:     chartEnv.TargetEnvironment,
+		Latest:            chartEnv.Latest,
+		AppMetrics:        &isAppMetricsEnabled,
+		ChartRefId:        chartEnv.Chart.ChartRefId,
+		IsOverride:        chartEnv.IsOverride,
+		IsBasicViewLocked: chartEnv.IsBasicViewLocked,
+		CurrentViewEditor: chartEnv.CurrentViewEditor,
+	}
+	_, isDraft, err := impl.configProtectionService.SaveDraftIfProtected(chartEnv.Chart.AppId, chartEnv.TargetEnvironment, pipelineBean.DeploymentTemplate, draftBean.DraftActionAddUpdate, draftRequest, userId)
+	return isDraft, err
+}
+
+// saveCmCsDraftsIfProtected saves each of the patched config maps or secrets as a draft when the configs of the app on
+// the environment are protected, returns true if they have been saved as drafts instead of being updated
+func (impl BulkUpdateServiceImpl) saveCmCsDraftsIfProtected(model *chartConfig.ConfigMapEnvModel, names []string, resourceType pipelineBean.ResourceType, userId int32) (bool, error) {
+	isProtected, err := impl.configProtectionService.IsConfigProtected(model.AppId, model.EnvironmentId)
+	if err != nil || !isProtected {
+		return false, err
+	}
+	var configs []*pipelineBean.ConfigData
+	if resourceType == pipelineBean.CS {
+		secretsList := &pipelineBean.SecretsList{}
+		err = json.Unmarshal([]byte(model.SecretData), secretsList)
+		configs = secretsList.ConfigData
+	} else {
+		configsList := &pipeline.ConfigsList{}
+		err = json.Unmarshal([]byte(model.ConfigMapData), configsList)
+		configs = configsList.ConfigData
+	}
+	if err != nil {
+		impl.logger.Errorw("error in unmarshalling env level configs", "err", err, "id", model.Id)
+		return false, err
+	}
+	patchedNames := make(map[string]bool, len(names))
+	for _, name := range names {
+		patchedNames[name] = true
+	}
+	for _, configData := range configs {
+		if !patchedNames[configData.Name] {
+			continue
+		}
+		draftRequest := &pipelineBean.ConfigDataRequest{
+			Id:            model.Id,
+			AppId:         model.AppId,
+			EnvironmentId: model.EnvironmentId,
+			ConfigData:    []*pipelineBean.ConfigData{configData},
+			UserId:        userId,
+		}
+		_, _, err = impl.configProtectionService.SaveDraftIfProtected(model.AppId, model.EnvironmentId, resourceType, draftBean.DraftActionAddUpdate, draftRequest, userId)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -41,6 +41,7 @@ type BulkUpdatePayload struct {
 	DeploymentTemplate *DeploymentTemplateTask `json:"deploymentTemplate"`
 	ConfigMap          *CmAndSecretTask        `json:"configMap"`
 	Secret             *CmAndSecretTask        `json:"secret"`
+	UserId             int32                   `json:"-"`
 }
 type BulkUpdateScript struct {
 	ApiVersion string             `json:"apiVersion" validate:"required"`
@@ -106,6 +107,8 @@ type BulkApplicationForEnvironmentPayload struct {
 	AppNamesExcludes []string `json:"appNamesExcludes,omitempty"`
 	UserId           int32    `json:"-"`
 	InvalidateCache  bool     `json:"invalidateCache"`
+	// TriggerType is set for hibernation not triggered by a user, it is recorded in deployment history
+	TriggerType string `json:"-"`
 }
 
 type BulkApplicationForEnvironmentResponse struct {
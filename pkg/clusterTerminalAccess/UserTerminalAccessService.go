@@ -577,10 +577,12 @@ func (impl *UserTerminalAccessServiceImpl) checkAndStartSession(ctx context.Cont
 		impl.TerminalAccessDataArrayMutex.Unlock()
 		//create terminal session if status is Running and store sessionId
 		request := &terminal.TerminalSessionRequest{
-			Shell:     metadataMap["ShellName"],
-			Namespace: namespace,
-			PodName:   terminalAccessPodName,
-			ClusterId: clusterId,
+			Shell:            metadataMap["ShellName"],
+			Namespace:        namespace,
+			PodName:          terminalAccessPodName,
+			ClusterId:        clusterId,
+			UserId:           terminalAccessData.UserId,
+			TerminalAccessId: terminalAccessId,
 		}
 		_, terminalMessage, err := impl.terminalSessionHandler.GetTerminalSession(request)
 		if err != nil {
@@ -0,0 +1,446 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configDraft
+
+import (
+	"context"
+	"encoding/json"
+	appRepository "github.com/devtron-labs/devtron/internal/sql/repository/app"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/configDiff"
+	configDiffBean "github.com/devtron-labs/devtron/pkg/configDiff/bean"
+	"github.com/devtron-labs/devtron/pkg/configDraft/adapter"
+	"github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	draftRepository "github.com/devtron-labs/devtron/pkg/configDraft/repository"
+	"github.com/devtron-labs/devtron/pkg/pipeline"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type ConfigDraftService interface {
+	// SetProtection protects or unprotects the configs of an app, or of all apps, on an environment
+	SetProtection(protection *bean.ConfigProtectionDto) error
+	GetAllProtections() ([]*bean.ConfigProtectionDto, error)
+
+	SubmitForReview(draftId int, userId int32) (*bean.ConfigDraftDto, error)
+	// ApproveAndPublish applies the reviewed version of the draft, the approver must not have authored any version of it
+	ApproveAndPublish(request *bean.PublishDraftRequest) (*bean.ConfigDraftDto, error)
+	Discard(draftId int, userId int32) error
+	AddComment(comment *bean.DraftCommentDto) (*bean.DraftCommentDto, error)
+
+	GetDraft(draftId int) (*bean.ConfigDraftDto, error)
+	// GetDrafts returns the drafts of the app on the environment, latest first, including published and discarded ones if activeOnly is false
+	GetDrafts(appId, envId int, activeOnly bool) ([]*bean.ConfigDraftDto, error)
+	GetDraftHistory(draftId int) (*bean.DraftHistoryDto, error)
+	// GetDraftDiff returns the latest version of the draft alongside the currently published config
+	GetDraftDiff(ctx context.Context, draftId int) (*bean.DraftDiffDto, error)
+}
+
+type ConfigDraftServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	configDraftRepository          draftRepository.ConfigDraftRepository
+	configMapService               pipeline.ConfigMapService
+	propertiesConfigService        pipeline.PropertiesConfigService
+	deploymentConfigurationService configDiff.DeploymentConfigurationService
+	appRepository                  appRepository.AppRepository
+	environmentRepository          repository.EnvironmentRepository
+	userService                    user.UserService
+}
+
+func NewConfigDraftServiceImpl(logger *zap.SugaredLogger,
+	configDraftRepository draftRepository.ConfigDraftRepository,
+	configMapService pipeline.ConfigMapService,
+	propertiesConfigService pipeline.PropertiesConfigService,
+	deploymentConfigurationService configDiff.DeploymentConfigurationService,
+	appRepository appRepository.AppRepository,
+	environmentRepository repository.EnvironmentRepository,
+	userService user.UserService) *ConfigDraftServiceImpl {
+	return &ConfigDraftServiceImpl{
+		logger:                         logger,
+		configDraftRepository:          configDraftRepository,
+		configMapService:               configMapService,
+		propertiesConfigService:        propertiesConfigService,
+		deploymentConfigurationService: deploymentConfigurationService,
+		appRepository:                  appRepository,
+		environmentRepository:          environmentRepository,
+		userService:                    userService,
+	}
+}
+
+func (impl *ConfigDraftServiceImpl) SetProtection(protection *bean.ConfigProtectionDto) error {
+	existing, err := impl.configDraftRepository.FindProtection(protection.AppId, protection.EnvId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching config protection", "appId", protection.AppId, "envId", protection.EnvId, "err", err)
+		return err
+	}
+	if err == pg.ErrNoRows {
+		if !protection.Protected {
+			return nil
+		}
+		err = impl.configDraftRepository.SaveProtection(&draftRepository.ConfigProtection{
+			AppId:    protection.AppId,
+			EnvId:    protection.EnvId,
+			Active:   true,
+			AuditLog: sql.NewDefaultAuditLog(protection.UserId),
+		})
+	} else {
+		existing.Active = protection.Protected
+		existing.UpdateAuditLog(protection.UserId)
+		err = impl.configDraftRepository.UpdateProtection(existing)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving config protection", "protection", protection, "err", err)
+	}
+	return err
+}
+
+func (impl *ConfigDraftServiceImpl) GetAllProtections() ([]*bean.ConfigProtectionDto, error) {
+	protections, err := impl.configDraftRepository.FindAllActiveProtections()
+	if err != nil {
+		impl.logger.Errorw("error in fetching config protections", "err", err)
+		return nil, err
+	}
+	result := make([]*bean.ConfigProtectionDto, 0, len(protections))
+	for _, protection := range protections {
+		result = append(result, &bean.ConfigProtectionDto{
+			AppId:     protection.AppId,
+			EnvId:     protection.EnvId,
+			Protected: protection.Active,
+		})
+	}
+	return result, nil
+}
+
+func (impl *ConfigDraftServiceImpl) SubmitForReview(draftId int, userId int32) (*bean.ConfigDraftDto, error) {
+	draft, err := impl.configDraftRepository.FindDraftById(draftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft", "draftId", draftId, "err", err)
+		return nil, err
+	}
+	if bean.DraftState(draft.DraftState) != bean.DraftStateDraft {
+		return nil, newConflictError(bean.DraftNotInDraftState)
+	}
+	draft.DraftState = string(bean.DraftStateAwaitingApproval)
+	draft.UpdateAuditLog(userId)
+	err = impl.updateDraft(draft)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetDraft(draftId)
+}
+
+func (impl *ConfigDraftServiceImpl) ApproveAndPublish(request *bean.PublishDraftRequest) (*bean.ConfigDraftDto, error) {
+	draft, err := impl.configDraftRepository.FindDraftById(request.DraftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft", "draftId", request.DraftId, "err", err)
+		return nil, err
+	}
+	if bean.DraftState(draft.DraftState) != bean.DraftStateAwaitingApproval {
+		return nil, newConflictError(bean.DraftNotAwaitingApproval)
+	}
+	versions, err := impl.configDraftRepository.FindVersionsByDraftId(draft.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft versions", "draftId", draft.Id, "err", err)
+		return nil, err
+	}
+	if len(versions) == 0 || versions[len(versions)-1].Id != request.DraftVersionId {
+		return nil, newConflictError(bean.DraftVersionStale)
+	}
+	if isDraftAuthor(versions, request.UserId) {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(bean.SelfApprovalNotAllowed).WithInternalMessage(bean.SelfApprovalNotAllowed)
+	}
+	version := versions[len(versions)-1]
+	// publish applies the change through the config services on their own connections, so the draft is marked
+	// published only once that succeeds and a failed publish leaves it awaiting approval
+	err = impl.publish(draft, version, request.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in publishing config draft", "draftId", draft.Id, "draftVersionId", version.Id, "err", err)
+		return nil, err
+	}
+	draft.DraftState = string(bean.DraftStatePublished)
+	draft.PublishedVersionId = version.Id
+	draft.ApprovedBy = request.UserId
+	draft.UpdateAuditLog(request.UserId)
+	updated, err := impl.configDraftRepository.UpdateDraftInState(draft, bean.DraftStateAwaitingApproval)
+	if err != nil {
+		impl.logger.Errorw("error in updating config draft", "draftId", draft.Id, "err", err)
+		return nil, err
+	}
+	if !updated {
+		// the draft was published or discarded concurrently
+		return nil, newConflictError(bean.DraftNotAwaitingApproval)
+	}
+	return adapter.ToDraftDto(draft, version), nil
+}
+
+func (impl *ConfigDraftServiceImpl) Discard(draftId int, userId int32) error {
+	draft, err := impl.configDraftRepository.FindDraftById(draftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft", "draftId", draftId, "err", err)
+		return err
+	}
+	if !bean.DraftState(draft.DraftState).IsActive() {
+		return newConflictError(bean.DraftNotActive)
+	}
+	draft.DraftState = string(bean.DraftStateDiscarded)
+	draft.UpdateAuditLog(userId)
+	return impl.updateDraft(draft)
+}
+
+func (impl *ConfigDraftServiceImpl) AddComment(comment *bean.DraftCommentDto) (*bean.DraftCommentDto, error) {
+	_, err := impl.configDraftRepository.FindDraftById(comment.DraftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft", "draftId", comment.DraftId, "err", err)
+		return nil, err
+	}
+	if comment.DraftVersionId == 0 {
+		version, err := impl.configDraftRepository.FindLatestVersion(comment.DraftId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching latest config draft version", "draftId", comment.DraftId, "err", err)
+			return nil, err
+		}
+		comment.DraftVersionId = version.Id
+	}
+	dbObject := &draftRepository.ConfigDraftComment{
+		DraftId:        comment.DraftId,
+		DraftVersionId: comment.DraftVersionId,
+		Comment:        comment.Comment,
+		AuditLog:       sql.NewDefaultAuditLog(comment.UserId),
+	}
+	err = impl.configDraftRepository.SaveComment(dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in saving config draft comment", "comment", comment, "err", err)
+		return nil, err
+	}
+	comment.Id = dbObject.Id
+	comment.CreatedOn = dbObject.CreatedOn
+	return comment, nil
+}
+
+func (impl *ConfigDraftServiceImpl) GetDraft(draftId int) (*bean.ConfigDraftDto, error) {
+	draft, err := impl.configDraftRepository.FindDraftById(draftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft", "draftId", draftId, "err", err)
+		return nil, err
+	}
+	version, err := impl.configDraftRepository.FindLatestVersion(draftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching latest config draft version", "draftId", draftId, "err", err)
+		return nil, err
+	}
+	return adapter.ToDraftDto(draft, version), nil
+}
+
+func (impl *ConfigDraftServiceImpl) GetDrafts(appId, envId int, activeOnly bool) ([]*bean.ConfigDraftDto, error) {
+	var states []string
+	if activeOnly {
+		states = []string{string(bean.DraftStateDraft), string(bean.DraftStateAwaitingApproval)}
+	}
+	drafts, err := impl.configDraftRepository.FindDrafts(appId, envId, states)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config drafts", "appId", appId, "envId", envId, "err", err)
+		return nil, err
+	}
+	draftIds := make([]int, 0, len(drafts))
+	for _, draft := range drafts {
+		draftIds = append(draftIds, draft.Id)
+	}
+	versions, err := impl.configDraftRepository.FindLatestVersions(draftIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching latest config draft versions", "draftIds", draftIds, "err", err)
+		return nil, err
+	}
+	versionByDraftId := make(map[int]*draftRepository.ConfigDraftVersion, len(versions))
+	for _, version := range versions {
+		versionByDraftId[version.DraftId] = version
+	}
+	result := make([]*bean.ConfigDraftDto, 0, len(drafts))
+	for _, draft := range drafts {
+		result = append(result, adapter.ToDraftDto(draft, versionByDraftId[draft.Id]))
+	}
+	return result, nil
+}
+
+func (impl *ConfigDraftServiceImpl) GetDraftHistory(draftId int) (*bean.DraftHistoryDto, error) {
+	draft, err := impl.configDraftRepository.FindDraftById(draftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft", "draftId", draftId, "err", err)
+		return nil, err
+	}
+	versions, err := impl.configDraftRepository.FindVersionsByDraftId(draftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft versions", "draftId", draftId, "err", err)
+		return nil, err
+	}
+	comments, err := impl.configDraftRepository.FindCommentsByDraftId(draftId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config draft comments", "draftId", draftId, "err", err)
+		return nil, err
+	}
+	userIds := make([]int32, 0, len(versions)+len(comments))
+	for _, version := range versions {
+		userIds = append(userIds, version.CreatedBy)
+	}
+	for _, comment := range comments {
+		userIds = append(userIds, comment.CreatedBy)
+	}
+	emailIds, err := impl.getEmailIds(userIds)
+	if err != nil {
+		return nil, err
+	}
+	history := &bean.DraftHistoryDto{
+		Versions: make([]*bean.DraftVersionDto, 0, len(versions)),
+		Comments: make([]*bean.DraftCommentDto, 0, len(comments)),
+	}
+	var latestVersion *draftRepository.ConfigDraftVersion
+	for _, version := range versions {
+		history.Versions = append(history.Versions, adapter.ToVersionDto(version, emailIds))
+		latestVersion = version
+	}
+	for _, comment := range comments {
+		history.Comments = append(history.Comments, adapter.ToCommentDto(comment, emailIds))
+	}
+	history.Draft = adapter.ToDraftDto(draft, latestVersion)
+	return history, nil
+}
+
+func (impl *ConfigDraftServiceImpl) GetDraftDiff(ctx context.Context, draftId int) (*bean.DraftDiffDto, error) {
+	draft, err := impl.GetDraft(draftId)
+	if err != nil {
+		return nil, err
+	}
+	app, err := impl.appRepository.FindById(draft.AppId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching app", "appId", draft.AppId, "err", err)
+		return nil, err
+	}
+	env, err := impl.environmentRepository.FindById(draft.EnvId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", draft.EnvId, "err", err)
+		return nil, err
+	}
+	queryParams := &configDiffBean.ConfigDataQueryParams{
+		AppName:    app.AppName,
+		EnvName:    env.Name,
+		ConfigType: string(configDiffBean.PublishedConfigState),
+	}
+	if draft.ResourceType != pipelineBean.DeploymentTemplate {
+		queryParams.ResourceName = draft.ResourceName
+		queryParams.ResourceType = draft.ResourceType.ToString()
+	}
+	publishedConfig, err := impl.deploymentConfigurationService.GetAllConfigData(ctx, queryParams)
+	if err != nil {
+		impl.logger.Errorw("error in fetching published config", "queryParams", queryParams, "err", err)
+		return nil, err
+	}
+	return &bean.DraftDiffDto{
+		Draft:           draft,
+		PublishedConfig: publishedConfig,
+	}, nil
+}
+
+func (impl *ConfigDraftServiceImpl) publish(draft *draftRepository.ConfigDraft, version *draftRepository.ConfigDraftVersion, userId int32) error {
+	switch pipelineBean.ResourceType(draft.ResourceType) {
+	case pipelineBean.DeploymentTemplate:
+		environmentProperties := &pipelineBean.EnvironmentProperties{}
+		err := json.Unmarshal([]byte(version.Data), environmentProperties)
+		if err != nil {
+			return err
+		}
+		environmentProperties.UserId = userId
+		// the change has been reviewed through the draft, it must not be saved as a draft again
+		environmentProperties.SkipConfigProtection = true
+		if bean.DraftAction(version.Action) == bean.DraftActionDelete {
+			_, err = impl.propertiesConfigService.ResetEnvironmentPropertiesSkippingProtection(environmentProperties.Id, userId)
+		} else if environmentProperties.Id == 0 {
+			_, _, err = impl.propertiesConfigService.CreateEnvironmentProperties(draft.AppId, environmentProperties)
+		} else {
+			_, _, err = impl.propertiesConfigService.UpdateEnvironmentProperties(draft.AppId, environmentProperties, userId)
+		}
+		return err
+	case pipelineBean.CM, pipelineBean.CS:
+		configDataRequest := &pipelineBean.ConfigDataRequest{}
+		err := json.Unmarshal([]byte(version.Data), configDataRequest)
+		if err != nil {
+			return err
+		}
+		configDataRequest.UserId = userId
+		configDataRequest.SkipConfigProtection = true
+		isConfigMap := pipelineBean.ResourceType(draft.ResourceType) == pipelineBean.CM
+		switch {
+		case bean.DraftAction(version.Action) == bean.DraftActionDelete && isConfigMap:
+			_, err = impl.configMapService.CMEnvironmentDeleteSkippingProtection(draft.ResourceName, configDataRequest.Id, userId)
+		case bean.DraftAction(version.Action) == bean.DraftActionDelete:
+			_, err = impl.configMapService.CSEnvironmentDeleteSkippingProtection(draft.ResourceName, configDataRequest.Id, userId)
+		case isConfigMap:
+			_, _, err = impl.configMapService.CMEnvironmentAddUpdate(configDataRequest)
+		default:
+			_, _, err = impl.configMapService.CSEnvironmentAddUpdate(configDataRequest)
+		}
+		return err
+	default:
+		return newBadRequestError(bean.UnsupportedDraftResource)
+	}
+}
+
+func (impl *ConfigDraftServiceImpl) updateDraft(draft *draftRepository.ConfigDraft) error {
+	tx, err := impl.configDraftRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.configDraftRepository.RollbackTx(tx)
+	err = impl.configDraftRepository.UpdateDraft(tx, draft)
+	if err != nil {
+		impl.logger.Errorw("error in updating config draft", "draftId", draft.Id, "err", err)
+		return err
+	}
+	err = impl.configDraftRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+	}
+	return err
+}
+
+func (impl *ConfigDraftServiceImpl) getEmailIds(userIds []int32) (map[int32]string, error) {
+	emailIds := make(map[int32]string, len(userIds))
+	if len(userIds) == 0 {
+		return emailIds, nil
+	}
+	users, err := impl.userService.GetByIds(userIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching users", "userIds", userIds, "err", err)
+		return nil, err
+	}
+	for _, userInfo := range users {
+		emailIds[userInfo.Id] = userInfo.EmailId
+	}
+	return emailIds, nil
+}
+
+func newBadRequestError(message string) *util.ApiError {
+	return util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(message).WithInternalMessage(message)
+}
+
+func newConflictError(message string) *util.ApiError {
+	return util.NewApiError().WithHttpStatusCode(http.StatusConflict).WithUserMessage(message).WithInternalMessage(message)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/pkg/configDraft/repository"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+)
+
+func ToDraftDto(draft *repository.ConfigDraft, version *repository.ConfigDraftVersion) *bean.ConfigDraftDto {
+	dto := &bean.ConfigDraftDto{
+		Id:           draft.Id,
+		AppId:        draft.AppId,
+		EnvId:        draft.EnvId,
+		ResourceType: pipelineBean.ResourceType(draft.ResourceType),
+		ResourceName: draft.ResourceName,
+		State:        bean.DraftState(draft.DraftState),
+		ApprovedBy:   draft.ApprovedBy,
+		CreatedBy:    draft.CreatedBy,
+		CreatedOn:    draft.CreatedOn,
+		UpdatedOn:    draft.UpdatedOn,
+	}
+	if version != nil {
+		dto.LatestVersionId = version.Id
+		dto.Action = bean.DraftAction(version.Action)
+		dto.Data = []byte(version.Data)
+	}
+	return dto
+}
+
+func ToVersionDto(version *repository.ConfigDraftVersion, emailIds map[int32]string) *bean.DraftVersionDto {
+	return &bean.DraftVersionDto{
+		Id:        version.Id,
+		DraftId:   version.DraftId,
+		Action:    bean.DraftAction(version.Action),
+		Data:      []byte(version.Data),
+		UserId:    version.CreatedBy,
+		EmailId:   emailIds[version.CreatedBy],
+		CreatedOn: version.CreatedOn,
+	}
+}
+
+func ToCommentDto(comment *repository.ConfigDraftComment, emailIds map[int32]string) *bean.DraftCommentDto {
+	return &bean.DraftCommentDto{
+		Id:             comment.Id,
+		DraftId:        comment.DraftId,
+		DraftVersionId: comment.DraftVersionId,
+		Comment:        comment.Comment,
+		UserId:         comment.CreatedBy,
+		EmailId:        emailIds[comment.CreatedBy],
+		CreatedOn:      comment.CreatedOn,
+	}
+}
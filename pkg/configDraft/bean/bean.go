@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"encoding/json"
+	configDiffBean "github.com/devtron-labs/devtron/pkg/configDiff/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"time"
+)
+
+type DraftState string
+
+const (
+	DraftStateDraft            DraftState = "DRAFT"
+	DraftStateAwaitingApproval DraftState = "AWAITING_APPROVAL"
+	DraftStatePublished        DraftState = "PUBLISHED"
+	DraftStateDiscarded        DraftState = "DISCARDED"
+)
+
+func (state DraftState) IsActive() bool {
+	return state == DraftStateDraft || state == DraftStateAwaitingApproval
+}
+
+type DraftAction string
+
+const (
+	DraftActionAddUpdate DraftAction = "ADD_UPDATE"
+	DraftActionDelete    DraftAction = "DELETE"
+)
+
+const (
+	DraftNotActive           = "draft is already published or discarded"
+	DraftNotInDraftState     = "draft is already submitted for review"
+	DraftNotAwaitingApproval = "draft is not submitted for review"
+	DraftVersionStale        = "draft has been changed after this version, review the latest version"
+	SelfApprovalNotAllowed   = "you can not approve a draft changed by you"
+	UnsupportedDraftResource = "drafts are only supported for deployment templates, config maps and secrets"
+	InvalidDraftResourceName = "config map and secret drafts must change exactly one named resource"
+	ChangeSavedAsDraft       = "configs of the environment are protected, the change has been saved as a draft"
+)
+
+// ConfigProtectionDto marks the configs of an app on an environment as protected, changes to protected configs
+// are saved as drafts and need approval of another user before being published. AppId 0 protects all apps on the environment
+type ConfigProtectionDto struct {
+	AppId     int   `json:"appId"`
+	EnvId     int   `json:"envId" validate:"required"`
+	Protected bool  `json:"protected"`
+	UserId    int32 `json:"-"`
+}
+
+// ConfigDraftRequest is a change to a deployment template, config map or secret of an app on an environment.
+// Data is the payload of the api the change was made through, bean.EnvironmentProperties for deployment templates
+// and bean.ConfigDataRequest for config maps and secrets
+type ConfigDraftRequest struct {
+	AppId        int               `json:"appId"`
+	EnvId        int               `json:"envId"`
+	ResourceType bean.ResourceType `json:"resourceType"`
+	Action       DraftAction       `json:"action"`
+	Data         json.RawMessage   `json:"data"`
+	UserId       int32             `json:"-"`
+}
+
+type ConfigDraftDto struct {
+	Id              int               `json:"id"`
+	AppId           int               `json:"appId"`
+	EnvId           int               `json:"envId"`
+	ResourceType    bean.ResourceType `json:"resourceType"`
+	ResourceName    string            `json:"resourceName"`
+	State           DraftState        `json:"state"`
+	LatestVersionId int               `json:"latestVersionId"`
+	Action          DraftAction       `json:"action"`
+	Data            json.RawMessage   `json:"data"`
+	ApprovedBy      int32             `json:"approvedBy,omitempty"`
+	CreatedBy       int32             `json:"createdBy"`
+	CreatedOn       time.Time         `json:"createdOn"`
+	UpdatedOn       time.Time         `json:"updatedOn"`
+	// IsDraft is set on the responses of config apis when the change was saved as a draft instead of being applied
+	IsDraft bool `json:"isDraft"`
+}
+
+type DraftVersionDto struct {
+	Id        int             `json:"id"`
+	DraftId   int             `json:"draftId"`
+	Action    DraftAction     `json:"action"`
+	Data      json.RawMessage `json:"data"`
+	UserId    int32           `json:"userId"`
+	EmailId   string          `json:"emailId"`
+	CreatedOn time.Time       `json:"createdOn"`
+}
+
+type DraftCommentDto struct {
+	Id             int       `json:"id"`
+	DraftId        int       `json:"draftId"`
+	DraftVersionId int       `json:"draftVersionId"`
+	Comment        string    `json:"comment" validate:"required,max=1000"`
+	UserId         int32     `json:"userId"`
+	EmailId        string    `json:"emailId"`
+	CreatedOn      time.Time `json:"createdOn"`
+}
+
+type DraftHistoryDto struct {
+	Draft    *ConfigDraftDto    `json:"draft"`
+	Versions []*DraftVersionDto `json:"versions"`
+	Comments []*DraftCommentDto `json:"comments"`
+}
+
+// DraftDiffDto has the published config of the draft's resource alongside the latest version of the draft
+type DraftDiffDto struct {
+	Draft           *ConfigDraftDto                            `json:"draft"`
+	PublishedConfig *configDiffBean.DeploymentAndCmCsConfigDto `json:"publishedConfig"`
+}
+
+type PublishDraftRequest struct {
+	DraftId        int   `json:"draftId"`
+	DraftVersionId int   `json:"draftVersionId" validate:"required"`
+	UserId         int32 `json:"-"`
+}
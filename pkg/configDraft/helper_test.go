@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configDraft
+
+import (
+	"github.com/devtron-labs/devtron/pkg/configDraft/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsDraftAuthor(t *testing.T) {
+	versions := []*repository.ConfigDraftVersion{
+		{AuditLog: sql.AuditLog{CreatedBy: 2}},
+		{AuditLog: sql.AuditLog{CreatedBy: 5}},
+	}
+	assert.True(t, isDraftAuthor(versions, 5))
+	assert.False(t, isDraftAuthor(versions, 7))
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package protection
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/configDraft/adapter"
+	"github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/pkg/configDraft/repository"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+// ConfigProtectionService is used by the config services to save changes to protected configs as drafts,
+// it is kept apart from the draft review flow as publishing a draft goes through the config services
+type ConfigProtectionService interface {
+	IsConfigProtected(appId, envId int) (bool, error)
+	// SaveDraftIfProtected saves the change as a new version of the resource's draft if the environment is protected
+	// for the app and returns the draft with isDraft set, isDraft is false if the change can be applied right away.
+	// payload is the request of the config service the change was made through
+	SaveDraftIfProtected(appId, envId int, resourceType pipelineBean.ResourceType, action bean.DraftAction, payload interface{}, userId int32) (draft *bean.ConfigDraftDto, isDraft bool, err error)
+}
+
+type ConfigProtectionServiceImpl struct {
+	logger                *zap.SugaredLogger
+	configDraftRepository repository.ConfigDraftRepository
+}
+
+func NewConfigProtectionServiceImpl(logger *zap.SugaredLogger,
+	configDraftRepository repository.ConfigDraftRepository) *ConfigProtectionServiceImpl {
+	return &ConfigProtectionServiceImpl{
+		logger:                logger,
+		configDraftRepository: configDraftRepository,
+	}
+}
+
+func (impl *ConfigProtectionServiceImpl) IsConfigProtected(appId, envId int) (bool, error) {
+	if envId == 0 {
+		return false, nil
+	}
+	protections, err := impl.configDraftRepository.FindActiveProtections(appId, envId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config protections", "appId", appId, "envId", envId, "err", err)
+		return false, err
+	}
+	return len(protections) > 0, nil
+}
+
+func (impl *ConfigProtectionServiceImpl) SaveDraftIfProtected(appId, envId int, resourceType pipelineBean.ResourceType,
+	action bean.DraftAction, payload interface{}, userId int32) (*bean.ConfigDraftDto, bool, error) {
+	isProtected, err := impl.IsConfigProtected(appId, envId)
+	if err != nil || !isProtected {
+		return nil, false, err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		impl.logger.Errorw("error in marshalling config draft payload", "appId", appId, "envId", envId, "err", err)
+		return nil, false, err
+	}
+	draft, err := impl.saveDraft(&bean.ConfigDraftRequest{
+		AppId:        appId,
+		EnvId:        envId,
+		ResourceType: resourceType,
+		Action:       action,
+		Data:         data,
+		UserId:       userId,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return draft, true, nil
+}
+
+func (impl *ConfigProtectionServiceImpl) saveDraft(request *bean.ConfigDraftRequest) (*bean.ConfigDraftDto, error) {
+	resourceName, err := getDraftResourceName(request)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := impl.configDraftRepository.FindActiveDraft(request.AppId, request.EnvId, string(request.ResourceType), resourceName)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching active config draft", "request", request, "err", err)
+		return nil, err
+	}
+	tx, err := impl.configDraftRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.configDraftRepository.RollbackTx(tx)
+	if draft.Id == 0 {
+		draft = &repository.ConfigDraft{
+			AppId:        request.AppId,
+			EnvId:        request.EnvId,
+			ResourceType: string(request.ResourceType),
+			ResourceName: resourceName,
+			DraftState:   string(bean.DraftStateDraft),
+			AuditLog:     sql.NewDefaultAuditLog(request.UserId),
+		}
+		err = impl.configDraftRepository.SaveDraft(tx, draft)
+	} else {
+		// a change made while the draft is in review needs to be reviewed again
+		draft.DraftState = string(bean.DraftStateDraft)
+		draft.UpdateAuditLog(request.UserId)
+		err = impl.configDraftRepository.UpdateDraft(tx, draft)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving config draft", "request", request, "err", err)
+		return nil, err
+	}
+	version := &repository.ConfigDraftVersion{
+		DraftId:  draft.Id,
+		Action:   string(request.Action),
+		Data:     string(request.Data),
+		AuditLog: sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.configDraftRepository.SaveVersion(tx, version)
+	if err != nil {
+		impl.logger.Errorw("error in saving config draft version", "draftId", draft.Id, "err", err)
+		return nil, err
+	}
+	err = impl.configDraftRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	dto := adapter.ToDraftDto(draft, version)
+	dto.IsDraft = true
+	return dto, nil
+}
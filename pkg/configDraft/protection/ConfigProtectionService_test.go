@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package protection
+
+import (
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/pkg/configDraft/repository"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeConfigDraftRepository struct {
+	repository.ConfigDraftRepository
+	protections []*repository.ConfigProtection
+	versions    []*repository.ConfigDraftVersion
+}
+
+func (repo *fakeConfigDraftRepository) FindActiveProtections(appId, envId int) ([]*repository.ConfigProtection, error) {
+	return repo.protections, nil
+}
+
+func (repo *fakeConfigDraftRepository) FindActiveDraft(appId, envId int, resourceType, resourceName string) (*repository.ConfigDraft, error) {
+	return &repository.ConfigDraft{}, pg.ErrNoRows
+}
+
+func (repo *fakeConfigDraftRepository) StartTx() (*pg.Tx, error) {
+	return nil, nil
+}
+
+func (repo *fakeConfigDraftRepository) RollbackTx(tx *pg.Tx) error {
+	return nil
+}
+
+func (repo *fakeConfigDraftRepository) CommitTx(tx *pg.Tx) error {
+	return nil
+}
+
+func (repo *fakeConfigDraftRepository) SaveDraft(tx *pg.Tx, draft *repository.ConfigDraft) error {
+	draft.Id = 1
+	return nil
+}
+
+func (repo *fakeConfigDraftRepository) SaveVersion(tx *pg.Tx, version *repository.ConfigDraftVersion) error {
+	version.Id = len(repo.versions) + 1
+	repo.versions = append(repo.versions, version)
+	return nil
+}
+
+func TestConfigProtectionServiceImpl_SaveDraftIfProtected(t *testing.T) {
+	payload := &pipelineBean.ConfigDataRequest{AppId: 1, EnvironmentId: 2, ConfigData: []*pipelineBean.ConfigData{{Name: "app-config"}}}
+
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	repo := &fakeConfigDraftRepository{}
+	impl := NewConfigProtectionServiceImpl(logger, repo)
+	draft, isDraft, err := impl.SaveDraftIfProtected(1, 2, pipelineBean.CM, bean.DraftActionAddUpdate, payload, 1)
+	assert.Nil(t, err)
+	assert.False(t, isDraft)
+	assert.Nil(t, draft)
+	assert.Empty(t, repo.versions)
+
+	repo.protections = []*repository.ConfigProtection{{AppId: 1, EnvId: 2}}
+	draft, isDraft, err = impl.SaveDraftIfProtected(1, 2, pipelineBean.CM, bean.DraftActionAddUpdate, payload, 1)
+	assert.Nil(t, err)
+	assert.True(t, isDraft)
+	assert.Equal(t, 1, draft.Id)
+	assert.Equal(t, "app-config", draft.ResourceName)
+	assert.True(t, draft.IsDraft)
+	assert.Len(t, repo.versions, 1)
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package protection
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"net/http"
+)
+
+// getDraftResourceName deployment templates have a single override per app and environment, config maps and secrets
+// are drafted one resource at a time
+func getDraftResourceName(request *bean.ConfigDraftRequest) (string, error) {
+	switch request.ResourceType {
+	case pipelineBean.DeploymentTemplate:
+		return "", nil
+	case pipelineBean.CM, pipelineBean.CS:
+		configDataRequest := &pipelineBean.ConfigDataRequest{}
+		err := json.Unmarshal(request.Data, configDataRequest)
+		if err != nil {
+			return "", newBadRequestError(err.Error())
+		}
+		if len(configDataRequest.ConfigData) != 1 || len(configDataRequest.ConfigData[0].Name) == 0 {
+			return "", newBadRequestError(bean.InvalidDraftResourceName)
+		}
+		return configDataRequest.ConfigData[0].Name, nil
+	default:
+		return "", newBadRequestError(bean.UnsupportedDraftResource)
+	}
+}
+
+func newBadRequestError(message string) *util.ApiError {
+	return util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(message).WithInternalMessage(message)
+}
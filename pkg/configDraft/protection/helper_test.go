@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package protection
+
+import (
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestGetDraftResourceName(t *testing.T) {
+	name, err := getDraftResourceName(&bean.ConfigDraftRequest{
+		ResourceType: pipelineBean.CM,
+		Data:         []byte(`{"appId":1,"environmentId":2,"configData":[{"name":"app-config"}]}`),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "app-config", name)
+
+	name, err = getDraftResourceName(&bean.ConfigDraftRequest{ResourceType: pipelineBean.DeploymentTemplate, Data: []byte(`{}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, "", name)
+
+	name, err = getDraftResourceName(&bean.ConfigDraftRequest{ResourceType: pipelineBean.DeploymentTemplate, Action: bean.DraftActionDelete})
+	assert.Nil(t, err)
+	assert.Equal(t, "", name)
+
+	_, err = getDraftResourceName(&bean.ConfigDraftRequest{ResourceType: pipelineBean.CS, Data: []byte(`{"configData":[]}`)})
+	assert.Equal(t, http.StatusBadRequest, err.(*util.ApiError).HttpStatusCode)
+
+	_, err = getDraftResourceName(&bean.ConfigDraftRequest{ResourceType: "Job"})
+	assert.NotNil(t, err)
+}
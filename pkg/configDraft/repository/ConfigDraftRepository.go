@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+type ConfigProtection struct {
+	tableName struct{} `sql:"config_protection" pg:",discard_unknown_columns"`
+	Id        int      `sql:"id,pk"`
+	AppId     int      `sql:"app_id,notnull"`
+	EnvId     int      `sql:"env_id,notnull"`
+	Active    bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type ConfigDraft struct {
+	tableName          struct{} `sql:"config_draft" pg:",discard_unknown_columns"`
+	Id                 int      `sql:"id,pk"`
+	AppId              int      `sql:"app_id,notnull"`
+	EnvId              int      `sql:"env_id,notnull"`
+	ResourceType       string   `sql:"resource_type,notnull"`
+	ResourceName       string   `sql:"resource_name,notnull"`
+	DraftState         string   `sql:"draft_state,notnull"`
+	PublishedVersionId int      `sql:"published_version_id"`
+	ApprovedBy         int32    `sql:"approved_by"`
+	sql.AuditLog
+}
+
+type ConfigDraftVersion struct {
+	tableName struct{} `sql:"config_draft_version" pg:",discard_unknown_columns"`
+	Id        int      `sql:"id,pk"`
+	DraftId   int      `sql:"draft_id,notnull"`
+	Action    string   `sql:"action,notnull"`
+	Data      string   `sql:"data"`
+	sql.AuditLog
+}
+
+type ConfigDraftComment struct {
+	tableName      struct{} `sql:"config_draft_comment" pg:",discard_unknown_columns"`
+	Id             int      `sql:"id,pk"`
+	DraftId        int      `sql:"draft_id,notnull"`
+	DraftVersionId int      `sql:"draft_version_id,notnull"`
+	Comment        string   `sql:"comment,notnull"`
+	sql.AuditLog
+}
+
+type ConfigDraftRepository interface {
+	//transaction util funcs
+	sql.TransactionWrapper
+	SaveProtection(protection *ConfigProtection) error
+	UpdateProtection(protection *ConfigProtection) error
+	FindProtection(appId, envId int) (*ConfigProtection, error)
+	FindAllActiveProtections() ([]*ConfigProtection, error)
+	// FindActiveProtections returns the protections of the app on the environment and of all apps on the environment
+	FindActiveProtections(appId, envId int) ([]*ConfigProtection, error)
+
+	SaveDraft(tx *pg.Tx, draft *ConfigDraft) error
+	UpdateDraft(tx *pg.Tx, draft *ConfigDraft) error
+	// UpdateDraftInState updates the draft only if it is still in the given state and returns whether it was updated
+	UpdateDraftInState(draft *ConfigDraft, state bean.DraftState) (bool, error)
+	FindDraftById(id int) (*ConfigDraft, error)
+	// FindActiveDraft returns the draft of the resource which is not yet published or discarded
+	FindActiveDraft(appId, envId int, resourceType, resourceName string) (*ConfigDraft, error)
+	FindDrafts(appId, envId int, states []string) ([]*ConfigDraft, error)
+
+	SaveVersion(tx *pg.Tx, version *ConfigDraftVersion) error
+	FindVersionsByDraftId(draftId int) ([]*ConfigDraftVersion, error)
+	FindLatestVersion(draftId int) (*ConfigDraftVersion, error)
+	// FindLatestVersions returns the latest version of each of the drafts
+	FindLatestVersions(draftIds []int) ([]*ConfigDraftVersion, error)
+
+	SaveComment(comment *ConfigDraftComment) error
+	FindCommentsByDraftId(draftId int) ([]*ConfigDraftComment, error)
+}
+
+type ConfigDraftRepositoryImpl struct {
+	*sql.TransactionUtilImpl
+	dbConnection *pg.DB
+}
+
+func NewConfigDraftRepositoryImpl(dbConnection *pg.DB, TransactionUtilImpl *sql.TransactionUtilImpl) *ConfigDraftRepositoryImpl {
+	return &ConfigDraftRepositoryImpl{
+		dbConnection:        dbConnection,
+		TransactionUtilImpl: TransactionUtilImpl,
+	}
+}
+
+func (impl ConfigDraftRepositoryImpl) SaveProtection(protection *ConfigProtection) error {
+	return impl.dbConnection.Insert(protection)
+}
+
+func (impl ConfigDraftRepositoryImpl) UpdateProtection(protection *ConfigProtection) error {
+	return impl.dbConnection.Update(protection)
+}
+
+func (impl ConfigDraftRepositoryImpl) FindProtection(appId, envId int) (*ConfigProtection, error) {
+	protection := &ConfigProtection{}
+	err := impl.dbConnection.Model(protection).
+		Where("app_id = ?", appId).
+		Where("env_id = ?", envId).
+		Select()
+	return protection, err
+}
+
+func (impl ConfigDraftRepositoryImpl) FindAllActiveProtections() ([]*ConfigProtection, error) {
+	protections := make([]*ConfigProtection, 0)
+	err := impl.dbConnection.Model(&protections).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return protections, err
+}
+
+func (impl ConfigDraftRepositoryImpl) FindActiveProtections(appId, envId int) ([]*ConfigProtection, error) {
+	protections := make([]*ConfigProtection, 0)
+	err := impl.dbConnection.Model(&protections).
+		Where("active = ?", true).
+		Where("env_id = ?", envId).
+		Where("app_id IN (?)", pg.In([]int{0, appId})).
+		Select()
+	return protections, err
+}
+
+func (impl ConfigDraftRepositoryImpl) SaveDraft(tx *pg.Tx, draft *ConfigDraft) error {
+	return tx.Insert(draft)
+}
+
+func (impl ConfigDraftRepositoryImpl) UpdateDraft(tx *pg.Tx, draft *ConfigDraft) error {
+	return tx.Update(draft)
+}
+
+func (impl ConfigDraftRepositoryImpl) UpdateDraftInState(draft *ConfigDraft, state bean.DraftState) (bool, error) {
+	res, err := impl.dbConnection.Model(draft).
+		WherePK().
+		Where("draft_state = ?", string(state)).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (impl ConfigDraftRepositoryImpl) FindDraftById(id int) (*ConfigDraft, error) {
+	draft := &ConfigDraft{}
+	err := impl.dbConnection.Model(draft).
+		Where("id = ?", id).
+		Select()
+	return draft, err
+}
+
+func (impl ConfigDraftRepositoryImpl) FindActiveDraft(appId, envId int, resourceType, resourceName string) (*ConfigDraft, error) {
+	draft := &ConfigDraft{}
+	err := impl.dbConnection.Model(draft).
+		Where("app_id = ?", appId).
+		Where("env_id = ?", envId).
+		Where("resource_type = ?", resourceType).
+		Where("resource_name = ?", resourceName).
+		Where("draft_state IN (?)", pg.In([]string{string(bean.DraftStateDraft), string(bean.DraftStateAwaitingApproval)})).
+		Select()
+	return draft, err
+}
+
+func (impl ConfigDraftRepositoryImpl) FindDrafts(appId, envId int, states []string) ([]*ConfigDraft, error) {
+	drafts := make([]*ConfigDraft, 0)
+	query := impl.dbConnection.Model(&drafts).
+		Where("app_id = ?", appId).
+		Where("env_id = ?", envId)
+	if len(states) > 0 {
+		query = query.Where("draft_state IN (?)", pg.In(states))
+	}
+	err := query.Order("id DESC").Select()
+	return drafts, err
+}
+
+func (impl ConfigDraftRepositoryImpl) SaveVersion(tx *pg.Tx, version *ConfigDraftVersion) error {
+	return tx.Insert(version)
+}
+
+func (impl ConfigDraftRepositoryImpl) FindVersionsByDraftId(draftId int) ([]*ConfigDraftVersion, error) {
+	versions := make([]*ConfigDraftVersion, 0)
+	err := impl.dbConnection.Model(&versions).
+		Where("draft_id = ?", draftId).
+		Order("id ASC").
+		Select()
+	return versions, err
+}
+
+func (impl ConfigDraftRepositoryImpl) FindLatestVersion(draftId int) (*ConfigDraftVersion, error) {
+	version := &ConfigDraftVersion{}
+	err := impl.dbConnection.Model(version).
+		Where("draft_id = ?", draftId).
+		Order("id DESC").
+		Limit(1).
+		Select()
+	return version, err
+}
+
+func (impl ConfigDraftRepositoryImpl) FindLatestVersions(draftIds []int) ([]*ConfigDraftVersion, error) {
+	versions := make([]*ConfigDraftVersion, 0)
+	if len(draftIds) == 0 {
+		return versions, nil
+	}
+	query := "SELECT DISTINCT ON (draft_id) * FROM config_draft_version " +
+		"WHERE draft_id IN (?) ORDER BY draft_id, id DESC;"
+	_, err := impl.dbConnection.Query(&versions, query, pg.In(draftIds))
+	return versions, err
+}
+
+func (impl ConfigDraftRepositoryImpl) SaveComment(comment *ConfigDraftComment) error {
+	return impl.dbConnection.Insert(comment)
+}
+
+func (impl ConfigDraftRepositoryImpl) FindCommentsByDraftId(draftId int) ([]*ConfigDraftComment, error) {
+	comments := make([]*ConfigDraftComment, 0)
+	err := impl.dbConnection.Model(&comments).
+		Where("draft_id = ?", draftId).
+		Order("id ASC").
+		Select()
+	return comments, err
+}
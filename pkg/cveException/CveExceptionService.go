@@ -0,0 +1,301 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cveException
+
+import (
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/sql/repository/app"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	repository2 "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/cveException/bean"
+	"github.com/devtron-labs/devtron/pkg/cveException/repository"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type CveExceptionService interface {
+	// CreateException requests an exception, it is pending until approved
+	CreateException(exception *bean.CveExceptionDto) (*bean.CveExceptionDto, error)
+	ApproveException(request *bean.ExceptionReviewRequest) (*bean.CveExceptionDto, error)
+	RejectException(request *bean.ExceptionReviewRequest) (*bean.CveExceptionDto, error)
+	RevokeException(request *bean.ExceptionReviewRequest) (*bean.CveExceptionDto, error)
+	GetException(id int) (*bean.CveExceptionDetailDto, error)
+	GetExceptions(filter *bean.ExceptionFilter) ([]*bean.CveExceptionDto, error)
+	// GetExpiringExceptions returns the approved exceptions expiring within the days, the configured window is used
+	// if days is not positive
+	GetExpiringExceptions(days int) ([]*bean.CveExceptionDto, error)
+	// GetAllowedCves returns the names of the cves allowed by the approved and not expired exceptions on the app or the
+	// environment
+	GetAllowedCves(appId, envId int) (map[string]bool, error)
+	// ExpireExceptions marks the approved exceptions past their expiry as expired
+	ExpireExceptions()
+}
+
+type CveExceptionServiceImpl struct {
+	logger                 *zap.SugaredLogger
+	cveExceptionRepository repository.CveExceptionRepository
+	appRepository          app.AppRepository
+	environmentRepository  repository2.EnvironmentRepository
+	config                 *bean.CveExceptionConfig
+}
+
+func NewCveExceptionServiceImpl(logger *zap.SugaredLogger,
+	cveExceptionRepository repository.CveExceptionRepository,
+	appRepository app.AppRepository,
+	environmentRepository repository2.EnvironmentRepository) (*CveExceptionServiceImpl, error) {
+	config := &bean.CveExceptionConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing cve exception config", "err", err)
+		return nil, err
+	}
+	return &CveExceptionServiceImpl{
+		logger:                 logger,
+		cveExceptionRepository: cveExceptionRepository,
+		appRepository:          appRepository,
+		environmentRepository:  environmentRepository,
+		config:                 config,
+	}, nil
+}
+
+func (impl *CveExceptionServiceImpl) CreateException(exception *bean.CveExceptionDto) (*bean.CveExceptionDto, error) {
+	err := validateException(exception, impl.config.CveExceptionMaxDays, time.Now())
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	dbObject := toExceptionDbObject(exception)
+	tx, err := impl.cveExceptionRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.cveExceptionRepository.RollbackTx(tx)
+	err = impl.cveExceptionRepository.Save(tx, dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in saving cve exception", "cveName", exception.CveName, "err", err)
+		return nil, err
+	}
+	err = impl.cveExceptionRepository.SaveAudit(tx, toAuditDbObject(dbObject.Id, bean.ExceptionActionRequested, exception.Justification, exception.UserId))
+	if err != nil {
+		impl.logger.Errorw("error in saving cve exception audit", "exceptionId", dbObject.Id, "err", err)
+		return nil, err
+	}
+	err = impl.cveExceptionRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	return impl.toExceptionDtos([]*repository.CveException{dbObject})[0], nil
+}
+
+func (impl *CveExceptionServiceImpl) ApproveException(request *bean.ExceptionReviewRequest) (*bean.CveExceptionDto, error) {
+	return impl.reviewException(request, true)
+}
+
+func (impl *CveExceptionServiceImpl) RejectException(request *bean.ExceptionReviewRequest) (*bean.CveExceptionDto, error) {
+	return impl.reviewException(request, false)
+}
+
+func (impl *CveExceptionServiceImpl) reviewException(request *bean.ExceptionReviewRequest, approve bool) (*bean.CveExceptionDto, error) {
+	exception, err := impl.cveExceptionRepository.FindById(request.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cve exception", "id", request.Id, "err", err)
+		return nil, err
+	}
+	now := time.Now()
+	err = validateReview(exception, request.UserId, approve, now)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	status, action := bean.ExceptionStatusRejected, bean.ExceptionActionRejected
+	if approve {
+		status, action = bean.ExceptionStatusApproved, bean.ExceptionActionApproved
+	}
+	exception.Status = status
+	exception.ReviewedBy = request.UserId
+	exception.ReviewedOn = &now
+	err = impl.updateException(exception, action, request.Comment, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return impl.toExceptionDtos([]*repository.CveException{exception})[0], nil
+}
+
+func (impl *CveExceptionServiceImpl) RevokeException(request *bean.ExceptionReviewRequest) (*bean.CveExceptionDto, error) {
+	exception, err := impl.cveExceptionRepository.FindById(request.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cve exception", "id", request.Id, "err", err)
+		return nil, err
+	}
+	err = validateRevoke(exception)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	exception.Status = bean.ExceptionStatusRevoked
+	err = impl.updateException(exception, bean.ExceptionActionRevoked, request.Comment, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return impl.toExceptionDtos([]*repository.CveException{exception})[0], nil
+}
+
+// updateException saves the exception along with the audit of the action
+func (impl *CveExceptionServiceImpl) updateException(exception *repository.CveException, action bean.ExceptionAction, comment string, userId int32) error {
+	tx, err := impl.cveExceptionRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.cveExceptionRepository.RollbackTx(tx)
+	exception.UpdateAuditLog(userId)
+	err = impl.cveExceptionRepository.Update(tx, exception)
+	if err != nil {
+		impl.logger.Errorw("error in updating cve exception", "id", exception.Id, "err", err)
+		return err
+	}
+	err = impl.cveExceptionRepository.SaveAudit(tx, toAuditDbObject(exception.Id, action, comment, userId))
+	if err != nil {
+		impl.logger.Errorw("error in saving cve exception audit", "exceptionId", exception.Id, "err", err)
+		return err
+	}
+	err = impl.cveExceptionRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *CveExceptionServiceImpl) GetException(id int) (*bean.CveExceptionDetailDto, error) {
+	exception, err := impl.cveExceptionRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cve exception", "id", id, "err", err)
+		return nil, err
+	}
+	audits, err := impl.cveExceptionRepository.FindAuditsByExceptionId(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cve exception audits", "id", id, "err", err)
+		return nil, err
+	}
+	history := make([]*bean.ExceptionAuditDto, 0, len(audits))
+	for _, audit := range audits {
+		history = append(history, toAuditDto(audit))
+	}
+	return &bean.CveExceptionDetailDto{
+		CveExceptionDto: impl.toExceptionDtos([]*repository.CveException{exception})[0],
+		History:         history,
+	}, nil
+}
+
+func (impl *CveExceptionServiceImpl) GetExceptions(filter *bean.ExceptionFilter) ([]*bean.CveExceptionDto, error) {
+	exceptions, err := impl.cveExceptionRepository.FindAll(filter)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cve exceptions", "filter", filter, "err", err)
+		return nil, err
+	}
+	return impl.toExceptionDtos(exceptions), nil
+}
+
+func (impl *CveExceptionServiceImpl) GetExpiringExceptions(days int) ([]*bean.CveExceptionDto, error) {
+	if days <= 0 {
+		days = impl.config.CveExceptionExpiringSoonDays
+	}
+	now := time.Now()
+	exceptions, err := impl.cveExceptionRepository.FindExpiringBetween(now, now.AddDate(0, 0, days))
+	if err != nil {
+		impl.logger.Errorw("error in fetching expiring cve exceptions", "days", days, "err", err)
+		return nil, err
+	}
+	return impl.toExceptionDtos(exceptions), nil
+}
+
+func (impl *CveExceptionServiceImpl) GetAllowedCves(appId, envId int) (map[string]bool, error) {
+	allowedCves := make(map[string]bool)
+	if appId == 0 && envId == 0 {
+		return allowedCves, nil
+	}
+	exceptions, err := impl.cveExceptionRepository.FindApplicable(appId, envId, time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in fetching applicable cve exceptions", "appId", appId, "envId", envId, "err", err)
+		return nil, err
+	}
+	for _, exception := range exceptions {
+		allowedCves[exception.CveName] = true
+	}
+	return allowedCves, nil
+}
+
+func (impl *CveExceptionServiceImpl) ExpireExceptions() {
+	exceptions, err := impl.cveExceptionRepository.FindExpiringBetween(time.Time{}, time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in fetching expired cve exceptions", "err", err)
+		return
+	}
+	for _, exception := range exceptions {
+		exception.Status = bean.ExceptionStatusExpired
+		err = impl.updateException(exception, bean.ExceptionActionExpired, "", userBean.SystemUserId)
+		if err != nil {
+			impl.logger.Errorw("error in expiring cve exception", "id", exception.Id, "err", err)
+		}
+	}
+}
+
+// toExceptionDtos converts the exceptions, filling the names of their apps and environments
+func (impl *CveExceptionServiceImpl) toExceptionDtos(exceptions []*repository.CveException) []*bean.CveExceptionDto {
+	now := time.Now()
+	appIds := make([]*int, 0)
+	envIds := make([]*int, 0)
+	for _, exception := range exceptions {
+		if exception.AppId > 0 {
+			appIds = append(appIds, &exception.AppId)
+		}
+		if exception.EnvId > 0 {
+			envIds = append(envIds, &exception.EnvId)
+		}
+	}
+	appNames := make(map[int]string)
+	if len(appIds) > 0 {
+		apps, err := impl.appRepository.FindByIds(appIds)
+		if err != nil {
+			// names are informational, the exceptions are returned without them
+			impl.logger.Errorw("error in fetching apps of cve exceptions", "err", err)
+		}
+		for _, item := range apps {
+			appNames[item.Id] = item.AppName
+		}
+	}
+	envNames := make(map[int]string)
+	if len(envIds) > 0 {
+		envs, err := impl.environmentRepository.FindByIds(envIds)
+		if err != nil {
+			impl.logger.Errorw("error in fetching environments of cve exceptions", "err", err)
+		}
+		for _, item := range envs {
+			envNames[item.Id] = item.Name
+		}
+	}
+	dtos := make([]*bean.CveExceptionDto, 0, len(exceptions))
+	for _, exception := range exceptions {
+		dto := toExceptionDto(exception, now)
+		dto.AppName = appNames[exception.AppId]
+		dto.EnvName = envNames[exception.EnvId]
+		dtos = append(dtos, dto)
+	}
+	return dtos
+}
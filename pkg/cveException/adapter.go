@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cveException
+
+import (
+	"github.com/devtron-labs/devtron/pkg/cveException/bean"
+	"github.com/devtron-labs/devtron/pkg/cveException/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"time"
+)
+
+func toExceptionDbObject(dto *bean.CveExceptionDto) *repository.CveException {
+	return &repository.CveException{
+		CveName:       dto.CveName,
+		AppId:         dto.AppId,
+		EnvId:         dto.EnvId,
+		Justification: dto.Justification,
+		ExpiresOn:     dto.ExpiresOn,
+		Status:        bean.ExceptionStatusPending,
+		Active:        true,
+		AuditLog:      sql.NewDefaultAuditLog(dto.UserId),
+	}
+}
+
+func toExceptionDto(exception *repository.CveException, now time.Time) *bean.CveExceptionDto {
+	return &bean.CveExceptionDto{
+		Id:            exception.Id,
+		CveName:       exception.CveName,
+		AppId:         exception.AppId,
+		EnvId:         exception.EnvId,
+		Justification: exception.Justification,
+		ExpiresOn:     exception.ExpiresOn,
+		Status:        getEffectiveStatus(exception, now),
+		RequestedBy:   exception.CreatedBy,
+		RequestedOn:   exception.CreatedOn,
+		ReviewedBy:    exception.ReviewedBy,
+		ReviewedOn:    exception.ReviewedOn,
+	}
+}
+
+func toAuditDbObject(exceptionId int, action bean.ExceptionAction, comment string, userId int32) *repository.CveExceptionAudit {
+	return &repository.CveExceptionAudit{
+		ExceptionId: exceptionId,
+		Action:      action,
+		Comment:     comment,
+		ActionBy:    userId,
+		ActionOn:    time.Now(),
+	}
+}
+
+func toAuditDto(audit *repository.CveExceptionAudit) *bean.ExceptionAuditDto {
+	return &bean.ExceptionAuditDto{
+		Action:   audit.Action,
+		Comment:  audit.Comment,
+		ActionBy: audit.ActionBy,
+		ActionOn: audit.ActionOn,
+	}
+}
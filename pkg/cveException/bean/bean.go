@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type ExceptionStatus string
+
+const (
+	ExceptionStatusPending  ExceptionStatus = "PENDING"
+	ExceptionStatusApproved ExceptionStatus = "APPROVED"
+	ExceptionStatusRejected ExceptionStatus = "REJECTED"
+	ExceptionStatusRevoked  ExceptionStatus = "REVOKED"
+	ExceptionStatusExpired  ExceptionStatus = "EXPIRED"
+)
+
+type ExceptionAction string
+
+const (
+	ExceptionActionRequested ExceptionAction = "REQUESTED"
+	ExceptionActionApproved  ExceptionAction = "APPROVED"
+	ExceptionActionRejected  ExceptionAction = "REJECTED"
+	ExceptionActionRevoked   ExceptionAction = "REVOKED"
+	ExceptionActionExpired   ExceptionAction = "EXPIRED"
+)
+
+const (
+	InvalidExceptionScope    = "an exception needs an app or an environment"
+	InvalidExpiry            = "expiry must be in the future and within %d days"
+	ExceptionNotPending      = "exception %d is %s, only pending exceptions can be approved or rejected"
+	ExceptionNotApproved     = "exception %d is %s, only approved exceptions can be revoked"
+	SelfApprovalNotAllowed   = "an exception cannot be approved or rejected by the user who requested it"
+	ExceptionExpiredOnReview = "exception %d expired before it was approved"
+)
+
+// CveExceptionDto allows a cve on an app, an environment or an app in an environment until it expires, overriding
+// the cve and severity policies there. An exception is enforced once approved, by someone other than the requester
+type CveExceptionDto struct {
+	Id            int             `json:"id"`
+	CveName       string          `json:"cveName" validate:"required,max=50"`
+	AppId         int             `json:"appId,omitempty"`
+	AppName       string          `json:"appName,omitempty"`
+	EnvId         int             `json:"envId,omitempty"`
+	EnvName       string          `json:"envName,omitempty"`
+	Justification string          `json:"justification" validate:"required,max=500"`
+	ExpiresOn     time.Time       `json:"expiresOn" validate:"required"`
+	Status        ExceptionStatus `json:"status"`
+	RequestedBy   int32           `json:"requestedBy"`
+	RequestedOn   time.Time       `json:"requestedOn"`
+	ReviewedBy    int32           `json:"reviewedBy,omitempty"`
+	ReviewedOn    *time.Time      `json:"reviewedOn,omitempty"`
+	UserId        int32           `json:"-"`
+}
+
+// ExceptionReviewRequest approves, rejects or revokes an exception
+type ExceptionReviewRequest struct {
+	Id      int    `json:"-"`
+	Comment string `json:"comment" validate:"max=500"`
+	UserId  int32  `json:"-"`
+}
+
+type ExceptionFilter struct {
+	CveName string
+	AppId   int
+	EnvId   int
+	Status  ExceptionStatus
+}
+
+type ExceptionAuditDto struct {
+	Action   ExceptionAction `json:"action"`
+	Comment  string          `json:"comment,omitempty"`
+	ActionBy int32           `json:"actionBy"`
+	ActionOn time.Time       `json:"actionOn"`
+}
+
+type CveExceptionDetailDto struct {
+	*CveExceptionDto
+	History []*ExceptionAuditDto `json:"history"`
+}
+
+type CveExceptionConfig struct {
+	// CveExceptionMaxDays caps how far in the future an exception can expire
+	CveExceptionMaxDays int `env:"CVE_EXCEPTION_MAX_DAYS" envDefault:"90"`
+	// CveExceptionExpiringSoonDays is the default window of the expiring soon report
+	CveExceptionExpiringSoonDays int `env:"CVE_EXCEPTION_EXPIRING_SOON_DAYS" envDefault:"14"`
+}
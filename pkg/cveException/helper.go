@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cveException
+
+import (
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/cveException/bean"
+	"github.com/devtron-labs/devtron/pkg/cveException/repository"
+	"strings"
+	"time"
+)
+
+func validateException(exception *bean.CveExceptionDto, maxDays int, now time.Time) error {
+	exception.CveName = strings.TrimSpace(exception.CveName)
+	if len(exception.CveName) == 0 {
+		return errors.New("cve name is required")
+	}
+	if exception.AppId == 0 && exception.EnvId == 0 {
+		return errors.New(bean.InvalidExceptionScope)
+	}
+	if !exception.ExpiresOn.After(now) || exception.ExpiresOn.After(now.AddDate(0, 0, maxDays)) {
+		return fmt.Errorf(bean.InvalidExpiry, maxDays)
+	}
+	return nil
+}
+
+// validateReview checks that a pending exception can be approved or rejected by the reviewer, an approval also needs
+// the exception to not have expired while pending
+func validateReview(exception *repository.CveException, reviewerId int32, approve bool, now time.Time) error {
+	if exception.Status != bean.ExceptionStatusPending {
+		return fmt.Errorf(bean.ExceptionNotPending, exception.Id, exception.Status)
+	}
+	if exception.CreatedBy == reviewerId {
+		return errors.New(bean.SelfApprovalNotAllowed)
+	}
+	if approve && !exception.ExpiresOn.After(now) {
+		return fmt.Errorf(bean.ExceptionExpiredOnReview, exception.Id)
+	}
+	return nil
+}
+
+func validateRevoke(exception *repository.CveException) error {
+	if exception.Status != bean.ExceptionStatusApproved {
+		return fmt.Errorf(bean.ExceptionNotApproved, exception.Id, exception.Status)
+	}
+	return nil
+}
+
+// getEffectiveStatus reports approved exceptions past their expiry as expired before the cron marks them
+func getEffectiveStatus(exception *repository.CveException, now time.Time) bean.ExceptionStatus {
+	if exception.Status == bean.ExceptionStatusApproved && !exception.ExpiresOn.After(now) {
+		return bean.ExceptionStatusExpired
+	}
+	return exception.Status
+}
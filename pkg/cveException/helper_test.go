@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cveException
+
+import (
+	"github.com/devtron-labs/devtron/pkg/cveException/bean"
+	"github.com/devtron-labs/devtron/pkg/cveException/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestValidateException(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	exception := &bean.CveExceptionDto{CveName: " CVE-2024-1234 ", AppId: 1, EnvId: 2, ExpiresOn: now.AddDate(0, 0, 30)}
+	assert.Nil(t, validateException(exception, 90, now))
+	assert.Equal(t, "CVE-2024-1234", exception.CveName)
+
+	noScope := &bean.CveExceptionDto{CveName: "CVE-2024-1234", ExpiresOn: now.AddDate(0, 0, 30)}
+	assert.EqualError(t, validateException(noScope, 90, now), bean.InvalidExceptionScope)
+
+	past := &bean.CveExceptionDto{CveName: "CVE-2024-1234", EnvId: 2, ExpiresOn: now.AddDate(0, 0, -1)}
+	assert.NotNil(t, validateException(past, 90, now))
+
+	tooLong := &bean.CveExceptionDto{CveName: "CVE-2024-1234", AppId: 1, ExpiresOn: now.AddDate(0, 0, 91)}
+	assert.NotNil(t, validateException(tooLong, 90, now))
+}
+
+func TestValidateReview(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	exception := &repository.CveException{Id: 1, Status: bean.ExceptionStatusPending, ExpiresOn: now.AddDate(0, 0, 10), AuditLog: sql.AuditLog{CreatedBy: 5}}
+	assert.Nil(t, validateReview(exception, 6, true, now))
+	assert.EqualError(t, validateReview(exception, 5, true, now), bean.SelfApprovalNotAllowed)
+	// an exception which expired while pending can still be rejected
+	assert.NotNil(t, validateReview(exception, 6, true, now.AddDate(0, 0, 11)))
+	assert.Nil(t, validateReview(exception, 6, false, now.AddDate(0, 0, 11)))
+
+	exception.Status = bean.ExceptionStatusApproved
+	assert.NotNil(t, validateReview(exception, 6, false, now))
+	assert.Nil(t, validateRevoke(exception))
+	assert.Equal(t, bean.ExceptionStatusApproved, getEffectiveStatus(exception, now))
+	assert.Equal(t, bean.ExceptionStatusExpired, getEffectiveStatus(exception, now.AddDate(0, 0, 10)))
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/cveException/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type CveException struct {
+	tableName     struct{}             `sql:"cve_exception" pg:",discard_unknown_columns"`
+	Id            int                  `sql:"id,pk"`
+	CveName       string               `sql:"cve_name,notnull"`
+	AppId         int                  `sql:"app_id"`
+	EnvId         int                  `sql:"env_id"`
+	Justification string               `sql:"justification,notnull"`
+	ExpiresOn     time.Time            `sql:"expires_on,notnull"`
+	Status        bean.ExceptionStatus `sql:"status,notnull"`
+	ReviewedBy    int32                `sql:"reviewed_by"`
+	ReviewedOn    *time.Time           `sql:"reviewed_on"`
+	Active        bool                 `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// CveExceptionAudit is the trail of the requests, reviews and expiry of an exception
+type CveExceptionAudit struct {
+	tableName   struct{}             `sql:"cve_exception_audit" pg:",discard_unknown_columns"`
+	Id          int                  `sql:"id,pk"`
+	ExceptionId int                  `sql:"exception_id,notnull"`
+	Action      bean.ExceptionAction `sql:"action,notnull"`
+	Comment     string               `sql:"comment"`
+	ActionBy    int32                `sql:"action_by,notnull"`
+	ActionOn    time.Time            `sql:"action_on,notnull"`
+}
+
+type CveExceptionRepository interface {
+	//transaction util funcs
+	sql.TransactionWrapper
+	Save(tx *pg.Tx, exception *CveException) error
+	Update(tx *pg.Tx, exception *CveException) error
+	FindById(id int) (*CveException, error)
+	FindAll(filter *bean.ExceptionFilter) ([]*CveException, error)
+	// FindApplicable returns the approved exceptions not expired at the time on the app or the environment
+	FindApplicable(appId, envId int, at time.Time) ([]*CveException, error)
+	// FindExpiringBetween returns the approved exceptions expiring after from until to, the earliest first
+	FindExpiringBetween(from, to time.Time) ([]*CveException, error)
+	SaveAudit(tx *pg.Tx, audit *CveExceptionAudit) error
+	FindAuditsByExceptionId(exceptionId int) ([]*CveExceptionAudit, error)
+}
+
+type CveExceptionRepositoryImpl struct {
+	*sql.TransactionUtilImpl
+	dbConnection *pg.DB
+}
+
+func NewCveExceptionRepositoryImpl(dbConnection *pg.DB, TransactionUtilImpl *sql.TransactionUtilImpl) *CveExceptionRepositoryImpl {
+	return &CveExceptionRepositoryImpl{
+		dbConnection:        dbConnection,
+		TransactionUtilImpl: TransactionUtilImpl,
+	}
+}
+
+func (impl CveExceptionRepositoryImpl) Save(tx *pg.Tx, exception *CveException) error {
+	return tx.Insert(exception)
+}
+
+func (impl CveExceptionRepositoryImpl) Update(tx *pg.Tx, exception *CveException) error {
+	return tx.Update(exception)
+}
+
+func (impl CveExceptionRepositoryImpl) FindById(id int) (*CveException, error) {
+	exception := &CveException{}
+	err := impl.dbConnection.Model(exception).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return exception, err
+}
+
+func (impl CveExceptionRepositoryImpl) FindAll(filter *bean.ExceptionFilter) ([]*CveException, error) {
+	exceptions := make([]*CveException, 0)
+	query := impl.dbConnection.Model(&exceptions).
+		Where("active = ?", true)
+	if len(filter.CveName) > 0 {
+		query = query.Where("cve_name = ?", filter.CveName)
+	}
+	if filter.AppId > 0 {
+		query = query.Where("app_id = ?", filter.AppId)
+	}
+	if filter.EnvId > 0 {
+		query = query.Where("env_id = ?", filter.EnvId)
+	}
+	if len(filter.Status) > 0 {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("id DESC").Select()
+	return exceptions, err
+}
+
+func (impl CveExceptionRepositoryImpl) FindApplicable(appId, envId int, at time.Time) ([]*CveException, error) {
+	exceptions := make([]*CveException, 0)
+	err := impl.dbConnection.Model(&exceptions).
+		Where("active = ?", true).
+		Where("status = ?", bean.ExceptionStatusApproved).
+		Where("expires_on > ?", at).
+		Where("COALESCE(app_id, 0) IN (0, ?)", appId).
+		Where("COALESCE(env_id, 0) IN (0, ?)", envId).
+		Select()
+	return exceptions, err
+}
+
+func (impl CveExceptionRepositoryImpl) FindExpiringBetween(from, to time.Time) ([]*CveException, error) {
+	exceptions := make([]*CveException, 0)
+	err := impl.dbConnection.Model(&exceptions).
+		Where("active = ?", true).
+		Where("status = ?", bean.ExceptionStatusApproved).
+		Where("expires_on > ?", from).
+		Where("expires_on <= ?", to).
+		Order("expires_on ASC").
+		Select()
+	return exceptions, err
+}
+
+func (impl CveExceptionRepositoryImpl) SaveAudit(tx *pg.Tx, audit *CveExceptionAudit) error {
+	return tx.Insert(audit)
+}
+
+func (impl CveExceptionRepositoryImpl) FindAuditsByExceptionId(exceptionId int) ([]*CveExceptionAudit, error) {
+	audits := make([]*CveExceptionAudit, 0)
+	err := impl.dbConnection.Model(&audits).
+		Where("exception_id = ?", exceptionId).
+		Order("id ASC").
+		Select()
+	return audits, err
+}
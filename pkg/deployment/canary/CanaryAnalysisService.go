@@ -0,0 +1,496 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canary
+
+import (
+	"context"
+	"fmt"
+	k8sUtil "github.com/devtron-labs/common-lib/utils/k8s"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	clusterRepository "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/canary/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/rollback"
+	"github.com/devtron-labs/devtron/pkg/k8s"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"slices"
+	"time"
+)
+
+type CanaryAnalysisService interface {
+	SaveConfig(config *CanaryAnalysisConfigDto) (*CanaryAnalysisConfigDto, error)
+	GetConfig(appId, pipelineId int) (*CanaryAnalysisConfigDto, error)
+	DeleteConfig(pipelineId int, userId int32) error
+
+	IsAnalysisEnabled(pipelineId int) (bool, error)
+	// HandleDeploymentSuccess starts the analysis of the release if it has not been started yet, and returns true once
+	// the release is promoted. True is returned only once per release, post deployment stages are triggered on it
+	HandleDeploymentSuccess(pipelineOverride *chartConfig.PipelineOverride) (bool, error)
+	// ProcessAnalyses starts the analysis of the releases in progress and moves every running analysis to its next
+	// step once the duration of its current step is over. The canary weight of the rollout is set at every step and
+	// a failing step rolls the pipeline back to its last healthy release. It returns the releases it promoted which
+	// are already deployed, their post deployment stages are left to the caller
+	ProcessAnalyses() []*chartConfig.PipelineOverride
+}
+
+type CanaryAnalysisServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	canaryAnalysisConfigRepository repository.CanaryAnalysisConfigRepository
+	canaryAnalysisRunRepository    repository.CanaryAnalysisRunRepository
+	pipelineRepository             pipelineConfig.PipelineRepository
+	pipelineOverrideRepository     chartConfig.PipelineOverrideRepository
+	cdWorkflowRepository           pipelineConfig.CdWorkflowRepository
+	clusterRepository              clusterRepository.ClusterRepository
+	pipelineStatusTimelineService  status.PipelineStatusTimelineService
+	deploymentRollbackService      rollback.DeploymentRollbackService
+	k8sCommonService               k8s.K8sCommonService
+	k8sUtil                        *k8sUtil.K8sServiceImpl
+}
+
+func NewCanaryAnalysisServiceImpl(logger *zap.SugaredLogger,
+	canaryAnalysisConfigRepository repository.CanaryAnalysisConfigRepository,
+	canaryAnalysisRunRepository repository.CanaryAnalysisRunRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	clusterRepository clusterRepository.ClusterRepository,
+	pipelineStatusTimelineService status.PipelineStatusTimelineService,
+	deploymentRollbackService rollback.DeploymentRollbackService,
+	k8sCommonService k8s.K8sCommonService,
+	k8sUtil *k8sUtil.K8sServiceImpl) *CanaryAnalysisServiceImpl {
+	return &CanaryAnalysisServiceImpl{
+		logger:                         logger,
+		canaryAnalysisConfigRepository: canaryAnalysisConfigRepository,
+		canaryAnalysisRunRepository:    canaryAnalysisRunRepository,
+		pipelineRepository:             pipelineRepository,
+		pipelineOverrideRepository:     pipelineOverrideRepository,
+		cdWorkflowRepository:           cdWorkflowRepository,
+		clusterRepository:              clusterRepository,
+		pipelineStatusTimelineService:  pipelineStatusTimelineService,
+		deploymentRollbackService:      deploymentRollbackService,
+		k8sCommonService:               k8sCommonService,
+		k8sUtil:                        k8sUtil,
+	}
+}
+
+func (impl *CanaryAnalysisServiceImpl) SaveConfig(config *CanaryAnalysisConfigDto) (*CanaryAnalysisConfigDto, error) {
+	err := validateConfig(config)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	pipeline, err := impl.pipelineRepository.FindById(config.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", config.PipelineId, "err", err)
+		return nil, err
+	}
+	if pipeline.AppId != config.AppId {
+		errMsg := fmt.Sprintf("pipeline %d does not belong to app %d", config.PipelineId, config.AppId)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	existing, err := impl.canaryAnalysisConfigRepository.FindByPipelineId(config.PipelineId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching canary analysis config", "pipelineId", config.PipelineId, "err", err)
+		return nil, err
+	}
+	dbObject, err := toCanaryAnalysisConfigDbObject(config)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Id > 0 {
+		dbObject.Id = existing.Id
+		dbObject.CreatedOn = existing.CreatedOn
+		dbObject.CreatedBy = existing.CreatedBy
+		err = impl.canaryAnalysisConfigRepository.Update(dbObject)
+	} else {
+		err = impl.canaryAnalysisConfigRepository.Save(dbObject)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving canary analysis config", "pipelineId", config.PipelineId, "err", err)
+		return nil, err
+	}
+	config.Id = dbObject.Id
+	return config, nil
+}
+
+func (impl *CanaryAnalysisServiceImpl) GetConfig(appId, pipelineId int) (*CanaryAnalysisConfigDto, error) {
+	config, err := impl.canaryAnalysisConfigRepository.FindByPipelineId(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching canary analysis config", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return toCanaryAnalysisConfigDto(config, appId)
+}
+
+func (impl *CanaryAnalysisServiceImpl) DeleteConfig(pipelineId int, userId int32) error {
+	config, err := impl.canaryAnalysisConfigRepository.FindByPipelineId(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching canary analysis config", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	config.Active = false
+	config.UpdateAuditLog(userId)
+	return impl.canaryAnalysisConfigRepository.Update(config)
+}
+
+func (impl *CanaryAnalysisServiceImpl) IsAnalysisEnabled(pipelineId int) (bool, error) {
+	config, err := impl.canaryAnalysisConfigRepository.FindByPipelineId(pipelineId)
+	if util.IsErrNoRows(err) {
+		return false, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching canary analysis config", "pipelineId", pipelineId, "err", err)
+		return false, err
+	}
+	return config.Enabled, nil
+}
+
+func (impl *CanaryAnalysisServiceImpl) HandleDeploymentSuccess(pipelineOverride *chartConfig.PipelineOverride) (bool, error) {
+	runner, err := impl.cdWorkflowRepository.FindByWorkflowIdAndRunnerType(context.Background(), pipelineOverride.CdWorkflowId, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deploy runner", "cdWorkflowId", pipelineOverride.CdWorkflowId, "err", err)
+		return false, err
+	}
+	if runner.IsAutoRollback() {
+		// the rollback target was healthy before, analysing it again could roll back in a loop
+		return true, nil
+	}
+	run, err := impl.canaryAnalysisRunRepository.FindByWfrId(runner.Id)
+	if util.IsErrNoRows(err) {
+		// the release got deployed before the analysis was started, the analysis still gates its post deployment stages
+		return false, impl.startAnalysis(&runner, pipelineOverride)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching canary analysis run", "wfrId", runner.Id, "err", err)
+		return false, err
+	}
+	// post deployment stages are triggered by either this or the promotion of the release, whichever is the later
+	return impl.canaryAnalysisRunRepository.MarkPostStagesTriggered(run.Id, userBean.SystemUserId)
+}
+
+func (impl *CanaryAnalysisServiceImpl) ProcessAnalyses() []*chartConfig.PipelineOverride {
+	wfrIds, err := impl.canaryAnalysisRunRepository.FindUnanalysedRunnerIds()
+	if err != nil {
+		impl.logger.Errorw("error in fetching deploy runners to analyse", "err", err)
+	}
+	for _, wfrId := range wfrIds {
+		err = impl.startAnalysisOfRunner(wfrId)
+		if err != nil {
+			impl.logger.Errorw("error in starting canary analysis", "wfrId", wfrId, "err", err)
+		}
+	}
+	runs, err := impl.canaryAnalysisRunRepository.FindAllRunning()
+	if err != nil {
+		impl.logger.Errorw("error in fetching running canary analyses", "err", err)
+		return nil
+	}
+	promotedReleases := make([]*chartConfig.PipelineOverride, 0)
+	for _, run := range runs {
+		runner, err := impl.processRun(run)
+		if err != nil {
+			impl.logger.Errorw("error in processing canary analysis", "runId", run.Id, "wfrId", run.CdWorkflowRunnerId, "err", err)
+			continue
+		}
+		if run.Status != repository.CanaryAnalysisPromoted || !slices.Contains(cdWorkflow.WfrHealthyStatusList, runner.Status) {
+			// a release promoted before it is deployed has its post deployment stages triggered on its success event
+			continue
+		}
+		pipelineOverride, err := impl.pipelineOverrideRepository.FindById(run.PipelineOverrideId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching pipeline override", "pipelineOverrideId", run.PipelineOverrideId, "err", err)
+			continue
+		}
+		promotedReleases = append(promotedReleases, pipelineOverride)
+	}
+	return promotedReleases
+}
+
+func (impl *CanaryAnalysisServiceImpl) startAnalysisOfRunner(wfrId int) error {
+	runner, err := impl.cdWorkflowRepository.FindWorkflowRunnerById(wfrId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deploy runner", "wfrId", wfrId, "err", err)
+		return err
+	}
+	if runner.IsAutoRollback() {
+		return nil
+	}
+	isLatest, err := impl.cdWorkflowRepository.IsLatestCDWfr(runner.CdWorkflow.PipelineId, runner.Id)
+	if err != nil || !isLatest {
+		return err
+	}
+	pipelineOverride, err := impl.pipelineOverrideRepository.FindLatestByCdWorkflowId(runner.CdWorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline override", "cdWorkflowId", runner.CdWorkflowId, "err", err)
+		return err
+	}
+	return impl.startAnalysis(runner, pipelineOverride)
+}
+
+// startAnalysis saves the run of the analysis of the release and shifts the traffic of its first step to it
+func (impl *CanaryAnalysisServiceImpl) startAnalysis(runner *pipelineConfig.CdWorkflowRunner, pipelineOverride *chartConfig.PipelineOverride) error {
+	config, err := impl.getConfig(pipelineOverride.PipelineId)
+	if err != nil {
+		return err
+	}
+	pipeline, err := impl.pipelineRepository.FindById(pipelineOverride.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", pipelineOverride.PipelineId, "err", err)
+		return err
+	}
+	run := &repository.CanaryAnalysisRun{
+		PipelineId:         pipelineOverride.PipelineId,
+		CdWorkflowRunnerId: runner.Id,
+		PipelineOverrideId: pipelineOverride.Id,
+		CurrentStep:        0,
+		StepStartedOn:      time.Now(),
+		Status:             repository.CanaryAnalysisRunning,
+		AuditLog:           sql.NewDefaultAuditLog(userBean.SystemUserId),
+	}
+	err = impl.canaryAnalysisRunRepository.Save(run)
+	if err != nil {
+		if _, findErr := impl.canaryAnalysisRunRepository.FindByWfrId(runner.Id); findErr == nil {
+			// started concurrently
+			return nil
+		}
+		impl.logger.Errorw("error in saving canary analysis run", "wfrId", runner.Id, "err", err)
+		return err
+	}
+	impl.saveTimeline(runner.Id, timelineStatus.TIMELINE_STATUS_CANARY_ANALYSIS_STARTED, timelineStatus.TIMELINE_DESCRIPTION_CANARY_ANALYSIS_STARTED)
+	err = impl.shiftTraffic(pipeline, config.Steps[0].Weight, true)
+	if err != nil {
+		impl.failAnalysis(run, runner, pipeline, fmt.Sprintf("Canary analysis could not be started: %s", err.Error()))
+		return nil
+	}
+	impl.saveTimeline(runner.Id, timelineStatus.TIMELINE_STATUS_CANARY_STEP_STARTED, fmt.Sprintf("Canary step 1/%d started at %d%% traffic.", len(config.Steps), config.Steps[0].Weight))
+	return nil
+}
+
+// processRun evaluates the current step of the run once its duration is over and moves the run ahead accordingly
+func (impl *CanaryAnalysisServiceImpl) processRun(run *repository.CanaryAnalysisRun) (*pipelineConfig.CdWorkflowRunner, error) {
+	runner, err := impl.cdWorkflowRepository.FindWorkflowRunnerById(run.CdWorkflowRunnerId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deploy runner", "wfrId", run.CdWorkflowRunnerId, "err", err)
+		return nil, err
+	}
+	isLatest, err := impl.cdWorkflowRepository.IsLatestCDWfr(run.PipelineId, runner.Id)
+	if err != nil {
+		impl.logger.Errorw("error in checking latest deploy runner", "pipelineId", run.PipelineId, "wfrId", runner.Id, "err", err)
+		return nil, err
+	}
+	if !isLatest {
+		// the traffic is left to the analysis of the release superseding this one
+		impl.logger.Infow("stopping canary analysis, release is superseded", "pipelineId", run.PipelineId, "wfrId", runner.Id)
+		return runner, impl.updateRun(run, repository.CanaryAnalysisAborted, "release superseded")
+	}
+	pipeline, err := impl.pipelineRepository.FindById(run.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", run.PipelineId, "err", err)
+		return nil, err
+	}
+	config, err := impl.getConfig(run.PipelineId)
+	if util.IsErrNoRows(err) || (err == nil && !config.Enabled) || (err == nil && run.CurrentStep >= len(config.Steps)) {
+		// the analysis got disabled in between
+		return runner, impl.promote(run, runner, pipeline)
+	} else if err != nil {
+		return nil, err
+	}
+	step := config.Steps[run.CurrentStep]
+	if time.Since(run.StepStartedOn) < time.Duration(step.DurationSeconds)*time.Second {
+		return runner, nil
+	}
+	promApi, err := impl.getPrometheusApi(pipeline.Environment.ClusterId)
+	if err != nil {
+		impl.logger.Errorw("error in creating prometheus client for canary analysis", "pipelineId", run.PipelineId, "err", err)
+		impl.failAnalysis(run, runner, pipeline, fmt.Sprintf("Canary analysis could not be run: %s", err.Error()))
+		return runner, nil
+	}
+	release := &ReleaseIdentifier{
+		Namespace:   pipeline.Environment.Namespace,
+		ReleaseName: pipeline.DeploymentAppName,
+		AppName:     pipeline.App.AppName,
+		EnvName:     pipeline.Environment.Name,
+	}
+	results := impl.evaluateMetrics(promApi, config.Metrics, release)
+	failedMetrics := getFailedMetricsMessage(results)
+	if len(failedMetrics) > 0 {
+		impl.failAnalysis(run, runner, pipeline, fmt.Sprintf("Canary analysis failed at step %d/%d (%d%% traffic): %s", run.CurrentStep+1, len(config.Steps), step.Weight, failedMetrics))
+		return runner, nil
+	}
+	impl.saveTimeline(runner.Id, timelineStatus.TIMELINE_STATUS_CANARY_STEP_PASSED,
+		fmt.Sprintf("Canary step %d/%d at %d%% traffic passed.", run.CurrentStep+1, len(config.Steps), step.Weight))
+	if run.CurrentStep == len(config.Steps)-1 {
+		return runner, impl.promote(run, runner, pipeline)
+	}
+	nextStep := config.Steps[run.CurrentStep+1]
+	// traffic is shifted before the progress is saved, a restart in between evaluates the step again
+	err = impl.shiftTraffic(pipeline, nextStep.Weight, true)
+	if err != nil {
+		return nil, err
+	}
+	run.CurrentStep++
+	run.StepStartedOn = time.Now()
+	err = impl.updateRun(run, repository.CanaryAnalysisRunning, "")
+	if err != nil {
+		return nil, err
+	}
+	impl.saveTimeline(runner.Id, timelineStatus.TIMELINE_STATUS_CANARY_STEP_STARTED,
+		fmt.Sprintf("Canary step %d/%d started at %d%% traffic.", run.CurrentStep+1, len(config.Steps), nextStep.Weight))
+	return runner, nil
+}
+
+// promote shifts all the traffic to the release, the run stays running to be retried if that fails
+func (impl *CanaryAnalysisServiceImpl) promote(run *repository.CanaryAnalysisRun, runner *pipelineConfig.CdWorkflowRunner, pipeline *pipelineConfig.Pipeline) error {
+	err := impl.shiftTraffic(pipeline, FullWeight, false)
+	if err != nil {
+		return err
+	}
+	err = impl.updateRun(run, repository.CanaryAnalysisPromoted, "")
+	if err != nil {
+		return err
+	}
+	impl.saveTimeline(runner.Id, timelineStatus.TIMELINE_STATUS_CANARY_PROMOTED, timelineStatus.TIMELINE_DESCRIPTION_CANARY_PROMOTED)
+	return nil
+}
+
+// failAnalysis takes the traffic off the release, marks the run failed and rolls the pipeline back
+func (impl *CanaryAnalysisServiceImpl) failAnalysis(run *repository.CanaryAnalysisRun, runner *pipelineConfig.CdWorkflowRunner, pipeline *pipelineConfig.Pipeline, message string) {
+	err := impl.shiftTraffic(pipeline, 0, true)
+	if err != nil {
+		impl.logger.Errorw("error in shifting traffic off the failed canary", "pipelineId", pipeline.Id, "err", err)
+	}
+	err = impl.updateRun(run, repository.CanaryAnalysisFailed, message)
+	if err != nil {
+		return
+	}
+	impl.handleFailedAnalysis(runner, message)
+}
+
+func (impl *CanaryAnalysisServiceImpl) updateRun(run *repository.CanaryAnalysisRun, runStatus repository.CanaryAnalysisRunStatus, message string) error {
+	run.Status = runStatus
+	run.Message = message
+	run.UpdateAuditLog(userBean.SystemUserId)
+	err := impl.canaryAnalysisRunRepository.Update(run)
+	if err != nil {
+		impl.logger.Errorw("error in updating canary analysis run", "runId", run.Id, "status", runStatus, "err", err)
+		return err
+	}
+	return nil
+}
+
+// shiftTraffic replaces the canary steps of the rollout of the release with one setting the weight, followed by an
+// indefinite pause if hold is set. A change of steps restarts the rollout from its first step, the weight is applied
+// right away and the rollout stays there till the next change
+func (impl *CanaryAnalysisServiceImpl) shiftTraffic(pipeline *pipelineConfig.Pipeline, weight int, hold bool) error {
+	patch, err := buildCanaryWeightPatch(weight, hold)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	restConfig, err, _ := impl.k8sCommonService.GetRestConfigByClusterId(ctx, pipeline.Environment.ClusterId)
+	if err != nil {
+		impl.logger.Errorw("error in getting rest config", "clusterId", pipeline.Environment.ClusterId, "err", err)
+		return err
+	}
+	_, err = impl.k8sUtil.PatchResourceRequest(ctx, restConfig, types.MergePatchType, patch, pipeline.DeploymentAppName, pipeline.Environment.Namespace, RolloutGVK)
+	if err != nil {
+		impl.logger.Errorw("error in setting canary weight of rollout", "rolloutName", pipeline.DeploymentAppName, "namespace", pipeline.Environment.Namespace, "weight", weight, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *CanaryAnalysisServiceImpl) getConfig(pipelineId int) (*CanaryAnalysisConfigDto, error) {
+	dbConfig, err := impl.canaryAnalysisConfigRepository.FindByPipelineId(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching canary analysis config", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return toCanaryAnalysisConfigDto(dbConfig, 0)
+}
+
+func (impl *CanaryAnalysisServiceImpl) getPrometheusApi(clusterId int) (v1.API, error) {
+	cluster, err := impl.clusterRepository.FindById(clusterId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster", "clusterId", clusterId, "err", err)
+		return nil, err
+	}
+	return newPrometheusApi(cluster)
+}
+
+func (impl *CanaryAnalysisServiceImpl) evaluateMetrics(promApi v1.API, metrics []*MetricCriterion, release *ReleaseIdentifier) []*MetricResult {
+	results := make([]*MetricResult, 0, len(metrics))
+	for _, metric := range metrics {
+		result := &MetricResult{Name: metric.Name}
+		results = append(results, result)
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
+		value, warnings, err := promApi.Query(ctx, renderQuery(metric.Query, release), time.Now())
+		cancel()
+		if len(warnings) > 0 {
+			impl.logger.Warnw("warnings in canary metric query", "metric", metric.Name, "warnings", warnings)
+		}
+		if err != nil {
+			impl.logger.Errorw("error in querying canary metric", "metric", metric.Name, "err", err)
+			result.Reason = err.Error()
+			continue
+		}
+		result.Value, err = extractSampleValue(value)
+		if err != nil {
+			result.Reason = err.Error()
+			continue
+		}
+		result.Passed = evaluateCriterion(metric, result.Value)
+		if !result.Passed {
+			result.Reason = fmt.Sprintf("%g is not %s %g", result.Value, metric.Operator, metric.Threshold)
+		}
+	}
+	return results
+}
+
+// handleFailedAnalysis marks the analysed release failed and rolls the pipeline back to its last healthy release
+func (impl *CanaryAnalysisServiceImpl) handleFailedAnalysis(runner *pipelineConfig.CdWorkflowRunner, message string) {
+	impl.saveTimeline(runner.Id, timelineStatus.TIMELINE_STATUS_CANARY_ANALYSIS_FAILED, message)
+	runner.Status = cdWorkflow.WorkflowFailed
+	runner.Message = message
+	runner.FinishedOn = time.Now()
+	runner.UpdateAuditLog(userBean.SystemUserId)
+	err := impl.cdWorkflowRepository.UpdateWorkFlowRunner(runner)
+	if err != nil {
+		impl.logger.Errorw("error in marking runner failed after canary analysis", "wfrId", runner.Id, "err", err)
+	}
+	_, err = impl.deploymentRollbackService.RollbackToLastHealthyRelease(runner.CdWorkflow.PipelineId, runner.Id, message)
+	if err != nil {
+		impl.logger.Errorw("error in rolling back after failed canary analysis", "wfrId", runner.Id, "err", err)
+		impl.saveTimeline(runner.Id, timelineStatus.TIMELINE_STATUS_CANARY_ROLLED_BACK, fmt.Sprintf("Rollback failed: %s", err.Error()))
+		return
+	}
+	impl.saveTimeline(runner.Id, timelineStatus.TIMELINE_STATUS_CANARY_ROLLED_BACK, "Rolled back to the last healthy release.")
+}
+
+func (impl *CanaryAnalysisServiceImpl) saveTimeline(wfrId int, status timelineStatus.TimelineStatus, description string) {
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(wfrId, status, description, userBean.SystemUserId)
+	err := impl.pipelineStatusTimelineService.SaveTimeline(timeline, nil)
+	if err != nil {
+		impl.logger.Errorw("error in saving canary analysis timeline", "wfrId", wfrId, "status", status, "err", err)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canary
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/deployment/canary/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func toCanaryAnalysisConfigDbObject(config *CanaryAnalysisConfigDto) (*repository.CanaryAnalysisConfig, error) {
+	steps, err := json.Marshal(config.Steps)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := json.Marshal(config.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.CanaryAnalysisConfig{
+		Id:         config.Id,
+		PipelineId: config.PipelineId,
+		Enabled:    config.Enabled,
+		Steps:      string(steps),
+		Metrics:    string(metrics),
+		Active:     true,
+		AuditLog:   sql.NewDefaultAuditLog(config.UserId),
+	}, nil
+}
+
+func toCanaryAnalysisConfigDto(config *repository.CanaryAnalysisConfig, appId int) (*CanaryAnalysisConfigDto, error) {
+	dto := &CanaryAnalysisConfigDto{
+		Id:         config.Id,
+		AppId:      appId,
+		PipelineId: config.PipelineId,
+		Enabled:    config.Enabled,
+	}
+	err := json.Unmarshal([]byte(config.Steps), &dto.Steps)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(config.Metrics), &dto.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	return dto, nil
+}
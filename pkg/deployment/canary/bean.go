@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canary
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"time"
+)
+
+type ComparisonOperator string
+
+const (
+	LessThan           ComparisonOperator = "<"
+	LessThanOrEqual    ComparisonOperator = "<="
+	GreaterThan        ComparisonOperator = ">"
+	GreaterThanOrEqual ComparisonOperator = ">="
+	Equal              ComparisonOperator = "=="
+)
+
+const (
+	// query placeholders, replaced with the values of the deployed release before querying prometheus
+	NamespacePlaceholder   = "{{namespace}}"
+	ReleaseNamePlaceholder = "{{releaseName}}"
+	AppNamePlaceholder     = "{{appName}}"
+	EnvNamePlaceholder     = "{{envName}}"
+
+	DefaultQueryTimeout = 30 * time.Second
+	PrometheusNotFound  = "prometheus endpoint is not configured for the cluster of this environment"
+
+	// FullWeight is the canary weight at which all the traffic goes to the release
+	FullWeight = 100
+)
+
+// RolloutGVK is the kind of the argo rollout deployed by the rollout charts, the traffic of the canary is shifted on it
+var RolloutGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+
+type AnalysisStep struct {
+	// Weight is the canary traffic weight in percent the release runs at during this step
+	Weight int `json:"weight" validate:"min=1,max=100"`
+	// DurationSeconds is the time to wait at this weight before evaluating the metrics
+	DurationSeconds int `json:"durationSeconds" validate:"min=0"`
+}
+
+type MetricCriterion struct {
+	Name string `json:"name" validate:"required"`
+	// Query is a PromQL expression resolving to a single sample
+	Query     string             `json:"query" validate:"required"`
+	Operator  ComparisonOperator `json:"operator" validate:"required,oneof=< <= > >= =="`
+	Threshold float64            `json:"threshold"`
+}
+
+type CanaryAnalysisConfigDto struct {
+	Id         int                `json:"id"`
+	AppId      int                `json:"appId" validate:"required"`
+	PipelineId int                `json:"pipelineId" validate:"required"`
+	Enabled    bool               `json:"enabled"`
+	Steps      []*AnalysisStep    `json:"steps" validate:"required,min=1,dive"`
+	Metrics    []*MetricCriterion `json:"metrics" validate:"required,min=1,dive"`
+	UserId     int32              `json:"-"`
+}
+
+// ReleaseIdentifier holds the values substituted in the metric queries
+type ReleaseIdentifier struct {
+	Namespace   string
+	ReleaseName string
+	AppName     string
+	EnvName     string
+}
+
+type MetricResult struct {
+	Name   string
+	Value  float64
+	Passed bool
+	Reason string
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canary
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/prometheus/common/model"
+	"math"
+	"strings"
+)
+
+func validateConfig(config *CanaryAnalysisConfigDto) error {
+	previousWeight := 0
+	for i, step := range config.Steps {
+		if step.Weight < previousWeight {
+			return fmt.Errorf("weight of step %d can not be less than the weight of its previous step", i+1)
+		}
+		previousWeight = step.Weight
+	}
+	for _, metric := range config.Metrics {
+		if !isValidOperator(metric.Operator) {
+			return fmt.Errorf("invalid operator %q for metric %q", metric.Operator, metric.Name)
+		}
+	}
+	return nil
+}
+
+func isValidOperator(operator ComparisonOperator) bool {
+	switch operator {
+	case LessThan, LessThanOrEqual, GreaterThan, GreaterThanOrEqual, Equal:
+		return true
+	}
+	return false
+}
+
+func renderQuery(query string, release *ReleaseIdentifier) string {
+	return strings.NewReplacer(
+		NamespacePlaceholder, release.Namespace,
+		ReleaseNamePlaceholder, release.ReleaseName,
+		AppNamePlaceholder, release.AppName,
+		EnvNamePlaceholder, release.EnvName,
+	).Replace(query)
+}
+
+// extractSampleValue reads the single sample a success criterion query is expected to return
+func extractSampleValue(value model.Value) (float64, error) {
+	switch result := value.(type) {
+	case *model.Scalar:
+		return float64(result.Value), nil
+	case model.Vector:
+		if len(result) == 0 {
+			return 0, fmt.Errorf("query returned no data")
+		}
+		if len(result) > 1 {
+			return 0, fmt.Errorf("query returned %d series, expected one", len(result))
+		}
+		return float64(result[0].Value), nil
+	default:
+		return 0, fmt.Errorf("unsupported query result type %s", value.Type())
+	}
+}
+
+func evaluateCriterion(criterion *MetricCriterion, value float64) bool {
+	if math.IsNaN(value) {
+		return false
+	}
+	switch criterion.Operator {
+	case LessThan:
+		return value < criterion.Threshold
+	case LessThanOrEqual:
+		return value <= criterion.Threshold
+	case GreaterThan:
+		return value > criterion.Threshold
+	case GreaterThanOrEqual:
+		return value >= criterion.Threshold
+	case Equal:
+		return value == criterion.Threshold
+	}
+	return false
+}
+
+func getFailedMetricsMessage(results []*MetricResult) string {
+	failed := make([]string, 0, len(results))
+	for _, result := range results {
+		if !result.Passed {
+			failed = append(failed, fmt.Sprintf("%s (%s)", result.Name, result.Reason))
+		}
+	}
+	return strings.Join(failed, ", ")
+}
+
+// buildCanaryWeightPatch builds the merge patch setting the canary steps of a rollout to the weight, followed by an
+// indefinite pause if hold is set
+func buildCanaryWeightPatch(weight int, hold bool) (string, error) {
+	steps := []map[string]interface{}{{"setWeight": weight}}
+	if hold {
+		steps = append(steps, map[string]interface{}{"pause": map[string]interface{}{}})
+	}
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"strategy": map[string]interface{}{
+				"canary": map[string]interface{}{
+					"steps": steps,
+				},
+			},
+		},
+	}
+	patchJson, err := json.Marshal(patch)
+	if err != nil {
+		return "", err
+	}
+	return string(patchJson), nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canary
+
+import (
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestEvaluateCriterion(t *testing.T) {
+	errorRate := &MetricCriterion{Name: "error rate", Operator: LessThan, Threshold: 0.05}
+	successRate := &MetricCriterion{Name: "success rate", Operator: GreaterThanOrEqual, Threshold: 0.99}
+	assert.True(t, evaluateCriterion(errorRate, 0.01))
+	assert.False(t, evaluateCriterion(errorRate, 0.05))
+	assert.True(t, evaluateCriterion(successRate, 0.99))
+	assert.False(t, evaluateCriterion(successRate, 0.98))
+	assert.False(t, evaluateCriterion(successRate, math.NaN()))
+}
+
+func TestExtractSampleValue(t *testing.T) {
+	value, err := extractSampleValue(model.Vector{{Value: 0.5}})
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, value)
+
+	value, err = extractSampleValue(&model.Scalar{Value: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(2), value)
+
+	_, err = extractSampleValue(model.Vector{})
+	assert.NotNil(t, err)
+
+	_, err = extractSampleValue(model.Vector{{Value: 1}, {Value: 2}})
+	assert.NotNil(t, err)
+}
+
+func TestRenderQuery(t *testing.T) {
+	release := &ReleaseIdentifier{Namespace: "prod", ReleaseName: "payments-prod", AppName: "payments", EnvName: "prod"}
+	query := `sum(rate(http_requests_total{namespace="{{namespace}}",release="{{releaseName}}",code=~"5.."}[5m]))`
+	assert.Equal(t, `sum(rate(http_requests_total{namespace="prod",release="payments-prod",code=~"5.."}[5m]))`, renderQuery(query, release))
+}
+
+func TestValidateConfig(t *testing.T) {
+	metrics := []*MetricCriterion{{Name: "errors", Query: "up", Operator: LessThan, Threshold: 1}}
+	config := &CanaryAnalysisConfigDto{Steps: []*AnalysisStep{{Weight: 10}, {Weight: 50}, {Weight: 100}}, Metrics: metrics}
+	assert.Nil(t, validateConfig(config))
+
+	config.Steps = []*AnalysisStep{{Weight: 50}, {Weight: 10}}
+	assert.NotNil(t, validateConfig(config))
+
+	config.Steps = []*AnalysisStep{{Weight: 100}}
+	config.Metrics = []*MetricCriterion{{Name: "errors", Query: "up", Operator: "!="}}
+	assert.NotNil(t, validateConfig(config))
+}
+
+func TestBuildCanaryWeightPatch(t *testing.T) {
+	patch, err := buildCanaryWeightPatch(20, true)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"spec":{"strategy":{"canary":{"steps":[{"setWeight":20},{"pause":{}}]}}}}`, patch)
+
+	patch, err = buildCanaryWeightPatch(FullWeight, false)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"spec":{"strategy":{"canary":{"steps":[{"setWeight":100}]}}}}`, patch)
+}
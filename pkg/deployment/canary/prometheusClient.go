@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canary
+
+import (
+	"crypto/tls"
+	"errors"
+	"github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"net/http"
+)
+
+type basicAuthRoundTripper struct {
+	userName string
+	password string
+	next     http.RoundTripper
+}
+
+func (rt *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(rt.userName, rt.password)
+	return rt.next.RoundTrip(req)
+}
+
+// newPrometheusApi creates a prometheus client from the endpoint and auth configured on the cluster
+func newPrometheusApi(cluster *repository.Cluster) (v1.API, error) {
+	if len(cluster.PrometheusEndpoint) == 0 {
+		return nil, errors.New(PrometheusNotFound)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(cluster.PTlsClientCert) > 0 && len(cluster.PTlsClientKey) > 0 {
+		certificate, err := tls.X509KeyPair([]byte(cluster.PTlsClientCert), []byte(cluster.PTlsClientKey))
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	}
+	var roundTripper http.RoundTripper = transport
+	if len(cluster.PUserName) > 0 {
+		roundTripper = &basicAuthRoundTripper{userName: cluster.PUserName, password: cluster.PPassword, next: transport}
+	}
+	client, err := api.NewClient(api.Config{Address: cluster.PrometheusEndpoint, RoundTripper: roundTripper})
+	if err != nil {
+		return nil, err
+	}
+	return v1.NewAPI(client), nil
+}
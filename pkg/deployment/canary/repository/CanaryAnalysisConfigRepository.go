@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+type CanaryAnalysisConfig struct {
+	tableName  struct{} `sql:"canary_analysis_config" pg:",discard_unknown_columns"`
+	Id         int      `sql:"id,pk"`
+	PipelineId int      `sql:"pipeline_id,notnull"`
+	Enabled    bool     `sql:"enabled,notnull"`
+	Steps      string   `sql:"steps,notnull"`   // json array of analysis steps
+	Metrics    string   `sql:"metrics,notnull"` // json array of metric success criteria
+	Active     bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type CanaryAnalysisConfigRepository interface {
+	Save(config *CanaryAnalysisConfig) error
+	Update(config *CanaryAnalysisConfig) error
+	FindByPipelineId(pipelineId int) (*CanaryAnalysisConfig, error)
+}
+
+type CanaryAnalysisConfigRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewCanaryAnalysisConfigRepositoryImpl(dbConnection *pg.DB) *CanaryAnalysisConfigRepositoryImpl {
+	return &CanaryAnalysisConfigRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *CanaryAnalysisConfigRepositoryImpl) Save(config *CanaryAnalysisConfig) error {
+	return impl.dbConnection.Insert(config)
+}
+
+func (impl *CanaryAnalysisConfigRepositoryImpl) Update(config *CanaryAnalysisConfig) error {
+	return impl.dbConnection.Update(config)
+}
+
+func (impl *CanaryAnalysisConfigRepositoryImpl) FindByPipelineId(pipelineId int) (*CanaryAnalysisConfig, error) {
+	config := &CanaryAnalysisConfig{}
+	err := impl.dbConnection.Model(config).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Select()
+	return config, err
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type CanaryAnalysisRunStatus string
+
+const (
+	CanaryAnalysisRunning  CanaryAnalysisRunStatus = "RUNNING"
+	CanaryAnalysisPromoted CanaryAnalysisRunStatus = "PROMOTED"
+	CanaryAnalysisFailed   CanaryAnalysisRunStatus = "FAILED"
+	// CanaryAnalysisAborted is set when the release got superseded before the analysis completed
+	CanaryAnalysisAborted CanaryAnalysisRunStatus = "ABORTED"
+)
+
+// CanaryAnalysisRun is the progress of the analysis of a release, it is persisted after every step so that the
+// analysis resumes from where it was when the orchestrator restarts
+type CanaryAnalysisRun struct {
+	tableName           struct{}                `sql:"canary_analysis_run" pg:",discard_unknown_columns"`
+	Id                  int                     `sql:"id,pk"`
+	PipelineId          int                     `sql:"pipeline_id,notnull"`
+	CdWorkflowRunnerId  int                     `sql:"cd_workflow_runner_id,notnull"`
+	PipelineOverrideId  int                     `sql:"pipeline_override_id,notnull"`
+	CurrentStep         int                     `sql:"current_step,notnull"` // index of the step the release runs at
+	StepStartedOn       time.Time               `sql:"step_started_on,notnull"`
+	Status              CanaryAnalysisRunStatus `sql:"status,notnull"`
+	Message             string                  `sql:"message"`
+	PostStagesTriggered bool                    `sql:"post_stages_triggered,notnull"`
+	sql.AuditLog
+}
+
+type CanaryAnalysisRunRepository interface {
+	Save(run *CanaryAnalysisRun) error
+	Update(run *CanaryAnalysisRun) error
+	FindByWfrId(wfrId int) (*CanaryAnalysisRun, error)
+	FindAllRunning() ([]*CanaryAnalysisRun, error)
+	// FindUnanalysedRunnerIds returns the in progress deploy runners of the pipelines with an enabled analysis
+	// for which no analysis has been started yet
+	FindUnanalysedRunnerIds() ([]int, error)
+	// MarkPostStagesTriggered sets the post stages of a promoted release triggered and returns true if this call did it
+	MarkPostStagesTriggered(id int, userId int32) (bool, error)
+}
+
+type CanaryAnalysisRunRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewCanaryAnalysisRunRepositoryImpl(dbConnection *pg.DB) *CanaryAnalysisRunRepositoryImpl {
+	return &CanaryAnalysisRunRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *CanaryAnalysisRunRepositoryImpl) Save(run *CanaryAnalysisRun) error {
+	return impl.dbConnection.Insert(run)
+}
+
+func (impl *CanaryAnalysisRunRepositoryImpl) Update(run *CanaryAnalysisRun) error {
+	return impl.dbConnection.Update(run)
+}
+
+func (impl *CanaryAnalysisRunRepositoryImpl) FindByWfrId(wfrId int) (*CanaryAnalysisRun, error) {
+	run := &CanaryAnalysisRun{}
+	err := impl.dbConnection.Model(run).
+		Where("cd_workflow_runner_id = ?", wfrId).
+		Select()
+	return run, err
+}
+
+func (impl *CanaryAnalysisRunRepositoryImpl) FindAllRunning() ([]*CanaryAnalysisRun, error) {
+	var runs []*CanaryAnalysisRun
+	err := impl.dbConnection.Model(&runs).
+		Where("status = ?", CanaryAnalysisRunning).
+		Order("id ASC").
+		Select()
+	return runs, err
+}
+
+func (impl *CanaryAnalysisRunRepositoryImpl) FindUnanalysedRunnerIds() ([]int, error) {
+	var wfrIds []int
+	query := `SELECT wfr.id FROM cd_workflow_runner wfr
+		INNER JOIN cd_workflow wf ON wf.id = wfr.cd_workflow_id
+		INNER JOIN canary_analysis_config config ON config.pipeline_id = wf.pipeline_id AND config.active = true AND config.enabled = true
+		LEFT JOIN canary_analysis_run run ON run.cd_workflow_runner_id = wfr.id
+		WHERE wfr.workflow_type = 'DEPLOY' AND wfr.status = 'Progressing' AND run.id IS NULL
+		ORDER BY wfr.id ASC;`
+	_, err := impl.dbConnection.Query(&wfrIds, query)
+	return wfrIds, err
+}
+
+func (impl *CanaryAnalysisRunRepositoryImpl) MarkPostStagesTriggered(id int, userId int32) (bool, error) {
+	res, err := impl.dbConnection.Model(&CanaryAnalysisRun{}).
+		Set("post_stages_triggered = ?", true).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("id = ?", id).
+		Where("status = ?", CanaryAnalysisPromoted).
+		Where("post_stages_triggered = ?", false).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
@@ -97,6 +97,7 @@ func (impl *DeployedAppServiceImpl) StopStartApp(ctx context.Context, stopReques
 		CiArtifactId:   wf.CiArtifactId,
 		UserId:         stopRequest.UserId,
 		CdWorkflowType: bean2.CD_WORKFLOW_TYPE_DEPLOY,
+		TriggerType:    stopRequest.TriggerType,
 	}
 	if stopRequest.RequestType == bean.STOP {
 		overrideRequest.AdditionalOverride = json.RawMessage([]byte(stopTemplate))
@@ -40,4 +40,6 @@ type StopAppRequest struct {
 	// ReferenceId is a unique identifier for the workflow runner
 	// refer pipelineConfig.CdWorkflowRunner
 	ReferenceId *string
+	// TriggerType is recorded on the deploy runner, set only for system triggered requests
+	TriggerType string `json:"-"`
 }
@@ -155,6 +155,8 @@ func (impl *GitOpsConfigReadServiceImpl) GetGitOpsConfigActive() (*bean2.GitOpsC
 		BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 		BitBucketProjectKey:   model.BitBucketProjectKey,
 		AllowCustomRepository: model.AllowCustomRepository,
+		CommitStrategy:        model.CommitStrategy,
+		AutoMergePullRequest:  model.AutoMergePullRequest,
 		EnableTLSVerification: true,
 		TLSConfig: &bean3.TLSConfig{
 			CaData:      model.CaCert,
@@ -214,6 +216,8 @@ func (impl *GitOpsConfigReadServiceImpl) GetGitOpsProviderByRepoURL(gitRepoUrl s
 				BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 				BitBucketProjectKey:   model.BitBucketProjectKey,
 				AllowCustomRepository: model.AllowCustomRepository,
+				CommitStrategy:        model.CommitStrategy,
+				AutoMergePullRequest:  model.AutoMergePullRequest,
 			}
 			// written with assumption that only one GitOpsConfig is present in DB for each provider(github, gitlab, etc)
 			break
@@ -254,6 +258,8 @@ func (impl *GitOpsConfigReadServiceImpl) GetGitOpsProviderMapByRepoURL(allGitRep
 			BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 			BitBucketProjectKey:   model.BitBucketProjectKey,
 			AllowCustomRepository: model.AllowCustomRepository,
+			CommitStrategy:        model.CommitStrategy,
+			AutoMergePullRequest:  model.AutoMergePullRequest,
 		}
 		modelHostToConfigMapping[host] = gitOpsConfig
 	}
@@ -307,6 +313,8 @@ func (impl *GitOpsConfigReadServiceImpl) GetGitOpsById(id int) (*bean2.GitOpsCon
 		BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 		BitBucketProjectKey:   model.BitBucketProjectKey,
 		AllowCustomRepository: model.AllowCustomRepository,
+		CommitStrategy:        model.CommitStrategy,
+		AutoMergePullRequest:  model.AutoMergePullRequest,
 		TLSConfig: &bean3.TLSConfig{
 			CaData:      model.CaCert,
 			TLSCertData: model.TlsCert,
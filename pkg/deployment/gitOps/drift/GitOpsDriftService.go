@@ -0,0 +1,592 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	draftBean "github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/common"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo"
+	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deployedAppMetrics"
+	"github.com/devtron-labs/devtron/pkg/pipeline"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/util/argo"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"path"
+	"time"
+)
+
+type GitOpsDriftService interface {
+	// CheckAll compares the values last committed by Devtron for every ArgoCd pipeline with the head of its GitOps
+	// repository and with the sync state of its ArgoCd application. Drifted pipelines are reconciled by a recommit
+	// if autoRecommit is set, unless commits go through pull requests
+	CheckAll(autoRecommit bool)
+	CheckPipeline(pipelineId int) (*bean.GitOpsDriftDto, error)
+	// GetReport returns the result of the last check of the pipelines, filtered on appId and envId when set
+	GetReport(appId, envId int) ([]*bean.GitOpsDriftDto, error)
+	// Reconcile recommits the last deployed values or adopts the changes of the repository. A recommit is rejected when
+	// commits go through pull requests, an adoption on protected configs is saved as a draft which is returned instead
+	Reconcile(request *bean.ReconcileRequest) (*bean.GitOpsDriftDto, *draftBean.ConfigDraftDto, error)
+}
+
+type GitOpsDriftServiceImpl struct {
+	logger                      *zap.SugaredLogger
+	gitOpsDriftRepository       repository.GitOpsDriftRepository
+	pipelineRepository          pipelineConfig.PipelineRepository
+	pipelineOverrideRepository  chartConfig.PipelineOverrideRepository
+	envConfigOverrideRepository chartConfig.EnvConfigOverrideRepository
+	deploymentConfigService     common.DeploymentConfigService
+	gitOpsConfigReadService     config.GitOpsConfigReadService
+	gitOperationService         git.GitOperationService
+	gitOpsMonorepoService       monorepo.GitOpsMonorepoService
+	argoClientWrapperService    argocdServer.ArgoClientWrapperService
+	argoUserService             argo.ArgoUserService
+	propertiesConfigService     pipeline.PropertiesConfigService
+	deployedAppMetricsService   deployedAppMetrics.DeployedAppMetricsService
+	*sql.TransactionUtilImpl
+}
+
+func NewGitOpsDriftServiceImpl(logger *zap.SugaredLogger,
+	gitOpsDriftRepository repository.GitOpsDriftRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	envConfigOverrideRepository chartConfig.EnvConfigOverrideRepository,
+	deploymentConfigService common.DeploymentConfigService,
+	gitOpsConfigReadService config.GitOpsConfigReadService,
+	gitOperationService git.GitOperationService,
+	gitOpsMonorepoService monorepo.GitOpsMonorepoService,
+	argoClientWrapperService argocdServer.ArgoClientWrapperService,
+	argoUserService argo.ArgoUserService,
+	propertiesConfigService pipeline.PropertiesConfigService,
+	deployedAppMetricsService deployedAppMetrics.DeployedAppMetricsService,
+	transactionUtilImpl *sql.TransactionUtilImpl) *GitOpsDriftServiceImpl {
+	return &GitOpsDriftServiceImpl{
+		logger:                      logger,
+		gitOpsDriftRepository:       gitOpsDriftRepository,
+		pipelineRepository:          pipelineRepository,
+		pipelineOverrideRepository:  pipelineOverrideRepository,
+		envConfigOverrideRepository: envConfigOverrideRepository,
+		deploymentConfigService:     deploymentConfigService,
+		gitOpsConfigReadService:     gitOpsConfigReadService,
+		gitOperationService:         gitOperationService,
+		gitOpsMonorepoService:       gitOpsMonorepoService,
+		argoClientWrapperService:    argoClientWrapperService,
+		argoUserService:             argoUserService,
+		propertiesConfigService:     propertiesConfigService,
+		deployedAppMetricsService:   deployedAppMetricsService,
+		TransactionUtilImpl:         transactionUtilImpl,
+	}
+}
+
+// driftTarget is the last GitOps deployment of a pipeline, along with where its values are in the GitOps repository
+type driftTarget struct {
+	pipeline         *pipelineConfig.Pipeline
+	pipelineOverride *chartConfig.PipelineOverride
+	envOverride      *chartConfig.EnvConfigOverride
+	repoUrl          string
+	repoName         string
+	chartLocation    string
+	valuesFileName   string
+}
+
+func (target *driftTarget) valuesFilePath() string {
+	return path.Join(target.chartLocation, target.valuesFileName)
+}
+
+// driftResult is the outcome of a check, along with the values found in the repository for reconciliation
+type driftResult struct {
+	drift        *repository.GitOpsDrift
+	paths        [][]string
+	headValues   map[string]interface{}
+	headContent  string
+	argoAppFound bool
+}
+
+func (impl *GitOpsDriftServiceImpl) CheckAll(autoRecommit bool) {
+	pipelines, err := impl.pipelineRepository.FindActiveByDeploymentAppType(util.PIPELINE_DEPLOYMENT_TYPE_ACD)
+	if err != nil {
+		impl.logger.Errorw("error in getting argocd pipelines for drift check", "err", err)
+		return
+	}
+	if autoRecommit {
+		activeGitOpsConfig, err := impl.gitOpsConfigReadService.GetGitOpsConfigActive()
+		if err != nil {
+			impl.logger.Errorw("error in fetching active gitOps config", "err", err)
+			return
+		}
+		// changes to the repository have to be reviewed when commits go through pull requests
+		autoRecommit = !activeGitOpsConfig.IsPullRequestCommitStrategy()
+	}
+	ctx, err := impl.getArgoCdContext()
+	if err != nil {
+		return
+	}
+	// values of all the pipelines of a repository are read from a single clone
+	targetsByRepo := make(map[string][]*driftTarget)
+	for _, pipeline := range pipelines {
+		target, err := impl.getDriftTarget(pipeline)
+		if err != nil {
+			impl.saveCheckError(pipeline, err)
+			continue
+		} else if target == nil || target.pipelineOverride.GitHash == "" {
+			continue
+		}
+		targetsByRepo[target.repoUrl] = append(targetsByRepo[target.repoUrl], target)
+	}
+	for repoUrl, targets := range targetsByRepo {
+		filePaths := make([]string, 0, len(targets))
+		for _, target := range targets {
+			filePaths = append(filePaths, target.valuesFilePath())
+		}
+		files, headCommitHash, err := impl.gitOperationService.ReadFilesFromHead(ctx, targets[0].repoName, repoUrl, filePaths)
+		if err != nil {
+			impl.logger.Errorw("error in reading gitops repository for drift check", "repoUrl", repoUrl, "err", err)
+			for _, target := range targets {
+				impl.saveCheckError(target.pipeline, err)
+			}
+			continue
+		}
+		for _, target := range targets {
+			result, err := impl.checkDrift(ctx, target, files, headCommitHash)
+			if err != nil {
+				impl.saveCheckError(target.pipeline, err)
+				continue
+			}
+			if autoRecommit && (result.drift.GitDrifted || result.drift.ClusterDrifted) {
+				err = impl.recommit(ctx, target, result, userBean.SystemUserId)
+				if err != nil {
+					impl.logger.Errorw("error in recommitting drifted values", "pipelineId", target.pipeline.Id, "err", err)
+				}
+			}
+		}
+	}
+}
+
+func (impl *GitOpsDriftServiceImpl) CheckPipeline(pipelineId int) (*bean.GitOpsDriftDto, error) {
+	pipeline, err := impl.pipelineRepository.FindById(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in getting pipeline", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	ctx, err := impl.getArgoCdContext()
+	if err != nil {
+		return nil, err
+	}
+	target, result, err := impl.checkPipeline(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusNotFound).WithUserMessage(bean.NoDeploymentFound).WithInternalMessage(bean.NoDeploymentFound)
+	}
+	return adaptDriftDto(result.drift, pipeline), nil
+}
+
+func (impl *GitOpsDriftServiceImpl) GetReport(appId, envId int) ([]*bean.GitOpsDriftDto, error) {
+	drifts, err := impl.gitOpsDriftRepository.FindAll(appId, envId)
+	if err != nil {
+		impl.logger.Errorw("error in getting gitops drifts", "appId", appId, "envId", envId, "err", err)
+		return nil, err
+	}
+	report := make([]*bean.GitOpsDriftDto, 0, len(drifts))
+	if len(drifts) == 0 {
+		return report, nil
+	}
+	pipelineIds := make([]int, 0, len(drifts))
+	for _, drift := range drifts {
+		pipelineIds = append(pipelineIds, drift.PipelineId)
+	}
+	pipelines, err := impl.pipelineRepository.FindByIdsIn(pipelineIds)
+	if err != nil {
+		impl.logger.Errorw("error in getting pipelines", "pipelineIds", pipelineIds, "err", err)
+		return nil, err
+	}
+	pipelineById := make(map[int]*pipelineConfig.Pipeline, len(pipelines))
+	for _, pipeline := range pipelines {
+		pipelineById[pipeline.Id] = pipeline
+	}
+	for _, drift := range drifts {
+		if pipeline, ok := pipelineById[drift.PipelineId]; ok {
+			report = append(report, adaptDriftDto(drift, pipeline))
+		}
+	}
+	return report, nil
+}
+
+func (impl *GitOpsDriftServiceImpl) Reconcile(request *bean.ReconcileRequest) (*bean.GitOpsDriftDto, *draftBean.ConfigDraftDto, error) {
+	if request.Action != bean.ReconcileActionRecommit && request.Action != bean.ReconcileActionAdopt {
+		message := fmt.Sprintf(bean.InvalidReconcileAction, request.Action)
+		return nil, nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(message).WithInternalMessage(message)
+	}
+	pipeline, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in getting pipeline", "pipelineId", request.PipelineId, "err", err)
+		return nil, nil, err
+	}
+	ctx, err := impl.getArgoCdContext()
+	if err != nil {
+		return nil, nil, err
+	}
+	// drift is checked again, the report may be outdated
+	target, result, err := impl.checkPipeline(ctx, pipeline)
+	if err != nil {
+		return nil, nil, err
+	}
+	if target == nil {
+		return nil, nil, util.NewApiError().WithHttpStatusCode(http.StatusNotFound).WithUserMessage(bean.NoDeploymentFound).WithInternalMessage(bean.NoDeploymentFound)
+	}
+	switch {
+	case result.drift.Status == bean.DriftStatusPending.String():
+		return nil, nil, util.NewApiError().WithHttpStatusCode(http.StatusConflict).WithUserMessage(bean.DeploymentInProgress).WithInternalMessage(bean.DeploymentInProgress)
+	case !result.drift.GitDrifted && !result.drift.ClusterDrifted:
+		return nil, nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.NoDriftToReconcile).WithInternalMessage(bean.NoDriftToReconcile)
+	case request.Action == bean.ReconcileActionAdopt && !result.drift.GitDrifted:
+		return nil, nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.NoGitDriftToAdopt).WithInternalMessage(bean.NoGitDriftToAdopt)
+	}
+	if request.Action == bean.ReconcileActionRecommit && result.drift.GitDrifted {
+		activeGitOpsConfig, err := impl.gitOpsConfigReadService.GetGitOpsConfigActive()
+		if err != nil {
+			impl.logger.Errorw("error in fetching active gitOps config", "err", err)
+			return nil, nil, err
+		}
+		// a recommit would bypass the review of the changes to the repository
+		if activeGitOpsConfig.IsPullRequestCommitStrategy() {
+			return nil, nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.RecommitNeedsReview).WithInternalMessage(bean.RecommitNeedsReview)
+		}
+	}
+	var draft *draftBean.ConfigDraftDto
+	if request.Action == bean.ReconcileActionAdopt {
+		draft, err = impl.adopt(ctx, target, result, request.UserId)
+	} else {
+		err = impl.recommit(ctx, target, result, request.UserId)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in reconciling gitops drift", "pipelineId", pipeline.Id, "action", request.Action, "err", err)
+		return nil, nil, err
+	} else if draft != nil {
+		return nil, draft, nil
+	}
+	driftDto, err := impl.CheckPipeline(pipeline.Id)
+	return driftDto, nil, err
+}
+
+func (impl *GitOpsDriftServiceImpl) checkPipeline(ctx context.Context, pipeline *pipelineConfig.Pipeline) (*driftTarget, *driftResult, error) {
+	target, err := impl.getDriftTarget(pipeline)
+	if err != nil || target == nil {
+		return nil, nil, err
+	}
+	if target.pipelineOverride.GitHash == "" {
+		// getDriftTarget has saved the check as pending
+		drift, err := impl.gitOpsDriftRepository.FindByPipelineId(pipeline.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return target, &driftResult{drift: drift}, nil
+	}
+	files, headCommitHash, err := impl.gitOperationService.ReadFilesFromHead(ctx, target.repoName, target.repoUrl, []string{target.valuesFilePath()})
+	if err != nil {
+		impl.logger.Errorw("error in reading gitops repository for drift check", "repoUrl", target.repoUrl, "err", err)
+		return nil, nil, err
+	}
+	result, err := impl.checkDrift(ctx, target, files, headCommitHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return target, result, nil
+}
+
+// getDriftTarget returns nil for a pipeline not deployed through GitOps yet. A deployment not committed yet
+// is saved as pending and returned without a repository to read
+func (impl *GitOpsDriftServiceImpl) getDriftTarget(pipeline *pipelineConfig.Pipeline) (*driftTarget, error) {
+	pipelineOverride, err := impl.pipelineOverrideRepository.FindLatestByPipelineId(pipeline.Id)
+	if err == pg.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in getting latest pipeline override", "pipelineId", pipeline.Id, "err", err)
+		return nil, err
+	}
+	target := &driftTarget{
+		pipeline:         pipeline,
+		pipelineOverride: pipelineOverride,
+	}
+	if pipelineOverride.GitHash == "" {
+		drift := impl.newDrift(pipeline, pipelineOverride)
+		drift.Status = bean.DriftStatusPending.String()
+		return target, impl.saveDrift(drift)
+	}
+	target.envOverride, err = impl.envConfigOverrideRepository.GetByIdIncludingInactive(pipelineOverride.EnvConfigOverrideId)
+	if err != nil {
+		impl.logger.Errorw("error in getting env config override", "envConfigOverrideId", pipelineOverride.EnvConfigOverrideId, "err", err)
+		return nil, err
+	}
+	deploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(pipeline.AppId, pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in getting deployment config", "appId", pipeline.AppId, "envId", pipeline.EnvironmentId, "err", err)
+		return nil, err
+	}
+	target.repoUrl = deploymentConfig.RepoURL
+	target.repoName = impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(deploymentConfig.RepoURL)
+	chartPathPrefix, err := impl.gitOpsMonorepoService.GetChartPathPrefix(pipeline.AppId, pipeline.EnvironmentId, deploymentConfig.RepoURL, userBean.SystemUserId)
+	if err != nil {
+		return nil, err
+	}
+	target.chartLocation = path.Join(chartPathPrefix, target.envOverride.Chart.ChartLocation)
+	target.valuesFileName = fmt.Sprintf("_%d-values.yaml", pipeline.EnvironmentId)
+	return target, nil
+}
+
+func (impl *GitOpsDriftServiceImpl) checkDrift(ctx context.Context, target *driftTarget, files map[string]string, headCommitHash string) (*driftResult, error) {
+	expectedValues, err := ParseValues(target.pipelineOverride.PipelineMergedValues)
+	if err != nil {
+		impl.logger.Errorw("error in parsing deployed values", "pipelineOverrideId", target.pipelineOverride.Id, "err", err)
+		return nil, err
+	}
+	result := &driftResult{
+		drift:       impl.newDrift(target.pipeline, target.pipelineOverride),
+		headContent: files[target.valuesFilePath()],
+	}
+	result.headValues, err = ParseValues(result.headContent)
+	if err != nil {
+		// a file edited by hand may not be valid yaml any more, all of it is drifted then
+		impl.logger.Warnw("error in parsing values of gitops repository", "pipelineId", target.pipeline.Id, "err", err)
+		result.headValues = make(map[string]interface{})
+	}
+	result.paths = DiffValues(expectedValues, result.headValues)
+	result.drift.GitDrifted = len(result.paths) > 0
+	result.drift.DriftedPaths = FormatPaths(result.paths)
+	result.drift.HeadCommitHash = headCommitHash
+
+	argoApplication, err := impl.argoClientWrapperService.GetArgoAppByName(ctx, target.pipeline.DeploymentAppName)
+	if err != nil {
+		impl.logger.Errorw("error in getting argocd application", "argoAppName", target.pipeline.DeploymentAppName, "err", err)
+		result.drift.Error = util.GetClientErrorDetailedMessage(err)
+	} else {
+		result.argoAppFound = true
+		result.drift.ArgoSyncStatus = string(argoApplication.Status.Sync.Status)
+		result.drift.ArgoSyncRevision = argoApplication.Status.Sync.Revision
+		result.drift.ClusterDrifted = argoApplication.Status.Sync.Status == v1alpha1.SyncStatusCodeOutOfSync
+	}
+	result.drift.Status = bean.GetDriftStatus(result.drift.GitDrifted, result.drift.ClusterDrifted).String()
+	if !result.argoAppFound && !result.drift.GitDrifted {
+		result.drift.Status = bean.DriftStatusUnknown.String()
+	}
+	return result, impl.saveDrift(result.drift)
+}
+
+// recommit commits the values last deployed by Devtron again if the repository drifted, and syncs the ArgoCd application
+func (impl *GitOpsDriftServiceImpl) recommit(ctx context.Context, target *driftTarget, result *driftResult, userId int32) error {
+	if result.drift.GitDrifted {
+		userEmailId, userName := impl.gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit(userId)
+		chartConfig := &git.ChartConfig{
+			FileName:       target.valuesFileName,
+			FileContent:    target.pipelineOverride.PipelineMergedValues,
+			ChartName:      target.envOverride.Chart.ChartName,
+			ChartLocation:  target.chartLocation,
+			ChartRepoName:  target.repoName,
+			ReleaseMessage: fmt.Sprintf("drift-reconcile-release-%d-env-%d ", target.pipelineOverride.Id, target.pipeline.EnvironmentId),
+			UserName:       userName,
+			UserEmailId:    userEmailId,
+		}
+		commitHash, commitTime, err := impl.gitOperationService.CommitValues(ctx, chartConfig)
+		if err != nil {
+			impl.logger.Errorw("error in recommitting values", "pipelineId", target.pipeline.Id, "err", err)
+			return err
+		}
+		err = impl.pipelineOverrideRepository.UpdateCommitDetails(ctx, nil, target.pipelineOverride.Id, commitHash, commitTime, userId)
+		if err != nil {
+			impl.logger.Errorw("error in updating commit details", "pipelineOverrideId", target.pipelineOverride.Id, "err", err)
+			return err
+		}
+	}
+	return impl.syncArgoCdApp(ctx, target, result)
+}
+
+// adopt saves the values changed in the repository in the deployment template of the environment, and makes
+// the head of the repository the last deployed values of the pipeline. If the configs of the environment are
+// protected, the change is saved as a draft instead and the pipeline is left drifted till the draft is published
+func (impl *GitOpsDriftServiceImpl) adopt(ctx context.Context, target *driftTarget, result *driftResult, userId int32) (*draftBean.ConfigDraftDto, error) {
+	envOverride := target.envOverride
+	baseValues := envOverride.EnvOverrideValues
+	if !envOverride.IsOverride {
+		baseValues = envOverride.Chart.GlobalOverride
+	}
+	values, err := ParseValues(baseValues)
+	if err != nil {
+		impl.logger.Errorw("error in parsing deployment template of environment", "envConfigOverrideId", envOverride.Id, "err", err)
+		return nil, err
+	}
+	ApplyDrift(values, result.headValues, result.paths)
+	envOverrideValues, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	isAppMetricsEnabled, err := impl.deployedAppMetricsService.GetMetricsFlagByAppIdAndEnvId(target.pipeline.AppId, target.pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in getting app metrics flag", "appId", target.pipeline.AppId, "envId", target.pipeline.EnvironmentId, "err", err)
+		return nil, err
+	}
+	environmentProperties := &pipelineBean.EnvironmentProperties{
+		Id:                envOverride.Id,
+		EnvOverrideValues: envOverrideValues,
+		Status:            envOverride.Status,
+		ManualReviewed:    envOverride.ManualReviewed,
+		Active:            envOverride.Active,
+		Namespace:         envOverride.Namespace,
+		EnvironmentId:     envOverride.TargetEnvironment,
+		UserId:            userId,
+		AppMetrics:        &isAppMetricsEnabled,
+		ChartRefId:        envOverride.Chart.ChartRefId,
+		IsOverride:        true,
+		IsBasicViewLocked: envOverride.IsBasicViewLocked,
+		CurrentViewEditor: envOverride.CurrentViewEditor,
+	}
+	_, draft, err := impl.propertiesConfigService.UpdateEnvironmentProperties(target.pipeline.AppId, environmentProperties, userId)
+	if err != nil {
+		impl.logger.Errorw("error in updating deployment template of environment", "envConfigOverrideId", envOverride.Id, "err", err)
+		return nil, err
+	} else if draft != nil {
+		return draft, nil
+	}
+	tx, err := impl.TransactionUtilImpl.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.TransactionUtilImpl.RollbackTx(tx)
+	err = impl.pipelineOverrideRepository.UpdatePipelineMergedValues(ctx, tx, target.pipelineOverride.Id, result.headContent, userId)
+	if err != nil {
+		impl.logger.Errorw("error in updating pipeline merged values", "pipelineOverrideId", target.pipelineOverride.Id, "err", err)
+		return nil, err
+	}
+	err = impl.pipelineOverrideRepository.UpdateCommitDetails(ctx, tx, target.pipelineOverride.Id, result.drift.HeadCommitHash, time.Now(), userId)
+	if err != nil {
+		impl.logger.Errorw("error in updating commit details", "pipelineOverrideId", target.pipelineOverride.Id, "err", err)
+		return nil, err
+	}
+	err = impl.TransactionUtilImpl.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	return nil, impl.syncArgoCdApp(ctx, target, result)
+}
+
+func (impl *GitOpsDriftServiceImpl) syncArgoCdApp(ctx context.Context, target *driftTarget, result *driftResult) error {
+	if !result.argoAppFound {
+		return nil
+	}
+	err := impl.argoClientWrapperService.SyncArgoCDApplicationIfNeededAndRefresh(ctx, target.pipeline.DeploymentAppName)
+	if err != nil {
+		impl.logger.Errorw("error in syncing argocd application", "argoAppName", target.pipeline.DeploymentAppName, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *GitOpsDriftServiceImpl) newDrift(pipeline *pipelineConfig.Pipeline, pipelineOverride *chartConfig.PipelineOverride) *repository.GitOpsDrift {
+	return &repository.GitOpsDrift{
+		PipelineId:         pipeline.Id,
+		AppId:              pipeline.AppId,
+		EnvId:              pipeline.EnvironmentId,
+		PipelineOverrideId: pipelineOverride.Id,
+		ExpectedCommitHash: pipelineOverride.GitHash,
+		CheckedOn:          time.Now(),
+		AuditLog:           sql.NewDefaultAuditLog(userBean.SystemUserId),
+	}
+}
+
+func (impl *GitOpsDriftServiceImpl) saveCheckError(pipeline *pipelineConfig.Pipeline, checkErr error) {
+	drift := &repository.GitOpsDrift{
+		PipelineId: pipeline.Id,
+		AppId:      pipeline.AppId,
+		EnvId:      pipeline.EnvironmentId,
+		Status:     bean.DriftStatusUnknown.String(),
+		Error:      checkErr.Error(),
+		CheckedOn:  time.Now(),
+		AuditLog:   sql.NewDefaultAuditLog(userBean.SystemUserId),
+	}
+	err := impl.saveDrift(drift)
+	if err != nil {
+		impl.logger.Errorw("error in saving drift check error", "pipelineId", pipeline.Id, "checkErr", checkErr, "err", err)
+	}
+}
+
+// saveDrift keeps a single check result per pipeline
+func (impl *GitOpsDriftServiceImpl) saveDrift(drift *repository.GitOpsDrift) error {
+	existing, err := impl.gitOpsDriftRepository.FindByPipelineId(drift.PipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in getting gitops drift", "pipelineId", drift.PipelineId, "err", err)
+		return err
+	}
+	if err == pg.ErrNoRows {
+		err = impl.gitOpsDriftRepository.Save(drift)
+	} else {
+		drift.Id = existing.Id
+		drift.CreatedOn = existing.CreatedOn
+		drift.CreatedBy = existing.CreatedBy
+		err = impl.gitOpsDriftRepository.Update(drift)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving gitops drift", "pipelineId", drift.PipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *GitOpsDriftServiceImpl) getArgoCdContext() (context.Context, error) {
+	acdToken, err := impl.argoUserService.GetLatestDevtronArgoCdUserToken()
+	if err != nil {
+		impl.logger.Errorw("error in getting acd token", "err", err)
+		return nil, err
+	}
+	return context.WithValue(context.Background(), "token", acdToken), nil
+}
+
+func adaptDriftDto(drift *repository.GitOpsDrift, pipeline *pipelineConfig.Pipeline) *bean.GitOpsDriftDto {
+	return &bean.GitOpsDriftDto{
+		PipelineId:         drift.PipelineId,
+		AppId:              drift.AppId,
+		AppName:            pipeline.App.AppName,
+		EnvId:              drift.EnvId,
+		EnvName:            pipeline.Environment.Name,
+		Status:             bean.DriftStatus(drift.Status),
+		GitDrifted:         drift.GitDrifted,
+		ClusterDrifted:     drift.ClusterDrifted,
+		DriftedPaths:       drift.DriftedPaths,
+		ExpectedCommitHash: drift.ExpectedCommitHash,
+		HeadCommitHash:     drift.HeadCommitHash,
+		ArgoSyncStatus:     drift.ArgoSyncStatus,
+		ArgoSyncRevision:   drift.ArgoSyncRevision,
+		Error:              drift.Error,
+		CheckedOn:          drift.CheckedOn,
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type DriftStatus string
+
+const (
+	DriftStatusInSync         DriftStatus = "InSync"
+	DriftStatusGitDrifted     DriftStatus = "GitDrifted"
+	DriftStatusClusterDrifted DriftStatus = "ClusterDrifted"
+	DriftStatusDrifted        DriftStatus = "Drifted"
+	// DriftStatusPending is for a deployment whose values are not committed yet, or waiting on a pull request
+	DriftStatusPending DriftStatus = "Pending"
+	DriftStatusUnknown DriftStatus = "Unknown"
+)
+
+func (s DriftStatus) String() string {
+	return string(s)
+}
+
+// GetDriftStatus combines the drift of the GitOps repository from Devtron, and of the cluster from the GitOps repository
+func GetDriftStatus(gitDrifted, clusterDrifted bool) DriftStatus {
+	switch {
+	case gitDrifted && clusterDrifted:
+		return DriftStatusDrifted
+	case gitDrifted:
+		return DriftStatusGitDrifted
+	case clusterDrifted:
+		return DriftStatusClusterDrifted
+	default:
+		return DriftStatusInSync
+	}
+}
+
+type ReconcileAction string
+
+const (
+	// ReconcileActionRecommit commits the values last deployed by Devtron again, overwriting the changes made in the repository
+	ReconcileActionRecommit ReconcileAction = "RECOMMIT"
+	// ReconcileActionAdopt saves the changes made in the repository in the deployment template of the environment
+	ReconcileActionAdopt ReconcileAction = "ADOPT"
+)
+
+const (
+	InvalidReconcileAction = "invalid reconcile action %q, supported actions are RECOMMIT and ADOPT"
+	NoDriftToReconcile     = "no drift found for the pipeline, nothing to reconcile"
+	NoGitDriftToAdopt      = "the GitOps repository has no changes to adopt"
+	NoDeploymentFound      = "pipeline has not been deployed through GitOps yet"
+	DeploymentInProgress   = "a deployment of the pipeline is in progress, try again once it is committed"
+	RecommitNeedsReview    = "commits to the GitOps repository go through pull requests, redeploy the pipeline to raise one with the last deployed values"
+)
+
+type GitOpsDriftDto struct {
+	PipelineId         int         `json:"pipelineId"`
+	AppId              int         `json:"appId"`
+	AppName            string      `json:"appName"`
+	EnvId              int         `json:"envId"`
+	EnvName            string      `json:"envName"`
+	Status             DriftStatus `json:"status"`
+	GitDrifted         bool        `json:"gitDrifted"`
+	ClusterDrifted     bool        `json:"clusterDrifted"`
+	DriftedPaths       []string    `json:"driftedPaths,omitempty"`
+	ExpectedCommitHash string      `json:"expectedCommitHash,omitempty"`
+	HeadCommitHash     string      `json:"headCommitHash,omitempty"`
+	ArgoSyncStatus     string      `json:"argoSyncStatus,omitempty"`
+	ArgoSyncRevision   string      `json:"argoSyncRevision,omitempty"`
+	Error              string      `json:"error,omitempty"`
+	CheckedOn          time.Time   `json:"checkedOn"`
+}
+
+type ReconcileRequest struct {
+	PipelineId int             `json:"-"`
+	Action     ReconcileAction `json:"action" validate:"required"`
+	UserId     int32           `json:"-"`
+}
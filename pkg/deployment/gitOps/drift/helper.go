@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"encoding/json"
+	"reflect"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strings"
+)
+
+// ParseValues parses a values file of the GitOps repository, yaml or json, an empty file being empty values
+func ParseValues(values string) (map[string]interface{}, error) {
+	parsed := make(map[string]interface{})
+	if len(strings.TrimSpace(values)) == 0 {
+		return parsed, nil
+	}
+	jsonValues, err := yaml.YAMLToJSON([]byte(values))
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(jsonValues, &parsed)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// DiffValues returns the paths of the values which differ between expected and actual, sorted.
+// Lists are compared as a whole
+func DiffValues(expected, actual map[string]interface{}) [][]string {
+	paths := diffValues(expected, actual, nil)
+	sort.Slice(paths, func(i, j int) bool {
+		return FormatPath(paths[i]) < FormatPath(paths[j])
+	})
+	return paths
+}
+
+func diffValues(expected, actual map[string]interface{}, prefix []string) [][]string {
+	paths := make([][]string, 0)
+	keys := make(map[string]bool, len(expected)+len(actual))
+	for key := range expected {
+		keys[key] = true
+	}
+	for key := range actual {
+		keys[key] = true
+	}
+	for key := range keys {
+		path := append(append([]string{}, prefix...), key)
+		expectedValue, expectedFound := expected[key]
+		actualValue, actualFound := actual[key]
+		expectedMap, expectedIsMap := expectedValue.(map[string]interface{})
+		actualMap, actualIsMap := actualValue.(map[string]interface{})
+		if expectedFound && actualFound && expectedIsMap && actualIsMap {
+			paths = append(paths, diffValues(expectedMap, actualMap, path)...)
+		} else if expectedFound != actualFound || !reflect.DeepEqual(expectedValue, actualValue) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// ApplyDrift sets the values of the given paths of actual on base, a path missing in actual is set to null
+// so that helm removes the key
+func ApplyDrift(base, actual map[string]interface{}, paths [][]string) {
+	for _, path := range paths {
+		value, _ := lookupValue(actual, path)
+		setValue(base, path, value)
+	}
+}
+
+func lookupValue(values map[string]interface{}, path []string) (interface{}, bool) {
+	var current interface{} = values
+	for _, key := range path {
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = currentMap[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func setValue(values map[string]interface{}, path []string, value interface{}) {
+	current := values
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[key] = next
+		}
+		current = next
+	}
+	current[path[len(path)-1]] = value
+}
+
+func FormatPath(path []string) string {
+	return strings.Join(path, ".")
+}
+
+func FormatPaths(paths [][]string) []string {
+	formatted := make([]string, 0, len(paths))
+	for _, path := range paths {
+		formatted = append(formatted, FormatPath(path))
+	}
+	return formatted
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiffValues(t *testing.T) {
+	expected, err := ParseValues(`{"replicaCount": 2, "image": {"tag": "v1", "pullPolicy": "IfNotPresent"}, "env": ["A", "B"], "removed": true}`)
+	assert.Nil(t, err)
+	actual, err := ParseValues("replicaCount: 3\nimage:\n  tag: v1\n  pullPolicy: Always\nenv:\n  - A\n  - B\nadded: x\n")
+	assert.Nil(t, err)
+
+	paths := DiffValues(expected, actual)
+	assert.Equal(t, []string{"added", "image.pullPolicy", "removed", "replicaCount"}, FormatPaths(paths))
+
+	assert.Empty(t, DiffValues(expected, expected))
+	empty, err := ParseValues("")
+	assert.Nil(t, err)
+	assert.Empty(t, DiffValues(empty, map[string]interface{}{}))
+}
+
+func TestApplyDrift(t *testing.T) {
+	expected, _ := ParseValues(`{"replicaCount": 2, "resources": {"limits": {"cpu": "1"}}, "removed": true}`)
+	actual, _ := ParseValues(`{"replicaCount": 3, "resources": {"limits": {"cpu": "2"}}, "added": {"key": "x"}}`)
+	base, _ := ParseValues(`{"replicaCount": 1, "service": {"port": 80}}`)
+
+	ApplyDrift(base, actual, DiffValues(expected, actual))
+	assert.Equal(t, map[string]interface{}{
+		"replicaCount": float64(3),
+		"service":      map[string]interface{}{"port": float64(80)},
+		"resources":    map[string]interface{}{"limits": map[string]interface{}{"cpu": "2"}},
+		"added":        map[string]interface{}{"key": "x"},
+		"removed":      nil,
+	}, base)
+}
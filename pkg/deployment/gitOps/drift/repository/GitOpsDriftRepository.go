@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+// GitOpsDrift is the result of the last drift check of a pipeline
+type GitOpsDrift struct {
+	tableName          struct{}  `sql:"gitops_drift" pg:",discard_unknown_columns"`
+	Id                 int       `sql:"id,pk"`
+	PipelineId         int       `sql:"pipeline_id,notnull"`
+	AppId              int       `sql:"app_id,notnull"`
+	EnvId              int       `sql:"env_id,notnull"`
+	PipelineOverrideId int       `sql:"pipeline_override_id"`
+	Status             string    `sql:"status,notnull"`
+	GitDrifted         bool      `sql:"git_drifted,notnull"`
+	ClusterDrifted     bool      `sql:"cluster_drifted,notnull"`
+	DriftedPaths       []string  `sql:"drifted_paths" pg:",array"`
+	ExpectedCommitHash string    `sql:"expected_commit_hash"`
+	HeadCommitHash     string    `sql:"head_commit_hash"`
+	ArgoSyncStatus     string    `sql:"argo_sync_status"`
+	ArgoSyncRevision   string    `sql:"argo_sync_revision"`
+	Error              string    `sql:"error"`
+	CheckedOn          time.Time `sql:"checked_on,type:timestamptz"`
+	sql.AuditLog
+}
+
+type GitOpsDriftRepository interface {
+	Save(drift *GitOpsDrift) error
+	Update(drift *GitOpsDrift) error
+	FindByPipelineId(pipelineId int) (*GitOpsDrift, error)
+	// FindAll returns the drifts of the active pipelines, filtered on appId and envId when set
+	FindAll(appId, envId int) ([]*GitOpsDrift, error)
+}
+
+type GitOpsDriftRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewGitOpsDriftRepositoryImpl(dbConnection *pg.DB) *GitOpsDriftRepositoryImpl {
+	return &GitOpsDriftRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *GitOpsDriftRepositoryImpl) Save(drift *GitOpsDrift) error {
+	return impl.dbConnection.Insert(drift)
+}
+
+func (impl *GitOpsDriftRepositoryImpl) Update(drift *GitOpsDrift) error {
+	return impl.dbConnection.Update(drift)
+}
+
+func (impl *GitOpsDriftRepositoryImpl) FindByPipelineId(pipelineId int) (*GitOpsDrift, error) {
+	drift := &GitOpsDrift{}
+	err := impl.dbConnection.Model(drift).
+		Where("pipeline_id = ?", pipelineId).
+		Select()
+	return drift, err
+}
+
+func (impl *GitOpsDriftRepositoryImpl) FindAll(appId, envId int) ([]*GitOpsDrift, error) {
+	drifts := make([]*GitOpsDrift, 0)
+	query := impl.dbConnection.Model(&drifts).
+		Join("INNER JOIN pipeline p ON p.id = gitops_drift.pipeline_id").
+		Where("p.deleted = ?", false)
+	if appId > 0 {
+		query = query.Where("gitops_drift.app_id = ?", appId)
+	}
+	if envId > 0 {
+		query = query.Where("gitops_drift.env_id = ?", envId)
+	}
+	err := query.Order("gitops_drift.app_id ASC", "gitops_drift.env_id ASC").Select()
+	return drifts, err
+}
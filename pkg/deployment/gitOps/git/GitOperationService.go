@@ -47,6 +47,10 @@ type GitOperationService interface {
 	GitPull(clonedDir string, repoUrl string) error
 
 	CommitValues(ctx context.Context, chartGitAttr *ChartConfig) (commitHash string, commitTime time.Time, err error)
+	// CommitValuesOnPullRequest commits the values on the TargetBranch of chartGitAttr, created from the default branch, and raises a pull request for it
+	CommitValuesOnPullRequest(ctx context.Context, chartGitAttr *ChartConfig, title, description string) (*PullRequestDetail, error)
+	GetPullRequest(ctx context.Context, repoName string, pullRequestId int) (*PullRequestDetail, error)
+	MergePullRequest(ctx context.Context, repoName string, pullRequestId int) (*PullRequestDetail, error)
 	PushChartToGitRepo(ctx context.Context, gitOpsRepoName, referenceTemplate, version, tempReferenceTemplateDir, repoUrl string, userId int32) (err error)
 	PushChartToGitOpsRepoForHelmApp(ctx context.Context, PushChartToGitRequest *bean.PushChartToGitRequestDTO, requirementsConfig *ChartConfig, valuesConfig *ChartConfig) (*commonBean.ChartGitAttribute, string, error)
 
@@ -54,6 +58,9 @@ type GitOperationService interface {
 	GetRepoUrlByRepoName(repoName string) (string, error)
 
 	CloneInDir(repoUrl, chartDir string) (string, error)
+	// ReadFilesFromHead reads files of the default branch of a GitOps repository along with the commit hash of its head,
+	// files not present in the repository are left out
+	ReadFilesFromHead(ctx context.Context, gitOpsRepoName, repoUrl string, filePaths []string) (files map[string]string, commitHash string, err error)
 	ReloadGitOpsProvider() error
 	UpdateGitHostUrlByProvider(request *apiBean.GitOpsConfigDto) error
 }
@@ -271,6 +278,69 @@ func (impl *GitOperationServiceImpl) CommitValues(ctx context.Context, chartGitA
 	return commitHash, commitTime, nil
 }
 
+func (impl *GitOperationServiceImpl) getPullRequestClient() (GitOpsPullRequestClient, *apiBean.GitOpsConfigDto, error) {
+	pullRequestClient, ok := impl.gitFactory.Client.(GitOpsPullRequestClient)
+	if !ok {
+		return nil, nil, fmt.Errorf("pull request commit strategy is not supported for the configured gitops provider")
+	}
+	bitbucketMetadata, err := impl.gitOpsConfigReadService.GetBitbucketMetadata()
+	if err != nil {
+		impl.logger.Errorw("error in getting bitbucket metadata", "err", err)
+		return nil, nil, err
+	}
+	gitOpsConfig := &apiBean.GitOpsConfigDto{BitBucketWorkspaceId: bitbucketMetadata.BitBucketWorkspaceId}
+	return pullRequestClient, gitOpsConfig, nil
+}
+
+func (impl *GitOperationServiceImpl) CommitValuesOnPullRequest(ctx context.Context, chartGitAttr *ChartConfig, title, description string) (*PullRequestDetail, error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "gitOperationService.CommitValuesOnPullRequest")
+	defer span.End()
+	if chartGitAttr.IsDefaultBranch() {
+		return nil, fmt.Errorf("pull request can not be raised from the default branch")
+	}
+	pullRequestClient, gitOpsConfig, err := impl.getPullRequestClient()
+	if err != nil {
+		return nil, err
+	}
+	err = pullRequestClient.CreateBranch(newCtx, chartGitAttr.ChartRepoName, chartGitAttr.TargetBranch, gitOpsConfig)
+	if err != nil {
+		impl.logger.Errorw("error in creating branch for pull request", "repoName", chartGitAttr.ChartRepoName, "branch", chartGitAttr.TargetBranch, "err", err)
+		return nil, err
+	}
+	_, _, err = impl.CommitValues(newCtx, chartGitAttr)
+	if err != nil {
+		impl.logger.Errorw("error in committing values for pull request", "repoName", chartGitAttr.ChartRepoName, "branch", chartGitAttr.TargetBranch, "err", err)
+		return nil, err
+	}
+	pullRequest, err := pullRequestClient.CreatePullRequest(newCtx, &PullRequestConfig{
+		RepoName:     chartGitAttr.ChartRepoName,
+		SourceBranch: chartGitAttr.TargetBranch,
+		Title:        title,
+		Description:  description,
+	}, gitOpsConfig)
+	if err != nil {
+		impl.logger.Errorw("error in raising pull request", "repoName", chartGitAttr.ChartRepoName, "branch", chartGitAttr.TargetBranch, "err", err)
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+func (impl *GitOperationServiceImpl) GetPullRequest(ctx context.Context, repoName string, pullRequestId int) (*PullRequestDetail, error) {
+	pullRequestClient, gitOpsConfig, err := impl.getPullRequestClient()
+	if err != nil {
+		return nil, err
+	}
+	return pullRequestClient.GetPullRequest(ctx, repoName, pullRequestId, gitOpsConfig)
+}
+
+func (impl *GitOperationServiceImpl) MergePullRequest(ctx context.Context, repoName string, pullRequestId int) (*PullRequestDetail, error) {
+	pullRequestClient, gitOpsConfig, err := impl.getPullRequestClient()
+	if err != nil {
+		return nil, err
+	}
+	return pullRequestClient.MergePullRequest(ctx, repoName, pullRequestId, gitOpsConfig)
+}
+
 func (impl *GitOperationServiceImpl) isRetryableGitCommitError(err error) bool {
 	if retryErr := (&retryFunc.RetryableError{}); errors.As(err, &retryErr) {
 		return true
@@ -401,13 +471,42 @@ func (impl *GitOperationServiceImpl) CloneInDir(repoUrl, chartDir string) (strin
 	}
 	return clonedDir, nil
 }
+
+func (impl *GitOperationServiceImpl) ReadFilesFromHead(ctx context.Context, gitOpsRepoName, repoUrl string, filePaths []string) (files map[string]string, commitHash string, err error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOperationServiceImpl.ReadFilesFromHead")
+	defer span.End()
+	chartDir := fmt.Sprintf("%s-%s", gitOpsRepoName, impl.chartTemplateService.GetDir())
+	clonedDir, err := impl.getClonedDir(newCtx, chartDir, repoUrl)
+	defer impl.chartTemplateService.CleanDir(clonedDir)
+	if err != nil {
+		impl.logger.Errorw("error in cloning repo", "url", repoUrl, "err", err)
+		return nil, "", err
+	}
+	commitHash, err = impl.gitFactory.GitOpsHelper.GetHeadCommitHash(clonedDir)
+	if err != nil {
+		return nil, "", err
+	}
+	files = make(map[string]string, len(filePaths))
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filepath.Join(clonedDir, filePath))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			impl.logger.Errorw("error in reading file of cloned repo", "url", repoUrl, "filePath", filePath, "err", err)
+			return nil, "", err
+		}
+		files[filePath] = string(content)
+	}
+	return files, commitHash, nil
+}
+
 func (impl *GitOperationServiceImpl) ReloadGitOpsProvider() error {
 	return impl.gitFactory.Reload(impl.gitOpsConfigReadService)
 }
 
 func (impl *GitOperationServiceImpl) UpdateGitHostUrlByProvider(request *apiBean.GitOpsConfigDto) error {
 	switch strings.ToUpper(request.Provider) {
-	case GITHUB_PROVIDER:
+	case GITHUB_PROVIDER, GITEA_PROVIDER, GOGS_PROVIDER:
 		orgUrl, err := buildGithubOrgUrl(request.Host, request.GitHubOrgId)
 		if err != nil {
 			return err
@@ -443,10 +542,29 @@ func (impl *GitOperationServiceImpl) UpdateGitHostUrlByProvider(request *apiBean
 		}
 	case BITBUCKET_PROVIDER:
 		request.Host = BITBUCKET_CLONE_BASE_URL + request.BitBucketWorkspaceId
+	case BITBUCKET_SERVER_PROVIDER:
+		projectUrl, err := buildBitbucketServerProjectUrl(request.Host, request.BitBucketProjectKey)
+		if err != nil {
+			return err
+		}
+		request.Host = projectUrl
 	}
 	return nil
 }
 
+// buildBitbucketServerProjectUrl returns the prefix of the http clone urls of the repos in the project, https://<host>/scm/<project-key>
+func buildBitbucketServerProjectUrl(host, projectKey string) (projectUrl string, err error) {
+	if !strings.HasPrefix(host, HTTP_URL_PROTOCOL) && !strings.HasPrefix(host, HTTPS_URL_PROTOCOL) {
+		return projectUrl, fmt.Errorf("invalid host url '%s'", host)
+	}
+	hostUrl, err := url.Parse(host)
+	if err != nil {
+		return "", err
+	}
+	hostUrl.Path = path.Join(hostUrl.Path, "scm", strings.ToLower(projectKey))
+	return hostUrl.String(), nil
+}
+
 func buildGithubOrgUrl(host, orgId string) (orgUrl string, err error) {
 	if !strings.HasPrefix(host, HTTP_URL_PROTOCOL) && !strings.HasPrefix(host, HTTPS_URL_PROTOCOL) {
 		return orgUrl, fmt.Errorf("invalid host url '%s'", host)
@@ -25,6 +25,7 @@ import (
 	"github.com/devtron-labs/devtron/util"
 	"github.com/go-pg/pg"
 	"go.uber.org/zap"
+	"strings"
 	"time"
 )
 
@@ -36,6 +37,24 @@ type GitOpsClient interface {
 	CreateReadme(ctx context.Context, config *gitOps.GitOpsConfigDto) (string, error)
 }
 
+// GitOpsPullRequestClient is implemented by the clients of the providers which support the pull request commit strategy,
+// the values are committed on a new branch which reaches the default branch only through a merged pull request
+type GitOpsPullRequestClient interface {
+	// CreateBranch creates the branch from the head of the default branch
+	CreateBranch(ctx context.Context, repoName, branch string, gitOpsConfig *gitOps.GitOpsConfigDto) error
+	CreatePullRequest(ctx context.Context, request *PullRequestConfig, gitOpsConfig *gitOps.GitOpsConfigDto) (*PullRequestDetail, error)
+	GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *gitOps.GitOpsConfigDto) (*PullRequestDetail, error)
+	MergePullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *gitOps.GitOpsConfigDto) (*PullRequestDetail, error)
+}
+
+func IsPullRequestSupportedProvider(provider string) bool {
+	switch strings.ToUpper(provider) {
+	case GITHUB_PROVIDER, GITLAB_PROVIDER, AZURE_DEVOPS_PROVIDER, BITBUCKET_PROVIDER:
+		return true
+	}
+	return false
+}
+
 func GetGitConfig(gitOpsConfigReadService config.GitOpsConfigReadService) (*bean.GitConfig, error) {
 	gitOpsConfig, err := gitOpsConfigReadService.GetGitOpsConfigActive()
 	if err != nil && err != pg.ErrNoRows {
@@ -94,6 +113,12 @@ func NewGitOpsClient(config *bean.GitConfig, logger *zap.SugaredLogger, gitOpsHe
 	} else if config.GitProvider == BITBUCKET_PROVIDER {
 		gitBitbucketClient := NewGitBitbucketClient(config.GitUserName, config.GitToken, config.GitHost, logger, gitOpsHelper, tlsConfig)
 		return gitBitbucketClient, nil
+	} else if config.GitProvider == GITEA_PROVIDER || config.GitProvider == GOGS_PROVIDER {
+		gitGiteaClient, err := NewGitGiteaClient(config.GitHost, config.GitToken, config.GithubOrganization, config.GitProvider == GOGS_PROVIDER, logger, gitOpsHelper, tlsConfig)
+		return gitGiteaClient, err
+	} else if config.GitProvider == BITBUCKET_SERVER_PROVIDER {
+		gitBitbucketServerClient, err := NewGitBitbucketServerClient(config.GitHost, config.GitUserName, config.GitToken, config.BitbucketProjectKey, logger, gitOpsHelper, tlsConfig)
+		return gitBitbucketServerClient, err
 	} else {
 		logger.Errorw("no gitops config provided, gitops will not work ")
 		return nil, nil
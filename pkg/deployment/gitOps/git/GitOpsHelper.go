@@ -105,6 +105,17 @@ func (impl *GitOpsHelper) Pull(repoRoot string) (err error) {
 	return impl.gitCommandManager.Pull(ctx, repoRoot)
 }
 
+// GetHeadCommitHash returns the commit hash of the checked out branch of a cloned repository
+func (impl *GitOpsHelper) GetHeadCommitHash(repoRoot string) (commitHash string, err error) {
+	ctx := git.BuildGitContext(context.Background())
+	commitHash, errMsg, err := impl.gitCommandManager.HeadCommitHash(ctx, repoRoot)
+	if err != nil {
+		impl.logger.Errorw("error in getting head commit hash", "repoRoot", repoRoot, "errMsg", errMsg, "err", err)
+		return "", err
+	}
+	return commitHash, nil
+}
+
 const PushErrorMessage = "failed to push some refs"
 
 func (impl *GitOpsHelper) CommitAndPushAllChanges(ctx context.Context, repoRoot, commitMsg, name, emailId string) (commitHash string, err error) {
@@ -189,6 +200,10 @@ Case BITBUCKET_PROVIDER:
 Case AZURE_DEVOPS_PROVIDER:
   - The clone URL format https://<organisation-name>@dev.azure.com/<organisation-name>/<project-name>/_git/<repo-name>
   - Here the <user-name> can differ from user to user. SanitiseCustomGitRepoURL will return the repo url in format : https://dev.azure.com/<organisation-name>/<project-name>/_git/<repo-name>
+
+Case BITBUCKET_SERVER_PROVIDER:
+  - The clone URL format https://<user-name>@<host>/scm/<project-key>/<repo-name>.git
+  - SanitiseCustomGitRepoURL will return the repo url in format : https://<host>/scm/<project-key>/<repo-name>.git
 */
 func SanitiseCustomGitRepoURL(activeGitOpsConfig apiGitOpsBean.GitOpsConfigDto, gitRepoURL string) (sanitisedGitRepoURL string) {
 	sanitisedGitRepoURL = gitRepoURL
@@ -202,5 +217,8 @@ func SanitiseCustomGitRepoURL(activeGitOpsConfig apiGitOpsBean.GitOpsConfigDto,
 			sanitisedGitRepoURL = strings.ReplaceAll(gitRepoURL, invalidBaseUrlFormat, "://dev.azure.com/")
 		}
 	}
+	if activeGitOpsConfig.Provider == BITBUCKET_SERVER_PROVIDER && strings.Contains(gitRepoURL, fmt.Sprintf("://%s@", activeGitOpsConfig.Username)) {
+		sanitisedGitRepoURL = strings.Replace(gitRepoURL, fmt.Sprintf("://%s@", activeGitOpsConfig.Username), "://", 1)
+	}
 	return sanitisedGitRepoURL
 }
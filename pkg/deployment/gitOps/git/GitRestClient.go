@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GitRestApiError is returned by the rest clients of the git providers which have no sdk, for any non 2xx response
+type GitRestApiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *GitRestApiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func isRestApiErrorWithStatus(err error, statusCode int) bool {
+	apiErr, ok := err.(*GitRestApiError)
+	return ok && apiErr.StatusCode == statusCode
+}
+
+type gitRestClient struct {
+	httpClient *http.Client
+	baseUrl    string
+	setAuth    func(request *http.Request)
+}
+
+func newGitRestClient(httpClient *http.Client, baseUrl string, setAuth func(request *http.Request)) *gitRestClient {
+	return &gitRestClient{
+		httpClient: httpClient,
+		baseUrl:    strings.TrimSuffix(baseUrl, "/"),
+		setAuth:    setAuth,
+	}
+}
+
+// doJson sends the body as json and decodes the json response into response, if given
+func (impl *gitRestClient) doJson(ctx context.Context, method, apiPath string, body, response interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	return impl.do(ctx, method, apiPath, reader, "application/json", response)
+}
+
+func (impl *gitRestClient) do(ctx context.Context, method, apiPath string, body io.Reader, contentType string, response interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, method, impl.baseUrl+apiPath, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", contentType)
+	}
+	request.Header.Set("Accept", "application/json")
+	impl.setAuth(request)
+	resp, err := impl.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &GitRestApiError{StatusCode: resp.StatusCode, Message: string(respBody)}
+	}
+	if response != nil && len(respBody) > 0 {
+		return json.Unmarshal(respBody, response)
+	}
+	return nil
+}
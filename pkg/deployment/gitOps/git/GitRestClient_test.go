@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/devtron-labs/devtron/api/bean"
+	git "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/commandManager"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newTestGitOpsHelper returns a helper working on local repos, the clients check the availability of created repos by cloning them
+func newTestGitOpsHelper() *GitOpsHelper {
+	return NewGitOpsHelperImpl(&git.BasicAuth{Username: "devtron", Password: "token"}, zap.NewNop().Sugar(), &bean.TLSConfig{}, false)
+}
+
+// newTestGitRepo creates a local bare repo with a commit on the default branch and returns its clone url
+func newTestGitRepo(t *testing.T) string {
+	seedDir, repoDir := t.TempDir(), filepath.Join(t.TempDir(), "repo.git")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "master", seedDir},
+		{"-C", seedDir, "-c", "user.name=devtron", "-c", "user.email=devtron@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"clone", "-q", "--bare", seedDir, repoDir},
+	} {
+		output, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Skipf("git is not available: %s", string(output))
+		}
+	}
+	return "file://" + repoDir
+}
+
+// removeClonedRepo removes the clones the availability checks leave in the gitops working dir
+func removeClonedRepo(t *testing.T, repoName string) {
+	t.Cleanup(func() {
+		_ = os.RemoveAll(filepath.Join(GIT_WORKING_DIR, "ensure-clone", repoName))
+	})
+}
+
+func Test_gitRestClient_doJson(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/echo":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+		case "/api/empty":
+			assert.Empty(t, r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+	client := newGitRestClient(server.Client(), server.URL+"/api/", func(request *http.Request) {
+		request.Header.Set("Authorization", "token secret")
+	})
+
+	response := map[string]string{}
+	err := client.doJson(context.Background(), http.MethodPost, "/echo", map[string]string{"name": "repo"}, &response)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"name": "repo"}, response)
+
+	err = client.doJson(context.Background(), http.MethodDelete, "/empty", nil, &response)
+	assert.Nil(t, err)
+
+	err = client.doJson(context.Background(), http.MethodGet, "/missing", nil, &response)
+	assert.True(t, isRestApiErrorWithStatus(err, http.StatusNotFound))
+	assert.False(t, isRestApiErrorWithStatus(err, http.StatusConflict))
+	assert.Equal(t, `404 Not Found: {"message":"not found"}`, err.Error())
+}
+
+func Test_gitRestClient_doJson_InvalidResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html>login</html>"))
+	}))
+	defer server.Close()
+	client := newGitRestClient(server.Client(), server.URL, func(request *http.Request) {})
+	err := client.doJson(context.Background(), http.MethodGet, "/repo", nil, &giteaRepository{})
+	assert.NotNil(t, err)
+	_, isApiErr := err.(*GitRestApiError)
+	assert.False(t, isApiErr)
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+}
+
+func Test_isRestApiErrorWithStatus(t *testing.T) {
+	assert.True(t, isRestApiErrorWithStatus(&GitRestApiError{StatusCode: http.StatusConflict}, http.StatusConflict))
+	assert.False(t, isRestApiErrorWithStatus(io.EOF, http.StatusConflict))
+	assert.False(t, isRestApiErrorWithStatus(nil, http.StatusConflict))
+}
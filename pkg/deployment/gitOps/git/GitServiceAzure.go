@@ -195,8 +195,8 @@ func (impl GitAzureClient) CreateReadme(ctx context.Context, config *bean2.GitOp
 }
 
 func (impl GitAzureClient) CommitValues(ctx context.Context, config *ChartConfig, gitOpsConfig *bean2.GitOpsConfigDto) (commitHash string, commitTime time.Time, err error) {
-	branch := "master"
-	branchfull := "refs/heads/master"
+	branch := config.GetTargetBranch()
+	branchfull := "refs/heads/" + branch
 	path := filepath.Join(config.ChartLocation, config.FileName)
 	newFile := true
 	oldObjId := "0000000000000000000000000000000000000000" //default commit hash
@@ -204,11 +204,16 @@ func (impl GitAzureClient) CommitValues(ctx context.Context, config *ChartConfig
 	// if file does not exist get hash from branch
 	// if branch doesn't exist use default hash
 	clientAzure := *impl.client
-	fc, err := clientAzure.GetItem(ctx, git.GetItemArgs{
+	getItemArgs := git.GetItemArgs{
 		RepositoryId: &config.ChartRepoName,
 		Path:         &path,
 		Project:      &impl.project,
-	})
+	}
+	if !config.IsDefaultBranch() {
+		versionType := git.GitVersionTypeValues.Branch
+		getItemArgs.VersionDescriptor = &git.GitVersionDescriptor{Version: &branch, VersionType: &versionType}
+	}
+	fc, err := clientAzure.GetItem(ctx, getItemArgs)
 	if err != nil {
 		notFoundStatus := 404
 		if e, ok := err.(azuredevops.WrappedError); ok && *e.StatusCode == notFoundStatus {
@@ -360,3 +365,144 @@ func (impl GitAzureClient) ensureProjectAvailabilityOnSsh(projectName string, re
 	}
 	return false, nil
 }
+
+func (impl GitAzureClient) CreateBranch(ctx context.Context, repoName, branch string, gitOpsConfig *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreateBranch", "GitAzureClient", start, err)
+	}()
+	clientAzure := *impl.client
+	defaultBranch := DEFAULT_BRANCH
+	branchStat, err := clientAzure.GetBranch(ctx, git.GetBranchArgs{Project: &impl.project, Name: &defaultBranch, RepositoryId: &repoName})
+	if err != nil {
+		impl.logger.Errorw("error in getting default branch azure", "repoName", repoName, "err", err)
+		return err
+	}
+	branchFull := "refs/heads/" + branch
+	// a ref is created by updating it from the zero object id
+	emptyObjId := "0000000000000000000000000000000000000000"
+	results, err := clientAzure.UpdateRefs(ctx, git.UpdateRefsArgs{
+		RefUpdates:   &[]git.GitRefUpdate{{Name: &branchFull, OldObjectId: &emptyObjId, NewObjectId: branchStat.Commit.CommitId}},
+		RepositoryId: &repoName,
+		Project:      &impl.project,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating branch azure", "repoName", repoName, "branch", branch, "err", err)
+		return err
+	}
+	if results != nil {
+		for _, result := range *results {
+			if result.Success != nil && !*result.Success {
+				err = fmt.Errorf("branch %s could not be created in repo %s", branch, repoName)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (impl GitAzureClient) CreatePullRequest(ctx context.Context, request *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreatePullRequest", "GitAzureClient", start, err)
+	}()
+	clientAzure := *impl.client
+	sourceRef := "refs/heads/" + request.SourceBranch
+	targetRef := "refs/heads/" + DEFAULT_BRANCH
+	pr, err := clientAzure.CreatePullRequest(ctx, git.CreatePullRequestArgs{
+		GitPullRequestToCreate: &git.GitPullRequest{
+			SourceRefName: &sourceRef,
+			TargetRefName: &targetRef,
+			Title:         &request.Title,
+			Description:   &request.Description,
+		},
+		RepositoryId: &request.RepoName,
+		Project:      &impl.project,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request azure", "repoName", request.RepoName, "branch", request.SourceBranch, "err", err)
+		return nil, err
+	}
+	return getAzurePullRequestDetail(pr), nil
+}
+
+func (impl GitAzureClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("GetPullRequest", "GitAzureClient", start, err)
+	}()
+	clientAzure := *impl.client
+	pr, err := clientAzure.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:  &repoName,
+		PullRequestId: &pullRequestId,
+		Project:       &impl.project,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request azure", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getAzurePullRequestDetail(pr), nil
+}
+
+// MergePullRequest completes the pull request, azure devops rejects the completion while any branch policy is not met
+func (impl GitAzureClient) MergePullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("MergePullRequest", "GitAzureClient", start, err)
+	}()
+	clientAzure := *impl.client
+	pr, err := clientAzure.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:  &repoName,
+		PullRequestId: &pullRequestId,
+		Project:       &impl.project,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request azure", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	completed := git.PullRequestStatusValues.Completed
+	pr, err = clientAzure.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			Status:                &completed,
+			LastMergeSourceCommit: pr.LastMergeSourceCommit,
+		},
+		RepositoryId:  &repoName,
+		PullRequestId: &pullRequestId,
+		Project:       &impl.project,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in completing pull request azure", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getAzurePullRequestDetail(pr), nil
+}
+
+func getAzurePullRequestDetail(pr *git.GitPullRequest) *PullRequestDetail {
+	detail := &PullRequestDetail{State: PullRequestStateOpen}
+	if pr.PullRequestId != nil {
+		detail.Id = *pr.PullRequestId
+	}
+	if pr.Repository != nil && pr.Repository.WebUrl != nil {
+		detail.Url = fmt.Sprintf("%s/pullrequest/%d", *pr.Repository.WebUrl, detail.Id)
+	}
+	if pr.MergeStatus != nil {
+		// the merge status only covers conflicts, branch policies are enforced when the pull request is completed
+		detail.IsMergeable = *pr.MergeStatus == git.PullRequestAsyncStatusValues.Succeeded
+	}
+	if pr.Status == nil {
+		return detail
+	}
+	switch *pr.Status {
+	case git.PullRequestStatusValues.Completed:
+		detail.State = PullRequestStateMerged
+		if pr.LastMergeCommit != nil && pr.LastMergeCommit.CommitId != nil {
+			detail.MergeCommitHash = *pr.LastMergeCommit.CommitId
+		}
+		if pr.ClosedDate != nil {
+			detail.MergedOn = pr.ClosedDate.Time
+		}
+	case git.PullRequestStatusValues.Abandoned:
+		detail.State = PullRequestStateClosed
+	}
+	return detail
+}
@@ -306,7 +306,7 @@ func (impl GitBitbucketClient) CommitValues(ctx context.Context, config *ChartCo
 		FilePath: bitbucketCommitFilePath,
 		FileName: fileName,
 		Message:  config.ReleaseMessage,
-		Branch:   "master",
+		Branch:   config.GetTargetBranch(),
 		Author:   authorBitbucket,
 	}
 	repoWriteOptions.WithContext(ctx)
@@ -321,7 +321,7 @@ func (impl GitBitbucketClient) CommitValues(ctx context.Context, config *ChartCo
 	commitOptions := &bitbucket.CommitsOptions{
 		RepoSlug:    config.ChartRepoName,
 		Owner:       gitOpsConfig.BitBucketWorkspaceId,
-		Branchortag: "master",
+		Branchortag: config.GetTargetBranch(),
 	}
 	commits, err := impl.client.Repositories.Commits.GetCommits(commitOptions)
 	if err != nil {
@@ -339,3 +339,122 @@ func (impl GitBitbucketClient) CommitValues(ctx context.Context, config *ChartCo
 	}
 	return commitHash, commitTime, nil
 }
+
+// CreateBranch needs no call for bitbucket, the first commit on a missing branch creates it from the head of the main branch
+func (impl GitBitbucketClient) CreateBranch(ctx context.Context, repoName, branch string, gitOpsConfig *bean2.GitOpsConfigDto) error {
+	return nil
+}
+
+func (impl GitBitbucketClient) CreatePullRequest(ctx context.Context, request *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreatePullRequest", "GitBitbucketClient", start, err)
+	}()
+	options := &bitbucket.PullRequestsOptions{
+		Owner:             gitOpsConfig.BitBucketWorkspaceId,
+		RepoSlug:          request.RepoName,
+		Title:             request.Title,
+		Description:       request.Description,
+		SourceBranch:      request.SourceBranch,
+		DestinationBranch: DEFAULT_BRANCH,
+		CloseSourceBranch: true,
+	}
+	response, err := impl.client.Repositories.PullRequests.Create(options.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request bitbucket", "repoName", request.RepoName, "branch", request.SourceBranch, "err", err)
+		return nil, err
+	}
+	return getBitbucketPullRequestDetail(response), nil
+}
+
+func (impl GitBitbucketClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetPullRequest", "GitBitbucketClient", start, err)
+	}()
+	options := &bitbucket.PullRequestsOptions{
+		Owner:    gitOpsConfig.BitBucketWorkspaceId,
+		RepoSlug: repoName,
+		ID:       strconv.Itoa(pullRequestId),
+	}
+	response, err := impl.client.Repositories.PullRequests.Get(options)
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request bitbucket", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	pullRequest = getBitbucketPullRequestDetail(response)
+	if pullRequest.IsOpen() {
+		// bitbucket has no mergeable flag, the pull request is taken as mergeable once every build status reported on it passed
+		statuses, err := impl.client.Repositories.PullRequests.Statuses(options)
+		if err != nil {
+			impl.logger.Errorw("error in getting pull request statuses bitbucket", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+			return nil, err
+		}
+		pullRequest.IsMergeable = isBitbucketStatusSuccessful(statuses)
+	}
+	return pullRequest, nil
+}
+
+func (impl GitBitbucketClient) MergePullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("MergePullRequest", "GitBitbucketClient", start, err)
+	}()
+	options := &bitbucket.PullRequestsOptions{
+		Owner:             gitOpsConfig.BitBucketWorkspaceId,
+		RepoSlug:          repoName,
+		ID:                strconv.Itoa(pullRequestId),
+		CloseSourceBranch: true,
+	}
+	response, err := impl.client.Repositories.PullRequests.Merge(options.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in merging pull request bitbucket", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getBitbucketPullRequestDetail(response), nil
+}
+
+// getBitbucketPullRequestDetail reads the pull request from the response, reference - https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests
+func getBitbucketPullRequestDetail(response interface{}) *PullRequestDetail {
+	detail := &PullRequestDetail{State: PullRequestStateOpen}
+	pr, ok := response.(map[string]interface{})
+	if !ok {
+		return detail
+	}
+	if id, ok := pr["id"].(float64); ok {
+		detail.Id = int(id)
+	}
+	if links, ok := pr["links"].(map[string]interface{}); ok {
+		if html, ok := links["html"].(map[string]interface{}); ok {
+			detail.Url, _ = html["href"].(string)
+		}
+	}
+	switch pr["state"] {
+	case "MERGED":
+		detail.State = PullRequestStateMerged
+		if mergeCommit, ok := pr["merge_commit"].(map[string]interface{}); ok {
+			detail.MergeCommitHash, _ = mergeCommit["hash"].(string)
+		}
+		if updatedOn, ok := pr["updated_on"].(string); ok {
+			detail.MergedOn, _ = time.Parse(time.RFC3339, updatedOn)
+		}
+	case "DECLINED", "SUPERSEDED":
+		detail.State = PullRequestStateClosed
+	}
+	return detail
+}
+
+func isBitbucketStatusSuccessful(response interface{}) bool {
+	statuses, ok := response.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	values, _ := statuses["values"].([]interface{})
+	for _, value := range values {
+		status, ok := value.(map[string]interface{})
+		if !ok || status["state"] != "SUCCESSFUL" {
+			return false
+		}
+	}
+	return true
+}
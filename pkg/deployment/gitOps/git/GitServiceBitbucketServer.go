@@ -0,0 +1,315 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/util"
+	"github.com/devtron-labs/devtron/util/retryFunc"
+	"go.uber.org/zap"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	BITBUCKET_SERVER_API_PATH       = "/rest/api/1.0"
+	BITBUCKET_SERVER_DEFAULT_BRANCH = "master"
+	BITBUCKET_SERVER_CLONE_HTTP     = "http"
+)
+
+type bitbucketServerLink struct {
+	Href string `json:"href"`
+	Name string `json:"name"`
+}
+
+type bitbucketServerRepository struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	Links struct {
+		Clone []bitbucketServerLink `json:"clone"`
+	} `json:"links"`
+}
+
+// getHttpCloneUrl returns the http clone link without the user name bitbucket server puts in it, https://<host>/scm/<project-key>/<repo>.git
+func (repo *bitbucketServerRepository) getHttpCloneUrl() string {
+	for _, link := range repo.Links.Clone {
+		if link.Name != BITBUCKET_SERVER_CLONE_HTTP {
+			continue
+		}
+		cloneUrl, err := url.Parse(link.Href)
+		if err != nil {
+			return link.Href
+		}
+		cloneUrl.User = nil
+		return cloneUrl.String()
+	}
+	return ""
+}
+
+type bitbucketServerCreateRepoRequest struct {
+	Name  string `json:"name"`
+	ScmId string `json:"scmId"`
+}
+
+type bitbucketServerCommit struct {
+	Id              string `json:"id"`
+	AuthorTimestamp int64  `json:"authorTimestamp"`
+}
+
+type bitbucketServerCommitPage struct {
+	Values []bitbucketServerCommit `json:"values"`
+}
+
+// GitBitbucketServerClient talks to the self hosted Bitbucket Server (Data Center), repos are created under the project of the configured key
+type GitBitbucketServerClient struct {
+	client       *gitRestClient
+	projectKey   string
+	logger       *zap.SugaredLogger
+	gitOpsHelper *GitOpsHelper
+}
+
+func NewGitBitbucketServerClient(host, username, token, projectKey string, logger *zap.SugaredLogger, gitOpsHelper *GitOpsHelper, tlsConfig *tls.Config) (GitBitbucketServerClient, error) {
+	if len(host) == 0 {
+		return GitBitbucketServerClient{}, fmt.Errorf("host is required for bitbucket server")
+	}
+	setAuth := func(request *http.Request) {
+		request.SetBasicAuth(username, token)
+	}
+	client := newGitRestClient(util.GetHTTPClientWithTLSConfig(tlsConfig), host+BITBUCKET_SERVER_API_PATH, setAuth)
+	return GitBitbucketServerClient{
+		client:       client,
+		projectKey:   projectKey,
+		logger:       logger,
+		gitOpsHelper: gitOpsHelper,
+	}, nil
+}
+
+func (impl GitBitbucketServerClient) reposPath() string {
+	return fmt.Sprintf("/projects/%s/repos", url.PathEscape(impl.projectKey))
+}
+
+func (impl GitBitbucketServerClient) repoPath(repoName string) string {
+	// bitbucket server derives the slug of the repo from its name in lower case
+	return impl.reposPath() + "/" + url.PathEscape(strings.ToLower(repoName))
+}
+
+func (impl GitBitbucketServerClient) DeleteRepository(config *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("DeleteRepository", "GitBitbucketServerClient", start, err)
+	}()
+	err = impl.client.doJson(context.Background(), http.MethodDelete, impl.repoPath(config.GitRepoName), nil, nil)
+	if err != nil {
+		impl.logger.Errorw("error in deleting repo bitbucket server", "repoName", config.GitRepoName, "err", err)
+	}
+	return err
+}
+
+func (impl GitBitbucketServerClient) GetRepoUrl(config *bean2.GitOpsConfigDto) (repoUrl string, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetRepoUrl", "GitBitbucketServerClient", start, err)
+	}()
+	repo := &bitbucketServerRepository{}
+	err = impl.client.doJson(context.Background(), http.MethodGet, impl.repoPath(config.GitRepoName), nil, repo)
+	if err != nil {
+		return "", err
+	}
+	return repo.getHttpCloneUrl(), nil
+}
+
+func (impl GitBitbucketServerClient) CreateRepository(ctx context.Context, config *bean2.GitOpsConfigDto) (url string, isNew bool, detailedErrorGitOpsConfigActions DetailedErrorGitOpsConfigActions) {
+	var err error
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateRepository", "GitBitbucketServerClient", start, err)
+	}()
+
+	detailedErrorGitOpsConfigActions.StageErrorMap = make(map[string]error)
+	url, err = impl.GetRepoUrl(config)
+	if err == nil {
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, GetRepoUrlStage)
+		return url, false, detailedErrorGitOpsConfigActions
+	} else if !isRestApiErrorWithStatus(err, http.StatusNotFound) {
+		impl.logger.Errorw("error in communication with bitbucket server", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[GetRepoUrlStage] = err
+		return "", false, detailedErrorGitOpsConfigActions
+	}
+	repo := &bitbucketServerRepository{}
+	createRequest := &bitbucketServerCreateRepoRequest{Name: config.GitRepoName, ScmId: "git"}
+	err = impl.client.doJson(ctx, http.MethodPost, impl.reposPath(), createRequest, repo)
+	if err != nil {
+		impl.logger.Errorw("error in creating repo bitbucket server", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[CreateRepoStage] = err
+		url, err = impl.GetRepoUrl(config)
+		if err != nil {
+			return "", true, detailedErrorGitOpsConfigActions
+		}
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, GetRepoUrlStage)
+		return url, false, detailedErrorGitOpsConfigActions
+	}
+	url = repo.getHttpCloneUrl()
+	impl.logger.Infow("bitbucket server repo created ", "repoUrl", url)
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, CreateRepoStage)
+
+	validated, err := impl.ensureProjectAvailabilityOnHttp(config)
+	if err != nil {
+		impl.logger.Errorw("error in ensuring project availability bitbucket server", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[CloneHttpStage] = err
+		return url, true, detailedErrorGitOpsConfigActions
+	}
+	if !validated {
+		detailedErrorGitOpsConfigActions.StageErrorMap[CloneHttpStage] = fmt.Errorf("unable to validate project:%s in given time", config.GitRepoName)
+		return "", true, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, CloneHttpStage)
+
+	_, err = impl.CreateReadme(ctx, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme bitbucket server", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[CreateReadmeStage] = err
+		return url, true, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, CreateReadmeStage)
+
+	validated, err = ensureProjectAvailabilityOnSsh(impl.gitOpsHelper, impl.logger, config.GitRepoName, url)
+	if err != nil {
+		impl.logger.Errorw("error in ensuring project availability bitbucket server", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[CloneSshStage] = err
+		return url, true, detailedErrorGitOpsConfigActions
+	}
+	if !validated {
+		detailedErrorGitOpsConfigActions.StageErrorMap[CloneSshStage] = fmt.Errorf("unable to validate project:%s in given time", config.GitRepoName)
+		return "", true, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, CloneSshStage)
+	return url, true, detailedErrorGitOpsConfigActions
+}
+
+func (impl GitBitbucketServerClient) ensureProjectAvailabilityOnHttp(config *bean2.GitOpsConfigDto) (bool, error) {
+	for count := 0; count < 3; count++ {
+		_, err := impl.GetRepoUrl(config)
+		if err == nil {
+			return true, nil
+		} else if !isRestApiErrorWithStatus(err, http.StatusNotFound) {
+			impl.logger.Errorw("error in validating repo bitbucket server", "repoName", config.GitRepoName, "err", err)
+			return false, err
+		}
+		impl.logger.Errorw("repo not available on http bitbucket server", "repoName", config.GitRepoName)
+		time.Sleep(10 * time.Second)
+	}
+	return false, nil
+}
+
+func (impl GitBitbucketServerClient) CreateReadme(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	var err error
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateReadme", "GitBitbucketServerClient", start, err)
+	}()
+	cfg := &ChartConfig{
+		ChartName:      config.GitRepoName,
+		ChartLocation:  "",
+		FileName:       "README.md",
+		FileContent:    "@devtron",
+		ReleaseMessage: "pushing readme",
+		ChartRepoName:  config.GitRepoName,
+		UserName:       config.Username,
+		UserEmailId:    config.UserEmailId,
+	}
+	hash, _, err := impl.CommitValues(ctx, cfg, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme bitbucket server", "repoName", config.GitRepoName, "err", err)
+	}
+	return hash, err
+}
+
+// getLatestCommitId returns the last commit on the default branch which touched the file, empty if the file does not exist yet
+func (impl GitBitbucketServerClient) getLatestCommitId(ctx context.Context, repoName, filePath string) (string, error) {
+	query := url.Values{}
+	query.Set("path", filePath)
+	query.Set("until", BITBUCKET_SERVER_DEFAULT_BRANCH)
+	query.Set("limit", "1")
+	commitPage := &bitbucketServerCommitPage{}
+	err := impl.client.doJson(ctx, http.MethodGet, impl.repoPath(repoName)+"/commits?"+query.Encode(), nil, commitPage)
+	if err != nil && isRestApiErrorWithStatus(err, http.StatusNotFound) {
+		// the default branch does not exist before the first commit on an empty repo
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	if len(commitPage.Values) == 0 {
+		return "", nil
+	}
+	return commitPage.Values[0].Id, nil
+}
+
+func (impl GitBitbucketServerClient) CommitValues(ctx context.Context, config *ChartConfig, gitOpsConfig *bean2.GitOpsConfigDto) (commitHash string, commitTime time.Time, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CommitValues", "GitBitbucketServerClient", start, err)
+	}()
+	filePath := path.Join(config.ChartLocation, config.FileName)
+	sourceCommitId, err := impl.getLatestCommitId(ctx, config.ChartRepoName, filePath)
+	if err != nil {
+		impl.logger.Errorw("error in fetching latest commit bitbucket server", "repoName", config.ChartRepoName, "filePath", filePath, "err", err)
+		return "", time.Time{}, err
+	}
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	formFields := map[string]string{
+		"content": config.FileContent,
+		"message": config.ReleaseMessage,
+		"branch":  BITBUCKET_SERVER_DEFAULT_BRANCH,
+	}
+	// the commit the file was last changed in must be given to edit an existing file, and left out to create one
+	if len(sourceCommitId) > 0 {
+		formFields["sourceCommitId"] = sourceCommitId
+	}
+	for key, value := range formFields {
+		err = writer.WriteField(key, value)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	commit := &bitbucketServerCommit{}
+	err = impl.client.do(ctx, http.MethodPut, impl.repoPath(config.ChartRepoName)+"/browse/"+filePath, body, writer.FormDataContentType(), commit)
+	if err != nil && isRestApiErrorWithStatus(err, http.StatusConflict) {
+		impl.logger.Warnw("conflict found in commit bitbucket server", "repoName", config.ChartRepoName, "err", err)
+		return "", time.Time{}, retryFunc.NewRetryableError(err)
+	} else if err != nil {
+		impl.logger.Errorw("error in commit bitbucket server", "repoName", config.ChartRepoName, "err", err)
+		return "", time.Time{}, err
+	}
+	commitTime = time.Now()
+	if commit.AuthorTimestamp > 0 {
+		commitTime = time.UnixMilli(commit.AuthorTimestamp)
+	}
+	return commit.Id, commitTime, nil
+}
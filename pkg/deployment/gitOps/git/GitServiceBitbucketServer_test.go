@@ -0,0 +1,261 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/util/retryFunc"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBitbucketServer serves the bitbucket server repository, commits and browse api for the repos of the DEV project
+type fakeBitbucketServer struct {
+	*httptest.Server
+	lock     sync.Mutex
+	cloneUrl string
+	repos    map[string]bool
+	files    map[string]string
+	commits  []map[string]string
+	failWith map[string]int
+}
+
+func newFakeBitbucketServer(t *testing.T, cloneUrl string) *fakeBitbucketServer {
+	fake := &fakeBitbucketServer{cloneUrl: cloneUrl, repos: map[string]bool{}, files: map[string]string{}, failWith: map[string]int{}}
+	fake.Server = httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+func (fake *fakeBitbucketServer) serve(w http.ResponseWriter, r *http.Request) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	if username, password, ok := r.BasicAuth(); !ok || username != "devtron" || password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if status, ok := fake.failWith[r.Method+" "+r.URL.Path]; ok {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"failed"}]}`))
+		return
+	}
+	apiPath := strings.TrimPrefix(r.URL.Path, BITBUCKET_SERVER_API_PATH+"/projects/DEV/repos")
+	switch {
+	case r.Method == http.MethodPost && len(apiPath) == 0:
+		createRequest := &bitbucketServerCreateRepoRequest{}
+		_ = json.NewDecoder(r.Body).Decode(createRequest)
+		slug := strings.ToLower(createRequest.Name)
+		fake.repos[slug] = true
+		fake.writeJson(w, http.StatusCreated, fake.repository(slug))
+	case strings.Contains(apiPath, "/commits"):
+		filePath := r.URL.Query().Get("path")
+		if len(fake.files) == 0 {
+			// the default branch does not exist on an empty repo
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		page := &bitbucketServerCommitPage{}
+		if commitId, ok := fake.files[filePath]; ok {
+			page.Values = append(page.Values, bitbucketServerCommit{Id: commitId})
+		}
+		fake.writeJson(w, http.StatusOK, page)
+	case strings.Contains(apiPath, "/browse/"):
+		fake.serveBrowse(w, r, apiPath[strings.Index(apiPath, "/browse/")+len("/browse/"):])
+	default:
+		slug := strings.TrimPrefix(apiPath, "/")
+		if !fake.repos[slug] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(fake.repos, slug)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		fake.writeJson(w, http.StatusOK, fake.repository(slug))
+	}
+}
+
+func (fake *fakeBitbucketServer) serveBrowse(w http.ResponseWriter, r *http.Request, filePath string) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	err := r.ParseMultipartForm(1 << 20)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	form := map[string]string{}
+	for key, values := range r.MultipartForm.Value {
+		form[key] = values[0]
+	}
+	// bitbucket server rejects editing a file without the commit it was last changed in
+	if form["sourceCommitId"] != fake.files[filePath] {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	fake.commits = append(fake.commits, form)
+	commitId := fmt.Sprintf("commit-%d", len(fake.commits))
+	fake.files[filePath] = commitId
+	fake.writeJson(w, http.StatusOK, &bitbucketServerCommit{Id: commitId, AuthorTimestamp: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC).UnixMilli()})
+}
+
+func (fake *fakeBitbucketServer) repository(slug string) *bitbucketServerRepository {
+	repo := &bitbucketServerRepository{Slug: slug, Name: slug}
+	repo.Links.Clone = []bitbucketServerLink{{Href: "ssh://git@bitbucket.example.com:7999/dev/" + slug + ".git", Name: "ssh"}, {Href: fake.cloneUrl, Name: BITBUCKET_SERVER_CLONE_HTTP}}
+	return repo
+}
+
+func (fake *fakeBitbucketServer) writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newTestBitbucketServerClient(t *testing.T, host string) GitBitbucketServerClient {
+	client, err := NewGitBitbucketServerClient(host, "devtron", "secret", "DEV", zap.NewNop().Sugar(), newTestGitOpsHelper(), nil)
+	assert.Nil(t, err)
+	return client
+}
+
+func Test_bitbucketServerRepository_getHttpCloneUrl(t *testing.T) {
+	repo := &bitbucketServerRepository{}
+	assert.Empty(t, repo.getHttpCloneUrl())
+	repo.Links.Clone = []bitbucketServerLink{
+		{Href: "ssh://git@bitbucket.example.com:7999/dev/payments.git", Name: "ssh"},
+		{Href: "https://admin@bitbucket.example.com/scm/dev/payments.git", Name: BITBUCKET_SERVER_CLONE_HTTP},
+	}
+	assert.Equal(t, "https://bitbucket.example.com/scm/dev/payments.git", repo.getHttpCloneUrl())
+}
+
+func TestNewGitBitbucketServerClient(t *testing.T) {
+	_, err := NewGitBitbucketServerClient("", "devtron", "secret", "DEV", zap.NewNop().Sugar(), newTestGitOpsHelper(), nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "/projects/DEV/repos/payments-api", newTestBitbucketServerClient(t, "https://bitbucket.example.com").repoPath("Payments-API"))
+}
+
+func TestGitBitbucketServerClient_CreateRepository(t *testing.T) {
+	cloneUrl := newTestGitRepo(t)
+	repoName := fmt.Sprintf("bitbucket-server-create-%d", time.Now().UnixNano())
+	removeClonedRepo(t, repoName)
+	server := newFakeBitbucketServer(t, cloneUrl)
+	client := newTestBitbucketServerClient(t, server.URL)
+	config := &bean2.GitOpsConfigDto{GitRepoName: repoName, Username: "devtron", UserEmailId: "devtron@example.com"}
+
+	url, isNew, detailedErr := client.CreateRepository(context.Background(), config)
+	assert.Equal(t, cloneUrl, url)
+	assert.True(t, isNew)
+	assert.Empty(t, detailedErr.StageErrorMap)
+	assert.Equal(t, []string{CreateRepoStage, CloneHttpStage, CreateReadmeStage, CloneSshStage}, detailedErr.SuccessfulStages)
+	// the readme is the first commit of the empty repo, so it is created without a source commit
+	assert.Len(t, server.commits, 1)
+	assert.Equal(t, map[string]string{"content": "@devtron", "message": "pushing readme", "branch": BITBUCKET_SERVER_DEFAULT_BRANCH}, server.commits[0])
+
+	url, isNew, detailedErr = client.CreateRepository(context.Background(), config)
+	assert.Equal(t, cloneUrl, url)
+	assert.False(t, isNew)
+	assert.Equal(t, []string{GetRepoUrlStage}, detailedErr.SuccessfulStages)
+}
+
+func TestGitBitbucketServerClient_CreateRepository_Errors(t *testing.T) {
+	server := newFakeBitbucketServer(t, "https://bitbucket.example.com/scm/dev/payments.git")
+	client := newTestBitbucketServerClient(t, server.URL)
+	config := &bean2.GitOpsConfigDto{GitRepoName: "payments"}
+	reposPath := BITBUCKET_SERVER_API_PATH + "/projects/DEV/repos"
+
+	server.failWith[http.MethodGet+" "+reposPath+"/payments"] = http.StatusInternalServerError
+	url, isNew, detailedErr := client.CreateRepository(context.Background(), config)
+	assert.Empty(t, url)
+	assert.False(t, isNew)
+	assert.True(t, isRestApiErrorWithStatus(detailedErr.StageErrorMap[GetRepoUrlStage], http.StatusInternalServerError))
+
+	delete(server.failWith, http.MethodGet+" "+reposPath+"/payments")
+	server.failWith[http.MethodPost+" "+reposPath] = http.StatusForbidden
+	url, isNew, detailedErr = client.CreateRepository(context.Background(), config)
+	assert.Empty(t, url)
+	assert.True(t, isNew)
+	assert.True(t, isRestApiErrorWithStatus(detailedErr.StageErrorMap[CreateRepoStage], http.StatusForbidden))
+}
+
+func TestGitBitbucketServerClient_CommitValues(t *testing.T) {
+	server := newFakeBitbucketServer(t, "")
+	server.repos["payments"] = true
+	client := newTestBitbucketServerClient(t, server.URL)
+	chartConfig := &ChartConfig{
+		ChartLocation:  "payments-staging",
+		FileName:       "values.yaml",
+		FileContent:    "replicaCount: 2",
+		ReleaseMessage: "scale up",
+		ChartRepoName:  "payments",
+	}
+
+	commitHash, commitTime, err := client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.Nil(t, err)
+	assert.Equal(t, "commit-1", commitHash)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), commitTime.UTC())
+
+	// an existing file is edited on top of the commit it was last changed in
+	chartConfig.FileContent = "replicaCount: 3"
+	commitHash, _, err = client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.Nil(t, err)
+	assert.Equal(t, "commit-2", commitHash)
+	assert.Equal(t, "commit-1", server.commits[1]["sourceCommitId"])
+	assert.Equal(t, "replicaCount: 3", server.commits[1]["content"])
+}
+
+func TestGitBitbucketServerClient_CommitValues_Errors(t *testing.T) {
+	server := newFakeBitbucketServer(t, "")
+	client := newTestBitbucketServerClient(t, server.URL)
+	chartConfig := &ChartConfig{ChartLocation: "payments-staging", FileName: "values.yaml", ChartRepoName: "payments"}
+	repoPath := BITBUCKET_SERVER_API_PATH + "/projects/DEV/repos/payments"
+
+	server.failWith[http.MethodPut+" "+repoPath+"/browse/payments-staging/values.yaml"] = http.StatusConflict
+	_, _, err := client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	_, isRetryable := err.(*retryFunc.RetryableError)
+	assert.True(t, isRetryable)
+
+	server.failWith[http.MethodPut+" "+repoPath+"/browse/payments-staging/values.yaml"] = http.StatusBadRequest
+	_, _, err = client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.True(t, isRestApiErrorWithStatus(err, http.StatusBadRequest))
+
+	server.failWith[http.MethodGet+" "+repoPath+"/commits"] = http.StatusUnauthorized
+	_, _, err = client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.True(t, isRestApiErrorWithStatus(err, http.StatusUnauthorized))
+}
+
+func TestGitBitbucketServerClient_DeleteRepository(t *testing.T) {
+	server := newFakeBitbucketServer(t, "")
+	server.repos["payments"] = true
+	client := newTestBitbucketServerClient(t, server.URL)
+
+	err := client.DeleteRepository(&bean2.GitOpsConfigDto{GitRepoName: "Payments"})
+	assert.Nil(t, err)
+	assert.False(t, server.repos["payments"])
+
+	err = client.DeleteRepository(&bean2.GitOpsConfigDto{GitRepoName: "Payments"})
+	assert.True(t, isRestApiErrorWithStatus(err, http.StatusNotFound))
+}
@@ -0,0 +1,349 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/util"
+	"github.com/devtron-labs/devtron/util/retryFunc"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	GITEA_API_PATH       = "/api/v1"
+	GITEA_DEFAULT_BRANCH = "master"
+)
+
+type giteaRepository struct {
+	Name     string `json:"name"`
+	CloneUrl string `json:"clone_url"`
+}
+
+type giteaCreateRepoOptions struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Private       bool   `json:"private"`
+	AutoInit      bool   `json:"auto_init"`
+	Readme        string `json:"readme"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+type giteaIdentity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type giteaFileOptions struct {
+	Branch    string         `json:"branch"`
+	Content   string         `json:"content"`
+	Message   string         `json:"message"`
+	Sha       string         `json:"sha,omitempty"`
+	Author    *giteaIdentity `json:"author"`
+	Committer *giteaIdentity `json:"committer"`
+}
+
+type giteaContent struct {
+	Sha string `json:"sha"`
+}
+
+type giteaFileResponse struct {
+	Commit struct {
+		Sha    string `json:"sha"`
+		Author struct {
+			Date time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+// GitGiteaClient serves Gitea and Gogs, Gogs being the project Gitea was forked from they share the repository api.
+// Gogs has no api to write files, commits to it are pushed from a clone
+type GitGiteaClient struct {
+	client       *gitRestClient
+	org          string
+	isGogs       bool
+	logger       *zap.SugaredLogger
+	gitOpsHelper *GitOpsHelper
+}
+
+func NewGitGiteaClient(host, token, org string, isGogs bool, logger *zap.SugaredLogger, gitOpsHelper *GitOpsHelper, tlsConfig *tls.Config) (GitGiteaClient, error) {
+	if len(host) == 0 {
+		return GitGiteaClient{}, fmt.Errorf("host is required for gitea and gogs")
+	}
+	setAuth := func(request *http.Request) {
+		request.Header.Set("Authorization", "token "+token)
+	}
+	client := newGitRestClient(util.GetHTTPClientWithTLSConfig(tlsConfig), host+GITEA_API_PATH, setAuth)
+	return GitGiteaClient{
+		client:       client,
+		org:          org,
+		isGogs:       isGogs,
+		logger:       logger,
+		gitOpsHelper: gitOpsHelper,
+	}, nil
+}
+
+func (impl GitGiteaClient) repoPath(repoName string) string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(impl.org), url.PathEscape(repoName))
+}
+
+func (impl GitGiteaClient) createRepoPath() string {
+	if impl.isGogs {
+		return fmt.Sprintf("/org/%s/repos", url.PathEscape(impl.org))
+	}
+	return fmt.Sprintf("/orgs/%s/repos", url.PathEscape(impl.org))
+}
+
+func (impl GitGiteaClient) DeleteRepository(config *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("DeleteRepository", "GitGiteaClient", start, err)
+	}()
+	err = impl.client.doJson(context.Background(), http.MethodDelete, impl.repoPath(config.GitRepoName), nil, nil)
+	if err != nil {
+		impl.logger.Errorw("error in deleting repo gitea", "repoName", config.GitRepoName, "err", err)
+	}
+	return err
+}
+
+func (impl GitGiteaClient) GetRepoUrl(config *bean2.GitOpsConfigDto) (repoUrl string, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetRepoUrl", "GitGiteaClient", start, err)
+	}()
+	repo := &giteaRepository{}
+	err = impl.client.doJson(context.Background(), http.MethodGet, impl.repoPath(config.GitRepoName), nil, repo)
+	if err != nil {
+		return "", err
+	}
+	return repo.CloneUrl, nil
+}
+
+func (impl GitGiteaClient) CreateRepository(ctx context.Context, config *bean2.GitOpsConfigDto) (url string, isNew bool, detailedErrorGitOpsConfigActions DetailedErrorGitOpsConfigActions) {
+	var err error
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+	}()
+
+	detailedErrorGitOpsConfigActions.StageErrorMap = make(map[string]error)
+	url, err = impl.GetRepoUrl(config)
+	if err == nil {
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, GetRepoUrlStage)
+		return url, false, detailedErrorGitOpsConfigActions
+	} else if !isRestApiErrorWithStatus(err, http.StatusNotFound) {
+		impl.logger.Errorw("error in communication with gitea", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[GetRepoUrlStage] = err
+		return "", false, detailedErrorGitOpsConfigActions
+	}
+	// the repo is initialised so that gogs, which cannot write files over its api, has a branch to clone and push to
+	createOptions := &giteaCreateRepoOptions{
+		Name:          config.GitRepoName,
+		Description:   config.Description,
+		Private:       true,
+		AutoInit:      true,
+		Readme:        "Default",
+		DefaultBranch: GITEA_DEFAULT_BRANCH,
+	}
+	createPath := impl.createRepoPath()
+	repo := &giteaRepository{}
+	err = impl.client.doJson(ctx, http.MethodPost, createPath, createOptions, repo)
+	if err != nil {
+		impl.logger.Errorw("error in creating repo gitea", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[CreateRepoStage] = err
+		url, err = impl.GetRepoUrl(config)
+		if err != nil {
+			return "", true, detailedErrorGitOpsConfigActions
+		}
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, GetRepoUrlStage)
+		return url, false, detailedErrorGitOpsConfigActions
+	}
+	url = repo.CloneUrl
+	impl.logger.Infow("gitea repo created ", "repoUrl", url)
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, CreateRepoStage)
+
+	validated, err := impl.ensureProjectAvailabilityOnHttp(config)
+	if err != nil {
+		impl.logger.Errorw("error in ensuring project availability gitea", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[CloneHttpStage] = err
+		return url, true, detailedErrorGitOpsConfigActions
+	}
+	if !validated {
+		detailedErrorGitOpsConfigActions.StageErrorMap[CloneHttpStage] = fmt.Errorf("unable to validate project:%s in given time", config.GitRepoName)
+		return "", true, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, CloneHttpStage)
+
+	_, err = impl.CreateReadme(ctx, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme gitea", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[CreateReadmeStage] = err
+		return url, true, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, CreateReadmeStage)
+
+	validated, err = ensureProjectAvailabilityOnSsh(impl.gitOpsHelper, impl.logger, config.GitRepoName, url)
+	if err != nil {
+		impl.logger.Errorw("error in ensuring project availability gitea", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[CloneSshStage] = err
+		return url, true, detailedErrorGitOpsConfigActions
+	}
+	if !validated {
+		detailedErrorGitOpsConfigActions.StageErrorMap[CloneSshStage] = fmt.Errorf("unable to validate project:%s in given time", config.GitRepoName)
+		return "", true, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, CloneSshStage)
+	return url, true, detailedErrorGitOpsConfigActions
+}
+
+func (impl GitGiteaClient) ensureProjectAvailabilityOnHttp(config *bean2.GitOpsConfigDto) (bool, error) {
+	for count := 0; count < 3; count++ {
+		_, err := impl.GetRepoUrl(config)
+		if err == nil {
+			return true, nil
+		} else if !isRestApiErrorWithStatus(err, http.StatusNotFound) {
+			impl.logger.Errorw("error in validating repo gitea", "repoName", config.GitRepoName, "err", err)
+			return false, err
+		}
+		impl.logger.Errorw("repo not available on http gitea", "repoName", config.GitRepoName)
+		time.Sleep(10 * time.Second)
+	}
+	return false, nil
+}
+
+func (impl GitGiteaClient) CreateReadme(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	var err error
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateReadme", "GitGiteaClient", start, err)
+	}()
+	cfg := &ChartConfig{
+		ChartName:      config.GitRepoName,
+		ChartLocation:  "",
+		FileName:       "README.md",
+		FileContent:    "@devtron",
+		ReleaseMessage: "pushing readme",
+		ChartRepoName:  config.GitRepoName,
+		UserName:       config.Username,
+		UserEmailId:    config.UserEmailId,
+	}
+	hash, _, err := impl.CommitValues(ctx, cfg, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme gitea", "repoName", config.GitRepoName, "err", err)
+	}
+	return hash, err
+}
+
+func (impl GitGiteaClient) CommitValues(ctx context.Context, config *ChartConfig, gitOpsConfig *bean2.GitOpsConfigDto) (commitHash string, commitTime time.Time, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CommitValues", "GitGiteaClient", start, err)
+	}()
+	if impl.isGogs {
+		return impl.commitByPush(ctx, config, gitOpsConfig)
+	}
+	filePath := filepath.Join(config.ChartLocation, config.FileName)
+	contentPath := fmt.Sprintf("%s/contents/%s", impl.repoPath(config.ChartRepoName), filePath)
+	existing := &giteaContent{}
+	err = impl.client.doJson(ctx, http.MethodGet, contentPath+"?ref="+GITEA_DEFAULT_BRANCH, nil, existing)
+	if err != nil && !isRestApiErrorWithStatus(err, http.StatusNotFound) {
+		impl.logger.Errorw("error in fetching file from gitea", "repoName", config.ChartRepoName, "filePath", filePath, "err", err)
+		return "", time.Time{}, err
+	}
+	author := &giteaIdentity{Name: config.UserName, Email: config.UserEmailId}
+	fileOptions := &giteaFileOptions{
+		Branch:    GITEA_DEFAULT_BRANCH,
+		Content:   base64.StdEncoding.EncodeToString([]byte(config.FileContent)),
+		Message:   config.ReleaseMessage,
+		Sha:       existing.Sha,
+		Author:    author,
+		Committer: author,
+	}
+	// gitea creates files with POST and updates existing ones, identified by their sha, with PUT
+	method := http.MethodPost
+	if len(existing.Sha) > 0 {
+		method = http.MethodPut
+	}
+	fileResponse := &giteaFileResponse{}
+	err = impl.client.doJson(ctx, method, contentPath, fileOptions, fileResponse)
+	if err != nil && isRestApiErrorWithStatus(err, http.StatusConflict) {
+		impl.logger.Warnw("conflict found in commit gitea", "repoName", config.ChartRepoName, "err", err)
+		return "", time.Time{}, retryFunc.NewRetryableError(err)
+	} else if err != nil {
+		impl.logger.Errorw("error in commit gitea", "repoName", config.ChartRepoName, "err", err)
+		return "", time.Time{}, err
+	}
+	commitTime = time.Now()
+	if !fileResponse.Commit.Author.Date.IsZero() {
+		commitTime = fileResponse.Commit.Author.Date
+	}
+	return fileResponse.Commit.Sha, commitTime, nil
+}
+
+func (impl GitGiteaClient) commitByPush(ctx context.Context, config *ChartConfig, gitOpsConfig *bean2.GitOpsConfigDto) (string, time.Time, error) {
+	repoUrl, err := impl.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: config.ChartRepoName})
+	if err != nil {
+		impl.logger.Errorw("error in getting repo url gogs", "repoName", config.ChartRepoName, "err", err)
+		return "", time.Time{}, err
+	}
+	return commitFileByPush(ctx, impl.gitOpsHelper, repoUrl, config)
+}
+
+// commitFileByPush commits the file of the chart config from a fresh clone of the repo, for providers which cannot write files over their api
+func commitFileByPush(ctx context.Context, gitOpsHelper *GitOpsHelper, repoUrl string, config *ChartConfig) (string, time.Time, error) {
+	clonedDir, err := gitOpsHelper.Clone(repoUrl, fmt.Sprintf("commit-%s-%s", config.ChartRepoName, getDir()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer os.RemoveAll(clonedDir)
+	filePath := filepath.Join(clonedDir, config.ChartLocation, config.FileName)
+	err = os.MkdirAll(filepath.Dir(filePath), os.ModePerm)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	err = os.WriteFile(filePath, []byte(config.FileContent), 0666)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	commitHash, err := gitOpsHelper.CommitAndPushAllChanges(ctx, clonedDir, config.ReleaseMessage, config.UserName, config.UserEmailId)
+	if err != nil {
+		return "", time.Time{}, retryFunc.NewRetryableError(err)
+	}
+	return commitHash, time.Now(), nil
+}
+
+// ensureProjectAvailabilityOnSsh checks that the repo can be cloned with the gitops credentials
+func ensureProjectAvailabilityOnSsh(gitOpsHelper *GitOpsHelper, logger *zap.SugaredLogger, repoName string, repoUrl string) (bool, error) {
+	for count := 0; count < 3; count++ {
+		_, err := gitOpsHelper.Clone(repoUrl, fmt.Sprintf("/ensure-clone/%s", repoName))
+		if err == nil {
+			logger.Infow("ensureProjectAvailability clone passed", "try count", count, "repoUrl", repoUrl)
+			return true, nil
+		}
+		logger.Errorw("ensureProjectAvailability clone failed", "try count", count, "err", err)
+		time.Sleep(10 * time.Second)
+	}
+	return false, nil
+}
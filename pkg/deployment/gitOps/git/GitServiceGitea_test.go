@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/util/retryFunc"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGiteaServer serves the gitea repository and contents api for the repos of the devtron org
+type fakeGiteaServer struct {
+	*httptest.Server
+	lock     sync.Mutex
+	cloneUrl string
+	repos    map[string]bool
+	files    map[string]string
+	commits  []*giteaFileOptions
+	methods  []string
+	failWith map[string]int
+}
+
+func newFakeGiteaServer(t *testing.T, cloneUrl string) *fakeGiteaServer {
+	fake := &fakeGiteaServer{cloneUrl: cloneUrl, repos: map[string]bool{}, files: map[string]string{}, failWith: map[string]int{}}
+	fake.Server = httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+func (fake *fakeGiteaServer) serve(w http.ResponseWriter, r *http.Request) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	if r.Header.Get("Authorization") != "token secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if status, ok := fake.failWith[r.Method+" "+r.URL.Path]; ok {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"message":"failed"}`))
+		return
+	}
+	apiPath := strings.TrimPrefix(r.URL.Path, GITEA_API_PATH)
+	switch {
+	case r.Method == http.MethodPost && (apiPath == "/orgs/devtron/repos" || apiPath == "/org/devtron/repos"):
+		options := &giteaCreateRepoOptions{}
+		_ = json.NewDecoder(r.Body).Decode(options)
+		if !options.Private || !options.AutoInit || options.DefaultBranch != GITEA_DEFAULT_BRANCH {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		fake.repos[options.Name] = true
+		fake.writeJson(w, http.StatusCreated, &giteaRepository{Name: options.Name, CloneUrl: fake.cloneUrl})
+	case strings.Contains(apiPath, "/contents/"):
+		fake.serveContents(w, r, apiPath)
+	case strings.HasPrefix(apiPath, "/repos/devtron/"):
+		repoName := strings.TrimPrefix(apiPath, "/repos/devtron/")
+		if !fake.repos[repoName] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(fake.repos, repoName)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fake.writeJson(w, http.StatusOK, &giteaRepository{Name: repoName, CloneUrl: fake.cloneUrl})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (fake *fakeGiteaServer) serveContents(w http.ResponseWriter, r *http.Request, apiPath string) {
+	filePath := apiPath[strings.Index(apiPath, "/contents/")+len("/contents/"):]
+	sha, exists := fake.files[filePath]
+	if r.Method == http.MethodGet {
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fake.writeJson(w, http.StatusOK, &giteaContent{Sha: sha})
+		return
+	}
+	options := &giteaFileOptions{}
+	_ = json.NewDecoder(r.Body).Decode(options)
+	// gitea rejects creating an existing file and updating one with a stale sha
+	if (r.Method == http.MethodPost && exists) || (r.Method == http.MethodPut && options.Sha != sha) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	fake.methods = append(fake.methods, r.Method)
+	fake.commits = append(fake.commits, options)
+	fake.files[filePath] = fmt.Sprintf("sha-%d", len(fake.commits))
+	response := &giteaFileResponse{}
+	response.Commit.Sha = fmt.Sprintf("commit-%d", len(fake.commits))
+	response.Commit.Author.Date = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	fake.writeJson(w, http.StatusCreated, response)
+}
+
+func (fake *fakeGiteaServer) writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newTestGiteaClient(t *testing.T, host string, isGogs bool) GitGiteaClient {
+	client, err := NewGitGiteaClient(host, "secret", "devtron", isGogs, zap.NewNop().Sugar(), newTestGitOpsHelper(), nil)
+	assert.Nil(t, err)
+	return client
+}
+
+func TestNewGitGiteaClient(t *testing.T) {
+	_, err := NewGitGiteaClient("", "secret", "devtron", false, zap.NewNop().Sugar(), newTestGitOpsHelper(), nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "/orgs/devtron/repos", newTestGiteaClient(t, "https://gitea.example.com", false).createRepoPath())
+	assert.Equal(t, "/org/devtron/repos", newTestGiteaClient(t, "https://gogs.example.com", true).createRepoPath())
+}
+
+func TestGitGiteaClient_CreateRepository(t *testing.T) {
+	cloneUrl := newTestGitRepo(t)
+	repoName := fmt.Sprintf("gitea-create-%d", time.Now().UnixNano())
+	removeClonedRepo(t, repoName)
+	server := newFakeGiteaServer(t, cloneUrl)
+	client := newTestGiteaClient(t, server.URL, false)
+	config := &bean2.GitOpsConfigDto{GitRepoName: repoName, Description: "payments", Username: "devtron", UserEmailId: "devtron@example.com"}
+
+	url, isNew, detailedErr := client.CreateRepository(context.Background(), config)
+	assert.Equal(t, cloneUrl, url)
+	assert.True(t, isNew)
+	assert.Empty(t, detailedErr.StageErrorMap)
+	assert.Equal(t, []string{CreateRepoStage, CloneHttpStage, CreateReadmeStage, CloneSshStage}, detailedErr.SuccessfulStages)
+	// the readme bootstraps the repo, it is committed on the default branch as a new file
+	assert.Equal(t, []string{http.MethodPost}, server.methods)
+	assert.Equal(t, GITEA_DEFAULT_BRANCH, server.commits[0].Branch)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("@devtron")), server.commits[0].Content)
+	assert.Equal(t, "devtron@example.com", server.commits[0].Author.Email)
+
+	url, isNew, detailedErr = client.CreateRepository(context.Background(), config)
+	assert.Equal(t, cloneUrl, url)
+	assert.False(t, isNew)
+	assert.Equal(t, []string{GetRepoUrlStage}, detailedErr.SuccessfulStages)
+}
+
+func TestGitGiteaClient_CreateRepository_Errors(t *testing.T) {
+	server := newFakeGiteaServer(t, "https://gitea.example.com/devtron/payments.git")
+	client := newTestGiteaClient(t, server.URL, false)
+	config := &bean2.GitOpsConfigDto{GitRepoName: "payments"}
+
+	server.failWith[http.MethodGet+" "+GITEA_API_PATH+"/repos/devtron/payments"] = http.StatusInternalServerError
+	url, isNew, detailedErr := client.CreateRepository(context.Background(), config)
+	assert.Empty(t, url)
+	assert.False(t, isNew)
+	assert.True(t, isRestApiErrorWithStatus(detailedErr.StageErrorMap[GetRepoUrlStage], http.StatusInternalServerError))
+
+	delete(server.failWith, http.MethodGet+" "+GITEA_API_PATH+"/repos/devtron/payments")
+	server.failWith[http.MethodPost+" "+GITEA_API_PATH+"/orgs/devtron/repos"] = http.StatusForbidden
+	url, isNew, detailedErr = client.CreateRepository(context.Background(), config)
+	assert.Empty(t, url)
+	assert.True(t, isNew)
+	assert.True(t, isRestApiErrorWithStatus(detailedErr.StageErrorMap[CreateRepoStage], http.StatusForbidden))
+	assert.Empty(t, detailedErr.SuccessfulStages)
+}
+
+func TestGitGiteaClient_CommitValues(t *testing.T) {
+	server := newFakeGiteaServer(t, "")
+	server.repos["payments"] = true
+	client := newTestGiteaClient(t, server.URL, false)
+	chartConfig := &ChartConfig{
+		ChartLocation:  "payments-staging",
+		FileName:       "values.yaml",
+		FileContent:    "replicaCount: 2",
+		ReleaseMessage: "scale up",
+		ChartRepoName:  "payments",
+		UserName:       "devtron",
+		UserEmailId:    "devtron@example.com",
+	}
+
+	commitHash, commitTime, err := client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.Nil(t, err)
+	assert.Equal(t, "commit-1", commitHash)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), commitTime)
+
+	// an existing file is updated with the sha it was last read at
+	chartConfig.FileContent = "replicaCount: 3"
+	commitHash, _, err = client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.Nil(t, err)
+	assert.Equal(t, "commit-2", commitHash)
+	assert.Equal(t, []string{http.MethodPost, http.MethodPut}, server.methods)
+	assert.Equal(t, "sha-1", server.commits[1].Sha)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("replicaCount: 3")), server.commits[1].Content)
+}
+
+func TestGitGiteaClient_CommitValues_Errors(t *testing.T) {
+	server := newFakeGiteaServer(t, "")
+	client := newTestGiteaClient(t, server.URL, false)
+	chartConfig := &ChartConfig{ChartLocation: "payments-staging", FileName: "values.yaml", ChartRepoName: "payments"}
+	contentPath := GITEA_API_PATH + "/repos/devtron/payments/contents/payments-staging/values.yaml"
+
+	server.failWith[http.MethodPost+" "+contentPath] = http.StatusConflict
+	_, _, err := client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	_, isRetryable := err.(*retryFunc.RetryableError)
+	assert.True(t, isRetryable)
+
+	server.failWith[http.MethodPost+" "+contentPath] = http.StatusInternalServerError
+	_, _, err = client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.True(t, isRestApiErrorWithStatus(err, http.StatusInternalServerError))
+
+	server.failWith[http.MethodGet+" "+contentPath] = http.StatusUnauthorized
+	_, _, err = client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.True(t, isRestApiErrorWithStatus(err, http.StatusUnauthorized))
+}
+
+func TestGitGiteaClient_CommitValues_Gogs(t *testing.T) {
+	cloneUrl := newTestGitRepo(t)
+	server := newFakeGiteaServer(t, cloneUrl)
+	server.repos["payments"] = true
+	client := newTestGiteaClient(t, server.URL, true)
+	chartConfig := &ChartConfig{
+		ChartLocation:  "payments-staging",
+		FileName:       "values.yaml",
+		FileContent:    "replicaCount: 2",
+		ReleaseMessage: "scale up",
+		ChartRepoName:  "payments",
+		UserName:       "devtron",
+		UserEmailId:    "devtron@example.com",
+	}
+
+	// gogs cannot write files over its api, the file is pushed from a clone instead
+	commitHash, _, err := client.CommitValues(context.Background(), chartConfig, &bean2.GitOpsConfigDto{})
+	assert.Nil(t, err)
+	assert.Empty(t, server.commits)
+	output, err := exec.Command("git", "--git-dir", strings.TrimPrefix(cloneUrl, "file://"), "show", "master:payments-staging/values.yaml").CombinedOutput()
+	assert.Nil(t, err, string(output))
+	assert.Equal(t, "replicaCount: 2", string(output))
+	output, err = exec.Command("git", "--git-dir", strings.TrimPrefix(cloneUrl, "file://"), "rev-parse", "master").CombinedOutput()
+	assert.Nil(t, err, string(output))
+	assert.Equal(t, strings.TrimSpace(string(output)), commitHash)
+}
+
+func TestGitGiteaClient_DeleteRepository(t *testing.T) {
+	server := newFakeGiteaServer(t, "")
+	server.repos["payments"] = true
+	client := newTestGiteaClient(t, server.URL, false)
+
+	err := client.DeleteRepository(&bean2.GitOpsConfigDto{GitRepoName: "payments"})
+	assert.Nil(t, err)
+	assert.False(t, server.repos["payments"])
+
+	err = client.DeleteRepository(&bean2.GitOpsConfigDto{GitRepoName: "payments"})
+	assert.True(t, isRestApiErrorWithStatus(err, http.StatusNotFound))
+}
+
+func TestGitGiteaClient_Unauthorized(t *testing.T) {
+	server := newFakeGiteaServer(t, "")
+	client, err := NewGitGiteaClient(server.URL, "expired", "devtron", false, zap.NewNop().Sugar(), newTestGitOpsHelper(), nil)
+	assert.Nil(t, err)
+	_, err = client.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: "payments"})
+	assert.True(t, isRestApiErrorWithStatus(err, http.StatusUnauthorized))
+}
@@ -214,7 +214,7 @@ func (impl GitHubClient) CommitValues(ctx context.Context, config *ChartConfig,
 		globalUtil.TriggerGitOpsMetrics("CommitValues", "GitHubClient", start, err)
 	}()
 
-	branch := "master"
+	branch := config.GetTargetBranch()
 	path := filepath.Join(config.ChartLocation, config.FileName)
 	newFile := false
 	fc, _, _, err := impl.client.Repositories.GetContents(ctx, impl.org, config.ChartRepoName, path, &github.RepositoryContentGetOptions{Ref: branch})
@@ -335,3 +335,87 @@ func (impl GitHubClient) ensureProjectAvailabilityOnSsh(projectName string, repo
 	}
 	return false, nil
 }
+
+func (impl GitHubClient) CreateBranch(ctx context.Context, repoName, branch string, gitOpsConfig *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreateBranch", "GitHubClient", start, err)
+	}()
+	defaultRef, _, err := impl.client.Git.GetRef(ctx, impl.org, repoName, "refs/heads/"+DEFAULT_BRANCH)
+	if err != nil {
+		impl.logger.Errorw("error in getting default branch github", "repoName", repoName, "err", err)
+		return err
+	}
+	newRef := "refs/heads/" + branch
+	_, _, err = impl.client.Git.CreateRef(ctx, impl.org, repoName, &github.Reference{
+		Ref:    &newRef,
+		Object: &github.GitObject{SHA: defaultRef.Object.SHA},
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating branch github", "repoName", repoName, "branch", branch, "err", err)
+	}
+	return err
+}
+
+func (impl GitHubClient) CreatePullRequest(ctx context.Context, request *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreatePullRequest", "GitHubClient", start, err)
+	}()
+	base := DEFAULT_BRANCH
+	pr, _, err := impl.client.PullRequests.Create(ctx, impl.org, request.RepoName, &github.NewPullRequest{
+		Title: &request.Title,
+		Head:  &request.SourceBranch,
+		Base:  &base,
+		Body:  &request.Description,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request github", "repoName", request.RepoName, "branch", request.SourceBranch, "err", err)
+		return nil, err
+	}
+	return impl.getPullRequestDetail(pr), nil
+}
+
+func (impl GitHubClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("GetPullRequest", "GitHubClient", start, err)
+	}()
+	pr, _, err := impl.client.PullRequests.Get(ctx, impl.org, repoName, pullRequestId)
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request github", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return impl.getPullRequestDetail(pr), nil
+}
+
+func (impl GitHubClient) MergePullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("MergePullRequest", "GitHubClient", start, err)
+	}()
+	_, _, err = impl.client.PullRequests.Merge(ctx, impl.org, repoName, pullRequestId, "", &github.PullRequestOptions{})
+	if err != nil {
+		impl.logger.Errorw("error in merging pull request github", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return impl.GetPullRequest(ctx, repoName, pullRequestId, gitOpsConfig)
+}
+
+func (impl GitHubClient) getPullRequestDetail(pr *github.PullRequest) *PullRequestDetail {
+	detail := &PullRequestDetail{
+		Id:    pr.GetNumber(),
+		Url:   pr.GetHTMLURL(),
+		State: PullRequestStateOpen,
+		// github reports clean only when the branch has no conflicts and all required status checks passed
+		IsMergeable: pr.GetMergeable() && pr.GetMergeableState() == "clean",
+	}
+	if pr.GetMerged() {
+		detail.State = PullRequestStateMerged
+		detail.MergeCommitHash = pr.GetMergeCommitSHA()
+		detail.MergedOn = pr.GetMergedAt()
+	} else if pr.GetState() == "closed" {
+		detail.State = PullRequestStateClosed
+	}
+	return detail
+}
@@ -346,7 +346,7 @@ func (impl GitLabClient) CommitValues(ctx context.Context, config *ChartConfig,
 		util.TriggerGitOpsMetrics("CommitValues", "GitLabClient", start, err)
 	}()
 
-	branch := "master"
+	branch := config.GetTargetBranch()
 	path := filepath.Join(config.ChartLocation, config.FileName)
 	exists, err := impl.checkIfFileExists(config.ChartRepoName, branch, path)
 	var fileAction gitlab.FileActionValue
@@ -376,3 +376,97 @@ func (impl GitLabClient) CommitValues(ctx context.Context, config *ChartConfig,
 	}
 	return c.ID, commitTime, err
 }
+
+func (impl GitLabClient) getProjectPath(repoName string) string {
+	return fmt.Sprintf("%s/%s", impl.config.GitlabGroupPath, repoName)
+}
+
+func (impl GitLabClient) CreateBranch(ctx context.Context, repoName, branch string, gitOpsConfig *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateBranch", "GitLabClient", start, err)
+	}()
+	_, _, err = impl.client.Branches.CreateBranch(impl.getProjectPath(repoName), &gitlab.CreateBranchOptions{
+		Branch: gitlab.String(branch),
+		Ref:    gitlab.String(DEFAULT_BRANCH),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in creating branch gitlab", "repoName", repoName, "branch", branch, "err", err)
+	}
+	return err
+}
+
+func (impl GitLabClient) CreatePullRequest(ctx context.Context, request *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreatePullRequest", "GitLabClient", start, err)
+	}()
+	mr, _, err := impl.client.MergeRequests.CreateMergeRequest(impl.getProjectPath(request.RepoName), &gitlab.CreateMergeRequestOptions{
+		Title:              gitlab.String(request.Title),
+		Description:        gitlab.String(request.Description),
+		SourceBranch:       gitlab.String(request.SourceBranch),
+		TargetBranch:       gitlab.String(DEFAULT_BRANCH),
+		RemoveSourceBranch: gitlab.Bool(true),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in creating merge request gitlab", "repoName", request.RepoName, "branch", request.SourceBranch, "err", err)
+		return nil, err
+	}
+	return getGitlabPullRequestDetail(mr), nil
+}
+
+func (impl GitLabClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetPullRequest", "GitLabClient", start, err)
+	}()
+	mr, _, err := impl.client.MergeRequests.GetMergeRequest(impl.getProjectPath(repoName), pullRequestId, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in getting merge request gitlab", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getGitlabPullRequestDetail(mr), nil
+}
+
+func (impl GitLabClient) MergePullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequestDetail, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("MergePullRequest", "GitLabClient", start, err)
+	}()
+	mr, _, err := impl.client.MergeRequests.AcceptMergeRequest(impl.getProjectPath(repoName), pullRequestId, &gitlab.AcceptMergeRequestOptions{
+		ShouldRemoveSourceBranch: gitlab.Bool(true),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in merging merge request gitlab", "repoName", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getGitlabPullRequestDetail(mr), nil
+}
+
+func getGitlabPullRequestDetail(mr *gitlab.MergeRequest) *PullRequestDetail {
+	detail := &PullRequestDetail{
+		Id:    mr.IID,
+		Url:   mr.WebURL,
+		State: PullRequestStateOpen,
+		// gitlab reports mergeable only when there are no conflicts, the pipeline succeeded and the required approvals are given
+		IsMergeable: mr.DetailedMergeStatus == "mergeable",
+	}
+	switch mr.State {
+	case "merged":
+		detail.State = PullRequestStateMerged
+		detail.MergeCommitHash = mr.MergeCommitSHA
+		if len(detail.MergeCommitHash) == 0 {
+			// fast-forward and squash merges leave no merge commit
+			detail.MergeCommitHash = mr.SquashCommitSHA
+		}
+		if len(detail.MergeCommitHash) == 0 {
+			detail.MergeCommitHash = mr.SHA
+		}
+		if mr.MergedAt != nil {
+			detail.MergedOn = *mr.MergedAt
+		}
+	case "closed", "locked":
+		detail.State = PullRequestStateClosed
+	}
+	return detail
+}
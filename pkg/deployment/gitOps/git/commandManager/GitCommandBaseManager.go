@@ -32,6 +32,7 @@ type GitCommandManagerBase interface {
 	Fetch(ctx GitContext, rootDir string) (response, errMsg string, err error)
 	ListBranch(ctx GitContext, rootDir string) (response, errMsg string, err error)
 	PullCli(ctx GitContext, rootDir string, branch string) (response, errMsg string, err error)
+	HeadCommitHash(ctx GitContext, rootDir string) (response, errMsg string, err error)
 }
 
 type GitManagerBaseImpl struct {
@@ -96,6 +97,19 @@ func (impl *GitManagerBaseImpl) PullCli(ctx GitContext, rootDir string, branch s
 	return output, errMsg, err
 }
 
+func (impl *GitManagerBaseImpl) HeadCommitHash(ctx GitContext, rootDir string) (response, errMsg string, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("HeadCommitHash", "GitCli", start, err)
+	}()
+	impl.logger.Debugw("git rev-parse ", "location", rootDir)
+	cmd, cancel := impl.createCmdWithContext(ctx, "git", "-C", rootDir, "rev-parse", "HEAD")
+	defer cancel()
+	output, errMsg, err := impl.runCommand(cmd)
+	impl.logger.Debugw("rev-parse output", "root", rootDir, "opt", output, "errMsg", errMsg, "error", err)
+	return output, errMsg, err
+}
+
 func (impl *GitManagerBaseImpl) runCommandWithCred(cmd *exec.Cmd, auth *BasicAuth, tlsPathInfo *git_manager.TlsPathInfo) (response, errMsg string, err error) {
 	cmd.Env = append(os.Environ(),
 		fmt.Sprintf("GIT_ASKPASS=%s", GIT_ASK_PASS),
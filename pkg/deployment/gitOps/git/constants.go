@@ -17,17 +17,21 @@
 package git
 
 const (
-	GIT_WORKING_DIR       = "/tmp/gitops/"
-	GetRepoUrlStage       = "Get Repo RedirectionUrl"
-	CreateRepoStage       = "Create Repo"
-	CloneHttpStage        = "Clone Http"
-	CreateReadmeStage     = "Create Readme"
-	CloneSshStage         = "Clone Ssh"
-	GITLAB_PROVIDER       = "GITLAB"
-	GITHUB_PROVIDER       = "GITHUB"
-	AZURE_DEVOPS_PROVIDER = "AZURE_DEVOPS"
-	BITBUCKET_PROVIDER    = "BITBUCKET_CLOUD"
-	GITHUB_API_V3         = "api/v3"
-	GITHUB_HOST           = "github.com"
-	GIT_TLS_DIR           = "/tmp/gitops/tls"
+	GIT_WORKING_DIR           = "/tmp/gitops/"
+	GetRepoUrlStage           = "Get Repo RedirectionUrl"
+	CreateRepoStage           = "Create Repo"
+	CloneHttpStage            = "Clone Http"
+	CreateReadmeStage         = "Create Readme"
+	CloneSshStage             = "Clone Ssh"
+	GITLAB_PROVIDER           = "GITLAB"
+	GITHUB_PROVIDER           = "GITHUB"
+	AZURE_DEVOPS_PROVIDER     = "AZURE_DEVOPS"
+	BITBUCKET_PROVIDER        = "BITBUCKET_CLOUD"
+	GITEA_PROVIDER            = "GITEA"
+	GOGS_PROVIDER             = "GOGS"
+	BITBUCKET_SERVER_PROVIDER = "BITBUCKET_SERVER"
+	GITHUB_API_V3             = "api/v3"
+	GITHUB_HOST               = "github.com"
+	DEFAULT_BRANCH            = "master"
+	GIT_TLS_DIR               = "/tmp/gitops/tls"
 )
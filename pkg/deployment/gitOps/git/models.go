@@ -37,6 +37,7 @@ type ChartConfig struct {
 	ChartRepoName    string
 	UserName         string
 	UserEmailId      string
+	TargetBranch     string // branch to commit on, the default branch if empty
 	bitBucketBaseDir string // base directory is required for bitbucket to load the
 }
 
@@ -48,3 +49,47 @@ func (c *ChartConfig) SetBitBucketBaseDir(dir string) {
 func (c *ChartConfig) GetBitBucketBaseDir() string {
 	return c.bitBucketBaseDir
 }
+
+func (c *ChartConfig) GetTargetBranch() string {
+	if len(c.TargetBranch) == 0 {
+		return DEFAULT_BRANCH
+	}
+	return c.TargetBranch
+}
+
+func (c *ChartConfig) IsDefaultBranch() bool {
+	return c.GetTargetBranch() == DEFAULT_BRANCH
+}
+
+type PullRequestState string
+
+const (
+	PullRequestStateOpen   PullRequestState = "OPEN"
+	PullRequestStateMerged PullRequestState = "MERGED"
+	PullRequestStateClosed PullRequestState = "CLOSED"
+)
+
+type PullRequestConfig struct {
+	RepoName     string
+	SourceBranch string
+	Title        string
+	Description  string
+}
+
+type PullRequestDetail struct {
+	Id    int
+	Url   string
+	State PullRequestState
+	// IsMergeable is set when the provider reports no conflicts and all required checks passed
+	IsMergeable     bool
+	MergeCommitHash string
+	MergedOn        time.Time
+}
+
+func (detail *PullRequestDetail) IsOpen() bool {
+	return detail.State == PullRequestStateOpen
+}
+
+func (detail *PullRequestDetail) IsMerged() bool {
+	return detail.State == PullRequestStateMerged
+}
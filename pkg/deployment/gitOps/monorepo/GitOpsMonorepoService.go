@@ -0,0 +1,493 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monorepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	"github.com/devtron-labs/devtron/internal/sql/repository/app"
+	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	chartRepoRepository "github.com/devtron-labs/devtron/pkg/chartRepo/repository"
+	"github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/common"
+	commonBean "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo/bean"
+	monorepoRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/pkg/team"
+	"github.com/devtron-labs/devtron/util/argo"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type GitOpsMonorepoService interface {
+	Save(request *bean.GitOpsMonorepoDto) (*bean.GitOpsMonorepoDto, error)
+	GetAll() ([]*bean.GitOpsMonorepoDto, error)
+	GetById(id int) (*bean.GitOpsMonorepoDto, error)
+	Delete(id int, userId int32) error
+	// FindForApp returns the shared GitOps repository new applications of the project of appId go to, nil if there is none
+	FindForApp(appId int) (*bean.GitOpsMonorepoDto, error)
+	// GetChartPathPrefix returns the directory of an application environment in repoUrl,
+	// empty if repoUrl is not a shared GitOps repository
+	GetChartPathPrefix(appId, envId int, repoUrl string, userId int32) (string, error)
+	MigrateApps(request *bean.MigrateAppsRequest) ([]*bean.MigratedAppDto, error)
+}
+
+type GitOpsMonorepoServiceImpl struct {
+	logger                   *zap.SugaredLogger
+	gitOpsMonorepoRepository monorepoRepository.GitOpsMonorepoRepository
+	teamRepository           team.TeamRepository
+	appRepository            app.AppRepository
+	environmentRepository    repository.EnvironmentRepository
+	chartRepository          chartRepoRepository.ChartRepository
+	pipelineRepository       pipelineConfig.PipelineRepository
+	deploymentConfigService  common.DeploymentConfigService
+	gitOperationService      git.GitOperationService
+	argoClientWrapperService argocdServer.ArgoClientWrapperService
+	argoUserService          argo.ArgoUserService
+}
+
+func NewGitOpsMonorepoServiceImpl(logger *zap.SugaredLogger,
+	gitOpsMonorepoRepository monorepoRepository.GitOpsMonorepoRepository,
+	teamRepository team.TeamRepository,
+	appRepository app.AppRepository,
+	environmentRepository repository.EnvironmentRepository,
+	chartRepository chartRepoRepository.ChartRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	deploymentConfigService common.DeploymentConfigService,
+	gitOperationService git.GitOperationService,
+	argoClientWrapperService argocdServer.ArgoClientWrapperService,
+	argoUserService argo.ArgoUserService) *GitOpsMonorepoServiceImpl {
+	return &GitOpsMonorepoServiceImpl{
+		logger:                   logger,
+		gitOpsMonorepoRepository: gitOpsMonorepoRepository,
+		teamRepository:           teamRepository,
+		appRepository:            appRepository,
+		environmentRepository:    environmentRepository,
+		chartRepository:          chartRepository,
+		pipelineRepository:       pipelineRepository,
+		deploymentConfigService:  deploymentConfigService,
+		gitOperationService:      gitOperationService,
+		argoClientWrapperService: argoClientWrapperService,
+		argoUserService:          argoUserService,
+	}
+}
+
+func (impl *GitOpsMonorepoServiceImpl) Save(request *bean.GitOpsMonorepoDto) (*bean.GitOpsMonorepoDto, error) {
+	if err := ValidatePathTemplate(request.PathTemplate); err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	existing, err := impl.gitOpsMonorepoRepository.FindActiveByTeamId(request.TeamId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in getting shared gitops repository of team", "teamId", request.TeamId, "err", err)
+		return nil, err
+	}
+	if err == nil && existing.Id != request.Id {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusConflict).WithUserMessage(bean.MonorepoAlreadyExists).WithInternalMessage(bean.MonorepoAlreadyExists)
+	}
+	if request.Id > 0 {
+		return impl.update(request)
+	}
+	ctx, err := impl.getArgoCdContext()
+	if err != nil {
+		return nil, err
+	}
+	chartGitAttribute, err := impl.gitOperationService.CreateGitRepositoryForDevtronApp(ctx, request.RepoName, request.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in creating shared gitops repository", "repoName", request.RepoName, "err", err)
+		return nil, err
+	}
+	err = impl.argoClientWrapperService.RegisterGitOpsRepoInArgoWithRetry(ctx, chartGitAttribute.RepoUrl, request.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in registering shared gitops repository in argocd", "repoUrl", chartGitAttribute.RepoUrl, "err", err)
+		return nil, err
+	}
+	monorepo := &monorepoRepository.GitOpsMonorepo{
+		Name:         request.Name,
+		RepoName:     request.RepoName,
+		RepoUrl:      chartGitAttribute.RepoUrl,
+		PathTemplate: request.PathTemplate,
+		TeamId:       request.TeamId,
+		Active:       true,
+		AuditLog:     sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.gitOpsMonorepoRepository.Save(monorepo)
+	if err != nil {
+		impl.logger.Errorw("error in saving shared gitops repository", "monorepo", monorepo, "err", err)
+		return nil, err
+	}
+	request.Id = monorepo.Id
+	request.RepoUrl = monorepo.RepoUrl
+	return request, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) update(request *bean.GitOpsMonorepoDto) (*bean.GitOpsMonorepoDto, error) {
+	monorepo, err := impl.gitOpsMonorepoRepository.FindById(request.Id)
+	if err != nil {
+		impl.logger.Errorw("error in getting shared gitops repository", "id", request.Id, "err", err)
+		return nil, err
+	}
+	if monorepo.RepoName != request.RepoName {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.RepoNameNotUpdatable).WithInternalMessage(bean.RepoNameNotUpdatable)
+	}
+	monorepo.Name = request.Name
+	monorepo.PathTemplate = request.PathTemplate
+	monorepo.TeamId = request.TeamId
+	monorepo.UpdatedBy = request.UserId
+	monorepo.UpdatedOn = time.Now()
+	err = impl.gitOpsMonorepoRepository.Update(monorepo)
+	if err != nil {
+		impl.logger.Errorw("error in updating shared gitops repository", "monorepo", monorepo, "err", err)
+		return nil, err
+	}
+	request.RepoUrl = monorepo.RepoUrl
+	return request, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) GetAll() ([]*bean.GitOpsMonorepoDto, error) {
+	monorepos, err := impl.gitOpsMonorepoRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in getting shared gitops repositories", "err", err)
+		return nil, err
+	}
+	result := make([]*bean.GitOpsMonorepoDto, 0, len(monorepos))
+	for _, monorepo := range monorepos {
+		dto, err := impl.toDto(monorepo)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dto)
+	}
+	return result, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) GetById(id int) (*bean.GitOpsMonorepoDto, error) {
+	monorepo, err := impl.gitOpsMonorepoRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in getting shared gitops repository", "id", id, "err", err)
+		return nil, err
+	}
+	return impl.toDto(monorepo)
+}
+
+func (impl *GitOpsMonorepoServiceImpl) Delete(id int, userId int32) error {
+	monorepo, err := impl.gitOpsMonorepoRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in getting shared gitops repository", "id", id, "err", err)
+		return err
+	}
+	// applications already in the repository keep using it, only new applications stop going there
+	monorepo.Active = false
+	monorepo.UpdatedBy = userId
+	monorepo.UpdatedOn = time.Now()
+	err = impl.gitOpsMonorepoRepository.Update(monorepo)
+	if err != nil {
+		impl.logger.Errorw("error in deleting shared gitops repository", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) FindForApp(appId int) (*bean.GitOpsMonorepoDto, error) {
+	application, err := impl.appRepository.FindById(appId)
+	if err != nil {
+		impl.logger.Errorw("error in getting app", "appId", appId, "err", err)
+		return nil, err
+	}
+	monorepo, err := impl.gitOpsMonorepoRepository.FindActiveByTeamId(application.TeamId)
+	if err == pg.ErrNoRows {
+		monorepo, err = impl.gitOpsMonorepoRepository.FindActiveByTeamId(0)
+	}
+	if err == pg.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in getting shared gitops repository for app", "appId", appId, "teamId", application.TeamId, "err", err)
+		return nil, err
+	}
+	return impl.toDto(monorepo)
+}
+
+func (impl *GitOpsMonorepoServiceImpl) GetChartPathPrefix(appId, envId int, repoUrl string, userId int32) (string, error) {
+	if gitOps.IsGitOpsRepoNotConfigured(repoUrl) {
+		return "", nil
+	}
+	monorepo, err := impl.gitOpsMonorepoRepository.FindByRepoUrl(repoUrl)
+	if err == pg.ErrNoRows {
+		return "", nil
+	} else if err != nil {
+		impl.logger.Errorw("error in getting shared gitops repository by url", "repoUrl", repoUrl, "err", err)
+		return "", err
+	}
+	application, err := impl.appRepository.FindById(appId)
+	if err != nil {
+		impl.logger.Errorw("error in getting app", "appId", appId, "err", err)
+		return "", err
+	}
+	monorepoPath, err := impl.getOrResolvePath(monorepo, application, envId)
+	if err != nil {
+		return "", err
+	}
+	if monorepoPath.Id == 0 {
+		monorepoPath.AuditLog = sql.NewDefaultAuditLog(userId)
+		err = impl.gitOpsMonorepoRepository.SavePath(nil, monorepoPath)
+		if err != nil {
+			// a concurrent deployment of the same environment may have saved it first
+			savedPath, findErr := impl.gitOpsMonorepoRepository.FindPath(monorepo.Id, appId, envId)
+			if findErr != nil {
+				impl.logger.Errorw("error in saving shared gitops repository path", "path", monorepoPath, "err", err)
+				return "", err
+			}
+			monorepoPath = savedPath
+		}
+	}
+	return monorepoPath.Path, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) MigrateApps(request *bean.MigrateAppsRequest) ([]*bean.MigratedAppDto, error) {
+	monorepo, err := impl.gitOpsMonorepoRepository.FindById(request.MonorepoId)
+	if err != nil {
+		impl.logger.Errorw("error in getting shared gitops repository", "id", request.MonorepoId, "err", err)
+		return nil, err
+	}
+	apps, err := impl.getAppsToMigrate(monorepo, request.AppIds)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*bean.MigratedAppDto, 0, len(apps))
+	for _, application := range apps {
+		migratedApp, err := impl.migrateApp(monorepo, application, request.DryRun, request.UserId)
+		if err != nil {
+			impl.logger.Errorw("error in migrating app to shared gitops repository", "appId", application.Id, "monorepoId", monorepo.Id, "err", err)
+			return nil, err
+		}
+		result = append(result, migratedApp)
+	}
+	return result, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) getAppsToMigrate(monorepo *monorepoRepository.GitOpsMonorepo, appIds []int) ([]*app.App, error) {
+	if len(appIds) == 0 {
+		if monorepo.TeamId == 0 {
+			return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.DefaultMonorepoAppIds).WithInternalMessage(bean.DefaultMonorepoAppIds)
+		}
+		teamApps, err := impl.appRepository.FindAppsByTeamId(monorepo.TeamId)
+		if err != nil {
+			impl.logger.Errorw("error in getting apps of team", "teamId", monorepo.TeamId, "err", err)
+			return nil, err
+		}
+		apps := make([]*app.App, 0, len(teamApps))
+		for _, teamApp := range teamApps {
+			if teamApp.AppType == helper.CustomApp {
+				apps = append(apps, teamApp)
+			}
+		}
+		return apps, nil
+	}
+	apps := make([]*app.App, 0, len(appIds))
+	for _, appId := range appIds {
+		application, err := impl.appRepository.FindById(appId)
+		if err != nil {
+			impl.logger.Errorw("error in getting app", "appId", appId, "err", err)
+			return nil, err
+		}
+		if monorepo.TeamId > 0 && application.TeamId != monorepo.TeamId {
+			message := fmt.Sprintf(bean.AppNotInMonorepoTeam, appId)
+			return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(message).WithInternalMessage(message)
+		}
+		apps = append(apps, application)
+	}
+	return apps, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) migrateApp(monorepo *monorepoRepository.GitOpsMonorepo, application *app.App, dryRun bool, userId int32) (*bean.MigratedAppDto, error) {
+	migratedApp := &bean.MigratedAppDto{
+		AppId:   application.Id,
+		AppName: application.AppName,
+	}
+	appConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(application.Id, 0)
+	if err != nil {
+		return nil, err
+	}
+	migratedApp.PreviousRepoUrl = appConfig.RepoURL
+	switch {
+	case common.IsCustomGitOpsRepo(appConfig.ConfigType):
+		migratedApp.SkipReason = bean.SkippedCustomRepository
+		return migratedApp, nil
+	case gitOps.IsGitOpsRepoNotConfigured(appConfig.RepoURL):
+		migratedApp.SkipReason = bean.SkippedNotUsingGitOps
+		return migratedApp, nil
+	case appConfig.RepoURL == monorepo.RepoUrl:
+		migratedApp.SkipReason = bean.SkippedAlreadyInMonorepo
+		return migratedApp, nil
+	}
+	pipelines, err := impl.pipelineRepository.FindActiveByAppId(application.Id)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in getting pipelines of app", "appId", application.Id, "err", err)
+		return nil, err
+	}
+	paths := make([]*monorepoRepository.GitOpsMonorepoPath, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		monorepoPath, err := impl.getOrResolvePath(monorepo, application, pipeline.EnvironmentId)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, monorepoPath)
+		migratedApp.Paths = append(migratedApp.Paths, &bean.MigratedEnvPath{
+			EnvId:   pipeline.EnvironmentId,
+			EnvName: pipeline.Environment.Name,
+			Path:    monorepoPath.Path,
+		})
+	}
+	if dryRun {
+		return migratedApp, nil
+	}
+	err = impl.switchAppRepoUrl(monorepo, application.Id, paths, userId)
+	if err != nil {
+		return nil, err
+	}
+	for _, pipeline := range pipelines {
+		err = impl.deploymentConfigService.UpdateRepoUrlForAppAndEnvId(monorepo.RepoUrl, application.Id, pipeline.EnvironmentId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	migratedApp.Migrated = true
+	return migratedApp, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) switchAppRepoUrl(monorepo *monorepoRepository.GitOpsMonorepo, appId int, paths []*monorepoRepository.GitOpsMonorepoPath, userId int32) error {
+	charts, err := impl.chartRepository.FindActiveChartsByAppId(appId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in getting charts of app", "appId", appId, "err", err)
+		return err
+	}
+	tx, err := impl.chartRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.chartRepository.RollbackTx(tx)
+	for _, chart := range charts {
+		chart.GitRepoUrl = monorepo.RepoUrl
+		chart.UpdatedBy = userId
+		chart.UpdatedOn = time.Now()
+	}
+	if len(charts) > 0 {
+		err = impl.chartRepository.UpdateAllInTx(tx, charts)
+		if err != nil {
+			impl.logger.Errorw("error in updating charts of app", "appId", appId, "err", err)
+			return err
+		}
+	}
+	for _, monorepoPath := range paths {
+		if monorepoPath.Id > 0 {
+			continue
+		}
+		monorepoPath.AuditLog = sql.NewDefaultAuditLog(userId)
+		err = impl.gitOpsMonorepoRepository.SavePath(tx, monorepoPath)
+		if err != nil {
+			impl.logger.Errorw("error in saving shared gitops repository path", "path", monorepoPath, "err", err)
+			return err
+		}
+	}
+	err = impl.chartRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return err
+	}
+	appConfig := &commonBean.DeploymentConfig{
+		AppId:      appId,
+		ConfigType: common.GetDeploymentConfigType(false),
+		RepoURL:    monorepo.RepoUrl,
+		Active:     true,
+	}
+	_, err = impl.deploymentConfigService.CreateOrUpdateConfig(nil, appConfig, userId)
+	if err != nil {
+		impl.logger.Errorw("error in updating app level deployment config", "appId", appId, "err", err)
+		return err
+	}
+	return nil
+}
+
+// getOrResolvePath returns the saved path of the application environment, or resolves a new unsaved one from the template
+func (impl *GitOpsMonorepoServiceImpl) getOrResolvePath(monorepo *monorepoRepository.GitOpsMonorepo, application *app.App, envId int) (*monorepoRepository.GitOpsMonorepoPath, error) {
+	monorepoPath, err := impl.gitOpsMonorepoRepository.FindPath(monorepo.Id, application.Id, envId)
+	if err == nil {
+		return monorepoPath, nil
+	} else if err != pg.ErrNoRows {
+		impl.logger.Errorw("error in getting shared gitops repository path", "monorepoId", monorepo.Id, "appId", application.Id, "envId", envId, "err", err)
+		return nil, err
+	}
+	values := bean.PathValues{App: application.AppName}
+	teamDetails, err := impl.teamRepository.FindOne(application.TeamId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in getting team", "teamId", application.TeamId, "err", err)
+		return nil, err
+	}
+	values.Team = teamDetails.Name
+	env, err := impl.environmentRepository.FindById(envId)
+	if err != nil {
+		impl.logger.Errorw("error in getting environment", "envId", envId, "err", err)
+		return nil, err
+	}
+	values.Env = env.Name
+	if env.Cluster != nil {
+		values.Cluster = env.Cluster.ClusterName
+	}
+	return &monorepoRepository.GitOpsMonorepoPath{
+		MonorepoId: monorepo.Id,
+		AppId:      application.Id,
+		EnvId:      envId,
+		Path:       ResolvePath(monorepo.PathTemplate, values),
+	}, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) toDto(monorepo *monorepoRepository.GitOpsMonorepo) (*bean.GitOpsMonorepoDto, error) {
+	dto := &bean.GitOpsMonorepoDto{
+		Id:           monorepo.Id,
+		Name:         monorepo.Name,
+		RepoName:     monorepo.RepoName,
+		RepoUrl:      monorepo.RepoUrl,
+		PathTemplate: monorepo.PathTemplate,
+		TeamId:       monorepo.TeamId,
+	}
+	if monorepo.TeamId > 0 {
+		teamDetails, err := impl.teamRepository.FindOne(monorepo.TeamId)
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("error in getting team", "teamId", monorepo.TeamId, "err", err)
+			return nil, err
+		}
+		dto.TeamName = teamDetails.Name
+	}
+	return dto, nil
+}
+
+func (impl *GitOpsMonorepoServiceImpl) getArgoCdContext() (context.Context, error) {
+	acdToken, err := impl.argoUserService.GetLatestDevtronArgoCdUserToken()
+	if err != nil {
+		impl.logger.Errorw("error in getting acd token", "err", err)
+		return nil, err
+	}
+	return context.WithValue(context.Background(), "token", acdToken), nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+const (
+	PathVariableTeam    = "{{team}}"
+	PathVariableApp     = "{{app}}"
+	PathVariableEnv     = "{{env}}"
+	PathVariableCluster = "{{cluster}}"
+)
+
+const (
+	InvalidPathTemplate      = "invalid path template %q, expected directories separated by '/' made of letters, digits, '.', '_', '-' and the variables {{team}}, {{app}}, {{env}}, {{cluster}}"
+	PathTemplateWithoutApp   = "path template %q must contain {{app}}, so that every application gets its own directory"
+	MonorepoAlreadyExists    = "a shared GitOps repository is already configured for this project"
+	RepoNameNotUpdatable     = "repository of a shared GitOps repository can not be changed, create a new one instead"
+	DefaultMonorepoAppIds    = "application ids are required to migrate applications to the default shared GitOps repository"
+	AppNotInMonorepoTeam     = "application %d does not belong to the project of the shared GitOps repository"
+	SkippedCustomRepository  = "application uses a custom GitOps repository"
+	SkippedNotUsingGitOps    = "application has no GitOps repository yet, it will use the shared GitOps repository on its first deployment"
+	SkippedAlreadyInMonorepo = "application already uses the shared GitOps repository"
+)
+
+// GitOpsMonorepoDto is a GitOps repository shared by the applications of a project, or by all the applications
+// not covered by a project level one if TeamId is not set. Every application (or application environment) gets
+// its own directory in it, as per PathTemplate
+type GitOpsMonorepoDto struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name" validate:"required,max=50"`
+	RepoName     string `json:"repoName" validate:"required"`
+	RepoUrl      string `json:"repoUrl"`
+	PathTemplate string `json:"pathTemplate" validate:"required"`
+	TeamId       int    `json:"teamId"`
+	TeamName     string `json:"teamName,omitempty"`
+	UserId       int32  `json:"-"`
+}
+
+// PathValues are the values of the path template variables for an application environment
+type PathValues struct {
+	Team    string
+	App     string
+	Env     string
+	Cluster string
+}
+
+// MigrateAppsRequest moves the existing applications of the project of a shared GitOps repository to it.
+// Only the configuration is switched, manifests are pushed to the new path on the next deployment
+// and the ArgoCd applications are re-pointed then
+type MigrateAppsRequest struct {
+	MonorepoId int   `json:"-"`
+	AppIds     []int `json:"appIds"`
+	DryRun     bool  `json:"dryRun"`
+	UserId     int32 `json:"-"`
+}
+
+type MigratedEnvPath struct {
+	EnvId   int    `json:"envId"`
+	EnvName string `json:"envName"`
+	Path    string `json:"path"`
+}
+
+type MigratedAppDto struct {
+	AppId           int                `json:"appId"`
+	AppName         string             `json:"appName"`
+	PreviousRepoUrl string             `json:"previousRepoUrl,omitempty"`
+	Paths           []*MigratedEnvPath `json:"paths,omitempty"`
+	Migrated        bool               `json:"migrated"`
+	SkipReason      string             `json:"skipReason,omitempty"`
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monorepo
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo/bean"
+	"regexp"
+	"strings"
+)
+
+var (
+	pathSegmentRegex   = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+	invalidValueRegex  = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+	pathVariableRegex  = regexp.MustCompile(`{{[a-z]+}}`)
+	pathVariables      = []string{bean.PathVariableTeam, bean.PathVariableApp, bean.PathVariableEnv, bean.PathVariableCluster}
+	pathVariableMarker = "x"
+)
+
+// ValidatePathTemplate checks that every directory of the template is made of allowed characters and known variables,
+// and that the template contains {{app}}
+func ValidatePathTemplate(template string) error {
+	if !strings.Contains(template, bean.PathVariableApp) {
+		return fmt.Errorf(bean.PathTemplateWithoutApp, template)
+	}
+	marked := pathVariableRegex.ReplaceAllStringFunc(template, func(variable string) string {
+		for _, knownVariable := range pathVariables {
+			if variable == knownVariable {
+				return pathVariableMarker
+			}
+		}
+		return variable
+	})
+	for _, segment := range strings.Split(marked, "/") {
+		if !pathSegmentRegex.MatchString(segment) || segment == "." || segment == ".." {
+			return fmt.Errorf(bean.InvalidPathTemplate, template)
+		}
+	}
+	return nil
+}
+
+// ResolvePath fills the variables of a valid path template, characters not allowed in a directory are replaced with '-'
+func ResolvePath(template string, values bean.PathValues) string {
+	replacer := strings.NewReplacer(
+		bean.PathVariableTeam, sanitisePathValue(values.Team),
+		bean.PathVariableApp, sanitisePathValue(values.App),
+		bean.PathVariableEnv, sanitisePathValue(values.Env),
+		bean.PathVariableCluster, sanitisePathValue(values.Cluster),
+	)
+	return replacer.Replace(template)
+}
+
+func sanitisePathValue(value string) string {
+	value = invalidValueRegex.ReplaceAllString(value, "-")
+	if len(value) == 0 || value == "." || value == ".." {
+		// keeps the directory valid for values which are not set, e.g. team of an application without a project
+		return "default"
+	}
+	return value
+}
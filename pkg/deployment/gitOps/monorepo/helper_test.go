@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monorepo
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo/bean"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidatePathTemplate(t *testing.T) {
+	validTemplates := []string{
+		"{{app}}",
+		"{{team}}/{{app}}/{{env}}",
+		"clusters/{{cluster}}/apps/{{app}}-{{env}}",
+		"apps/v1.0/{{app}}",
+	}
+	for _, template := range validTemplates {
+		assert.NoError(t, ValidatePathTemplate(template), template)
+	}
+	invalidTemplates := []string{
+		"",
+		"{{team}}/{{env}}",
+		"/{{app}}",
+		"{{app}}/",
+		"{{team}}//{{app}}",
+		"../{{app}}",
+		"{{app}}/{{namespace}}",
+		"{{app}}/my dir",
+		"{{ app }}",
+	}
+	for _, template := range invalidTemplates {
+		assert.Error(t, ValidatePathTemplate(template), template)
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	values := bean.PathValues{Team: "payments", App: "checkout", Env: "prod", Cluster: "eu-west-1"}
+	assert.Equal(t, "payments/checkout/prod", ResolvePath("{{team}}/{{app}}/{{env}}", values))
+	assert.Equal(t, "clusters/eu-west-1/checkout", ResolvePath("clusters/{{cluster}}/{{app}}", values))
+
+	values = bean.PathValues{Team: "Platform Team", App: "api", Env: "..", Cluster: ""}
+	assert.Equal(t, "Platform-Team/api/default/default", ResolvePath("{{team}}/{{app}}/{{env}}/{{cluster}}", values))
+}
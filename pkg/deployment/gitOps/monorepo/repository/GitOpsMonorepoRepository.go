@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+type GitOpsMonorepo struct {
+	tableName    struct{} `sql:"gitops_monorepo" pg:",discard_unknown_columns"`
+	Id           int      `sql:"id,pk"`
+	Name         string   `sql:"name,notnull"`
+	RepoName     string   `sql:"repo_name,notnull"`
+	RepoUrl      string   `sql:"repo_url,notnull"`
+	PathTemplate string   `sql:"path_template,notnull"`
+	TeamId       int      `sql:"team_id"`
+	Active       bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// GitOpsMonorepoPath is the directory of an application environment in a shared GitOps repository.
+// It is resolved once, so that later changes of the path template do not move deployed applications
+type GitOpsMonorepoPath struct {
+	tableName  struct{} `sql:"gitops_monorepo_path" pg:",discard_unknown_columns"`
+	Id         int      `sql:"id,pk"`
+	MonorepoId int      `sql:"monorepo_id,notnull"`
+	AppId      int      `sql:"app_id,notnull"`
+	EnvId      int      `sql:"env_id,notnull"`
+	Path       string   `sql:"path,notnull"`
+	sql.AuditLog
+}
+
+type GitOpsMonorepoRepository interface {
+	Save(monorepo *GitOpsMonorepo) error
+	Update(monorepo *GitOpsMonorepo) error
+	FindById(id int) (*GitOpsMonorepo, error)
+	FindAllActive() ([]*GitOpsMonorepo, error)
+	FindActiveByTeamId(teamId int) (*GitOpsMonorepo, error)
+	// FindByRepoUrl includes deleted ones, applications moved to a shared repository keep using it after it is deleted
+	FindByRepoUrl(repoUrl string) (*GitOpsMonorepo, error)
+
+	SavePath(tx *pg.Tx, path *GitOpsMonorepoPath) error
+	FindPath(monorepoId, appId, envId int) (*GitOpsMonorepoPath, error)
+}
+
+type GitOpsMonorepoRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewGitOpsMonorepoRepositoryImpl(dbConnection *pg.DB) *GitOpsMonorepoRepositoryImpl {
+	return &GitOpsMonorepoRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *GitOpsMonorepoRepositoryImpl) Save(monorepo *GitOpsMonorepo) error {
+	return impl.dbConnection.Insert(monorepo)
+}
+
+func (impl *GitOpsMonorepoRepositoryImpl) Update(monorepo *GitOpsMonorepo) error {
+	return impl.dbConnection.Update(monorepo)
+}
+
+func (impl *GitOpsMonorepoRepositoryImpl) FindById(id int) (*GitOpsMonorepo, error) {
+	monorepo := &GitOpsMonorepo{}
+	err := impl.dbConnection.Model(monorepo).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return monorepo, err
+}
+
+func (impl *GitOpsMonorepoRepositoryImpl) FindAllActive() ([]*GitOpsMonorepo, error) {
+	monorepos := make([]*GitOpsMonorepo, 0)
+	err := impl.dbConnection.Model(&monorepos).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return monorepos, err
+}
+
+func (impl *GitOpsMonorepoRepositoryImpl) FindActiveByTeamId(teamId int) (*GitOpsMonorepo, error) {
+	monorepo := &GitOpsMonorepo{}
+	query := impl.dbConnection.Model(monorepo).
+		Where("active = ?", true)
+	if teamId > 0 {
+		query = query.Where("team_id = ?", teamId)
+	} else {
+		query = query.Where("team_id IS NULL")
+	}
+	err := query.Limit(1).Select()
+	return monorepo, err
+}
+
+func (impl *GitOpsMonorepoRepositoryImpl) FindByRepoUrl(repoUrl string) (*GitOpsMonorepo, error) {
+	monorepo := &GitOpsMonorepo{}
+	err := impl.dbConnection.Model(monorepo).
+		Where("repo_url = ?", repoUrl).
+		Order("active DESC", "id DESC").
+		Limit(1).
+		Select()
+	return monorepo, err
+}
+
+func (impl *GitOpsMonorepoRepositoryImpl) SavePath(tx *pg.Tx, path *GitOpsMonorepoPath) error {
+	if tx == nil {
+		return impl.dbConnection.Insert(path)
+	}
+	return tx.Insert(path)
+}
+
+func (impl *GitOpsMonorepoRepositoryImpl) FindPath(monorepoId, appId, envId int) (*GitOpsMonorepoPath, error) {
+	path := &GitOpsMonorepoPath{}
+	err := impl.dbConnection.Model(path).
+		Where("monorepo_id = ?", monorepoId).
+		Where("app_id = ?", appId).
+		Where("env_id = ?", envId).
+		Select()
+	return path, err
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pullRequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	cd "github.com/devtron-labs/devtron/pkg/workflow/cd"
+	"github.com/devtron-labs/devtron/util/argo"
+	"go.uber.org/zap"
+	"slices"
+	"time"
+)
+
+type GitOpsPullRequestService interface {
+	// ProcessOpenPullRequests polls the providers for the open GitOps pull requests; merged ones are
+	// rolled out (ArgoCd sync if manual sync is enabled) and deployments of the closed ones are marked failed
+	ProcessOpenPullRequests()
+}
+
+type GitOpsPullRequestServiceImpl struct {
+	logger                        *zap.SugaredLogger
+	gitOpsPullRequestRepository   repository.GitOpsPullRequestRepository
+	gitOperationService           git.GitOperationService
+	cdWorkflowRepository          pipelineConfig.CdWorkflowRepository
+	cdWorkflowCommonService       cd.CdWorkflowCommonService
+	pipelineRepository            pipelineConfig.PipelineRepository
+	pipelineOverrideRepository    chartConfig.PipelineOverrideRepository
+	pipelineStatusTimelineService status.PipelineStatusTimelineService
+	argoClientWrapperService      argocdServer.ArgoClientWrapperService
+	argoUserService               argo.ArgoUserService
+	acdConfig                     *argocdServer.ACDConfig
+	transactionManager            sql.TransactionWrapper
+}
+
+func NewGitOpsPullRequestServiceImpl(logger *zap.SugaredLogger,
+	gitOpsPullRequestRepository repository.GitOpsPullRequestRepository,
+	gitOperationService git.GitOperationService,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	cdWorkflowCommonService cd.CdWorkflowCommonService,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	pipelineStatusTimelineService status.PipelineStatusTimelineService,
+	argoClientWrapperService argocdServer.ArgoClientWrapperService,
+	argoUserService argo.ArgoUserService,
+	acdConfig *argocdServer.ACDConfig,
+	transactionManager sql.TransactionWrapper) *GitOpsPullRequestServiceImpl {
+	return &GitOpsPullRequestServiceImpl{
+		logger:                        logger,
+		gitOpsPullRequestRepository:   gitOpsPullRequestRepository,
+		gitOperationService:           gitOperationService,
+		cdWorkflowRepository:          cdWorkflowRepository,
+		cdWorkflowCommonService:       cdWorkflowCommonService,
+		pipelineRepository:            pipelineRepository,
+		pipelineOverrideRepository:    pipelineOverrideRepository,
+		pipelineStatusTimelineService: pipelineStatusTimelineService,
+		argoClientWrapperService:      argoClientWrapperService,
+		argoUserService:               argoUserService,
+		acdConfig:                     acdConfig,
+		transactionManager:            transactionManager,
+	}
+}
+
+func (impl *GitOpsPullRequestServiceImpl) ProcessOpenPullRequests() {
+	pullRequests, err := impl.gitOpsPullRequestRepository.FindAllOpen()
+	if err != nil {
+		impl.logger.Errorw("error in fetching open gitops pull requests", "err", err)
+		return
+	}
+	for _, pullRequest := range pullRequests {
+		err = impl.processPullRequest(pullRequest)
+		if err != nil {
+			impl.logger.Errorw("error in processing gitops pull request", "pullRequestId", pullRequest.PullRequestId, "repoName", pullRequest.RepoName, "err", err)
+		}
+	}
+}
+
+func (impl *GitOpsPullRequestServiceImpl) processPullRequest(pullRequest *repository.GitOpsPullRequest) error {
+	runner, err := impl.cdWorkflowRepository.FindBasicWorkflowRunnerById(pullRequest.CdWorkflowRunnerId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd workflow runner", "cdWfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+		return err
+	}
+	if slices.Contains(cdWorkflow.WfrTerminalStatusList, runner.Status) {
+		// deployment is already superseded or aborted, the pull request is left as is for the users
+		impl.logger.Infow("deployment is already terminated, not tracking the pull request anymore", "cdWfrId", runner.Id, "status", runner.Status, "pullRequestUrl", pullRequest.PullRequestUrl)
+		return impl.updateState(pullRequest, repository.PullRequestStateClosed)
+	}
+	ctx := context.Background()
+	pullRequestDetail, err := impl.gitOperationService.GetPullRequest(ctx, pullRequest.RepoName, pullRequest.PullRequestId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pull request from provider", "pullRequestId", pullRequest.PullRequestId, "repoName", pullRequest.RepoName, "err", err)
+		return err
+	}
+	if pullRequestDetail.IsOpen() && pullRequest.AutoMerge && pullRequestDetail.IsMergeable {
+		pullRequestDetail, err = impl.gitOperationService.MergePullRequest(ctx, pullRequest.RepoName, pullRequest.PullRequestId)
+		if err != nil {
+			impl.logger.Errorw("error in merging pull request", "pullRequestId", pullRequest.PullRequestId, "repoName", pullRequest.RepoName, "err", err)
+			return err
+		}
+	}
+	if pullRequestDetail.IsMerged() {
+		return impl.onPullRequestMerged(ctx, pullRequest, pullRequestDetail)
+	} else if !pullRequestDetail.IsOpen() {
+		return impl.onPullRequestClosed(pullRequest)
+	}
+	return nil
+}
+
+func (impl *GitOpsPullRequestServiceImpl) onPullRequestMerged(ctx context.Context, pullRequest *repository.GitOpsPullRequest, pullRequestDetail *git.PullRequestDetail) error {
+	mergedOn := pullRequestDetail.MergedOn
+	if mergedOn.IsZero() {
+		mergedOn = time.Now()
+	}
+	tx, err := impl.transactionManager.StartTx()
+	defer impl.transactionManager.RollbackTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in transaction begin in saving gitops timeline", "err", err)
+		return err
+	}
+	err = impl.pipelineOverrideRepository.UpdateCommitDetails(ctx, tx, pullRequest.PipelineOverrideId, pullRequestDetail.MergeCommitHash, mergedOn, userBean.SystemUserId)
+	if err != nil {
+		impl.logger.Errorw("error in updating commit details to PipelineConfigOverride", "pipelineOverrideId", pullRequest.PipelineOverrideId, "err", err)
+		return err
+	}
+	gitCommitTimeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(pullRequest.CdWorkflowRunnerId, timelineStatus.TIMELINE_STATUS_GIT_COMMIT, timelineStatus.TIMELINE_DESCRIPTION_ARGOCD_GIT_COMMIT, userBean.SystemUserId)
+	timelines := []*pipelineConfig.PipelineStatusTimeline{gitCommitTimeline}
+	if impl.acdConfig.IsManualSyncEnabled() {
+		argoCDSyncInitiatedTimeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(pullRequest.CdWorkflowRunnerId, timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_INITIATED, timelineStatus.TIMELINE_DESCRIPTION_ARGOCD_SYNC_INITIATED, userBean.SystemUserId)
+		timelines = append(timelines, argoCDSyncInitiatedTimeline)
+	}
+	err = impl.pipelineStatusTimelineService.SaveMultipleTimelinesIfNotAlreadyPresent(timelines, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving git commit success timeline", "cdWfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+		return err
+	}
+	err = impl.transactionManager.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction to save gitops timeline", "err", err)
+		return err
+	}
+	err = impl.syncArgoCdApp(pullRequest)
+	if err != nil {
+		impl.logger.Errorw("error in syncing argoCd app after pull request merge", "pipelineId", pullRequest.PipelineId, "err", err)
+		if markErr := impl.cdWorkflowCommonService.MarkDeploymentFailedForRunnerId(pullRequest.CdWorkflowRunnerId, err, userBean.SystemUserId); markErr != nil {
+			impl.logger.Errorw("error in marking deployment failed", "cdWfrId", pullRequest.CdWorkflowRunnerId, "err", markErr)
+		}
+	}
+	pullRequest.MergeCommitHash = pullRequestDetail.MergeCommitHash
+	pullRequest.MergedOn = mergedOn
+	return impl.updateState(pullRequest, repository.PullRequestStateMerged)
+}
+
+func (impl *GitOpsPullRequestServiceImpl) syncArgoCdApp(pullRequest *repository.GitOpsPullRequest) error {
+	pipeline, err := impl.pipelineRepository.FindById(pullRequest.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", pullRequest.PipelineId, "err", err)
+		return err
+	}
+	acdToken, err := impl.argoUserService.GetLatestDevtronArgoCdUserToken()
+	if err != nil {
+		impl.logger.Errorw("error in getting acd token", "err", err)
+		return err
+	}
+	ctx := context.WithValue(context.Background(), "token", acdToken)
+	syncTime := time.Now()
+	err = impl.argoClientWrapperService.SyncArgoCDApplicationIfNeededAndRefresh(ctx, pipeline.DeploymentAppName)
+	if err != nil {
+		return fmt.Errorf("error in syncing argoCD app. err: %s", util.GetClientErrorDetailedMessage(err))
+	}
+	if impl.acdConfig.IsManualSyncEnabled() {
+		timeline := &pipelineConfig.PipelineStatusTimeline{
+			CdWorkflowRunnerId: pullRequest.CdWorkflowRunnerId,
+			StatusTime:         syncTime,
+			Status:             timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_COMPLETED,
+			StatusDetail:       timelineStatus.TIMELINE_DESCRIPTION_ARGOCD_SYNC_COMPLETED,
+		}
+		timeline.CreateAuditLog(userBean.SystemUserId)
+		_, err = impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+		if err != nil {
+			impl.logger.Errorw("error in saving pipeline status timeline", "err", err)
+		}
+	}
+	return nil
+}
+
+func (impl *GitOpsPullRequestServiceImpl) onPullRequestClosed(pullRequest *repository.GitOpsPullRequest) error {
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(pullRequest.CdWorkflowRunnerId, timelineStatus.TIMELINE_STATUS_GIT_COMMIT_FAILED, timelineStatus.TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_CLOSED, userBean.SystemUserId)
+	err := impl.pipelineStatusTimelineService.SaveTimeline(timeline, nil)
+	if err != nil {
+		impl.logger.Errorw("error in saving pull request closed timeline", "cdWfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+		return err
+	}
+	err = impl.cdWorkflowCommonService.MarkDeploymentFailedForRunnerId(pullRequest.CdWorkflowRunnerId, errors.New(timelineStatus.TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_CLOSED), userBean.SystemUserId)
+	if err != nil {
+		impl.logger.Errorw("error in marking deployment failed", "cdWfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+		return err
+	}
+	return impl.updateState(pullRequest, repository.PullRequestStateClosed)
+}
+
+func (impl *GitOpsPullRequestServiceImpl) updateState(pullRequest *repository.GitOpsPullRequest, state repository.PullRequestState) error {
+	pullRequest.State = state
+	pullRequest.UpdateAuditLog(userBean.SystemUserId)
+	err := impl.gitOpsPullRequestRepository.Update(pullRequest)
+	if err != nil {
+		impl.logger.Errorw("error in updating gitops pull request", "id", pullRequest.Id, "state", state, "err", err)
+		return err
+	}
+	return nil
+}
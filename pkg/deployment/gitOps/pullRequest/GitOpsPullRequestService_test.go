@@ -0,0 +1,302 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pullRequest
+
+import (
+	"context"
+	"errors"
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+	cd "github.com/devtron-labs/devtron/pkg/workflow/cd"
+	"github.com/devtron-labs/devtron/util/argo"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// fakeGitOperationService is the git provider of the pull requests, keyed by pull request id
+type fakeGitOperationService struct {
+	git.GitOperationService
+	pullRequests map[int]*git.PullRequestDetail
+	merged       []int
+	mergeErr     error
+}
+
+func (fake *fakeGitOperationService) GetPullRequest(ctx context.Context, repoName string, pullRequestId int) (*git.PullRequestDetail, error) {
+	pullRequest, ok := fake.pullRequests[pullRequestId]
+	if !ok {
+		return nil, errors.New("pull request not found")
+	}
+	return pullRequest, nil
+}
+
+func (fake *fakeGitOperationService) MergePullRequest(ctx context.Context, repoName string, pullRequestId int) (*git.PullRequestDetail, error) {
+	if fake.mergeErr != nil {
+		return nil, fake.mergeErr
+	}
+	fake.merged = append(fake.merged, pullRequestId)
+	pullRequest := fake.pullRequests[pullRequestId]
+	pullRequest.State = git.PullRequestStateMerged
+	pullRequest.MergeCommitHash = "merge-commit"
+	pullRequest.MergedOn = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	return pullRequest, nil
+}
+
+type fakeGitOpsPullRequestRepository struct {
+	repository.GitOpsPullRequestRepository
+	pullRequests []*repository.GitOpsPullRequest
+	updated      map[int]repository.PullRequestState
+}
+
+func (fake *fakeGitOpsPullRequestRepository) FindAllOpen() ([]*repository.GitOpsPullRequest, error) {
+	return fake.pullRequests, nil
+}
+
+func (fake *fakeGitOpsPullRequestRepository) Update(pullRequest *repository.GitOpsPullRequest) error {
+	fake.updated[pullRequest.Id] = pullRequest.State
+	return nil
+}
+
+type fakeCdWorkflowRepository struct {
+	pipelineConfig.CdWorkflowRepository
+	runnerStatus map[int]string
+}
+
+func (fake *fakeCdWorkflowRepository) FindBasicWorkflowRunnerById(wfrId int) (*pipelineConfig.CdWorkflowRunner, error) {
+	return &pipelineConfig.CdWorkflowRunner{Id: wfrId, Status: fake.runnerStatus[wfrId]}, nil
+}
+
+type fakeCdWorkflowCommonService struct {
+	cd.CdWorkflowCommonService
+	failed map[int]string
+}
+
+func (fake *fakeCdWorkflowCommonService) MarkDeploymentFailedForRunnerId(cdWfrId int, releaseErr error, triggeredBy int32) error {
+	fake.failed[cdWfrId] = releaseErr.Error()
+	return nil
+}
+
+type fakePipelineRepository struct {
+	pipelineConfig.PipelineRepository
+}
+
+func (fake *fakePipelineRepository) FindById(id int) (*pipelineConfig.Pipeline, error) {
+	return &pipelineConfig.Pipeline{Id: id, DeploymentAppName: "payments-staging"}, nil
+}
+
+type fakePipelineOverrideRepository struct {
+	chartConfig.PipelineOverrideRepository
+	commits map[int]string
+}
+
+func (fake *fakePipelineOverrideRepository) UpdateCommitDetails(ctx context.Context, tx *pg.Tx, id int, gitHash string, commitTime time.Time, userId int32) error {
+	fake.commits[id] = gitHash
+	return nil
+}
+
+type fakePipelineStatusTimelineService struct {
+	status.PipelineStatusTimelineService
+	timelines map[int][]timelineStatus.TimelineStatus
+}
+
+func (fake *fakePipelineStatusTimelineService) NewDevtronAppPipelineStatusTimelineDbObject(cdWorkflowRunnerId int, timelineStatus timelineStatus.TimelineStatus, timelineDescription string, userId int32) *pipelineConfig.PipelineStatusTimeline {
+	return &pipelineConfig.PipelineStatusTimeline{CdWorkflowRunnerId: cdWorkflowRunnerId, Status: timelineStatus, StatusDetail: timelineDescription}
+}
+
+func (fake *fakePipelineStatusTimelineService) SaveTimeline(timeline *pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) error {
+	fake.timelines[timeline.CdWorkflowRunnerId] = append(fake.timelines[timeline.CdWorkflowRunnerId], timeline.Status)
+	return nil
+}
+
+func (fake *fakePipelineStatusTimelineService) SaveTimelineIfNotAlreadyPresent(timeline *pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) (bool, error) {
+	return true, fake.SaveTimeline(timeline, tx)
+}
+
+func (fake *fakePipelineStatusTimelineService) SaveMultipleTimelinesIfNotAlreadyPresent(timelines []*pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) error {
+	for _, timeline := range timelines {
+		_ = fake.SaveTimeline(timeline, tx)
+	}
+	return nil
+}
+
+type fakeArgoClientWrapperService struct {
+	argocdServer.ArgoClientWrapperService
+	synced  []string
+	syncErr error
+}
+
+func (fake *fakeArgoClientWrapperService) SyncArgoCDApplicationIfNeededAndRefresh(ctx context.Context, argoAppName string) error {
+	fake.synced = append(fake.synced, argoAppName)
+	return fake.syncErr
+}
+
+type fakeArgoUserService struct {
+	argo.ArgoUserService
+}
+
+func (fake *fakeArgoUserService) GetLatestDevtronArgoCdUserToken() (string, error) {
+	return "token", nil
+}
+
+type fakeTransactionManager struct{}
+
+func (fake fakeTransactionManager) StartTx() (*pg.Tx, error) { return nil, nil }
+
+func (fake fakeTransactionManager) RollbackTx(tx *pg.Tx) error { return nil }
+
+func (fake fakeTransactionManager) CommitTx(tx *pg.Tx) error { return nil }
+
+type pullRequestServiceFakes struct {
+	gitOperationService           *fakeGitOperationService
+	pullRequestRepository         *fakeGitOpsPullRequestRepository
+	cdWorkflowRepository          *fakeCdWorkflowRepository
+	cdWorkflowCommonService       *fakeCdWorkflowCommonService
+	pipelineOverrideRepository    *fakePipelineOverrideRepository
+	pipelineStatusTimelineService *fakePipelineStatusTimelineService
+	argoClientWrapperService      *fakeArgoClientWrapperService
+}
+
+func newTestGitOpsPullRequestService(pullRequest *repository.GitOpsPullRequest, detail *git.PullRequestDetail, runnerStatus string) (*GitOpsPullRequestServiceImpl, *pullRequestServiceFakes) {
+	fakes := &pullRequestServiceFakes{
+		gitOperationService:           &fakeGitOperationService{pullRequests: map[int]*git.PullRequestDetail{detail.Id: detail}},
+		pullRequestRepository:         &fakeGitOpsPullRequestRepository{pullRequests: []*repository.GitOpsPullRequest{pullRequest}, updated: map[int]repository.PullRequestState{}},
+		cdWorkflowRepository:          &fakeCdWorkflowRepository{runnerStatus: map[int]string{pullRequest.CdWorkflowRunnerId: runnerStatus}},
+		cdWorkflowCommonService:       &fakeCdWorkflowCommonService{failed: map[int]string{}},
+		pipelineOverrideRepository:    &fakePipelineOverrideRepository{commits: map[int]string{}},
+		pipelineStatusTimelineService: &fakePipelineStatusTimelineService{timelines: map[int][]timelineStatus.TimelineStatus{}},
+		argoClientWrapperService:      &fakeArgoClientWrapperService{},
+	}
+	impl := NewGitOpsPullRequestServiceImpl(zap.NewNop().Sugar(), fakes.pullRequestRepository, fakes.gitOperationService,
+		fakes.cdWorkflowRepository, fakes.cdWorkflowCommonService, &fakePipelineRepository{}, fakes.pipelineOverrideRepository,
+		fakes.pipelineStatusTimelineService, fakes.argoClientWrapperService, &fakeArgoUserService{},
+		&argocdServer.ACDConfig{ArgoCDAutoSyncEnabled: false}, fakeTransactionManager{})
+	return impl, fakes
+}
+
+func newTestGitOpsPullRequest(autoMerge bool) *repository.GitOpsPullRequest {
+	return &repository.GitOpsPullRequest{
+		Id:                 1,
+		CdWorkflowRunnerId: 11,
+		PipelineOverrideId: 21,
+		PipelineId:         31,
+		RepoName:           "payments",
+		Branch:             "devtron/release-21-env-4",
+		PullRequestId:      7,
+		State:              repository.PullRequestStateOpen,
+		AutoMerge:          autoMerge,
+	}
+}
+
+func TestGitOpsPullRequestServiceImpl_ProcessOpenPullRequests_Merged(t *testing.T) {
+	impl, fakes := newTestGitOpsPullRequestService(newTestGitOpsPullRequest(false),
+		&git.PullRequestDetail{Id: 7, State: git.PullRequestStateMerged, MergeCommitHash: "abc123", MergedOn: time.Now()}, cdWorkflow.WorkflowInProgress)
+
+	impl.ProcessOpenPullRequests()
+	assert.Empty(t, fakes.gitOperationService.merged)
+	assert.Equal(t, map[int]string{21: "abc123"}, fakes.pipelineOverrideRepository.commits)
+	assert.Equal(t, []timelineStatus.TimelineStatus{timelineStatus.TIMELINE_STATUS_GIT_COMMIT, timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_INITIATED, timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_COMPLETED}, fakes.pipelineStatusTimelineService.timelines[11])
+	assert.Equal(t, []string{"payments-staging"}, fakes.argoClientWrapperService.synced)
+	assert.Equal(t, map[int]repository.PullRequestState{1: repository.PullRequestStateMerged}, fakes.pullRequestRepository.updated)
+	assert.Equal(t, "abc123", fakes.pullRequestRepository.pullRequests[0].MergeCommitHash)
+	assert.Empty(t, fakes.cdWorkflowCommonService.failed)
+}
+
+func TestGitOpsPullRequestServiceImpl_ProcessOpenPullRequests_AutoMerge(t *testing.T) {
+	impl, fakes := newTestGitOpsPullRequestService(newTestGitOpsPullRequest(true),
+		&git.PullRequestDetail{Id: 7, State: git.PullRequestStateOpen, IsMergeable: true}, cdWorkflow.WorkflowInProgress)
+
+	impl.ProcessOpenPullRequests()
+	assert.Equal(t, []int{7}, fakes.gitOperationService.merged)
+	assert.Equal(t, map[int]string{21: "merge-commit"}, fakes.pipelineOverrideRepository.commits)
+	assert.Equal(t, map[int]repository.PullRequestState{1: repository.PullRequestStateMerged}, fakes.pullRequestRepository.updated)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), fakes.pullRequestRepository.pullRequests[0].MergedOn)
+}
+
+func TestGitOpsPullRequestServiceImpl_ProcessOpenPullRequests_Open(t *testing.T) {
+	tests := []struct {
+		name      string
+		autoMerge bool
+		mergeable bool
+	}{
+		{"auto merge disabled", false, true},
+		{"checks pending", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, fakes := newTestGitOpsPullRequestService(newTestGitOpsPullRequest(tt.autoMerge),
+				&git.PullRequestDetail{Id: 7, State: git.PullRequestStateOpen, IsMergeable: tt.mergeable}, cdWorkflow.WorkflowInProgress)
+
+			impl.ProcessOpenPullRequests()
+			assert.Empty(t, fakes.gitOperationService.merged)
+			assert.Empty(t, fakes.pullRequestRepository.updated)
+			assert.Empty(t, fakes.pipelineStatusTimelineService.timelines)
+			assert.Empty(t, fakes.argoClientWrapperService.synced)
+		})
+	}
+}
+
+func TestGitOpsPullRequestServiceImpl_ProcessOpenPullRequests_AutoMergeFailed(t *testing.T) {
+	impl, fakes := newTestGitOpsPullRequestService(newTestGitOpsPullRequest(true),
+		&git.PullRequestDetail{Id: 7, State: git.PullRequestStateOpen, IsMergeable: true}, cdWorkflow.WorkflowInProgress)
+	fakes.gitOperationService.mergeErr = errors.New("merge blocked by branch protection")
+
+	impl.ProcessOpenPullRequests()
+	// the pull request stays open and the merge is attempted again on the next run
+	assert.Empty(t, fakes.pullRequestRepository.updated)
+	assert.Empty(t, fakes.cdWorkflowCommonService.failed)
+}
+
+func TestGitOpsPullRequestServiceImpl_ProcessOpenPullRequests_Closed(t *testing.T) {
+	impl, fakes := newTestGitOpsPullRequestService(newTestGitOpsPullRequest(true),
+		&git.PullRequestDetail{Id: 7, State: git.PullRequestStateClosed}, cdWorkflow.WorkflowInProgress)
+
+	impl.ProcessOpenPullRequests()
+	assert.Empty(t, fakes.gitOperationService.merged)
+	assert.Equal(t, []timelineStatus.TimelineStatus{timelineStatus.TIMELINE_STATUS_GIT_COMMIT_FAILED}, fakes.pipelineStatusTimelineService.timelines[11])
+	assert.Equal(t, map[int]string{11: timelineStatus.TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_CLOSED}, fakes.cdWorkflowCommonService.failed)
+	assert.Equal(t, map[int]repository.PullRequestState{1: repository.PullRequestStateClosed}, fakes.pullRequestRepository.updated)
+	assert.Empty(t, fakes.argoClientWrapperService.synced)
+}
+
+func TestGitOpsPullRequestServiceImpl_ProcessOpenPullRequests_SyncFailed(t *testing.T) {
+	impl, fakes := newTestGitOpsPullRequestService(newTestGitOpsPullRequest(false),
+		&git.PullRequestDetail{Id: 7, State: git.PullRequestStateMerged, MergeCommitHash: "abc123"}, cdWorkflow.WorkflowInProgress)
+	fakes.argoClientWrapperService.syncErr = errors.New("argocd unavailable")
+
+	impl.ProcessOpenPullRequests()
+	assert.Contains(t, fakes.cdWorkflowCommonService.failed[11], "argocd unavailable")
+	assert.Equal(t, map[int]repository.PullRequestState{1: repository.PullRequestStateMerged}, fakes.pullRequestRepository.updated)
+}
+
+func TestGitOpsPullRequestServiceImpl_ProcessOpenPullRequests_DeploymentTerminated(t *testing.T) {
+	impl, fakes := newTestGitOpsPullRequestService(newTestGitOpsPullRequest(true),
+		&git.PullRequestDetail{Id: 7, State: git.PullRequestStateOpen, IsMergeable: true}, cdWorkflow.WorkflowAborted)
+
+	impl.ProcessOpenPullRequests()
+	// a superseded deployment stops tracking its pull request without touching it on the provider
+	assert.Empty(t, fakes.gitOperationService.merged)
+	assert.Equal(t, map[int]repository.PullRequestState{1: repository.PullRequestStateClosed}, fakes.pullRequestRepository.updated)
+	assert.Empty(t, fakes.cdWorkflowCommonService.failed)
+}
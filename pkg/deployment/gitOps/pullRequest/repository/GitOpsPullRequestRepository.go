@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type PullRequestState string
+
+const (
+	PullRequestStateOpen   PullRequestState = "OPEN"
+	PullRequestStateMerged PullRequestState = "MERGED"
+	PullRequestStateClosed PullRequestState = "CLOSED"
+)
+
+// GitOpsPullRequest tracks the pull request raised for a deployment when the pull request commit strategy is configured
+type GitOpsPullRequest struct {
+	tableName          struct{}         `sql:"gitops_pull_request" pg:",discard_unknown_columns"`
+	Id                 int              `sql:"id,pk"`
+	CdWorkflowRunnerId int              `sql:"cd_workflow_runner_id,notnull"`
+	PipelineOverrideId int              `sql:"pipeline_override_id,notnull"`
+	PipelineId         int              `sql:"pipeline_id,notnull"`
+	RepoName           string           `sql:"repo_name,notnull"`
+	Branch             string           `sql:"branch,notnull"`
+	PullRequestId      int              `sql:"pull_request_id,notnull"`
+	PullRequestUrl     string           `sql:"pull_request_url"`
+	State              PullRequestState `sql:"state,notnull"`
+	AutoMerge          bool             `sql:"auto_merge,notnull"`
+	MergeCommitHash    string           `sql:"merge_commit_hash"`
+	MergedOn           time.Time        `sql:"merged_on"`
+	sql.AuditLog
+}
+
+type GitOpsPullRequestRepository interface {
+	Save(tx *pg.Tx, pullRequest *GitOpsPullRequest) error
+	Update(pullRequest *GitOpsPullRequest) error
+	FindAllOpen() ([]*GitOpsPullRequest, error)
+	FindByCdWorkflowRunnerId(cdWfrId int) (*GitOpsPullRequest, error)
+}
+
+type GitOpsPullRequestRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewGitOpsPullRequestRepositoryImpl(dbConnection *pg.DB) *GitOpsPullRequestRepositoryImpl {
+	return &GitOpsPullRequestRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) Save(tx *pg.Tx, pullRequest *GitOpsPullRequest) error {
+	return tx.Insert(pullRequest)
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) Update(pullRequest *GitOpsPullRequest) error {
+	return impl.dbConnection.Update(pullRequest)
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) FindAllOpen() ([]*GitOpsPullRequest, error) {
+	pullRequests := make([]*GitOpsPullRequest, 0)
+	err := impl.dbConnection.Model(&pullRequests).
+		Where("state = ?", PullRequestStateOpen).
+		Order("id ASC").
+		Select()
+	return pullRequests, err
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) FindByCdWorkflowRunnerId(cdWfrId int) (*GitOpsPullRequest, error) {
+	pullRequest := &GitOpsPullRequest{}
+	err := impl.dbConnection.Model(pullRequest).
+		Where("cd_workflow_runner_id = ?", cdWfrId).
+		Order("id DESC").
+		Limit(1).
+		Select()
+	return pullRequest, err
+}
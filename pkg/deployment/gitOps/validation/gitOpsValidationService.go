@@ -233,6 +233,12 @@ func (impl *GitOpsValidationServiceImpl) extractErrorMessageByProvider(err error
 		return fmt.Errorf("bitbucket client error: %s", err.Error())
 	case git.GITHUB_PROVIDER:
 		return fmt.Errorf("github client error: %s", err.Error())
+	case git.GITEA_PROVIDER:
+		return fmt.Errorf("gitea client error: %s", err.Error())
+	case git.GOGS_PROVIDER:
+		return fmt.Errorf("gogs client error: %s", err.Error())
+	case git.BITBUCKET_SERVER_PROVIDER:
+		return fmt.Errorf("bitbucket server client error: %s", err.Error())
 	}
 	return err
 }
@@ -266,6 +272,14 @@ func (impl *GitOpsValidationServiceImpl) getValidationErrorForNonOrganisationalU
 	case git.AZURE_DEVOPS_PROVIDER:
 		errorMessageKey = "The repository must belong to Azure DevOps Project"
 		errorMessage = fmt.Sprintf("%s as configured in global configurations > GitOps", activeGitOpsConfig.AzureProjectName)
+
+	case git.GITEA_PROVIDER, git.GOGS_PROVIDER:
+		errorMessageKey = "The repository must belong to organization"
+		errorMessage = fmt.Sprintf("%s as configured in global configurations > GitOps", activeGitOpsConfig.GitHubOrgId)
+
+	case git.BITBUCKET_SERVER_PROVIDER:
+		errorMessageKey = "The repository must belong to Bitbucket Server Project"
+		errorMessage = fmt.Sprintf("%s as configured in global configurations > GitOps", activeGitOpsConfig.BitBucketProjectKey)
 	}
 	return fmt.Errorf("%s: %s", errorMessageKey, errorMessage)
 }
@@ -19,7 +19,13 @@ package gitOps
 import (
 	"github.com/devtron-labs/devtron/internal/sql/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift"
+	driftRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo"
+	monorepoRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
+	pullRequestRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/validation"
 	"github.com/google/wire"
 )
@@ -36,6 +42,24 @@ var GitOpsWireSet = wire.NewSet(
 
 	validation.NewGitOpsValidationServiceImpl,
 	wire.Bind(new(validation.GitOpsValidationService), new(*validation.GitOpsValidationServiceImpl)),
+
+	pullRequestRepository.NewGitOpsPullRequestRepositoryImpl,
+	wire.Bind(new(pullRequestRepository.GitOpsPullRequestRepository), new(*pullRequestRepository.GitOpsPullRequestRepositoryImpl)),
+
+	pullRequest.NewGitOpsPullRequestServiceImpl,
+	wire.Bind(new(pullRequest.GitOpsPullRequestService), new(*pullRequest.GitOpsPullRequestServiceImpl)),
+
+	monorepoRepository.NewGitOpsMonorepoRepositoryImpl,
+	wire.Bind(new(monorepoRepository.GitOpsMonorepoRepository), new(*monorepoRepository.GitOpsMonorepoRepositoryImpl)),
+
+	monorepo.NewGitOpsMonorepoServiceImpl,
+	wire.Bind(new(monorepo.GitOpsMonorepoService), new(*monorepo.GitOpsMonorepoServiceImpl)),
+
+	driftRepository.NewGitOpsDriftRepositoryImpl,
+	wire.Bind(new(driftRepository.GitOpsDriftRepository), new(*driftRepository.GitOpsDriftRepositoryImpl)),
+
+	drift.NewGitOpsDriftServiceImpl,
+	wire.Bind(new(drift.GitOpsDriftService), new(*drift.GitOpsDriftServiceImpl)),
 )
 
 var GitOpsEAWireSet = wire.NewSet(
@@ -32,10 +32,14 @@ import (
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
 	gitOpsBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/config/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo"
+	pullRequestRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/chartRef"
 	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
+	"path"
 	"time"
 )
 
@@ -59,7 +63,10 @@ type GitOpsManifestPushServiceImpl struct {
 	argoClientWrapperService      argocdServer.ArgoClientWrapperService
 	deploymentConfigService       common.DeploymentConfigService
 	chartTemplateService          util.ChartTemplateService
-	*sql.TransactionUtilImpl
+	gitOpsPullRequestRepository   pullRequestRepository.GitOpsPullRequestRepository
+	cdWorkflowRepository          pipelineConfig.CdWorkflowRepository
+	gitOpsMonorepoService         monorepo.GitOpsMonorepoService
+	transactionManager            sql.TransactionWrapper
 }
 
 func NewGitOpsManifestPushServiceImpl(logger *zap.SugaredLogger,
@@ -71,9 +78,12 @@ func NewGitOpsManifestPushServiceImpl(logger *zap.SugaredLogger,
 	chartService chartService.ChartService,
 	gitOperationService git.GitOperationService,
 	argoClientWrapperService argocdServer.ArgoClientWrapperService,
-	transactionUtilImpl *sql.TransactionUtilImpl,
+	transactionManager sql.TransactionWrapper,
 	deploymentConfigService common.DeploymentConfigService,
-	chartTemplateService util.ChartTemplateService) *GitOpsManifestPushServiceImpl {
+	chartTemplateService util.ChartTemplateService,
+	gitOpsPullRequestRepository pullRequestRepository.GitOpsPullRequestRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	gitOpsMonorepoService monorepo.GitOpsMonorepoService) *GitOpsManifestPushServiceImpl {
 	return &GitOpsManifestPushServiceImpl{
 		logger:                        logger,
 		pipelineStatusTimelineService: pipelineStatusTimelineService,
@@ -84,9 +94,12 @@ func NewGitOpsManifestPushServiceImpl(logger *zap.SugaredLogger,
 		chartService:                  chartService,
 		gitOperationService:           gitOperationService,
 		argoClientWrapperService:      argoClientWrapperService,
-		TransactionUtilImpl:           transactionUtilImpl,
+		transactionManager:            transactionManager,
 		deploymentConfigService:       deploymentConfigService,
 		chartTemplateService:          chartTemplateService,
+		gitOpsPullRequestRepository:   gitOpsPullRequestRepository,
+		cdWorkflowRepository:          cdWorkflowRepository,
+		gitOpsMonorepoService:         gitOpsMonorepoService,
 	}
 }
 
@@ -95,19 +108,30 @@ func (impl *GitOpsManifestPushServiceImpl) createRepoForGitOperation(manifestPus
 	if manifestPushTemplate.IsCustomGitRepository {
 		return manifestPushTemplate.RepoUrl, nil
 	}
-	gitOpsRepoName := impl.gitOpsConfigReadService.GetGitOpsRepoName(manifestPushTemplate.AppName)
-	chartGitAttr, err := impl.gitOperationService.CreateGitRepositoryForDevtronApp(ctx, gitOpsRepoName, manifestPushTemplate.UserId)
+	sharedRepo, err := impl.gitOpsMonorepoService.FindForApp(manifestPushTemplate.AppId)
 	if err != nil {
-		impl.logger.Errorw("error in pushing chart to git ", "gitOpsRepoName", gitOpsRepoName, "err", err)
-		return "", fmt.Errorf("No repository configured for Gitops! Error while creating git repository: '%s'", gitOpsRepoName)
+		impl.logger.Errorw("error in getting shared gitops repository for app", "appId", manifestPushTemplate.AppId, "err", err)
+		return "", err
+	}
+	gitOpsRepoName := impl.gitOpsConfigReadService.GetGitOpsRepoName(manifestPushTemplate.AppName)
+	repoUrl := ""
+	if sharedRepo != nil {
+		gitOpsRepoName = sharedRepo.RepoName
+		repoUrl = sharedRepo.RepoUrl
+	} else {
+		chartGitAttr, err := impl.gitOperationService.CreateGitRepositoryForDevtronApp(ctx, gitOpsRepoName, manifestPushTemplate.UserId)
+		if err != nil {
+			impl.logger.Errorw("error in pushing chart to git ", "gitOpsRepoName", gitOpsRepoName, "err", err)
+			return "", fmt.Errorf("No repository configured for Gitops! Error while creating git repository: '%s'", gitOpsRepoName)
+		}
+		repoUrl = chartGitAttr.RepoUrl
 	}
-	chartGitAttr.ChartLocation = manifestPushTemplate.ChartLocation
-	err = impl.argoClientWrapperService.RegisterGitOpsRepoInArgoWithRetry(ctx, chartGitAttr.RepoUrl, manifestPushTemplate.UserId)
+	err = impl.argoClientWrapperService.RegisterGitOpsRepoInArgoWithRetry(ctx, repoUrl, manifestPushTemplate.UserId)
 	if err != nil {
 		impl.logger.Errorw("error in registering app in acd", "err", err)
 		return "", fmt.Errorf("Error in registering repository '%s' in ArgoCd", gitOpsRepoName)
 	}
-	return chartGitAttr.RepoUrl, nil
+	return repoUrl, nil
 }
 
 func (impl *GitOpsManifestPushServiceImpl) validateManifestPushRequest(globalGitOpsConfigStatus gitOpsBean.GitOpsConfigurationStatus, manifestPushTemplate bean.ManifestPushTemplate) error {
@@ -168,6 +192,18 @@ func (impl *GitOpsManifestPushServiceImpl) PushChart(ctx context.Context, manife
 		}
 
 	}
+	// applications sharing a GitOps repository are placed in their own directory of it
+	chartPathPrefix, err := impl.gitOpsMonorepoService.GetChartPathPrefix(manifestPushTemplate.AppId, manifestPushTemplate.EnvironmentId, manifestPushTemplate.RepoUrl, manifestPushTemplate.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in getting chart path in shared gitops repository", "appId", manifestPushTemplate.AppId, "envId", manifestPushTemplate.EnvironmentId, "err", err)
+		manifestPushResponse.Error = err
+		impl.SaveTimelineForError(manifestPushTemplate, err)
+		return manifestPushResponse
+	}
+	if len(chartPathPrefix) > 0 {
+		manifestPushTemplate.ChartLocation = path.Join(chartPathPrefix, manifestPushTemplate.ChartLocation)
+		manifestPushTemplate.ChartReferenceTemplate = path.Join(chartPathPrefix, manifestPushTemplate.ChartReferenceTemplate)
+	}
 	// 4. Push Chart to Git Repository
 	err = impl.pushChartToGitRepo(newCtx, manifestPushTemplate)
 	if err != nil {
@@ -177,7 +213,26 @@ func (impl *GitOpsManifestPushServiceImpl) PushChart(ctx context.Context, manife
 		return manifestPushResponse
 	}
 
-	// 5. Commit chart values to Git Repository
+	// 5. Commit chart values to Git Repository, through a pull request if configured
+	activeGitOpsConfig, err := impl.gitOpsConfigReadService.GetGitOpsConfigActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching active gitOps config", "err", err)
+		manifestPushResponse.Error = err
+		impl.SaveTimelineForError(manifestPushTemplate, err)
+		return manifestPushResponse
+	}
+	if activeGitOpsConfig.IsPullRequestCommitStrategy() {
+		pullRequestUrl, err := impl.raisePullRequestForValues(newCtx, manifestPushTemplate, activeGitOpsConfig.AutoMergePullRequest)
+		if err != nil {
+			impl.logger.Errorw("error in raising pull request for values", "err", err)
+			manifestPushResponse.Error = err
+			impl.SaveTimelineForError(manifestPushTemplate, err)
+			return manifestPushResponse
+		}
+		// commit details and GIT_COMMIT timeline are updated once the pull request is merged
+		manifestPushResponse.PullRequestUrl = pullRequestUrl
+		return manifestPushResponse
+	}
 	commitHash, commitTime, err := impl.commitValuesToGit(newCtx, manifestPushTemplate)
 	if err != nil {
 		impl.logger.Errorw("error in committing values to git", "err", err)
@@ -188,8 +243,8 @@ func (impl *GitOpsManifestPushServiceImpl) PushChart(ctx context.Context, manife
 	manifestPushResponse.CommitHash = commitHash
 	manifestPushResponse.CommitTime = commitTime
 	// 6. Update commit details in PipelineConfigOverride and Deployment Status Timelines
-	tx, err := impl.TransactionUtilImpl.StartTx()
-	defer impl.TransactionUtilImpl.RollbackTx(tx)
+	tx, err := impl.transactionManager.StartTx()
+	defer impl.transactionManager.RollbackTx(tx)
 	if err != nil {
 		impl.logger.Errorw("error in transaction begin in saving gitops timeline", "err", err)
 		manifestPushResponse.Error = err
@@ -214,7 +269,7 @@ func (impl *GitOpsManifestPushServiceImpl) PushChart(ctx context.Context, manife
 	if timelineErr != nil {
 		impl.logger.Errorw("Error in saving git commit success timeline", err, timelineErr)
 	}
-	err = impl.TransactionUtilImpl.CommitTx(tx)
+	err = impl.transactionManager.CommitTx(tx)
 	if err != nil {
 		impl.logger.Errorw("error in committing transaction to save gitops timeline", "err", err)
 		manifestPushResponse.Error = err
@@ -243,16 +298,10 @@ func (impl *GitOpsManifestPushServiceImpl) pushChartToGitRepo(ctx context.Contex
 	return nil
 }
 
-func (impl *GitOpsManifestPushServiceImpl) commitValuesToGit(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate) (commitHash string, commitTime time.Time, err error) {
-	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsManifestPushServiceImpl.commitValuesToGit")
-	defer span.End()
-	commitHash = ""
-	commitTime = time.Time{}
+func (impl *GitOpsManifestPushServiceImpl) buildValuesChartConfig(manifestPushTemplate *bean.ManifestPushTemplate) *git.ChartConfig {
 	chartRepoName := impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(manifestPushTemplate.RepoUrl)
-	_, span = otel.Tracer("orchestrator").Start(newCtx, "gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit")
 	//getting username & emailId for commit author data
 	userEmailId, userName := impl.gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit(manifestPushTemplate.UserId)
-	span.End()
 	chartGitAttr := &git.ChartConfig{
 		FileName:       fmt.Sprintf("_%d-values.yaml", manifestPushTemplate.TargetEnvironmentName),
 		FileContent:    manifestPushTemplate.MergedValues,
@@ -265,6 +314,17 @@ func (impl *GitOpsManifestPushServiceImpl) commitValuesToGit(ctx context.Context
 	}
 	bitBucketBaseDir := fmt.Sprintf("%d-%s", manifestPushTemplate.PipelineOverrideId, impl.chartTemplateService.GetDir())
 	chartGitAttr.SetBitBucketBaseDir(bitBucketBaseDir)
+	return chartGitAttr
+}
+
+func (impl *GitOpsManifestPushServiceImpl) commitValuesToGit(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate) (commitHash string, commitTime time.Time, err error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsManifestPushServiceImpl.commitValuesToGit")
+	defer span.End()
+	commitHash = ""
+	commitTime = time.Time{}
+	_, span = otel.Tracer("orchestrator").Start(newCtx, "GitOpsManifestPushServiceImpl.buildValuesChartConfig")
+	chartGitAttr := impl.buildValuesChartConfig(manifestPushTemplate)
+	span.End()
 	commitHash, commitTime, err = impl.gitOperationService.CommitValues(newCtx, chartGitAttr)
 	if err != nil {
 		impl.logger.Errorw("error in git commit", "err", err)
@@ -273,6 +333,85 @@ func (impl *GitOpsManifestPushServiceImpl) commitValuesToGit(ctx context.Context
 	return commitHash, commitTime, nil
 }
 
+// raisePullRequestForValues commits the values on a release branch and raises a pull request for it against the default branch.
+// The pull request is tracked till it is merged or closed, see pullRequest.GitOpsPullRequestService
+func (impl *GitOpsManifestPushServiceImpl) raisePullRequestForValues(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate, autoMerge bool) (string, error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsManifestPushServiceImpl.raisePullRequestForValues")
+	defer span.End()
+	chartGitAttr := impl.buildValuesChartConfig(manifestPushTemplate)
+	chartGitAttr.TargetBranch = fmt.Sprintf("devtron/release-%d-env-%d", manifestPushTemplate.PipelineOverrideId, manifestPushTemplate.TargetEnvironmentName)
+	existingPullRequest, err := impl.gitOpsPullRequestRepository.FindByCdWorkflowRunnerId(manifestPushTemplate.WorkflowRunnerId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching gitops pull request", "wfrId", manifestPushTemplate.WorkflowRunnerId, "err", err)
+		return "", err
+	}
+	if err == nil && existingPullRequest.State == pullRequestRepository.PullRequestStateOpen && existingPullRequest.Branch == chartGitAttr.TargetBranch {
+		// the values are pushed again for the same deployment, they are committed on the branch of its open pull request
+		return impl.updatePullRequestForValues(newCtx, manifestPushTemplate, chartGitAttr, existingPullRequest)
+	}
+	title := fmt.Sprintf("Deploy %s on %s (release-%d)", manifestPushTemplate.AppName, manifestPushTemplate.EnvironmentName, manifestPushTemplate.PipelineOverrideId)
+	description := fmt.Sprintf("Raised by Devtron for the deployment of %s on %s. The deployment will be synced once this pull request is merged.", manifestPushTemplate.AppName, manifestPushTemplate.EnvironmentName)
+	pullRequestDetail, err := impl.gitOperationService.CommitValuesOnPullRequest(newCtx, chartGitAttr, title, description)
+	if err != nil {
+		impl.logger.Errorw("error in raising pull request", "repoName", chartGitAttr.ChartRepoName, "branch", chartGitAttr.TargetBranch, "err", err)
+		return "", err
+	}
+	tx, err := impl.transactionManager.StartTx()
+	defer impl.transactionManager.RollbackTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in transaction begin in saving gitops pull request", "err", err)
+		return "", err
+	}
+	pullRequest := &pullRequestRepository.GitOpsPullRequest{
+		CdWorkflowRunnerId: manifestPushTemplate.WorkflowRunnerId,
+		PipelineOverrideId: manifestPushTemplate.PipelineOverrideId,
+		PipelineId:         manifestPushTemplate.PipelineId,
+		RepoName:           chartGitAttr.ChartRepoName,
+		Branch:             chartGitAttr.TargetBranch,
+		PullRequestId:      pullRequestDetail.Id,
+		PullRequestUrl:     pullRequestDetail.Url,
+		State:              pullRequestRepository.PullRequestStateOpen,
+		AutoMerge:          autoMerge,
+	}
+	pullRequest.CreateAuditLog(manifestPushTemplate.UserId)
+	err = impl.gitOpsPullRequestRepository.Save(tx, pullRequest)
+	if err != nil {
+		impl.logger.Errorw("error in saving gitops pull request", "pullRequest", pullRequest, "err", err)
+		return "", err
+	}
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(manifestPushTemplate.WorkflowRunnerId, timelineStatus.TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED, timelineStatus.TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_RAISED, manifestPushTemplate.UserId)
+	err = impl.pipelineStatusTimelineService.SaveTimeline(timeline, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving pull request raised timeline", "timeline", timeline, "err", err)
+		return "", err
+	}
+	err = impl.transactionManager.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction to save gitops pull request", "err", err)
+		return "", err
+	}
+	err = impl.cdWorkflowRepository.UpdateGitOpsPullRequestUrl(manifestPushTemplate.WorkflowRunnerId, pullRequestDetail.Url)
+	if err != nil {
+		impl.logger.Errorw("error in updating pull request url in workflow runner", "wfrId", manifestPushTemplate.WorkflowRunnerId, "err", err)
+		return "", err
+	}
+	return pullRequestDetail.Url, nil
+}
+
+func (impl *GitOpsManifestPushServiceImpl) updatePullRequestForValues(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate, chartGitAttr *git.ChartConfig, pullRequest *pullRequestRepository.GitOpsPullRequest) (string, error) {
+	_, _, err := impl.gitOperationService.CommitValues(ctx, chartGitAttr)
+	if err != nil {
+		impl.logger.Errorw("error in committing values on pull request branch", "repoName", chartGitAttr.ChartRepoName, "branch", chartGitAttr.TargetBranch, "err", err)
+		return "", err
+	}
+	err = impl.cdWorkflowRepository.UpdateGitOpsPullRequestUrl(manifestPushTemplate.WorkflowRunnerId, pullRequest.PullRequestUrl)
+	if err != nil {
+		impl.logger.Errorw("error in updating pull request url in workflow runner", "wfrId", manifestPushTemplate.WorkflowRunnerId, "err", err)
+		return "", err
+	}
+	return pullRequest.PullRequestUrl, nil
+}
+
 func (impl *GitOpsManifestPushServiceImpl) SaveTimelineForError(manifestPushTemplate *bean.ManifestPushTemplate, gitCommitErr error) {
 	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(manifestPushTemplate.WorkflowRunnerId, timelineStatus.TIMELINE_STATUS_GIT_COMMIT_FAILED, fmt.Sprintf("Git commit failed - %v", gitCommitErr), manifestPushTemplate.UserId)
 	timelineErr := impl.pipelineStatusTimelineService.SaveTimeline(timeline, nil)
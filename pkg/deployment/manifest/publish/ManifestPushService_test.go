@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publish
+
+import (
+	"context"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/app/bean"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	pullRequestRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// fakeGitOperationService raises the pull requests on an in memory provider
+type fakeGitOperationService struct {
+	git.GitOperationService
+	raised    []*git.ChartConfig
+	committed []*git.ChartConfig
+}
+
+func (fake *fakeGitOperationService) CommitValuesOnPullRequest(ctx context.Context, chartGitAttr *git.ChartConfig, title, description string) (*git.PullRequestDetail, error) {
+	fake.raised = append(fake.raised, chartGitAttr)
+	return &git.PullRequestDetail{Id: 7, Url: "https://github.com/devtron/payments/pull/7", State: git.PullRequestStateOpen}, nil
+}
+
+func (fake *fakeGitOperationService) CommitValues(ctx context.Context, chartGitAttr *git.ChartConfig) (string, time.Time, error) {
+	fake.committed = append(fake.committed, chartGitAttr)
+	return "abc123", time.Now(), nil
+}
+
+type fakeGitOpsPullRequestRepository struct {
+	pullRequestRepository.GitOpsPullRequestRepository
+	pullRequests []*pullRequestRepository.GitOpsPullRequest
+}
+
+func (fake *fakeGitOpsPullRequestRepository) Save(tx *pg.Tx, pullRequest *pullRequestRepository.GitOpsPullRequest) error {
+	fake.pullRequests = append(fake.pullRequests, pullRequest)
+	return nil
+}
+
+func (fake *fakeGitOpsPullRequestRepository) FindByCdWorkflowRunnerId(cdWfrId int) (*pullRequestRepository.GitOpsPullRequest, error) {
+	for i := len(fake.pullRequests) - 1; i >= 0; i-- {
+		if fake.pullRequests[i].CdWorkflowRunnerId == cdWfrId {
+			return fake.pullRequests[i], nil
+		}
+	}
+	return &pullRequestRepository.GitOpsPullRequest{}, pg.ErrNoRows
+}
+
+type fakeGitOpsConfigReadService struct {
+	config.GitOpsConfigReadService
+}
+
+func (fake *fakeGitOpsConfigReadService) GetGitOpsRepoNameFromUrl(gitRepoUrl string) string {
+	return "payments"
+}
+
+func (fake *fakeGitOpsConfigReadService) GetUserEmailIdAndNameForGitOpsCommit(userId int32) (string, string) {
+	return "devtron@example.com", "devtron"
+}
+
+type fakeChartTemplateService struct {
+	util.ChartTemplateService
+}
+
+func (fake *fakeChartTemplateService) GetDir() string {
+	return "dir"
+}
+
+type fakePipelineStatusTimelineService struct {
+	status.PipelineStatusTimelineService
+	timelines []timelineStatus.TimelineStatus
+}
+
+func (fake *fakePipelineStatusTimelineService) NewDevtronAppPipelineStatusTimelineDbObject(cdWorkflowRunnerId int, timelineStatus timelineStatus.TimelineStatus, timelineDescription string, userId int32) *pipelineConfig.PipelineStatusTimeline {
+	return &pipelineConfig.PipelineStatusTimeline{CdWorkflowRunnerId: cdWorkflowRunnerId, Status: timelineStatus}
+}
+
+func (fake *fakePipelineStatusTimelineService) SaveTimeline(timeline *pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) error {
+	fake.timelines = append(fake.timelines, timeline.Status)
+	return nil
+}
+
+type fakeCdWorkflowRepository struct {
+	pipelineConfig.CdWorkflowRepository
+	pullRequestUrls map[int]string
+}
+
+func (fake *fakeCdWorkflowRepository) UpdateGitOpsPullRequestUrl(wfrId int, pullRequestUrl string) error {
+	fake.pullRequestUrls[wfrId] = pullRequestUrl
+	return nil
+}
+
+type fakeTransactionManager struct{}
+
+func (fake fakeTransactionManager) StartTx() (*pg.Tx, error) { return nil, nil }
+
+func (fake fakeTransactionManager) RollbackTx(tx *pg.Tx) error { return nil }
+
+func (fake fakeTransactionManager) CommitTx(tx *pg.Tx) error { return nil }
+
+func newTestManifestPushTemplate(pipelineOverrideId int) *bean.ManifestPushTemplate {
+	return &bean.ManifestPushTemplate{
+		WorkflowRunnerId:      11,
+		PipelineOverrideId:    pipelineOverrideId,
+		PipelineId:            31,
+		AppName:               "payments",
+		EnvironmentName:       "staging",
+		TargetEnvironmentName: 4,
+		RepoUrl:               "https://github.com/devtron/payments.git",
+		MergedValues:          "replicaCount: 2",
+		ChartLocation:         "payments-staging",
+		UserId:                2,
+	}
+}
+
+func TestGitOpsManifestPushServiceImpl_raisePullRequestForValues(t *testing.T) {
+	gitOperationService := &fakeGitOperationService{}
+	pullRequestRepo := &fakeGitOpsPullRequestRepository{}
+	timelineService := &fakePipelineStatusTimelineService{}
+	cdWorkflowRepository := &fakeCdWorkflowRepository{pullRequestUrls: map[int]string{}}
+	impl := &GitOpsManifestPushServiceImpl{
+		logger:                        zap.NewNop().Sugar(),
+		pipelineStatusTimelineService: timelineService,
+		gitOpsConfigReadService:       &fakeGitOpsConfigReadService{},
+		gitOperationService:           gitOperationService,
+		chartTemplateService:          &fakeChartTemplateService{},
+		gitOpsPullRequestRepository:   pullRequestRepo,
+		cdWorkflowRepository:          cdWorkflowRepository,
+		transactionManager:            fakeTransactionManager{},
+	}
+
+	pullRequestUrl, err := impl.raisePullRequestForValues(context.Background(), newTestManifestPushTemplate(21), true)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://github.com/devtron/payments/pull/7", pullRequestUrl)
+	assert.Len(t, gitOperationService.raised, 1)
+	assert.Equal(t, "devtron/release-21-env-4", gitOperationService.raised[0].TargetBranch)
+	assert.Equal(t, "_4-values.yaml", gitOperationService.raised[0].FileName)
+	assert.Len(t, pullRequestRepo.pullRequests, 1)
+	pullRequest := pullRequestRepo.pullRequests[0]
+	assert.Equal(t, pullRequestRepository.PullRequestStateOpen, pullRequest.State)
+	assert.Equal(t, 7, pullRequest.PullRequestId)
+	assert.Equal(t, "devtron/release-21-env-4", pullRequest.Branch)
+	assert.True(t, pullRequest.AutoMerge)
+	assert.Equal(t, []timelineStatus.TimelineStatus{timelineStatus.TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED}, timelineService.timelines)
+	assert.Equal(t, map[int]string{11: "https://github.com/devtron/payments/pull/7"}, cdWorkflowRepository.pullRequestUrls)
+
+	// pushed again for the same deployment, the values are committed on the branch of the open pull request
+	pullRequestUrl, err = impl.raisePullRequestForValues(context.Background(), newTestManifestPushTemplate(21), true)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://github.com/devtron/payments/pull/7", pullRequestUrl)
+	assert.Len(t, gitOperationService.raised, 1)
+	assert.Len(t, pullRequestRepo.pullRequests, 1)
+	assert.Len(t, gitOperationService.committed, 1)
+	assert.Equal(t, "devtron/release-21-env-4", gitOperationService.committed[0].TargetBranch)
+	assert.Len(t, timelineService.timelines, 1)
+
+	// a pull request no longer open is not reused
+	pullRequest.State = pullRequestRepository.PullRequestStateClosed
+	_, err = impl.raisePullRequestForValues(context.Background(), newTestManifestPushTemplate(21), true)
+	assert.Nil(t, err)
+	assert.Len(t, gitOperationService.raised, 2)
+	assert.Len(t, pullRequestRepo.pullRequests, 2)
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rollback
+
+import (
+	"context"
+	"fmt"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/rollback/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/util/argo"
+	util2 "github.com/devtron-labs/devtron/util/event"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type DeploymentRollbackService interface {
+	// RollbackToLastHealthyRelease re-deploys the artifact and the config snapshot of the
+	// last healthy deployment of the pipeline older than the given runner
+	RollbackToLastHealthyRelease(pipelineId int, wfrId int, reason string) (releaseId int, err error)
+
+	SaveAutoRollbackPolicy(policy *AutoRollbackPolicyDto) (*AutoRollbackPolicyDto, error)
+	GetAutoRollbackPolicy(appId, pipelineId int) (*AutoRollbackPolicyDto, error)
+	// HandleUnhealthyDeployment rolls back the deploy runner if it ended unhealthy
+	// and auto rollback is enabled for its pipeline
+	HandleUnhealthyDeployment(wfrId int)
+}
+
+type DeploymentRollbackServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	cdWorkflowRepository         pipelineConfig.CdWorkflowRepository
+	pipelineRepository           pipelineConfig.PipelineRepository
+	autoRollbackPolicyRepository repository.AutoRollbackPolicyRepository
+	cdTriggerService             devtronApps.TriggerService
+	argoUserService              argo.ArgoUserService
+	eventClient                  client.EventClient
+	eventFactory                 client.EventFactory
+}
+
+func NewDeploymentRollbackServiceImpl(logger *zap.SugaredLogger,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	autoRollbackPolicyRepository repository.AutoRollbackPolicyRepository,
+	cdTriggerService devtronApps.TriggerService,
+	argoUserService argo.ArgoUserService,
+	eventClient client.EventClient,
+	eventFactory client.EventFactory) *DeploymentRollbackServiceImpl {
+	return &DeploymentRollbackServiceImpl{
+		logger:                       logger,
+		cdWorkflowRepository:         cdWorkflowRepository,
+		pipelineRepository:           pipelineRepository,
+		autoRollbackPolicyRepository: autoRollbackPolicyRepository,
+		cdTriggerService:             cdTriggerService,
+		argoUserService:              argoUserService,
+		eventClient:                  eventClient,
+		eventFactory:                 eventFactory,
+	}
+}
+
+func (impl *DeploymentRollbackServiceImpl) RollbackToLastHealthyRelease(pipelineId int, wfrId int, reason string) (int, error) {
+	healthyRunner, err := impl.cdWorkflowRepository.FindLastHealthyDeployRunnerBeforeId(pipelineId, wfrId)
+	if util.IsErrNoRows(err) {
+		return 0, fmt.Errorf("no healthy deployment found to rollback pipeline %d", pipelineId)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching last healthy deployment", "pipelineId", pipelineId, "wfrId", wfrId, "err", err)
+		return 0, err
+	}
+	acdToken, err := impl.argoUserService.GetLatestDevtronArgoCdUserToken()
+	if err != nil {
+		impl.logger.Errorw("error in getting acd token", "err", err)
+		return 0, err
+	}
+	overrideRequest := &apiBean.ValuesOverrideRequest{
+		PipelineId:                            pipelineId,
+		CiArtifactId:                          healthyRunner.CdWorkflow.CiArtifactId,
+		CdWorkflowType:                        apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentType:                        models.DEPLOYMENTTYPE_DEPLOY,
+		DeploymentWithConfig:                  apiBean.DEPLOYMENT_CONFIG_TYPE_SPECIFIC_TRIGGER,
+		WfrIdForDeploymentWithSpecificTrigger: healthyRunner.Id,
+		// system user, as in case of auto trigger
+		UserId: 1,
+		// rolling back is a remediation, it is not held back by deployment windows
+		DeploymentWindowOverrideReason:    reason,
+		IsDeploymentWindowOverrideAllowed: true,
+		TriggerType:                       cdWorkflow.TriggerTypeAutoRollback,
+	}
+	triggerContext := triggerBean.TriggerContext{
+		Context: context.WithValue(context.Background(), "token", acdToken),
+	}
+	releaseId, err := impl.cdTriggerService.ManualCdTrigger(triggerContext, overrideRequest)
+	if err != nil {
+		impl.logger.Errorw("error in triggering rollback deployment", "pipelineId", pipelineId, "targetWfrId", healthyRunner.Id, "err", err)
+		return 0, err
+	}
+	impl.logger.Infow("rollback deployment triggered", "pipelineId", pipelineId, "failedWfrId", wfrId, "targetWfrId", healthyRunner.Id, "reason", reason)
+	return releaseId, nil
+}
+
+func (impl *DeploymentRollbackServiceImpl) SaveAutoRollbackPolicy(policy *AutoRollbackPolicyDto) (*AutoRollbackPolicyDto, error) {
+	pipeline, err := impl.pipelineRepository.FindById(policy.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", policy.PipelineId, "err", err)
+		return nil, err
+	}
+	if pipeline.AppId != policy.AppId {
+		errMsg := fmt.Sprintf("pipeline %d does not belong to app %d", policy.PipelineId, policy.AppId)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	existing, err := impl.autoRollbackPolicyRepository.FindByPipelineId(policy.PipelineId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching auto rollback policy", "pipelineId", policy.PipelineId, "err", err)
+		return nil, err
+	}
+	dbObject := &repository.AutoRollbackPolicy{
+		PipelineId: policy.PipelineId,
+		Enabled:    policy.Enabled,
+		Active:     true,
+		AuditLog:   sql.NewDefaultAuditLog(policy.UserId),
+	}
+	if existing != nil && existing.Id > 0 {
+		dbObject.Id = existing.Id
+		dbObject.CreatedOn = existing.CreatedOn
+		dbObject.CreatedBy = existing.CreatedBy
+		err = impl.autoRollbackPolicyRepository.Update(dbObject)
+	} else {
+		err = impl.autoRollbackPolicyRepository.Save(dbObject)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving auto rollback policy", "pipelineId", policy.PipelineId, "err", err)
+		return nil, err
+	}
+	policy.Id = dbObject.Id
+	return policy, nil
+}
+
+func (impl *DeploymentRollbackServiceImpl) GetAutoRollbackPolicy(appId, pipelineId int) (*AutoRollbackPolicyDto, error) {
+	policy, err := impl.autoRollbackPolicyRepository.FindByPipelineId(pipelineId)
+	if util.IsErrNoRows(err) {
+		return &AutoRollbackPolicyDto{AppId: appId, PipelineId: pipelineId}, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching auto rollback policy", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return &AutoRollbackPolicyDto{
+		Id:         policy.Id,
+		AppId:      appId,
+		PipelineId: policy.PipelineId,
+		Enabled:    policy.Enabled,
+	}, nil
+}
+
+func (impl *DeploymentRollbackServiceImpl) HandleUnhealthyDeployment(wfrId int) {
+	wfr, err := impl.cdWorkflowRepository.FindWorkflowRunnerById(wfrId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd workflow runner", "wfrId", wfrId, "err", err)
+		return
+	}
+	if !isAutoRollbackCandidate(wfr) {
+		return
+	}
+	pipelineId := wfr.CdWorkflow.PipelineId
+	policy, err := impl.autoRollbackPolicyRepository.FindByPipelineId(pipelineId)
+	if util.IsErrNoRows(err) {
+		return
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching auto rollback policy", "pipelineId", pipelineId, "err", err)
+		return
+	}
+	if !policy.Enabled {
+		return
+	}
+	isLatest, err := impl.cdWorkflowRepository.IsLatestCDWfr(pipelineId, wfr.Id)
+	if err != nil {
+		impl.logger.Errorw("error in checking latest deploy runner", "pipelineId", pipelineId, "wfrId", wfr.Id, "err", err)
+		return
+	}
+	if !isLatest {
+		// a newer deployment is already on its way, rolling back would override it
+		impl.logger.Infow("skipping auto rollback, deployment is superseded", "pipelineId", pipelineId, "wfrId", wfr.Id)
+		return
+	}
+	// status updates of the same runner arrive from several sources and replicas, only the first one rolls back
+	claimed, err := impl.cdWorkflowRepository.MarkRollbackTriggered(wfr.Id)
+	if err != nil {
+		impl.logger.Errorw("error in claiming auto rollback", "wfrId", wfr.Id, "err", err)
+		return
+	} else if !claimed {
+		return
+	}
+	failureReason := fmt.Sprintf(AutoRollbackFailureReason, wfr.Status)
+	_, err = impl.RollbackToLastHealthyRelease(pipelineId, wfr.Id, fmt.Sprintf(AutoRollbackReason, wfr.Id, wfr.Status))
+	if err != nil {
+		impl.logger.Errorw("error in auto rollback of unhealthy deployment", "pipelineId", pipelineId, "wfrId", wfr.Id, "err", err)
+		failureReason = fmt.Sprintf(AutoRollbackErrorReason, wfr.Status, err.Error())
+	}
+	impl.writeAutoRollbackEvent(wfr, failureReason)
+}
+
+func (impl *DeploymentRollbackServiceImpl) writeAutoRollbackEvent(wfr *pipelineConfig.CdWorkflowRunner, failureReason string) {
+	pipeline := wfr.CdWorkflow.Pipeline
+	event, _ := impl.eventFactory.Build(util2.Fail, &pipeline.Id, pipeline.AppId, &pipeline.EnvironmentId, util2.CD)
+	event = impl.eventFactory.BuildExtraCDData(event, wfr, 0, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	event.Payload.FailureReason = failureReason
+	_, evtErr := impl.eventClient.WriteNotificationEvent(event)
+	if evtErr != nil {
+		impl.logger.Errorw("auto rollback event not sent", "wfrId", wfr.Id, "error", evtErr)
+	}
+}
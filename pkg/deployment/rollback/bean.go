@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rollback
+
+const (
+	// AutoRollbackReason is audited as the deployment window override reason of the rollback deployment
+	AutoRollbackReason        = "automatic rollback of deployment %d, status %s"
+	AutoRollbackFailureReason = "Deployment status %s, rolled back automatically to the last healthy release"
+	AutoRollbackErrorReason   = "Deployment status %s, automatic rollback failed: %s"
+)
+
+type AutoRollbackPolicyDto struct {
+	Id         int   `json:"id"`
+	AppId      int   `json:"appId" validate:"required"`
+	PipelineId int   `json:"pipelineId" validate:"required"`
+	Enabled    bool  `json:"enabled"`
+	UserId     int32 `json:"-"`
+}
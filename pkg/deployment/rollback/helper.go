@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rollback
+
+import (
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"k8s.io/utils/strings/slices"
+)
+
+// isAutoRollbackCandidate reports whether the deploy runner ended unhealthy in a way a rollback can remediate.
+// Superseded releases are replaced by a newer deployment already and rollbacks are never rolled back again.
+func isAutoRollbackCandidate(wfr *pipelineConfig.CdWorkflowRunner) bool {
+	if wfr.WorkflowType != apiBean.CD_WORKFLOW_TYPE_DEPLOY || wfr.IsAutoRollback() {
+		return false
+	}
+	if wfr.Message == cdWorkflow.ErrorDeploymentSuperseded.Error() {
+		return false
+	}
+	return slices.Contains(cdWorkflow.WfrUnhealthyStatusList, wfr.Status)
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rollback
+
+import (
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsAutoRollbackCandidate(t *testing.T) {
+	tests := []struct {
+		name string
+		wfr  *pipelineConfig.CdWorkflowRunner
+		want bool
+	}{
+		{"failed deployment", &pipelineConfig.CdWorkflowRunner{WorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY, Status: cdWorkflow.WorkflowFailed}, true},
+		{"timed out deployment", &pipelineConfig.CdWorkflowRunner{WorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY, Status: cdWorkflow.WorkflowTimedOut}, true},
+		{"degraded deployment", &pipelineConfig.CdWorkflowRunner{WorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY, Status: "Degraded"}, true},
+		{"succeeded deployment", &pipelineConfig.CdWorkflowRunner{WorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY, Status: cdWorkflow.WorkflowSucceeded}, false},
+		{"in progress deployment", &pipelineConfig.CdWorkflowRunner{WorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY, Status: cdWorkflow.WorkflowInProgress}, false},
+		{"failed post stage", &pipelineConfig.CdWorkflowRunner{WorkflowType: apiBean.CD_WORKFLOW_TYPE_POST, Status: cdWorkflow.WorkflowFailed}, false},
+		{"superseded deployment", &pipelineConfig.CdWorkflowRunner{WorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY, Status: cdWorkflow.WorkflowFailed, Message: cdWorkflow.NEW_DEPLOYMENT_INITIATED}, false},
+		{"failed rollback", &pipelineConfig.CdWorkflowRunner{WorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY, Status: cdWorkflow.WorkflowFailed, TriggerType: cdWorkflow.TriggerTypeAutoRollback}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isAutoRollbackCandidate(tt.wfr))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+type AutoRollbackPolicy struct {
+	tableName  struct{} `sql:"auto_rollback_policy" pg:",discard_unknown_columns"`
+	Id         int      `sql:"id,pk"`
+	PipelineId int      `sql:"pipeline_id,notnull"`
+	Enabled    bool     `sql:"enabled,notnull"`
+	Active     bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type AutoRollbackPolicyRepository interface {
+	Save(policy *AutoRollbackPolicy) error
+	Update(policy *AutoRollbackPolicy) error
+	FindByPipelineId(pipelineId int) (*AutoRollbackPolicy, error)
+}
+
+type AutoRollbackPolicyRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewAutoRollbackPolicyRepositoryImpl(dbConnection *pg.DB) *AutoRollbackPolicyRepositoryImpl {
+	return &AutoRollbackPolicyRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *AutoRollbackPolicyRepositoryImpl) Save(policy *AutoRollbackPolicy) error {
+	return impl.dbConnection.Insert(policy)
+}
+
+func (impl *AutoRollbackPolicyRepositoryImpl) Update(policy *AutoRollbackPolicy) error {
+	return impl.dbConnection.Update(policy)
+}
+
+func (impl *AutoRollbackPolicyRepositoryImpl) FindByPipelineId(pipelineId int) (*AutoRollbackPolicy, error) {
+	policy := &AutoRollbackPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
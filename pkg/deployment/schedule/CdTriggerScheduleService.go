@@ -0,0 +1,360 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"context"
+	"fmt"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	userRepository "github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
+	deployedAppBean "github.com/devtron-labs/devtron/pkg/deployment/deployedApp/bean"
+	scheduleRepository "github.com/devtron-labs/devtron/pkg/deployment/schedule/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/util/argo"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type CdTriggerScheduleService interface {
+	SaveSchedule(schedule *CdTriggerScheduleDto) (*CdTriggerScheduleDto, error)
+	DeleteSchedule(id int, userId int32) error
+	GetSchedule(id int) (*CdTriggerScheduleDto, error)
+	GetSchedules(appId, pipelineId int) ([]*CdTriggerScheduleDto, error)
+	// TriggerDueSchedules triggers the actions of all the schedules which are due, it must run on a single replica
+	TriggerDueSchedules()
+}
+
+type CdTriggerScheduleServiceImpl struct {
+	logger                      *zap.SugaredLogger
+	cdTriggerScheduleRepository scheduleRepository.CdTriggerScheduleRepository
+	pipelineRepository          pipelineConfig.PipelineRepository
+	ciArtifactRepository        repository.CiArtifactRepository
+	cdTriggerService            devtronApps.TriggerService
+	deployedAppService          deployedApp.DeployedAppService
+	deploymentApprovalService   deploymentApproval.DeploymentApprovalService
+	argoUserService             argo.ArgoUserService
+	userRepository              userRepository.UserRepository
+	enforcer                    casbin.Enforcer
+	enforcerUtil                rbac.EnforcerUtil
+}
+
+func NewCdTriggerScheduleServiceImpl(logger *zap.SugaredLogger,
+	cdTriggerScheduleRepository scheduleRepository.CdTriggerScheduleRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	ciArtifactRepository repository.CiArtifactRepository,
+	cdTriggerService devtronApps.TriggerService,
+	deployedAppService deployedApp.DeployedAppService,
+	deploymentApprovalService deploymentApproval.DeploymentApprovalService,
+	argoUserService argo.ArgoUserService,
+	userRepository userRepository.UserRepository,
+	enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil) *CdTriggerScheduleServiceImpl {
+	return &CdTriggerScheduleServiceImpl{
+		logger:                      logger,
+		cdTriggerScheduleRepository: cdTriggerScheduleRepository,
+		pipelineRepository:          pipelineRepository,
+		ciArtifactRepository:        ciArtifactRepository,
+		cdTriggerService:            cdTriggerService,
+		deployedAppService:          deployedAppService,
+		deploymentApprovalService:   deploymentApprovalService,
+		argoUserService:             argoUserService,
+		userRepository:              userRepository,
+		enforcer:                    enforcer,
+		enforcerUtil:                enforcerUtil,
+	}
+}
+
+func (impl *CdTriggerScheduleServiceImpl) SaveSchedule(schedule *CdTriggerScheduleDto) (*CdTriggerScheduleDto, error) {
+	pipeline, err := impl.pipelineRepository.FindById(schedule.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", schedule.PipelineId, "err", err)
+		return nil, err
+	}
+	if pipeline.AppId != schedule.AppId {
+		errMsg := fmt.Sprintf("pipeline %d does not belong to app %d", schedule.PipelineId, schedule.AppId)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	if len(schedule.Timezone) == 0 {
+		schedule.Timezone = DefaultTimezone
+	}
+	nextRunOn, err := getNextRunOn(schedule.CronExpression, schedule.Timezone, time.Now())
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	dbObject := &scheduleRepository.CdTriggerSchedule{
+		PipelineId:     schedule.PipelineId,
+		Action:         string(schedule.Action),
+		CronExpression: schedule.CronExpression,
+		Timezone:       schedule.Timezone,
+		Enabled:        schedule.Enabled,
+		NextRunOn:      nextRunOn,
+		Active:         true,
+		AuditLog:       sql.NewDefaultAuditLog(schedule.UserId),
+	}
+	if schedule.Id > 0 {
+		existing, err := impl.cdTriggerScheduleRepository.FindById(schedule.Id)
+		if err != nil {
+			impl.logger.Errorw("error in fetching cd trigger schedule", "id", schedule.Id, "err", err)
+			return nil, err
+		}
+		if existing.PipelineId != schedule.PipelineId {
+			errMsg := fmt.Sprintf("schedule %d does not belong to pipeline %d", schedule.Id, schedule.PipelineId)
+			return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+		}
+		dbObject.Id = existing.Id
+		dbObject.LastTriggeredOn = existing.LastTriggeredOn
+		dbObject.LastTriggerMessage = existing.LastTriggerMessage
+		dbObject.CreatedOn = existing.CreatedOn
+		dbObject.CreatedBy = existing.CreatedBy
+		err = impl.cdTriggerScheduleRepository.Update(dbObject)
+	} else {
+		err = impl.cdTriggerScheduleRepository.Save(dbObject)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving cd trigger schedule", "schedule", schedule, "err", err)
+		return nil, err
+	}
+	return toScheduleDto(dbObject, schedule.AppId), nil
+}
+
+func (impl *CdTriggerScheduleServiceImpl) DeleteSchedule(id int, userId int32) error {
+	schedule, err := impl.cdTriggerScheduleRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd trigger schedule", "id", id, "err", err)
+		return err
+	}
+	schedule.Active = false
+	schedule.UpdateAuditLog(userId)
+	err = impl.cdTriggerScheduleRepository.Update(schedule)
+	if err != nil {
+		impl.logger.Errorw("error in deleting cd trigger schedule", "id", id, "err", err)
+	}
+	return err
+}
+
+func (impl *CdTriggerScheduleServiceImpl) GetSchedule(id int) (*CdTriggerScheduleDto, error) {
+	schedule, err := impl.cdTriggerScheduleRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd trigger schedule", "id", id, "err", err)
+		return nil, err
+	}
+	pipeline, err := impl.pipelineRepository.FindById(schedule.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", schedule.PipelineId, "err", err)
+		return nil, err
+	}
+	return toScheduleDto(schedule, pipeline.AppId), nil
+}
+
+func (impl *CdTriggerScheduleServiceImpl) GetSchedules(appId, pipelineId int) ([]*CdTriggerScheduleDto, error) {
+	pipeline, err := impl.pipelineRepository.FindById(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	// rbac is evaluated on the app in the path, schedules of another app's pipeline must not leak through it
+	if pipeline.AppId != appId {
+		errMsg := fmt.Sprintf("pipeline %d does not belong to app %d", pipelineId, appId)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusNotFound).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	schedules, err := impl.cdTriggerScheduleRepository.FindByPipelineId(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd trigger schedules", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	result := make([]*CdTriggerScheduleDto, 0, len(schedules))
+	for _, schedule := range schedules {
+		result = append(result, toScheduleDto(schedule, appId))
+	}
+	return result, nil
+}
+
+func (impl *CdTriggerScheduleServiceImpl) TriggerDueSchedules() {
+	now := time.Now()
+	schedules, err := impl.cdTriggerScheduleRepository.FindDueSchedules(now)
+	if err != nil {
+		impl.logger.Errorw("error in fetching due cd trigger schedules", "err", err)
+		return
+	}
+	for _, schedule := range schedules {
+		impl.triggerSchedule(schedule, now)
+	}
+}
+
+func (impl *CdTriggerScheduleServiceImpl) triggerSchedule(schedule *scheduleRepository.CdTriggerSchedule, now time.Time) {
+	// the next run is moved ahead before triggering, a slow or failing trigger must not be retried on every tick
+	nextRunOn, err := getNextRunOn(schedule.CronExpression, schedule.Timezone, now)
+	if err != nil {
+		impl.logger.Errorw("disabling cd trigger schedule with invalid expression", "scheduleId", schedule.Id, "err", err)
+		schedule.Enabled = false
+	}
+	schedule.NextRunOn = nextRunOn
+	schedule.LastTriggeredOn = now
+	err = impl.cdTriggerScheduleRepository.Update(schedule)
+	if err != nil {
+		impl.logger.Errorw("error in updating next run of cd trigger schedule", "scheduleId", schedule.Id, "err", err)
+		return
+	}
+	if !schedule.Enabled {
+		return
+	}
+	message, err := impl.executeSchedule(schedule)
+	if err != nil {
+		impl.logger.Errorw("error in triggering cd trigger schedule", "scheduleId", schedule.Id, "pipelineId", schedule.PipelineId, "action", schedule.Action, "err", err)
+		message = err.Error()
+	}
+	schedule.LastTriggerMessage = message
+	err = impl.cdTriggerScheduleRepository.Update(schedule)
+	if err != nil {
+		impl.logger.Errorw("error in updating trigger result of cd trigger schedule", "scheduleId", schedule.Id, "err", err)
+	}
+}
+
+// executeSchedule returns a message describing what was done for the schedule's trigger history
+func (impl *CdTriggerScheduleServiceImpl) executeSchedule(schedule *scheduleRepository.CdTriggerSchedule) (string, error) {
+	pipeline, err := impl.pipelineRepository.FindById(schedule.PipelineId)
+	if err != nil {
+		return "", err
+	}
+	// the schedule acts on behalf of the user who last saved it, their trigger access is checked again on every run
+	// as it may have been revoked or the user deactivated since
+	userId := schedule.UpdatedBy
+	isAuthorised, err := impl.isAuthorisedToTrigger(userId, pipeline)
+	if err != nil {
+		return "", err
+	} else if !isAuthorised {
+		impl.logger.Warnw("skipping cd trigger schedule as its owner can not trigger the pipeline", "scheduleId", schedule.Id, "userId", userId)
+		return ScheduleOwnerNotAuthorised, nil
+	}
+	acdToken, err := impl.argoUserService.GetLatestDevtronArgoCdUserToken()
+	if err != nil {
+		return "", err
+	}
+	ctx := context.WithValue(context.Background(), "token", acdToken)
+	switch ScheduleAction(schedule.Action) {
+	case ScheduleActionDeployLatest:
+		return impl.deployLatestApprovedArtifact(ctx, pipeline, userId)
+	case ScheduleActionHibernate, ScheduleActionUnhibernate:
+		requestType := deployedAppBean.STOP
+		if ScheduleAction(schedule.Action) == ScheduleActionUnhibernate {
+			requestType = deployedAppBean.START
+		}
+		_, err = impl.deployedAppService.StopStartApp(ctx, &deployedAppBean.StopAppRequest{
+			AppId:         pipeline.AppId,
+			EnvironmentId: pipeline.EnvironmentId,
+			UserId:        userId,
+			RequestType:   requestType,
+			TriggerType:   cdWorkflow.TriggerTypeScheduled,
+		})
+		if err != nil {
+			return "", err
+		}
+		return ScheduledTriggerSuccess, nil
+	default:
+		return "", fmt.Errorf("unsupported schedule action %s", schedule.Action)
+	}
+}
+
+// isAuthorisedToTrigger returns false if the user is no longer active or can no longer trigger the pipeline
+func (impl *CdTriggerScheduleServiceImpl) isAuthorisedToTrigger(userId int32, pipeline *pipelineConfig.Pipeline) (bool, error) {
+	user, err := impl.userRepository.GetById(userId)
+	if err == pg.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching user", "userId", userId, "err", err)
+		return false, err
+	}
+	appObject := impl.enforcerUtil.GetAppRBACNameByAppId(pipeline.AppId)
+	envObject := impl.enforcerUtil.GetAppRBACByAppIdAndPipelineId(pipeline.AppId, pipeline.Id)
+	return impl.enforcer.EnforceByEmail(user.EmailId, casbin.ResourceApplications, casbin.ActionTrigger, appObject) &&
+		impl.enforcer.EnforceByEmail(user.EmailId, casbin.ResourceEnvironment, casbin.ActionTrigger, envObject), nil
+}
+
+func (impl *CdTriggerScheduleServiceImpl) deployLatestApprovedArtifact(ctx context.Context, pipeline *pipelineConfig.Pipeline, userId int32) (string, error) {
+	artifacts, err := impl.ciArtifactRepository.GetArtifactsByCDPipeline(pipeline.Id, artifactLookupLimit, pipeline.CiPipelineId, apiBean.CI_WORKFLOW_TYPE)
+	if err != nil {
+		return "", err
+	}
+	artifactIds := make([]int, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		artifactIds = append(artifactIds, artifact.Id)
+	}
+	policy, approvalInfo, err := impl.deploymentApprovalService.GetArtifactApprovalInfo(pipeline, artifactIds)
+	if err != nil {
+		return "", err
+	}
+	if policy == nil {
+		approvalInfo = nil
+	}
+	artifact := selectArtifactToDeploy(artifacts, approvalInfo)
+	if artifact == nil {
+		return NoArtifactToDeploy, nil
+	}
+	if artifact.Latest {
+		return fmt.Sprintf(LatestAlreadyDeployed, artifact.Id), nil
+	}
+	overrideRequest := &apiBean.ValuesOverrideRequest{
+		PipelineId:           pipeline.Id,
+		AppId:                pipeline.AppId,
+		CiArtifactId:         artifact.Id,
+		CdWorkflowType:       apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentType:       models.DEPLOYMENTTYPE_DEPLOY,
+		DeploymentWithConfig: apiBean.DEPLOYMENT_CONFIG_TYPE_LAST_SAVED,
+		UserId:               userId,
+		TriggerType:          cdWorkflow.TriggerTypeScheduled,
+	}
+	_, err = impl.cdTriggerService.ManualCdTrigger(triggerBean.TriggerContext{Context: ctx}, overrideRequest)
+	if err != nil {
+		return "", err
+	}
+	return ScheduledTriggerSuccess, nil
+}
+
+func toScheduleDto(schedule *scheduleRepository.CdTriggerSchedule, appId int) *CdTriggerScheduleDto {
+	dto := &CdTriggerScheduleDto{
+		Id:                 schedule.Id,
+		AppId:              appId,
+		PipelineId:         schedule.PipelineId,
+		Action:             ScheduleAction(schedule.Action),
+		CronExpression:     schedule.CronExpression,
+		Timezone:           schedule.Timezone,
+		Enabled:            schedule.Enabled,
+		LastTriggerMessage: schedule.LastTriggerMessage,
+	}
+	if schedule.Enabled && !schedule.NextRunOn.IsZero() {
+		nextRunOn := schedule.NextRunOn
+		dto.NextRunOn = &nextRunOn
+	}
+	if !schedule.LastTriggeredOn.IsZero() {
+		lastTriggeredOn := schedule.LastTriggeredOn
+		dto.LastTriggeredOn = &lastTriggeredOn
+	}
+	return dto
+}
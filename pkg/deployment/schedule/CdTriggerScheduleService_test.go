@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"context"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	userRepository "github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
+	deployedAppBean "github.com/devtron-labs/devtron/pkg/deployment/deployedApp/bean"
+	scheduleRepository "github.com/devtron-labs/devtron/pkg/deployment/schedule/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/util/argo"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakePipelineRepository struct {
+	pipelineConfig.PipelineRepository
+}
+
+func (repo fakePipelineRepository) FindById(id int) (*pipelineConfig.Pipeline, error) {
+	return &pipelineConfig.Pipeline{Id: id, AppId: 1, EnvironmentId: 2}, nil
+}
+
+type fakeUserRepository struct {
+	userRepository.UserRepository
+	activeUsers map[int32]string
+}
+
+func (repo fakeUserRepository) GetById(id int32) (*userRepository.UserModel, error) {
+	emailId, ok := repo.activeUsers[id]
+	if !ok {
+		return &userRepository.UserModel{}, pg.ErrNoRows
+	}
+	return &userRepository.UserModel{Id: id, EmailId: emailId}, nil
+}
+
+type fakeEnforcer struct {
+	casbin.Enforcer
+	triggerAccess map[string]bool
+}
+
+func (enforcer fakeEnforcer) EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool {
+	return action == casbin.ActionTrigger && enforcer.triggerAccess[emailId]
+}
+
+type fakeEnforcerUtil struct {
+	rbac.EnforcerUtil
+}
+
+func (enforcerUtil fakeEnforcerUtil) GetAppRBACNameByAppId(appId int) string {
+	return "team/app"
+}
+
+func (enforcerUtil fakeEnforcerUtil) GetAppRBACByAppIdAndPipelineId(appId int, pipelineId int) string {
+	return "env/app"
+}
+
+type fakeArgoUserService struct {
+	argo.ArgoUserService
+}
+
+func (service fakeArgoUserService) GetLatestDevtronArgoCdUserToken() (string, error) {
+	return "token", nil
+}
+
+type fakeDeployedAppService struct {
+	deployedApp.DeployedAppService
+	requests []*deployedAppBean.StopAppRequest
+}
+
+func (service *fakeDeployedAppService) StopStartApp(ctx context.Context, stopRequest *deployedAppBean.StopAppRequest) (int, error) {
+	service.requests = append(service.requests, stopRequest)
+	return 1, nil
+}
+
+func TestCdTriggerScheduleServiceImpl_executeSchedule(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	deployedAppService := &fakeDeployedAppService{}
+	impl := NewCdTriggerScheduleServiceImpl(logger, nil, fakePipelineRepository{}, nil, nil, deployedAppService, nil, fakeArgoUserService{},
+		fakeUserRepository{activeUsers: map[int32]string{2: "editor@example.com", 3: "viewer@example.com"}},
+		fakeEnforcer{triggerAccess: map[string]bool{"editor@example.com": true}}, fakeEnforcerUtil{})
+	newSchedule := func(updatedBy int32) *scheduleRepository.CdTriggerSchedule {
+		return &scheduleRepository.CdTriggerSchedule{
+			Id:         1,
+			PipelineId: 10,
+			Action:     string(ScheduleActionHibernate),
+			AuditLog:   sql.AuditLog{CreatedBy: 1, UpdatedBy: updatedBy},
+		}
+	}
+
+	// the schedule runs as the user who last saved it, not as its author
+	message, err := impl.executeSchedule(newSchedule(2))
+	assert.Nil(t, err)
+	assert.Equal(t, ScheduledTriggerSuccess, message)
+	assert.Len(t, deployedAppService.requests, 1)
+	assert.Equal(t, int32(2), deployedAppService.requests[0].UserId)
+	assert.Equal(t, deployedAppBean.STOP, deployedAppService.requests[0].RequestType)
+
+	// skipped once the user lost trigger access
+	message, err = impl.executeSchedule(newSchedule(3))
+	assert.Nil(t, err)
+	assert.Equal(t, ScheduleOwnerNotAuthorised, message)
+	assert.Len(t, deployedAppService.requests, 1)
+
+	// skipped once the user is deactivated
+	message, err = impl.executeSchedule(newSchedule(4))
+	assert.Nil(t, err)
+	assert.Equal(t, ScheduleOwnerNotAuthorised, message)
+	assert.Len(t, deployedAppService.requests, 1)
+}
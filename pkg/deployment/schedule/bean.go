@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import "time"
+
+type ScheduleAction string
+
+const (
+	// ScheduleActionDeployLatest deploys the latest artifact of the pipeline which is approved for deployment on it
+	ScheduleActionDeployLatest ScheduleAction = "DEPLOY_LATEST"
+	ScheduleActionHibernate    ScheduleAction = "HIBERNATE"
+	ScheduleActionUnhibernate  ScheduleAction = "UNHIBERNATE"
+)
+
+const (
+	DefaultTimezone         = "UTC"
+	NoArtifactToDeploy      = "no approved artifact found to deploy"
+	LatestAlreadyDeployed   = "latest approved artifact %d is already deployed"
+	ScheduledTriggerSuccess = "triggered successfully"
+	// ScheduleOwnerNotAuthorised is recorded when the user who last saved the schedule is deactivated or lost access
+	ScheduleOwnerNotAuthorised = "skipped, the user who last saved the schedule can no longer trigger the pipeline"
+	InvalidCronExpression      = "invalid cron expression %q: %s"
+	InvalidTimezone            = "invalid timezone %q"
+	// artifactLookupLimit is the number of latest artifacts of the pipeline looked through for an approved one
+	artifactLookupLimit = 20
+)
+
+// CdTriggerScheduleDto triggers the action on a cd pipeline on the standard 5 field cron expression, evaluated in the timezone
+type CdTriggerScheduleDto struct {
+	Id                 int            `json:"id"`
+	AppId              int            `json:"appId" validate:"required"`
+	PipelineId         int            `json:"pipelineId" validate:"required"`
+	Action             ScheduleAction `json:"action" validate:"oneof=DEPLOY_LATEST HIBERNATE UNHIBERNATE"`
+	CronExpression     string         `json:"cronExpression" validate:"required"`
+	Timezone           string         `json:"timezone"`
+	Enabled            bool           `json:"enabled"`
+	NextRunOn          *time.Time     `json:"nextRunOn,omitempty"`
+	LastTriggeredOn    *time.Time     `json:"lastTriggeredOn,omitempty"`
+	LastTriggerMessage string         `json:"lastTriggerMessage,omitempty"`
+	UserId             int32          `json:"-"`
+}
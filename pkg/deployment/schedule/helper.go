@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	"github.com/robfig/cron/v3"
+	"time"
+)
+
+// getNextRunOn returns the first time after from at which the cron expression fires in the timezone
+func getNextRunOn(cronExpression, timezone string, from time.Time) (time.Time, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(InvalidTimezone, timezone)
+	}
+	schedule, err := cron.ParseStandard(cronExpression)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(InvalidCronExpression, cronExpression, err.Error())
+	}
+	return schedule.Next(from.In(location)), nil
+}
+
+// selectArtifactToDeploy picks the latest of the artifacts, ordered latest first, which is approved for deployment.
+// Approval info is nil if deployments on the pipeline need no approval
+func selectArtifactToDeploy(artifacts []*repository.CiArtifact, approvalInfo map[int]*bean.ArtifactApprovalInfo) *repository.CiArtifact {
+	for _, artifact := range artifacts {
+		if approvalInfo == nil {
+			return artifact
+		}
+		if info, ok := approvalInfo[artifact.Id]; ok && info.IsApproved {
+			return artifact
+		}
+	}
+	return nil
+}
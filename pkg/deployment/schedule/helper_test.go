@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetNextRunOn(t *testing.T) {
+	from := time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC)
+	nextRunOn, err := getNextRunOn("0 2 * * *", "UTC", from)
+	assert.Nil(t, err)
+	assert.True(t, nextRunOn.Equal(time.Date(2024, 3, 10, 2, 0, 0, 0, time.UTC)))
+
+	// 02:00 in Kolkata is 20:30 UTC of the previous day
+	nextRunOn, err = getNextRunOn("0 2 * * *", "Asia/Kolkata", from)
+	assert.Nil(t, err)
+	assert.True(t, nextRunOn.Equal(time.Date(2024, 3, 10, 20, 30, 0, 0, time.UTC)))
+
+	_, err = getNextRunOn("0 2 * *", "UTC", from)
+	assert.NotNil(t, err)
+	_, err = getNextRunOn("0 2 * * *", "Mars/Olympus", from)
+	assert.NotNil(t, err)
+}
+
+func TestSelectArtifactToDeploy(t *testing.T) {
+	artifacts := []*repository.CiArtifact{{Id: 3}, {Id: 2}, {Id: 1}}
+	assert.Equal(t, 3, selectArtifactToDeploy(artifacts, nil).Id)
+	approvalInfo := map[int]*bean.ArtifactApprovalInfo{
+		3: {IsApproved: false},
+		2: {IsApproved: true},
+	}
+	assert.Equal(t, 2, selectArtifactToDeploy(artifacts, approvalInfo).Id)
+	assert.Nil(t, selectArtifactToDeploy(artifacts, map[int]*bean.ArtifactApprovalInfo{}))
+}
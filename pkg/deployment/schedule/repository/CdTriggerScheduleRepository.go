@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type CdTriggerSchedule struct {
+	tableName          struct{}  `sql:"cd_trigger_schedule" pg:",discard_unknown_columns"`
+	Id                 int       `sql:"id,pk"`
+	PipelineId         int       `sql:"pipeline_id,notnull"`
+	Action             string    `sql:"action,notnull"`
+	CronExpression     string    `sql:"cron_expression,notnull"`
+	Timezone           string    `sql:"timezone,notnull"`
+	Enabled            bool      `sql:"enabled,notnull"`
+	NextRunOn          time.Time `sql:"next_run_on"`
+	LastTriggeredOn    time.Time `sql:"last_triggered_on"`
+	LastTriggerMessage string    `sql:"last_trigger_message"`
+	Active             bool      `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type CdTriggerScheduleRepository interface {
+	Save(schedule *CdTriggerSchedule) error
+	Update(schedule *CdTriggerSchedule) error
+	FindById(id int) (*CdTriggerSchedule, error)
+	FindByPipelineId(pipelineId int) ([]*CdTriggerSchedule, error)
+	// FindDueSchedules returns the enabled schedules whose next run is not after the given time
+	FindDueSchedules(now time.Time) ([]*CdTriggerSchedule, error)
+}
+
+type CdTriggerScheduleRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewCdTriggerScheduleRepositoryImpl(dbConnection *pg.DB) *CdTriggerScheduleRepositoryImpl {
+	return &CdTriggerScheduleRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *CdTriggerScheduleRepositoryImpl) Save(schedule *CdTriggerSchedule) error {
+	return impl.dbConnection.Insert(schedule)
+}
+
+func (impl *CdTriggerScheduleRepositoryImpl) Update(schedule *CdTriggerSchedule) error {
+	return impl.dbConnection.Update(schedule)
+}
+
+func (impl *CdTriggerScheduleRepositoryImpl) FindById(id int) (*CdTriggerSchedule, error) {
+	schedule := &CdTriggerSchedule{}
+	err := impl.dbConnection.Model(schedule).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return schedule, err
+}
+
+func (impl *CdTriggerScheduleRepositoryImpl) FindByPipelineId(pipelineId int) ([]*CdTriggerSchedule, error) {
+	schedules := make([]*CdTriggerSchedule, 0)
+	err := impl.dbConnection.Model(&schedules).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return schedules, err
+}
+
+func (impl *CdTriggerScheduleRepositoryImpl) FindDueSchedules(now time.Time) ([]*CdTriggerSchedule, error) {
+	schedules := make([]*CdTriggerSchedule, 0)
+	err := impl.dbConnection.Model(&schedules).
+		Where("active = ?", true).
+		Where("enabled = ?", true).
+		Where("next_run_on <= ?", now).
+		Order("next_run_on ASC").
+		Select()
+	return schedules, err
+}
@@ -56,6 +56,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/deployment/common"
 	bean9 "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monorepo"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest"
 	bean5 "github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/chartRef/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/publish"
@@ -63,9 +64,12 @@ import (
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/helper"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/userDeploymentRequest/service"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval"
+	"github.com/devtron-labs/devtron/pkg/deploymentWindow"
 	clientErrors "github.com/devtron-labs/devtron/pkg/errors"
 	"github.com/devtron-labs/devtron/pkg/eventProcessor/out"
 	"github.com/devtron-labs/devtron/pkg/imageDigestPolicy"
+	"github.com/devtron-labs/devtron/pkg/imageSignature"
 	k8s2 "github.com/devtron-labs/devtron/pkg/k8s"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
 	bean8 "github.com/devtron-labs/devtron/pkg/pipeline/bean"
@@ -166,6 +170,10 @@ type TriggerServiceImpl struct {
 	deploymentServiceTypeConfig   *util3.DeploymentServiceTypeConfig
 	ciCdPipelineOrchestrator      pipeline.CiCdPipelineOrchestrator
 	attributeService              attributes.AttributesService
+	deploymentWindowService       deploymentWindow.DeploymentWindowService
+	deploymentApprovalService     deploymentApproval.DeploymentApprovalService
+	gitOpsMonorepoService         monorepo.GitOpsMonorepoService
+	imageSignatureService         imageSignature.ImageSignatureService
 }
 
 func NewTriggerServiceImpl(logger *zap.SugaredLogger,
@@ -221,6 +229,10 @@ func NewTriggerServiceImpl(logger *zap.SugaredLogger,
 	transactionUtilImpl *sql.TransactionUtilImpl,
 	deploymentConfigService common.DeploymentConfigService,
 	ciCdPipelineOrchestrator pipeline.CiCdPipelineOrchestrator, attributeService attributes.AttributesService,
+	deploymentWindowService deploymentWindow.DeploymentWindowService,
+	deploymentApprovalService deploymentApproval.DeploymentApprovalService,
+	gitOpsMonorepoService monorepo.GitOpsMonorepoService,
+	imageSignatureService imageSignature.ImageSignatureService,
 ) (*TriggerServiceImpl, error) {
 	impl := &TriggerServiceImpl{
 		logger:                              logger,
@@ -278,6 +290,10 @@ func NewTriggerServiceImpl(logger *zap.SugaredLogger,
 		deploymentServiceTypeConfig:         envVariables.DeploymentServiceTypeConfig,
 		ciCdPipelineOrchestrator:            ciCdPipelineOrchestrator,
 		attributeService:                    attributeService,
+		deploymentWindowService:             deploymentWindowService,
+		deploymentApprovalService:           deploymentApprovalService,
+		gitOpsMonorepoService:               gitOpsMonorepoService,
+		imageSignatureService:               imageSignatureService,
 	}
 	config, err := types.GetCdConfig()
 	if err != nil {
@@ -368,6 +384,52 @@ func (impl *TriggerServiceImpl) validateDeploymentTriggerRequest(ctx context.Con
 	return nil
 }
 
+// enforceDeploymentWindow blocks the deployment if a blackout window is active or no maintenance window is open,
+// unless it is a permitted override; the runner is marked failed with the blocking reason
+func (impl *TriggerServiceImpl) enforceDeploymentWindow(runner *pipelineConfig.CdWorkflowRunner, cdPipeline *pipelineConfig.Pipeline,
+	overrideReason string, isOverrideAllowed bool, triggeredAt time.Time, triggeredBy int32) error {
+	checkRequest := adapter.GetDeploymentWindowCheckRequest(runner, cdPipeline, overrideReason, isOverrideAllowed, triggeredAt, triggeredBy)
+	err := impl.deploymentWindowService.EnforceDeploymentWindow(checkRequest)
+	if err != nil {
+		impl.logger.Errorw("deployment blocked by deployment window", "pipelineId", cdPipeline.Id, "wfrId", runner.Id, "err", err)
+		if markErr := impl.cdWorkflowCommonService.MarkCurrentDeploymentFailed(runner, err, triggeredBy); markErr != nil {
+			impl.logger.Errorw("error while updating current runner status to failed, enforceDeploymentWindow", "wfrId", runner.Id, "err", markErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// enforceDeploymentApproval blocks the deployment if the pipeline needs approvals which the artifact does not have,
+// the runner is marked failed with the reason
+func (impl *TriggerServiceImpl) enforceDeploymentApproval(runner *pipelineConfig.CdWorkflowRunner, cdPipeline *pipelineConfig.Pipeline,
+	artifactId int, triggeredBy int32) error {
+	err := impl.deploymentApprovalService.EnforceApproval(cdPipeline, artifactId)
+	if err != nil {
+		impl.logger.Errorw("deployment blocked for missing approval", "pipelineId", cdPipeline.Id, "artifactId", artifactId, "wfrId", runner.Id, "err", err)
+		if markErr := impl.cdWorkflowCommonService.MarkCurrentDeploymentFailed(runner, err, triggeredBy); markErr != nil {
+			impl.logger.Errorw("error while updating current runner status to failed, enforceDeploymentApproval", "wfrId", runner.Id, "err", markErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// enforceImageSignature blocks the deployment if the artifact has no trusted signature for a signature policy
+// applicable on the environment, the runner is marked failed with the reason
+func (impl *TriggerServiceImpl) enforceImageSignature(ctx context.Context, runner *pipelineConfig.CdWorkflowRunner, cdPipeline *pipelineConfig.Pipeline,
+	artifact *repository3.CiArtifact, triggeredBy int32) error {
+	err := impl.imageSignatureService.EnforceSignatureVerification(ctx, cdPipeline.EnvironmentId, artifact, triggeredBy)
+	if err != nil {
+		impl.logger.Errorw("deployment blocked by image signature policy", "pipelineId", cdPipeline.Id, "artifactId", artifact.Id, "wfrId", runner.Id, "err", err)
+		if markErr := impl.cdWorkflowCommonService.MarkCurrentDeploymentFailed(runner, err, triggeredBy); markErr != nil {
+			impl.logger.Errorw("error while updating current runner status to failed, enforceImageSignature", "wfrId", runner.Id, "err", markErr)
+		}
+		return err
+	}
+	return nil
+}
+
 // TODO: write a wrapper to handle auto and manual trigger
 func (impl *TriggerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerContext, overrideRequest *bean3.ValuesOverrideRequest) (int, error) {
 	//setting triggeredAt variable to have consistent data for various audit log places in db for deployment time
@@ -455,6 +517,7 @@ func (impl *TriggerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 			CdWorkflowId: cdWorkflowId,
 			AuditLog:     sql.AuditLog{CreatedOn: triggeredAt, CreatedBy: overrideRequest.UserId, UpdatedOn: triggeredAt, UpdatedBy: overrideRequest.UserId},
 			ReferenceId:  triggerContext.ReferenceId,
+			TriggerType:  overrideRequest.TriggerType,
 		}
 		savedWfr, err := impl.cdWorkflowRepository.SaveWorkFlowRunner(runner)
 		overrideRequest.WfrId = savedWfr.Id
@@ -495,6 +558,22 @@ func (impl *TriggerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 				impl.logger.Errorw("validation error deployment request", "cdWfr", runner.Id, "err", validationErr)
 				return 0, validationErr
 			}
+			windowErr := impl.enforceDeploymentWindow(runner, cdPipeline, overrideRequest.DeploymentWindowOverrideReason,
+				overrideRequest.IsDeploymentWindowOverrideAllowed, triggeredAt, overrideRequest.UserId)
+			if windowErr != nil {
+				return 0, windowErr
+			}
+			// a rollback goes back to an artifact which was running healthy on the pipeline, it needs no fresh approval
+			if overrideRequest.TriggerType != cdWorkflow.TriggerTypeAutoRollback {
+				approvalErr := impl.enforceDeploymentApproval(runner, cdPipeline, artifact.Id, overrideRequest.UserId)
+				if approvalErr != nil {
+					return 0, approvalErr
+				}
+			}
+			signatureErr := impl.enforceImageSignature(ctx, runner, cdPipeline, artifact, overrideRequest.UserId)
+			if signatureErr != nil {
+				return 0, signatureErr
+			}
 		}
 		// Deploy the release
 		var releaseErr error
@@ -629,6 +708,19 @@ func (impl *TriggerServiceImpl) TriggerAutomaticDeployment(request bean.TriggerR
 		impl.logger.Errorw("validation error deployment request", "cdWfr", runner.Id, "err", validationErr)
 		return validationErr
 	}
+	// auto triggers can never override a deployment window
+	windowErr := impl.enforceDeploymentWindow(runner, pipeline, "", false, triggeredAt, 1)
+	if windowErr != nil {
+		return windowErr
+	}
+	approvalErr := impl.enforceDeploymentApproval(runner, pipeline, artifact.Id, 1)
+	if approvalErr != nil {
+		return approvalErr
+	}
+	signatureErr := impl.enforceImageSignature(ctx, runner, pipeline, artifact, 1)
+	if signatureErr != nil {
+		return signatureErr
+	}
 	releaseErr := impl.TriggerCD(ctx, artifact, cdWf.Id, savedWfr.Id, pipeline, envDeploymentConfig, triggeredAt)
 	// if releaseErr found, then the mark current deployment Failed and return
 	if releaseErr != nil {
@@ -827,31 +919,31 @@ func (impl *TriggerServiceImpl) TriggerRelease(overrideRequest *bean3.ValuesOver
 
 func (impl *TriggerServiceImpl) performGitOps(ctx context.Context,
 	overrideRequest *bean3.ValuesOverrideRequest, valuesOverrideResponse *app.ValuesOverrideResponse,
-	builtChartPath string, triggerEvent bean.TriggerEvent) error {
+	builtChartPath string, triggerEvent bean.TriggerEvent) (isPullRequestRaised bool, err error) {
 	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "TriggerServiceImpl.performGitOps")
 	defer span.End()
 	// update workflow runner status, used in app workflow view
-	err := impl.cdWorkflowCommonService.UpdateNonTerminalStatusInRunner(newCtx, overrideRequest.WfrId, overrideRequest.UserId, cdWorkflow.WorkflowInProgress)
+	err = impl.cdWorkflowCommonService.UpdateNonTerminalStatusInRunner(newCtx, overrideRequest.WfrId, overrideRequest.UserId, cdWorkflow.WorkflowInProgress)
 	if err != nil {
 		impl.logger.Errorw("error in updating the workflow runner status", "err", err)
-		return err
+		return isPullRequestRaised, err
 	}
 	manifestPushTemplate, err := impl.buildManifestPushTemplate(overrideRequest, valuesOverrideResponse, builtChartPath)
 	if err != nil {
 		impl.logger.Errorw("error in building manifest push template", "err", err)
-		return err
+		return isPullRequestRaised, err
 	}
 	manifestPushService := impl.getManifestPushService(triggerEvent)
 	manifestPushResponse := manifestPushService.PushChart(newCtx, manifestPushTemplate)
 	if manifestPushResponse.Error != nil {
 		impl.logger.Errorw("error in pushing manifest to git", "err", manifestPushResponse.Error, "git_repo_url", manifestPushTemplate.RepoUrl)
-		return manifestPushResponse.Error
+		return isPullRequestRaised, manifestPushResponse.Error
 	}
 	if manifestPushResponse.IsNewGitRepoConfigured() {
 		// Update GitOps repo url after repo new repo created
 		valuesOverrideResponse.DeploymentConfig.RepoURL = manifestPushResponse.NewGitRepoUrl
 	}
-	return nil
+	return manifestPushResponse.IsPullRequestRaised(), nil
 }
 
 func (impl *TriggerServiceImpl) buildTriggerEventForOverrideRequest(overrideRequest *bean3.ValuesOverrideRequest, triggeredAt time.Time) (triggerEvent bean.TriggerEvent, skipRequest bool, err error) {
@@ -882,6 +974,11 @@ func (impl *TriggerServiceImpl) buildTriggerEventForOverrideRequest(overrideRequ
 		// git commit has already been performed
 		triggerEvent.PerformChartPush = false
 	}
+	if slices.Contains(timelineStatuses, timelineStatus.TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED) {
+		// values are committed through a pull request, ArgoCd app will be synced once it is merged
+		triggerEvent.PerformChartPush = false
+		triggerEvent.SkipArgoCdSync = true
+	}
 	if slices.Contains(timelineStatuses, timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_COMPLETED) {
 		// ArgoCd sync has already been performed
 		triggerEvent.DeployArgoCdApp = false
@@ -893,11 +990,15 @@ func (impl *TriggerServiceImpl) triggerPipeline(overrideRequest *bean3.ValuesOve
 	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "TriggerServiceImpl.triggerPipeline")
 	defer span.End()
 	if triggerEvent.PerformChartPush {
-		err = impl.performGitOps(newCtx, overrideRequest, valuesOverrideResponse, builtChartPath, triggerEvent)
+		isPullRequestRaised, err := impl.performGitOps(newCtx, overrideRequest, valuesOverrideResponse, builtChartPath, triggerEvent)
 		if err != nil {
 			impl.logger.Errorw("error in performing GitOps", "cdWfrId", overrideRequest.WfrId, "err", err)
 			return releaseNo, err
 		}
+		if isPullRequestRaised {
+			// ArgoCd app will be synced once the pull request is merged
+			triggerEvent.SkipArgoCdSync = true
+		}
 	}
 	if triggerEvent.PerformDeploymentOnCluster {
 		err = impl.deployApp(newCtx, overrideRequest, valuesOverrideResponse, triggerEvent)
@@ -928,6 +1029,7 @@ func (impl *TriggerServiceImpl) buildManifestPushTemplate(overrideRequest *bean3
 	manifestPushTemplate := &bean4.ManifestPushTemplate{
 		WorkflowRunnerId:      overrideRequest.WfrId,
 		AppId:                 overrideRequest.AppId,
+		PipelineId:            overrideRequest.PipelineId,
 		ChartRefId:            valuesOverrideResponse.EnvOverride.Chart.ChartRefId,
 		EnvironmentId:         valuesOverrideResponse.EnvOverride.Environment.Id,
 		EnvironmentName:       valuesOverrideResponse.EnvOverride.Environment.Namespace,
@@ -973,7 +1075,7 @@ func (impl *TriggerServiceImpl) deployApp(ctx context.Context, overrideRequest *
 	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "TriggerServiceImpl.deployApp")
 	defer span.End()
 	if util.IsAcdApp(overrideRequest.DeploymentAppType) && triggerEvent.DeployArgoCdApp {
-		err := impl.deployArgoCdApp(newCtx, overrideRequest, valuesOverrideResponse)
+		err := impl.deployArgoCdApp(newCtx, overrideRequest, valuesOverrideResponse, triggerEvent)
 		if err != nil {
 			impl.logger.Errorw("error in deploying app on ArgoCd", "err", err)
 			return err
@@ -1139,21 +1241,31 @@ func (impl *TriggerServiceImpl) createHelmAppForCdPipeline(ctx context.Context,
 }
 
 func (impl *TriggerServiceImpl) deployArgoCdApp(ctx context.Context, overrideRequest *bean3.ValuesOverrideRequest,
-	valuesOverrideResponse *app.ValuesOverrideResponse) error {
+	valuesOverrideResponse *app.ValuesOverrideResponse, triggerEvent bean.TriggerEvent) error {
 	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "TriggerServiceImpl.deployArgoCdApp")
 	defer span.End()
 	impl.logger.Debugw("new pipeline found", "pipeline", valuesOverrideResponse.Pipeline)
-	name, err := impl.createArgoApplicationIfRequired(newCtx, overrideRequest.AppId, valuesOverrideResponse.EnvOverride, valuesOverrideResponse.Pipeline, overrideRequest.UserId)
+	// chart of the environment is in its own directory if the app is in a shared GitOps repository
+	chartPathPrefix, err := impl.gitOpsMonorepoService.GetChartPathPrefix(overrideRequest.AppId, valuesOverrideResponse.Pipeline.EnvironmentId, valuesOverrideResponse.DeploymentConfig.RepoURL, overrideRequest.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in getting chart path in shared gitops repository", "appId", overrideRequest.AppId, "envId", valuesOverrideResponse.Pipeline.EnvironmentId, "err", err)
+		return err
+	}
+	name, err := impl.createArgoApplicationIfRequired(newCtx, overrideRequest.AppId, valuesOverrideResponse.EnvOverride, valuesOverrideResponse.Pipeline, chartPathPrefix, overrideRequest.UserId)
 	if err != nil {
 		impl.logger.Errorw("acd application create error on cd trigger", "err", err, "req", overrideRequest)
 		return err
 	}
 	impl.logger.Debugw("ArgoCd application created", "name", name)
-	updateAppInArgoCd, err := impl.updateArgoPipeline(newCtx, valuesOverrideResponse.Pipeline, valuesOverrideResponse.EnvOverride, valuesOverrideResponse.DeploymentConfig)
+	updateAppInArgoCd, err := impl.updateArgoPipeline(newCtx, valuesOverrideResponse.Pipeline, valuesOverrideResponse.EnvOverride, valuesOverrideResponse.DeploymentConfig, chartPathPrefix)
 	if err != nil {
 		impl.logger.Errorw("error in updating argocd app ", "err", err)
 		return err
 	}
+	if triggerEvent.SkipArgoCdSync {
+		impl.logger.Infow("skipping ArgoCd sync, waiting for gitops pull request to be merged", "argoAppName", valuesOverrideResponse.Pipeline.DeploymentAppName)
+		return nil
+	}
 	syncTime := time.Now()
 	err = impl.argoClientWrapperService.SyncArgoCDApplicationIfNeededAndRefresh(newCtx, valuesOverrideResponse.Pipeline.DeploymentAppName)
 	if err != nil {
@@ -1182,7 +1294,7 @@ func (impl *TriggerServiceImpl) deployArgoCdApp(ctx context.Context, overrideReq
 }
 
 // update repoUrl, revision and argo app sync mode (auto/manual) if needed
-func (impl *TriggerServiceImpl) updateArgoPipeline(ctx context.Context, pipeline *pipelineConfig.Pipeline, envOverride *chartConfig.EnvConfigOverride, deploymentConfig *bean9.DeploymentConfig) (bool, error) {
+func (impl *TriggerServiceImpl) updateArgoPipeline(ctx context.Context, pipeline *pipelineConfig.Pipeline, envOverride *chartConfig.EnvConfigOverride, deploymentConfig *bean9.DeploymentConfig, chartPathPrefix string) (bool, error) {
 	if ctx == nil {
 		impl.logger.Errorw("err in syncing ACD, ctx is NULL", "pipelineName", pipeline.Name)
 		return false, nil
@@ -1200,10 +1312,11 @@ func (impl *TriggerServiceImpl) updateArgoPipeline(ctx context.Context, pipeline
 	appStatus, _ := status2.FromError(err)
 	if appStatus.Code() == codes.OK {
 		impl.logger.Debugw("argo app exists", "app", argoAppName, "pipeline", pipeline.Name)
-		if impl.argoClientWrapperService.IsArgoAppPatchRequired(argoApplication.Spec.Source, deploymentConfig.RepoURL, envOverride.Chart.ChartLocation) {
+		chartLocation := path.Join(chartPathPrefix, envOverride.Chart.ChartLocation)
+		if impl.argoClientWrapperService.IsArgoAppPatchRequired(argoApplication.Spec.Source, deploymentConfig.RepoURL, chartLocation) {
 			patchRequestDto := &bean7.ArgoCdAppPatchReqDto{
 				ArgoAppName:    argoAppName,
-				ChartLocation:  envOverride.Chart.ChartLocation,
+				ChartLocation:  chartLocation,
 				GitRepoUrl:     deploymentConfig.RepoURL,
 				TargetRevision: bean7.TargetRevisionMaster,
 				PatchType:      bean7.PatchTypeMerge,
@@ -1235,7 +1348,7 @@ func (impl *TriggerServiceImpl) updateArgoPipeline(ctx context.Context, pipeline
 	}
 }
 
-func (impl *TriggerServiceImpl) createArgoApplicationIfRequired(ctx context.Context, appId int, envConfigOverride *chartConfig.EnvConfigOverride, pipeline *pipelineConfig.Pipeline, userId int32) (string, error) {
+func (impl *TriggerServiceImpl) createArgoApplicationIfRequired(ctx context.Context, appId int, envConfigOverride *chartConfig.EnvConfigOverride, pipeline *pipelineConfig.Pipeline, chartPathPrefix string, userId int32) (string, error) {
 	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "TriggerServiceImpl.createArgoApplicationIfRequired")
 	defer span.End()
 	// repo has been registered while helm create
@@ -1266,7 +1379,7 @@ func (impl *TriggerServiceImpl) createArgoApplicationIfRequired(ctx context.Cont
 			TargetServer:    envModel.Cluster.ServerUrl,
 			Project:         "default",
 			ValuesFile:      helper.GetValuesFileForEnv(envModel.Id),
-			RepoPath:        chart.ChartLocation,
+			RepoPath:        path.Join(chartPathPrefix, chart.ChartLocation),
 			RepoUrl:         chart.GitRepoUrl,
 			AutoSyncEnabled: impl.ACDConfig.ArgoCDAutoSyncEnabled,
 		}
@@ -22,6 +22,7 @@ import (
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	bean2 "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/deploymentWindow"
 	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
 	"time"
 )
@@ -46,6 +47,26 @@ func GetVulnerabilityCheckRequest(cdPipeline *pipelineConfig.Pipeline, imageDige
 	}
 }
 
+func GetDeploymentWindowCheckRequest(runner *pipelineConfig.CdWorkflowRunner, cdPipeline *pipelineConfig.Pipeline,
+	overrideReason string, isOverrideAllowed bool, triggeredAt time.Time, triggeredBy int32) *deploymentWindow.DeploymentWindowCheckRequest {
+	scope := deploymentWindow.TargetScope{
+		AppId: cdPipeline.AppId,
+		EnvId: cdPipeline.EnvironmentId,
+	}
+	if cdPipeline.Environment.Id != 0 {
+		scope.ClusterId = cdPipeline.Environment.ClusterId
+	}
+	return &deploymentWindow.DeploymentWindowCheckRequest{
+		TargetScope:        scope,
+		PipelineId:         cdPipeline.Id,
+		CdWorkflowRunnerId: runner.Id,
+		TriggeredBy:        triggeredBy,
+		TriggeredAt:        triggeredAt,
+		OverrideReason:     overrideReason,
+		IsOverrideAllowed:  isOverrideAllowed,
+	}
+}
+
 func NewUserDeploymentRequest(overrideRequest *apiBean.ValuesOverrideRequest, triggeredAt time.Time, triggeredBy int32) *eventProcessorBean.UserDeploymentRequest {
 	return &eventProcessorBean.UserDeploymentRequest{
 		ValuesOverrideRequest: overrideRequest,
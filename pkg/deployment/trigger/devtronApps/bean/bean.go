@@ -33,10 +33,12 @@ type TriggerEvent struct {
 	PerformChartPush           bool
 	PerformDeploymentOnCluster bool
 	DeployArgoCdApp            bool
-	DeploymentAppType          string
-	ManifestStorageType        string
-	TriggeredBy                int32
-	TriggeredAt                time.Time
+	// SkipArgoCdSync is set when the values are committed through a pull request, the app is synced once it is merged
+	SkipArgoCdSync      bool
+	DeploymentAppType   string
+	ManifestStorageType string
+	TriggeredBy         int32
+	TriggeredAt         time.Time
 }
 
 type TriggerRequest struct {
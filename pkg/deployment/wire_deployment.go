@@ -17,10 +17,13 @@
 package deployment
 
 import (
+	"github.com/devtron-labs/devtron/pkg/deployment/canary"
 	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest"
 	"github.com/devtron-labs/devtron/pkg/deployment/providerConfig"
+	"github.com/devtron-labs/devtron/pkg/deployment/rollback"
+	"github.com/devtron-labs/devtron/pkg/deployment/schedule"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger"
 	"github.com/google/wire"
 )
@@ -33,4 +36,7 @@ var DeploymentWireSet = wire.NewSet(
 	trigger.DeploymentTriggerWireSet,
 	deployedApp.DeployedAppWireSet,
 	providerConfig.DeploymentProviderConfigWireSet,
+	rollback.DeploymentRollbackWireSet,
+	canary.CanaryAnalysisWireSet,
+	schedule.CdTriggerScheduleWireSet,
 )
@@ -0,0 +1,438 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentApproval
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	approvalRepository "github.com/devtron-labs/devtron/pkg/deploymentApproval/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type DeploymentApprovalService interface {
+	CreatePolicy(policy *bean.ApprovalPolicyDto) (*bean.ApprovalPolicyDto, error)
+	UpdatePolicy(policy *bean.ApprovalPolicyDto) (*bean.ApprovalPolicyDto, error)
+	DeletePolicy(id int, userId int32) error
+	GetAllPolicies() ([]*bean.ApprovalPolicyDto, error)
+	// GetApplicablePolicy returns the policy enforced on the pipeline, nil if deployments on it need no approval
+	GetApplicablePolicy(pipeline *pipelineConfig.Pipeline) (*bean.ApprovalPolicyDto, error)
+
+	RaiseApprovalRequest(request *bean.ApprovalRequestDto) (*bean.ApprovalRequestDto, error)
+	GetApprovalRequest(id int) (*bean.ApprovalRequestDto, error)
+	// ActOnApprovalRequest records an approval or rejection of the user, a single rejection rejects the request
+	ActOnApprovalRequest(action *bean.ApprovalActionDto) (*bean.ArtifactApprovalInfo, error)
+
+	// GetArtifactApprovalInfo returns the policy applicable on the pipeline and the latest approval request of each artifact
+	// on it by artifact id, the policy is nil if deployments on the pipeline need no approval
+	GetArtifactApprovalInfo(pipeline *pipelineConfig.Pipeline, artifactIds []int) (*bean.ApprovalPolicyDto, map[int]*bean.ArtifactApprovalInfo, error)
+	// EnforceApproval returns an error if the pipeline needs approvals which the artifact does not have
+	EnforceApproval(pipeline *pipelineConfig.Pipeline, artifactId int) error
+}
+
+type DeploymentApprovalServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	deploymentApprovalRepository approvalRepository.DeploymentApprovalRepository
+	pipelineRepository           pipelineConfig.PipelineRepository
+	ciArtifactRepository         repository.CiArtifactRepository
+	userService                  user.UserService
+	roleGroupService             user.RoleGroupService
+}
+
+func NewDeploymentApprovalServiceImpl(logger *zap.SugaredLogger,
+	deploymentApprovalRepository approvalRepository.DeploymentApprovalRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	ciArtifactRepository repository.CiArtifactRepository,
+	userService user.UserService, roleGroupService user.RoleGroupService) *DeploymentApprovalServiceImpl {
+	return &DeploymentApprovalServiceImpl{
+		logger:                       logger,
+		deploymentApprovalRepository: deploymentApprovalRepository,
+		pipelineRepository:           pipelineRepository,
+		ciArtifactRepository:         ciArtifactRepository,
+		userService:                  userService,
+		roleGroupService:             roleGroupService,
+	}
+}
+
+func (impl *DeploymentApprovalServiceImpl) CreatePolicy(policy *bean.ApprovalPolicyDto) (*bean.ApprovalPolicyDto, error) {
+	err := impl.validatePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	dbObject := toPolicyDbObject(policy)
+	dbObject.AuditLog = sql.NewDefaultAuditLog(policy.UserId)
+	err = impl.deploymentApprovalRepository.SavePolicy(dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in saving deployment approval policy", "policy", policy, "err", err)
+		return nil, err
+	}
+	policy.Id = dbObject.Id
+	return policy, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) UpdatePolicy(policy *bean.ApprovalPolicyDto) (*bean.ApprovalPolicyDto, error) {
+	existing, err := impl.deploymentApprovalRepository.FindPolicyById(policy.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment approval policy", "id", policy.Id, "err", err)
+		return nil, err
+	}
+	err = impl.validatePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	dbObject := toPolicyDbObject(policy)
+	dbObject.AuditLog = existing.AuditLog
+	dbObject.UpdateAuditLog(policy.UserId)
+	err = impl.deploymentApprovalRepository.UpdatePolicy(dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in updating deployment approval policy", "policy", policy, "err", err)
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) DeletePolicy(id int, userId int32) error {
+	policy, err := impl.deploymentApprovalRepository.FindPolicyById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment approval policy", "id", id, "err", err)
+		return err
+	}
+	policy.Active = false
+	policy.UpdateAuditLog(userId)
+	err = impl.deploymentApprovalRepository.UpdatePolicy(policy)
+	if err != nil {
+		impl.logger.Errorw("error in deleting deployment approval policy", "id", id, "err", err)
+	}
+	return err
+}
+
+func (impl *DeploymentApprovalServiceImpl) GetAllPolicies() ([]*bean.ApprovalPolicyDto, error) {
+	policies, err := impl.deploymentApprovalRepository.FindAllActivePolicies()
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment approval policies", "err", err)
+		return nil, err
+	}
+	result := make([]*bean.ApprovalPolicyDto, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, toPolicyDto(policy))
+	}
+	return result, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) GetApplicablePolicy(pipeline *pipelineConfig.Pipeline) (*bean.ApprovalPolicyDto, error) {
+	policies, err := impl.deploymentApprovalRepository.FindActivePoliciesByPipelineOrEnv(pipeline.Id, pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment approval policies", "pipelineId", pipeline.Id, "err", err)
+		return nil, err
+	}
+	policy := selectApplicablePolicy(policies, pipeline.Id)
+	if policy == nil {
+		return nil, nil
+	}
+	return toPolicyDto(policy), nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) RaiseApprovalRequest(request *bean.ApprovalRequestDto) (*bean.ApprovalRequestDto, error) {
+	if len(request.Comment) > bean.CommentLimit {
+		errMsg := fmt.Sprintf("comment can not be longer than %d characters", bean.CommentLimit)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	pipeline, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	policy, err := impl.GetApplicablePolicy(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.NoApprovalPolicy).WithInternalMessage(bean.NoApprovalPolicy)
+	}
+	latestRequests, err := impl.deploymentApprovalRepository.FindLatestRequests(request.PipelineId, []int{request.ArtifactId})
+	if err != nil {
+		impl.logger.Errorw("error in fetching approval requests", "pipelineId", request.PipelineId, "artifactId", request.ArtifactId, "err", err)
+		return nil, err
+	}
+	if len(latestRequests) > 0 && latestRequests[0].Status != string(bean.ApprovalRejected) {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusConflict).WithUserMessage(bean.ApprovalAlreadyRequired).WithInternalMessage(bean.ApprovalAlreadyRequired)
+	}
+	_, err = impl.ciArtifactRepository.Get(request.ArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact", "artifactId", request.ArtifactId, "err", err)
+		return nil, err
+	}
+	tx, err := impl.deploymentApprovalRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.deploymentApprovalRepository.RollbackTx(tx)
+	dbObject := &approvalRepository.DeploymentApprovalRequest{
+		ArtifactId: request.ArtifactId,
+		PipelineId: request.PipelineId,
+		Status:     string(bean.ApprovalRequested),
+		Comment:    request.Comment,
+		AuditLog:   sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.deploymentApprovalRepository.SaveRequest(tx, dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in saving approval request", "request", request, "err", err)
+		return nil, err
+	}
+	err = impl.deploymentApprovalRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	request.Id = dbObject.Id
+	request.Status = bean.ApprovalRequested
+	return request, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) GetApprovalRequest(id int) (*bean.ApprovalRequestDto, error) {
+	request, err := impl.deploymentApprovalRepository.FindRequestById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching approval request", "id", id, "err", err)
+		return nil, err
+	}
+	return &bean.ApprovalRequestDto{
+		Id:         request.Id,
+		ArtifactId: request.ArtifactId,
+		PipelineId: request.PipelineId,
+		Comment:    request.Comment,
+		Status:     bean.ApprovalStatus(request.Status),
+	}, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) ActOnApprovalRequest(action *bean.ApprovalActionDto) (*bean.ArtifactApprovalInfo, error) {
+	if len(action.Comment) > bean.CommentLimit {
+		errMsg := fmt.Sprintf("comment can not be longer than %d characters", bean.CommentLimit)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	request, err := impl.deploymentApprovalRepository.FindRequestById(action.ApprovalRequestId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching approval request", "id", action.ApprovalRequestId, "err", err)
+		return nil, err
+	}
+	if request.Status != string(bean.ApprovalRequested) {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusConflict).WithUserMessage(bean.ApprovalNotPending).WithInternalMessage(bean.ApprovalNotPending)
+	}
+	pipeline, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	policy, err := impl.GetApplicablePolicy(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.NoApprovalPolicy).WithInternalMessage(bean.NoApprovalPolicy)
+	}
+	err = impl.validateApprover(policy, request, action.UserId)
+	if err != nil {
+		return nil, err
+	}
+	userActions, err := impl.deploymentApprovalRepository.FindUserActionsByRequestIds([]int{request.Id})
+	if err != nil {
+		impl.logger.Errorw("error in fetching approval user actions", "approvalRequestId", request.Id, "err", err)
+		return nil, err
+	}
+	for _, userAction := range userActions {
+		if userAction.UserId == action.UserId {
+			return nil, util.NewApiError().WithHttpStatusCode(http.StatusConflict).WithUserMessage(bean.AlreadyActioned).WithInternalMessage(bean.AlreadyActioned)
+		}
+	}
+	userAction := &approvalRepository.DeploymentApprovalUserAction{
+		ApprovalRequestId: request.Id,
+		UserId:            action.UserId,
+		Action:            string(action.Action),
+		Comment:           action.Comment,
+		AuditLog:          sql.NewDefaultAuditLog(action.UserId),
+	}
+	userActions = append(userActions, userAction)
+	request.Status = string(getApprovalStatus(userActions, policy.MinApprovers))
+	request.UpdateAuditLog(action.UserId)
+
+	tx, err := impl.deploymentApprovalRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.deploymentApprovalRepository.RollbackTx(tx)
+	err = impl.deploymentApprovalRepository.SaveUserAction(tx, userAction)
+	if err != nil {
+		impl.logger.Errorw("error in saving approval user action", "approvalRequestId", request.Id, "err", err)
+		return nil, err
+	}
+	// another approver may have settled the request since it was read
+	updated, err := impl.deploymentApprovalRepository.UpdateRequestInStatus(tx, request, string(bean.ApprovalRequested))
+	if err != nil {
+		impl.logger.Errorw("error in updating approval request", "approvalRequestId", request.Id, "err", err)
+		return nil, err
+	}
+	if !updated {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusConflict).WithUserMessage(bean.ApprovalNotPending).WithInternalMessage(bean.ApprovalNotPending)
+	}
+	err = impl.deploymentApprovalRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	approvalInfo, err := impl.buildApprovalInfo([]*approvalRepository.DeploymentApprovalRequest{request}, policy)
+	if err != nil {
+		return nil, err
+	}
+	return approvalInfo[request.ArtifactId], nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) GetArtifactApprovalInfo(pipeline *pipelineConfig.Pipeline, artifactIds []int) (*bean.ApprovalPolicyDto, map[int]*bean.ArtifactApprovalInfo, error) {
+	policy, err := impl.GetApplicablePolicy(pipeline)
+	if err != nil {
+		return nil, nil, err
+	}
+	if policy == nil {
+		return nil, map[int]*bean.ArtifactApprovalInfo{}, nil
+	}
+	requests, err := impl.deploymentApprovalRepository.FindLatestRequests(pipeline.Id, artifactIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching approval requests", "pipelineId", pipeline.Id, "artifactIds", artifactIds, "err", err)
+		return nil, nil, err
+	}
+	approvalInfo, err := impl.buildApprovalInfo(requests, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+	return policy, approvalInfo, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) EnforceApproval(pipeline *pipelineConfig.Pipeline, artifactId int) error {
+	policy, approvalInfo, err := impl.GetArtifactApprovalInfo(pipeline, []int{artifactId})
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return nil
+	}
+	if info, ok := approvalInfo[artifactId]; ok && info.IsApproved {
+		return nil
+	}
+	return util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(bean.DeploymentNotApproved).WithInternalMessage(bean.DeploymentNotApproved)
+}
+
+func (impl *DeploymentApprovalServiceImpl) validatePolicy(policy *bean.ApprovalPolicyDto) error {
+	if (policy.PipelineId == 0) == (policy.EnvId == 0) {
+		return util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.InvalidPolicyScope).WithInternalMessage(bean.InvalidPolicyScope)
+	}
+	for _, roleGroupId := range policy.ApproverRoleGroupIds {
+		_, err := impl.roleGroupService.FetchRoleGroupsById(roleGroupId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching approver role group", "roleGroupId", roleGroupId, "err", err)
+			errMsg := fmt.Sprintf("invalid user group id %d", roleGroupId)
+			return util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(err.Error())
+		}
+	}
+	policies, err := impl.deploymentApprovalRepository.FindActivePoliciesByPipelineOrEnv(policy.PipelineId, policy.EnvId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment approval policies", "pipelineId", policy.PipelineId, "envId", policy.EnvId, "err", err)
+		return err
+	}
+	for _, existing := range policies {
+		if existing.Id != policy.Id && existing.PipelineId == policy.PipelineId && existing.EnvId == policy.EnvId {
+			return util.NewApiError().WithHttpStatusCode(http.StatusConflict).WithUserMessage(bean.PolicyAlreadyExists).WithInternalMessage(bean.PolicyAlreadyExists)
+		}
+	}
+	return nil
+}
+
+// validateApprover checks that the user is not the one who built the artifact and is part of an approver user group of the policy
+func (impl *DeploymentApprovalServiceImpl) validateApprover(policy *bean.ApprovalPolicyDto, request *approvalRepository.DeploymentApprovalRequest, userId int32) error {
+	artifact, err := impl.ciArtifactRepository.Get(request.ArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact", "artifactId", request.ArtifactId, "err", err)
+		return err
+	}
+	builtBy := []int32{artifact.CreatedBy}
+	if artifact.ParentCiArtifact > 0 {
+		parentArtifact, err := impl.ciArtifactRepository.Get(artifact.ParentCiArtifact)
+		if err != nil {
+			impl.logger.Errorw("error in fetching parent artifact", "artifactId", artifact.ParentCiArtifact, "err", err)
+			return err
+		}
+		builtBy = append(builtBy, parentArtifact.CreatedBy)
+	}
+	for _, builderId := range builtBy {
+		if builderId == userId {
+			return util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(bean.SelfApprovalNotAllowed).WithInternalMessage(bean.SelfApprovalNotAllowed)
+		}
+	}
+	if len(policy.ApproverRoleGroupIds) == 0 {
+		return nil
+	}
+	userInfo, err := impl.userService.GetById(userId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching user", "userId", userId, "err", err)
+		return err
+	}
+	if !isEligibleApprover(policy.ApproverRoleGroupIds, userInfo) {
+		return util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(bean.NotAnEligibleApprover).WithInternalMessage(bean.NotAnEligibleApprover)
+	}
+	return nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) buildApprovalInfo(requests []*approvalRepository.DeploymentApprovalRequest, policy *bean.ApprovalPolicyDto) (map[int]*bean.ArtifactApprovalInfo, error) {
+	result := make(map[int]*bean.ArtifactApprovalInfo, len(requests))
+	if len(requests) == 0 {
+		return result, nil
+	}
+	requestIds := make([]int, 0, len(requests))
+	userIds := make([]int32, 0, len(requests))
+	for _, request := range requests {
+		requestIds = append(requestIds, request.Id)
+		userIds = append(userIds, request.CreatedBy)
+	}
+	userActions, err := impl.deploymentApprovalRepository.FindUserActionsByRequestIds(requestIds)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching approval user actions", "approvalRequestIds", requestIds, "err", err)
+		return nil, err
+	}
+	userActionsByRequestId := make(map[int][]*approvalRepository.DeploymentApprovalUserAction)
+	for _, userAction := range userActions {
+		userActionsByRequestId[userAction.ApprovalRequestId] = append(userActionsByRequestId[userAction.ApprovalRequestId], userAction)
+		userIds = append(userIds, userAction.UserId)
+	}
+	users, err := impl.userService.GetByIds(userIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching users", "userIds", userIds, "err", err)
+		return nil, err
+	}
+	emailIds := make(map[int32]string, len(users))
+	for _, userInfo := range users {
+		emailIds[userInfo.Id] = userInfo.EmailId
+	}
+	for _, request := range requests {
+		result[request.ArtifactId] = toArtifactApprovalInfo(request, userActionsByRequestId[request.Id], policy.MinApprovers, emailIds)
+	}
+	return result, nil
+}
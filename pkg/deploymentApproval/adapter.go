@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentApproval
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/repository"
+)
+
+func toPolicyDbObject(policy *bean.ApprovalPolicyDto) *repository.DeploymentApprovalPolicy {
+	return &repository.DeploymentApprovalPolicy{
+		Id:                   policy.Id,
+		PipelineId:           policy.PipelineId,
+		EnvId:                policy.EnvId,
+		MinApprovers:         policy.MinApprovers,
+		ApproverRoleGroupIds: policy.ApproverRoleGroupIds,
+		Active:               true,
+	}
+}
+
+func toPolicyDto(policy *repository.DeploymentApprovalPolicy) *bean.ApprovalPolicyDto {
+	approverRoleGroupIds := policy.ApproverRoleGroupIds
+	if approverRoleGroupIds == nil {
+		approverRoleGroupIds = make([]int32, 0)
+	}
+	return &bean.ApprovalPolicyDto{
+		Id:                   policy.Id,
+		PipelineId:           policy.PipelineId,
+		EnvId:                policy.EnvId,
+		MinApprovers:         policy.MinApprovers,
+		ApproverRoleGroupIds: approverRoleGroupIds,
+	}
+}
+
+func toArtifactApprovalInfo(request *repository.DeploymentApprovalRequest, userActions []*repository.DeploymentApprovalUserAction,
+	minApprovers int, emailIds map[int32]string) *bean.ArtifactApprovalInfo {
+	status := getApprovalStatus(userActions, minApprovers)
+	info := &bean.ArtifactApprovalInfo{
+		ApprovalRequestId: request.Id,
+		Status:            status,
+		RequestedBy:       emailIds[request.CreatedBy],
+		RequestedOn:       request.CreatedOn,
+		MinApprovers:      minApprovers,
+		IsApproved:        status == bean.ApprovalApproved,
+		UserApprovals:     make([]*bean.UserApprovalDto, 0, len(userActions)),
+	}
+	for _, userAction := range userActions {
+		if userAction.Action == string(bean.ActionApprove) {
+			info.ApprovalCount++
+		}
+		info.UserApprovals = append(info.UserApprovals, &bean.UserApprovalDto{
+			UserId:     userAction.UserId,
+			EmailId:    emailIds[userAction.UserId],
+			Action:     bean.ApprovalAction(userAction.Action),
+			Comment:    userAction.Comment,
+			ActionedOn: userAction.CreatedOn,
+		})
+	}
+	return info
+}
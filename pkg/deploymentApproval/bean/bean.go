@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type ApprovalStatus string
+
+const (
+	ApprovalRequested ApprovalStatus = "REQUESTED"
+	ApprovalApproved  ApprovalStatus = "APPROVED"
+	ApprovalRejected  ApprovalStatus = "REJECTED"
+)
+
+type ApprovalAction string
+
+const (
+	ActionApprove ApprovalAction = "APPROVE"
+	ActionReject  ApprovalAction = "REJECT"
+)
+
+const (
+	InvalidPolicyId         = "invalid approval policy id"
+	InvalidPolicyScope      = "approval policy must be scoped to exactly one of pipelineId or envId"
+	PolicyAlreadyExists     = "an approval policy already exists for this pipeline or environment"
+	NoApprovalPolicy        = "no approval policy is configured for this pipeline"
+	ApprovalAlreadyRequired = "approval is already requested for this artifact"
+	ApprovalNotPending      = "approval request is not pending"
+	SelfApprovalNotAllowed  = "you can not approve an artifact built by you"
+	NotAnEligibleApprover   = "you are not part of any user group eligible to approve deployments on this pipeline"
+	AlreadyActioned         = "you have already approved or rejected this request"
+	DeploymentNotApproved   = "artifact is not approved for deployment on this pipeline"
+	CommentLimit            = 500
+)
+
+// ApprovalPolicyDto configures the approvals needed before deploying on a cd pipeline or on all pipelines of an environment,
+// a pipeline policy takes precedence over its environment's policy
+type ApprovalPolicyDto struct {
+	Id                   int     `json:"id"`
+	PipelineId           int     `json:"pipelineId,omitempty"`
+	EnvId                int     `json:"envId,omitempty"`
+	MinApprovers         int     `json:"minApprovers" validate:"min=1"`
+	ApproverRoleGroupIds []int32 `json:"approverRoleGroupIds"`
+	UserId               int32   `json:"-"`
+}
+
+type ApprovalRequestDto struct {
+	Id         int            `json:"id"`
+	ArtifactId int            `json:"artifactId" validate:"required"`
+	PipelineId int            `json:"pipelineId" validate:"required"`
+	Comment    string         `json:"comment,omitempty"`
+	Status     ApprovalStatus `json:"status,omitempty"`
+	UserId     int32          `json:"-"`
+}
+
+type ApprovalActionDto struct {
+	ApprovalRequestId int            `json:"approvalRequestId"`
+	Action            ApprovalAction `json:"-"`
+	Comment           string         `json:"comment,omitempty"`
+	UserId            int32          `json:"-"`
+}
+
+type UserApprovalDto struct {
+	UserId     int32          `json:"userId"`
+	EmailId    string         `json:"emailId"`
+	Action     ApprovalAction `json:"action"`
+	Comment    string         `json:"comment,omitempty"`
+	ActionedOn time.Time      `json:"actionedOn"`
+}
+
+// ArtifactApprovalInfo is the latest approval request of an artifact on a cd pipeline
+type ArtifactApprovalInfo struct {
+	ApprovalRequestId int                `json:"approvalRequestId"`
+	Status            ApprovalStatus     `json:"status"`
+	RequestedBy       string             `json:"requestedBy"`
+	RequestedOn       time.Time          `json:"requestedOn"`
+	ApprovalCount     int                `json:"approvalCount"`
+	MinApprovers      int                `json:"minApprovers"`
+	IsApproved        bool               `json:"isApproved"`
+	UserApprovals     []*UserApprovalDto `json:"userApprovals"`
+}
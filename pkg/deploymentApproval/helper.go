@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentApproval
+
+import (
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/repository"
+)
+
+// getApprovalStatus is evaluated against the current policy, so raising the approvers needed on a policy
+// takes effect on requests which were already approved
+func getApprovalStatus(userActions []*repository.DeploymentApprovalUserAction, minApprovers int) bean.ApprovalStatus {
+	approvals := 0
+	for _, userAction := range userActions {
+		switch bean.ApprovalAction(userAction.Action) {
+		case bean.ActionReject:
+			return bean.ApprovalRejected
+		case bean.ActionApprove:
+			approvals++
+		}
+	}
+	if approvals >= minApprovers {
+		return bean.ApprovalApproved
+	}
+	return bean.ApprovalRequested
+}
+
+// selectApplicablePolicy prefers the policy of the pipeline over the policy of its environment
+func selectApplicablePolicy(policies []*repository.DeploymentApprovalPolicy, pipelineId int) *repository.DeploymentApprovalPolicy {
+	var envPolicy *repository.DeploymentApprovalPolicy
+	for _, policy := range policies {
+		if policy.PipelineId == pipelineId {
+			return policy
+		}
+		if policy.PipelineId == 0 && envPolicy == nil {
+			envPolicy = policy
+		}
+	}
+	return envPolicy
+}
+
+// isEligibleApprover super admins can approve irrespective of the approver user groups
+func isEligibleApprover(approverRoleGroupIds []int32, userInfo *apiBean.UserInfo) bool {
+	if userInfo.SuperAdmin {
+		return true
+	}
+	for _, userRoleGroup := range userInfo.UserRoleGroup {
+		if userRoleGroup.RoleGroup == nil {
+			continue
+		}
+		for _, roleGroupId := range approverRoleGroupIds {
+			if userRoleGroup.RoleGroup.Id == roleGroupId {
+				return true
+			}
+		}
+	}
+	return false
+}
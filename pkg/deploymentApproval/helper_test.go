@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentApproval
+
+import (
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetApprovalStatus(t *testing.T) {
+	approve := &repository.DeploymentApprovalUserAction{Action: string(bean.ActionApprove)}
+	reject := &repository.DeploymentApprovalUserAction{Action: string(bean.ActionReject)}
+	assert.Equal(t, bean.ApprovalRequested, getApprovalStatus(nil, 1))
+	assert.Equal(t, bean.ApprovalRequested, getApprovalStatus([]*repository.DeploymentApprovalUserAction{approve}, 2))
+	assert.Equal(t, bean.ApprovalApproved, getApprovalStatus([]*repository.DeploymentApprovalUserAction{approve, approve}, 2))
+	assert.Equal(t, bean.ApprovalRejected, getApprovalStatus([]*repository.DeploymentApprovalUserAction{approve, approve, reject}, 2))
+}
+
+func TestSelectApplicablePolicy(t *testing.T) {
+	envPolicy := &repository.DeploymentApprovalPolicy{Id: 1, EnvId: 3}
+	pipelinePolicy := &repository.DeploymentApprovalPolicy{Id: 2, PipelineId: 7}
+	assert.Nil(t, selectApplicablePolicy(nil, 7))
+	assert.Equal(t, envPolicy, selectApplicablePolicy([]*repository.DeploymentApprovalPolicy{envPolicy}, 7))
+	assert.Equal(t, pipelinePolicy, selectApplicablePolicy([]*repository.DeploymentApprovalPolicy{envPolicy, pipelinePolicy}, 7))
+}
+
+func TestIsEligibleApprover(t *testing.T) {
+	member := &apiBean.UserInfo{UserRoleGroup: []apiBean.UserRoleGroup{{RoleGroup: &apiBean.RoleGroup{Id: 4}}}}
+	assert.True(t, isEligibleApprover([]int32{2, 4}, member))
+	assert.False(t, isEligibleApprover([]int32{2}, member))
+	assert.True(t, isEligibleApprover([]int32{2}, &apiBean.UserInfo{SuperAdmin: true}))
+}
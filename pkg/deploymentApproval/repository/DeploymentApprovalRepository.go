@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+)
+
+type DeploymentApprovalPolicy struct {
+	tableName            struct{} `sql:"deployment_approval_policy" pg:",discard_unknown_columns"`
+	Id                   int      `sql:"id,pk"`
+	PipelineId           int      `sql:"pipeline_id,notnull"`
+	EnvId                int      `sql:"env_id,notnull"`
+	MinApprovers         int      `sql:"min_approvers,notnull"`
+	ApproverRoleGroupIds []int32  `sql:"approver_role_group_ids" pg:",array"`
+	Active               bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type DeploymentApprovalRequest struct {
+	tableName  struct{} `sql:"deployment_approval_request" pg:",discard_unknown_columns"`
+	Id         int      `sql:"id,pk"`
+	ArtifactId int      `sql:"artifact_id,notnull"`
+	PipelineId int      `sql:"pipeline_id,notnull"`
+	Status     string   `sql:"status,notnull"`
+	Comment    string   `sql:"comment"`
+	sql.AuditLog
+}
+
+type DeploymentApprovalUserAction struct {
+	tableName         struct{} `sql:"deployment_approval_user_action" pg:",discard_unknown_columns"`
+	Id                int      `sql:"id,pk"`
+	ApprovalRequestId int      `sql:"approval_request_id,notnull"`
+	UserId            int32    `sql:"user_id,notnull"`
+	Action            string   `sql:"action,notnull"`
+	Comment           string   `sql:"comment"`
+	sql.AuditLog
+}
+
+type DeploymentApprovalRepository interface {
+	//transaction util funcs
+	sql.TransactionWrapper
+	SavePolicy(policy *DeploymentApprovalPolicy) error
+	UpdatePolicy(policy *DeploymentApprovalPolicy) error
+	FindPolicyById(id int) (*DeploymentApprovalPolicy, error)
+	FindAllActivePolicies() ([]*DeploymentApprovalPolicy, error)
+	// FindActivePoliciesByPipelineOrEnv returns the policies configured on the pipeline or on its environment
+	FindActivePoliciesByPipelineOrEnv(pipelineId, envId int) ([]*DeploymentApprovalPolicy, error)
+
+	SaveRequest(tx *pg.Tx, request *DeploymentApprovalRequest) error
+	// UpdateRequestInStatus updates the request only while it is still in the given status, it returns false when it has moved on
+	UpdateRequestInStatus(tx *pg.Tx, request *DeploymentApprovalRequest, status string) (bool, error)
+	FindRequestById(id int) (*DeploymentApprovalRequest, error)
+	// FindLatestRequests returns the latest approval request of each of the artifacts on the pipeline
+	FindLatestRequests(pipelineId int, artifactIds []int) ([]*DeploymentApprovalRequest, error)
+
+	SaveUserAction(tx *pg.Tx, action *DeploymentApprovalUserAction) error
+	FindUserActionsByRequestIds(requestIds []int) ([]*DeploymentApprovalUserAction, error)
+}
+
+type DeploymentApprovalRepositoryImpl struct {
+	*sql.TransactionUtilImpl
+	dbConnection *pg.DB
+}
+
+func NewDeploymentApprovalRepositoryImpl(dbConnection *pg.DB, TransactionUtilImpl *sql.TransactionUtilImpl) *DeploymentApprovalRepositoryImpl {
+	return &DeploymentApprovalRepositoryImpl{
+		dbConnection:        dbConnection,
+		TransactionUtilImpl: TransactionUtilImpl,
+	}
+}
+
+func (impl DeploymentApprovalRepositoryImpl) SavePolicy(policy *DeploymentApprovalPolicy) error {
+	return impl.dbConnection.Insert(policy)
+}
+
+func (impl DeploymentApprovalRepositoryImpl) UpdatePolicy(policy *DeploymentApprovalPolicy) error {
+	return impl.dbConnection.Update(policy)
+}
+
+func (impl DeploymentApprovalRepositoryImpl) FindPolicyById(id int) (*DeploymentApprovalPolicy, error) {
+	policy := &DeploymentApprovalPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl DeploymentApprovalRepositoryImpl) FindAllActivePolicies() ([]*DeploymentApprovalPolicy, error) {
+	policies := make([]*DeploymentApprovalPolicy, 0)
+	err := impl.dbConnection.Model(&policies).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return policies, err
+}
+
+func (impl DeploymentApprovalRepositoryImpl) FindActivePoliciesByPipelineOrEnv(pipelineId, envId int) ([]*DeploymentApprovalPolicy, error) {
+	policies := make([]*DeploymentApprovalPolicy, 0)
+	err := impl.dbConnection.Model(&policies).
+		Where("active = ?", true).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereOr("pipeline_id = ?", pipelineId).
+				WhereOr("env_id = ? AND pipeline_id = 0", envId)
+			return q, nil
+		}).
+		Select()
+	return policies, err
+}
+
+func (impl DeploymentApprovalRepositoryImpl) SaveRequest(tx *pg.Tx, request *DeploymentApprovalRequest) error {
+	return tx.Insert(request)
+}
+
+func (impl DeploymentApprovalRepositoryImpl) UpdateRequestInStatus(tx *pg.Tx, request *DeploymentApprovalRequest, status string) (bool, error) {
+	result, err := tx.Model(request).
+		WherePK().
+		Where("status = ?", status).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (impl DeploymentApprovalRepositoryImpl) FindRequestById(id int) (*DeploymentApprovalRequest, error) {
+	request := &DeploymentApprovalRequest{}
+	err := impl.dbConnection.Model(request).
+		Where("id = ?", id).
+		Select()
+	return request, err
+}
+
+func (impl DeploymentApprovalRepositoryImpl) FindLatestRequests(pipelineId int, artifactIds []int) ([]*DeploymentApprovalRequest, error) {
+	requests := make([]*DeploymentApprovalRequest, 0)
+	if len(artifactIds) == 0 {
+		return requests, nil
+	}
+	query := "SELECT DISTINCT ON (artifact_id) * FROM deployment_approval_request " +
+		"WHERE pipeline_id = ? AND artifact_id IN (?) ORDER BY artifact_id, id DESC;"
+	_, err := impl.dbConnection.Query(&requests, query, pipelineId, pg.In(artifactIds))
+	return requests, err
+}
+
+func (impl DeploymentApprovalRepositoryImpl) SaveUserAction(tx *pg.Tx, action *DeploymentApprovalUserAction) error {
+	return tx.Insert(action)
+}
+
+func (impl DeploymentApprovalRepositoryImpl) FindUserActionsByRequestIds(requestIds []int) ([]*DeploymentApprovalUserAction, error) {
+	actions := make([]*DeploymentApprovalUserAction, 0)
+	if len(requestIds) == 0 {
+		return actions, nil
+	}
+	err := impl.dbConnection.Model(&actions).
+		Where("approval_request_id IN (?)", pg.In(requestIds)).
+		Order("id ASC").
+		Select()
+	return actions, err
+}
@@ -0,0 +1,374 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentWindow
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/util"
+	repository2 "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/deploymentWindow/repository"
+	"github.com/devtron-labs/devtron/pkg/devtronResource/bean"
+	"github.com/devtron-labs/devtron/pkg/devtronResource/read"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type DeploymentWindowService interface {
+	CreateWindow(window *DeploymentWindowDto) (*DeploymentWindowDto, error)
+	UpdateWindow(window *DeploymentWindowDto) (*DeploymentWindowDto, error)
+	DeleteWindow(id int, userId int32) error
+	GetWindow(id int) (*DeploymentWindowDto, error)
+	GetAllWindows() ([]*DeploymentWindowDto, error)
+
+	// GetDeploymentWindowState evaluates all windows applicable on the app and env at the given instant
+	GetDeploymentWindowState(appId, envId int, at time.Time) (*DeploymentWindowState, error)
+	// EnforceDeploymentWindow returns an error if a deployment is not allowed at request.TriggeredAt.
+	// A blocked deployment is let through only for super admins with an override reason, which is audited.
+	EnforceDeploymentWindow(request *DeploymentWindowCheckRequest) error
+	GetOverrideAudits(pipelineId int) ([]*OverrideAuditDto, error)
+}
+
+type DeploymentWindowServiceImpl struct {
+	logger                              *zap.SugaredLogger
+	deploymentWindowRepository          repository.DeploymentWindowRepository
+	qualifierMappingService             resourceQualifiers.QualifierMappingService
+	devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService
+	environmentRepository               repository2.EnvironmentRepository
+}
+
+func NewDeploymentWindowServiceImpl(logger *zap.SugaredLogger,
+	deploymentWindowRepository repository.DeploymentWindowRepository,
+	qualifierMappingService resourceQualifiers.QualifierMappingService,
+	devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService,
+	environmentRepository repository2.EnvironmentRepository) *DeploymentWindowServiceImpl {
+	return &DeploymentWindowServiceImpl{
+		logger:                              logger,
+		deploymentWindowRepository:          deploymentWindowRepository,
+		qualifierMappingService:             qualifierMappingService,
+		devtronResourceSearchableKeyService: devtronResourceSearchableKeyService,
+		environmentRepository:               environmentRepository,
+	}
+}
+
+func (impl *DeploymentWindowServiceImpl) CreateWindow(window *DeploymentWindowDto) (*DeploymentWindowDto, error) {
+	err := validateWindow(window)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	tx, err := impl.deploymentWindowRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.deploymentWindowRepository.RollbackTx(tx)
+	dbObject := toDeploymentWindowDbObject(window)
+	err = impl.deploymentWindowRepository.Save(tx, dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in saving deployment window", "name", window.Name, "err", err)
+		return nil, err
+	}
+	err = impl.createScopeMappings(tx, dbObject.Id, window.Scope, window.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in saving deployment window scope", "windowId", dbObject.Id, "err", err)
+		return nil, err
+	}
+	err = impl.deploymentWindowRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	window.Id = dbObject.Id
+	return window, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) UpdateWindow(window *DeploymentWindowDto) (*DeploymentWindowDto, error) {
+	err := validateWindow(window)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	existing, err := impl.deploymentWindowRepository.FindById(window.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment window", "id", window.Id, "err", err)
+		return nil, err
+	}
+	tx, err := impl.deploymentWindowRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.deploymentWindowRepository.RollbackTx(tx)
+	dbObject := toDeploymentWindowDbObject(window)
+	dbObject.CreatedOn = existing.CreatedOn
+	dbObject.CreatedBy = existing.CreatedBy
+	err = impl.deploymentWindowRepository.Update(tx, dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in updating deployment window", "id", window.Id, "err", err)
+		return nil, err
+	}
+	err = impl.deleteScopeMappings(tx, window.Id, window.UserId)
+	if err != nil {
+		return nil, err
+	}
+	err = impl.createScopeMappings(tx, window.Id, window.Scope, window.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in saving deployment window scope", "windowId", window.Id, "err", err)
+		return nil, err
+	}
+	err = impl.deploymentWindowRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	return window, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) DeleteWindow(id int, userId int32) error {
+	window, err := impl.deploymentWindowRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment window", "id", id, "err", err)
+		return err
+	}
+	tx, err := impl.deploymentWindowRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.deploymentWindowRepository.RollbackTx(tx)
+	window.Active = false
+	window.UpdateAuditLog(userId)
+	err = impl.deploymentWindowRepository.Update(tx, window)
+	if err != nil {
+		impl.logger.Errorw("error in deleting deployment window", "id", id, "err", err)
+		return err
+	}
+	err = impl.deleteScopeMappings(tx, id, userId)
+	if err != nil {
+		return err
+	}
+	return impl.deploymentWindowRepository.CommitTx(tx)
+}
+
+func (impl *DeploymentWindowServiceImpl) GetWindow(id int) (*DeploymentWindowDto, error) {
+	window, err := impl.deploymentWindowRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment window", "id", id, "err", err)
+		return nil, err
+	}
+	windows, err := impl.toDtosWithScope([]*repository.DeploymentWindow{window})
+	if err != nil {
+		return nil, err
+	}
+	return windows[0], nil
+}
+
+func (impl *DeploymentWindowServiceImpl) GetAllWindows() ([]*DeploymentWindowDto, error) {
+	windows, err := impl.deploymentWindowRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment windows", "err", err)
+		return nil, err
+	}
+	return impl.toDtosWithScope(windows)
+}
+
+func (impl *DeploymentWindowServiceImpl) GetDeploymentWindowState(appId, envId int, at time.Time) (*DeploymentWindowState, error) {
+	env, err := impl.environmentRepository.FindById(envId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", envId, "err", err)
+		return nil, err
+	}
+	return impl.getStateForScope(TargetScope{AppId: appId, EnvId: envId, ClusterId: env.ClusterId}, at)
+}
+
+func (impl *DeploymentWindowServiceImpl) EnforceDeploymentWindow(request *DeploymentWindowCheckRequest) error {
+	if request.ClusterId == 0 {
+		env, err := impl.environmentRepository.FindById(request.EnvId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching environment", "envId", request.EnvId, "err", err)
+			return err
+		}
+		request.ClusterId = env.ClusterId
+	}
+	state, err := impl.getStateForScope(request.TargetScope, request.TriggeredAt)
+	if err != nil {
+		impl.logger.Errorw("error in evaluating deployment windows", "scope", request.TargetScope, "err", err)
+		return err
+	}
+	if state.IsDeploymentAllowed {
+		return nil
+	}
+	message := getBlockedDeploymentMessage(state)
+	if len(strings.TrimSpace(request.OverrideReason)) == 0 {
+		return util.NewApiError().WithHttpStatusCode(http.StatusUnprocessableEntity).WithUserMessage(message).WithInternalMessage(message)
+	}
+	if !request.IsOverrideAllowed {
+		return util.NewApiError().WithHttpStatusCode(http.StatusForbidden).WithUserMessage(OverrideNotAllowed).WithInternalMessage(OverrideNotAllowed)
+	}
+	if len(request.OverrideReason) > OverrideReasonLimit {
+		errMsg := fmt.Sprintf("override reason can not be longer than %d characters", OverrideReasonLimit)
+		return util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	err = impl.deploymentWindowRepository.SaveOverrideAudit(toOverrideAuditDbObject(request, state))
+	if err != nil {
+		impl.logger.Errorw("error in saving deployment window override audit", "pipelineId", request.PipelineId, "err", err)
+		return err
+	}
+	impl.logger.Infow("deployment window overridden", "pipelineId", request.PipelineId, "userId", request.TriggeredBy, "reason", request.OverrideReason)
+	return nil
+}
+
+func (impl *DeploymentWindowServiceImpl) GetOverrideAudits(pipelineId int) ([]*OverrideAuditDto, error) {
+	audits, err := impl.deploymentWindowRepository.FindOverrideAuditsByPipelineId(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment window override audits", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	result := make([]*OverrideAuditDto, 0, len(audits))
+	for _, audit := range audits {
+		result = append(result, toOverrideAuditDto(audit))
+	}
+	return result, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) getStateForScope(scope TargetScope, at time.Time) (*DeploymentWindowState, error) {
+	windows, err := impl.GetAllWindows()
+	if err != nil {
+		return nil, err
+	}
+	applicableWindows := make([]*DeploymentWindowDto, 0)
+	for _, window := range windows {
+		if isApplicable(window.Scope, scope) {
+			applicableWindows = append(applicableWindows, window)
+		}
+	}
+	return evaluateWindows(applicableWindows, at)
+}
+
+// isApplicable checks if the window applies on the target, every non-empty scope dimension must match
+// so that a window for app X on prod does not apply on other apps on prod
+func isApplicable(windowScope *WindowScope, scope TargetScope) bool {
+	if windowScope.IsEmpty() {
+		return false
+	}
+	if windowScope.Global {
+		return true
+	}
+	return matchesScopeDimension(windowScope.ClusterIds, scope.ClusterId) &&
+		matchesScopeDimension(windowScope.EnvIds, scope.EnvId) &&
+		matchesScopeDimension(windowScope.AppIds, scope.AppId)
+}
+
+// matchesScopeDimension treats an empty dimension as unrestricted
+func matchesScopeDimension(ids []int, id int) bool {
+	return len(ids) == 0 || containsId(ids, id)
+}
+
+func containsId(ids []int, id int) bool {
+	for _, item := range ids {
+		if item == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (impl *DeploymentWindowServiceImpl) toDtosWithScope(windows []*repository.DeploymentWindow) ([]*DeploymentWindowDto, error) {
+	windowIds := make([]int, 0, len(windows))
+	dtoMap := make(map[int]*DeploymentWindowDto, len(windows))
+	result := make([]*DeploymentWindowDto, 0, len(windows))
+	for _, window := range windows {
+		dto := toDeploymentWindowDto(window)
+		windowIds = append(windowIds, window.Id)
+		dtoMap[window.Id] = dto
+		result = append(result, dto)
+	}
+	if len(windowIds) == 0 {
+		return result, nil
+	}
+	mappings, err := impl.qualifierMappingService.GetQualifierMappings(resourceQualifiers.DeploymentWindow, nil, windowIds)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching deployment window scope", "windowIds", windowIds, "err", err)
+		return nil, err
+	}
+	searchableKeyIdNameMap := impl.devtronResourceSearchableKeyService.GetAllSearchableKeyIdNameMap()
+	for _, mapping := range mappings {
+		dto, ok := dtoMap[mapping.ResourceId]
+		if !ok {
+			continue
+		}
+		if mapping.QualifierId == int(resourceQualifiers.GLOBAL_QUALIFIER) {
+			dto.Scope.Global = true
+			continue
+		}
+		switch searchableKeyIdNameMap[mapping.IdentifierKey] {
+		case bean.DEVTRON_RESOURCE_SEARCHABLE_KEY_CLUSTER_ID:
+			dto.Scope.ClusterIds = append(dto.Scope.ClusterIds, mapping.IdentifierValueInt)
+		case bean.DEVTRON_RESOURCE_SEARCHABLE_KEY_ENV_ID:
+			dto.Scope.EnvIds = append(dto.Scope.EnvIds, mapping.IdentifierValueInt)
+		case bean.DEVTRON_RESOURCE_SEARCHABLE_KEY_APP_ID:
+			dto.Scope.AppIds = append(dto.Scope.AppIds, mapping.IdentifierValueInt)
+		}
+	}
+	return result, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) createScopeMappings(tx *pg.Tx, windowId int, scope *WindowScope, userId int32) error {
+	resourceIds := []int{windowId}
+	if scope.Global {
+		return impl.qualifierMappingService.CreateMappings(tx, userId, resourceQualifiers.DeploymentWindow, resourceIds, resourceQualifiers.GlobalSelector, []*resourceQualifiers.SelectionIdentifier{{}})
+	}
+	selections := map[resourceQualifiers.QualifierSelector][]*resourceQualifiers.SelectionIdentifier{}
+	for _, clusterId := range scope.ClusterIds {
+		selections[resourceQualifiers.ClusterSelector] = append(selections[resourceQualifiers.ClusterSelector], &resourceQualifiers.SelectionIdentifier{ClusterId: clusterId})
+	}
+	for _, envId := range scope.EnvIds {
+		selections[resourceQualifiers.EnvironmentSelector] = append(selections[resourceQualifiers.EnvironmentSelector], &resourceQualifiers.SelectionIdentifier{EnvId: envId})
+	}
+	for _, appId := range scope.AppIds {
+		selections[resourceQualifiers.ApplicationSelector] = append(selections[resourceQualifiers.ApplicationSelector], &resourceQualifiers.SelectionIdentifier{AppId: appId})
+	}
+	for selector, identifiers := range selections {
+		err := impl.qualifierMappingService.CreateMappings(tx, userId, resourceQualifiers.DeploymentWindow, resourceIds, selector, identifiers)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *DeploymentWindowServiceImpl) deleteScopeMappings(tx *pg.Tx, windowId int, userId int32) error {
+	mappings, err := impl.qualifierMappingService.GetQualifierMappings(resourceQualifiers.DeploymentWindow, nil, []int{windowId})
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching deployment window scope", "windowId", windowId, "err", err)
+		return err
+	}
+	if len(mappings) == 0 {
+		return nil
+	}
+	mappingIds := make([]int, 0, len(mappings))
+	for _, mapping := range mappings {
+		mappingIds = append(mappingIds, mapping.Id)
+	}
+	err = impl.qualifierMappingService.DeleteAllByIds(mappingIds, userId, tx)
+	if err != nil {
+		impl.logger.Errorw("error in deleting deployment window scope", "windowId", windowId, "err", err)
+	}
+	return err
+}
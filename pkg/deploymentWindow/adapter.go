@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentWindow
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deploymentWindow/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"strconv"
+	"strings"
+)
+
+func toDeploymentWindowDbObject(dto *DeploymentWindowDto) *repository.DeploymentWindow {
+	timeZone := dto.TimeZone
+	if len(timeZone) == 0 {
+		timeZone = DefaultTimeZone
+	}
+	weekDays := make([]string, 0, len(dto.WeekDays))
+	for _, day := range dto.WeekDays {
+		weekDays = append(weekDays, strings.ToUpper(day))
+	}
+	return &repository.DeploymentWindow{
+		Id:          dto.Id,
+		Name:        dto.Name,
+		Description: dto.Description,
+		WindowType:  string(dto.Type),
+		Frequency:   string(dto.Frequency),
+		TimeZone:    timeZone,
+		StartDate:   dto.StartDate,
+		EndDate:     dto.EndDate,
+		WeekDays:    strings.Join(weekDays, WeekDaysSeparator),
+		StartTime:   dto.StartTime,
+		EndTime:     dto.EndTime,
+		Active:      true,
+		AuditLog:    sql.NewDefaultAuditLog(dto.UserId),
+	}
+}
+
+func toDeploymentWindowDto(window *repository.DeploymentWindow) *DeploymentWindowDto {
+	weekDays := make([]string, 0)
+	if len(window.WeekDays) > 0 {
+		weekDays = strings.Split(window.WeekDays, WeekDaysSeparator)
+	}
+	return &DeploymentWindowDto{
+		Id:          window.Id,
+		Name:        window.Name,
+		Description: window.Description,
+		Type:        WindowType(window.WindowType),
+		Frequency:   Frequency(window.Frequency),
+		TimeZone:    window.TimeZone,
+		StartDate:   window.StartDate,
+		EndDate:     window.EndDate,
+		WeekDays:    weekDays,
+		StartTime:   window.StartTime,
+		EndTime:     window.EndTime,
+		Scope:       &WindowScope{},
+	}
+}
+
+func toOverrideAuditDbObject(request *DeploymentWindowCheckRequest, state *DeploymentWindowState) *repository.DeploymentWindowOverrideAudit {
+	windowIds := make([]string, 0, len(state.BlockingWindows))
+	for _, window := range state.BlockingWindows {
+		windowIds = append(windowIds, strconv.Itoa(window.Id))
+	}
+	return &repository.DeploymentWindowOverrideAudit{
+		PipelineId:         request.PipelineId,
+		CdWorkflowRunnerId: request.CdWorkflowRunnerId,
+		WindowIds:          strings.Join(windowIds, WindowIdsSeparator),
+		Reason:             request.OverrideReason,
+		AuditLog: sql.AuditLog{
+			CreatedOn: request.TriggeredAt,
+			CreatedBy: request.TriggeredBy,
+			UpdatedOn: request.TriggeredAt,
+			UpdatedBy: request.TriggeredBy,
+		},
+	}
+}
+
+func toOverrideAuditDto(audit *repository.DeploymentWindowOverrideAudit) *OverrideAuditDto {
+	windowIds := make([]int, 0)
+	for _, id := range strings.Split(audit.WindowIds, WindowIdsSeparator) {
+		if windowId, err := strconv.Atoi(id); err == nil {
+			windowIds = append(windowIds, windowId)
+		}
+	}
+	return &OverrideAuditDto{
+		Id:                 audit.Id,
+		PipelineId:         audit.PipelineId,
+		CdWorkflowRunnerId: audit.CdWorkflowRunnerId,
+		WindowIds:          windowIds,
+		Reason:             audit.Reason,
+		OverriddenBy:       audit.CreatedBy,
+		OverriddenOn:       audit.CreatedOn,
+	}
+}
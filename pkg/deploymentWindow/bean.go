@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentWindow
+
+import (
+	"time"
+)
+
+type WindowType string
+
+const (
+	// Maintenance windows allow deployments only while one of them is open
+	Maintenance WindowType = "MAINTENANCE"
+	// Blackout windows block deployments while they are open
+	Blackout WindowType = "BLACKOUT"
+)
+
+type Frequency string
+
+const (
+	// Fixed windows are open once, between StartDate and EndDate
+	Fixed Frequency = "FIXED"
+	// Weekly windows are open on the given WeekDays between StartTime and EndTime
+	Weekly Frequency = "WEEKLY"
+)
+
+const (
+	DefaultTimeZone     = "UTC"
+	ClockLayout         = "15:04"
+	WeekDaysSeparator   = ","
+	WindowIdsSeparator  = ","
+	InvalidWindowId     = "invalid deployment window id"
+	OverrideNotAllowed  = "only super admins can override a deployment window"
+	DeploymentBlocked   = "deployment blocked by deployment window"
+	OverrideReasonLimit = 500
+)
+
+var weekDayNames = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+type DeploymentWindowDto struct {
+	Id          int        `json:"id"`
+	Name        string     `json:"name" validate:"required,max=100"`
+	Description string     `json:"description" validate:"max=350"`
+	Type        WindowType `json:"type" validate:"oneof=MAINTENANCE BLACKOUT"`
+	Frequency   Frequency  `json:"frequency" validate:"oneof=FIXED WEEKLY"`
+	// TimeZone is an IANA zone name like Asia/Kolkata, defaults to UTC
+	TimeZone string `json:"timeZone"`
+	// StartDate and EndDate are used for FIXED windows
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	// WeekDays, StartTime and EndTime (HH:MM) are used for WEEKLY windows
+	WeekDays  []string     `json:"weekDays"`
+	StartTime string       `json:"startTime"`
+	EndTime   string       `json:"endTime"`
+	Scope     *WindowScope `json:"scope" validate:"required"`
+	UserId    int32        `json:"-"`
+}
+
+// WindowScope selects where a window applies, mapped through resource qualifiers
+type WindowScope struct {
+	Global     bool  `json:"global"`
+	ClusterIds []int `json:"clusterIds"`
+	EnvIds     []int `json:"envIds"`
+	AppIds     []int `json:"appIds"`
+}
+
+func (scope *WindowScope) IsEmpty() bool {
+	return scope == nil || (!scope.Global && len(scope.ClusterIds) == 0 && len(scope.EnvIds) == 0 && len(scope.AppIds) == 0)
+}
+
+type TargetScope struct {
+	AppId     int `json:"appId"`
+	EnvId     int `json:"envId"`
+	ClusterId int `json:"clusterId"`
+}
+
+type DeploymentWindowCheckRequest struct {
+	TargetScope
+	PipelineId         int
+	CdWorkflowRunnerId int
+	TriggeredBy        int32
+	TriggeredAt        time.Time
+	// OverrideReason is mandatory to deploy while blocked and is audited
+	OverrideReason    string
+	IsOverrideAllowed bool
+}
+
+type DeploymentWindowState struct {
+	IsDeploymentAllowed bool                   `json:"isDeploymentAllowed"`
+	BlockingWindows     []*DeploymentWindowDto `json:"blockingWindows"`
+	// MaintenanceWindows are listed when deployments are only allowed inside them
+	MaintenanceWindows []*DeploymentWindowDto `json:"maintenanceWindows,omitempty"`
+	EvaluatedAt        time.Time              `json:"evaluatedAt"`
+}
+
+type OverrideAuditDto struct {
+	Id                 int       `json:"id"`
+	PipelineId         int       `json:"pipelineId"`
+	CdWorkflowRunnerId int       `json:"cdWorkflowRunnerId"`
+	WindowIds          []int     `json:"windowIds"`
+	Reason             string    `json:"reason"`
+	OverriddenBy       int32     `json:"overriddenBy"`
+	OverriddenOn       time.Time `json:"overriddenOn"`
+}
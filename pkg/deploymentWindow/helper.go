@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentWindow
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func validateWindow(window *DeploymentWindowDto) error {
+	if window.Scope.IsEmpty() {
+		return fmt.Errorf("deployment window %q must be scoped to at least one cluster, environment or application", window.Name)
+	}
+	if _, err := getLocation(window.TimeZone); err != nil {
+		return fmt.Errorf("invalid time zone %q", window.TimeZone)
+	}
+	switch window.Frequency {
+	case Fixed:
+		if window.StartDate.IsZero() || window.EndDate.IsZero() {
+			return fmt.Errorf("startDate and endDate are required for %s windows", Fixed)
+		}
+		if !window.EndDate.After(window.StartDate) {
+			return fmt.Errorf("endDate must be after startDate")
+		}
+	case Weekly:
+		if len(window.WeekDays) == 0 {
+			return fmt.Errorf("weekDays are required for %s windows", Weekly)
+		}
+		for _, day := range window.WeekDays {
+			if _, ok := weekDayNames[strings.ToUpper(day)]; !ok {
+				return fmt.Errorf("invalid week day %q, expected one of SUN MON TUE WED THU FRI SAT", day)
+			}
+		}
+		if _, err := parseClock(window.StartTime); err != nil {
+			return fmt.Errorf("invalid startTime %q, expected HH:MM", window.StartTime)
+		}
+		if _, err := parseClock(window.EndTime); err != nil {
+			return fmt.Errorf("invalid endTime %q, expected HH:MM", window.EndTime)
+		}
+	default:
+		return fmt.Errorf("invalid frequency %q", window.Frequency)
+	}
+	return nil
+}
+
+func getLocation(timeZone string) (*time.Location, error) {
+	if len(timeZone) == 0 {
+		timeZone = DefaultTimeZone
+	}
+	return time.LoadLocation(timeZone)
+}
+
+// parseClock returns minutes since midnight for a HH:MM string
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse(ClockLayout, clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// isWindowOpen reports whether the window is open at the given instant.
+// Weekly windows whose end time is before the start time span midnight and
+// close on the day after the configured week day.
+func isWindowOpen(window *DeploymentWindowDto, at time.Time) (bool, error) {
+	if window.Frequency == Fixed {
+		return !at.Before(window.StartDate) && at.Before(window.EndDate), nil
+	}
+	location, err := getLocation(window.TimeZone)
+	if err != nil {
+		return false, err
+	}
+	start, err := parseClock(window.StartTime)
+	if err != nil {
+		return false, err
+	}
+	end, err := parseClock(window.EndTime)
+	if err != nil {
+		return false, err
+	}
+	localTime := at.In(location)
+	minute := localTime.Hour()*60 + localTime.Minute()
+	today := localTime.Weekday()
+	yesterday := (today + 6) % 7
+	days := make(map[time.Weekday]bool, len(window.WeekDays))
+	for _, day := range window.WeekDays {
+		days[weekDayNames[strings.ToUpper(day)]] = true
+	}
+	switch {
+	case start < end:
+		return days[today] && minute >= start && minute < end, nil
+	case start == end:
+		// same start and end time keeps the window open for the whole day
+		return days[today], nil
+	default:
+		return (days[today] && minute >= start) || (days[yesterday] && minute < end), nil
+	}
+}
+
+// evaluateWindows decides whether deploying at the given instant is allowed.
+// Any open blackout window blocks the deployment; if maintenance windows
+// apply, at least one of them has to be open.
+func evaluateWindows(windows []*DeploymentWindowDto, at time.Time) (*DeploymentWindowState, error) {
+	state := &DeploymentWindowState{
+		BlockingWindows: make([]*DeploymentWindowDto, 0),
+		EvaluatedAt:     at,
+	}
+	isAnyMaintenanceWindowOpen := false
+	for _, window := range windows {
+		open, err := isWindowOpen(window, at)
+		if err != nil {
+			return nil, err
+		}
+		switch window.Type {
+		case Blackout:
+			if open {
+				state.BlockingWindows = append(state.BlockingWindows, window)
+			}
+		case Maintenance:
+			state.MaintenanceWindows = append(state.MaintenanceWindows, window)
+			if open {
+				isAnyMaintenanceWindowOpen = true
+			}
+		}
+	}
+	if len(state.MaintenanceWindows) > 0 && !isAnyMaintenanceWindowOpen {
+		state.BlockingWindows = append(state.BlockingWindows, state.MaintenanceWindows...)
+	}
+	state.IsDeploymentAllowed = len(state.BlockingWindows) == 0
+	return state, nil
+}
+
+func getBlockedDeploymentMessage(state *DeploymentWindowState) string {
+	names := make([]string, 0, len(state.BlockingWindows))
+	for _, window := range state.BlockingWindows {
+		if window.Type == Blackout {
+			names = append(names, fmt.Sprintf("blackout %q", window.Name))
+		} else {
+			names = append(names, fmt.Sprintf("outside maintenance window %q", window.Name))
+		}
+	}
+	return fmt.Sprintf("%s: %s", DeploymentBlocked, strings.Join(names, ", "))
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentWindow
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIsWindowOpen(t *testing.T) {
+	businessHours := &DeploymentWindowDto{
+		Type:      Maintenance,
+		Frequency: Weekly,
+		TimeZone:  "UTC",
+		WeekDays:  []string{"TUE", "WED", "THU"},
+		StartTime: "10:00",
+		EndTime:   "16:00",
+	}
+	overnight := &DeploymentWindowDto{
+		Type:      Blackout,
+		Frequency: Weekly,
+		WeekDays:  []string{"FRI"},
+		StartTime: "22:00",
+		EndTime:   "02:00",
+	}
+	freeze := &DeploymentWindowDto{
+		Type:      Blackout,
+		Frequency: Fixed,
+		StartDate: time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name   string
+		window *DeploymentWindowDto
+		at     time.Time
+		want   bool
+	}{
+		{"inside business hours", businessHours, time.Date(2024, 10, 15, 11, 0, 0, 0, time.UTC), true},
+		{"business hours end is exclusive", businessHours, time.Date(2024, 10, 15, 16, 0, 0, 0, time.UTC), false},
+		{"outside business days", businessHours, time.Date(2024, 10, 14, 11, 0, 0, 0, time.UTC), false},
+		{"overnight on configured day", overnight, time.Date(2024, 10, 18, 23, 0, 0, 0, time.UTC), true},
+		{"overnight spills into next day", overnight, time.Date(2024, 10, 19, 1, 0, 0, 0, time.UTC), true},
+		{"overnight closed after end", overnight, time.Date(2024, 10, 19, 3, 0, 0, 0, time.UTC), false},
+		{"inside freeze", freeze, time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), true},
+		{"after freeze", freeze, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, err := isWindowOpen(tt.window, tt.at)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, open)
+		})
+	}
+}
+
+func TestIsWindowOpenInTimeZone(t *testing.T) {
+	window := &DeploymentWindowDto{
+		Type:      Maintenance,
+		Frequency: Weekly,
+		TimeZone:  "Asia/Kolkata",
+		WeekDays:  []string{"TUE"},
+		StartTime: "10:00",
+		EndTime:   "16:00",
+	}
+	// 05:00 UTC is 10:30 IST
+	open, err := isWindowOpen(window, time.Date(2024, 10, 15, 5, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.True(t, open)
+}
+
+func TestEvaluateWindows(t *testing.T) {
+	at := time.Date(2024, 10, 15, 11, 0, 0, 0, time.UTC)
+	openMaintenance := &DeploymentWindowDto{Name: "open", Type: Maintenance, Frequency: Fixed, StartDate: at.Add(-time.Hour), EndDate: at.Add(time.Hour)}
+	closedMaintenance := &DeploymentWindowDto{Name: "closed", Type: Maintenance, Frequency: Fixed, StartDate: at.Add(time.Hour), EndDate: at.Add(2 * time.Hour)}
+	openBlackout := &DeploymentWindowDto{Name: "freeze", Type: Blackout, Frequency: Fixed, StartDate: at.Add(-time.Hour), EndDate: at.Add(time.Hour)}
+
+	state, err := evaluateWindows(nil, at)
+	assert.Nil(t, err)
+	assert.True(t, state.IsDeploymentAllowed)
+
+	state, err = evaluateWindows([]*DeploymentWindowDto{openMaintenance, closedMaintenance}, at)
+	assert.Nil(t, err)
+	assert.True(t, state.IsDeploymentAllowed)
+
+	state, err = evaluateWindows([]*DeploymentWindowDto{closedMaintenance}, at)
+	assert.Nil(t, err)
+	assert.False(t, state.IsDeploymentAllowed)
+	assert.Equal(t, []*DeploymentWindowDto{closedMaintenance}, state.BlockingWindows)
+
+	state, err = evaluateWindows([]*DeploymentWindowDto{openMaintenance, openBlackout}, at)
+	assert.Nil(t, err)
+	assert.False(t, state.IsDeploymentAllowed)
+	assert.Equal(t, []*DeploymentWindowDto{openBlackout}, state.BlockingWindows)
+}
+
+func TestIsApplicable(t *testing.T) {
+	target := TargetScope{AppId: 1, EnvId: 10, ClusterId: 100}
+	tests := []struct {
+		name  string
+		scope *WindowScope
+		want  bool
+	}{
+		{name: "global", scope: &WindowScope{Global: true}, want: true},
+		{name: "empty scope", scope: &WindowScope{}, want: false},
+		{name: "env only", scope: &WindowScope{EnvIds: []int{10}}, want: true},
+		{name: "other env", scope: &WindowScope{EnvIds: []int{11}}, want: false},
+		{name: "app on env", scope: &WindowScope{EnvIds: []int{10}, AppIds: []int{1}}, want: true},
+		{name: "other app on same env", scope: &WindowScope{EnvIds: []int{10}, AppIds: []int{2}}, want: false},
+		{name: "app on other env", scope: &WindowScope{EnvIds: []int{11}, AppIds: []int{1}}, want: false},
+		{name: "app on cluster", scope: &WindowScope{ClusterIds: []int{100}, AppIds: []int{1, 2}}, want: true},
+		{name: "env on other cluster", scope: &WindowScope{ClusterIds: []int{101}, EnvIds: []int{10}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isApplicable(tt.scope, target))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type DeploymentWindow struct {
+	tableName   struct{}  `sql:"deployment_window" pg:",discard_unknown_columns"`
+	Id          int       `sql:"id,pk"`
+	Name        string    `sql:"name,notnull"`
+	Description string    `sql:"description"`
+	WindowType  string    `sql:"window_type,notnull"`
+	Frequency   string    `sql:"frequency,notnull"`
+	TimeZone    string    `sql:"time_zone,notnull"`
+	StartDate   time.Time `sql:"start_date"`
+	EndDate     time.Time `sql:"end_date"`
+	WeekDays    string    `sql:"week_days"`
+	StartTime   string    `sql:"start_time"`
+	EndTime     string    `sql:"end_time"`
+	Active      bool      `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type DeploymentWindowOverrideAudit struct {
+	tableName          struct{} `sql:"deployment_window_override_audit" pg:",discard_unknown_columns"`
+	Id                 int      `sql:"id,pk"`
+	PipelineId         int      `sql:"pipeline_id,notnull"`
+	CdWorkflowRunnerId int      `sql:"cd_workflow_runner_id"`
+	WindowIds          string   `sql:"window_ids,notnull"`
+	Reason             string   `sql:"reason,notnull"`
+	sql.AuditLog
+}
+
+type DeploymentWindowRepository interface {
+	//transaction util funcs
+	sql.TransactionWrapper
+	Save(tx *pg.Tx, window *DeploymentWindow) error
+	Update(tx *pg.Tx, window *DeploymentWindow) error
+	FindById(id int) (*DeploymentWindow, error)
+	FindByIds(ids []int) ([]*DeploymentWindow, error)
+	FindAllActive() ([]*DeploymentWindow, error)
+	SaveOverrideAudit(audit *DeploymentWindowOverrideAudit) error
+	FindOverrideAuditsByPipelineId(pipelineId int) ([]*DeploymentWindowOverrideAudit, error)
+}
+
+type DeploymentWindowRepositoryImpl struct {
+	*sql.TransactionUtilImpl
+	dbConnection *pg.DB
+}
+
+func NewDeploymentWindowRepositoryImpl(dbConnection *pg.DB, TransactionUtilImpl *sql.TransactionUtilImpl) *DeploymentWindowRepositoryImpl {
+	return &DeploymentWindowRepositoryImpl{
+		dbConnection:        dbConnection,
+		TransactionUtilImpl: TransactionUtilImpl,
+	}
+}
+
+func (impl DeploymentWindowRepositoryImpl) Save(tx *pg.Tx, window *DeploymentWindow) error {
+	return tx.Insert(window)
+}
+
+func (impl DeploymentWindowRepositoryImpl) Update(tx *pg.Tx, window *DeploymentWindow) error {
+	return tx.Update(window)
+}
+
+func (impl DeploymentWindowRepositoryImpl) FindById(id int) (*DeploymentWindow, error) {
+	window := &DeploymentWindow{}
+	err := impl.dbConnection.Model(window).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return window, err
+}
+
+func (impl DeploymentWindowRepositoryImpl) FindByIds(ids []int) ([]*DeploymentWindow, error) {
+	windows := make([]*DeploymentWindow, 0)
+	if len(ids) == 0 {
+		return windows, nil
+	}
+	err := impl.dbConnection.Model(&windows).
+		Where("id IN (?)", pg.In(ids)).
+		Where("active = ?", true).
+		Select()
+	return windows, err
+}
+
+func (impl DeploymentWindowRepositoryImpl) FindAllActive() ([]*DeploymentWindow, error) {
+	windows := make([]*DeploymentWindow, 0)
+	err := impl.dbConnection.Model(&windows).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return windows, err
+}
+
+func (impl DeploymentWindowRepositoryImpl) SaveOverrideAudit(audit *DeploymentWindowOverrideAudit) error {
+	return impl.dbConnection.Insert(audit)
+}
+
+func (impl DeploymentWindowRepositoryImpl) FindOverrideAuditsByPipelineId(pipelineId int) ([]*DeploymentWindowOverrideAudit, error) {
+	audits := make([]*DeploymentWindowOverrideAudit, 0)
+	err := impl.dbConnection.Model(&audits).
+		Where("pipeline_id = ?", pipelineId).
+		Order("id DESC").
+		Select()
+	return audits, err
+}
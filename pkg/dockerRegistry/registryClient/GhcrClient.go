@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ghcrClient lists the container packages of the owner with the github api, ghcr does not serve the catalog
+type ghcrClient struct {
+	*distributionClient
+	apiUrl string
+}
+
+type githubPackage struct {
+	Name string `json:"name"`
+}
+
+func (impl *ghcrClient) ListRepositories(ctx context.Context) ([]string, error) {
+	owner := impl.namespace
+	// the owner may be an organisation or a user, the api has an endpoint for each
+	repositories, err := impl.listPackages(ctx, fmt.Sprintf("%s/orgs/%s/packages", impl.apiUrl, owner))
+	if err == errNotFound {
+		repositories, err = impl.listPackages(ctx, fmt.Sprintf("%s/users/%s/packages", impl.apiUrl, owner))
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(repositories)
+	return repositories, nil
+}
+
+var errNotFound = errors.New("not found")
+
+type githubPackageVersion struct {
+	Id       int `json:"id"`
+	Metadata struct {
+		Container struct {
+			Tags []string `json:"tags"`
+		} `json:"container"`
+	} `json:"metadata"`
+}
+
+// DeleteImage deletes the package version having the tag, ghcr does not allow deleting manifests
+func (impl *ghcrClient) DeleteImage(ctx context.Context, repositoryName string, tag string) error {
+	packageName := url.PathEscape(strings.TrimPrefix(impl.qualifyRepository(repositoryName), impl.namespace+"/"))
+	versionsUrl := fmt.Sprintf("%s/orgs/%s/packages/container/%s/versions", impl.apiUrl, impl.namespace, packageName)
+	versionId, err := impl.findPackageVersion(ctx, versionsUrl, tag)
+	if err == errNotFound {
+		versionsUrl = fmt.Sprintf("%s/users/%s/packages/container/%s/versions", impl.apiUrl, impl.namespace, packageName)
+		versionId, err = impl.findPackageVersion(ctx, versionsUrl, tag)
+	}
+	if err != nil {
+		return err
+	}
+	resp, err := impl.doGithubRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", versionsUrl, versionId))
+	if err != nil {
+		return err
+	}
+	if err = checkResponse(resp); err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (impl *ghcrClient) findPackageVersion(ctx context.Context, versionsUrl string, tag string) (int, error) {
+	for page := 1; ; page++ {
+		resp, err := impl.doGithubRequest(ctx, http.MethodGet, fmt.Sprintf("%s?page=%d&per_page=%d", versionsUrl, page, pageSize))
+		if err != nil {
+			return 0, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return 0, errNotFound
+		}
+		if err = checkResponse(resp); err != nil {
+			return 0, err
+		}
+		versions := make([]githubPackageVersion, 0)
+		err = json.NewDecoder(resp.Body).Decode(&versions)
+		resp.Body.Close()
+		if err != nil {
+			return 0, err
+		}
+		for _, version := range versions {
+			for _, versionTag := range version.Metadata.Container.Tags {
+				if versionTag == tag {
+					return version.Id, nil
+				}
+			}
+		}
+		if len(versions) < pageSize {
+			return 0, fmt.Errorf("tag %s not found in package versions", tag)
+		}
+	}
+}
+
+func (impl *ghcrClient) doGithubRequest(ctx context.Context, method string, requestUrl string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+impl.password)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	return impl.httpClient.Do(req)
+}
+
+func (impl *ghcrClient) listPackages(ctx context.Context, packagesUrl string) ([]string, error) {
+	repositories := make([]string, 0)
+	for page := 1; ; page++ {
+		pageUrl := fmt.Sprintf("%s?package_type=container&page=%d&per_page=%d", packagesUrl, page, pageSize)
+		resp, err := impl.doGithubRequest(ctx, http.MethodGet, pageUrl)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return nil, errNotFound
+		}
+		if err = checkResponse(resp); err != nil {
+			return nil, err
+		}
+		packages := make([]githubPackage, 0)
+		err = json.NewDecoder(resp.Body).Decode(&packages)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, githubPackage := range packages {
+			repositories = append(repositories, githubPackage.Name)
+		}
+		if len(packages) < pageSize {
+			break
+		}
+	}
+	return repositories, nil
+}
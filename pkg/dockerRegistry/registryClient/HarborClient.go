@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// harborClient lists repositories with the harbor api, robot accounts are not allowed to list the catalog
+type harborClient struct {
+	*distributionClient
+}
+
+type harborRepository struct {
+	Name string `json:"name"`
+}
+
+func (impl *harborClient) ListRepositories(ctx context.Context) ([]string, error) {
+	repositories := make([]string, 0)
+	for page := 1; ; page++ {
+		pageUrl := fmt.Sprintf("%s/api/v2.0/repositories?page=%d&page_size=%d", impl.baseUrl, page, pageSize)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageUrl, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(impl.username, impl.password)
+		resp, err := impl.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkResponse(resp); err != nil {
+			return nil, err
+		}
+		harborRepositories := make([]harborRepository, 0)
+		err = json.NewDecoder(resp.Body).Decode(&harborRepositories)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, harborRepository := range harborRepositories {
+			repositories = append(repositories, harborRepository.Name)
+		}
+		if len(harborRepositories) < pageSize {
+			break
+		}
+	}
+	return impl.filterNamespace(repositories), nil
+}
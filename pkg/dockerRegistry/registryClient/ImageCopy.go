@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CopyImageResponse has the image pushed to the target registry, its digest is the digest of the source image
+type CopyImageResponse struct {
+	Image  string
+	Digest string
+}
+
+// manifest has the references of an image manifest or of an image index, which are copied before the manifest
+type manifest struct {
+	MediaType    string       `json:"mediaType"`
+	ArtifactType string       `json:"artifactType,omitempty"`
+	Config       *descriptor  `json:"config,omitempty"`
+	Layers       []descriptor `json:"layers,omitempty"`
+	Manifests    []descriptor `json:"manifests,omitempty"`
+}
+
+type descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Urls         []string          `json:"urls,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// CopyImage copies the image with its blobs to the target registry through the distribution api, the manifests are
+// pushed unchanged so that the digest of the image stays the same. The image is pushed to targetRepository, or to the
+// repository of the source image relative to the registry url when empty, with the tag of the source image.
+func CopyImage(ctx context.Context, source *RegistryConfig, sourceImage string, target *RegistryConfig, targetRepository string) (*CopyImageResponse, error) {
+	sourceHttpClient, err := newHttpClient(source)
+	if err != nil {
+		return nil, err
+	}
+	targetHttpClient, err := newHttpClient(target)
+	if err != nil {
+		return nil, err
+	}
+	sourceClient := newDistributionClient(source, sourceHttpClient)
+	targetClient := newDistributionClient(target, targetHttpClient)
+	_, sourceRepository, tag, digest, err := splitImage(sourceImage)
+	if err != nil {
+		return nil, err
+	}
+	if len(tag) == 0 {
+		return nil, fmt.Errorf("image %s has no tag to push to the target registry", sourceImage)
+	}
+	targetRepository, err = GetTargetRepository(source, sourceImage, target, targetRepository)
+	if err != nil {
+		return nil, err
+	}
+	reference := tag
+	if len(digest) > 0 {
+		reference = digest
+	}
+	copier := &imageCopier{
+		source:           sourceClient,
+		target:           targetClient,
+		sourceRepository: sourceRepository,
+		targetRepository: targetRepository,
+	}
+	copiedDigest, err := copier.copyManifest(ctx, reference, tag)
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) > 0 && copiedDigest != digest {
+		return nil, fmt.Errorf("digest of the copied image %s does not match the source digest %s", copiedDigest, digest)
+	}
+	host, _ := splitRegistryUrl(target.RegistryURL)
+	return &CopyImageResponse{
+		Image:  fmt.Sprintf("%s/%s:%s", host, targetRepository, tag),
+		Digest: copiedDigest,
+	}, nil
+}
+
+// GetTargetRepository returns the repository CopyImage pushes the image to, including the namespace of the target
+// registry url
+func GetTargetRepository(source *RegistryConfig, sourceImage string, target *RegistryConfig, targetRepository string) (string, error) {
+	_, sourceRepository, _, _, err := splitImage(sourceImage)
+	if err != nil {
+		return "", err
+	}
+	if len(targetRepository) == 0 {
+		targetRepository = sourceRepository
+		if _, namespace := splitRegistryUrl(source.RegistryURL); namespace != "" {
+			targetRepository = strings.TrimPrefix(sourceRepository, namespace+"/")
+		}
+	}
+	_, targetNamespace := splitRegistryUrl(target.RegistryURL)
+	targetClient := &distributionClient{namespace: targetNamespace}
+	return targetClient.qualifyRepository(targetRepository), nil
+}
+
+// splitImage splits an image like host/repository:tag@digest, the tag or the digest can be missing
+func splitImage(image string) (host string, repositoryName string, tag string, digest string, err error) {
+	image, digest, _ = strings.Cut(image, "@")
+	host, path, ok := strings.Cut(image, "/")
+	if !ok || len(host) == 0 || len(path) == 0 {
+		return "", "", "", "", fmt.Errorf("invalid image %q, expected host/repository:tag", image)
+	}
+	repositoryName = path
+	if index := strings.LastIndex(path, ":"); index > strings.LastIndex(path, "/") {
+		repositoryName, tag = path[:index], path[index+1:]
+	}
+	return host, repositoryName, tag, digest, nil
+}
+
+type imageCopier struct {
+	source           *distributionClient
+	target           *distributionClient
+	sourceRepository string
+	targetRepository string
+}
+
+func (impl *imageCopier) sourceScope() string {
+	return fmt.Sprintf("repository:%s:pull", impl.sourceRepository)
+}
+
+func (impl *imageCopier) targetScope() string {
+	return fmt.Sprintf("repository:%s:pull,push", impl.targetRepository)
+}
+
+// copyManifest copies the blobs and the child manifests referred by the manifest before pushing it by targetReference,
+// and returns the digest of the manifest
+func (impl *imageCopier) copyManifest(ctx context.Context, reference string, targetReference string) (string, error) {
+	resp, err := impl.source.request(ctx, http.MethodGet, fmt.Sprintf("%s/v2/%s/manifests/%s", impl.source.baseUrl, impl.sourceRepository, reference),
+		impl.sourceScope(), map[string]string{"Accept": manifestMediaTypes})
+	if err != nil {
+		return "", err
+	}
+	content, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	mediaType := resp.Header.Get("Content-Type")
+	parsedManifest := &manifest{}
+	err = json.Unmarshal(content, parsedManifest)
+	if err != nil {
+		return "", fmt.Errorf("invalid manifest of %s:%s, %w", impl.sourceRepository, reference, err)
+	}
+	if len(parsedManifest.MediaType) > 0 {
+		mediaType = parsedManifest.MediaType
+	}
+	for _, child := range parsedManifest.Manifests {
+		_, err = impl.copyManifest(ctx, child.Digest, child.Digest)
+		if err != nil {
+			return "", err
+		}
+	}
+	blobs := parsedManifest.Layers
+	if parsedManifest.Config != nil {
+		blobs = append(blobs, *parsedManifest.Config)
+	}
+	for _, blob := range blobs {
+		// foreign layers are pulled from their urls, registries do not store them
+		if len(blob.Urls) > 0 {
+			continue
+		}
+		err = impl.copyBlob(ctx, blob)
+		if err != nil {
+			return "", err
+		}
+	}
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+	resp, err = impl.target.send(ctx, http.MethodPut, fmt.Sprintf("%s/v2/%s/manifests/%s", impl.target.baseUrl, impl.targetRepository, targetReference),
+		impl.targetScope(), map[string]string{"Content-Type": mediaType}, &requestBody{reader: bytes.NewReader(content), length: int64(len(content))})
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return digest, nil
+}
+
+// copyBlob streams the blob from the source registry with a monolithic upload, blobs already in the target are skipped
+func (impl *imageCopier) copyBlob(ctx context.Context, blob descriptor) error {
+	resp, err := impl.target.request(ctx, http.MethodHead, fmt.Sprintf("%s/v2/%s/blobs/%s", impl.target.baseUrl, impl.targetRepository, blob.Digest),
+		impl.targetScope(), nil)
+	if err == nil {
+		resp.Body.Close()
+		return nil
+	} else if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return err
+	}
+	resp, err = impl.target.request(ctx, http.MethodPost, fmt.Sprintf("%s/v2/%s/blobs/uploads/", impl.target.baseUrl, impl.targetRepository),
+		impl.targetScope(), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	uploadUrl, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return fmt.Errorf("registry did not return the upload location for %s", impl.targetRepository)
+	}
+	query := uploadUrl.Query()
+	query.Set("digest", blob.Digest)
+	uploadUrl.RawQuery = query.Encode()
+
+	blobResp, err := impl.source.request(ctx, http.MethodGet, fmt.Sprintf("%s/v2/%s/blobs/%s", impl.source.baseUrl, impl.sourceRepository, blob.Digest),
+		impl.sourceScope(), nil)
+	if err != nil {
+		return err
+	}
+	defer blobResp.Body.Close()
+	length := blobResp.ContentLength
+	if length < 0 {
+		length = blob.Size
+	}
+	resp, err = impl.target.send(ctx, http.MethodPut, uploadUrl.String(), impl.targetScope(),
+		map[string]string{"Content-Type": "application/octet-stream"}, &requestBody{reader: blobResp.Body, length: length})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// memoryRegistry serves the manifests and blobs of the distribution api from memory, behind basic auth
+type memoryRegistry struct {
+	lock      sync.Mutex
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	uploads   []string
+}
+
+func newMemoryRegistry() *memoryRegistry {
+	return &memoryRegistry{manifests: make(map[string][]byte), blobs: make(map[string][]byte)}
+}
+
+func digestOf(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+func (impl *memoryRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != "user" || password != "secret" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	switch {
+	case strings.Contains(path, "/manifests/"):
+		repositoryName, reference, _ := strings.Cut(path, "/manifests/")
+		key := repositoryName + "@" + reference
+		if r.Method == http.MethodPut {
+			content, _ := io.ReadAll(r.Body)
+			impl.manifests[key] = content
+			impl.manifests[repositoryName+"@"+digestOf(content)] = content
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		content, ok := impl.manifests[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		w.Write(content)
+	case strings.HasSuffix(path, "/blobs/uploads/") && r.Method == http.MethodPost:
+		w.Header().Set("Location", fmt.Sprintf("/v2/%supload-%d?state=1", path, len(impl.uploads)))
+		w.WriteHeader(http.StatusAccepted)
+	case strings.Contains(path, "/blobs/uploads/") && r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		digest := r.URL.Query().Get("digest")
+		if digest != digestOf(content) || r.URL.Query().Get("state") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		impl.blobs[digest] = content
+		impl.uploads = append(impl.uploads, digest)
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(path, "/blobs/"):
+		_, digest, _ := strings.Cut(path, "/blobs/")
+		content, ok := impl.blobs[digest]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		if r.Method == http.MethodGet {
+			w.Write(content)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestCopyImage(t *testing.T) {
+	config := []byte(`{"architecture":"amd64"}`)
+	sharedLayer := []byte("shared layer")
+	layer := []byte("application layer")
+	manifestContent := []byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json",`+
+		`"config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"%s","size":%d},`+
+		`"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"%s","size":%d},`+
+		`{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"%s","size":%d}]}`,
+		digestOf(config), len(config), digestOf(sharedLayer), len(sharedLayer), digestOf(layer), len(layer)))
+	manifestDigest := digestOf(manifestContent)
+
+	sourceRegistry := newMemoryRegistry()
+	sourceRegistry.manifests["staging/api@v1"] = manifestContent
+	sourceRegistry.manifests["staging/api@"+manifestDigest] = manifestContent
+	for _, blob := range [][]byte{config, sharedLayer, layer} {
+		sourceRegistry.blobs[digestOf(blob)] = blob
+	}
+	sourceServer := httptest.NewServer(sourceRegistry)
+	defer sourceServer.Close()
+	sourceConfig := &RegistryConfig{RegistryURL: sourceServer.URL + "/staging", Username: "user", Password: "secret"}
+	sourceHost := strings.TrimPrefix(sourceServer.URL, "http://")
+
+	t.Run("copy keeps the digest and skips blobs present in the target", func(t *testing.T) {
+		targetRegistry := newMemoryRegistry()
+		targetRegistry.blobs[digestOf(sharedLayer)] = sharedLayer
+		targetServer := httptest.NewServer(targetRegistry)
+		defer targetServer.Close()
+		targetConfig := &RegistryConfig{RegistryURL: targetServer.URL + "/prod", Username: "user", Password: "secret"}
+
+		response, err := CopyImage(context.Background(), sourceConfig, sourceHost+"/staging/api:v1@"+manifestDigest, targetConfig, "")
+		assert.Nil(t, err)
+		assert.Equal(t, manifestDigest, response.Digest)
+		assert.Equal(t, strings.TrimPrefix(targetServer.URL, "http://")+"/prod/api:v1", response.Image)
+		assert.Equal(t, manifestContent, targetRegistry.manifests["prod/api@v1"])
+		assert.ElementsMatch(t, []string{digestOf(config), digestOf(layer)}, targetRegistry.uploads)
+	})
+
+	t.Run("copy to a given repository", func(t *testing.T) {
+		targetRegistry := newMemoryRegistry()
+		targetServer := httptest.NewServer(targetRegistry)
+		defer targetServer.Close()
+		targetConfig := &RegistryConfig{RegistryURL: targetServer.URL, Username: "user", Password: "secret"}
+
+		response, err := CopyImage(context.Background(), sourceConfig, sourceHost+"/staging/api:v1", targetConfig, "release/api")
+		assert.Nil(t, err)
+		assert.Equal(t, manifestDigest, response.Digest)
+		assert.Equal(t, manifestContent, targetRegistry.manifests["release/api@v1"])
+		assert.Len(t, targetRegistry.uploads, 3)
+	})
+
+	t.Run("copy fails with invalid target credentials", func(t *testing.T) {
+		targetServer := httptest.NewServer(newMemoryRegistry())
+		defer targetServer.Close()
+		targetConfig := &RegistryConfig{RegistryURL: targetServer.URL, Username: "user", Password: "wrong"}
+
+		_, err := CopyImage(context.Background(), sourceConfig, sourceHost+"/staging/api:v1", targetConfig, "")
+		assert.Equal(t, ErrUnauthorized, err)
+	})
+
+	t.Run("image without tag", func(t *testing.T) {
+		_, err := CopyImage(context.Background(), sourceConfig, sourceHost+"/staging/api@"+manifestDigest, sourceConfig, "")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestSplitImage(t *testing.T) {
+	host, repositoryName, tag, digest, err := splitImage("registry.io:5000/team/api:v1@sha256:abc")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"registry.io:5000", "team/api", "v1", "sha256:abc"}, []string{host, repositoryName, tag, digest})
+
+	host, repositoryName, tag, digest, err = splitImage("registry.io:5000/team/api")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"registry.io:5000", "team/api", "", ""}, []string{host, repositoryName, tag, digest})
+
+	_, _, _, _, err = splitImage("api:v1")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	CosignSimpleSigningMediaType  = "application/vnd.dev.cosign.simplesigning.v1+json"
+	NotationSignatureArtifactType = "application/vnd.cncf.notary.signature"
+	NotationJwsMediaType          = "application/jose+json"
+
+	cosignSignatureAnnotation   = "dev.cosignproject.cosign/signature"
+	cosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	cosignChainAnnotation       = "dev.sigstore.cosign/chain"
+	cosignBundleAnnotation      = "dev.sigstore.cosign/bundle"
+
+	ociIndexMediaType = "application/vnd.oci.image.index.v1+json"
+
+	// maxSignatureSize limits what is read of the signature manifests and blobs
+	maxSignatureSize = 4 << 20
+)
+
+// CosignSignature is a layer of the signature image cosign pushes with the tag sha256-<digest>.sig, the signature is
+// over the payload. Keyless signatures have the signing certificate and the transparency log bundle
+type CosignSignature struct {
+	Payload     []byte
+	Signature   string
+	Certificate string
+	Chain       string
+	Bundle      string
+}
+
+// NotationSignature is the envelope of a notation signature artifact referring to the image
+type NotationSignature struct {
+	MediaType string
+	Envelope  []byte
+}
+
+type ImageSignatures struct {
+	Digest   string
+	Cosign   []*CosignSignature
+	Notation []*NotationSignature
+}
+
+// FetchImageSignatures fetches the cosign and notation signatures of the image, which is resolved to its digest when
+// it has none. Notation signatures are listed with the referrers api, or the referrers tag on registries without it.
+// The registry of the image is accessed anonymously when config is nil
+func FetchImageSignatures(ctx context.Context, config *RegistryConfig, image string) (*ImageSignatures, error) {
+	host, repositoryName, tag, digest, err := splitImage(image)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &RegistryConfig{RegistryURL: host}
+	}
+	httpClient, err := newHttpClient(config)
+	if err != nil {
+		return nil, err
+	}
+	fetcher := &signatureFetcher{client: newDistributionClient(config, httpClient), repositoryName: repositoryName}
+	if len(digest) == 0 {
+		if len(tag) == 0 {
+			tag = "latest"
+		}
+		digest, err = fetcher.resolveDigest(ctx, tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+	signatures := &ImageSignatures{Digest: digest}
+	signatures.Cosign, err = fetcher.fetchCosignSignatures(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	signatures.Notation, err = fetcher.fetchNotationSignatures(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	return signatures, nil
+}
+
+type signatureFetcher struct {
+	client         *distributionClient
+	repositoryName string
+}
+
+func (impl *signatureFetcher) scope() string {
+	return fmt.Sprintf("repository:%s:pull", impl.repositoryName)
+}
+
+// get returns errNotFound if the registry has nothing at the path
+func (impl *signatureFetcher) get(ctx context.Context, path string, accept string) ([]byte, error) {
+	headers := make(map[string]string)
+	if len(accept) > 0 {
+		headers["Accept"] = accept
+	}
+	resp, err := impl.client.request(ctx, http.MethodGet, fmt.Sprintf("%s/v2/%s/%s", impl.client.baseUrl, impl.repositoryName, path),
+		impl.scope(), headers)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, errNotFound
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(io.LimitReader(resp.Body, maxSignatureSize))
+}
+
+func (impl *signatureFetcher) getManifest(ctx context.Context, reference string) (*manifest, error) {
+	content, err := impl.get(ctx, "manifests/"+reference, manifestMediaTypes)
+	if err != nil {
+		return nil, err
+	}
+	parsedManifest := &manifest{}
+	err = json.Unmarshal(content, parsedManifest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest of %s:%s, %w", impl.repositoryName, reference, err)
+	}
+	return parsedManifest, nil
+}
+
+// getBlob verifies the content against the digest, signatures are verified over the content
+func (impl *signatureFetcher) getBlob(ctx context.Context, digest string) ([]byte, error) {
+	content, err := impl.get(ctx, "blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	if fmt.Sprintf("sha256:%x", sha256.Sum256(content)) != digest {
+		return nil, fmt.Errorf("content of blob %s does not match its digest", digest)
+	}
+	return content, nil
+}
+
+func (impl *signatureFetcher) resolveDigest(ctx context.Context, tag string) (string, error) {
+	content, err := impl.get(ctx, "manifests/"+tag, manifestMediaTypes)
+	if err != nil {
+		return "", fmt.Errorf("error in resolving digest of %s:%s, %w", impl.repositoryName, tag, err)
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content)), nil
+}
+
+func (impl *signatureFetcher) fetchCosignSignatures(ctx context.Context, digest string) ([]*CosignSignature, error) {
+	signatures := make([]*CosignSignature, 0)
+	signatureManifest, err := impl.getManifest(ctx, strings.Replace(digest, ":", "-", 1)+".sig")
+	if errors.Is(err, errNotFound) {
+		return signatures, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, layer := range signatureManifest.Layers {
+		if layer.MediaType != CosignSimpleSigningMediaType || len(layer.Annotations[cosignSignatureAnnotation]) == 0 {
+			continue
+		}
+		payload, err := impl.getBlob(ctx, layer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, &CosignSignature{
+			Payload:     payload,
+			Signature:   layer.Annotations[cosignSignatureAnnotation],
+			Certificate: layer.Annotations[cosignCertificateAnnotation],
+			Chain:       layer.Annotations[cosignChainAnnotation],
+			Bundle:      layer.Annotations[cosignBundleAnnotation],
+		})
+	}
+	return signatures, nil
+}
+
+func (impl *signatureFetcher) fetchNotationSignatures(ctx context.Context, digest string) ([]*NotationSignature, error) {
+	signatures := make([]*NotationSignature, 0)
+	referrers, err := impl.listReferrers(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	for _, referrer := range referrers {
+		if referrer.ArtifactType != NotationSignatureArtifactType {
+			continue
+		}
+		signatureManifest, err := impl.getManifest(ctx, referrer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if len(signatureManifest.Layers) == 0 {
+			continue
+		}
+		envelope, err := impl.getBlob(ctx, signatureManifest.Layers[0].Digest)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, &NotationSignature{MediaType: signatureManifest.Layers[0].MediaType, Envelope: envelope})
+	}
+	return signatures, nil
+}
+
+// listReferrers falls back to the referrers tag sha256-<digest> when the registry does not serve the referrers api
+func (impl *signatureFetcher) listReferrers(ctx context.Context, digest string) ([]descriptor, error) {
+	content, err := impl.get(ctx, fmt.Sprintf("referrers/%s?artifactType=%s", digest, url.QueryEscape(NotationSignatureArtifactType)), ociIndexMediaType)
+	if errors.Is(err, errNotFound) {
+		content, err = impl.get(ctx, "manifests/"+strings.Replace(digest, ":", "-", 1), ociIndexMediaType)
+		if errors.Is(err, errNotFound) {
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := &manifest{}
+	err = json.Unmarshal(content, index)
+	if err != nil {
+		return nil, fmt.Errorf("invalid referrers of %s@%s, %w", impl.repositoryName, digest, err)
+	}
+	return index.Manifests, nil
+}
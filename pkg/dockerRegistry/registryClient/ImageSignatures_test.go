@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchImageSignatures(t *testing.T) {
+	manifestContent := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","layers":[]}`)
+	manifestDigest := digestOf(manifestContent)
+	payload := []byte(fmt.Sprintf(`{"critical":{"image":{"docker-manifest-digest":"%s"}}}`, manifestDigest))
+	cosignManifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"layers":[{"mediaType":"%s","digest":"%s","size":%d,`+
+		`"annotations":{"dev.cosignproject.cosign/signature":"c2lnbmF0dXJl"}}]}`, CosignSimpleSigningMediaType, digestOf(payload), len(payload)))
+	envelope := []byte(`{"payload":"e30","protected":"e30","signature":"c2ln"}`)
+	notationManifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"artifactType":"%s","layers":[{"mediaType":"%s","digest":"%s","size":%d}]}`,
+		NotationSignatureArtifactType, NotationJwsMediaType, digestOf(envelope), len(envelope)))
+	referrersIndex := []byte(fmt.Sprintf(`{"schemaVersion":2,"manifests":[{"artifactType":"%s","digest":"%s"},{"artifactType":"application/spdx+json","digest":"sha256:0"}]}`,
+		NotationSignatureArtifactType, digestOf(notationManifest)))
+
+	registry := newMemoryRegistry()
+	registry.manifests["apps/api@v1"] = manifestContent
+	registry.manifests["apps/api@"+manifestDigest] = manifestContent
+	registry.manifests["apps/api@"+strings.Replace(manifestDigest, ":", "-", 1)+".sig"] = cosignManifest
+	registry.manifests["apps/api@"+strings.Replace(manifestDigest, ":", "-", 1)] = referrersIndex
+	registry.manifests["apps/api@"+digestOf(notationManifest)] = notationManifest
+	registry.blobs[digestOf(payload)] = payload
+	registry.blobs[digestOf(envelope)] = envelope
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	config := &RegistryConfig{RegistryURL: server.URL, Username: "user", Password: "secret"}
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("signatures of a tagged image", func(t *testing.T) {
+		signatures, err := FetchImageSignatures(context.Background(), config, host+"/apps/api:v1")
+		assert.Nil(t, err)
+		assert.Equal(t, manifestDigest, signatures.Digest)
+		assert.Len(t, signatures.Cosign, 1)
+		assert.Equal(t, payload, signatures.Cosign[0].Payload)
+		assert.Equal(t, "c2lnbmF0dXJl", signatures.Cosign[0].Signature)
+		assert.Len(t, signatures.Notation, 1)
+		assert.Equal(t, NotationJwsMediaType, signatures.Notation[0].MediaType)
+		assert.Equal(t, envelope, signatures.Notation[0].Envelope)
+	})
+
+	t.Run("unsigned image", func(t *testing.T) {
+		unsigned := []byte(`{"schemaVersion":2,"layers":[{"digest":"sha256:1"}]}`)
+		registry.manifests["apps/worker@v1"] = unsigned
+		signatures, err := FetchImageSignatures(context.Background(), config, host+"/apps/worker:v1")
+		assert.Nil(t, err)
+		assert.Equal(t, digestOf(unsigned), signatures.Digest)
+		assert.Empty(t, signatures.Cosign)
+		assert.Empty(t, signatures.Notation)
+	})
+
+	t.Run("missing image", func(t *testing.T) {
+		_, err := FetchImageSignatures(context.Background(), config, host+"/apps/missing:v1")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,382 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	repository "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RegistryClient talks to the api of a container registry with the credentials saved for it
+type RegistryClient interface {
+	// Ping fails with ErrUnauthorized when the registry denies the credentials
+	Ping(ctx context.Context) error
+	ListRepositories(ctx context.Context) ([]string, error)
+	ListTags(ctx context.Context, repositoryName string) ([]string, error)
+	// DeleteImage deletes the manifest the tag points to, other tags of the same manifest are deleted with it
+	DeleteImage(ctx context.Context, repositoryName string, tag string) error
+}
+
+// NewRegistryClient returns the client of the registry provider, registries without a provider api are browsed
+// through the distribution api
+func NewRegistryClient(config *RegistryConfig) (RegistryClient, error) {
+	httpClient, err := newHttpClient(config)
+	if err != nil {
+		return nil, err
+	}
+	client := newDistributionClient(config, httpClient)
+	switch config.RegistryType {
+	case repository.REGISTRYTYPE_HARBOR:
+		return &harborClient{distributionClient: client}, nil
+	case repository.REGISTRYTYPE_GHCR:
+		// packages are owned by the user of the token when the registry url has no owner
+		if client.namespace == "" {
+			client.namespace = strings.ToLower(config.Username)
+		}
+		return &ghcrClient{distributionClient: client, apiUrl: githubApiUrl}, nil
+	default:
+		return client, nil
+	}
+}
+
+func newHttpClient(config *RegistryConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+	switch config.Connection {
+	case ConnectionInsecure:
+		tlsConfig.InsecureSkipVerify = true
+	case ConnectionSecureWithCert:
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+		if ok := certPool.AppendCertsFromPEM([]byte(config.Cert)); !ok {
+			return nil, fmt.Errorf("invalid certificate of registry %s", config.RegistryURL)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}, nil
+}
+
+// distributionClient uses the distribution (docker registry v2) api, authenticating with basic auth or with a
+// bearer token issued by the realm of the registry
+type distributionClient struct {
+	httpClient *http.Client
+	baseUrl    string
+	namespace  string
+	username   string
+	password   string
+	// authorizations caches the answered challenge per scope, requests with a body can not be replayed
+	authorizations map[string]string
+}
+
+func newDistributionClient(config *RegistryConfig, httpClient *http.Client) *distributionClient {
+	host, namespace := splitRegistryUrl(config.RegistryURL)
+	scheme := "https"
+	if strings.HasPrefix(config.RegistryURL, "http://") {
+		scheme = "http"
+	}
+	return &distributionClient{
+		httpClient: httpClient,
+		baseUrl:    fmt.Sprintf("%s://%s", scheme, host),
+		namespace:  namespace,
+		username:   config.Username,
+		password:   config.Password,
+
+		authorizations: make(map[string]string),
+	}
+}
+
+func (impl *distributionClient) Ping(ctx context.Context) error {
+	resp, err := impl.get(ctx, impl.baseUrl+"/v2/", "")
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (impl *distributionClient) ListRepositories(ctx context.Context) ([]string, error) {
+	repositories := make([]string, 0)
+	nextUrl := fmt.Sprintf("%s/v2/_catalog?n=%d", impl.baseUrl, pageSize)
+	for nextUrl != "" {
+		catalog := &RepositoryListResponse{}
+		var err error
+		nextUrl, err = impl.getPage(ctx, nextUrl, "registry:catalog:*", catalog)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, catalog.Repositories...)
+	}
+	return impl.filterNamespace(repositories), nil
+}
+
+func (impl *distributionClient) ListTags(ctx context.Context, repositoryName string) ([]string, error) {
+	repositoryName = impl.qualifyRepository(repositoryName)
+	tags := make([]string, 0)
+	nextUrl := fmt.Sprintf("%s/v2/%s/tags/list?n=%d", impl.baseUrl, repositoryName, pageSize)
+	for nextUrl != "" {
+		tagList := &TagListResponse{}
+		var err error
+		nextUrl, err = impl.getPage(ctx, nextUrl, fmt.Sprintf("repository:%s:pull", repositoryName), tagList)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tagList.Tags...)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// manifestMediaTypes are accepted when resolving a tag, the digest of the manifest differs with the media type
+var manifestMediaTypes = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+func (impl *distributionClient) DeleteImage(ctx context.Context, repositoryName string, tag string) error {
+	repositoryName = impl.qualifyRepository(repositoryName)
+	scope := fmt.Sprintf("repository:%s:pull,delete", repositoryName)
+	resp, err := impl.request(ctx, http.MethodHead, fmt.Sprintf("%s/v2/%s/manifests/%s", impl.baseUrl, repositoryName, tag), scope,
+		map[string]string{"Accept": manifestMediaTypes})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return fmt.Errorf("registry did not return the digest of %s:%s", repositoryName, tag)
+	}
+	resp, err = impl.request(ctx, http.MethodDelete, fmt.Sprintf("%s/v2/%s/manifests/%s", impl.baseUrl, repositoryName, digest), scope, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// qualifyRepository prefixes the namespace of the registry url, repositories are listed relative to it
+func (impl *distributionClient) qualifyRepository(repositoryName string) string {
+	repositoryName = strings.Trim(repositoryName, "/")
+	if impl.namespace == "" || strings.HasPrefix(repositoryName, impl.namespace+"/") {
+		return repositoryName
+	}
+	return impl.namespace + "/" + repositoryName
+}
+
+// filterNamespace keeps the repositories under the namespace of the registry url, relative to it
+func (impl *distributionClient) filterNamespace(repositories []string) []string {
+	filtered := make([]string, 0, len(repositories))
+	for _, repositoryName := range repositories {
+		if impl.namespace == "" {
+			filtered = append(filtered, repositoryName)
+		} else if relativeName, ok := strings.CutPrefix(repositoryName, impl.namespace+"/"); ok {
+			filtered = append(filtered, relativeName)
+		}
+	}
+	sort.Strings(filtered)
+	return filtered
+}
+
+// getPage decodes the response into page and returns the url of the next page from the link header
+func (impl *distributionClient) getPage(ctx context.Context, pageUrl string, scope string, page interface{}) (string, error) {
+	resp, err := impl.get(ctx, pageUrl, scope)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	err = json.NewDecoder(resp.Body).Decode(page)
+	if err != nil {
+		return "", err
+	}
+	return impl.nextPageUrl(resp), nil
+}
+
+var linkNextRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+func (impl *distributionClient) nextPageUrl(resp *http.Response) string {
+	match := linkNextRegex.FindStringSubmatch(resp.Header.Get("Link"))
+	if len(match) < 2 {
+		return ""
+	}
+	next, err := url.Parse(match[1])
+	if err != nil {
+		return ""
+	}
+	return resp.Request.URL.ResolveReference(next).String()
+}
+
+func (impl *distributionClient) get(ctx context.Context, requestUrl string, scope string) (*http.Response, error) {
+	return impl.request(ctx, http.MethodGet, requestUrl, scope, nil)
+}
+
+// request retries the request with the credentials answering the challenge of the registry
+func (impl *distributionClient) request(ctx context.Context, method string, requestUrl string, scope string, headers map[string]string) (*http.Response, error) {
+	return impl.send(ctx, method, requestUrl, scope, headers, nil)
+}
+
+// requestBody is streamed to the registry, the length is sent as registries reject chunked uploads
+type requestBody struct {
+	reader io.Reader
+	length int64
+}
+
+// send is request with a body, it is sent with the cached authorization of the scope as it can not be retried
+func (impl *distributionClient) send(ctx context.Context, method string, requestUrl string, scope string, headers map[string]string, body *requestBody) (*http.Response, error) {
+	authorization := impl.authorizations[scope]
+	resp, err := impl.do(ctx, method, requestUrl, authorization, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && body == nil {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		authorization, err = impl.answerChallenge(ctx, challenge, scope)
+		if err != nil {
+			return nil, err
+		}
+		impl.authorizations[scope] = authorization
+		resp, err = impl.do(ctx, method, requestUrl, authorization, headers, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, checkResponse(resp)
+}
+
+func (impl *distributionClient) do(ctx context.Context, method string, requestUrl string, authorization string, headers map[string]string, body *requestBody) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = body.reader
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = body.length
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return impl.httpClient.Do(req)
+}
+
+func (impl *distributionClient) answerChallenge(ctx context.Context, challenge string, scope string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		req, _ := http.NewRequest(http.MethodGet, impl.baseUrl, nil)
+		req.SetBasicAuth(impl.username, impl.password)
+		return req.Header.Get("Authorization"), nil
+	case "bearer":
+		token, err := impl.fetchToken(ctx, params, scope)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q from registry", challenge)
+	}
+}
+
+type tokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+func (impl *distributionClient) fetchToken(ctx context.Context, params map[string]string, scope string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid token realm %q of registry", params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	if challengeScope := params["scope"]; challengeScope != "" {
+		scope = challengeScope
+	}
+	if scope != "" {
+		query.Set("scope", scope)
+	}
+	realm.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if impl.username != "" || impl.password != "" {
+		req.SetBasicAuth(impl.username, impl.password)
+	}
+	resp, err := impl.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return "", err
+	}
+	token := &tokenResponse{}
+	err = json.NewDecoder(resp.Body).Decode(token)
+	if err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
+
+var challengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// parseChallenge parses a WWW-Authenticate header like: Bearer realm="https://auth.io/token",service="registry.io"
+func parseChallenge(challenge string) (scheme string, params map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params = make(map[string]string)
+	for _, match := range challengeParamRegex.FindAllStringSubmatch(rest, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+	return strings.ToLower(scheme), params
+}
+
+// checkResponse closes the body of a failed response
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return ErrUnauthorized
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("registry responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
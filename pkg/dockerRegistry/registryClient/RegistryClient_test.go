@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"context"
+	"fmt"
+	repository "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTokenAuthRegistry serves the distribution api behind a token realm which accepts user:secret
+func newTokenAuthRegistry(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, `{"token":"token-%s"}`, r.URL.Query().Get("scope"))
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer token-") {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/v2/":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/v2/_catalog" && r.URL.Query().Get("last") == "":
+			assert.Equal(t, "Bearer token-registry:catalog:*", r.Header.Get("Authorization"))
+			w.Header().Set("Link", `</v2/_catalog?last=team%2Fapi&n=100>; rel="next"`)
+			fmt.Fprint(w, `{"repositories":["team/web","team/api"]}`)
+		case r.URL.Path == "/v2/_catalog":
+			fmt.Fprint(w, `{"repositories":["other/worker","team/worker"]}`)
+		case r.URL.Path == "/v2/team/api/tags/list":
+			assert.Equal(t, "Bearer token-repository:team/api:pull", r.Header.Get("Authorization"))
+			fmt.Fprint(w, `{"name":"team/api","tags":["v2","v1"]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestDistributionClient(t *testing.T) {
+	server := newTokenAuthRegistry(t)
+	defer server.Close()
+
+	t.Run("ping with valid credentials", func(t *testing.T) {
+		client, err := NewRegistryClient(&RegistryConfig{RegistryType: repository.REGISTRYTYPE_QUAY, RegistryURL: server.URL, Username: "user", Password: "secret"})
+		assert.Nil(t, err)
+		assert.Nil(t, client.Ping(context.Background()))
+	})
+
+	t.Run("ping with invalid credentials", func(t *testing.T) {
+		client, err := NewRegistryClient(&RegistryConfig{RegistryType: repository.REGISTRYTYPE_ACR, RegistryURL: server.URL, Username: "user", Password: "wrong"})
+		assert.Nil(t, err)
+		assert.Equal(t, ErrUnauthorized, client.Ping(context.Background()))
+	})
+
+	t.Run("list repositories across pages within the namespace", func(t *testing.T) {
+		client, err := NewRegistryClient(&RegistryConfig{RegistryType: repository.REGISTRYTYPE_ACR, RegistryURL: server.URL + "/team", Username: "user", Password: "secret"})
+		assert.Nil(t, err)
+		repositories, err := client.ListRepositories(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"api", "web", "worker"}, repositories)
+	})
+
+	t.Run("list tags of a repository relative to the namespace", func(t *testing.T) {
+		client, err := NewRegistryClient(&RegistryConfig{RegistryType: repository.REGISTRYTYPE_ACR, RegistryURL: server.URL + "/team", Username: "user", Password: "secret"})
+		assert.Nil(t, err)
+		tags, err := client.ListTags(context.Background(), "api")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"v1", "v2"}, tags)
+	})
+}
+
+func TestDistributionClient_DeleteImage(t *testing.T) {
+	deleted := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "secret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/team/api/manifests/v1":
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.oci.image.manifest.v1+json")
+			w.Header().Set("Docker-Content-Digest", "sha256:abc")
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/team/api/manifests/sha256:abc":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewRegistryClient(&RegistryConfig{RegistryType: repository.REGISTRYTYPE_QUAY, RegistryURL: server.URL + "/team", Username: "user", Password: "secret"})
+	assert.Nil(t, err)
+	assert.Nil(t, client.DeleteImage(context.Background(), "team/api", "v1"))
+	assert.Equal(t, []string{"/v2/team/api/manifests/sha256:abc"}, deleted)
+	assert.NotNil(t, client.DeleteImage(context.Background(), "team/api", "v2"))
+}
+
+func TestHarborClient_ListRepositories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "robot$library+ci" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "/api/v2.0/repositories", r.URL.Path)
+		fmt.Fprint(w, `[{"name":"library/nginx"},{"name":"library/alpine"},{"name":"infra/agent"}]`)
+	}))
+	defer server.Close()
+
+	client, err := NewRegistryClient(&RegistryConfig{RegistryType: repository.REGISTRYTYPE_HARBOR, RegistryURL: server.URL + "/library", Username: "robot$library+ci", Password: "secret"})
+	assert.Nil(t, err)
+	repositories, err := client.ListRepositories(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alpine", "nginx"}, repositories)
+}
+
+func TestGhcrClient_ListRepositories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer pat", r.Header.Get("Authorization"))
+		assert.Equal(t, "container", r.URL.Query().Get("package_type"))
+		switch r.URL.Path {
+		case "/users/octocat/packages":
+			fmt.Fprint(w, `[{"name":"web"},{"name":"api"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registryClient, err := NewRegistryClient(&RegistryConfig{RegistryType: repository.REGISTRYTYPE_GHCR, RegistryURL: "ghcr.io", Username: "Octocat", Password: "pat"})
+	assert.Nil(t, err)
+	client := registryClient.(*ghcrClient)
+	client.apiUrl = server.URL
+	repositories, err := client.ListRepositories(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"api", "web"}, repositories)
+}
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *RegistryConfig
+		wantErr bool
+	}{
+		{
+			name:   "harbor robot account",
+			config: &RegistryConfig{RegistryType: repository.REGISTRYTYPE_HARBOR, CredentialType: repository.REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT, Username: "robot$library+ci", Password: "secret"},
+		},
+		{
+			name:    "harbor robot account without robot prefix",
+			config:  &RegistryConfig{RegistryType: repository.REGISTRYTYPE_HARBOR, CredentialType: repository.REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT, Username: "admin", Password: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "quay robot account without namespace",
+			config:  &RegistryConfig{RegistryType: repository.REGISTRYTYPE_QUAY, CredentialType: repository.REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT, Username: "ci", Password: "secret"},
+			wantErr: true,
+		},
+		{
+			name:   "acr service principal",
+			config: &RegistryConfig{RegistryType: repository.REGISTRYTYPE_ACR, CredentialType: repository.REGISTRY_CREDENTIAL_TYPE_SERVICE_PRINCIPAL, Username: "a0b1c2d3", Password: "secret"},
+		},
+		{
+			name:    "acr with robot account",
+			config:  &RegistryConfig{RegistryType: repository.REGISTRYTYPE_ACR, CredentialType: repository.REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT, Username: "ci", Password: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "ghcr with other host",
+			config:  &RegistryConfig{RegistryType: repository.REGISTRYTYPE_GHCR, CredentialType: repository.REGISTRY_CREDENTIAL_TYPE_PERSONAL_ACCESS_TOKEN, RegistryURL: "docker.io/octocat", Username: "octocat", Password: "pat"},
+			wantErr: true,
+		},
+		{
+			name:   "ghcr personal access token",
+			config: &RegistryConfig{RegistryType: repository.REGISTRYTYPE_GHCR, CredentialType: repository.REGISTRY_CREDENTIAL_TYPE_PERSONAL_ACCESS_TOKEN, RegistryURL: "ghcr.io/devtron-labs", Username: "octocat", Password: "pat"},
+		},
+		{
+			name:   "other registry is not validated",
+			config: &RegistryConfig{RegistryType: repository.REGISTRYTYPE_OTHER},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCredentials(tt.config)
+			assert.Equal(t, tt.wantErr, err != nil, "error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+)
+
+func GetRegistryConfig(bean *types.DockerArtifactStoreBean) *RegistryConfig {
+	return &RegistryConfig{
+		RegistryType:   bean.RegistryType,
+		CredentialType: bean.CredentialType,
+		RegistryURL:    bean.RegistryURL,
+		Username:       bean.Username,
+		Password:       bean.Password,
+		Connection:     bean.Connection,
+		Cert:           bean.Cert,
+	}
+}
+
+func GetRegistryConfigForStore(store *repository.DockerArtifactStore) *RegistryConfig {
+	return &RegistryConfig{
+		RegistryType:   store.RegistryType,
+		CredentialType: store.CredentialType,
+		RegistryURL:    store.RegistryURL,
+		Username:       store.Username,
+		Password:       store.Password,
+		Connection:     store.Connection,
+		Cert:           store.Cert,
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryClient
+
+import (
+	"errors"
+	"fmt"
+	repository "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"strings"
+)
+
+const (
+	ConnectionInsecure       = "insecure"
+	ConnectionSecureWithCert = "secure-with-cert"
+
+	// pageSize is the number of entries requested per page from the registry apis
+	pageSize = 100
+
+	githubApiUrl = "https://api.github.com"
+	ghcrHost     = "ghcr.io"
+)
+
+var ErrUnauthorized = errors.New("registry denied the credentials")
+
+// RegistryConfig is what a client needs to connect to a registry
+type RegistryConfig struct {
+	RegistryType   repository.RegistryType
+	CredentialType repository.RegistryCredentialType
+	RegistryURL    string
+	Username       string
+	Password       string
+	Connection     string
+	Cert           string
+}
+
+type RepositoryListResponse struct {
+	Repositories []string `json:"repositories"`
+}
+
+type TagListResponse struct {
+	Repository string   `json:"repository"`
+	Tags       []string `json:"tags"`
+}
+
+// credentialTypesByRegistryType lists the credentials supported by the registries which have provider specific handling
+var credentialTypesByRegistryType = map[repository.RegistryType][]repository.RegistryCredentialType{
+	repository.REGISTRYTYPE_HARBOR: {repository.REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT, repository.REGISTRY_CREDENTIAL_TYPE_USERNAME_PASSWORD},
+	repository.REGISTRYTYPE_QUAY:   {repository.REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT, repository.REGISTRY_CREDENTIAL_TYPE_USERNAME_PASSWORD},
+	repository.REGISTRYTYPE_ACR:    {repository.REGISTRY_CREDENTIAL_TYPE_SERVICE_PRINCIPAL, repository.REGISTRY_CREDENTIAL_TYPE_TOKEN},
+	repository.REGISTRYTYPE_GHCR:   {repository.REGISTRY_CREDENTIAL_TYPE_PERSONAL_ACCESS_TOKEN},
+}
+
+// IsSupportedRegistryType is true for the registry types which can be browsed and validated with this package
+func IsSupportedRegistryType(registryType repository.RegistryType) bool {
+	_, ok := credentialTypesByRegistryType[registryType]
+	return ok
+}
+
+// ValidateCredentials checks that the credentials match what the provider issues for the given credential type
+func ValidateCredentials(config *RegistryConfig) error {
+	credentialTypes, ok := credentialTypesByRegistryType[config.RegistryType]
+	if !ok {
+		return nil
+	}
+	if !containsCredentialType(credentialTypes, config.CredentialType) {
+		return fmt.Errorf("credential type %q is not supported for %s registry, supported types are %v", config.CredentialType, config.RegistryType, credentialTypes)
+	}
+	// the password is not sent back on update when unchanged
+	if len(config.Username) == 0 {
+		return fmt.Errorf("username is required for %s registry", config.RegistryType)
+	}
+	switch {
+	case config.RegistryType == repository.REGISTRYTYPE_HARBOR && config.CredentialType == repository.REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT:
+		// robot account names are prefixed with "robot$" by default, the prefix can be changed in harbor
+		if !strings.Contains(config.Username, "robot") {
+			return fmt.Errorf("invalid harbor robot account name %q, expected the robot prefix eg. robot$project+name", config.Username)
+		}
+	case config.RegistryType == repository.REGISTRYTYPE_QUAY && config.CredentialType == repository.REGISTRY_CREDENTIAL_TYPE_ROBOT_ACCOUNT:
+		if !strings.Contains(config.Username, "+") {
+			return fmt.Errorf("invalid quay robot account name %q, expected namespace+name", config.Username)
+		}
+	case config.RegistryType == repository.REGISTRYTYPE_GHCR:
+		host, _ := splitRegistryUrl(config.RegistryURL)
+		if host != ghcrHost {
+			return fmt.Errorf("invalid ghcr registry url %q, expected %s/<owner>", config.RegistryURL, ghcrHost)
+		}
+	}
+	return nil
+}
+
+func containsCredentialType(credentialTypes []repository.RegistryCredentialType, credentialType repository.RegistryCredentialType) bool {
+	for _, supportedType := range credentialTypes {
+		if supportedType == credentialType {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRegistryUrl returns the host of the registry and the namespace, which is the path of the registry url if any
+func splitRegistryUrl(registryUrl string) (host string, namespace string) {
+	registryUrl = strings.TrimPrefix(registryUrl, "oci://")
+	registryUrl = strings.TrimPrefix(registryUrl, "https://")
+	registryUrl = strings.TrimPrefix(registryUrl, "http://")
+	registryUrl = strings.TrimSuffix(registryUrl, "/")
+	host, namespace, _ = strings.Cut(registryUrl, "/")
+	return host, namespace
+}
@@ -84,6 +84,7 @@ type CiCompleteEvent struct {
 	PluginArtifactStage           string                   `json:"pluginArtifactStage"`
 	pluginImageDetails            *registry.ImageDetailsFromCR
 	PluginArtifacts               *PluginArtifacts `json:"pluginArtifacts"`
+	Sbom                          json.RawMessage  `json:"sbom,omitempty"` // Sbom is the document generated by ci-runner when CI_SBOM_GENERATION_ENABLED is set
 }
 
 func (c *CiCompleteEvent) GetPluginImageDetails() *registry.ImageDetailsFromCR {
@@ -22,9 +22,13 @@ import (
 	"errors"
 	"fmt"
 	v1alpha12 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/devtron-labs/common-lib/async"
 	pubsub "github.com/devtron-labs/common-lib/pubsub-lib"
 	"github.com/devtron-labs/common-lib/pubsub-lib/model"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/pkg/app"
 	appStoreBean "github.com/devtron-labs/devtron/pkg/appStore/bean"
 	"github.com/devtron-labs/devtron/pkg/appStore/installedApp/repository"
@@ -32,6 +36,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/appStore/installedApp/service/FullMode"
 	"github.com/devtron-labs/devtron/pkg/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/rollback"
 	bean2 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
 	bean3 "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
@@ -40,6 +45,7 @@ import (
 	"github.com/go-pg/pg"
 	"go.uber.org/zap"
 	"k8s.io/utils/pointer"
+	"slices"
 	"time"
 )
 
@@ -54,8 +60,11 @@ type DeployedApplicationEventProcessorImpl struct {
 	pipelineBuilder           pipeline.PipelineBuilder
 	appStoreDeploymentService service.AppStoreDeploymentService
 
-	pipelineRepository     pipelineConfig.PipelineRepository
-	installedAppRepository repository.InstalledAppRepository
+	pipelineRepository        pipelineConfig.PipelineRepository
+	installedAppRepository    repository.InstalledAppRepository
+	cdWorkflowRepository      pipelineConfig.CdWorkflowRepository
+	deploymentRollbackService rollback.DeploymentRollbackService
+	asyncRunnable             *async.Runnable
 }
 
 func NewDeployedApplicationEventProcessorImpl(logger *zap.SugaredLogger,
@@ -68,7 +77,10 @@ func NewDeployedApplicationEventProcessorImpl(logger *zap.SugaredLogger,
 	pipelineBuilder pipeline.PipelineBuilder,
 	appStoreDeploymentService service.AppStoreDeploymentService,
 	pipelineRepository pipelineConfig.PipelineRepository,
-	installedAppRepository repository.InstalledAppRepository) *DeployedApplicationEventProcessorImpl {
+	installedAppRepository repository.InstalledAppRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	deploymentRollbackService rollback.DeploymentRollbackService,
+	asyncRunnable *async.Runnable) *DeployedApplicationEventProcessorImpl {
 	deployedApplicationEventProcessorImpl := &DeployedApplicationEventProcessorImpl{
 		logger:                    logger,
 		pubSubClient:              pubSubClient,
@@ -80,8 +92,11 @@ func NewDeployedApplicationEventProcessorImpl(logger *zap.SugaredLogger,
 		pipelineBuilder:           pipelineBuilder,
 		appStoreDeploymentService: appStoreDeploymentService,
 
-		pipelineRepository:     pipelineRepository,
-		installedAppRepository: installedAppRepository,
+		pipelineRepository:        pipelineRepository,
+		installedAppRepository:    installedAppRepository,
+		cdWorkflowRepository:      cdWorkflowRepository,
+		deploymentRollbackService: deploymentRollbackService,
+		asyncRunnable:             asyncRunnable,
 	}
 	return deployedApplicationEventProcessorImpl
 }
@@ -151,6 +166,8 @@ func (impl *DeployedApplicationEventProcessorImpl) SubscribeArgoAppUpdate() erro
 				impl.logger.Errorw("deployment success event error", "pipelineOverride", pipelineOverride, "err", err)
 				return
 			}
+		} else if !isAppStoreApplication && pipelineOverride != nil && pipelineOverride.CdWorkflowId > 0 {
+			impl.handleUnhealthyDeployment(pipelineOverride)
 		}
 		impl.logger.Debugw("application status update completed", "app", app.Name)
 	}
@@ -169,6 +186,22 @@ func (impl *DeployedApplicationEventProcessorImpl) SubscribeArgoAppUpdate() erro
 	return nil
 }
 
+// handleUnhealthyDeployment rolls back in the background when the status update marked the deploy runner degraded or timed out,
+// the status cron does not revisit runners which are already in a terminal state
+func (impl *DeployedApplicationEventProcessorImpl) handleUnhealthyDeployment(pipelineOverride *chartConfig.PipelineOverride) {
+	wfr, err := impl.cdWorkflowRepository.FindByWorkflowIdAndRunnerType(context.Background(), pipelineOverride.CdWorkflowId, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deploy runner for unhealthy deployment check", "cdWorkflowId", pipelineOverride.CdWorkflowId, "err", err)
+		return
+	}
+	if !slices.Contains(cdWorkflow.WfrUnhealthyStatusList, wfr.Status) {
+		return
+	}
+	impl.asyncRunnable.Execute(func() {
+		impl.deploymentRollbackService.HandleUnhealthyDeployment(wfr.Id)
+	})
+}
+
 func (impl *DeployedApplicationEventProcessorImpl) SubscribeArgoAppDeleteStatus() error {
 	callback := func(msg *model.PubSubMsg) {
 
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package in
+
+import (
+	"context"
+	"github.com/devtron-labs/common-lib/async"
+	"github.com/devtron-labs/common-lib/constants"
+	"github.com/devtron-labs/common-lib/utils/k8s/health"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/rollback"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeCdWorkflowRepository struct {
+	pipelineConfig.CdWorkflowRepository
+	runner pipelineConfig.CdWorkflowRunner
+}
+
+func (impl *fakeCdWorkflowRepository) FindByWorkflowIdAndRunnerType(ctx context.Context, wfId int, runnerType apiBean.WorkflowType) (pipelineConfig.CdWorkflowRunner, error) {
+	if wfId != impl.runner.CdWorkflowId || runnerType != apiBean.CD_WORKFLOW_TYPE_DEPLOY {
+		return pipelineConfig.CdWorkflowRunner{}, pg.ErrNoRows
+	}
+	return impl.runner, nil
+}
+
+type fakeDeploymentRollbackService struct {
+	rollback.DeploymentRollbackService
+	handledWfrIds chan int
+}
+
+func (impl *fakeDeploymentRollbackService) HandleUnhealthyDeployment(wfrId int) {
+	impl.handledWfrIds <- wfrId
+}
+
+func TestDeployedApplicationEventProcessorImpl_HandleUnhealthyDeployment(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	tests := []struct {
+		name           string
+		status         string
+		wantRolledBack bool
+	}{
+		{"degraded deployment", string(health.HealthStatusDegraded), true},
+		{"timed out deployment", cdWorkflow.WorkflowTimedOut, true},
+		{"failed deployment", cdWorkflow.WorkflowFailed, true},
+		{"progressing deployment", cdWorkflow.WorkflowInProgress, false},
+		{"healthy deployment", cdWorkflow.WorkflowSucceeded, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cdWorkflowRepository := &fakeCdWorkflowRepository{
+				runner: pipelineConfig.CdWorkflowRunner{Id: 21, CdWorkflowId: 11, Status: tt.status},
+			}
+			rollbackService := &fakeDeploymentRollbackService{handledWfrIds: make(chan int, 1)}
+			impl := &DeployedApplicationEventProcessorImpl{
+				logger:                    logger,
+				cdWorkflowRepository:      cdWorkflowRepository,
+				deploymentRollbackService: rollbackService,
+				asyncRunnable:             async.NewAsyncRunnable(logger, constants.Orchestrator),
+			}
+			impl.handleUnhealthyDeployment(&chartConfig.PipelineOverride{Id: 5, CdWorkflowId: 11})
+			select {
+			case wfrId := <-rollbackService.handledWfrIds:
+				assert.True(t, tt.wantRolledBack)
+				assert.Equal(t, 21, wfrId)
+			case <-time.After(200 * time.Millisecond):
+				assert.False(t, tt.wantRolledBack)
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ import (
 	cdWorkflowModelBean "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	util3 "github.com/devtron-labs/devtron/internal/util"
 	"github.com/devtron-labs/devtron/pkg/app"
+	"github.com/devtron-labs/devtron/pkg/artifactProvenance"
 	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/common"
 	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
@@ -84,6 +85,7 @@ type WorkflowEventProcessorImpl struct {
 	cdWorkflowCommonService      cd.CdWorkflowCommonService
 	cdPipelineConfigService      pipeline.CdPipelineConfigService
 	userDeploymentRequestService service.UserDeploymentRequestService
+	artifactProvenanceService    artifactProvenance.ArtifactProvenanceService
 
 	devtronAppReleaseContextMap     map[int]bean.DevtronAppReleaseContextType
 	devtronAppReleaseContextMapLock *sync.Mutex
@@ -115,7 +117,8 @@ func NewWorkflowEventProcessorImpl(logger *zap.SugaredLogger,
 	pipelineRepository pipelineConfig.PipelineRepository,
 	ciArtifactRepository repository.CiArtifactRepository,
 	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
-	deploymentConfigService common.DeploymentConfigService) (*WorkflowEventProcessorImpl, error) {
+	deploymentConfigService common.DeploymentConfigService,
+	artifactProvenanceService artifactProvenance.ArtifactProvenanceService) (*WorkflowEventProcessorImpl, error) {
 	impl := &WorkflowEventProcessorImpl{
 		logger:                          logger,
 		pubSubClient:                    pubSubClient,
@@ -141,6 +144,7 @@ func NewWorkflowEventProcessorImpl(logger *zap.SugaredLogger,
 		ciArtifactRepository:            ciArtifactRepository,
 		cdWorkflowRepository:            cdWorkflowRepository,
 		deploymentConfigService:         deploymentConfigService,
+		artifactProvenanceService:       artifactProvenanceService,
 	}
 	appServiceConfig, err := app.GetAppServiceConfig()
 	if err != nil {
@@ -603,6 +607,7 @@ func (impl *WorkflowEventProcessorImpl) validateAndHandleCiSuccessEvent(triggerC
 			ciPipelineId, "request", request, "error", err)
 		return 0, err
 	}
+	impl.artifactProvenanceService.RecordBuildAttestations(buildArtifactId, request.Sbom, request.UserId)
 	return buildArtifactId, nil
 }
 
@@ -677,6 +682,7 @@ func (impl *WorkflowEventProcessorImpl) BuildCiArtifactRequest(event bean.CiComp
 		IsArtifactUploaded:            event.IsArtifactUploaded,
 		PluginRegistryArtifactDetails: pluginArtifacts,
 		PluginArtifactStage:           event.PluginArtifactStage,
+		Sbom:                          event.Sbom,
 	}
 	// if DataSource is empty, repository.WEBHOOK is considered as default
 	if request.DataSource == "" {
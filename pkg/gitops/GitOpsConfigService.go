@@ -106,7 +106,22 @@ func NewGitOpsConfigServiceImpl(Logger *zap.SugaredLogger,
 	}
 }
 
+func (impl *GitOpsConfigServiceImpl) validateCommitStrategy(config *apiBean.GitOpsConfigDto) error {
+	if config.IsPullRequestCommitStrategy() && !git.IsPullRequestSupportedProvider(config.Provider) {
+		errMsg := fmt.Sprintf("pull request commit strategy is not supported for gitops provider '%s'", config.Provider)
+		return &util.ApiError{
+			HttpStatusCode:  http.StatusBadRequest,
+			InternalMessage: errMsg,
+			UserMessage:     errMsg,
+		}
+	}
+	return nil
+}
+
 func (impl *GitOpsConfigServiceImpl) ValidateAndCreateGitOpsConfig(config *apiBean.GitOpsConfigDto) (apiBean.DetailedErrorGitOpsConfigResponse, error) {
+	if err := impl.validateCommitStrategy(config); err != nil {
+		return apiBean.DetailedErrorGitOpsConfigResponse{}, err
+	}
 	detailedErrorGitOpsConfigResponse := impl.GitOpsValidateDryRun(config)
 	if len(detailedErrorGitOpsConfigResponse.StageErrorMap) == 0 {
 		//create argo-cd user, if not created, here argo-cd integration has to be installed
@@ -122,6 +137,9 @@ func (impl *GitOpsConfigServiceImpl) ValidateAndCreateGitOpsConfig(config *apiBe
 }
 
 func (impl *GitOpsConfigServiceImpl) ValidateAndUpdateGitOpsConfig(config *apiBean.GitOpsConfigDto) (apiBean.DetailedErrorGitOpsConfigResponse, error) {
+	if err := impl.validateCommitStrategy(config); err != nil {
+		return apiBean.DetailedErrorGitOpsConfigResponse{}, err
+	}
 	isTokenEmpty := config.Token == ""
 	isTlsDetailsEmpty := config.EnableTLSVerification &&
 		(config.TLSConfig == nil ||
@@ -215,6 +233,8 @@ func (impl *GitOpsConfigServiceImpl) createGitOpsConfig(ctx context.Context, req
 		Host:                  request.Host,
 		Active:                true,
 		AllowCustomRepository: request.AllowCustomRepository,
+		CommitStrategy:        request.CommitStrategy,
+		AutoMergePullRequest:  request.AutoMergePullRequest,
 		BitBucketWorkspaceId:  request.BitBucketWorkspaceId,
 		BitBucketProjectKey:   request.BitBucketProjectKey,
 		EnableTLSVerification: request.EnableTLSVerification,
@@ -497,6 +517,8 @@ func (impl *GitOpsConfigServiceImpl) updateGitOpsConfig(request *apiBean.GitOpsC
 	model.BitBucketWorkspaceId = request.BitBucketWorkspaceId
 	model.BitBucketProjectKey = request.BitBucketProjectKey
 	model.AllowCustomRepository = request.AllowCustomRepository
+	model.CommitStrategy = request.CommitStrategy
+	model.AutoMergePullRequest = request.AutoMergePullRequest
 	model.EnableTLSVerification = request.EnableTLSVerification
 	model.UpdatedBy = request.UserId
 	model.UpdatedOn = time.Now()
@@ -712,6 +734,8 @@ func (impl *GitOpsConfigServiceImpl) GetGitOpsConfigById(id int) (*apiBean.GitOp
 		BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 		BitBucketProjectKey:   model.BitBucketProjectKey,
 		AllowCustomRepository: model.AllowCustomRepository,
+		CommitStrategy:        model.CommitStrategy,
+		AutoMergePullRequest:  model.AutoMergePullRequest,
 		EnableTLSVerification: model.EnableTLSVerification,
 		TLSConfig: &bean.TLSConfig{ // sending empty values as they are hidden in FE
 			CaData:      "",
@@ -747,6 +771,8 @@ func (impl *GitOpsConfigServiceImpl) GetAllGitOpsConfig() ([]*apiBean.GitOpsConf
 			BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 			BitBucketProjectKey:   model.BitBucketProjectKey,
 			AllowCustomRepository: model.AllowCustomRepository,
+			CommitStrategy:        model.CommitStrategy,
+			AutoMergePullRequest:  model.AutoMergePullRequest,
 			EnableTLSVerification: model.EnableTLSVerification,
 			TLSConfig: &bean.TLSConfig{ // sending empty values as they are hidden in FE
 				CaData:      "",
@@ -782,6 +808,8 @@ func (impl *GitOpsConfigServiceImpl) GetGitOpsConfigByProvider(provider string)
 		BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 		BitBucketProjectKey:   model.BitBucketProjectKey,
 		AllowCustomRepository: model.AllowCustomRepository,
+		CommitStrategy:        model.CommitStrategy,
+		AutoMergePullRequest:  model.AutoMergePullRequest,
 		EnableTLSVerification: model.EnableTLSVerification,
 		TLSConfig: &bean.TLSConfig{ // sending empty values as they are hidden in FE
 			CaData:      "",
@@ -0,0 +1,454 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hibernationPolicy
+
+import (
+	"context"
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	resourceGroupRepository "github.com/devtron-labs/devtron/internal/sql/repository/resourceGroup"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	userRepository "github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/devtron-labs/devtron/pkg/bulkAction"
+	"github.com/devtron-labs/devtron/pkg/cluster"
+	clusterRepository "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	devtronResourceBean "github.com/devtron-labs/devtron/pkg/devtronResource/bean"
+	"github.com/devtron-labs/devtron/pkg/devtronResource/read"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/bean"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/repository"
+	"github.com/devtron-labs/devtron/pkg/k8s/capacity"
+	capacityBean "github.com/devtron-labs/devtron/pkg/k8s/capacity/bean"
+	"github.com/devtron-labs/devtron/pkg/resourceGroup"
+	"github.com/devtron-labs/devtron/util/argo"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type HibernationPolicyService interface {
+	SavePolicy(policy *bean.HibernationPolicyDto) (*bean.HibernationPolicyDto, error)
+	DeletePolicy(id int, userId int32) error
+	GetPolicy(id int) (*bean.HibernationPolicyDto, error)
+	GetPoliciesByEnvId(envId int) ([]*bean.HibernationPolicyDto, error)
+	SetKeepAwake(request *bean.KeepAwakeRequest) (*bean.HibernationPolicyDto, error)
+	// GetPolicyAppIds returns the ids of the applications hibernated by the policy
+	GetPolicyAppIds(id int) ([]int, error)
+	GetSavingsReport(id int, from, to time.Time) (*bean.HibernationReportDto, error)
+	// ApplyPolicies hibernates or wakes up the applications of the policies whose state is due to change, it must run on a single replica
+	ApplyPolicies()
+}
+
+type HibernationPolicyServiceImpl struct {
+	logger                              *zap.SugaredLogger
+	hibernationPolicyRepository         repository.HibernationPolicyRepository
+	environmentRepository               clusterRepository.EnvironmentRepository
+	clusterService                      cluster.ClusterService
+	pipelineRepository                  pipelineConfig.PipelineRepository
+	resourceGroupRepository             resourceGroupRepository.ResourceGroupRepository
+	resourceGroupService                resourceGroup.ResourceGroupService
+	devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService
+	bulkUpdateService                   bulkAction.BulkUpdateService
+	k8sCapacityService                  capacity.K8sCapacityService
+	argoUserService                     argo.ArgoUserService
+	enforcer                            casbin.Enforcer
+	userRepository                      userRepository.UserRepository
+	costConfig                          *bean.HibernationCostConfig
+}
+
+func NewHibernationPolicyServiceImpl(logger *zap.SugaredLogger,
+	hibernationPolicyRepository repository.HibernationPolicyRepository,
+	environmentRepository clusterRepository.EnvironmentRepository,
+	clusterService cluster.ClusterService,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	resourceGroupRepository resourceGroupRepository.ResourceGroupRepository,
+	resourceGroupService resourceGroup.ResourceGroupService,
+	devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService,
+	bulkUpdateService bulkAction.BulkUpdateService,
+	k8sCapacityService capacity.K8sCapacityService,
+	argoUserService argo.ArgoUserService,
+	enforcer casbin.Enforcer,
+	userRepository userRepository.UserRepository) (*HibernationPolicyServiceImpl, error) {
+	costConfig := &bean.HibernationCostConfig{}
+	err := env.Parse(costConfig)
+	if err != nil {
+		logger.Errorw("error in parsing hibernation cost config", "err", err)
+		return nil, err
+	}
+	return &HibernationPolicyServiceImpl{
+		logger:                              logger,
+		hibernationPolicyRepository:         hibernationPolicyRepository,
+		environmentRepository:               environmentRepository,
+		clusterService:                      clusterService,
+		pipelineRepository:                  pipelineRepository,
+		resourceGroupRepository:             resourceGroupRepository,
+		resourceGroupService:                resourceGroupService,
+		devtronResourceSearchableKeyService: devtronResourceSearchableKeyService,
+		bulkUpdateService:                   bulkUpdateService,
+		k8sCapacityService:                  k8sCapacityService,
+		argoUserService:                     argoUserService,
+		enforcer:                            enforcer,
+		userRepository:                      userRepository,
+		costConfig:                          costConfig,
+	}, nil
+}
+
+func (impl *HibernationPolicyServiceImpl) SavePolicy(policy *bean.HibernationPolicyDto) (*bean.HibernationPolicyDto, error) {
+	if len(policy.Timezone) == 0 {
+		policy.Timezone = bean.DefaultTimezone
+	}
+	err := validateSchedule(policy)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	environment, err := impl.environmentRepository.FindById(policy.EnvId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", policy.EnvId, "err", err)
+		return nil, err
+	}
+	if environment.IsVirtualEnvironment {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.VirtualEnvNotSupported).WithInternalMessage(bean.VirtualEnvNotSupported)
+	}
+	if policy.ResourceGroupId > 0 {
+		err = impl.validateResourceGroup(policy.ResourceGroupId, policy.EnvId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	dbObject := adaptToPolicyModel(policy)
+	if policy.Id > 0 {
+		existing, err := impl.hibernationPolicyRepository.FindPolicyById(policy.Id)
+		if err != nil {
+			impl.logger.Errorw("error in fetching hibernation policy", "id", policy.Id, "err", err)
+			return nil, err
+		}
+		dbObject.Id = existing.Id
+		dbObject.KeepAwakeUntil = existing.KeepAwakeUntil
+		dbObject.KeepAwakeBy = existing.KeepAwakeBy
+		dbObject.CreatedOn = existing.CreatedOn
+		dbObject.CreatedBy = existing.CreatedBy
+		// a policy moved to other applications starts afresh, the ones it hibernated are left as they are
+		if existing.EnvId == dbObject.EnvId && existing.ResourceGroupId == dbObject.ResourceGroupId && dbObject.Enabled {
+			dbObject.CurrentState = existing.CurrentState
+		} else {
+			impl.closeOpenRun(existing.Id, time.Now())
+		}
+		err = impl.hibernationPolicyRepository.UpdatePolicy(dbObject)
+	} else {
+		err = impl.hibernationPolicyRepository.SavePolicy(dbObject)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving hibernation policy", "policy", policy, "err", err)
+		return nil, err
+	}
+	return adaptToPolicyDto(dbObject), nil
+}
+
+func (impl *HibernationPolicyServiceImpl) validateResourceGroup(resourceGroupId, envId int) error {
+	group, err := impl.resourceGroupRepository.FindById(resourceGroupId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching resource group", "resourceGroupId", resourceGroupId, "err", err)
+		return err
+	}
+	appKey := impl.devtronResourceSearchableKeyService.GetAllSearchableKeyNameIdMap()[devtronResourceBean.DEVTRON_RESOURCE_SEARCHABLE_KEY_APP_ID]
+	if err == pg.ErrNoRows || !group.Active || group.ResourceId != envId || group.ResourceKey != appKey {
+		errMsg := fmt.Sprintf(bean.InvalidResourceGroup, resourceGroupId, envId)
+		return util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	return nil
+}
+
+func (impl *HibernationPolicyServiceImpl) DeletePolicy(id int, userId int32) error {
+	policy, err := impl.hibernationPolicyRepository.FindPolicyById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernation policy", "id", id, "err", err)
+		return err
+	}
+	policy.Active = false
+	policy.UpdateAuditLog(userId)
+	err = impl.hibernationPolicyRepository.UpdatePolicy(policy)
+	if err != nil {
+		impl.logger.Errorw("error in deleting hibernation policy", "id", id, "err", err)
+		return err
+	}
+	impl.closeOpenRun(id, time.Now())
+	return nil
+}
+
+func (impl *HibernationPolicyServiceImpl) GetPolicy(id int) (*bean.HibernationPolicyDto, error) {
+	policy, err := impl.hibernationPolicyRepository.FindPolicyById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernation policy", "id", id, "err", err)
+		return nil, err
+	}
+	return adaptToPolicyDto(policy), nil
+}
+
+func (impl *HibernationPolicyServiceImpl) GetPoliciesByEnvId(envId int) ([]*bean.HibernationPolicyDto, error) {
+	policies, err := impl.hibernationPolicyRepository.FindPoliciesByEnvId(envId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernation policies", "envId", envId, "err", err)
+		return nil, err
+	}
+	result := make([]*bean.HibernationPolicyDto, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, adaptToPolicyDto(policy))
+	}
+	return result, nil
+}
+
+func (impl *HibernationPolicyServiceImpl) SetKeepAwake(request *bean.KeepAwakeRequest) (*bean.HibernationPolicyDto, error) {
+	policy, err := impl.hibernationPolicyRepository.FindPolicyById(request.PolicyId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernation policy", "id", request.PolicyId, "err", err)
+		return nil, err
+	}
+	if request.KeepAwakeUntil == nil {
+		policy.KeepAwakeUntil = time.Time{}
+		policy.KeepAwakeBy = 0
+	} else {
+		now := time.Now()
+		if !request.KeepAwakeUntil.After(now) || request.KeepAwakeUntil.Sub(now) > bean.MaxKeepAwakeDuration {
+			errMsg := fmt.Sprintf(bean.InvalidKeepAwakeUntil, bean.MaxKeepAwakeDuration)
+			return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+		}
+		policy.KeepAwakeUntil = *request.KeepAwakeUntil
+		policy.KeepAwakeBy = request.UserId
+	}
+	policy.UpdateAuditLog(request.UserId)
+	err = impl.hibernationPolicyRepository.UpdatePolicy(policy)
+	if err != nil {
+		impl.logger.Errorw("error in updating keep awake of hibernation policy", "request", request, "err", err)
+		return nil, err
+	}
+	return adaptToPolicyDto(policy), nil
+}
+
+func (impl *HibernationPolicyServiceImpl) GetPolicyAppIds(id int) ([]int, error) {
+	policy, err := impl.hibernationPolicyRepository.FindPolicyById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernation policy", "id", id, "err", err)
+		return nil, err
+	}
+	if policy.ResourceGroupId > 0 {
+		return impl.resourceGroupService.GetResourceIdsByResourceGroupId(policy.ResourceGroupId)
+	}
+	pipelines, err := impl.pipelineRepository.FindActiveByEnvId(policy.EnvId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipelines", "envId", policy.EnvId, "err", err)
+		return nil, err
+	}
+	appIds := make([]int, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		appIds = append(appIds, pipeline.AppId)
+	}
+	return appIds, nil
+}
+
+func (impl *HibernationPolicyServiceImpl) GetSavingsReport(id int, from, to time.Time) (*bean.HibernationReportDto, error) {
+	runs, err := impl.hibernationPolicyRepository.FindRunsOverlapping(id, from, to)
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernation runs", "policyId", id, "err", err)
+		return nil, err
+	}
+	report := &bean.HibernationReportDto{
+		PolicyId: id,
+		From:     from,
+		To:       to,
+		Runs:     make([]*bean.HibernationRunDto, 0, len(runs)),
+	}
+	for _, run := range runs {
+		runDto := getRunSavings(run, from, to, impl.costConfig)
+		report.Runs = append(report.Runs, runDto)
+		report.HibernatedHours += runDto.HibernatedHours
+		report.CpuCoreHours += runDto.CpuCoreHours
+		report.MemoryGibHours += runDto.MemoryGibHours
+		report.EstimatedSavings += runDto.EstimatedSavings
+	}
+	return report, nil
+}
+
+func (impl *HibernationPolicyServiceImpl) ApplyPolicies() {
+	policies, err := impl.hibernationPolicyRepository.FindAllEnabledPolicies()
+	if err != nil {
+		impl.logger.Errorw("error in fetching enabled hibernation policies", "err", err)
+		return
+	}
+	now := time.Now()
+	for _, policy := range policies {
+		err = impl.applyPolicy(policy, now)
+		if err != nil {
+			impl.logger.Errorw("error in applying hibernation policy", "policyId", policy.Id, "err", err)
+		}
+	}
+}
+
+// applyPolicy acts only when the state the schedule asks for differs from the one the policy last applied,
+// so that a user hibernating or waking up the applications by hand is not overridden until the next change
+func (impl *HibernationPolicyServiceImpl) applyPolicy(policy *repository.HibernationPolicy, now time.Time) error {
+	desiredState, err := getDesiredState(policy, now)
+	if err != nil {
+		return err
+	}
+	if bean.PolicyState(policy.CurrentState) == desiredState {
+		return nil
+	}
+	request := &bulkAction.BulkApplicationForEnvironmentPayload{
+		EnvId:       policy.EnvId,
+		UserId:      userBean.SystemUserId,
+		TriggerType: cdWorkflow.TriggerTypeScheduled,
+	}
+	// waking up is limited to the applications of the open hibernation run instead of the current resource group
+	if desiredState == bean.PolicyStateHibernated && policy.ResourceGroupId > 0 {
+		request.AppIdIncludes, err = impl.resourceGroupService.GetResourceIdsByResourceGroupId(policy.ResourceGroupId)
+		if err != nil {
+			return err
+		}
+		if len(request.AppIdIncludes) == 0 {
+			// an empty include list would select every application of the environment
+			return fmt.Errorf(bean.EmptyResourceGroup, policy.ResourceGroupId)
+		}
+	}
+	checkAuth, err := impl.getPolicyOwnerAuth(policy)
+	if err != nil {
+		return err
+	}
+	acdToken, err := impl.argoUserService.GetLatestDevtronArgoCdUserToken()
+	if err != nil {
+		return err
+	}
+	ctx := context.WithValue(context.Background(), "token", acdToken)
+	if desiredState == bean.PolicyStateHibernated {
+		err = impl.hibernate(ctx, policy, request, checkAuth, now)
+	} else {
+		err = impl.unhibernate(ctx, policy, request, checkAuth, now)
+	}
+	if err != nil {
+		return err
+	}
+	policy.CurrentState = string(desiredState)
+	return impl.hibernationPolicyRepository.UpdatePolicy(policy)
+}
+
+func (impl *HibernationPolicyServiceImpl) hibernate(ctx context.Context, policy *repository.HibernationPolicy,
+	request *bulkAction.BulkApplicationForEnvironmentPayload, checkAuth bulkActionAuth, now time.Time) error {
+	// pods are gone once hibernated, their requests are read beforehand
+	environment, err := impl.environmentRepository.FindById(policy.EnvId)
+	if err != nil {
+		return err
+	}
+	pods := impl.getPodCapacityDetails(ctx, environment)
+	response, err := impl.bulkUpdateService.BulkHibernate(request, ctx, nil, "", checkAuth)
+	if err != nil {
+		return err
+	}
+	hibernatedAppIds := getSuccessfulAppIds(response)
+	run := &repository.HibernationRun{
+		PolicyId:     policy.Id,
+		HibernatedOn: now,
+		AppIds:       hibernatedAppIds,
+		Message:      fmt.Sprintf(bean.HibernatedByPolicy, len(hibernatedAppIds), len(response.Response)),
+	}
+	if len(hibernatedAppIds) > 0 && len(pods) > 0 {
+		pipelines, err := impl.pipelineRepository.FindActiveByInFilter(policy.EnvId, hibernatedAppIds)
+		if err != nil {
+			impl.logger.Errorw("error in fetching hibernated pipelines, savings will not be estimated", "policyId", policy.Id, "err", err)
+		}
+		releaseNames := make([]string, 0, len(pipelines))
+		for _, pipeline := range pipelines {
+			releaseNames = append(releaseNames, pipeline.DeploymentAppName)
+		}
+		run.CpuRequestMilli, run.MemoryRequestBytes = sumPodRequests(pods, releaseNames)
+	}
+	impl.closeOpenRun(policy.Id, now)
+	return impl.hibernationPolicyRepository.SaveRun(run)
+}
+
+// unhibernate wakes up only the applications the policy hibernated, the ones a user hibernated by hand or
+// which joined the resource group afterwards are left alone
+func (impl *HibernationPolicyServiceImpl) unhibernate(ctx context.Context, policy *repository.HibernationPolicy,
+	request *bulkAction.BulkApplicationForEnvironmentPayload, checkAuth bulkActionAuth, now time.Time) error {
+	run, err := impl.hibernationPolicyRepository.FindOpenRun(policy.Id)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching open hibernation run", "policyId", policy.Id, "err", err)
+		return err
+	}
+	if err == pg.ErrNoRows || len(run.AppIds) == 0 {
+		impl.logger.Infow("hibernation policy has no hibernated applications to wake up", "policyId", policy.Id)
+		return nil
+	}
+	request.AppIdIncludes = run.AppIds
+	response, err := impl.bulkUpdateService.BulkUnHibernate(request, ctx, nil, "", checkAuth)
+	if err != nil {
+		return err
+	}
+	impl.logger.Infow("hibernation policy woke up applications", "policyId", policy.Id,
+		"message", fmt.Sprintf(bean.UnhibernatedByPolicy, len(getSuccessfulAppIds(response)), len(response.Response)))
+	run.WokenOn = now
+	return impl.hibernationPolicyRepository.UpdateRun(run)
+}
+
+// getPodCapacityDetails is best effort, a failure only leaves the savings of the run unestimated
+func (impl *HibernationPolicyServiceImpl) getPodCapacityDetails(ctx context.Context, environment *clusterRepository.Environment) []*capacityBean.PodCapacityDetail {
+	clusterBean, err := impl.clusterService.FindById(environment.ClusterId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster, savings will not be estimated", "clusterId", environment.ClusterId, "err", err)
+		return nil
+	}
+	pods, err := impl.k8sCapacityService.GetPodCapacityDetailsByNamespace(ctx, clusterBean, environment.Namespace)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pod requests, savings will not be estimated", "envId", environment.Id, "err", err)
+		return nil
+	}
+	return pods
+}
+
+// closeOpenRun stops accounting the savings of the policy's last hibernation
+func (impl *HibernationPolicyServiceImpl) closeOpenRun(policyId int, now time.Time) {
+	run, err := impl.hibernationPolicyRepository.FindOpenRun(policyId)
+	if err != nil {
+		if err != pg.ErrNoRows {
+			impl.logger.Errorw("error in fetching open hibernation run", "policyId", policyId, "err", err)
+		}
+		return
+	}
+	run.WokenOn = now
+	err = impl.hibernationPolicyRepository.UpdateRun(run)
+	if err != nil {
+		impl.logger.Errorw("error in closing hibernation run", "runId", run.Id, "err", err)
+	}
+}
+
+type bulkActionAuth = func(token string, appObject string, envObject string) bool
+
+// getPolicyOwnerAuth authorises the bulk actions of a policy against the user who last saved it, a policy
+// stops acting on the applications its owner can no longer trigger and entirely once the owner is deactivated
+func (impl *HibernationPolicyServiceImpl) getPolicyOwnerAuth(policy *repository.HibernationPolicy) (bulkActionAuth, error) {
+	owner, err := impl.userRepository.GetById(policy.UpdatedBy)
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernation policy owner", "policyId", policy.Id, "userId", policy.UpdatedBy, "err", err)
+		return nil, err
+	}
+	return func(token string, appObject string, envObject string) bool {
+		return impl.enforcer.EnforceByEmail(owner.EmailId, casbin.ResourceApplications, casbin.ActionTrigger, appObject) &&
+			impl.enforcer.EnforceByEmail(owner.EmailId, casbin.ResourceEnvironment, casbin.ActionTrigger, envObject)
+	}, nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hibernationPolicy
+
+import (
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/bean"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func adaptToPolicyModel(policy *bean.HibernationPolicyDto) *repository.HibernationPolicy {
+	dbObject := &repository.HibernationPolicy{
+		Name:             policy.Name,
+		EnvId:            policy.EnvId,
+		ResourceGroupId:  policy.ResourceGroupId,
+		Timezone:         policy.Timezone,
+		WeekdayWakeTime:  policy.Weekdays.WakeTime,
+		WeekdaySleepTime: policy.Weekdays.SleepTime,
+		Holidays:         policy.Holidays,
+		Enabled:          policy.Enabled,
+		Active:           true,
+		AuditLog:         sql.NewDefaultAuditLog(policy.UserId),
+	}
+	if policy.Weekend != nil {
+		dbObject.WeekendWakeTime = policy.Weekend.WakeTime
+		dbObject.WeekendSleepTime = policy.Weekend.SleepTime
+	}
+	return dbObject
+}
+
+func adaptToPolicyDto(policy *repository.HibernationPolicy) *bean.HibernationPolicyDto {
+	dto := &bean.HibernationPolicyDto{
+		Id:              policy.Id,
+		Name:            policy.Name,
+		EnvId:           policy.EnvId,
+		ResourceGroupId: policy.ResourceGroupId,
+		Timezone:        policy.Timezone,
+		Weekdays: &bean.DaySchedule{
+			WakeTime:  policy.WeekdayWakeTime,
+			SleepTime: policy.WeekdaySleepTime,
+		},
+		Holidays:     policy.Holidays,
+		Enabled:      policy.Enabled,
+		CurrentState: bean.PolicyState(policy.CurrentState),
+	}
+	if len(policy.WeekendWakeTime) > 0 {
+		dto.Weekend = &bean.DaySchedule{
+			WakeTime:  policy.WeekendWakeTime,
+			SleepTime: policy.WeekendSleepTime,
+		}
+	}
+	if !policy.KeepAwakeUntil.IsZero() {
+		keepAwakeUntil := policy.KeepAwakeUntil
+		dto.KeepAwakeUntil = &keepAwakeUntil
+		dto.KeepAwakeBy = policy.KeepAwakeBy
+	}
+	if dto.Holidays == nil {
+		dto.Holidays = make([]string, 0)
+	}
+	return dto
+}
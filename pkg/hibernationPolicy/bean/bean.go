@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type PolicyState string
+
+const (
+	PolicyStateAwake      PolicyState = "AWAKE"
+	PolicyStateHibernated PolicyState = "HIBERNATED"
+)
+
+const (
+	DefaultTimezone = "UTC"
+	TimeOfDayLayout = "15:04"
+	HolidayLayout   = "2006-01-02"
+	// MaxKeepAwakeDuration caps how far ahead a user can keep an environment from being hibernated
+	MaxKeepAwakeDuration = 7 * 24 * time.Hour
+	DefaultReportDays    = 30
+)
+
+const (
+	InvalidTimeOfDay       = "invalid time of day %q, expected HH:MM"
+	InvalidHoliday         = "invalid holiday %q, expected YYYY-MM-DD"
+	InvalidTimezone        = "invalid timezone %q"
+	InvalidResourceGroup   = "resource group %d is not an application group of environment %d"
+	EmptyResourceGroup     = "resource group %d has no applications"
+	InvalidKeepAwakeUntil  = "keep awake until must be in the future and within %s"
+	HibernatedByPolicy     = "hibernated %d of %d applications"
+	UnhibernatedByPolicy   = "woken up %d of %d applications"
+	VirtualEnvNotSupported = "hibernation policies are not supported on virtual environments"
+)
+
+// DaySchedule is the part of a day for which the applications are kept awake, times are HH:MM in the policy's timezone.
+// A sleep time before the wake time keeps them awake past midnight
+type DaySchedule struct {
+	WakeTime  string `json:"wakeTime" validate:"required"`
+	SleepTime string `json:"sleepTime" validate:"required"`
+}
+
+// HibernationPolicyDto hibernates the applications of an environment, or of an application group in it, outside their awake hours.
+// Applications stay hibernated for the whole weekend if no weekend schedule is set, and for the whole day on holidays
+type HibernationPolicyDto struct {
+	Id              int          `json:"id"`
+	Name            string       `json:"name" validate:"required,max=50"`
+	EnvId           int          `json:"envId" validate:"required"`
+	ResourceGroupId int          `json:"resourceGroupId,omitempty"`
+	Timezone        string       `json:"timezone"`
+	Weekdays        *DaySchedule `json:"weekdays" validate:"required"`
+	Weekend         *DaySchedule `json:"weekend,omitempty"`
+	Holidays        []string     `json:"holidays"`
+	Enabled         bool         `json:"enabled"`
+	KeepAwakeUntil  *time.Time   `json:"keepAwakeUntil,omitempty"`
+	KeepAwakeBy     int32        `json:"keepAwakeBy,omitempty"`
+	CurrentState    PolicyState  `json:"currentState,omitempty"`
+	UserId          int32        `json:"-"`
+}
+
+// KeepAwakeRequest keeps the applications of a policy awake until the given time, a nil time removes the override
+type KeepAwakeRequest struct {
+	PolicyId       int        `json:"-"`
+	KeepAwakeUntil *time.Time `json:"keepAwakeUntil"`
+	UserId         int32      `json:"-"`
+}
+
+type HibernationRunDto struct {
+	Id               int        `json:"id"`
+	HibernatedOn     time.Time  `json:"hibernatedOn"`
+	WokenOn          *time.Time `json:"wokenOn,omitempty"`
+	AppIds           []int      `json:"appIds"`
+	HibernatedHours  float64    `json:"hibernatedHours"`
+	CpuCoreHours     float64    `json:"cpuCoreHours"`
+	MemoryGibHours   float64    `json:"memoryGibHours"`
+	EstimatedSavings float64    `json:"estimatedSavings"`
+	Message          string     `json:"message"`
+}
+
+// HibernationReportDto estimates the savings of a policy from the resource requests of the pods it scaled down
+type HibernationReportDto struct {
+	PolicyId         int                  `json:"policyId"`
+	From             time.Time            `json:"from"`
+	To               time.Time            `json:"to"`
+	Runs             []*HibernationRunDto `json:"runs"`
+	HibernatedHours  float64              `json:"hibernatedHours"`
+	CpuCoreHours     float64              `json:"cpuCoreHours"`
+	MemoryGibHours   float64              `json:"memoryGibHours"`
+	EstimatedSavings float64              `json:"estimatedSavings"`
+}
+
+type HibernationCostConfig struct {
+	CpuCoreHourCost   float64 `env:"HIBERNATION_CPU_CORE_HOUR_COST" envDefault:"0.0316"`
+	MemoryGibHourCost float64 `env:"HIBERNATION_MEMORY_GIB_HOUR_COST" envDefault:"0.0042"`
+}
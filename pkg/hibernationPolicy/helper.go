@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hibernationPolicy
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/bulkAction"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/bean"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/repository"
+	capacityBean "github.com/devtron-labs/devtron/pkg/k8s/capacity/bean"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseTimeOfDay returns the minutes since midnight of a HH:MM time
+func parseTimeOfDay(timeOfDay string) (int, error) {
+	parsed, err := time.Parse(bean.TimeOfDayLayout, timeOfDay)
+	if err != nil {
+		return 0, fmt.Errorf(bean.InvalidTimeOfDay, timeOfDay)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func validateSchedule(policy *bean.HibernationPolicyDto) error {
+	if _, err := time.LoadLocation(policy.Timezone); err != nil {
+		return fmt.Errorf(bean.InvalidTimezone, policy.Timezone)
+	}
+	daySchedules := []*bean.DaySchedule{policy.Weekdays}
+	if policy.Weekend != nil {
+		daySchedules = append(daySchedules, policy.Weekend)
+	}
+	for _, daySchedule := range daySchedules {
+		if _, err := parseTimeOfDay(daySchedule.WakeTime); err != nil {
+			return err
+		}
+		if _, err := parseTimeOfDay(daySchedule.SleepTime); err != nil {
+			return err
+		}
+	}
+	for _, holiday := range policy.Holidays {
+		if _, err := time.Parse(bean.HolidayLayout, holiday); err != nil {
+			return fmt.Errorf(bean.InvalidHoliday, holiday)
+		}
+	}
+	return nil
+}
+
+// isWithinAwakeHours tells whether the minute of the day falls between the wake and the sleep time, equal times keep awake all day
+func isWithinAwakeHours(wakeTime, sleepTime string, minuteOfDay int) (bool, error) {
+	wake, err := parseTimeOfDay(wakeTime)
+	if err != nil {
+		return false, err
+	}
+	sleep, err := parseTimeOfDay(sleepTime)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case wake == sleep:
+		return true, nil
+	case wake < sleep:
+		return minuteOfDay >= wake && minuteOfDay < sleep, nil
+	default:
+		return minuteOfDay >= wake || minuteOfDay < sleep, nil
+	}
+}
+
+// getDesiredState evaluates the policy's schedule at the given time, a keep awake override wins over the schedule
+func getDesiredState(policy *repository.HibernationPolicy, now time.Time) (bean.PolicyState, error) {
+	if now.Before(policy.KeepAwakeUntil) {
+		return bean.PolicyStateAwake, nil
+	}
+	location, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		return "", fmt.Errorf(bean.InvalidTimezone, policy.Timezone)
+	}
+	localNow := now.In(location)
+	today := localNow.Format(bean.HolidayLayout)
+	for _, holiday := range policy.Holidays {
+		if holiday == today {
+			return bean.PolicyStateHibernated, nil
+		}
+	}
+	wakeTime, sleepTime := policy.WeekdayWakeTime, policy.WeekdaySleepTime
+	if weekday := localNow.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		if len(policy.WeekendWakeTime) == 0 {
+			return bean.PolicyStateHibernated, nil
+		}
+		wakeTime, sleepTime = policy.WeekendWakeTime, policy.WeekendSleepTime
+	}
+	awake, err := isWithinAwakeHours(wakeTime, sleepTime, localNow.Hour()*60+localNow.Minute())
+	if err != nil {
+		return "", err
+	}
+	if awake {
+		return bean.PolicyStateAwake, nil
+	}
+	return bean.PolicyStateHibernated, nil
+}
+
+// sumPodRequests adds up the requests of the pods belonging to the releases, a pod belongs to the longest release name it is prefixed by
+func sumPodRequests(pods []*capacityBean.PodCapacityDetail, releaseNames []string) (cpuMilli int64, memoryBytes int64) {
+	for _, pod := range pods {
+		matchedRelease := ""
+		for _, releaseName := range releaseNames {
+			if strings.HasPrefix(pod.Name, releaseName+"-") && len(releaseName) > len(matchedRelease) {
+				matchedRelease = releaseName
+			}
+		}
+		if len(matchedRelease) == 0 {
+			continue
+		}
+		if pod.Cpu != nil {
+			if quantity, err := resource.ParseQuantity(pod.Cpu.Request); err == nil {
+				cpuMilli += quantity.MilliValue()
+			}
+		}
+		if pod.Memory != nil {
+			if quantity, err := resource.ParseQuantity(pod.Memory.Request); err == nil {
+				memoryBytes += quantity.Value()
+			}
+		}
+	}
+	return cpuMilli, memoryBytes
+}
+
+// getRunSavings estimates the savings of the part of the run falling in the period
+func getRunSavings(run *repository.HibernationRun, from, to time.Time, costConfig *bean.HibernationCostConfig) *bean.HibernationRunDto {
+	runDto := &bean.HibernationRunDto{
+		Id:           run.Id,
+		HibernatedOn: run.HibernatedOn,
+		AppIds:       run.AppIds,
+		Message:      run.Message,
+	}
+	end := to
+	if !run.WokenOn.IsZero() {
+		wokenOn := run.WokenOn
+		runDto.WokenOn = &wokenOn
+		if wokenOn.Before(end) {
+			end = wokenOn
+		}
+	}
+	start := run.HibernatedOn
+	if start.Before(from) {
+		start = from
+	}
+	if !end.After(start) {
+		return runDto
+	}
+	runDto.HibernatedHours = end.Sub(start).Hours()
+	runDto.CpuCoreHours = float64(run.CpuRequestMilli) / 1000 * runDto.HibernatedHours
+	runDto.MemoryGibHours = float64(run.MemoryRequestBytes) / capacityBean.Gibibyte * runDto.HibernatedHours
+	runDto.EstimatedSavings = runDto.CpuCoreHours*costConfig.CpuCoreHourCost + runDto.MemoryGibHours*costConfig.MemoryGibHourCost
+	return runDto
+}
+
+// getSuccessfulAppIds returns the ids of the applications a bulk hibernate or un-hibernate acted upon
+func getSuccessfulAppIds(response *bulkAction.BulkApplicationHibernateUnhibernateForEnvironmentResponse) []int {
+	appIds := make([]int, 0, len(response.Response))
+	for _, appResponse := range response.Response {
+		if success, ok := appResponse["success"].(bool); !ok || !success {
+			continue
+		}
+		appIdStr, _ := appResponse["id"].(string)
+		if appId, err := strconv.Atoi(appIdStr); err == nil {
+			appIds = append(appIds, appId)
+		}
+	}
+	return appIds
+}
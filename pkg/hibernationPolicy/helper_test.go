@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hibernationPolicy
+
+import (
+	"github.com/devtron-labs/devtron/pkg/bulkAction"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/bean"
+	"github.com/devtron-labs/devtron/pkg/hibernationPolicy/repository"
+	capacityBean "github.com/devtron-labs/devtron/pkg/k8s/capacity/bean"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetDesiredState(t *testing.T) {
+	policy := &repository.HibernationPolicy{
+		Timezone:         "Asia/Kolkata",
+		WeekdayWakeTime:  "09:00",
+		WeekdaySleepTime: "20:00",
+		Holidays:         []string{"2024-03-25"},
+	}
+	// Monday 10:00 in Kolkata
+	monday := time.Date(2024, 3, 11, 4, 30, 0, 0, time.UTC)
+	state, err := getDesiredState(policy, monday)
+	assert.Nil(t, err)
+	assert.Equal(t, bean.PolicyStateAwake, state)
+
+	// Monday 20:30 in Kolkata
+	state, _ = getDesiredState(policy, monday.Add(10*time.Hour+30*time.Minute))
+	assert.Equal(t, bean.PolicyStateHibernated, state)
+
+	// Saturday noon, no weekend schedule
+	saturday := time.Date(2024, 3, 16, 6, 30, 0, 0, time.UTC)
+	state, _ = getDesiredState(policy, saturday)
+	assert.Equal(t, bean.PolicyStateHibernated, state)
+	policy.WeekendWakeTime, policy.WeekendSleepTime = "11:00", "13:00"
+	state, _ = getDesiredState(policy, saturday)
+	assert.Equal(t, bean.PolicyStateAwake, state)
+
+	// holiday on a Monday
+	state, _ = getDesiredState(policy, time.Date(2024, 3, 25, 4, 30, 0, 0, time.UTC))
+	assert.Equal(t, bean.PolicyStateHibernated, state)
+
+	policy.KeepAwakeUntil = saturday.Add(time.Hour)
+	state, _ = getDesiredState(policy, saturday.Add(-12*time.Hour))
+	assert.Equal(t, bean.PolicyStateAwake, state)
+}
+
+func TestIsWithinAwakeHours(t *testing.T) {
+	awake, err := isWithinAwakeHours("22:00", "06:00", 23*60)
+	assert.Nil(t, err)
+	assert.True(t, awake)
+	awake, _ = isWithinAwakeHours("22:00", "06:00", 7*60)
+	assert.False(t, awake)
+	awake, _ = isWithinAwakeHours("08:00", "08:00", 3*60)
+	assert.True(t, awake)
+	_, err = isWithinAwakeHours("25:00", "06:00", 0)
+	assert.NotNil(t, err)
+}
+
+func TestSumPodRequests(t *testing.T) {
+	pods := []*capacityBean.PodCapacityDetail{
+		{Name: "web-dev-5d9f-abc", Cpu: &capacityBean.ResourceDetailObject{Request: "250m"}, Memory: &capacityBean.ResourceDetailObject{Request: "256Mi"}},
+		{Name: "web-api-dev-7c4b-xyz", Cpu: &capacityBean.ResourceDetailObject{Request: "2000m"}, Memory: &capacityBean.ResourceDetailObject{Request: "2Gi"}},
+		{Name: "other-dev-1-abc", Cpu: &capacityBean.ResourceDetailObject{Request: "1000m"}, Memory: &capacityBean.ResourceDetailObject{Request: "1Gi"}},
+	}
+	cpuMilli, memoryBytes := sumPodRequests(pods, []string{"web-dev", "web-api-dev"})
+	assert.Equal(t, int64(2250), cpuMilli)
+	assert.Equal(t, int64(256*capacityBean.Mebibyte+2*capacityBean.Gibibyte), memoryBytes)
+	cpuMilli, _ = sumPodRequests(pods, []string{"api-dev"})
+	assert.Equal(t, int64(0), cpuMilli)
+}
+
+func TestGetRunSavings(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+	costConfig := &bean.HibernationCostConfig{CpuCoreHourCost: 0.5, MemoryGibHourCost: 0.1}
+	run := &repository.HibernationRun{
+		HibernatedOn:       from.Add(-2 * time.Hour),
+		WokenOn:            from.Add(10 * time.Hour),
+		CpuRequestMilli:    2000,
+		MemoryRequestBytes: 4 * capacityBean.Gibibyte,
+	}
+	runDto := getRunSavings(run, from, to, costConfig)
+	assert.Equal(t, 10.0, runDto.HibernatedHours)
+	assert.Equal(t, 20.0, runDto.CpuCoreHours)
+	assert.Equal(t, 40.0, runDto.MemoryGibHours)
+	assert.InDelta(t, 14.0, runDto.EstimatedSavings, 0.0001)
+
+	// still hibernated, counted from the start till the end of the period
+	run.WokenOn = time.Time{}
+	assert.Equal(t, 48.0, getRunSavings(run, from, to, costConfig).HibernatedHours)
+}
+
+func TestGetSuccessfulAppIds(t *testing.T) {
+	response := &bulkAction.BulkApplicationHibernateUnhibernateForEnvironmentResponse{
+		Response: []map[string]any{
+			{"id": "1", "appName": "web", "success": true},
+			{"id": "2", "appName": "api", "success": false, bulkAction.Skipped: "Application is already hibernated"},
+		},
+	}
+	assert.Equal(t, []int{1}, getSuccessfulAppIds(response))
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type HibernationPolicy struct {
+	tableName        struct{}  `sql:"hibernation_policy" pg:",discard_unknown_columns"`
+	Id               int       `sql:"id,pk"`
+	Name             string    `sql:"name,notnull"`
+	EnvId            int       `sql:"env_id,notnull"`
+	ResourceGroupId  int       `sql:"resource_group_id"`
+	Timezone         string    `sql:"timezone,notnull"`
+	WeekdayWakeTime  string    `sql:"weekday_wake_time,notnull"`
+	WeekdaySleepTime string    `sql:"weekday_sleep_time,notnull"`
+	WeekendWakeTime  string    `sql:"weekend_wake_time"`
+	WeekendSleepTime string    `sql:"weekend_sleep_time"`
+	Holidays         []string  `sql:"holidays" pg:",array"`
+	Enabled          bool      `sql:"enabled,notnull"`
+	KeepAwakeUntil   time.Time `sql:"keep_awake_until"`
+	KeepAwakeBy      int32     `sql:"keep_awake_by"`
+	CurrentState     string    `sql:"current_state"`
+	Active           bool      `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// HibernationRun is a stretch of time for which a policy kept its applications hibernated
+type HibernationRun struct {
+	tableName          struct{}  `sql:"hibernation_run" pg:",discard_unknown_columns"`
+	Id                 int       `sql:"id,pk"`
+	PolicyId           int       `sql:"policy_id,notnull"`
+	HibernatedOn       time.Time `sql:"hibernated_on,notnull"`
+	WokenOn            time.Time `sql:"woken_on"`
+	AppIds             []int     `sql:"app_ids" pg:",array"`
+	CpuRequestMilli    int64     `sql:"cpu_request_milli,notnull"`
+	MemoryRequestBytes int64     `sql:"memory_request_bytes,notnull"`
+	Message            string    `sql:"message"`
+}
+
+type HibernationPolicyRepository interface {
+	SavePolicy(policy *HibernationPolicy) error
+	UpdatePolicy(policy *HibernationPolicy) error
+	FindPolicyById(id int) (*HibernationPolicy, error)
+	FindPoliciesByEnvId(envId int) ([]*HibernationPolicy, error)
+	FindAllEnabledPolicies() ([]*HibernationPolicy, error)
+
+	SaveRun(run *HibernationRun) error
+	UpdateRun(run *HibernationRun) error
+	// FindOpenRun returns the run of the policy whose applications have not been woken up yet
+	FindOpenRun(policyId int) (*HibernationRun, error)
+	// FindRunsOverlapping returns the runs of the policy for which the applications were hibernated at some point in the period
+	FindRunsOverlapping(policyId int, from, to time.Time) ([]*HibernationRun, error)
+}
+
+type HibernationPolicyRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewHibernationPolicyRepositoryImpl(dbConnection *pg.DB) *HibernationPolicyRepositoryImpl {
+	return &HibernationPolicyRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *HibernationPolicyRepositoryImpl) SavePolicy(policy *HibernationPolicy) error {
+	return impl.dbConnection.Insert(policy)
+}
+
+func (impl *HibernationPolicyRepositoryImpl) UpdatePolicy(policy *HibernationPolicy) error {
+	return impl.dbConnection.Update(policy)
+}
+
+func (impl *HibernationPolicyRepositoryImpl) FindPolicyById(id int) (*HibernationPolicy, error) {
+	policy := &HibernationPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl *HibernationPolicyRepositoryImpl) FindPoliciesByEnvId(envId int) ([]*HibernationPolicy, error) {
+	policies := make([]*HibernationPolicy, 0)
+	err := impl.dbConnection.Model(&policies).
+		Where("env_id = ?", envId).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return policies, err
+}
+
+func (impl *HibernationPolicyRepositoryImpl) FindAllEnabledPolicies() ([]*HibernationPolicy, error) {
+	policies := make([]*HibernationPolicy, 0)
+	err := impl.dbConnection.Model(&policies).
+		Where("enabled = ?", true).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return policies, err
+}
+
+func (impl *HibernationPolicyRepositoryImpl) SaveRun(run *HibernationRun) error {
+	return impl.dbConnection.Insert(run)
+}
+
+func (impl *HibernationPolicyRepositoryImpl) UpdateRun(run *HibernationRun) error {
+	return impl.dbConnection.Update(run)
+}
+
+func (impl *HibernationPolicyRepositoryImpl) FindOpenRun(policyId int) (*HibernationRun, error) {
+	run := &HibernationRun{}
+	err := impl.dbConnection.Model(run).
+		Where("policy_id = ?", policyId).
+		Where("woken_on IS NULL").
+		Order("id DESC").
+		Limit(1).
+		Select()
+	return run, err
+}
+
+func (impl *HibernationPolicyRepositoryImpl) FindRunsOverlapping(policyId int, from, to time.Time) ([]*HibernationRun, error) {
+	runs := make([]*HibernationRun, 0)
+	err := impl.dbConnection.Model(&runs).
+		Where("policy_id = ?", policyId).
+		Where("hibernated_on < ?", to).
+		Where("woken_on IS NULL OR woken_on > ?", from).
+		Order("hibernated_on ASC").
+		Select()
+	return runs, err
+}
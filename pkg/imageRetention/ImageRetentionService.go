@@ -0,0 +1,355 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageRetention
+
+import (
+	"context"
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry/registryClient"
+	"github.com/devtron-labs/devtron/pkg/imageRetention/bean"
+	"github.com/devtron-labs/devtron/pkg/imageRetention/repository"
+	"github.com/devtron-labs/devtron/pkg/pipeline"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type ImageRetentionService interface {
+	SavePolicy(policy *bean.ImageRetentionPolicyDto) (*bean.ImageRetentionPolicyDto, error)
+	DeletePolicy(id int, userId int32) error
+	GetPolicy(id int) (*bean.ImageRetentionPolicyDto, error)
+	GetPolicies(dockerRegistryId string) ([]*bean.ImageRetentionPolicyDto, error)
+	// GetRetentionPlan is the dry run of a policy, it lists what would be deleted without deleting anything
+	GetRetentionPlan(policyId int) (*bean.RetentionPlanDto, error)
+	// ApplyPolicy deletes from the registry the images the plan of the policy does not keep, and logs each deletion
+	ApplyPolicy(policyId int, userId int32) ([]*bean.ImageDeletionDto, error)
+	// ApplyAllPolicies applies the enabled policies of the registries supporting deletion
+	ApplyAllPolicies()
+	GetDeletions(policyId int, limit int) ([]*bean.ImageDeletionDto, error)
+}
+
+type ImageRetentionServiceImpl struct {
+	logger                   *zap.SugaredLogger
+	imageRetentionRepository repository.ImageRetentionRepository
+	dockerRegistryConfig     pipeline.DockerRegistryConfig
+	imageTaggingService      pipeline.ImageTaggingService
+	customTagService         pipeline.CustomTagService
+	config                   *bean.ImageRetentionConfig
+}
+
+func NewImageRetentionServiceImpl(logger *zap.SugaredLogger,
+	imageRetentionRepository repository.ImageRetentionRepository,
+	dockerRegistryConfig pipeline.DockerRegistryConfig,
+	imageTaggingService pipeline.ImageTaggingService,
+	customTagService pipeline.CustomTagService) (*ImageRetentionServiceImpl, error) {
+	config := &bean.ImageRetentionConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing image retention config", "err", err)
+		return nil, err
+	}
+	return &ImageRetentionServiceImpl{
+		logger:                   logger,
+		imageRetentionRepository: imageRetentionRepository,
+		dockerRegistryConfig:     dockerRegistryConfig,
+		imageTaggingService:      imageTaggingService,
+		customTagService:         customTagService,
+		config:                   config,
+	}, nil
+}
+
+func (impl *ImageRetentionServiceImpl) SavePolicy(policy *bean.ImageRetentionPolicyDto) (*bean.ImageRetentionPolicyDto, error) {
+	// keeping at least the latest image of each repository leaves something to roll forward from
+	if policy.KeepLastCount < 1 {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(bean.InvalidKeepLastCount).WithInternalMessage(bean.InvalidKeepLastCount)
+	}
+	store, err := impl.dockerRegistryConfig.FetchOneDockerAccount(policy.DockerRegistryId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching docker registry", "dockerRegistryId", policy.DockerRegistryId, "err", err)
+		return nil, err
+	}
+	if policy.Enabled && !registryClient.IsSupportedRegistryType(store.RegistryType) {
+		message := fmt.Sprintf(bean.UnsupportedRegistry, store.RegistryType)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(message).WithInternalMessage(message)
+	}
+	dbObject := adaptToPolicyModel(policy)
+	if policy.Id > 0 {
+		existing, err := impl.imageRetentionRepository.FindPolicyById(policy.Id)
+		if err != nil {
+			impl.logger.Errorw("error in fetching image retention policy", "id", policy.Id, "err", err)
+			return nil, err
+		}
+		dbObject.CreatedOn = existing.CreatedOn
+		dbObject.CreatedBy = existing.CreatedBy
+		err = impl.imageRetentionRepository.UpdatePolicy(dbObject)
+	} else {
+		err = impl.imageRetentionRepository.SavePolicy(dbObject)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving image retention policy", "policy", policy, "err", err)
+		return nil, err
+	}
+	policy.Id = dbObject.Id
+	return policy, nil
+}
+
+func (impl *ImageRetentionServiceImpl) DeletePolicy(id int, userId int32) error {
+	policy, err := impl.imageRetentionRepository.FindPolicyById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image retention policy", "id", id, "err", err)
+		return err
+	}
+	policy.Active = false
+	policy.UpdateAuditLog(userId)
+	err = impl.imageRetentionRepository.UpdatePolicy(policy)
+	if err != nil {
+		impl.logger.Errorw("error in deleting image retention policy", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *ImageRetentionServiceImpl) GetPolicy(id int) (*bean.ImageRetentionPolicyDto, error) {
+	policy, err := impl.imageRetentionRepository.FindPolicyById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image retention policy", "id", id, "err", err)
+		return nil, err
+	}
+	return adaptToPolicyDto(policy), nil
+}
+
+func (impl *ImageRetentionServiceImpl) GetPolicies(dockerRegistryId string) ([]*bean.ImageRetentionPolicyDto, error) {
+	policies, err := impl.imageRetentionRepository.FindPolicies(dockerRegistryId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image retention policies", "dockerRegistryId", dockerRegistryId, "err", err)
+		return nil, err
+	}
+	result := make([]*bean.ImageRetentionPolicyDto, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, adaptToPolicyDto(policy))
+	}
+	return result, nil
+}
+
+func (impl *ImageRetentionServiceImpl) GetRetentionPlan(policyId int) (*bean.RetentionPlanDto, error) {
+	policy, err := impl.GetPolicy(policyId)
+	if err != nil {
+		return nil, err
+	}
+	store, err := impl.dockerRegistryConfig.FetchOneDockerAccount(policy.DockerRegistryId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching docker registry", "dockerRegistryId", policy.DockerRegistryId, "err", err)
+		return nil, err
+	}
+	plan, err := impl.buildPlan(policy)
+	if err != nil {
+		return nil, err
+	}
+	planDto := &bean.RetentionPlanDto{
+		PolicyId:        policy.Id,
+		DeleteSupported: registryClient.IsSupportedRegistryType(store.RegistryType),
+		TotalImages:     len(plan),
+		Items:           plan,
+	}
+	for _, item := range plan {
+		if item.Action == bean.RetentionActionDelete {
+			planDto.ImagesToDelete++
+		}
+	}
+	return planDto, nil
+}
+
+func (impl *ImageRetentionServiceImpl) buildPlan(policy *bean.ImageRetentionPolicyDto) ([]*bean.RetentionPlanItem, error) {
+	artifacts, err := impl.imageRetentionRepository.FindCandidateArtifacts(policy.DockerRegistryId, policy.AppId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching candidate artifacts", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	images := make([]string, 0, len(artifacts))
+	appIds := make(map[int]bool)
+	for _, artifact := range artifacts {
+		images = append(images, artifact.Image)
+		appIds[artifact.AppId] = true
+	}
+	deployments, err := impl.imageRetentionRepository.FindLastDeployments(images)
+	if err != nil {
+		impl.logger.Errorw("error in fetching last deployments of images", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	lastDeployedOnByImage := make(map[string]time.Time, len(deployments))
+	for _, deployment := range deployments {
+		lastDeployedOnByImage[deployment.Image] = deployment.LastDeployedOn
+	}
+	runningImages, err := impl.imageRetentionRepository.FindRunningImages(images)
+	if err != nil {
+		impl.logger.Errorw("error in fetching running images", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	running := make(map[string]bool, len(runningImages))
+	for _, image := range runningImages {
+		running[image] = true
+	}
+	tagged := make(map[int]bool)
+	if policy.KeepTagged {
+		for appId := range appIds {
+			tagsByArtifactId, err := impl.imageTaggingService.GetTagsDataMapByAppId(appId)
+			if err != nil {
+				impl.logger.Errorw("error in fetching image tags", "appId", appId, "err", err)
+				return nil, err
+			}
+			for artifactId, tags := range tagsByArtifactId {
+				tagged[artifactId] = len(tags) > 0
+			}
+		}
+	}
+	candidates := make([]*bean.ImageCandidate, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		imageRepository, tag, err := parseImage(artifact.Image)
+		if err != nil {
+			// an image which cannot be parsed cannot be deleted either
+			impl.logger.Warnw("skipping artifact with invalid image", "artifactId", artifact.ArtifactId, "image", artifact.Image, "err", err)
+			continue
+		}
+		candidates = append(candidates, &bean.ImageCandidate{
+			ArtifactId:     artifact.ArtifactId,
+			AppId:          artifact.AppId,
+			Image:          artifact.Image,
+			ImageDigest:    artifact.ImageDigest,
+			Repository:     imageRepository,
+			Tag:            tag,
+			CreatedOn:      artifact.CreatedOn,
+			LastDeployedOn: lastDeployedOnByImage[artifact.Image],
+			Running:        running[artifact.Image],
+			Tagged:         tagged[artifact.ArtifactId],
+		})
+	}
+	return BuildRetentionPlan(policy, candidates, time.Now()), nil
+}
+
+func (impl *ImageRetentionServiceImpl) ApplyPolicy(policyId int, userId int32) ([]*bean.ImageDeletionDto, error) {
+	policy, err := impl.GetPolicy(policyId)
+	if err != nil {
+		return nil, err
+	}
+	store, err := impl.dockerRegistryConfig.FetchOneDockerAccount(policy.DockerRegistryId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching docker registry", "dockerRegistryId", policy.DockerRegistryId, "err", err)
+		return nil, err
+	}
+	if !registryClient.IsSupportedRegistryType(store.RegistryType) {
+		message := fmt.Sprintf(bean.UnsupportedRegistry, store.RegistryType)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(message).WithInternalMessage(message)
+	}
+	client, err := registryClient.NewRegistryClient(registryClient.GetRegistryConfig(store))
+	if err != nil {
+		impl.logger.Errorw("error in creating registry client", "dockerRegistryId", store.Id, "err", err)
+		return nil, err
+	}
+	plan, err := impl.buildPlan(policy)
+	if err != nil {
+		return nil, err
+	}
+	deletions := make([]*bean.ImageDeletionDto, 0)
+	for _, item := range plan {
+		if item.Action != bean.RetentionActionDelete {
+			continue
+		}
+		if len(deletions) >= impl.config.ImageRetentionMaxDeletionsPerRun {
+			break
+		}
+		deletion := impl.deleteImage(client, policy, item, userId)
+		if deletion != nil {
+			deletions = append(deletions, adaptToDeletionDto(deletion))
+		}
+	}
+	impl.logger.Infow("applied image retention policy", "policyId", policy.Id, "message", fmt.Sprintf(bean.RetentionRunMessage, countDeleted(deletions), len(deletions)))
+	return deletions, nil
+}
+
+// deleteImage deletes the image from the registry and releases its path for custom tags, the outcome is logged
+// in the audit whether the deletion succeeded or not
+func (impl *ImageRetentionServiceImpl) deleteImage(client registryClient.RegistryClient, policy *bean.ImageRetentionPolicyDto,
+	item *bean.RetentionPlanItem, userId int32) *repository.ImageDeletion {
+	deletion := &repository.ImageDeletion{
+		PolicyId:    policy.Id,
+		ArtifactIds: item.ArtifactIds,
+		Image:       item.Image,
+		Status:      string(bean.DeletionStatusDeleted),
+		DeletedOn:   time.Now(),
+		DeletedBy:   userId,
+	}
+	imageRepository, tag, err := parseImage(item.Image)
+	if err == nil {
+		err = client.DeleteImage(context.Background(), imageRepository, tag)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in deleting image from registry", "policyId", policy.Id, "image", item.Image, "err", err)
+		deletion.Status = string(bean.DeletionStatusFailed)
+		deletion.ErrorMessage = err.Error()
+	} else {
+		err = impl.customTagService.DeactivateImagePathReservationByImagePath([]string{item.Image})
+		if err != nil {
+			impl.logger.Errorw("error in releasing image path reservation", "image", item.Image, "err", err)
+		}
+	}
+	err = impl.imageRetentionRepository.SaveDeletion(deletion)
+	if err != nil {
+		impl.logger.Errorw("error in saving image deletion audit", "policyId", policy.Id, "image", item.Image, "err", err)
+		return nil
+	}
+	return deletion
+}
+
+func countDeleted(deletions []*bean.ImageDeletionDto) int {
+	deleted := 0
+	for _, deletion := range deletions {
+		if deletion.Status == bean.DeletionStatusDeleted {
+			deleted++
+		}
+	}
+	return deleted
+}
+
+func (impl *ImageRetentionServiceImpl) ApplyAllPolicies() {
+	policies, err := impl.imageRetentionRepository.FindAllEnabledPolicies()
+	if err != nil {
+		impl.logger.Errorw("error in fetching enabled image retention policies", "err", err)
+		return
+	}
+	for _, policy := range policies {
+		_, err = impl.ApplyPolicy(policy.Id, userBean.SystemUserId)
+		if err != nil {
+			impl.logger.Errorw("error in applying image retention policy", "policyId", policy.Id, "err", err)
+		}
+	}
+}
+
+func (impl *ImageRetentionServiceImpl) GetDeletions(policyId int, limit int) ([]*bean.ImageDeletionDto, error) {
+	deletions, err := impl.imageRetentionRepository.FindDeletionsByPolicyId(policyId, limit)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image deletions", "policyId", policyId, "err", err)
+		return nil, err
+	}
+	result := make([]*bean.ImageDeletionDto, 0, len(deletions))
+	for _, deletion := range deletions {
+		result = append(result, adaptToDeletionDto(deletion))
+	}
+	return result, nil
+}
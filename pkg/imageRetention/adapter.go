@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageRetention
+
+import (
+	"github.com/devtron-labs/devtron/pkg/imageRetention/bean"
+	"github.com/devtron-labs/devtron/pkg/imageRetention/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func adaptToPolicyModel(policy *bean.ImageRetentionPolicyDto) *repository.ImageRetentionPolicy {
+	return &repository.ImageRetentionPolicy{
+		Id:                     policy.Id,
+		Name:                   policy.Name,
+		DockerRegistryId:       policy.DockerRegistryId,
+		AppId:                  policy.AppId,
+		KeepLastCount:          policy.KeepLastCount,
+		KeepDeployedWithinDays: policy.KeepDeployedWithinDays,
+		KeepTagged:             policy.KeepTagged,
+		Enabled:                policy.Enabled,
+		Active:                 true,
+		AuditLog:               sql.NewDefaultAuditLog(policy.UserId),
+	}
+}
+
+func adaptToPolicyDto(policy *repository.ImageRetentionPolicy) *bean.ImageRetentionPolicyDto {
+	return &bean.ImageRetentionPolicyDto{
+		Id:                     policy.Id,
+		Name:                   policy.Name,
+		DockerRegistryId:       policy.DockerRegistryId,
+		AppId:                  policy.AppId,
+		KeepLastCount:          policy.KeepLastCount,
+		KeepDeployedWithinDays: policy.KeepDeployedWithinDays,
+		KeepTagged:             policy.KeepTagged,
+		Enabled:                policy.Enabled,
+	}
+}
+
+func adaptToDeletionDto(deletion *repository.ImageDeletion) *bean.ImageDeletionDto {
+	return &bean.ImageDeletionDto{
+		Id:           deletion.Id,
+		PolicyId:     deletion.PolicyId,
+		ArtifactIds:  deletion.ArtifactIds,
+		Image:        deletion.Image,
+		Status:       bean.DeletionStatus(deletion.Status),
+		ErrorMessage: deletion.ErrorMessage,
+		DeletedOn:    deletion.DeletedOn,
+		DeletedBy:    deletion.DeletedBy,
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type RetentionAction string
+
+const (
+	RetentionActionKeep   RetentionAction = "KEEP"
+	RetentionActionDelete RetentionAction = "DELETE"
+)
+
+// KeepReason is why the plan keeps an image, the first matching rule is reported
+type KeepReason string
+
+const (
+	KeepReasonRunning  KeepReason = "RUNNING"
+	KeepReasonLatest   KeepReason = "LATEST"
+	KeepReasonDeployed KeepReason = "RECENTLY_DEPLOYED"
+	KeepReasonTagged   KeepReason = "TAGGED"
+	// KeepReasonSharedDigest keeps an image whose manifest is also the one of a kept image, deleting the manifest
+	// deletes all its tags
+	KeepReasonSharedDigest KeepReason = "SHARED_DIGEST"
+)
+
+type DeletionStatus string
+
+const (
+	DeletionStatusDeleted DeletionStatus = "DELETED"
+	DeletionStatusFailed  DeletionStatus = "FAILED"
+)
+
+const (
+	UnsupportedRegistry  = "images cannot be deleted from %s registry, only the dry run report is available"
+	InvalidKeepLastCount = "keep last count must be at least 1"
+	RetentionRunMessage  = "deleted %d of %d images"
+)
+
+// ImageRetentionPolicyDto deletes the images built for the apps of a registry, or for one app, except the ones kept by
+// its rules. Images currently deployed are always kept
+type ImageRetentionPolicyDto struct {
+	Id                     int    `json:"id"`
+	Name                   string `json:"name" validate:"required,max=50"`
+	DockerRegistryId       string `json:"dockerRegistryId" validate:"required"`
+	AppId                  int    `json:"appId,omitempty"`
+	KeepLastCount          int    `json:"keepLastCount"`
+	KeepDeployedWithinDays int    `json:"keepDeployedWithinDays"`
+	KeepTagged             bool   `json:"keepTagged"`
+	Enabled                bool   `json:"enabled"`
+	UserId                 int32  `json:"-"`
+}
+
+// ImageCandidate is an image built in the registry along with what the retention rules look at
+type ImageCandidate struct {
+	ArtifactId     int
+	AppId          int
+	Image          string
+	ImageDigest    string
+	Repository     string
+	Tag            string
+	CreatedOn      time.Time
+	LastDeployedOn time.Time
+	Running        bool
+	Tagged         bool
+}
+
+type RetentionPlanItem struct {
+	ArtifactIds    []int           `json:"artifactIds"`
+	AppId          int             `json:"appId"`
+	Image          string          `json:"image"`
+	ImageDigest    string          `json:"imageDigest,omitempty"`
+	CreatedOn      time.Time       `json:"createdOn"`
+	LastDeployedOn *time.Time      `json:"lastDeployedOn,omitempty"`
+	Action         RetentionAction `json:"action"`
+	KeepReason     KeepReason      `json:"keepReason,omitempty"`
+}
+
+// RetentionPlanDto is the dry run report of a policy, nothing is deleted while computing it
+type RetentionPlanDto struct {
+	PolicyId        int                  `json:"policyId"`
+	DeleteSupported bool                 `json:"deleteSupported"`
+	TotalImages     int                  `json:"totalImages"`
+	ImagesToDelete  int                  `json:"imagesToDelete"`
+	Items           []*RetentionPlanItem `json:"items"`
+}
+
+type ImageDeletionDto struct {
+	Id           int            `json:"id"`
+	PolicyId     int            `json:"policyId"`
+	ArtifactIds  []int          `json:"artifactIds"`
+	Image        string         `json:"image"`
+	Status       DeletionStatus `json:"status"`
+	ErrorMessage string         `json:"errorMessage,omitempty"`
+	DeletedOn    time.Time      `json:"deletedOn"`
+	DeletedBy    int32          `json:"deletedBy"`
+}
+
+type ImageRetentionConfig struct {
+	// ImageRetentionMaxDeletionsPerRun caps the images deleted for a policy in a run, the rest are deleted in the next runs
+	ImageRetentionMaxDeletionsPerRun int `env:"IMAGE_RETENTION_MAX_DELETIONS_PER_RUN" envDefault:"100"`
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageRetention
+
+import (
+	"github.com/devtron-labs/devtron/pkg/imageRetention/bean"
+	"github.com/devtron-labs/devtron/util"
+	"sort"
+	"strings"
+	"time"
+)
+
+// parseImage splits an image into its repository, relative to the registry host, and its tag
+func parseImage(image string) (repository string, tag string, err error) {
+	imageMetadata, err := util.ExtractImageRepoAndTag(image)
+	if err != nil {
+		return "", "", err
+	}
+	_, repository, _ = strings.Cut(imageMetadata.Repo, "/")
+	return repository, imageMetadata.Tag, nil
+}
+
+// mergeCandidates merges the artifacts pointing to the same image, an image is kept if any of them is
+func mergeCandidates(candidates []*bean.ImageCandidate) map[string]*bean.RetentionPlanItem {
+	itemsByImage := make(map[string]*bean.RetentionPlanItem)
+	for _, candidate := range candidates {
+		item, ok := itemsByImage[candidate.Image]
+		if !ok {
+			item = &bean.RetentionPlanItem{
+				AppId:       candidate.AppId,
+				Image:       candidate.Image,
+				ImageDigest: candidate.ImageDigest,
+				CreatedOn:   candidate.CreatedOn,
+			}
+			itemsByImage[candidate.Image] = item
+		}
+		item.ArtifactIds = append(item.ArtifactIds, candidate.ArtifactId)
+		if candidate.CreatedOn.After(item.CreatedOn) {
+			item.CreatedOn = candidate.CreatedOn
+		}
+	}
+	return itemsByImage
+}
+
+// BuildRetentionPlan decides for each image whether the policy keeps it. Images currently running are always kept,
+// then the latest KeepLastCount images of each repository, the ones deployed within KeepDeployedWithinDays and the
+// tagged ones if KeepTagged is set. Images sharing the manifest of a kept image are kept too
+func BuildRetentionPlan(policy *bean.ImageRetentionPolicyDto, candidates []*bean.ImageCandidate, now time.Time) []*bean.RetentionPlanItem {
+	itemsByImage := mergeCandidates(candidates)
+	candidateByImage := make(map[string]*bean.ImageCandidate, len(itemsByImage))
+	itemsByRepository := make(map[string][]*bean.RetentionPlanItem)
+	for _, candidate := range candidates {
+		merged, ok := candidateByImage[candidate.Image]
+		if !ok {
+			merged = &bean.ImageCandidate{Image: candidate.Image, Repository: candidate.Repository}
+			candidateByImage[candidate.Image] = merged
+			itemsByRepository[candidate.Repository] = append(itemsByRepository[candidate.Repository], itemsByImage[candidate.Image])
+		}
+		merged.Running = merged.Running || candidate.Running
+		merged.Tagged = merged.Tagged || candidate.Tagged
+		if candidate.LastDeployedOn.After(merged.LastDeployedOn) {
+			merged.LastDeployedOn = candidate.LastDeployedOn
+		}
+	}
+	deployedAfter := now.AddDate(0, 0, -policy.KeepDeployedWithinDays)
+	repositories := make([]string, 0, len(itemsByRepository))
+	for repository := range itemsByRepository {
+		repositories = append(repositories, repository)
+	}
+	sort.Strings(repositories)
+	plan := make([]*bean.RetentionPlanItem, 0, len(itemsByImage))
+	for _, repository := range repositories {
+		items := itemsByRepository[repository]
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].CreatedOn.After(items[j].CreatedOn)
+		})
+		for rank, item := range items {
+			candidate := candidateByImage[item.Image]
+			if !candidate.LastDeployedOn.IsZero() {
+				lastDeployedOn := candidate.LastDeployedOn
+				item.LastDeployedOn = &lastDeployedOn
+			}
+			item.Action = bean.RetentionActionKeep
+			switch {
+			case candidate.Running:
+				item.KeepReason = bean.KeepReasonRunning
+			case rank < policy.KeepLastCount:
+				item.KeepReason = bean.KeepReasonLatest
+			case policy.KeepDeployedWithinDays > 0 && candidate.LastDeployedOn.After(deployedAfter):
+				item.KeepReason = bean.KeepReasonDeployed
+			case policy.KeepTagged && candidate.Tagged:
+				item.KeepReason = bean.KeepReasonTagged
+			default:
+				item.Action = bean.RetentionActionDelete
+			}
+			plan = append(plan, item)
+		}
+	}
+	keptDigests := make(map[string]bool)
+	for _, item := range plan {
+		if item.Action == bean.RetentionActionKeep && len(item.ImageDigest) > 0 {
+			keptDigests[item.ImageDigest] = true
+		}
+	}
+	for _, item := range plan {
+		if item.Action == bean.RetentionActionDelete && keptDigests[item.ImageDigest] {
+			item.Action = bean.RetentionActionKeep
+			item.KeepReason = bean.KeepReasonSharedDigest
+		}
+	}
+	return plan
+}
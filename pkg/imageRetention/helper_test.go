@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageRetention
+
+import (
+	"github.com/devtron-labs/devtron/pkg/imageRetention/bean"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseImage(t *testing.T) {
+	repository, tag, err := parseImage("harbor.example.com/team/api:a1b2c3")
+	assert.Nil(t, err)
+	assert.Equal(t, "team/api", repository)
+	assert.Equal(t, "a1b2c3", tag)
+}
+
+func TestBuildRetentionPlan(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+	candidates := []*bean.ImageCandidate{
+		{ArtifactId: 1, Image: "r/api:1", Repository: "api", CreatedOn: daysAgo(50)},
+		{ArtifactId: 2, Image: "r/api:2", Repository: "api", CreatedOn: daysAgo(40), Running: true},
+		{ArtifactId: 3, Image: "r/api:3", Repository: "api", CreatedOn: daysAgo(30), LastDeployedOn: daysAgo(5)},
+		{ArtifactId: 4, Image: "r/api:4", Repository: "api", CreatedOn: daysAgo(20), Tagged: true},
+		{ArtifactId: 5, Image: "r/api:5", Repository: "api", CreatedOn: daysAgo(10), LastDeployedOn: daysAgo(20)},
+		{ArtifactId: 6, Image: "r/api:6", Repository: "api", CreatedOn: daysAgo(2)},
+		// an artifact of a linked pipeline sharing the image of artifact 1
+		{ArtifactId: 7, Image: "r/api:1", Repository: "api", CreatedOn: daysAgo(49)},
+		{ArtifactId: 8, Image: "r/web:1", Repository: "web", CreatedOn: daysAgo(60)},
+		// a retag of the running image
+		{ArtifactId: 9, Image: "r/api:0", ImageDigest: "sha256:2", Repository: "api", CreatedOn: daysAgo(70)},
+		{ArtifactId: 10, Image: "r/api:2-stable", ImageDigest: "sha256:2", Repository: "api", CreatedOn: daysAgo(40), Running: true},
+	}
+	policy := &bean.ImageRetentionPolicyDto{KeepLastCount: 1, KeepDeployedWithinDays: 7, KeepTagged: true}
+
+	plan := BuildRetentionPlan(policy, candidates, now)
+
+	actions := make(map[string]bean.RetentionAction)
+	reasons := make(map[string]bean.KeepReason)
+	for _, item := range plan {
+		actions[item.Image] = item.Action
+		reasons[item.Image] = item.KeepReason
+	}
+	assert.Equal(t, 9, len(plan))
+	assert.Equal(t, bean.KeepReasonSharedDigest, reasons["r/api:0"])
+	assert.Equal(t, bean.KeepReasonLatest, reasons["r/api:6"])
+	assert.Equal(t, bean.RetentionActionDelete, actions["r/api:5"])
+	assert.Equal(t, bean.KeepReasonTagged, reasons["r/api:4"])
+	assert.Equal(t, bean.KeepReasonDeployed, reasons["r/api:3"])
+	assert.Equal(t, bean.KeepReasonRunning, reasons["r/api:2"])
+	assert.Equal(t, bean.RetentionActionDelete, actions["r/api:1"])
+	assert.Equal(t, bean.KeepReasonLatest, reasons["r/web:1"])
+	for _, item := range plan {
+		if item.Image == "r/api:1" {
+			assert.ElementsMatch(t, []int{1, 7}, item.ArtifactIds)
+		}
+	}
+
+	t.Run("tagged images are deleted when not kept by the policy", func(t *testing.T) {
+		policy := &bean.ImageRetentionPolicyDto{KeepLastCount: 1}
+		plan := BuildRetentionPlan(policy, candidates, now)
+		for _, item := range plan {
+			if item.Image == "r/api:4" || item.Image == "r/api:3" {
+				assert.Equal(t, bean.RetentionActionDelete, item.Action)
+			}
+		}
+	})
+}
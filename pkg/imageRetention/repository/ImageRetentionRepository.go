@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"fmt"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/imageRetention/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type ImageRetentionPolicy struct {
+	tableName              struct{} `sql:"image_retention_policy" pg:",discard_unknown_columns"`
+	Id                     int      `sql:"id,pk"`
+	Name                   string   `sql:"name,notnull"`
+	DockerRegistryId       string   `sql:"docker_registry_id,notnull"`
+	AppId                  int      `sql:"app_id"`
+	KeepLastCount          int      `sql:"keep_last_count,notnull"`
+	KeepDeployedWithinDays int      `sql:"keep_deployed_within_days,notnull"`
+	KeepTagged             bool     `sql:"keep_tagged,notnull"`
+	Enabled                bool     `sql:"enabled,notnull"`
+	Active                 bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// ImageDeletion is the audit log of an image deleted from the registry by a policy
+type ImageDeletion struct {
+	tableName    struct{}  `sql:"image_retention_deletion" pg:",discard_unknown_columns"`
+	Id           int       `sql:"id,pk"`
+	PolicyId     int       `sql:"policy_id,notnull"`
+	ArtifactIds  []int     `sql:"artifact_ids" pg:",array"`
+	Image        string    `sql:"image,notnull"`
+	Status       string    `sql:"status,notnull"`
+	ErrorMessage string    `sql:"error_message"`
+	DeletedOn    time.Time `sql:"deleted_on,notnull"`
+	DeletedBy    int32     `sql:"deleted_by,notnull"`
+}
+
+type CandidateArtifact struct {
+	ArtifactId  int       `sql:"artifact_id"`
+	AppId       int       `sql:"app_id"`
+	Image       string    `sql:"image"`
+	ImageDigest string    `sql:"image_digest"`
+	CreatedOn   time.Time `sql:"created_on"`
+}
+
+type ImageDeployment struct {
+	Image          string    `sql:"image"`
+	LastDeployedOn time.Time `sql:"last_deployed_on"`
+}
+
+type ImageRetentionRepository interface {
+	SavePolicy(policy *ImageRetentionPolicy) error
+	UpdatePolicy(policy *ImageRetentionPolicy) error
+	FindPolicyById(id int) (*ImageRetentionPolicy, error)
+	// FindPolicies returns the policies of the registry, or of all registries when dockerRegistryId is empty
+	FindPolicies(dockerRegistryId string) ([]*ImageRetentionPolicy, error)
+	FindAllEnabledPolicies() ([]*ImageRetentionPolicy, error)
+
+	SaveDeletion(deletion *ImageDeletion) error
+	FindDeletionsByPolicyId(policyId int, limit int) ([]*ImageDeletion, error)
+
+	// FindCandidateArtifacts returns the images built by ci into the registry for the app, or for all apps when appId is 0,
+	// leaving out the ones already deleted
+	FindCandidateArtifacts(dockerRegistryId string, appId int) ([]*CandidateArtifact, error)
+	// FindLastDeployments returns when each of the images was last deployed
+	FindLastDeployments(images []string) ([]*ImageDeployment, error)
+	// FindRunningImages returns the images of the last deployment and of the last successful deployment of every pipeline
+	FindRunningImages(images []string) ([]string, error)
+}
+
+type ImageRetentionRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewImageRetentionRepositoryImpl(dbConnection *pg.DB) *ImageRetentionRepositoryImpl {
+	return &ImageRetentionRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *ImageRetentionRepositoryImpl) SavePolicy(policy *ImageRetentionPolicy) error {
+	return impl.dbConnection.Insert(policy)
+}
+
+func (impl *ImageRetentionRepositoryImpl) UpdatePolicy(policy *ImageRetentionPolicy) error {
+	return impl.dbConnection.Update(policy)
+}
+
+func (impl *ImageRetentionRepositoryImpl) FindPolicyById(id int) (*ImageRetentionPolicy, error) {
+	policy := &ImageRetentionPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl *ImageRetentionRepositoryImpl) FindPolicies(dockerRegistryId string) ([]*ImageRetentionPolicy, error) {
+	policies := make([]*ImageRetentionPolicy, 0)
+	query := impl.dbConnection.Model(&policies).
+		Where("active = ?", true)
+	if len(dockerRegistryId) > 0 {
+		query = query.Where("docker_registry_id = ?", dockerRegistryId)
+	}
+	err := query.Order("id ASC").Select()
+	return policies, err
+}
+
+func (impl *ImageRetentionRepositoryImpl) FindAllEnabledPolicies() ([]*ImageRetentionPolicy, error) {
+	policies := make([]*ImageRetentionPolicy, 0)
+	err := impl.dbConnection.Model(&policies).
+		Where("enabled = ?", true).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return policies, err
+}
+
+func (impl *ImageRetentionRepositoryImpl) SaveDeletion(deletion *ImageDeletion) error {
+	return impl.dbConnection.Insert(deletion)
+}
+
+func (impl *ImageRetentionRepositoryImpl) FindDeletionsByPolicyId(policyId int, limit int) ([]*ImageDeletion, error) {
+	deletions := make([]*ImageDeletion, 0)
+	err := impl.dbConnection.Model(&deletions).
+		Where("policy_id = ?", policyId).
+		Order("id DESC").
+		Limit(limit).
+		Select()
+	return deletions, err
+}
+
+func (impl *ImageRetentionRepositoryImpl) FindCandidateArtifacts(dockerRegistryId string, appId int) ([]*CandidateArtifact, error) {
+	artifacts := make([]*CandidateArtifact, 0)
+	query := "SELECT cia.id AS artifact_id, cp.app_id, cia.image, cia.image_digest, cia.created_on" +
+		" FROM ci_artifact cia" +
+		" INNER JOIN ci_pipeline cp ON cp.id = cia.pipeline_id" +
+		" WHERE cia.data_source = ? AND cia.credentials_source_type = ? AND cia.credentials_source_value = ?" +
+		" AND NOT EXISTS (SELECT 1 FROM image_retention_deletion ird WHERE cia.id = ANY(ird.artifact_ids) AND ird.status = ?)"
+	params := []interface{}{repository.CI_RUNNER, repository.GLOBAL_CONTAINER_REGISTRY, dockerRegistryId, bean.DeletionStatusDeleted}
+	if appId > 0 {
+		query += " AND cp.app_id = ?"
+		params = append(params, appId)
+	}
+	query += " ORDER BY cia.id DESC"
+	_, err := impl.dbConnection.Query(&artifacts, query, params...)
+	return artifacts, err
+}
+
+func (impl *ImageRetentionRepositoryImpl) FindLastDeployments(images []string) ([]*ImageDeployment, error) {
+	deployments := make([]*ImageDeployment, 0)
+	if len(images) == 0 {
+		return deployments, nil
+	}
+	query := "SELECT cia.image, MAX(cwr.started_on) AS last_deployed_on" +
+		" FROM cd_workflow_runner cwr" +
+		" INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id" +
+		" INNER JOIN ci_artifact cia ON cia.id = cw.ci_artifact_id" +
+		" WHERE cwr.workflow_type = ? AND cia.image IN (?)" +
+		" GROUP BY cia.image"
+	_, err := impl.dbConnection.Query(&deployments, query, apiBean.CD_WORKFLOW_TYPE_DEPLOY, pg.In(images))
+	return deployments, err
+}
+
+func (impl *ImageRetentionRepositoryImpl) FindRunningImages(images []string) ([]string, error) {
+	runningImages := make([]string, 0)
+	if len(images) == 0 {
+		return runningImages, nil
+	}
+	// the last deployment may still be in progress or have failed, the previous successful one may then be running
+	latestDeploymentQuery := "(SELECT DISTINCT ON (cw.pipeline_id) cw.ci_artifact_id" +
+		" FROM cd_workflow_runner cwr" +
+		" INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id" +
+		" INNER JOIN pipeline p ON p.id = cw.pipeline_id AND p.deleted = false" +
+		" WHERE cwr.workflow_type = ? %s" +
+		" ORDER BY cw.pipeline_id, cwr.id DESC)"
+	query := "SELECT DISTINCT cia.image FROM (" +
+		fmt.Sprintf(latestDeploymentQuery, "") + " UNION " + fmt.Sprintf(latestDeploymentQuery, "AND cwr.status = ?") +
+		") latest INNER JOIN ci_artifact cia ON cia.id = latest.ci_artifact_id" +
+		" WHERE cia.image IN (?)"
+	_, err := impl.dbConnection.Query(&runningImages, query, apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+		apiBean.CD_WORKFLOW_TYPE_DEPLOY, cdWorkflow.WorkflowSucceeded, pg.In(images))
+	return runningImages, err
+}
@@ -0,0 +1,548 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageSignature
+
+import (
+	"context"
+	"fmt"
+	"github.com/caarlos0/env"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	dockerRegistryRepository "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	repository3 "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/devtronResource/bean"
+	"github.com/devtron-labs/devtron/pkg/devtronResource/read"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry/registryClient"
+	signatureBean "github.com/devtron-labs/devtron/pkg/imageSignature/bean"
+	"github.com/devtron-labs/devtron/pkg/imageSignature/repository"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type ImageSignatureService interface {
+	CreatePolicy(policy *signatureBean.ImageSignaturePolicyDto) (*signatureBean.ImageSignaturePolicyDto, error)
+	UpdatePolicy(policy *signatureBean.ImageSignaturePolicyDto) (*signatureBean.ImageSignaturePolicyDto, error)
+	DeletePolicy(id int, userId int32) error
+	GetPolicy(id int) (*signatureBean.ImageSignaturePolicyDto, error)
+	GetAllPolicies() ([]*signatureBean.ImageSignaturePolicyDto, error)
+
+	// EnforceSignatureVerification verifies the artifact against the policies applicable on the environment before a
+	// deployment and returns an error if it is not verified. The verification is recorded on the artifact
+	EnforceSignatureVerification(ctx context.Context, envId int, artifact *repository2.CiArtifact, userId int32) error
+	VerifyArtifact(ctx context.Context, request *signatureBean.VerifyArtifactRequest) (*signatureBean.VerificationResult, error)
+	// VerifyImages verifies the images against the policies applicable on the cluster and environment, images are
+	// missing in the result when no policy applies
+	VerifyImages(ctx context.Context, images []string, clusterId int, envId int) (map[string]*signatureBean.VerificationResult, error)
+	GetArtifactVerifications(appId, ciArtifactId int) ([]*signatureBean.VerificationDto, error)
+	// GetArtifactSignatureInfo returns the latest verification of the artifacts on the environment
+	GetArtifactSignatureInfo(envId int, ciArtifactIds []int) (map[int]*signatureBean.ArtifactSignatureInfo, error)
+}
+
+type ImageSignatureServiceImpl struct {
+	logger                              *zap.SugaredLogger
+	imageSignatureRepository            repository.ImageSignatureRepository
+	qualifierMappingService             resourceQualifiers.QualifierMappingService
+	devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService
+	environmentRepository               repository3.EnvironmentRepository
+	ciArtifactRepository                repository2.CiArtifactRepository
+	ciPipelineRepository                pipelineConfig.CiPipelineRepository
+	ciTemplateOverrideRepository        pipelineConfig.CiTemplateOverrideRepository
+	dockerArtifactStoreRepository       dockerRegistryRepository.DockerArtifactStoreRepository
+	config                              *signatureBean.ImageSignatureConfig
+	roots                               *trustRoots
+}
+
+func NewImageSignatureServiceImpl(logger *zap.SugaredLogger,
+	imageSignatureRepository repository.ImageSignatureRepository,
+	qualifierMappingService resourceQualifiers.QualifierMappingService,
+	devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService,
+	environmentRepository repository3.EnvironmentRepository,
+	ciArtifactRepository repository2.CiArtifactRepository,
+	ciPipelineRepository pipelineConfig.CiPipelineRepository,
+	ciTemplateOverrideRepository pipelineConfig.CiTemplateOverrideRepository,
+	dockerArtifactStoreRepository dockerRegistryRepository.DockerArtifactStoreRepository) (*ImageSignatureServiceImpl, error) {
+	config := &signatureBean.ImageSignatureConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing image signature config", "err", err)
+		return nil, err
+	}
+	roots, err := newTrustRoots(config.FulcioRootCertificates, config.RekorPublicKey)
+	if err != nil {
+		logger.Errorw("error in parsing sigstore roots", "err", err)
+		return nil, err
+	}
+	return &ImageSignatureServiceImpl{
+		logger:                              logger,
+		imageSignatureRepository:            imageSignatureRepository,
+		qualifierMappingService:             qualifierMappingService,
+		devtronResourceSearchableKeyService: devtronResourceSearchableKeyService,
+		environmentRepository:               environmentRepository,
+		ciArtifactRepository:                ciArtifactRepository,
+		ciPipelineRepository:                ciPipelineRepository,
+		ciTemplateOverrideRepository:        ciTemplateOverrideRepository,
+		dockerArtifactStoreRepository:       dockerArtifactStoreRepository,
+		config:                              config,
+		roots:                               roots,
+	}, nil
+}
+
+func (impl *ImageSignatureServiceImpl) CreatePolicy(policy *signatureBean.ImageSignaturePolicyDto) (*signatureBean.ImageSignaturePolicyDto, error) {
+	err := validatePolicy(policy, impl.roots)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	dbObject, err := toPolicyDbObject(policy)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := impl.imageSignatureRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.imageSignatureRepository.RollbackTx(tx)
+	err = impl.imageSignatureRepository.Save(tx, dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in saving image signature policy", "name", policy.Name, "err", err)
+		return nil, err
+	}
+	err = impl.createScopeMappings(tx, dbObject.Id, policy.Scope, policy.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in saving image signature policy scope", "policyId", dbObject.Id, "err", err)
+		return nil, err
+	}
+	err = impl.imageSignatureRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	policy.Id = dbObject.Id
+	return policy, nil
+}
+
+func (impl *ImageSignatureServiceImpl) UpdatePolicy(policy *signatureBean.ImageSignaturePolicyDto) (*signatureBean.ImageSignaturePolicyDto, error) {
+	err := validatePolicy(policy, impl.roots)
+	if err != nil {
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(err.Error()).WithInternalMessage(err.Error())
+	}
+	existing, err := impl.imageSignatureRepository.FindById(policy.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image signature policy", "id", policy.Id, "err", err)
+		return nil, err
+	}
+	dbObject, err := toPolicyDbObject(policy)
+	if err != nil {
+		return nil, err
+	}
+	dbObject.CreatedOn = existing.CreatedOn
+	dbObject.CreatedBy = existing.CreatedBy
+	tx, err := impl.imageSignatureRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.imageSignatureRepository.RollbackTx(tx)
+	err = impl.imageSignatureRepository.Update(tx, dbObject)
+	if err != nil {
+		impl.logger.Errorw("error in updating image signature policy", "id", policy.Id, "err", err)
+		return nil, err
+	}
+	err = impl.deleteScopeMappings(tx, policy.Id, policy.UserId)
+	if err != nil {
+		return nil, err
+	}
+	err = impl.createScopeMappings(tx, policy.Id, policy.Scope, policy.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in saving image signature policy scope", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	err = impl.imageSignatureRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (impl *ImageSignatureServiceImpl) DeletePolicy(id int, userId int32) error {
+	policy, err := impl.imageSignatureRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image signature policy", "id", id, "err", err)
+		return err
+	}
+	tx, err := impl.imageSignatureRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.imageSignatureRepository.RollbackTx(tx)
+	policy.Active = false
+	policy.UpdateAuditLog(userId)
+	err = impl.imageSignatureRepository.Update(tx, policy)
+	if err != nil {
+		impl.logger.Errorw("error in deleting image signature policy", "id", id, "err", err)
+		return err
+	}
+	err = impl.deleteScopeMappings(tx, id, userId)
+	if err != nil {
+		return err
+	}
+	return impl.imageSignatureRepository.CommitTx(tx)
+}
+
+func (impl *ImageSignatureServiceImpl) GetPolicy(id int) (*signatureBean.ImageSignaturePolicyDto, error) {
+	policy, err := impl.imageSignatureRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image signature policy", "id", id, "err", err)
+		return nil, err
+	}
+	policies, err := impl.toDtosWithScope([]*repository.ImageSignaturePolicy{policy})
+	if err != nil {
+		return nil, err
+	}
+	return policies[0], nil
+}
+
+func (impl *ImageSignatureServiceImpl) GetAllPolicies() ([]*signatureBean.ImageSignaturePolicyDto, error) {
+	policies, err := impl.imageSignatureRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching image signature policies", "err", err)
+		return nil, err
+	}
+	return impl.toDtosWithScope(policies)
+}
+
+func (impl *ImageSignatureServiceImpl) EnforceSignatureVerification(ctx context.Context, envId int, artifact *repository2.CiArtifact, userId int32) error {
+	result, err := impl.verifyArtifactOnEnv(ctx, envId, artifact, userId)
+	if err != nil {
+		return err
+	}
+	if result == nil || result.IsVerified() {
+		return nil
+	}
+	message := fmt.Sprintf(signatureBean.DeploymentBlocked, artifact.Image, result.Message)
+	return util.NewApiError().WithHttpStatusCode(http.StatusUnprocessableEntity).WithUserMessage(message).WithInternalMessage(message)
+}
+
+func (impl *ImageSignatureServiceImpl) VerifyArtifact(ctx context.Context, request *signatureBean.VerifyArtifactRequest) (*signatureBean.VerificationResult, error) {
+	artifact, err := impl.validateAppArtifact(request.AppId, request.CiArtifactId)
+	if err != nil {
+		return nil, err
+	}
+	result, err := impl.verifyArtifactOnEnv(ctx, request.EnvId, artifact, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		// no policy applies on the environment, every image is allowed
+		result = getVerificationResult(artifact.Image, artifact.ImageDigest, make([]*signatureBean.PolicyResult, 0))
+	}
+	return result, nil
+}
+
+func (impl *ImageSignatureServiceImpl) VerifyImages(ctx context.Context, images []string, clusterId int, envId int) (map[string]*signatureBean.VerificationResult, error) {
+	results := make(map[string]*signatureBean.VerificationResult)
+	policies, err := impl.getApplicablePolicies(clusterId, envId)
+	if err != nil || len(policies) == 0 {
+		return results, err
+	}
+	artifacts, err := impl.ciArtifactRepository.FindCiArtifactByImagePaths(images)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci artifacts by images", "images", images, "err", err)
+		return nil, err
+	}
+	artifactByImage := make(map[string]*repository2.CiArtifact, len(artifacts))
+	for i := range artifacts {
+		artifactByImage[artifacts[i].Image] = &artifacts[i]
+	}
+	for _, image := range images {
+		var registryConfig *registryClient.RegistryConfig
+		if artifact, ok := artifactByImage[image]; ok {
+			registryConfig, err = impl.getRegistryConfig(artifact)
+			if err != nil {
+				return nil, err
+			}
+		}
+		results[image] = impl.verifyImage(ctx, image, registryConfig, policies)
+	}
+	return results, nil
+}
+
+func (impl *ImageSignatureServiceImpl) GetArtifactVerifications(appId, ciArtifactId int) ([]*signatureBean.VerificationDto, error) {
+	artifact, err := impl.validateAppArtifact(appId, ciArtifactId)
+	if err != nil {
+		return nil, err
+	}
+	verifications, err := impl.imageSignatureRepository.FindVerificationsByArtifactId(ciArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image signature verifications", "ciArtifactId", ciArtifactId, "err", err)
+		return nil, err
+	}
+	result := make([]*signatureBean.VerificationDto, 0, len(verifications))
+	for _, verification := range verifications {
+		dto := toVerificationDto(verification)
+		dto.Image = artifact.Image
+		result = append(result, dto)
+	}
+	return result, nil
+}
+
+func (impl *ImageSignatureServiceImpl) GetArtifactSignatureInfo(envId int, ciArtifactIds []int) (map[int]*signatureBean.ArtifactSignatureInfo, error) {
+	result := make(map[int]*signatureBean.ArtifactSignatureInfo, len(ciArtifactIds))
+	env, err := impl.environmentRepository.FindById(envId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", envId, "err", err)
+		return nil, err
+	}
+	policies, err := impl.getApplicablePolicies(env.ClusterId, envId)
+	if err != nil || len(policies) == 0 {
+		return result, err
+	}
+	verifications, err := impl.imageSignatureRepository.FindLatestVerifications(envId, ciArtifactIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image signature verifications", "envId", envId, "err", err)
+		return nil, err
+	}
+	for _, ciArtifactId := range ciArtifactIds {
+		result[ciArtifactId] = &signatureBean.ArtifactSignatureInfo{PolicyApplicable: true}
+	}
+	for _, verification := range verifications {
+		result[verification.CiArtifactId] = &signatureBean.ArtifactSignatureInfo{
+			PolicyApplicable: true,
+			Status:           signatureBean.VerificationStatus(verification.Status),
+			Message:          verification.Message,
+			VerifiedOn:       verification.VerifiedOn,
+		}
+	}
+	return result, nil
+}
+
+// verifyArtifactOnEnv returns nil if no policy applies on the environment, the verification is recorded otherwise
+func (impl *ImageSignatureServiceImpl) verifyArtifactOnEnv(ctx context.Context, envId int, artifact *repository2.CiArtifact, userId int32) (*signatureBean.VerificationResult, error) {
+	env, err := impl.environmentRepository.FindById(envId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", envId, "err", err)
+		return nil, err
+	}
+	policies, err := impl.getApplicablePolicies(env.ClusterId, envId)
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	registryConfig, err := impl.getRegistryConfig(artifact)
+	if err != nil {
+		return nil, err
+	}
+	image := artifact.Image
+	if len(artifact.ImageDigest) > 0 {
+		// verify the built image even if the tag was pushed over since
+		image = fmt.Sprintf("%s@%s", artifact.Image, artifact.ImageDigest)
+	}
+	result := impl.verifyImage(ctx, image, registryConfig, policies)
+	result.Image = artifact.Image
+	err = impl.imageSignatureRepository.SaveVerification(toVerificationDbObject(artifact.Id, envId, result, userId))
+	if err != nil {
+		impl.logger.Errorw("error in saving image signature verification", "ciArtifactId", artifact.Id, "envId", envId, "err", err)
+		return nil, err
+	}
+	impl.logger.Infow("image signature verified", "ciArtifactId", artifact.Id, "envId", envId, "status", result.Status)
+	return result, nil
+}
+
+// verifyImage fails the policies when the signatures can not be fetched, so that unreachable registries do not
+// bypass the policies
+func (impl *ImageSignatureServiceImpl) verifyImage(ctx context.Context, image string, registryConfig *registryClient.RegistryConfig,
+	policies []*signatureBean.ImageSignaturePolicyDto) *signatureBean.VerificationResult {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(impl.config.FetchTimeoutSecs)*time.Second)
+	defer cancel()
+	signatures, err := registryClient.FetchImageSignatures(ctx, registryConfig, image)
+	if err != nil {
+		impl.logger.Errorw("error in fetching image signatures", "image", image, "err", err)
+		return getFailedResult(image, policies, fmt.Sprintf(signatureBean.SignatureCheckUnavailable, image, err.Error()))
+	}
+	now := time.Now()
+	policyResults := make([]*signatureBean.PolicyResult, 0, len(policies))
+	for _, policy := range policies {
+		policyResults = append(policyResults, verifyPolicy(policy, signatures, impl.roots, now))
+	}
+	return getVerificationResult(image, signatures.Digest, policyResults)
+}
+
+func (impl *ImageSignatureServiceImpl) getApplicablePolicies(clusterId int, envId int) ([]*signatureBean.ImageSignaturePolicyDto, error) {
+	policies, err := impl.GetAllPolicies()
+	if err != nil {
+		return nil, err
+	}
+	applicablePolicies := make([]*signatureBean.ImageSignaturePolicyDto, 0)
+	for _, policy := range policies {
+		if isApplicable(policy.Scope, clusterId, envId) {
+			applicablePolicies = append(applicablePolicies, policy)
+		}
+	}
+	return applicablePolicies, nil
+}
+
+// getRegistryConfig returns the registry the artifact was pushed to, the same way image pull secrets are resolved.
+// It returns nil for images of external ci, their registry is accessed anonymously
+func (impl *ImageSignatureServiceImpl) getRegistryConfig(artifact *repository2.CiArtifact) (*registryClient.RegistryConfig, error) {
+	var dockerRegistryId string
+	if artifact.IsRegistryCredentialMapped() {
+		dockerRegistryId = artifact.CredentialSourceValue
+	} else if artifact.PipelineId > 0 {
+		ciPipeline, err := impl.ciPipelineRepository.FindById(artifact.PipelineId)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching ci pipeline", "ciPipelineId", artifact.PipelineId, "err", err)
+			return nil, err
+		}
+		if err == nil && ciPipeline.CiTemplate != nil && ciPipeline.CiTemplate.DockerRegistryId != nil {
+			dockerRegistryId = *ciPipeline.CiTemplate.DockerRegistryId
+			if ciPipeline.IsDockerConfigOverridden {
+				ciPipelineId := ciPipeline.Id
+				if ciPipeline.ParentCiPipeline != 0 {
+					ciPipelineId = ciPipeline.ParentCiPipeline
+				}
+				ciTemplateOverride, err := impl.ciTemplateOverrideRepository.FindByCiPipelineId(ciPipelineId)
+				if err != nil {
+					impl.logger.Errorw("error in fetching ci template override", "ciPipelineId", ciPipelineId, "err", err)
+					return nil, err
+				}
+				dockerRegistryId = ciTemplateOverride.DockerRegistryId
+			}
+		}
+	}
+	if len(dockerRegistryId) == 0 {
+		return nil, nil
+	}
+	store, err := impl.dockerArtifactStoreRepository.FindOne(dockerRegistryId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching docker registry", "dockerRegistryId", dockerRegistryId, "err", err)
+		return nil, err
+	}
+	config := registryClient.GetRegistryConfigForStore(store)
+	if store.RegistryType == dockerRegistryRepository.REGISTRYTYPE_ECR {
+		username, password, err := dockerRegistry.CreateCredentialForEcr(store.AWSRegion, store.AWSAccessKeyId, store.AWSSecretAccessKey)
+		if err != nil {
+			impl.logger.Errorw("error in creating ecr credentials", "dockerRegistryId", store.Id, "err", err)
+			return nil, err
+		}
+		config.Username = username
+		config.Password = password
+	}
+	return config, nil
+}
+
+func (impl *ImageSignatureServiceImpl) validateAppArtifact(appId, ciArtifactId int) (*repository2.CiArtifact, error) {
+	artifact, err := impl.ciArtifactRepository.Get(ciArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return nil, err
+	}
+	ciPipeline, err := impl.ciPipelineRepository.FindByIdIncludingInActive(artifact.PipelineId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching ci pipeline", "ciPipelineId", artifact.PipelineId, "err", err)
+		return nil, err
+	}
+	if util.IsErrNoRows(err) || ciPipeline.AppId != appId {
+		errMsg := fmt.Sprintf(signatureBean.ArtifactNotInApp, ciArtifactId, appId)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusNotFound).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+	}
+	return artifact, nil
+}
+
+func (impl *ImageSignatureServiceImpl) toDtosWithScope(policies []*repository.ImageSignaturePolicy) ([]*signatureBean.ImageSignaturePolicyDto, error) {
+	policyIds := make([]int, 0, len(policies))
+	dtoMap := make(map[int]*signatureBean.ImageSignaturePolicyDto, len(policies))
+	result := make([]*signatureBean.ImageSignaturePolicyDto, 0, len(policies))
+	for _, policy := range policies {
+		dto := toPolicyDto(policy)
+		policyIds = append(policyIds, policy.Id)
+		dtoMap[policy.Id] = dto
+		result = append(result, dto)
+	}
+	if len(policyIds) == 0 {
+		return result, nil
+	}
+	mappings, err := impl.qualifierMappingService.GetQualifierMappings(resourceQualifiers.ImageSignaturePolicy, nil, policyIds)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching image signature policy scope", "policyIds", policyIds, "err", err)
+		return nil, err
+	}
+	searchableKeyIdNameMap := impl.devtronResourceSearchableKeyService.GetAllSearchableKeyIdNameMap()
+	for _, mapping := range mappings {
+		dto, ok := dtoMap[mapping.ResourceId]
+		if !ok {
+			continue
+		}
+		if mapping.QualifierId == int(resourceQualifiers.GLOBAL_QUALIFIER) {
+			dto.Scope.Global = true
+			continue
+		}
+		switch searchableKeyIdNameMap[mapping.IdentifierKey] {
+		case bean.DEVTRON_RESOURCE_SEARCHABLE_KEY_CLUSTER_ID:
+			dto.Scope.ClusterIds = append(dto.Scope.ClusterIds, mapping.IdentifierValueInt)
+		case bean.DEVTRON_RESOURCE_SEARCHABLE_KEY_ENV_ID:
+			dto.Scope.EnvIds = append(dto.Scope.EnvIds, mapping.IdentifierValueInt)
+		}
+	}
+	return result, nil
+}
+
+func (impl *ImageSignatureServiceImpl) createScopeMappings(tx *pg.Tx, policyId int, scope *signatureBean.PolicyScope, userId int32) error {
+	resourceIds := []int{policyId}
+	if scope.Global {
+		return impl.qualifierMappingService.CreateMappings(tx, userId, resourceQualifiers.ImageSignaturePolicy, resourceIds, resourceQualifiers.GlobalSelector, []*resourceQualifiers.SelectionIdentifier{{}})
+	}
+	selections := map[resourceQualifiers.QualifierSelector][]*resourceQualifiers.SelectionIdentifier{}
+	for _, clusterId := range scope.ClusterIds {
+		selections[resourceQualifiers.ClusterSelector] = append(selections[resourceQualifiers.ClusterSelector], &resourceQualifiers.SelectionIdentifier{ClusterId: clusterId})
+	}
+	for _, envId := range scope.EnvIds {
+		selections[resourceQualifiers.EnvironmentSelector] = append(selections[resourceQualifiers.EnvironmentSelector], &resourceQualifiers.SelectionIdentifier{EnvId: envId})
+	}
+	for selector, identifiers := range selections {
+		err := impl.qualifierMappingService.CreateMappings(tx, userId, resourceQualifiers.ImageSignaturePolicy, resourceIds, selector, identifiers)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *ImageSignatureServiceImpl) deleteScopeMappings(tx *pg.Tx, policyId int, userId int32) error {
+	mappings, err := impl.qualifierMappingService.GetQualifierMappings(resourceQualifiers.ImageSignaturePolicy, nil, []int{policyId})
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching image signature policy scope", "policyId", policyId, "err", err)
+		return err
+	}
+	if len(mappings) == 0 {
+		return nil
+	}
+	mappingIds := make([]int, 0, len(mappings))
+	for _, mapping := range mappings {
+		mappingIds = append(mappingIds, mapping.Id)
+	}
+	err = impl.qualifierMappingService.DeleteAllByIds(mappingIds, userId, tx)
+	if err != nil {
+		impl.logger.Errorw("error in deleting image signature policy scope", "policyId", policyId, "err", err)
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageSignature
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/imageSignature/bean"
+	"github.com/devtron-labs/devtron/pkg/imageSignature/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"time"
+)
+
+func toPolicyDbObject(dto *bean.ImageSignaturePolicyDto) (*repository.ImageSignaturePolicy, error) {
+	keylessIdentities := make([]*bean.KeylessIdentity, 0, len(dto.KeylessIdentities))
+	keylessIdentities = append(keylessIdentities, dto.KeylessIdentities...)
+	identities, err := json.Marshal(keylessIdentities)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.ImageSignaturePolicy{
+		Id:                  dto.Id,
+		Name:                dto.Name,
+		Description:         dto.Description,
+		Verifier:            string(dto.Verifier),
+		PublicKeys:          dto.PublicKeys,
+		TrustedCertificates: dto.TrustedCertificates,
+		TrustedSubjects:     dto.TrustedSubjects,
+		KeylessIdentities:   string(identities),
+		Active:              true,
+		AuditLog:            sql.NewDefaultAuditLog(dto.UserId),
+	}, nil
+}
+
+func toPolicyDto(policy *repository.ImageSignaturePolicy) *bean.ImageSignaturePolicyDto {
+	keylessIdentities := make([]*bean.KeylessIdentity, 0)
+	if len(policy.KeylessIdentities) > 0 {
+		// identities are validated before saving
+		_ = json.Unmarshal([]byte(policy.KeylessIdentities), &keylessIdentities)
+	}
+	return &bean.ImageSignaturePolicyDto{
+		Id:                  policy.Id,
+		Name:                policy.Name,
+		Description:         policy.Description,
+		Verifier:            bean.VerifierType(policy.Verifier),
+		PublicKeys:          policy.PublicKeys,
+		KeylessIdentities:   keylessIdentities,
+		TrustedCertificates: policy.TrustedCertificates,
+		TrustedSubjects:     policy.TrustedSubjects,
+		Scope:               &bean.PolicyScope{},
+	}
+}
+
+func toVerificationDbObject(ciArtifactId int, envId int, result *bean.VerificationResult, userId int32) *repository.ImageSignatureVerification {
+	policies, _ := json.Marshal(result.Policies)
+	return &repository.ImageSignatureVerification{
+		CiArtifactId:  ciArtifactId,
+		EnvironmentId: envId,
+		ImageDigest:   result.Digest,
+		Status:        string(result.Status),
+		Result:        string(policies),
+		Message:       result.Message,
+		VerifiedOn:    time.Now(),
+		VerifiedBy:    userId,
+	}
+}
+
+func toVerificationDto(verification *repository.ImageSignatureVerification) *bean.VerificationDto {
+	policies := make([]*bean.PolicyResult, 0)
+	if len(verification.Result) > 0 {
+		_ = json.Unmarshal([]byte(verification.Result), &policies)
+	}
+	return &bean.VerificationDto{
+		Id:            verification.Id,
+		CiArtifactId:  verification.CiArtifactId,
+		EnvironmentId: verification.EnvironmentId,
+		VerificationResult: &bean.VerificationResult{
+			Digest:   verification.ImageDigest,
+			Status:   bean.VerificationStatus(verification.Status),
+			Policies: policies,
+			Message:  verification.Message,
+		},
+		VerifiedOn: verification.VerifiedOn,
+		VerifiedBy: verification.VerifiedBy,
+	}
+}
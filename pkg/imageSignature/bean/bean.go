@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type VerifierType string
+
+const (
+	VerifierCosign   VerifierType = "COSIGN"
+	VerifierNotation VerifierType = "NOTATION"
+)
+
+type VerificationStatus string
+
+const (
+	VerificationStatusVerified VerificationStatus = "VERIFIED"
+	VerificationStatusFailed   VerificationStatus = "FAILED"
+)
+
+const (
+	ImageSignatureCheck       = "IMAGE_SIGNATURE"
+	DeploymentBlocked         = "deployment blocked, image signature verification failed for %s: %s"
+	NoSignatureFound          = "no %s signature found"
+	NoTrustedSignature        = "no signature verified with the trusted keys or identities"
+	MissingTrustMaterial      = "cosign policies need public keys or keyless identities, notation policies need trusted certificates"
+	KeylessNotConfigured      = "keyless verification needs SIGSTORE_FULCIO_ROOT_CERTIFICATES and SIGSTORE_REKOR_PUBLIC_KEY"
+	InvalidKeylessIdentity    = "keyless identities need an issuer and a subject or subject regex"
+	ArtifactNotInApp          = "artifact %d does not belong to app %d"
+	SignatureCheckUnavailable = "could not fetch signatures of %s: %s"
+	SignatureCheckFailed      = "image signature policies could not be evaluated: %s"
+)
+
+// ImageSignaturePolicyDto trusts cosign public keys or keyless identities, or notation certificate roots.
+// A policy applies on the clusters and environments of its scope, and a deployment needs a trusted signature for
+// every applicable policy
+type ImageSignaturePolicyDto struct {
+	Id          int          `json:"id"`
+	Name        string       `json:"name" validate:"required,max=100"`
+	Description string       `json:"description" validate:"max=350"`
+	Verifier    VerifierType `json:"verifier" validate:"oneof=COSIGN NOTATION"`
+	// PublicKeys are PEM encoded ECDSA, RSA or ED25519 keys for cosign
+	PublicKeys []string `json:"publicKeys"`
+	// KeylessIdentities are matched with the fulcio certificate of cosign keyless signatures
+	KeylessIdentities []*KeylessIdentity `json:"keylessIdentities"`
+	// TrustedCertificates are PEM encoded root certificates for notation
+	TrustedCertificates []string `json:"trustedCertificates"`
+	// TrustedSubjects restrict the notation signing certificate subject, any subject is trusted if empty
+	TrustedSubjects []string     `json:"trustedSubjects"`
+	Scope           *PolicyScope `json:"scope" validate:"required"`
+	UserId          int32        `json:"-"`
+}
+
+type KeylessIdentity struct {
+	Issuer       string `json:"issuer"`
+	Subject      string `json:"subject"`
+	SubjectRegex string `json:"subjectRegex"`
+}
+
+// PolicyScope selects where a policy applies, mapped through resource qualifiers
+type PolicyScope struct {
+	Global     bool  `json:"global"`
+	ClusterIds []int `json:"clusterIds"`
+	EnvIds     []int `json:"envIds"`
+}
+
+func (scope *PolicyScope) IsEmpty() bool {
+	return scope == nil || (!scope.Global && len(scope.ClusterIds) == 0 && len(scope.EnvIds) == 0)
+}
+
+type PolicyResult struct {
+	PolicyId   int    `json:"policyId"`
+	PolicyName string `json:"policyName"`
+	Verified   bool   `json:"verified"`
+	// Signer is the key fingerprint, keyless identity or certificate subject of the verified signature
+	Signer  string `json:"signer,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type VerificationResult struct {
+	Image    string             `json:"image"`
+	Digest   string             `json:"digest"`
+	Status   VerificationStatus `json:"status"`
+	Policies []*PolicyResult    `json:"policies"`
+	Message  string             `json:"message,omitempty"`
+}
+
+func (result *VerificationResult) IsVerified() bool {
+	return result.Status == VerificationStatusVerified
+}
+
+type VerifyArtifactRequest struct {
+	AppId        int   `json:"appId" validate:"required"`
+	CiArtifactId int   `json:"ciArtifactId" validate:"required"`
+	EnvId        int   `json:"envId" validate:"required"`
+	UserId       int32 `json:"-"`
+}
+
+type VerificationDto struct {
+	Id            int `json:"id"`
+	CiArtifactId  int `json:"ciArtifactId"`
+	EnvironmentId int `json:"environmentId"`
+	*VerificationResult
+	VerifiedOn time.Time `json:"verifiedOn"`
+	VerifiedBy int32     `json:"verifiedBy"`
+}
+
+// ArtifactSignatureInfo is the latest verification of an artifact against the policies of an environment
+type ArtifactSignatureInfo struct {
+	PolicyApplicable bool               `json:"policyApplicable"`
+	Status           VerificationStatus `json:"status,omitempty"`
+	Message          string             `json:"message,omitempty"`
+	VerifiedOn       time.Time          `json:"verifiedOn,omitempty"`
+}
+
+type ImageSignatureConfig struct {
+	// FulcioRootCertificates and RekorPublicKey are PEM encoded, keyless signatures are verified only when both are set
+	FulcioRootCertificates string `env:"SIGSTORE_FULCIO_ROOT_CERTIFICATES" envDefault:""`
+	RekorPublicKey         string `env:"SIGSTORE_REKOR_PUBLIC_KEY" envDefault:""`
+	FetchTimeoutSecs       int    `env:"IMAGE_SIGNATURE_FETCH_TIMEOUT_SECS" envDefault:"30"`
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageSignature
+
+import (
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/imageSignature/bean"
+	"regexp"
+	"strings"
+)
+
+func validatePolicy(policy *bean.ImageSignaturePolicyDto, roots *trustRoots) error {
+	if policy.Scope.IsEmpty() {
+		return fmt.Errorf("image signature policy %q must be scoped to at least one cluster or environment", policy.Name)
+	}
+	switch policy.Verifier {
+	case bean.VerifierCosign:
+		if len(policy.PublicKeys) == 0 && len(policy.KeylessIdentities) == 0 {
+			return errors.New(bean.MissingTrustMaterial)
+		}
+		for _, publicKey := range policy.PublicKeys {
+			if _, err := parsePublicKey(publicKey); err != nil {
+				return err
+			}
+		}
+		if len(policy.KeylessIdentities) > 0 && roots == nil {
+			return errors.New(bean.KeylessNotConfigured)
+		}
+		for _, identity := range policy.KeylessIdentities {
+			if len(identity.Issuer) == 0 || (len(identity.Subject) == 0 && len(identity.SubjectRegex) == 0) {
+				return errors.New(bean.InvalidKeylessIdentity)
+			}
+			if len(identity.SubjectRegex) > 0 {
+				if _, err := regexp.Compile(identity.SubjectRegex); err != nil {
+					return fmt.Errorf("invalid subject regex %q", identity.SubjectRegex)
+				}
+			}
+		}
+	case bean.VerifierNotation:
+		if len(policy.TrustedCertificates) == 0 {
+			return errors.New(bean.MissingTrustMaterial)
+		}
+		if _, err := parseCertificatePool(strings.Join(policy.TrustedCertificates, "\n")); err != nil {
+			return fmt.Errorf("invalid trusted certificates, %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid verifier %q", policy.Verifier)
+	}
+	return nil
+}
+
+func isApplicable(scope *bean.PolicyScope, clusterId int, envId int) bool {
+	if scope.Global {
+		return true
+	}
+	return containsId(scope.ClusterIds, clusterId) || containsId(scope.EnvIds, envId)
+}
+
+func containsId(ids []int, id int) bool {
+	for _, item := range ids {
+		if item == id {
+			return true
+		}
+	}
+	return false
+}
+
+// getVerificationResult needs every policy verified, an image without applicable policies is verified
+func getVerificationResult(image string, digest string, policyResults []*bean.PolicyResult) *bean.VerificationResult {
+	result := &bean.VerificationResult{Image: image, Digest: digest, Status: bean.VerificationStatusVerified, Policies: policyResults}
+	failedPolicies := make([]string, 0)
+	for _, policyResult := range policyResults {
+		if !policyResult.Verified {
+			failedPolicies = append(failedPolicies, fmt.Sprintf("%s: %s", policyResult.PolicyName, policyResult.Message))
+		}
+	}
+	if len(failedPolicies) > 0 {
+		result.Status = bean.VerificationStatusFailed
+		result.Message = strings.Join(failedPolicies, "; ")
+	}
+	return result
+}
+
+// getFailedResult fails every policy when the signatures of the image could not be fetched
+func getFailedResult(image string, policies []*bean.ImageSignaturePolicyDto, message string) *bean.VerificationResult {
+	policyResults := make([]*bean.PolicyResult, 0, len(policies))
+	for _, policy := range policies {
+		policyResults = append(policyResults, &bean.PolicyResult{PolicyId: policy.Id, PolicyName: policy.Name, Message: message})
+	}
+	return &bean.VerificationResult{Image: image, Status: bean.VerificationStatusFailed, Policies: policyResults, Message: message}
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type ImageSignaturePolicy struct {
+	tableName           struct{} `sql:"image_signature_policy" pg:",discard_unknown_columns"`
+	Id                  int      `sql:"id,pk"`
+	Name                string   `sql:"name,notnull"`
+	Description         string   `sql:"description"`
+	Verifier            string   `sql:"verifier,notnull"`
+	PublicKeys          []string `sql:"public_keys" pg:",array"`
+	TrustedCertificates []string `sql:"trusted_certificates" pg:",array"`
+	TrustedSubjects     []string `sql:"trusted_subjects" pg:",array"`
+	// KeylessIdentities is the json of the keyless identities
+	KeylessIdentities string `sql:"keyless_identities"`
+	Active            bool   `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// ImageSignatureVerification records a verification of an artifact against the policies of an environment
+type ImageSignatureVerification struct {
+	tableName     struct{}  `sql:"image_signature_verification" pg:",discard_unknown_columns"`
+	Id            int       `sql:"id,pk"`
+	CiArtifactId  int       `sql:"ci_artifact_id,notnull"`
+	EnvironmentId int       `sql:"environment_id,notnull"`
+	ImageDigest   string    `sql:"image_digest"`
+	Status        string    `sql:"status,notnull"`
+	Result        string    `sql:"result"`
+	Message       string    `sql:"message"`
+	VerifiedOn    time.Time `sql:"verified_on,notnull"`
+	VerifiedBy    int32     `sql:"verified_by,notnull"`
+}
+
+type ImageSignatureRepository interface {
+	//transaction util funcs
+	sql.TransactionWrapper
+	Save(tx *pg.Tx, policy *ImageSignaturePolicy) error
+	Update(tx *pg.Tx, policy *ImageSignaturePolicy) error
+	FindById(id int) (*ImageSignaturePolicy, error)
+	FindAllActive() ([]*ImageSignaturePolicy, error)
+	SaveVerification(verification *ImageSignatureVerification) error
+	FindVerificationsByArtifactId(ciArtifactId int) ([]*ImageSignatureVerification, error)
+	// FindLatestVerifications returns the last verification of each of the artifacts on the environment
+	FindLatestVerifications(environmentId int, ciArtifactIds []int) ([]*ImageSignatureVerification, error)
+}
+
+type ImageSignatureRepositoryImpl struct {
+	*sql.TransactionUtilImpl
+	dbConnection *pg.DB
+}
+
+func NewImageSignatureRepositoryImpl(dbConnection *pg.DB, TransactionUtilImpl *sql.TransactionUtilImpl) *ImageSignatureRepositoryImpl {
+	return &ImageSignatureRepositoryImpl{
+		dbConnection:        dbConnection,
+		TransactionUtilImpl: TransactionUtilImpl,
+	}
+}
+
+func (impl ImageSignatureRepositoryImpl) Save(tx *pg.Tx, policy *ImageSignaturePolicy) error {
+	return tx.Insert(policy)
+}
+
+func (impl ImageSignatureRepositoryImpl) Update(tx *pg.Tx, policy *ImageSignaturePolicy) error {
+	return tx.Update(policy)
+}
+
+func (impl ImageSignatureRepositoryImpl) FindById(id int) (*ImageSignaturePolicy, error) {
+	policy := &ImageSignaturePolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl ImageSignatureRepositoryImpl) FindAllActive() ([]*ImageSignaturePolicy, error) {
+	policies := make([]*ImageSignaturePolicy, 0)
+	err := impl.dbConnection.Model(&policies).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return policies, err
+}
+
+func (impl ImageSignatureRepositoryImpl) SaveVerification(verification *ImageSignatureVerification) error {
+	return impl.dbConnection.Insert(verification)
+}
+
+func (impl ImageSignatureRepositoryImpl) FindVerificationsByArtifactId(ciArtifactId int) ([]*ImageSignatureVerification, error) {
+	verifications := make([]*ImageSignatureVerification, 0)
+	err := impl.dbConnection.Model(&verifications).
+		Where("ci_artifact_id = ?", ciArtifactId).
+		Order("id DESC").
+		Select()
+	return verifications, err
+}
+
+func (impl ImageSignatureRepositoryImpl) FindLatestVerifications(environmentId int, ciArtifactIds []int) ([]*ImageSignatureVerification, error) {
+	verifications := make([]*ImageSignatureVerification, 0)
+	if len(ciArtifactIds) == 0 {
+		return verifications, nil
+	}
+	query := "SELECT DISTINCT ON (ci_artifact_id) * FROM image_signature_verification" +
+		" WHERE environment_id = ? AND ci_artifact_id IN (?)" +
+		" ORDER BY ci_artifact_id, id DESC"
+	_, err := impl.dbConnection.Query(&verifications, query, environmentId, pg.In(ciArtifactIds))
+	return verifications, err
+}
@@ -0,0 +1,544 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageSignature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry/registryClient"
+	"github.com/devtron-labs/devtron/pkg/imageSignature/bean"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// fulcio certificates carry the oidc issuer, as a der utf8 string in the newer extension
+	fulcioIssuerOid       = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+	fulcioLegacyIssuerOid = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+)
+
+const (
+	notationSigningTimeHeader = "io.cncf.notary.signingTime"
+	notationExpiryHeader      = "io.cncf.notary.expiry"
+)
+
+// trustRoots are the sigstore roots keyless signatures are verified with
+type trustRoots struct {
+	fulcioRoots *x509.CertPool
+	rekorKey    crypto.PublicKey
+}
+
+// newTrustRoots returns nil when the roots are not configured, keyless signatures are not trusted then
+func newTrustRoots(fulcioRootCertificates string, rekorPublicKey string) (*trustRoots, error) {
+	if len(fulcioRootCertificates) == 0 || len(rekorPublicKey) == 0 {
+		return nil, nil
+	}
+	fulcioRoots, err := parseCertificatePool(fulcioRootCertificates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fulcio root certificates, %w", err)
+	}
+	rekorKey, err := parsePublicKey(rekorPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rekor public key, %w", err)
+	}
+	return &trustRoots{fulcioRoots: fulcioRoots, rekorKey: rekorKey}, nil
+}
+
+// verifyPolicy looks for a signature of the image trusted by the policy
+func verifyPolicy(policy *bean.ImageSignaturePolicyDto, signatures *registryClient.ImageSignatures, roots *trustRoots, now time.Time) *bean.PolicyResult {
+	result := &bean.PolicyResult{PolicyId: policy.Id, PolicyName: policy.Name}
+	var signer string
+	var err error
+	switch policy.Verifier {
+	case bean.VerifierCosign:
+		if len(signatures.Cosign) == 0 {
+			result.Message = fmt.Sprintf(bean.NoSignatureFound, strings.ToLower(string(policy.Verifier)))
+			return result
+		}
+		for _, signature := range signatures.Cosign {
+			if signer, err = verifyCosignSignature(signature, signatures.Digest, policy, roots); err == nil {
+				break
+			}
+		}
+	case bean.VerifierNotation:
+		if len(signatures.Notation) == 0 {
+			result.Message = fmt.Sprintf(bean.NoSignatureFound, strings.ToLower(string(policy.Verifier)))
+			return result
+		}
+		for _, signature := range signatures.Notation {
+			if signer, err = verifyNotationSignature(signature, signatures.Digest, policy, now); err == nil {
+				break
+			}
+		}
+	default:
+		err = fmt.Errorf("unknown verifier %s", policy.Verifier)
+	}
+	if err != nil {
+		result.Message = fmt.Sprintf("%s, %s", bean.NoTrustedSignature, err.Error())
+		return result
+	}
+	result.Verified = true
+	result.Signer = signer
+	return result
+}
+
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifyCosignSignature returns the signer if one of the public keys or, for keyless signatures, the identities of
+// the policy trust the signature
+func verifyCosignSignature(signature *registryClient.CosignSignature, digest string, policy *bean.ImageSignaturePolicyDto, roots *trustRoots) (string, error) {
+	payload := &simpleSigningPayload{}
+	err := json.Unmarshal(signature.Payload, payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature payload, %w", err)
+	}
+	if payload.Critical.Image.DockerManifestDigest != digest {
+		return "", fmt.Errorf("signature is for digest %s", payload.Critical.Image.DockerManifestDigest)
+	}
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature.Signature)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature encoding, %w", err)
+	}
+	for _, publicKey := range policy.PublicKeys {
+		key, err := parsePublicKey(publicKey)
+		if err != nil {
+			return "", err
+		}
+		if verifyWithKey(key, signature.Payload, signatureBytes) == nil {
+			return keyFingerprint(key), nil
+		}
+	}
+	if len(signature.Certificate) == 0 || len(policy.KeylessIdentities) == 0 {
+		return "", errors.New("signature is not signed by a trusted key")
+	}
+	if roots == nil {
+		return "", errors.New(bean.KeylessNotConfigured)
+	}
+	return verifyKeylessSignature(signature, signatureBytes, policy.KeylessIdentities, roots)
+}
+
+type rekorBundle struct {
+	SignedEntryTimestamp string        `json:"SignedEntryTimestamp"`
+	Payload              *rekorPayload `json:"Payload"`
+}
+
+// rekorPayload fields are in the order of the canonical json the signed entry timestamp is over
+type rekorPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+type hashedRekordEntry struct {
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content string `json:"content"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// verifyKeylessSignature verifies the fulcio certificate at the time rekor logged the signature, as the certificate
+// expires minutes after signing, and matches its identity
+func verifyKeylessSignature(signature *registryClient.CosignSignature, signatureBytes []byte, identities []*bean.KeylessIdentity, roots *trustRoots) (string, error) {
+	if len(signature.Bundle) == 0 {
+		return "", errors.New("keyless signature has no transparency log bundle")
+	}
+	bundle := &rekorBundle{}
+	err := json.Unmarshal([]byte(signature.Bundle), bundle)
+	if err != nil || bundle.Payload == nil {
+		return "", errors.New("invalid transparency log bundle")
+	}
+	canonicalPayload, err := json.Marshal(bundle.Payload)
+	if err != nil {
+		return "", err
+	}
+	entryTimestamp, err := base64.StdEncoding.DecodeString(bundle.SignedEntryTimestamp)
+	if err != nil {
+		return "", errors.New("invalid signed entry timestamp encoding")
+	}
+	if err = verifyWithKey(roots.rekorKey, canonicalPayload, entryTimestamp); err != nil {
+		return "", errors.New("transparency log bundle is not signed by rekor")
+	}
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return "", errors.New("invalid transparency log entry encoding")
+	}
+	entry := &hashedRekordEntry{}
+	if err = json.Unmarshal(body, entry); err != nil {
+		return "", errors.New("invalid transparency log entry")
+	}
+	payloadHash := sha256.Sum256(signature.Payload)
+	if entry.Spec.Signature.Content != signature.Signature || entry.Spec.Data.Hash.Value != hex.EncodeToString(payloadHash[:]) {
+		return "", errors.New("transparency log entry is for another signature")
+	}
+	certificates, err := parseCertificates(signature.Certificate + "\n" + signature.Chain)
+	if err != nil || len(certificates) == 0 {
+		return "", errors.New("invalid signing certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	leaf := certificates[0]
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         roots.fulcioRoots,
+		Intermediates: intermediates,
+		CurrentTime:   time.Unix(bundle.Payload.IntegratedTime, 0),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return "", fmt.Errorf("signing certificate is not trusted, %w", err)
+	}
+	if err = verifyWithKey(leaf.PublicKey, signature.Payload, signatureBytes); err != nil {
+		return "", err
+	}
+	issuer, subjects := certificateIdentity(leaf)
+	for _, identity := range identities {
+		if identity.Issuer != issuer {
+			continue
+		}
+		for _, subject := range subjects {
+			if matchesSubject(identity, subject) {
+				return fmt.Sprintf("%s (%s)", subject, issuer), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("signer %s of %s is not a trusted identity", strings.Join(subjects, ","), issuer)
+}
+
+func certificateIdentity(certificate *x509.Certificate) (string, []string) {
+	var issuer string
+	for _, extension := range certificate.Extensions {
+		if extension.Id.Equal(fulcioIssuerOid) {
+			var value string
+			if _, err := asn1.Unmarshal(extension.Value, &value); err == nil {
+				issuer = value
+				break
+			}
+		} else if extension.Id.Equal(fulcioLegacyIssuerOid) && len(issuer) == 0 {
+			issuer = string(extension.Value)
+		}
+	}
+	subjects := make([]string, 0, len(certificate.EmailAddresses)+len(certificate.URIs))
+	subjects = append(subjects, certificate.EmailAddresses...)
+	for _, uri := range certificate.URIs {
+		subjects = append(subjects, uri.String())
+	}
+	return issuer, subjects
+}
+
+func matchesSubject(identity *bean.KeylessIdentity, subject string) bool {
+	if len(identity.Subject) > 0 {
+		return identity.Subject == subject
+	}
+	matched, err := regexp.MatchString("^(?:"+identity.SubjectRegex+")$", subject)
+	return err == nil && matched
+}
+
+type jwsEnvelope struct {
+	Payload   string `json:"payload"`
+	Protected string `json:"protected"`
+	Header    struct {
+		X5c []string `json:"x5c"`
+	} `json:"header"`
+	Signature string `json:"signature"`
+}
+
+type notationPayload struct {
+	TargetArtifact struct {
+		Digest string `json:"digest"`
+	} `json:"targetArtifact"`
+}
+
+// verifyNotationSignature verifies a jws envelope signed by a certificate chaining to the trusted certificates at
+// signing time. Cose envelopes are not supported
+func verifyNotationSignature(signature *registryClient.NotationSignature, digest string, policy *bean.ImageSignaturePolicyDto, now time.Time) (string, error) {
+	if signature.MediaType != registryClient.NotationJwsMediaType {
+		return "", fmt.Errorf("unsupported signature envelope %s", signature.MediaType)
+	}
+	envelope := &jwsEnvelope{}
+	err := json.Unmarshal(signature.Envelope, envelope)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature envelope, %w", err)
+	}
+	protectedHeader, err := decodeJwsPart(envelope.Protected)
+	if err != nil {
+		return "", err
+	}
+	header := make(map[string]interface{})
+	if err = json.Unmarshal(protectedHeader, &header); err != nil {
+		return "", fmt.Errorf("invalid signature header, %w", err)
+	}
+	signingTime, err := headerTime(header, notationSigningTimeHeader)
+	if err != nil || signingTime.IsZero() {
+		return "", errors.New("signature has no valid signing time")
+	}
+	expiry, err := headerTime(header, notationExpiryHeader)
+	if err != nil {
+		return "", err
+	}
+	if !expiry.IsZero() && now.After(expiry) {
+		return "", fmt.Errorf("signature expired on %s", expiry.Format(time.RFC3339))
+	}
+	payloadBytes, err := decodeJwsPart(envelope.Payload)
+	if err != nil {
+		return "", err
+	}
+	payload := &notationPayload{}
+	if err = json.Unmarshal(payloadBytes, payload); err != nil {
+		return "", fmt.Errorf("invalid signature payload, %w", err)
+	}
+	if payload.TargetArtifact.Digest != digest {
+		return "", fmt.Errorf("signature is for digest %s", payload.TargetArtifact.Digest)
+	}
+	if len(envelope.Header.X5c) == 0 {
+		return "", errors.New("signature has no certificate chain")
+	}
+	certificates := make([]*x509.Certificate, 0, len(envelope.Header.X5c))
+	for _, encoded := range envelope.Header.X5c {
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", errors.New("invalid certificate chain encoding")
+		}
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return "", fmt.Errorf("invalid certificate chain, %w", err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	trustedRoots, err := parseCertificatePool(strings.Join(policy.TrustedCertificates, "\n"))
+	if err != nil {
+		return "", err
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	leaf := certificates[0]
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         trustedRoots,
+		Intermediates: intermediates,
+		CurrentTime:   signingTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return "", fmt.Errorf("signing certificate is not trusted, %w", err)
+	}
+	signatureBytes, err := decodeJwsPart(envelope.Signature)
+	if err != nil {
+		return "", err
+	}
+	alg, _ := header["alg"].(string)
+	err = verifyJws(alg, leaf.PublicKey, []byte(envelope.Protected+"."+envelope.Payload), signatureBytes)
+	if err != nil {
+		return "", err
+	}
+	subject := leaf.Subject.String()
+	if len(policy.TrustedSubjects) == 0 {
+		return subject, nil
+	}
+	for _, trustedSubject := range policy.TrustedSubjects {
+		if equalSubjects(trustedSubject, subject) {
+			return subject, nil
+		}
+	}
+	return "", fmt.Errorf("signer %s is not a trusted subject", subject)
+}
+
+func decodeJwsPart(part string) ([]byte, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return nil, errors.New("invalid signature envelope encoding")
+	}
+	return decoded, nil
+}
+
+func headerTime(header map[string]interface{}, key string) (time.Time, error) {
+	value, ok := header[key].(string)
+	if !ok {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s in signature header", key)
+	}
+	return parsed, nil
+}
+
+func verifyJws(alg string, publicKey crypto.PublicKey, signingInput []byte, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "PS256", "ES256":
+		hash = crypto.SHA256
+	case "PS384", "ES384":
+		hash = crypto.SHA384
+	case "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signature algorithm %s", alg)
+	}
+	digest := hashOf(hash, signingInput)
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "PS") {
+			return fmt.Errorf("signature algorithm %s does not match the rsa certificate", alg)
+		}
+		return rsa.VerifyPSS(key, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(signature) != 2*size {
+			return fmt.Errorf("signature algorithm %s does not match the ecdsa certificate", alg)
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return errors.New("unsupported certificate key")
+	}
+}
+
+func hashOf(hash crypto.Hash, content []byte) []byte {
+	switch hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384(content)
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512(content)
+		return sum[:]
+	default:
+		sum := sha256.Sum256(content)
+		return sum[:]
+	}
+}
+
+// verifyWithKey verifies cosign signatures, which are over the sha256 of the content for ecdsa and rsa keys
+func verifyWithKey(publicKey crypto.PublicKey, content []byte, signature []byte) error {
+	digest := sha256.Sum256(content)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, content, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return errors.New("unsupported public key")
+	}
+}
+
+func parsePublicKey(publicKey string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return nil, errors.New("public key is not pem encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key, %w", err)
+	}
+	return key, nil
+}
+
+func keyFingerprint(publicKey crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(der))
+}
+
+func parseCertificates(certificates string) ([]*x509.Certificate, error) {
+	result := make([]*x509.Certificate, 0)
+	rest := []byte(certificates)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate, %w", err)
+		}
+		result = append(result, certificate)
+	}
+	return result, nil
+}
+
+func parseCertificatePool(certificates string) (*x509.CertPool, error) {
+	parsed, err := parseCertificates(certificates)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no pem encoded certificate found")
+	}
+	pool := x509.NewCertPool()
+	for _, certificate := range parsed {
+		pool.AddCert(certificate)
+	}
+	return pool, nil
+}
+
+// equalSubjects compares distinguished names irrespective of the order and spacing of their attributes
+func equalSubjects(first string, second string) bool {
+	normalize := func(name string) string {
+		attributes := strings.Split(name, ",")
+		for i := range attributes {
+			attributes[i] = strings.TrimSpace(attributes[i])
+		}
+		sort.Strings(attributes)
+		return strings.Join(attributes, ",")
+	}
+	return normalize(first) == normalize(second)
+}
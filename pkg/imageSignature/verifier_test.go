@@ -0,0 +1,261 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageSignature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry/registryClient"
+	"github.com/devtron-labs/devtron/pkg/imageSignature/bean"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+const testDigest = "sha256:5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef"
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	return key
+}
+
+func publicKeyPem(t *testing.T, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func signAsn1(t *testing.T, key *ecdsa.PrivateKey, content []byte) []byte {
+	digest := sha256.Sum256(content)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	assert.Nil(t, err)
+	return signature
+}
+
+// newTestCertificate issues a code signing certificate, self signed when parent is nil
+func newTestCertificate(t *testing.T, template *x509.Certificate, key *ecdsa.PrivateKey, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, []byte) {
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.Nil(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	return certificate, der
+}
+
+func certificatePem(der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func cosignPayload(digest string) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"registry.local/app"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, digest))
+}
+
+func TestVerifyCosignPolicy(t *testing.T) {
+	signingKey := newTestKey(t)
+	otherKey := newTestKey(t)
+	payload := cosignPayload(testDigest)
+	signature := &registryClient.CosignSignature{Payload: payload, Signature: base64.StdEncoding.EncodeToString(signAsn1(t, signingKey, payload))}
+	now := time.Now()
+
+	t.Run("signed with a trusted key", func(t *testing.T) {
+		policy := &bean.ImageSignaturePolicyDto{Id: 1, Name: "release", Verifier: bean.VerifierCosign, PublicKeys: []string{publicKeyPem(t, otherKey), publicKeyPem(t, signingKey)}}
+		result := verifyPolicy(policy, &registryClient.ImageSignatures{Digest: testDigest, Cosign: []*registryClient.CosignSignature{signature}}, nil, now)
+		assert.True(t, result.Verified, result.Message)
+		assert.Equal(t, keyFingerprint(&signingKey.PublicKey), result.Signer)
+	})
+
+	t.Run("signed with an untrusted key", func(t *testing.T) {
+		policy := &bean.ImageSignaturePolicyDto{Name: "release", Verifier: bean.VerifierCosign, PublicKeys: []string{publicKeyPem(t, otherKey)}}
+		result := verifyPolicy(policy, &registryClient.ImageSignatures{Digest: testDigest, Cosign: []*registryClient.CosignSignature{signature}}, nil, now)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("signature of another image", func(t *testing.T) {
+		policy := &bean.ImageSignaturePolicyDto{Name: "release", Verifier: bean.VerifierCosign, PublicKeys: []string{publicKeyPem(t, signingKey)}}
+		result := verifyPolicy(policy, &registryClient.ImageSignatures{Digest: "sha256:0000", Cosign: []*registryClient.CosignSignature{signature}}, nil, now)
+		assert.False(t, result.Verified)
+		assert.Contains(t, result.Message, "signature is for digest")
+	})
+
+	t.Run("unsigned image", func(t *testing.T) {
+		policy := &bean.ImageSignaturePolicyDto{Name: "release", Verifier: bean.VerifierCosign, PublicKeys: []string{publicKeyPem(t, signingKey)}}
+		result := verifyPolicy(policy, &registryClient.ImageSignatures{Digest: testDigest}, nil, now)
+		assert.False(t, result.Verified)
+		assert.Equal(t, "no cosign signature found", result.Message)
+	})
+}
+
+func TestVerifyCosignKeylessPolicy(t *testing.T) {
+	rootKey, leafKey, rekorKey := newTestKey(t), newTestKey(t), newTestKey(t)
+	signedAt := time.Now().Add(-time.Hour)
+	root, rootDer := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "fulcio"},
+		NotBefore: signedAt.Add(-time.Hour), NotAfter: signedAt.Add(24 * time.Hour)}, rootKey, nil, nil)
+	issuer, err := asn1.Marshal("https://token.actions.githubusercontent.com")
+	assert.Nil(t, err)
+	// the leaf expires minutes after signing like fulcio certificates
+	_, leafDer := newTestCertificate(t, &x509.Certificate{EmailAddresses: []string{"ci@example.com"},
+		NotBefore: signedAt.Add(-time.Minute), NotAfter: signedAt.Add(10 * time.Minute),
+		ExtraExtensions: []pkix.Extension{{Id: fulcioIssuerOid, Value: issuer}}}, leafKey, root, rootKey)
+
+	payload := cosignPayload(testDigest)
+	encodedSignature := base64.StdEncoding.EncodeToString(signAsn1(t, leafKey, payload))
+	payloadHash := sha256.Sum256(payload)
+	body := fmt.Sprintf(`{"apiVersion":"0.0.1","kind":"hashedrekord","spec":{"data":{"hash":{"algorithm":"sha256","value":"%s"}},"signature":{"content":"%s"}}}`,
+		hex.EncodeToString(payloadHash[:]), encodedSignature)
+	entry := &rekorPayload{Body: base64.StdEncoding.EncodeToString([]byte(body)), IntegratedTime: signedAt.Unix(), LogID: "c0d23d6a", LogIndex: 42}
+	canonicalEntry, err := json.Marshal(entry)
+	assert.Nil(t, err)
+	bundle, err := json.Marshal(&rekorBundle{SignedEntryTimestamp: base64.StdEncoding.EncodeToString(signAsn1(t, rekorKey, canonicalEntry)), Payload: entry})
+	assert.Nil(t, err)
+	signature := &registryClient.CosignSignature{Payload: payload, Signature: encodedSignature, Certificate: certificatePem(leafDer), Bundle: string(bundle)}
+	signatures := &registryClient.ImageSignatures{Digest: testDigest, Cosign: []*registryClient.CosignSignature{signature}}
+
+	roots, err := newTrustRoots(certificatePem(rootDer), publicKeyPem(t, rekorKey))
+	assert.Nil(t, err)
+	tests := []struct {
+		name     string
+		identity *bean.KeylessIdentity
+		roots    *trustRoots
+		verified bool
+	}{
+		{name: "trusted identity", identity: &bean.KeylessIdentity{Issuer: "https://token.actions.githubusercontent.com", Subject: "ci@example.com"}, roots: roots, verified: true},
+		{name: "trusted identity regex", identity: &bean.KeylessIdentity{Issuer: "https://token.actions.githubusercontent.com", SubjectRegex: ".*@example.com"}, roots: roots, verified: true},
+		{name: "other issuer", identity: &bean.KeylessIdentity{Issuer: "https://accounts.google.com", Subject: "ci@example.com"}, roots: roots},
+		{name: "other subject", identity: &bean.KeylessIdentity{Issuer: "https://token.actions.githubusercontent.com", Subject: "dev@example.com"}, roots: roots},
+		{name: "roots not configured", identity: &bean.KeylessIdentity{Issuer: "https://token.actions.githubusercontent.com", Subject: "ci@example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &bean.ImageSignaturePolicyDto{Name: "keyless", Verifier: bean.VerifierCosign, KeylessIdentities: []*bean.KeylessIdentity{tt.identity}}
+			result := verifyPolicy(policy, signatures, tt.roots, time.Now())
+			assert.Equal(t, tt.verified, result.Verified, result.Message)
+		})
+	}
+
+	t.Run("bundle not signed by rekor", func(t *testing.T) {
+		otherRoots, err := newTrustRoots(certificatePem(rootDer), publicKeyPem(t, newTestKey(t)))
+		assert.Nil(t, err)
+		policy := &bean.ImageSignaturePolicyDto{Name: "keyless", Verifier: bean.VerifierCosign,
+			KeylessIdentities: []*bean.KeylessIdentity{{Issuer: "https://token.actions.githubusercontent.com", Subject: "ci@example.com"}}}
+		result := verifyPolicy(policy, signatures, otherRoots, time.Now())
+		assert.False(t, result.Verified)
+		assert.Contains(t, result.Message, "not signed by rekor")
+	})
+}
+
+func newJwsEnvelope(t *testing.T, key *ecdsa.PrivateKey, chain [][]byte, header map[string]interface{}, digest string) []byte {
+	protectedHeader, err := json.Marshal(header)
+	assert.Nil(t, err)
+	protected := base64.RawURLEncoding.EncodeToString(protectedHeader)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"targetArtifact":{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"%s","size":1024}}`, digest)))
+	hash := sha256.Sum256([]byte(protected + "." + payload))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	assert.Nil(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	x5c := make([]string, 0, len(chain))
+	for _, der := range chain {
+		x5c = append(x5c, base64.StdEncoding.EncodeToString(der))
+	}
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payload":   payload,
+		"protected": protected,
+		"header":    map[string]interface{}{"x5c": x5c},
+		"signature": base64.RawURLEncoding.EncodeToString(signature),
+	})
+	assert.Nil(t, err)
+	return envelope
+}
+
+func TestVerifyNotationPolicy(t *testing.T) {
+	rootKey, leafKey := newTestKey(t), newTestKey(t)
+	now := time.Now()
+	root, rootDer := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "acme root"},
+		NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}, rootKey, nil, nil)
+	_, leafDer := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "release", Organization: []string{"acme"}},
+		NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}, leafKey, root, rootKey)
+	header := map[string]interface{}{"alg": "ES256", "cty": "application/vnd.cncf.notary.payload.v1+json", notationSigningTimeHeader: now.Format(time.RFC3339)}
+	signature := &registryClient.NotationSignature{MediaType: registryClient.NotationJwsMediaType,
+		Envelope: newJwsEnvelope(t, leafKey, [][]byte{leafDer, rootDer}, header, testDigest)}
+	signatures := &registryClient.ImageSignatures{Digest: testDigest, Notation: []*registryClient.NotationSignature{signature}}
+
+	t.Run("signed by a trusted certificate", func(t *testing.T) {
+		policy := &bean.ImageSignaturePolicyDto{Name: "notation", Verifier: bean.VerifierNotation, TrustedCertificates: []string{certificatePem(rootDer)},
+			TrustedSubjects: []string{"O=acme, CN=release"}}
+		result := verifyPolicy(policy, signatures, nil, now)
+		assert.True(t, result.Verified, result.Message)
+		assert.Equal(t, "CN=release,O=acme", result.Signer)
+	})
+
+	t.Run("untrusted subject", func(t *testing.T) {
+		policy := &bean.ImageSignaturePolicyDto{Name: "notation", Verifier: bean.VerifierNotation, TrustedCertificates: []string{certificatePem(rootDer)},
+			TrustedSubjects: []string{"CN=staging,O=acme"}}
+		result := verifyPolicy(policy, signatures, nil, now)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("untrusted root", func(t *testing.T) {
+		_, otherRootDer := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "other root"},
+			NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}, newTestKey(t), nil, nil)
+		policy := &bean.ImageSignaturePolicyDto{Name: "notation", Verifier: bean.VerifierNotation, TrustedCertificates: []string{certificatePem(otherRootDer)}}
+		result := verifyPolicy(policy, signatures, nil, now)
+		assert.False(t, result.Verified)
+		assert.Contains(t, result.Message, "signing certificate is not trusted")
+	})
+
+	t.Run("expired signature", func(t *testing.T) {
+		expiredHeader := map[string]interface{}{"alg": "ES256", notationSigningTimeHeader: now.Format(time.RFC3339), notationExpiryHeader: now.Add(-time.Minute).Format(time.RFC3339)}
+		expired := &registryClient.NotationSignature{MediaType: registryClient.NotationJwsMediaType,
+			Envelope: newJwsEnvelope(t, leafKey, [][]byte{leafDer}, expiredHeader, testDigest)}
+		policy := &bean.ImageSignaturePolicyDto{Name: "notation", Verifier: bean.VerifierNotation, TrustedCertificates: []string{certificatePem(rootDer)}}
+		result := verifyPolicy(policy, &registryClient.ImageSignatures{Digest: testDigest, Notation: []*registryClient.NotationSignature{expired}}, nil, now)
+		assert.False(t, result.Verified)
+		assert.Contains(t, result.Message, "signature expired")
+	})
+}
+
+func TestGetVerificationResult(t *testing.T) {
+	result := getVerificationResult("registry.local/app:v1", testDigest, []*bean.PolicyResult{
+		{PolicyName: "release", Verified: true},
+		{PolicyName: "notation", Message: "no notation signature found"},
+	})
+	assert.False(t, result.IsVerified())
+	assert.Equal(t, "notation: no notation signature found", result.Message)
+	assert.True(t, getVerificationResult("registry.local/app:v1", testDigest, nil).IsVerified())
+}
@@ -57,6 +57,8 @@ type K8sCapacityService interface {
 	DrainNode(ctx context.Context, request *bean.NodeUpdateRequestDto) (string, error)
 	EditNodeTaints(ctx context.Context, request *bean.NodeUpdateRequestDto) (string, error)
 	GetNode(ctx context.Context, clusterId int, nodeName string) (*corev1.Node, error)
+	// GetPodCapacityDetailsByNamespace returns the resource requests and limits of the running pods of a namespace
+	GetPodCapacityDetailsByNamespace(ctx context.Context, cluster *cluster.ClusterBean, namespace string) ([]*bean.PodCapacityDetail, error)
 }
 
 type K8sCapacityServiceImpl struct {
@@ -327,6 +329,31 @@ func (impl *K8sCapacityServiceImpl) GetNodeCapacityDetailByNameAndCluster(ctx co
 	return nodeDetail, nil
 }
 
+func (impl *K8sCapacityServiceImpl) GetPodCapacityDetailsByNamespace(ctx context.Context, cluster *cluster.ClusterBean, namespace string) ([]*bean.PodCapacityDetail, error) {
+	_, _, k8sClientSet, err := impl.k8sCommonService.GetK8sConfigAndClients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := impl.K8sUtil.GetPodsListForNamespace(ctx, k8sClientSet, namespace)
+	if err != nil {
+		impl.logger.Errorw("error in getting pod list", "clusterId", cluster.Id, "namespace", namespace, "err", err)
+		return nil, err
+	}
+	podDetails := make([]*bean.PodCapacityDetail, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		requests, limits := resourcehelper.PodRequestsAndLimits(&pod)
+		podDetail := getPodDetail(pod, resource.Quantity{}, resource.Quantity{}, limits, requests)
+		//allocatable is node specific, percentages do not apply to a namespace listing
+		podDetail.Cpu.RequestPercentage, podDetail.Cpu.LimitPercentage = "", ""
+		podDetail.Memory.RequestPercentage, podDetail.Memory.LimitPercentage = "", ""
+		podDetails = append(podDetails, podDetail)
+	}
+	return podDetails, nil
+}
+
 func (impl *K8sCapacityServiceImpl) getNodeGroupAndTaints(node *corev1.Node) (string, []*bean.LabelAnnotationTaintObject) {
 
 	nodeGroup := impl.getNodeGroup(node)
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package leaderElection
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/leaderElection/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"os"
+	"time"
+)
+
+// LeaderElectionService lets crons running on every replica of the orchestrator agree on a single replica doing the work
+type LeaderElectionService interface {
+	// IsLeader acquires or renews the named lease for this replica, the lease is held for leaseDuration after the last renewal.
+	// A cron should renew more often than leaseDuration to stay the leader
+	IsLeader(leaseName string, leaseDuration time.Duration) bool
+}
+
+type LeaderElectionServiceImpl struct {
+	logger                *zap.SugaredLogger
+	leaderLeaseRepository repository.LeaderLeaseRepository
+	holderIdentity        string
+}
+
+func NewLeaderElectionServiceImpl(logger *zap.SugaredLogger, leaderLeaseRepository repository.LeaderLeaseRepository) *LeaderElectionServiceImpl {
+	hostname, err := os.Hostname()
+	if err != nil {
+		logger.Warnw("error in getting hostname for leader election identity", "err", err)
+	}
+	return &LeaderElectionServiceImpl{
+		logger:                logger,
+		leaderLeaseRepository: leaderLeaseRepository,
+		// the uuid keeps identities unique across restarts of a pod with the same name
+		holderIdentity: fmt.Sprintf("%s-%s", hostname, uuid.NewString()),
+	}
+}
+
+func (impl *LeaderElectionServiceImpl) IsLeader(leaseName string, leaseDuration time.Duration) bool {
+	now := time.Now()
+	isLeader, err := impl.leaderLeaseRepository.TryAcquire(leaseName, impl.holderIdentity, now, now.Add(leaseDuration))
+	if err != nil {
+		impl.logger.Errorw("error in acquiring leader lease", "leaseName", leaseName, "holderIdentity", impl.holderIdentity, "err", err)
+		return false
+	}
+	return isLeader
+}
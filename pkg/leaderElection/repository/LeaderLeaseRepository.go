@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type LeaderLeaseRepository interface {
+	// TryAcquire takes or renews the lease for the holder, it fails if another holder has an unexpired lease
+	TryAcquire(leaseName, holderIdentity string, now, expiresOn time.Time) (bool, error)
+}
+
+type LeaderLeaseRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewLeaderLeaseRepositoryImpl(dbConnection *pg.DB) *LeaderLeaseRepositoryImpl {
+	return &LeaderLeaseRepositoryImpl{
+		dbConnection: dbConnection,
+	}
+}
+
+func (impl LeaderLeaseRepositoryImpl) TryAcquire(leaseName, holderIdentity string, now, expiresOn time.Time) (bool, error) {
+	query := "INSERT INTO cron_leader_lease (lease_name, holder_identity, renewed_on, expires_on) VALUES (?, ?, ?, ?) " +
+		"ON CONFLICT (lease_name) DO UPDATE SET holder_identity = EXCLUDED.holder_identity, " +
+		"renewed_on = EXCLUDED.renewed_on, expires_on = EXCLUDED.expires_on " +
+		"WHERE cron_leader_lease.holder_identity = EXCLUDED.holder_identity OR cron_leader_lease.expires_on < ?;"
+	result, err := impl.dbConnection.Exec(query, leaseName, holderIdentity, now, expiresOn, now)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	util "github.com/devtron-labs/devtron/util/event"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+// ChannelNotificationSenderImpl delivers notifications to the teams, discord and pager duty channels, the notifier
+// service does not know these channels so the orchestrator posts to them itself. Slack and webhook channels are
+// supported too, for the notifications which are not pipeline events and so are never sent to the notifier service
+type ChannelNotificationSenderImpl struct {
+	logger              *zap.SugaredLogger
+	client              *http.Client
+	teamsRepository     repository.TeamsNotificationRepository
+	discordRepository   repository.DiscordNotificationRepository
+	pagerDutyRepository repository.PagerDutyNotificationRepository
+	slackRepository     repository.SlackNotificationRepository
+	webhookRepository   repository.WebhookNotificationRepository
+}
+
+func NewChannelNotificationSenderImpl(logger *zap.SugaredLogger, client *http.Client,
+	teamsRepository repository.TeamsNotificationRepository, discordRepository repository.DiscordNotificationRepository,
+	pagerDutyRepository repository.PagerDutyNotificationRepository, slackRepository repository.SlackNotificationRepository,
+	webhookRepository repository.WebhookNotificationRepository) *ChannelNotificationSenderImpl {
+	return &ChannelNotificationSenderImpl{
+		logger:              logger,
+		client:              client,
+		teamsRepository:     teamsRepository,
+		discordRepository:   discordRepository,
+		pagerDutyRepository: pagerDutyRepository,
+		slackRepository:     slackRepository,
+		webhookRepository:   webhookRepository,
+	}
+}
+
+// SendNotification posts the message to the channel config, it returns false when the channel has nothing to deliver for the message
+func (impl *ChannelNotificationSenderImpl) SendNotification(destination util.Channel, configId int, message *beans.ChannelMessage) (bool, error) {
+	switch destination {
+	case util.Teams:
+		teamsConfig, err := impl.teamsRepository.FindOne(configId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching teams config", "configId", configId, "err", err)
+			return false, err
+		}
+		return true, impl.post(teamsConfig.WebHookUrl, nil, buildTeamsAdaptiveCard(message))
+	case util.Discord:
+		discordConfig, err := impl.discordRepository.FindOne(configId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching discord config", "configId", configId, "err", err)
+			return false, err
+		}
+		return true, impl.post(discordConfig.WebHookUrl, nil, buildDiscordEmbed(message))
+	case util.PagerDuty:
+		pagerDutyConfig, err := impl.pagerDutyRepository.FindOne(configId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching pager duty config", "configId", configId, "err", err)
+			return false, err
+		}
+		event := buildPagerDutyEvent(pagerDutyConfig, message)
+		if event == nil {
+			return false, nil
+		}
+		return true, impl.post(beans.PAGER_DUTY_EVENTS_URL, nil, event)
+	case util.Slack:
+		slackConfig, err := impl.slackRepository.FindOne(configId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching slack config", "configId", configId, "err", err)
+			return false, err
+		}
+		return true, impl.post(slackConfig.WebHookUrl, nil, buildSlackMessage(message))
+	case util.Webhook:
+		webhookConfig, err := impl.webhookRepository.FindOne(configId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching webhook config", "configId", configId, "err", err)
+			return false, err
+		}
+		if !webhookConfig.Active {
+			return false, nil
+		}
+		return true, impl.post(webhookConfig.WebHookUrl, getWebhookHeaders(webhookConfig), buildWebhookMessage(message))
+	}
+	return false, fmt.Errorf("notifications to %s are not sent by the orchestrator", destination)
+}
+
+func (impl *ChannelNotificationSenderImpl) post(url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		impl.logger.Errorw("error in marshaling channel notification", "err", err)
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		impl.logger.Errorw("error in creating channel notification request", "err", err)
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := impl.client.Do(req)
+	if err != nil {
+		impl.logger.Errorw("error in sending channel notification", "err", err)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		impl.logger.Errorw("channel notification rejected", "status", resp.StatusCode, "response", string(respBody))
+		return fmt.Errorf("channel notification rejected with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChannelNotificationSenderImpl_post(t *testing.T) {
+	var received, token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("Content-Type")
+		token = r.Header.Get("X-Token")
+		if r.URL.Path == "/rejected" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("invalid payload"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	sender := NewChannelNotificationSenderImpl(zap.NewNop().Sugar(), server.Client(), nil, nil, nil, nil, nil)
+
+	err := sender.post(server.URL+"/accepted", map[string]string{"X-Token": "secret"}, map[string]string{"content": "hello"})
+	assert.Nil(t, err)
+	assert.Equal(t, "application/json", received)
+	assert.Equal(t, "secret", token)
+
+	err = sender.post(server.URL+"/rejected", nil, map[string]string{"content": "hello"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "invalid payload")
+}
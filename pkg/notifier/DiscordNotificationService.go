@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type DiscordNotificationService interface {
+	SaveOrEditNotificationConfig(channelReq []beans.DiscordConfigDto, userId int32) ([]int, error)
+	FetchDiscordNotificationConfigById(id int) (*beans.DiscordConfigDto, error)
+	FetchAllDiscordNotificationConfig() ([]*beans.DiscordConfigDto, error)
+	FetchAllDiscordNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error)
+	DeleteNotificationConfig(deleteReq *beans.DiscordConfigDto, userId int32) error
+}
+
+type DiscordNotificationServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	discordRepository              repository.DiscordNotificationRepository
+	notificationSettingsRepository repository.NotificationSettingsRepository
+}
+
+func NewDiscordNotificationServiceImpl(logger *zap.SugaredLogger, discordRepository repository.DiscordNotificationRepository,
+	notificationSettingsRepository repository.NotificationSettingsRepository) *DiscordNotificationServiceImpl {
+	return &DiscordNotificationServiceImpl{
+		logger:                         logger,
+		discordRepository:              discordRepository,
+		notificationSettingsRepository: notificationSettingsRepository,
+	}
+}
+
+func (impl *DiscordNotificationServiceImpl) SaveOrEditNotificationConfig(channelReq []beans.DiscordConfigDto, userId int32) ([]int, error) {
+	var responseIds []int
+	discordConfigs := buildDiscordNewConfigs(channelReq, userId)
+	for _, config := range discordConfigs {
+		if config.Id != 0 {
+			model, err := impl.discordRepository.FindOne(config.Id)
+			if err != nil {
+				impl.logger.Errorw("err while fetching discord config", "err", err)
+				return []int{}, err
+			}
+			impl.buildConfigUpdateModel(config, model, userId)
+			_, uErr := impl.discordRepository.UpdateDiscordConfig(model)
+			if uErr != nil {
+				impl.logger.Errorw("err while updating discord config", "err", uErr)
+				return []int{}, uErr
+			}
+		} else {
+			_, iErr := impl.discordRepository.SaveDiscordConfig(config)
+			if iErr != nil {
+				impl.logger.Errorw("err while inserting discord config", "err", iErr)
+				return []int{}, iErr
+			}
+		}
+		responseIds = append(responseIds, config.Id)
+	}
+	return responseIds, nil
+}
+
+func (impl *DiscordNotificationServiceImpl) FetchDiscordNotificationConfigById(id int) (*beans.DiscordConfigDto, error) {
+	discordConfig, err := impl.discordRepository.FindOne(id)
+	if err != nil {
+		impl.logger.Errorw("cannot find discord config", "id", id, "err", err)
+		return nil, err
+	}
+	discordConfigDto := adaptDiscordConfig(*discordConfig)
+	return &discordConfigDto, nil
+}
+
+func (impl *DiscordNotificationServiceImpl) FetchAllDiscordNotificationConfig() ([]*beans.DiscordConfigDto, error) {
+	responseDto := make([]*beans.DiscordConfigDto, 0)
+	discordConfigs, err := impl.discordRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all discord config", "err", err)
+		return []*beans.DiscordConfigDto{}, err
+	}
+	for _, discordConfig := range discordConfigs {
+		discordConfigDto := adaptDiscordConfig(discordConfig)
+		responseDto = append(responseDto, &discordConfigDto)
+	}
+	return responseDto, nil
+}
+
+func (impl *DiscordNotificationServiceImpl) FetchAllDiscordNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error) {
+	var responseDto []*beans.NotificationChannelAutoResponse
+	discordConfigs, err := impl.discordRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all discord config", "err", err)
+		return []*beans.NotificationChannelAutoResponse{}, err
+	}
+	for _, discordConfig := range discordConfigs {
+		responseDto = append(responseDto, &beans.NotificationChannelAutoResponse{
+			Id:         discordConfig.Id,
+			ConfigName: discordConfig.ConfigName,
+		})
+	}
+	return responseDto, nil
+}
+
+func (impl *DiscordNotificationServiceImpl) DeleteNotificationConfig(deleteReq *beans.DiscordConfigDto, userId int32) error {
+	existingConfig, err := impl.discordRepository.FindOne(deleteReq.Id)
+	if err != nil {
+		impl.logger.Errorw("No matching entry found for delete", "err", err, "id", deleteReq.Id)
+		return err
+	}
+	notifications, err := impl.notificationSettingsRepository.FindNotificationSettingsByConfigIdAndConfigType(deleteReq.Id, beans.DISCORD_CONFIG_TYPE)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in deleting discord config", "config", deleteReq)
+		return err
+	}
+	if len(notifications) > 0 {
+		impl.logger.Errorw("found notifications using this config, cannot delete", "config", deleteReq)
+		return fmt.Errorf(" Please delete all notifications using this config before deleting")
+	}
+
+	existingConfig.UpdatedOn = time.Now()
+	existingConfig.UpdatedBy = userId
+	//deleting discord config
+	err = impl.discordRepository.MarkDiscordConfigDeleted(existingConfig)
+	if err != nil {
+		impl.logger.Errorw("error in deleting discord config", "err", err, "id", existingConfig.Id)
+		return err
+	}
+	return nil
+}
+
+func adaptDiscordConfig(discordConfig repository.DiscordConfig) beans.DiscordConfigDto {
+	return beans.DiscordConfigDto{
+		OwnerId:     discordConfig.OwnerId,
+		WebhookUrl:  discordConfig.WebHookUrl,
+		ConfigName:  discordConfig.ConfigName,
+		Description: discordConfig.Description,
+		Id:          discordConfig.Id,
+	}
+}
+
+func buildDiscordNewConfigs(discordReq []beans.DiscordConfigDto, userId int32) []*repository.DiscordConfig {
+	var discordConfigs []*repository.DiscordConfig
+	for _, c := range discordReq {
+		discordConfig := &repository.DiscordConfig{
+			Id:          c.Id,
+			ConfigName:  c.ConfigName,
+			WebHookUrl:  c.WebhookUrl,
+			Description: c.Description,
+			OwnerId:     userId,
+			AuditLog:    sql.NewDefaultAuditLog(userId),
+		}
+		discordConfigs = append(discordConfigs, discordConfig)
+	}
+	return discordConfigs
+}
+
+func (impl *DiscordNotificationServiceImpl) buildConfigUpdateModel(discordConfig *repository.DiscordConfig, model *repository.DiscordConfig, userId int32) {
+	model.WebHookUrl = discordConfig.WebHookUrl
+	model.ConfigName = discordConfig.ConfigName
+	model.Description = discordConfig.Description
+	model.OwnerId = discordConfig.OwnerId
+	model.UpdatedOn = time.Now()
+	model.UpdatedBy = userId
+}
+
+const (
+	discordColorInfo    = 0x3498DB
+	discordColorSuccess = 0x2ECC71
+	discordColorWarning = 0xF1C40F
+	discordColorFailure = 0xE74C3C
+)
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	Url         string              `json:"url,omitempty"`
+	Color       int                 `json:"color"`
+	Fields      []discordEmbedField `json:"fields,omitempty"`
+}
+
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func buildDiscordEmbed(message *beans.ChannelMessage) *discordMessage {
+	embed := discordEmbed{
+		Title:       message.Title,
+		Description: message.Text,
+		Url:         message.Link,
+		Color:       getDiscordColor(message.Level),
+	}
+	for _, fact := range message.Facts {
+		// discord rejects the whole embed when a field has an empty value
+		if len(fact.Value) == 0 {
+			continue
+		}
+		embed.Fields = append(embed.Fields, discordEmbedField{Name: fact.Name, Value: fact.Value, Inline: true})
+	}
+	return &discordMessage{Embeds: []discordEmbed{embed}}
+}
+
+func getDiscordColor(level beans.ChannelMessageLevel) int {
+	switch level {
+	case beans.ChannelMessageLevelSuccess:
+		return discordColorSuccess
+	case beans.ChannelMessageLevelWarning:
+		return discordColorWarning
+	case beans.ChannelMessageLevelFailure:
+		return discordColorFailure
+	default:
+		return discordColorInfo
+	}
+}
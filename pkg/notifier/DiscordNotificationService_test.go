@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_isDiscordWebhookUrl(t *testing.T) {
+	tests := []struct {
+		name       string
+		webhookUrl string
+		valid      bool
+	}{
+		{"discord webhook", "https://discord.com/api/webhooks/123/token", true},
+		{"legacy discord webhook", "https://discordapp.com/api/webhooks/123/token", true},
+		{"slack webhook", "https://hooks.slack.com/services/T000/B000/XXXX", false},
+		{"plain http", "http://discord.com/api/webhooks/123/token", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, beans.IsDiscordWebhookUrl(tt.webhookUrl))
+		})
+	}
+}
+
+func Test_buildDiscordNewConfigs(t *testing.T) {
+	configs := buildDiscordNewConfigs([]beans.DiscordConfigDto{
+		{Id: 2, ConfigName: "on-call", WebhookUrl: "https://discord.com/api/webhooks/123/token", Description: "prod alerts"},
+	}, 1)
+	assert.Len(t, configs, 1)
+	assert.Equal(t, 2, configs[0].Id)
+	assert.Equal(t, "on-call", configs[0].ConfigName)
+	assert.Equal(t, "https://discord.com/api/webhooks/123/token", configs[0].WebHookUrl)
+	assert.Equal(t, int32(1), configs[0].OwnerId)
+	assert.Equal(t, int32(1), configs[0].CreatedBy)
+}
+
+func Test_buildDiscordEmbed(t *testing.T) {
+	message := &beans.ChannelMessage{
+		Title: "Build succeeded: payments",
+		Link:  "https://devtron.example.com/dashboard/app/1/ci-details/2/3/artifacts",
+		Level: beans.ChannelMessageLevelSuccess,
+		Facts: []beans.ChannelMessageFact{{Name: "Pipeline", Value: "ci-main"}, {Name: "Environment", Value: ""}},
+	}
+	body, err := json.Marshal(buildDiscordEmbed(message))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"embeds":[{
+		"title":"Build succeeded: payments",
+		"url":"https://devtron.example.com/dashboard/app/1/ci-details/2/3/artifacts",
+		"color":3066993,
+		"fields":[{"name":"Pipeline","value":"ci-main","inline":true}]
+	}]}`, string(body))
+	assert.Equal(t, discordColorFailure, buildDiscordEmbed(&beans.ChannelMessage{Level: beans.ChannelMessageLevelFailure}).Embeds[0].Color)
+}
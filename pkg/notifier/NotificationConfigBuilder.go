@@ -29,7 +29,7 @@ import (
 type NotificationConfigBuilder interface {
 	BuildNotificationSettingsConfig(notificationSettingsRequest *beans.NotificationConfigRequest, existingNotificationSettingsConfig *repository.NotificationSettingsView, userId int32) (*repository.NotificationSettingsView, error)
 	BuildNewNotificationSettings(notificationSettingsRequest *beans.NotificationConfigRequest, notificationSettingsView *repository.NotificationSettingsView) ([]repository.NotificationSettings, error)
-	BuildNotificationSettingWithPipeline(teamId *int, envId *int, appId *int, pipelineId *int, clusterId *int, pipelineType util.PipelineType, eventTypeId int, viewId int, providers []*beans.Provider) (repository.NotificationSettings, error)
+	BuildNotificationSettingWithPipeline(teamId *int, envId *int, appId *int, pipelineId *int, clusterId *int, pipelineType util.PipelineType, eventTypeId int, viewId int, providers []*beans.Provider, settingOptions beans.NotificationSettingOptions) (repository.NotificationSettings, error)
 }
 
 type NotificationConfigBuilderImpl struct {
@@ -52,6 +52,7 @@ func (impl NotificationConfigBuilderImpl) BuildNotificationSettingsConfig(notifi
 	nsConfig.PipelineType = notificationSettingsRequest.PipelineType
 	nsConfig.EventTypeIds = notificationSettingsRequest.EventTypeIds
 	nsConfig.Providers = notificationSettingsRequest.Providers
+	nsConfig.NotificationSettingOptions = notificationSettingsRequest.NotificationSettingOptions
 
 	config, err := json.Marshal(nsConfig)
 	if err != nil {
@@ -84,7 +85,7 @@ func (impl NotificationConfigBuilderImpl) BuildNewNotificationSettings(notificat
 	var notificationSettings []repository.NotificationSettings
 	for _, item := range tempRequest {
 		for _, e := range notificationSettingsRequest.EventTypeIds {
-			notificationSetting, err := impl.BuildNotificationSettingWithPipeline(item.TeamId, item.EnvId, item.AppId, item.PipelineId, item.ClusterId, notificationSettingsRequest.PipelineType, e, notificationSettingsView.Id, notificationSettingsRequest.Providers)
+			notificationSetting, err := impl.BuildNotificationSettingWithPipeline(item.TeamId, item.EnvId, item.AppId, item.PipelineId, item.ClusterId, notificationSettingsRequest.PipelineType, e, notificationSettingsView.Id, notificationSettingsRequest.Providers, notificationSettingsRequest.NotificationSettingOptions)
 			if err != nil {
 				impl.logger.Error(err)
 				return nil, err
@@ -112,7 +113,7 @@ func (impl NotificationConfigBuilderImpl) buildNotificationSetting(notificationS
 	return notificationSetting, nil
 }
 
-func (impl NotificationConfigBuilderImpl) BuildNotificationSettingWithPipeline(teamId *int, envId *int, appId *int, pipelineId *int, clusterId *int, pipelineType util.PipelineType, eventTypeId int, viewId int, providers []*beans.Provider) (repository.NotificationSettings, error) {
+func (impl NotificationConfigBuilderImpl) BuildNotificationSettingWithPipeline(teamId *int, envId *int, appId *int, pipelineId *int, clusterId *int, pipelineType util.PipelineType, eventTypeId int, viewId int, providers []*beans.Provider, settingOptions beans.NotificationSettingOptions) (repository.NotificationSettings, error) {
 
 	if teamId == nil && appId == nil && envId == nil && pipelineId == nil && clusterId == nil {
 		return repository.NotificationSettings{}, errors.New("no filter criteria is selected")
@@ -132,6 +133,9 @@ func (impl NotificationConfigBuilderImpl) BuildNotificationSettingWithPipeline(t
 		Config:       string(providersJson),
 		ViewId:       viewId,
 		ClusterId:    clusterId,
+		Condition:          settingOptions.Condition,
+		DigestIntervalMins: settingOptions.DigestIntervalMins,
+		CooldownMins:       settingOptions.CooldownMins,
 	}
 	return notificationSetting, nil
 }
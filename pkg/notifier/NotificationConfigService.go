@@ -19,10 +19,14 @@ package notifier
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/cel"
+	client "github.com/devtron-labs/devtron/client/events"
 	clusterService "github.com/devtron-labs/devtron/pkg/cluster"
 	"github.com/devtron-labs/devtron/pkg/notifier/beans"
 	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
 	util3 "github.com/devtron-labs/devtron/util"
+	"net/http"
 	"time"
 
 	"github.com/devtron-labs/devtron/internal/sql/repository/app"
@@ -57,6 +61,9 @@ type NotificationConfigServiceImpl struct {
 	pipelineRepository             pipelineConfig.PipelineRepository
 	slackRepository                repository.SlackNotificationRepository
 	webhookRepository              repository.WebhookNotificationRepository
+	teamsRepository                repository.TeamsNotificationRepository
+	discordRepository              repository.DiscordNotificationRepository
+	pagerDutyRepository            repository.PagerDutyNotificationRepository
 	sesRepository                  repository.SESNotificationRepository
 	smtpRepository                 repository.SMTPNotificationRepository
 	teamRepository                 repository2.TeamRepository
@@ -65,6 +72,7 @@ type NotificationConfigServiceImpl struct {
 	appRepository                  app.AppRepository
 	userRepository                 repository4.UserRepository
 	ciPipelineMaterialRepository   pipelineConfig.CiPipelineMaterialRepository
+	celEvaluatorService            cel.EvaluatorService
 }
 
 const allNonProdEnvsName = "All non-prod environments"
@@ -73,9 +81,12 @@ const allProdEnvsName = "All prod environments"
 func NewNotificationConfigServiceImpl(logger *zap.SugaredLogger, notificationSettingsRepository repository.NotificationSettingsRepository, notificationConfigBuilder NotificationConfigBuilder, ciPipelineRepository pipelineConfig.CiPipelineRepository,
 	pipelineRepository pipelineConfig.PipelineRepository, slackRepository repository.SlackNotificationRepository, webhookRepository repository.WebhookNotificationRepository,
 	sesRepository repository.SESNotificationRepository, smtpRepository repository.SMTPNotificationRepository,
+	teamsRepository repository.TeamsNotificationRepository, discordRepository repository.DiscordNotificationRepository,
+	pagerDutyRepository repository.PagerDutyNotificationRepository,
 	teamRepository repository2.TeamRepository,
 	environmentRepository repository3.EnvironmentRepository, appRepository app.AppRepository, clusterService clusterService.ClusterService,
-	userRepository repository4.UserRepository, ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository) *NotificationConfigServiceImpl {
+	userRepository repository4.UserRepository, ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository,
+	celEvaluatorService cel.EvaluatorService) *NotificationConfigServiceImpl {
 	return &NotificationConfigServiceImpl{
 		logger:                         logger,
 		notificationSettingsRepository: notificationSettingsRepository,
@@ -85,6 +96,9 @@ func NewNotificationConfigServiceImpl(logger *zap.SugaredLogger, notificationSet
 		sesRepository:                  sesRepository,
 		slackRepository:                slackRepository,
 		webhookRepository:              webhookRepository,
+		teamsRepository:                teamsRepository,
+		discordRepository:              discordRepository,
+		pagerDutyRepository:            pagerDutyRepository,
 		smtpRepository:                 smtpRepository,
 		teamRepository:                 teamRepository,
 		environmentRepository:          environmentRepository,
@@ -92,6 +106,7 @@ func NewNotificationConfigServiceImpl(logger *zap.SugaredLogger, notificationSet
 		userRepository:                 userRepository,
 		ciPipelineMaterialRepository:   ciPipelineMaterialRepository,
 		clusterService:                 clusterService,
+		celEvaluatorService:            celEvaluatorService,
 	}
 }
 
@@ -170,6 +185,9 @@ func (impl *NotificationConfigServiceImpl) CreateOrUpdateNotificationSettings(no
 	defer tx.Rollback()
 
 	for _, request := range notificationSettingsRequest.NotificationConfigRequest {
+		if err = impl.validateSettingOptions(notificationSettingsRequest.Providers, request.NotificationSettingOptions); err != nil {
+			return 0, err
+		}
 		if request.Id != 0 {
 			_, err := impl.notificationSettingsRepository.DeleteNotificationSettingsByConfigId(request.Id, tx)
 			if err != nil {
@@ -217,6 +235,40 @@ func (impl *NotificationConfigServiceImpl) UpdateNotificationSettings(notificati
 	return configId, nil
 }
 
+// validateSettingOptions checks the options of a notification setting against its providers. Conditions, digests and cooldowns
+// are applied by the orchestrator and only to the channels it delivers, the notifier service would notify the others regardless.
+func (impl *NotificationConfigServiceImpl) validateSettingOptions(providers []*beans.Provider, options beans.NotificationSettingOptions) error {
+	err := impl.validateCondition(options.Condition)
+	if err != nil {
+		return err
+	}
+	if len(options.Condition) == 0 && options.DigestIntervalMins == 0 && options.CooldownMins == 0 {
+		return nil
+	}
+	for _, provider := range providers {
+		if !client.IsChannelDestination(provider.Destination) {
+			errMsg := fmt.Sprintf("notification conditions, digests and cooldowns are supported only for teams, discord and pager duty providers, %s is not supported", provider.Destination)
+			return util2.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(errMsg).WithInternalMessage(errMsg)
+		}
+	}
+	return nil
+}
+
+// validateCondition type checks the CEL condition of a notification setting against the event params it is evaluated with
+func (impl *NotificationConfigServiceImpl) validateCondition(condition string) error {
+	if len(condition) == 0 {
+		return nil
+	}
+	_, _, err := impl.celEvaluatorService.Validate(client.GetNotificationConditionRequest(condition))
+	if err != nil {
+		impl.logger.Errorw("invalid notification condition", "condition", condition, "err", err)
+		return util2.NewApiError().WithHttpStatusCode(http.StatusBadRequest).
+			WithUserMessage(fmt.Sprintf("invalid notification condition: %s", err.Error())).
+			WithInternalMessage(err.Error())
+	}
+	return nil
+}
+
 func (impl *NotificationConfigServiceImpl) BuildNotificationSettingsResponse(notificationSettingViews []*repository.NotificationSettingsViewWithAppEnv) ([]*beans.NotificationSettingsResponse, int, error) {
 	var notificationSettingsResponses []*beans.NotificationSettingsResponse
 	deletedItemCount := 0
@@ -305,6 +357,9 @@ func (impl *NotificationConfigServiceImpl) BuildNotificationSettingsResponse(not
 		if config.Providers != nil && len(config.Providers) > 0 {
 			var slackIds []*int
 			var webhookIds []*int
+			var teamsIds []*int
+			var discordIds []*int
+			var pagerDutyIds []*int
 			var sesUserIds []int32
 			var smtpUserIds []int32
 			var providerConfigs []*beans.ProvidersConfig
@@ -319,6 +374,12 @@ func (impl *NotificationConfigServiceImpl) BuildNotificationSettingsResponse(not
 						smtpUserIds = append(smtpUserIds, int32(item.ConfigId))
 					} else if item.Destination == util.Webhook {
 						webhookIds = append(webhookIds, &item.ConfigId)
+					} else if item.Destination == util.Teams {
+						teamsIds = append(teamsIds, &item.ConfigId)
+					} else if item.Destination == util.Discord {
+						discordIds = append(discordIds, &item.ConfigId)
+					} else if item.Destination == util.PagerDuty {
+						pagerDutyIds = append(pagerDutyIds, &item.ConfigId)
 					}
 				} else {
 					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Dest: string(item.Destination), Recipient: item.Recipient})
@@ -344,6 +405,36 @@ func (impl *NotificationConfigServiceImpl) BuildNotificationSettingsResponse(not
 					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Id: item.Id, ConfigName: item.ConfigName, Dest: string(util.Webhook)})
 				}
 			}
+			if len(teamsIds) > 0 {
+				teamsConfigs, err := impl.teamsRepository.FindByIds(teamsIds)
+				if err != nil && err != pg.ErrNoRows {
+					impl.logger.Errorw("error in fetching teams config", "teamsIds", teamsIds, "err", err)
+					return notificationSettingsResponses, deletedItemCount, err
+				}
+				for _, item := range teamsConfigs {
+					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Id: item.Id, ConfigName: item.ConfigName, Dest: string(util.Teams)})
+				}
+			}
+			if len(discordIds) > 0 {
+				discordConfigs, err := impl.discordRepository.FindByIds(discordIds)
+				if err != nil && err != pg.ErrNoRows {
+					impl.logger.Errorw("error in fetching discord config", "discordIds", discordIds, "err", err)
+					return notificationSettingsResponses, deletedItemCount, err
+				}
+				for _, item := range discordConfigs {
+					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Id: item.Id, ConfigName: item.ConfigName, Dest: string(util.Discord)})
+				}
+			}
+			if len(pagerDutyIds) > 0 {
+				pagerDutyConfigs, err := impl.pagerDutyRepository.FindByIds(pagerDutyIds)
+				if err != nil && err != pg.ErrNoRows {
+					impl.logger.Errorw("error in fetching pager duty config", "pagerDutyIds", pagerDutyIds, "err", err)
+					return notificationSettingsResponses, deletedItemCount, err
+				}
+				for _, item := range pagerDutyConfigs {
+					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Id: item.Id, ConfigName: item.ConfigName, Dest: string(util.PagerDuty)})
+				}
+			}
 
 			if len(sesUserIds) > 0 {
 				sesConfigs, err := impl.userRepository.GetByIds(sesUserIds)
@@ -411,6 +502,7 @@ func (impl *NotificationConfigServiceImpl) BuildNotificationSettingsResponse(not
 
 		notificationSettingsResponse.PipelineType = string(config.PipelineType)
 		notificationSettingsResponse.EventTypes = config.EventTypeIds
+		notificationSettingsResponse.NotificationSettingOptions = config.NotificationSettingOptions
 
 		notificationSettingsResponses = append(notificationSettingsResponses, notificationSettingsResponse)
 	}
@@ -607,6 +699,17 @@ func (impl *NotificationConfigServiceImpl) updateNotificationSetting(notificatio
 		nsConfig.EventTypeIds = notificationSettingsRequest.EventTypeIds
 	} else if updateType == util.UpdateRecipients {
 		nsConfig.Providers = notificationSettingsRequest.Providers
+	} else if updateType == util.UpdateCondition {
+		nsConfig.Condition = notificationSettingsRequest.Condition
+	} else if updateType == util.UpdateDigest {
+		nsConfig.DigestIntervalMins = notificationSettingsRequest.DigestIntervalMins
+		nsConfig.CooldownMins = notificationSettingsRequest.CooldownMins
+	}
+	if updateType == util.UpdateCondition || updateType == util.UpdateDigest || updateType == util.UpdateRecipients {
+		err = impl.validateSettingOptions(nsConfig.Providers, nsConfig.NotificationSettingOptions)
+		if err != nil {
+			return 0, err
+		}
 	}
 	config, err := json.Marshal(nsConfig)
 	if err != nil {
@@ -630,6 +733,7 @@ func (impl *NotificationConfigServiceImpl) updateNotificationSetting(notificatio
 		notificationSettingsRequest.PipelineId = nsConfig.PipelineId
 		notificationSettingsRequest.PipelineType = nsConfig.PipelineType
 		notificationSettingsRequest.Providers = nsConfig.Providers
+		notificationSettingsRequest.NotificationSettingOptions = nsConfig.NotificationSettingOptions
 		var notificationSettings []repository.NotificationSettings
 		nsOptions, err := impl.notificationSettingsRepository.FetchNotificationSettingGroupBy(notificationSettingsRequest.Id)
 		if err != nil {
@@ -645,7 +749,7 @@ func (impl *NotificationConfigServiceImpl) updateNotificationSetting(notificatio
 		} else {
 			for _, item := range nsOptions {
 				for _, e := range notificationSettingsRequest.EventTypeIds {
-					notificationSetting, err := impl.notificationConfigBuilder.BuildNotificationSettingWithPipeline(item.TeamId, item.EnvId, item.AppId, item.PipelineId, item.ClusterId, util.PipelineType(item.PipelineType), e, notificationSettingsRequest.Id, nsConfig.Providers)
+					notificationSetting, err := impl.notificationConfigBuilder.BuildNotificationSettingWithPipeline(item.TeamId, item.EnvId, item.AppId, item.PipelineId, item.ClusterId, util.PipelineType(item.PipelineType), e, notificationSettingsRequest.Id, nsConfig.Providers, nsConfig.NotificationSettingOptions)
 					if err != nil {
 						impl.logger.Error(err)
 						return 0, err
@@ -673,6 +777,22 @@ func (impl *NotificationConfigServiceImpl) updateNotificationSetting(notificatio
 				return 0, sErr
 			}
 		}
+	} else if updateType == util.UpdateCondition || updateType == util.UpdateDigest {
+		nsOptions, err := impl.notificationSettingsRepository.FindNotificationSettingsByViewId(notificationSettingsRequest.Id)
+		if err != nil {
+			impl.logger.Errorw("failed to fetch existing notification settings view", "err", err)
+			return 0, err
+		}
+		for _, ns := range nsOptions {
+			ns.Condition = nsConfig.Condition
+			ns.DigestIntervalMins = nsConfig.DigestIntervalMins
+			ns.CooldownMins = nsConfig.CooldownMins
+			_, err = impl.notificationSettingsRepository.UpdateNotificationSettings(&ns, tx)
+			if err != nil {
+				impl.logger.Errorw("failed to update notification settings options", "id", ns.Id, "updateType", updateType, "err", err)
+				return 0, err
+			}
+		}
 	} else if updateType == util.UpdateRecipients {
 		nsOptions, err := impl.notificationSettingsRepository.FindNotificationSettingsByViewId(notificationSettingsRequest.Id)
 		if err != nil {
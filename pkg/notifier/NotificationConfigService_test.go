@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"github.com/devtron-labs/devtron/cel"
+	util2 "github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	util "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestNotificationConfigServiceImpl_validateSettingOptions(t *testing.T) {
+	logger, err := util2.NewSugardLogger()
+	assert.Nil(t, err)
+	impl := &NotificationConfigServiceImpl{logger: logger, celEvaluatorService: cel.NewCELServiceImpl(logger)}
+	teams := &beans.Provider{Destination: util.Teams, ConfigId: 1}
+	pagerDuty := &beans.Provider{Destination: util.PagerDuty, ConfigId: 2}
+	slack := &beans.Provider{Destination: util.Slack, ConfigId: 3}
+	smtp := &beans.Provider{Destination: util.SMTP, Recipient: "oncall@example.com"}
+	condition := beans.NotificationSettingOptions{Condition: `isProdEnv && eventType == "fail"`}
+	tests := []struct {
+		name       string
+		providers  []*beans.Provider
+		options    beans.NotificationSettingOptions
+		wantStatus int
+	}{
+		{"no condition on notifier providers", []*beans.Provider{slack, smtp}, beans.NotificationSettingOptions{}, 0},
+		{"condition on orchestrator providers", []*beans.Provider{teams, pagerDuty}, condition, 0},
+		{"condition on slack", []*beans.Provider{teams, slack}, condition, http.StatusBadRequest},
+		{"condition on smtp", []*beans.Provider{smtp}, condition, http.StatusBadRequest},
+		{"digest on orchestrator providers", []*beans.Provider{teams}, beans.NotificationSettingOptions{DigestIntervalMins: 30}, 0},
+		{"digest on slack", []*beans.Provider{slack}, beans.NotificationSettingOptions{DigestIntervalMins: 30}, http.StatusBadRequest},
+		{"cooldown on smtp", []*beans.Provider{smtp}, beans.NotificationSettingOptions{CooldownMins: 10}, http.StatusBadRequest},
+		{"invalid condition", []*beans.Provider{teams}, beans.NotificationSettingOptions{Condition: "isProdEnv +"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := impl.validateSettingOptions(tt.providers, tt.options)
+			if tt.wantStatus == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			apiErr, ok := err.(*util2.ApiError)
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantStatus, apiErr.HttpStatusCode)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type PagerDutyNotificationService interface {
+	SaveOrEditNotificationConfig(channelReq []beans.PagerDutyConfigDto, userId int32) ([]int, error)
+	FetchPagerDutyNotificationConfigById(id int) (*beans.PagerDutyConfigDto, error)
+	FetchAllPagerDutyNotificationConfig() ([]*beans.PagerDutyConfigDto, error)
+	FetchAllPagerDutyNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error)
+	DeleteNotificationConfig(deleteReq *beans.PagerDutyConfigDto, userId int32) error
+}
+
+type PagerDutyNotificationServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	pagerDutyRepository            repository.PagerDutyNotificationRepository
+	notificationSettingsRepository repository.NotificationSettingsRepository
+}
+
+func NewPagerDutyNotificationServiceImpl(logger *zap.SugaredLogger, pagerDutyRepository repository.PagerDutyNotificationRepository,
+	notificationSettingsRepository repository.NotificationSettingsRepository) *PagerDutyNotificationServiceImpl {
+	return &PagerDutyNotificationServiceImpl{
+		logger:                         logger,
+		pagerDutyRepository:            pagerDutyRepository,
+		notificationSettingsRepository: notificationSettingsRepository,
+	}
+}
+
+func (impl *PagerDutyNotificationServiceImpl) SaveOrEditNotificationConfig(channelReq []beans.PagerDutyConfigDto, userId int32) ([]int, error) {
+	var responseIds []int
+	pagerDutyConfigs := buildPagerDutyNewConfigs(channelReq, userId)
+	for _, config := range pagerDutyConfigs {
+		if config.Id != 0 {
+			model, err := impl.pagerDutyRepository.FindOne(config.Id)
+			if err != nil {
+				impl.logger.Errorw("err while fetching pager duty config", "err", err)
+				return []int{}, err
+			}
+			impl.buildConfigUpdateModel(config, model, userId)
+			_, uErr := impl.pagerDutyRepository.UpdatePagerDutyConfig(model)
+			if uErr != nil {
+				impl.logger.Errorw("err while updating pager duty config", "err", uErr)
+				return []int{}, uErr
+			}
+		} else {
+			_, iErr := impl.pagerDutyRepository.SavePagerDutyConfig(config)
+			if iErr != nil {
+				impl.logger.Errorw("err while inserting pager duty config", "err", iErr)
+				return []int{}, iErr
+			}
+		}
+		responseIds = append(responseIds, config.Id)
+	}
+	return responseIds, nil
+}
+
+func (impl *PagerDutyNotificationServiceImpl) FetchPagerDutyNotificationConfigById(id int) (*beans.PagerDutyConfigDto, error) {
+	pagerDutyConfig, err := impl.pagerDutyRepository.FindOne(id)
+	if err != nil {
+		impl.logger.Errorw("cannot find pager duty config", "id", id, "err", err)
+		return nil, err
+	}
+	pagerDutyConfigDto := adaptPagerDutyConfig(*pagerDutyConfig)
+	return &pagerDutyConfigDto, nil
+}
+
+func (impl *PagerDutyNotificationServiceImpl) FetchAllPagerDutyNotificationConfig() ([]*beans.PagerDutyConfigDto, error) {
+	responseDto := make([]*beans.PagerDutyConfigDto, 0)
+	pagerDutyConfigs, err := impl.pagerDutyRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all pager duty config", "err", err)
+		return []*beans.PagerDutyConfigDto{}, err
+	}
+	for _, pagerDutyConfig := range pagerDutyConfigs {
+		pagerDutyConfigDto := adaptPagerDutyConfig(pagerDutyConfig)
+		responseDto = append(responseDto, &pagerDutyConfigDto)
+	}
+	return responseDto, nil
+}
+
+func (impl *PagerDutyNotificationServiceImpl) FetchAllPagerDutyNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error) {
+	var responseDto []*beans.NotificationChannelAutoResponse
+	pagerDutyConfigs, err := impl.pagerDutyRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all pager duty config", "err", err)
+		return []*beans.NotificationChannelAutoResponse{}, err
+	}
+	for _, pagerDutyConfig := range pagerDutyConfigs {
+		responseDto = append(responseDto, &beans.NotificationChannelAutoResponse{
+			Id:         pagerDutyConfig.Id,
+			ConfigName: pagerDutyConfig.ConfigName,
+		})
+	}
+	return responseDto, nil
+}
+
+func (impl *PagerDutyNotificationServiceImpl) DeleteNotificationConfig(deleteReq *beans.PagerDutyConfigDto, userId int32) error {
+	existingConfig, err := impl.pagerDutyRepository.FindOne(deleteReq.Id)
+	if err != nil {
+		impl.logger.Errorw("No matching entry found for delete", "err", err, "id", deleteReq.Id)
+		return err
+	}
+	notifications, err := impl.notificationSettingsRepository.FindNotificationSettingsByConfigIdAndConfigType(deleteReq.Id, beans.PAGER_DUTY_CONFIG_TYPE)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in deleting pager duty config", "config", deleteReq)
+		return err
+	}
+	if len(notifications) > 0 {
+		impl.logger.Errorw("found notifications using this config, cannot delete", "config", deleteReq)
+		return fmt.Errorf(" Please delete all notifications using this config before deleting")
+	}
+
+	existingConfig.UpdatedOn = time.Now()
+	existingConfig.UpdatedBy = userId
+	//deleting pager duty config
+	err = impl.pagerDutyRepository.MarkPagerDutyConfigDeleted(existingConfig)
+	if err != nil {
+		impl.logger.Errorw("error in deleting pager duty config", "err", err, "id", existingConfig.Id)
+		return err
+	}
+	return nil
+}
+
+func adaptPagerDutyConfig(pagerDutyConfig repository.PagerDutyConfig) beans.PagerDutyConfigDto {
+	return beans.PagerDutyConfigDto{
+		OwnerId:     pagerDutyConfig.OwnerId,
+		RoutingKey:  pagerDutyConfig.RoutingKey,
+		Severity:    beans.PagerDutySeverity(pagerDutyConfig.Severity),
+		ConfigName:  pagerDutyConfig.ConfigName,
+		Description: pagerDutyConfig.Description,
+		Id:          pagerDutyConfig.Id,
+	}
+}
+
+func buildPagerDutyNewConfigs(pagerDutyReq []beans.PagerDutyConfigDto, userId int32) []*repository.PagerDutyConfig {
+	var pagerDutyConfigs []*repository.PagerDutyConfig
+	for _, c := range pagerDutyReq {
+		pagerDutyConfig := &repository.PagerDutyConfig{
+			Id:          c.Id,
+			ConfigName:  c.ConfigName,
+			RoutingKey:  c.RoutingKey,
+			Severity:    string(getPagerDutySeverity(c.Severity)),
+			Description: c.Description,
+			OwnerId:     userId,
+			AuditLog:    sql.NewDefaultAuditLog(userId),
+		}
+		pagerDutyConfigs = append(pagerDutyConfigs, pagerDutyConfig)
+	}
+	return pagerDutyConfigs
+}
+
+// getPagerDutySeverity defaults to critical, deployment failures are meant to page on-call
+func getPagerDutySeverity(severity beans.PagerDutySeverity) beans.PagerDutySeverity {
+	if len(severity) == 0 {
+		return beans.PagerDutySeverityCritical
+	}
+	return severity
+}
+
+func (impl *PagerDutyNotificationServiceImpl) buildConfigUpdateModel(pagerDutyConfig *repository.PagerDutyConfig, model *repository.PagerDutyConfig, userId int32) {
+	model.RoutingKey = pagerDutyConfig.RoutingKey
+	model.Severity = pagerDutyConfig.Severity
+	model.ConfigName = pagerDutyConfig.ConfigName
+	model.Description = pagerDutyConfig.Description
+	model.OwnerId = pagerDutyConfig.OwnerId
+	model.UpdatedOn = time.Now()
+	model.UpdatedBy = userId
+}
+
+const (
+	pagerDutyEventActionTrigger = "trigger"
+	pagerDutyEventActionResolve = "resolve"
+	pagerDutyEventSource        = "devtron"
+	// pager duty rejects summaries longer than this
+	pagerDutyMaxSummaryLength = 1024
+)
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key,omitempty"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Links       []pagerDutyLink   `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// buildPagerDutyEvent builds the events v2 request for the message, failures and warnings trigger an incident and
+// success resolves the one opened for the same dedup key. Informational messages do not page anyone, nil is returned for them.
+func buildPagerDutyEvent(pagerDutyConfig *repository.PagerDutyConfig, message *beans.ChannelMessage) *pagerDutyEvent {
+	event := &pagerDutyEvent{
+		RoutingKey: pagerDutyConfig.RoutingKey,
+		DedupKey:   message.DedupKey,
+	}
+	switch message.Level {
+	case beans.ChannelMessageLevelSuccess:
+		if len(message.DedupKey) == 0 {
+			return nil
+		}
+		event.EventAction = pagerDutyEventActionResolve
+		return event
+	case beans.ChannelMessageLevelWarning, beans.ChannelMessageLevelFailure:
+		event.EventAction = pagerDutyEventActionTrigger
+	default:
+		return nil
+	}
+	severity := getPagerDutySeverity(beans.PagerDutySeverity(pagerDutyConfig.Severity))
+	if message.Level == beans.ChannelMessageLevelWarning {
+		severity = beans.PagerDutySeverityWarning
+	}
+	summary := message.Title
+	if len(message.Text) > 0 {
+		summary = fmt.Sprintf("%s: %s", message.Title, message.Text)
+	}
+	if len(summary) > pagerDutyMaxSummaryLength {
+		summary = summary[:pagerDutyMaxSummaryLength]
+	}
+	event.Payload = &pagerDutyPayload{
+		Summary:  summary,
+		Source:   pagerDutyEventSource,
+		Severity: string(severity),
+	}
+	if len(message.Facts) > 0 {
+		event.Payload.CustomDetails = make(map[string]string, len(message.Facts))
+		for _, fact := range message.Facts {
+			event.Payload.CustomDetails[fact.Name] = fact.Value
+		}
+	}
+	if len(message.Link) > 0 {
+		event.Links = []pagerDutyLink{{Href: message.Link, Text: "View details"}}
+	}
+	return event
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_buildPagerDutyNewConfigs(t *testing.T) {
+	configs := buildPagerDutyNewConfigs([]beans.PagerDutyConfigDto{
+		{ConfigName: "default severity", RoutingKey: "key-1"},
+		{ConfigName: "warning severity", RoutingKey: "key-2", Severity: beans.PagerDutySeverityWarning},
+	}, 1)
+	assert.Len(t, configs, 2)
+	assert.Equal(t, "key-1", configs[0].RoutingKey)
+	assert.Equal(t, string(beans.PagerDutySeverityCritical), configs[0].Severity)
+	assert.Equal(t, "key-2", configs[1].RoutingKey)
+	assert.Equal(t, string(beans.PagerDutySeverityWarning), configs[1].Severity)
+}
+
+func Test_buildPagerDutyEvent(t *testing.T) {
+	pagerDutyConfig := &repository.PagerDutyConfig{RoutingKey: "routing-key", Severity: string(beans.PagerDutySeverityError)}
+	message := &beans.ChannelMessage{
+		Title:    "Deployment failed: payments / prod",
+		Text:     "pods are crash looping",
+		Link:     "https://devtron.example.com/dashboard/app/1/cd-details/2/3/4/source-code",
+		Level:    beans.ChannelMessageLevelFailure,
+		Facts:    []beans.ChannelMessageFact{{Name: "Application", Value: "payments"}},
+		DedupKey: "devtron/CD/3/DEPLOY",
+	}
+	t.Run("failure triggers an incident", func(t *testing.T) {
+		body, err := json.Marshal(buildPagerDutyEvent(pagerDutyConfig, message))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"routing_key":"routing-key",
+			"event_action":"trigger",
+			"dedup_key":"devtron/CD/3/DEPLOY",
+			"payload":{
+				"summary":"Deployment failed: payments / prod: pods are crash looping",
+				"source":"devtron",
+				"severity":"error",
+				"custom_details":{"Application":"payments"}
+			},
+			"links":[{"href":"https://devtron.example.com/dashboard/app/1/cd-details/2/3/4/source-code","text":"View details"}]
+		}`, string(body))
+	})
+	t.Run("warning overrides the configured severity", func(t *testing.T) {
+		event := buildPagerDutyEvent(pagerDutyConfig, &beans.ChannelMessage{Title: "token expiring", Level: beans.ChannelMessageLevelWarning})
+		assert.Equal(t, "trigger", event.EventAction)
+		assert.Equal(t, string(beans.PagerDutySeverityWarning), event.Payload.Severity)
+	})
+	t.Run("success resolves the incident", func(t *testing.T) {
+		body, err := json.Marshal(buildPagerDutyEvent(pagerDutyConfig, &beans.ChannelMessage{Title: "Deployment succeeded", Level: beans.ChannelMessageLevelSuccess, DedupKey: "devtron/CD/3/DEPLOY"}))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"routing_key":"routing-key","event_action":"resolve","dedup_key":"devtron/CD/3/DEPLOY"}`, string(body))
+	})
+	t.Run("informational messages are not sent", func(t *testing.T) {
+		assert.Nil(t, buildPagerDutyEvent(pagerDutyConfig, &beans.ChannelMessage{Title: "Deployment triggered", Level: beans.ChannelMessageLevelInfo}))
+	})
+}
@@ -47,17 +47,25 @@ type SlackNotificationServiceImpl struct {
 	teamService                    team.TeamService
 	slackRepository                repository.SlackNotificationRepository
 	webhookRepository              repository.WebhookNotificationRepository
+	teamsRepository                repository.TeamsNotificationRepository
+	discordRepository              repository.DiscordNotificationRepository
+	pagerDutyRepository            repository.PagerDutyNotificationRepository
 	userRepository                 repository2.UserRepository
 	notificationSettingsRepository repository.NotificationSettingsRepository
 }
 
 func NewSlackNotificationServiceImpl(logger *zap.SugaredLogger, slackRepository repository.SlackNotificationRepository, webhookRepository repository.WebhookNotificationRepository, teamService team.TeamService,
-	userRepository repository2.UserRepository, notificationSettingsRepository repository.NotificationSettingsRepository) *SlackNotificationServiceImpl {
+	userRepository repository2.UserRepository, notificationSettingsRepository repository.NotificationSettingsRepository,
+	teamsRepository repository.TeamsNotificationRepository, discordRepository repository.DiscordNotificationRepository,
+	pagerDutyRepository repository.PagerDutyNotificationRepository) *SlackNotificationServiceImpl {
 	return &SlackNotificationServiceImpl{
 		logger:                         logger,
 		teamService:                    teamService,
 		slackRepository:                slackRepository,
 		webhookRepository:              webhookRepository,
+		teamsRepository:                teamsRepository,
+		discordRepository:              discordRepository,
+		pagerDutyRepository:            pagerDutyRepository,
 		userRepository:                 userRepository,
 		notificationSettingsRepository: notificationSettingsRepository,
 	}
@@ -214,6 +222,42 @@ func (impl *SlackNotificationServiceImpl) RecipientListingSuggestion(value strin
 			Dest:      util2.Webhook}
 		results = append(results, result)
 	}
+	teamsConfigs, err := impl.teamsRepository.FindByName(value)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all teams config", "err", err)
+		return []*beans.NotificationRecipientListingResponse{}, err
+	}
+	for _, teamsConfig := range teamsConfigs {
+		result := &beans.NotificationRecipientListingResponse{
+			ConfigId:  teamsConfig.Id,
+			Recipient: teamsConfig.ConfigName,
+			Dest:      util2.Teams}
+		results = append(results, result)
+	}
+	discordConfigs, err := impl.discordRepository.FindByName(value)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all discord config", "err", err)
+		return []*beans.NotificationRecipientListingResponse{}, err
+	}
+	for _, discordConfig := range discordConfigs {
+		result := &beans.NotificationRecipientListingResponse{
+			ConfigId:  discordConfig.Id,
+			Recipient: discordConfig.ConfigName,
+			Dest:      util2.Discord}
+		results = append(results, result)
+	}
+	pagerDutyConfigs, err := impl.pagerDutyRepository.FindByName(value)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all pager duty config", "err", err)
+		return []*beans.NotificationRecipientListingResponse{}, err
+	}
+	for _, pagerDutyConfig := range pagerDutyConfigs {
+		result := &beans.NotificationRecipientListingResponse{
+			ConfigId:  pagerDutyConfig.Id,
+			Recipient: pagerDutyConfig.ConfigName,
+			Dest:      util2.PagerDuty}
+		results = append(results, result)
+	}
 	userList, err := impl.userRepository.FetchUserMatchesByEmailIdExcludingApiTokenUser(value)
 	if err != nil && !util.IsErrNoRows(err) {
 		impl.logger.Errorw("cannot find all slack config", "err", err)
@@ -293,3 +337,59 @@ func (impl *SlackNotificationServiceImpl) DeleteNotificationConfig(deleteReq *be
 	}
 	return nil
 }
+
+const (
+	slackColorInfo    = "#3498DB"
+	slackColorSuccess = "#2ECC71"
+	slackColorWarning = "#F1C40F"
+	slackColorFailure = "#E74C3C"
+)
+
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color     string       `json:"color"`
+	Title     string       `json:"title"`
+	TitleLink string       `json:"title_link,omitempty"`
+	Text      string       `json:"text,omitempty"`
+	Fields    []slackField `json:"fields,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// buildSlackMessage renders the message for a slack incoming webhook, text is the fallback shown in push notifications
+func buildSlackMessage(message *beans.ChannelMessage) *slackMessage {
+	attachment := slackAttachment{
+		Color:     getSlackColor(message.Level),
+		Title:     message.Title,
+		TitleLink: message.Link,
+		Text:      message.Text,
+	}
+	for _, fact := range message.Facts {
+		if len(fact.Value) == 0 {
+			continue
+		}
+		attachment.Fields = append(attachment.Fields, slackField{Title: fact.Name, Value: fact.Value, Short: true})
+	}
+	return &slackMessage{Text: message.Title, Attachments: []slackAttachment{attachment}}
+}
+
+func getSlackColor(level beans.ChannelMessageLevel) string {
+	switch level {
+	case beans.ChannelMessageLevelSuccess:
+		return slackColorSuccess
+	case beans.ChannelMessageLevelWarning:
+		return slackColorWarning
+	case beans.ChannelMessageLevelFailure:
+		return slackColorFailure
+	default:
+		return slackColorInfo
+	}
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_buildSlackMessage(t *testing.T) {
+	message := &beans.ChannelMessage{
+		Title: "API token expiring: ci-token",
+		Text:  "API token ci-token expires soon",
+		Level: beans.ChannelMessageLevelWarning,
+		Facts: []beans.ChannelMessageFact{{Name: "Owner", Value: "admin@example.com"}, {Name: "Environment", Value: ""}},
+	}
+	body, err := json.Marshal(buildSlackMessage(message))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"text":"API token expiring: ci-token","attachments":[{
+		"color":"#F1C40F",
+		"title":"API token expiring: ci-token",
+		"text":"API token ci-token expires soon",
+		"fields":[{"title":"Owner","value":"admin@example.com","short":true}]
+	}]}`, string(body))
+}
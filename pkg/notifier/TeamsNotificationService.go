@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type TeamsNotificationService interface {
+	SaveOrEditNotificationConfig(channelReq []beans.TeamsConfigDto, userId int32) ([]int, error)
+	FetchTeamsNotificationConfigById(id int) (*beans.TeamsConfigDto, error)
+	FetchAllTeamsNotificationConfig() ([]*beans.TeamsConfigDto, error)
+	FetchAllTeamsNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error)
+	DeleteNotificationConfig(deleteReq *beans.TeamsConfigDto, userId int32) error
+}
+
+type TeamsNotificationServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	teamsRepository                repository.TeamsNotificationRepository
+	notificationSettingsRepository repository.NotificationSettingsRepository
+}
+
+func NewTeamsNotificationServiceImpl(logger *zap.SugaredLogger, teamsRepository repository.TeamsNotificationRepository,
+	notificationSettingsRepository repository.NotificationSettingsRepository) *TeamsNotificationServiceImpl {
+	return &TeamsNotificationServiceImpl{
+		logger:                         logger,
+		teamsRepository:                teamsRepository,
+		notificationSettingsRepository: notificationSettingsRepository,
+	}
+}
+
+func (impl *TeamsNotificationServiceImpl) SaveOrEditNotificationConfig(channelReq []beans.TeamsConfigDto, userId int32) ([]int, error) {
+	var responseIds []int
+	teamsConfigs := buildTeamsNewConfigs(channelReq, userId)
+	for _, config := range teamsConfigs {
+		if config.Id != 0 {
+			model, err := impl.teamsRepository.FindOne(config.Id)
+			if err != nil {
+				impl.logger.Errorw("err while fetching teams config", "err", err)
+				return []int{}, err
+			}
+			impl.buildConfigUpdateModel(config, model, userId)
+			_, uErr := impl.teamsRepository.UpdateTeamsConfig(model)
+			if uErr != nil {
+				impl.logger.Errorw("err while updating teams config", "err", uErr)
+				return []int{}, uErr
+			}
+		} else {
+			_, iErr := impl.teamsRepository.SaveTeamsConfig(config)
+			if iErr != nil {
+				impl.logger.Errorw("err while inserting teams config", "err", iErr)
+				return []int{}, iErr
+			}
+		}
+		responseIds = append(responseIds, config.Id)
+	}
+	return responseIds, nil
+}
+
+func (impl *TeamsNotificationServiceImpl) FetchTeamsNotificationConfigById(id int) (*beans.TeamsConfigDto, error) {
+	teamsConfig, err := impl.teamsRepository.FindOne(id)
+	if err != nil {
+		impl.logger.Errorw("cannot find teams config", "id", id, "err", err)
+		return nil, err
+	}
+	teamsConfigDto := adaptTeamsConfig(*teamsConfig)
+	return &teamsConfigDto, nil
+}
+
+func (impl *TeamsNotificationServiceImpl) FetchAllTeamsNotificationConfig() ([]*beans.TeamsConfigDto, error) {
+	responseDto := make([]*beans.TeamsConfigDto, 0)
+	teamsConfigs, err := impl.teamsRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all teams config", "err", err)
+		return []*beans.TeamsConfigDto{}, err
+	}
+	for _, teamsConfig := range teamsConfigs {
+		teamsConfigDto := adaptTeamsConfig(teamsConfig)
+		responseDto = append(responseDto, &teamsConfigDto)
+	}
+	return responseDto, nil
+}
+
+func (impl *TeamsNotificationServiceImpl) FetchAllTeamsNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error) {
+	var responseDto []*beans.NotificationChannelAutoResponse
+	teamsConfigs, err := impl.teamsRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all teams config", "err", err)
+		return []*beans.NotificationChannelAutoResponse{}, err
+	}
+	for _, teamsConfig := range teamsConfigs {
+		responseDto = append(responseDto, &beans.NotificationChannelAutoResponse{
+			Id:         teamsConfig.Id,
+			ConfigName: teamsConfig.ConfigName,
+		})
+	}
+	return responseDto, nil
+}
+
+func (impl *TeamsNotificationServiceImpl) DeleteNotificationConfig(deleteReq *beans.TeamsConfigDto, userId int32) error {
+	existingConfig, err := impl.teamsRepository.FindOne(deleteReq.Id)
+	if err != nil {
+		impl.logger.Errorw("No matching entry found for delete", "err", err, "id", deleteReq.Id)
+		return err
+	}
+	notifications, err := impl.notificationSettingsRepository.FindNotificationSettingsByConfigIdAndConfigType(deleteReq.Id, beans.TEAMS_CONFIG_TYPE)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in deleting teams config", "config", deleteReq)
+		return err
+	}
+	if len(notifications) > 0 {
+		impl.logger.Errorw("found notifications using this config, cannot delete", "config", deleteReq)
+		return fmt.Errorf(" Please delete all notifications using this config before deleting")
+	}
+
+	existingConfig.UpdatedOn = time.Now()
+	existingConfig.UpdatedBy = userId
+	//deleting teams config
+	err = impl.teamsRepository.MarkTeamsConfigDeleted(existingConfig)
+	if err != nil {
+		impl.logger.Errorw("error in deleting teams config", "err", err, "id", existingConfig.Id)
+		return err
+	}
+	return nil
+}
+
+func adaptTeamsConfig(teamsConfig repository.TeamsConfig) beans.TeamsConfigDto {
+	return beans.TeamsConfigDto{
+		OwnerId:     teamsConfig.OwnerId,
+		WebhookUrl:  teamsConfig.WebHookUrl,
+		ConfigName:  teamsConfig.ConfigName,
+		Description: teamsConfig.Description,
+		Id:          teamsConfig.Id,
+	}
+}
+
+func buildTeamsNewConfigs(teamsReq []beans.TeamsConfigDto, userId int32) []*repository.TeamsConfig {
+	var teamsConfigs []*repository.TeamsConfig
+	for _, c := range teamsReq {
+		teamsConfig := &repository.TeamsConfig{
+			Id:          c.Id,
+			ConfigName:  c.ConfigName,
+			WebHookUrl:  c.WebhookUrl,
+			Description: c.Description,
+			OwnerId:     userId,
+			AuditLog:    sql.NewDefaultAuditLog(userId),
+		}
+		teamsConfigs = append(teamsConfigs, teamsConfig)
+	}
+	return teamsConfigs
+}
+
+func (impl *TeamsNotificationServiceImpl) buildConfigUpdateModel(teamsConfig *repository.TeamsConfig, model *repository.TeamsConfig, userId int32) {
+	model.WebHookUrl = teamsConfig.WebHookUrl
+	model.ConfigName = teamsConfig.ConfigName
+	model.Description = teamsConfig.Description
+	model.OwnerId = teamsConfig.OwnerId
+	model.UpdatedOn = time.Now()
+	model.UpdatedBy = userId
+}
+
+const teamsAdaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+
+type teamsMessage struct {
+	Type        string                `json:"type"`
+	Attachments []teamsCardAttachment `json:"attachments"`
+}
+
+type teamsCardAttachment struct {
+	ContentType string            `json:"contentType"`
+	Content     teamsAdaptiveCard `json:"content"`
+}
+
+type teamsAdaptiveCard struct {
+	Schema  string             `json:"$schema"`
+	Type    string             `json:"type"`
+	Version string             `json:"version"`
+	Body    []teamsCardElement `json:"body"`
+	Actions []teamsCardAction  `json:"actions,omitempty"`
+}
+
+type teamsCardElement struct {
+	Type   string          `json:"type"`
+	Text   string          `json:"text,omitempty"`
+	Size   string          `json:"size,omitempty"`
+	Weight string          `json:"weight,omitempty"`
+	Color  string          `json:"color,omitempty"`
+	Wrap   bool            `json:"wrap,omitempty"`
+	Facts  []teamsCardFact `json:"facts,omitempty"`
+}
+
+type teamsCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type teamsCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Url   string `json:"url"`
+}
+
+// buildTeamsAdaptiveCard wraps the message in an adaptive card, the format teams incoming webhooks and workflows accept
+func buildTeamsAdaptiveCard(message *beans.ChannelMessage) *teamsMessage {
+	body := []teamsCardElement{
+		{Type: "TextBlock", Text: message.Title, Size: "Medium", Weight: "Bolder", Color: getTeamsTitleColor(message.Level), Wrap: true},
+	}
+	if len(message.Text) > 0 {
+		body = append(body, teamsCardElement{Type: "TextBlock", Text: message.Text, Wrap: true})
+	}
+	if len(message.Facts) > 0 {
+		facts := make([]teamsCardFact, 0, len(message.Facts))
+		for _, fact := range message.Facts {
+			facts = append(facts, teamsCardFact{Title: fact.Name, Value: fact.Value})
+		}
+		body = append(body, teamsCardElement{Type: "FactSet", Facts: facts})
+	}
+	card := teamsAdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body:    body,
+	}
+	if len(message.Link) > 0 {
+		card.Actions = []teamsCardAction{{Type: "Action.OpenUrl", Title: "View details", Url: message.Link}}
+	}
+	return &teamsMessage{
+		Type:        "message",
+		Attachments: []teamsCardAttachment{{ContentType: teamsAdaptiveCardContentType, Content: card}},
+	}
+}
+
+func getTeamsTitleColor(level beans.ChannelMessageLevel) string {
+	switch level {
+	case beans.ChannelMessageLevelSuccess:
+		return "Good"
+	case beans.ChannelMessageLevelWarning:
+		return "Warning"
+	case beans.ChannelMessageLevelFailure:
+		return "Attention"
+	default:
+		return "Default"
+	}
+}
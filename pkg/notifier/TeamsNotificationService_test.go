@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_buildTeamsAdaptiveCard(t *testing.T) {
+	message := &beans.ChannelMessage{
+		Title: "Deployment failed: payments / prod",
+		Text:  "pods are crash looping",
+		Link:  "https://devtron.example.com/dashboard/app/1/cd-details/2/3/4/source-code",
+		Level: beans.ChannelMessageLevelFailure,
+		Facts: []beans.ChannelMessageFact{{Name: "Application", Value: "payments"}},
+	}
+	body, err := json.Marshal(buildTeamsAdaptiveCard(message))
+	assert.Nil(t, err)
+	var payload map[string]interface{}
+	assert.Nil(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "message", payload["type"])
+	attachment := payload["attachments"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", attachment["contentType"])
+	card := attachment["content"].(map[string]interface{})
+	assert.Equal(t, "AdaptiveCard", card["type"])
+	cardBody := card["body"].([]interface{})
+	assert.Len(t, cardBody, 3)
+	title := cardBody[0].(map[string]interface{})
+	assert.Equal(t, message.Title, title["text"])
+	assert.Equal(t, "Attention", title["color"])
+	facts := cardBody[2].(map[string]interface{})["facts"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"title": "Application", "value": "payments"}, facts[0])
+	action := card["actions"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Action.OpenUrl", action["type"])
+	assert.Equal(t, message.Link, action["url"])
+
+	triggeredCard := buildTeamsAdaptiveCard(&beans.ChannelMessage{Title: "Build triggered: payments"}).Attachments[0].Content
+	assert.Len(t, triggeredCard.Body, 1)
+	assert.Empty(t, triggeredCard.Actions)
+}
@@ -221,3 +221,31 @@ func (impl *WebhookNotificationServiceImpl) DeleteNotificationConfig(deleteReq *
 	}
 	return nil
 }
+
+type webhookMessage struct {
+	Title string                     `json:"title"`
+	Text  string                     `json:"text"`
+	Link  string                     `json:"link,omitempty"`
+	Level beans.ChannelMessageLevel  `json:"level"`
+	Facts []beans.ChannelMessageFact `json:"facts,omitempty"`
+}
+
+// buildWebhookMessage renders the message as plain json, the payload template of the webhook config is written
+// for pipeline events and is not applied to messages sent by the orchestrator itself
+func buildWebhookMessage(message *beans.ChannelMessage) *webhookMessage {
+	return &webhookMessage{
+		Title: message.Title,
+		Text:  message.Text,
+		Link:  message.Link,
+		Level: message.Level,
+		Facts: message.Facts,
+	}
+}
+
+func getWebhookHeaders(webhookConfig *repository.WebhookConfig) map[string]string {
+	headers := make(map[string]string, len(webhookConfig.Header))
+	for key, value := range webhookConfig.Header {
+		headers[key] = fmt.Sprint(value)
+	}
+	return headers
+}
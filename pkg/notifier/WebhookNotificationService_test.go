@@ -157,3 +157,8 @@ func TestWebhookNotificationServiceImpl_SaveOrEditNotificationConfig(t *testing.
 		})
 	}
 }
+
+func Test_getWebhookHeaders(t *testing.T) {
+	headers := getWebhookHeaders(&repository.WebhookConfig{Header: map[string]interface{}{"Authorization": "Bearer token", "X-Retries": 3.0}})
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token", "X-Retries": "3"}, headers)
+}
@@ -2,6 +2,7 @@ package beans
 
 import (
 	util "github.com/devtron-labs/devtron/util/event"
+	"strings"
 )
 
 type NotificationConfigRequest struct {
@@ -16,6 +17,18 @@ type NotificationConfigRequest struct {
 	PipelineType util.PipelineType `json:"pipelineType" validate:"required"`
 	EventTypeIds []int             `json:"eventTypeIds" validate:"required"`
 	Providers    []*Provider       `json:"providers"`
+	NotificationSettingOptions
+}
+
+type NotificationSettingOptions struct {
+	// Condition is an optional CEL expression, the notification is sent only for events it evaluates to true on.
+	// Condition, digest and cooldown are supported only on settings whose providers are all delivered by the orchestrator
+	// (teams, discord and pager duty).
+	Condition string `json:"condition,omitempty"`
+	// DigestIntervalMins collapses events of the same pipeline and event type into one digest sent every interval
+	DigestIntervalMins int `json:"digestIntervalMins,omitempty" validate:"min=0"`
+	// CooldownMins suppresses repeats of an identical event within the window
+	CooldownMins int `json:"cooldownMins,omitempty" validate:"min=0"`
 }
 
 func (notificationSettingsRequest *NotificationConfigRequest) GenerateSettingCombinationsV1() []*LocalRequest {
@@ -174,6 +187,7 @@ type NSConfig struct {
 	PipelineType util.PipelineType `json:"pipelineType" validate:"required"`
 	EventTypeIds []int             `json:"eventTypeIds" validate:"required"`
 	Providers    []*Provider       `json:"providers" validate:"required"`
+	NotificationSettingOptions
 }
 
 type NotificationSettingRequest struct {
@@ -230,6 +244,7 @@ type NotificationSettingsResponse struct {
 	PipelineType     string             `json:"pipelineType"`
 	ProvidersConfig  []*ProvidersConfig `json:"providerConfigs"`
 	EventTypes       []int              `json:"eventTypes"`
+	NotificationSettingOptions
 }
 
 type SearchFilterResponse struct {
@@ -392,3 +407,96 @@ type WebhookConfigDto struct {
 	Description string                 `json:"description"`
 	Id          int                    `json:"id" validate:"number"`
 }
+
+const TEAMS_CONFIG_TYPE = "teams"
+
+type TeamsChannelConfig struct {
+	Channel         util.Channel     `json:"channel" validate:"required"`
+	TeamsConfigDtos []TeamsConfigDto `json:"configs"`
+}
+
+type TeamsConfigDto struct {
+	OwnerId     int32  `json:"userId" validate:"number"`
+	WebhookUrl  string `json:"webhookUrl" validate:"required,url"`
+	ConfigName  string `json:"configName" validate:"required"`
+	Description string `json:"description"`
+	Id          int    `json:"id" validate:"number"`
+}
+
+const DISCORD_CONFIG_TYPE = "discord"
+
+// discord accepts incoming webhooks on both the current and the legacy domain
+const DISCORD_WEBHOOK_URL = "https://discord.com/api/webhooks/"
+const DISCORD_LEGACY_WEBHOOK_URL = "https://discordapp.com/api/webhooks/"
+
+func IsDiscordWebhookUrl(webhookUrl string) bool {
+	return strings.HasPrefix(webhookUrl, DISCORD_WEBHOOK_URL) || strings.HasPrefix(webhookUrl, DISCORD_LEGACY_WEBHOOK_URL)
+}
+
+type DiscordChannelConfig struct {
+	Channel           util.Channel       `json:"channel" validate:"required"`
+	DiscordConfigDtos []DiscordConfigDto `json:"configs"`
+}
+
+type DiscordConfigDto struct {
+	OwnerId     int32  `json:"userId" validate:"number"`
+	WebhookUrl  string `json:"webhookUrl" validate:"required,url"`
+	ConfigName  string `json:"configName" validate:"required"`
+	Description string `json:"description"`
+	Id          int    `json:"id" validate:"number"`
+}
+
+const PAGER_DUTY_CONFIG_TYPE = "pagerduty"
+
+// PagerDutySeverity is the severity sent in the PagerDuty Events v2 payload
+type PagerDutySeverity string
+
+const (
+	PagerDutySeverityCritical PagerDutySeverity = "critical"
+	PagerDutySeverityError    PagerDutySeverity = "error"
+	PagerDutySeverityWarning  PagerDutySeverity = "warning"
+	PagerDutySeverityInfo     PagerDutySeverity = "info"
+)
+
+type PagerDutyChannelConfig struct {
+	Channel             util.Channel         `json:"channel" validate:"required"`
+	PagerDutyConfigDtos []PagerDutyConfigDto `json:"configs"`
+}
+
+type PagerDutyConfigDto struct {
+	OwnerId     int32             `json:"userId" validate:"number"`
+	RoutingKey  string            `json:"routingKey" validate:"required"`
+	Severity    PagerDutySeverity `json:"severity" validate:"omitempty,oneof=critical error warning info"`
+	ConfigName  string            `json:"configName" validate:"required"`
+	Description string            `json:"description"`
+	Id          int               `json:"id" validate:"number"`
+}
+
+const PAGER_DUTY_EVENTS_URL = "https://events.pagerduty.com/v2/enqueue"
+
+// ChannelMessageLevel decides how a channel highlights the message, pager duty opens an incident for
+// failures and warnings and resolves it on success
+type ChannelMessageLevel string
+
+const (
+	ChannelMessageLevelInfo    ChannelMessageLevel = "info"
+	ChannelMessageLevelSuccess ChannelMessageLevel = "success"
+	ChannelMessageLevelWarning ChannelMessageLevel = "warning"
+	ChannelMessageLevelFailure ChannelMessageLevel = "failure"
+)
+
+// ChannelMessage is the content of a notification sent by the orchestrator itself instead of the notifier service
+type ChannelMessage struct {
+	Title string
+	Text  string
+	Link  string
+	Level ChannelMessageLevel
+	Facts []ChannelMessageFact
+	// DedupKey identifies the resource the message is about, successive messages with the same key update the same pager duty incident
+	DedupKey string
+}
+
+type ChannelMessageFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
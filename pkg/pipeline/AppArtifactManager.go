@@ -28,6 +28,9 @@ import (
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
 	bean2 "github.com/devtron-labs/devtron/pkg/bean"
+	"github.com/devtron-labs/devtron/pkg/deploymentApproval"
+	approvalBean "github.com/devtron-labs/devtron/pkg/deploymentApproval/bean"
+	"github.com/devtron-labs/devtron/pkg/imageSignature"
 	repository2 "github.com/devtron-labs/devtron/pkg/pipeline/repository"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
 	"github.com/go-pg/pg"
@@ -48,18 +51,20 @@ type AppArtifactManager interface {
 }
 
 type AppArtifactManagerImpl struct {
-	logger                  *zap.SugaredLogger
-	cdWorkflowRepository    pipelineConfig.CdWorkflowRepository
-	userService             user.UserService
-	imageTaggingService     ImageTaggingService
-	ciArtifactRepository    repository.CiArtifactRepository
-	ciWorkflowRepository    pipelineConfig.CiWorkflowRepository
-	pipelineStageService    PipelineStageService
-	config                  *types.CdConfig
-	cdPipelineConfigService CdPipelineConfigService
-	dockerArtifactRegistry  dockerArtifactStoreRegistry.DockerArtifactStoreRepository
-	CiPipelineRepository    pipelineConfig.CiPipelineRepository
-	ciTemplateService       CiTemplateService
+	logger                    *zap.SugaredLogger
+	cdWorkflowRepository      pipelineConfig.CdWorkflowRepository
+	userService               user.UserService
+	imageTaggingService       ImageTaggingService
+	ciArtifactRepository      repository.CiArtifactRepository
+	ciWorkflowRepository      pipelineConfig.CiWorkflowRepository
+	pipelineStageService      PipelineStageService
+	config                    *types.CdConfig
+	cdPipelineConfigService   CdPipelineConfigService
+	dockerArtifactRegistry    dockerArtifactStoreRegistry.DockerArtifactStoreRepository
+	CiPipelineRepository      pipelineConfig.CiPipelineRepository
+	ciTemplateService         CiTemplateService
+	deploymentApprovalService deploymentApproval.DeploymentApprovalService
+	imageSignatureService     imageSignature.ImageSignatureService
 }
 
 func NewAppArtifactManagerImpl(
@@ -73,24 +78,28 @@ func NewAppArtifactManagerImpl(
 	cdPipelineConfigService CdPipelineConfigService,
 	dockerArtifactRegistry dockerArtifactStoreRegistry.DockerArtifactStoreRepository,
 	CiPipelineRepository pipelineConfig.CiPipelineRepository,
-	ciTemplateService CiTemplateService) *AppArtifactManagerImpl {
+	ciTemplateService CiTemplateService,
+	deploymentApprovalService deploymentApproval.DeploymentApprovalService,
+	imageSignatureService imageSignature.ImageSignatureService) *AppArtifactManagerImpl {
 	cdConfig, err := types.GetCdConfig()
 	if err != nil {
 		return nil
 	}
 	return &AppArtifactManagerImpl{
-		logger:                  logger,
-		cdWorkflowRepository:    cdWorkflowRepository,
-		userService:             userService,
-		imageTaggingService:     imageTaggingService,
-		ciArtifactRepository:    ciArtifactRepository,
-		ciWorkflowRepository:    ciWorkflowRepository,
-		cdPipelineConfigService: cdPipelineConfigService,
-		pipelineStageService:    pipelineStageService,
-		config:                  cdConfig,
-		dockerArtifactRegistry:  dockerArtifactRegistry,
-		CiPipelineRepository:    CiPipelineRepository,
-		ciTemplateService:       ciTemplateService,
+		logger:                    logger,
+		cdWorkflowRepository:      cdWorkflowRepository,
+		userService:               userService,
+		imageTaggingService:       imageTaggingService,
+		ciArtifactRepository:      ciArtifactRepository,
+		ciWorkflowRepository:      ciWorkflowRepository,
+		cdPipelineConfigService:   cdPipelineConfigService,
+		pipelineStageService:      pipelineStageService,
+		config:                    cdConfig,
+		dockerArtifactRegistry:    dockerArtifactRegistry,
+		CiPipelineRepository:      CiPipelineRepository,
+		ciTemplateService:         ciTemplateService,
+		deploymentApprovalService: deploymentApprovalService,
+		imageSignatureService:     imageSignatureService,
 	}
 }
 
@@ -493,6 +502,18 @@ func (impl *AppArtifactManagerImpl) RetrieveArtifactsByCDPipelineV2(pipeline *pi
 			impl.logger.Errorw("error in setting additional data in fetched artifacts", "pipelineId", pipeline.Id, "err", err)
 			return ciArtifactsResponse, err
 		}
+		if stage == bean.CD_WORKFLOW_TYPE_DEPLOY {
+			ciArtifactsResponse.ApprovalPolicy, err = impl.setApprovalInfoInArtifacts(ciArtifacts, pipeline)
+			if err != nil {
+				impl.logger.Errorw("error in setting approval info in fetched artifacts", "pipelineId", pipeline.Id, "err", err)
+				return ciArtifactsResponse, err
+			}
+			err = impl.setSignatureInfoInArtifacts(ciArtifacts, pipeline)
+			if err != nil {
+				impl.logger.Errorw("error in setting signature info in fetched artifacts", "pipelineId", pipeline.Id, "err", err)
+				return ciArtifactsResponse, err
+			}
+		}
 	}
 
 	ciArtifactsResponse.CdPipelineId = pipeline.Id
@@ -568,6 +589,38 @@ func (impl *AppArtifactManagerImpl) setAdditionalDataInArtifacts(ciArtifacts []b
 
 }
 
+func (impl *AppArtifactManagerImpl) setApprovalInfoInArtifacts(ciArtifacts []bean2.CiArtifactBean, pipeline *pipelineConfig.Pipeline) (*approvalBean.ApprovalPolicyDto, error) {
+	artifactIds := make([]int, 0, len(ciArtifacts))
+	for _, artifact := range ciArtifacts {
+		artifactIds = append(artifactIds, artifact.Id)
+	}
+	policy, approvalInfo, err := impl.deploymentApprovalService.GetArtifactApprovalInfo(pipeline, artifactIds)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ciArtifacts {
+		ciArtifacts[i].ApprovalInfo = approvalInfo[ciArtifacts[i].Id]
+	}
+	return policy, nil
+}
+
+// setSignatureInfoInArtifacts sets the last signature verification of the artifacts on the pipeline environment, when a
+// signature policy applies on it
+func (impl *AppArtifactManagerImpl) setSignatureInfoInArtifacts(ciArtifacts []bean2.CiArtifactBean, pipeline *pipelineConfig.Pipeline) error {
+	artifactIds := make([]int, 0, len(ciArtifacts))
+	for _, artifact := range ciArtifacts {
+		artifactIds = append(artifactIds, artifact.Id)
+	}
+	signatureInfo, err := impl.imageSignatureService.GetArtifactSignatureInfo(pipeline.EnvironmentId, artifactIds)
+	if err != nil {
+		return err
+	}
+	for i := range ciArtifacts {
+		ciArtifacts[i].SignatureInfo = signatureInfo[ciArtifacts[i].Id]
+	}
+	return nil
+}
+
 func (impl *AppArtifactManagerImpl) setGitTriggerData(ciArtifacts []bean2.CiArtifactBean) ([]bean2.CiArtifactBean, error) {
 	directArtifactIndexes, directWorkflowIds, artifactsWithParentIndexes, parentArtifactIds := make([]int, 0), make([]int, 0), make([]int, 0), make([]int, 0)
 	for i, artifact := range ciArtifacts {
@@ -735,6 +735,8 @@ func (impl *CdHandlerImpl) converterWFR(wfr pipelineConfig.CdWorkflowRunner) pip
 		workflow.IsArtifactUploaded = isArtifactUploaded
 		workflow.BlobStorageEnabled = wfr.BlobStorageEnabled
 		workflow.RefCdWorkflowRunnerId = wfr.RefCdWorkflowRunnerId
+		workflow.TriggerType = wfr.TriggerType
+		workflow.GitOpsPullRequestUrl = wfr.GitOpsPullRequestUrl
 	}
 	return workflow
 }
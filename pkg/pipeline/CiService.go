@@ -790,6 +790,10 @@ func (impl *CiServiceImpl) buildWfRequestForCiPipeline(pipeline *pipelineConfig.
 		OrchestratorToken:           impl.config.OrchestratorToken,
 		ImageRetryCount:             impl.config.ImageRetryCount,
 		ImageRetryInterval:          impl.config.ImageRetryInterval,
+		GenerateSbom:                impl.config.CiSbomGenerationEnabled,
+		SbomFormat:                  impl.config.CiSbomFormat,
+		SignImage:                   impl.config.CiImageSigningEnabled,
+		ImageSigningTool:            impl.config.CiImageSigningTool,
 		WorkflowExecutor:            impl.config.GetWorkflowExecutorType(),
 		Type:                        pipelineConfigBean.CI_WORKFLOW_PIPELINE_TYPE,
 		CiArtifactLastFetch:         trigger.CiArtifactLastFetch,
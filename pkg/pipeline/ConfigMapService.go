@@ -26,6 +26,8 @@ import (
 	chartRepoRepository "github.com/devtron-labs/devtron/pkg/chartRepo/repository"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/repository"
 	"github.com/devtron-labs/devtron/pkg/commonService"
+	draftBean "github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/pkg/configDraft/protection"
 	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
 	history2 "github.com/devtron-labs/devtron/pkg/pipeline/history"
 	"github.com/devtron-labs/devtron/pkg/pipeline/history/repository"
@@ -54,7 +56,9 @@ type ConfigsList struct {
 type ConfigMapService interface {
 	CMGlobalAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, error)
 	CMGlobalFetch(appId int) (*bean.ConfigDataRequest, error)
-	CMEnvironmentAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, error)
+	// CMEnvironmentAddUpdate and CSEnvironmentAddUpdate return the saved draft instead of applying the change when the
+	// configs of the app on the environment are protected, the same applies to the env level deletes
+	CMEnvironmentAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, *draftBean.ConfigDraftDto, error)
 	CMEnvironmentFetch(appId int, envId int) (*bean.ConfigDataRequest, error)
 	CMGlobalFetchForEdit(name string, id int) (*bean.ConfigDataRequest, error)
 	CMEnvironmentFetchForEdit(name string, id int, appId int, envId int) (*bean.ConfigDataRequest, error)
@@ -62,18 +66,22 @@ type ConfigMapService interface {
 
 	CSGlobalAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, error)
 	CSGlobalFetch(appId int) (*bean.ConfigDataRequest, error)
-	CSEnvironmentAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, error)
+	CSEnvironmentAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, *draftBean.ConfigDraftDto, error)
 	CSEnvironmentFetch(appId int, envId int) (*bean.ConfigDataRequest, error)
 
 	CMGlobalDelete(name string, id int, userId int32) (bool, error)
-	CMEnvironmentDelete(name string, id int, userId int32) (bool, error)
+	CMEnvironmentDelete(name string, id int, userId int32) (bool, *draftBean.ConfigDraftDto, error)
 	CSGlobalDelete(name string, id int, userId int32) (bool, error)
-	CSEnvironmentDelete(name string, id int, userId int32) (bool, error)
+	CSEnvironmentDelete(name string, id int, userId int32) (bool, *draftBean.ConfigDraftDto, error)
+	// CMEnvironmentDeleteSkippingProtection and CSEnvironmentDeleteSkippingProtection delete without saving a draft
+	// for protected configs, used to apply a delete which has already been reviewed
+	CMEnvironmentDeleteSkippingProtection(name string, id int, userId int32) (bool, error)
+	CSEnvironmentDeleteSkippingProtection(name string, id int, userId int32) (bool, error)
 
 	CMGlobalDeleteByAppId(name string, appId int, userId int32) (bool, error)
-	CMEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, error)
+	CMEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, *draftBean.ConfigDraftDto, error)
 	CSGlobalDeleteByAppId(name string, appId int, userId int32) (bool, error)
-	CSEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, error)
+	CSEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, *draftBean.ConfigDraftDto, error)
 
 	CSGlobalFetchForEdit(name string, id int) (*bean.ConfigDataRequest, error)
 	CSEnvironmentFetchForEdit(name string, id int, appId int, envId int) (*bean.ConfigDataRequest, error)
@@ -101,6 +109,7 @@ type ConfigMapServiceImpl struct {
 	configMapHistoryService     history2.ConfigMapHistoryService
 	environmentRepository       repository2.EnvironmentRepository
 	scopedVariableManager       variables.ScopedVariableCMCSManager
+	configProtectionService     protection.ConfigProtectionService
 }
 
 func NewConfigMapServiceImpl(chartRepository chartRepoRepository.ChartRepository,
@@ -112,6 +121,7 @@ func NewConfigMapServiceImpl(chartRepository chartRepoRepository.ChartRepository
 	commonService commonService.CommonService, appRepository app.AppRepository,
 	configMapHistoryService history2.ConfigMapHistoryService, environmentRepository repository2.EnvironmentRepository,
 	scopedVariableManager variables.ScopedVariableCMCSManager,
+	configProtectionService protection.ConfigProtectionService,
 ) *ConfigMapServiceImpl {
 	return &ConfigMapServiceImpl{
 		chartRepository:             chartRepository,
@@ -126,6 +136,7 @@ func NewConfigMapServiceImpl(chartRepository chartRepoRepository.ChartRepository
 		configMapHistoryService:     configMapHistoryService,
 		environmentRepository:       environmentRepository,
 		scopedVariableManager:       scopedVariableManager,
+		configProtectionService:     configProtectionService,
 	}
 }
 
@@ -279,16 +290,16 @@ func (impl ConfigMapServiceImpl) CMGlobalFetch(appId int) (*bean.ConfigDataReque
 	return configDataRequest, nil
 }
 
-func (impl ConfigMapServiceImpl) CMEnvironmentAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, error) {
+func (impl ConfigMapServiceImpl) CMEnvironmentAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, *draftBean.ConfigDraftDto, error) {
 
 	if len(configMapRequest.ConfigData) != 1 {
-		return nil, fmt.Errorf("invalid request multiple config found for add or update")
+		return nil, nil, fmt.Errorf("invalid request multiple config found for add or update")
 	}
 	configData := configMapRequest.ConfigData[0]
 	valid, err := impl.validateConfigData(configData)
 	if err != nil && !valid {
 		impl.logger.Errorw("error in validating", "error", err)
-		return configMapRequest, err
+		return configMapRequest, nil, err
 	}
 	var model *chartConfig.ConfigMapEnvModel
 	if configMapRequest.Id > 0 {
@@ -298,7 +309,13 @@ func (impl ConfigMapServiceImpl) CMEnvironmentAddUpdate(configMapRequest *bean.C
 	}
 	if err != nil && err != pg.ErrNoRows {
 		impl.logger.Errorw("error while fetching from db", "error", err)
-		return nil, err
+		return nil, nil, err
+	}
+	draft, isDraft, draftErr := impl.saveEnvLevelDraftIfProtected(configMapRequest, model, bean.CM, draftBean.DraftActionAddUpdate)
+	if draftErr != nil {
+		return nil, nil, draftErr
+	} else if isDraft {
+		return configMapRequest, draft, nil
 	}
 	if err == nil && model.Id > 0 {
 		configsList := &ConfigsList{}
@@ -330,7 +347,7 @@ func (impl ConfigMapServiceImpl) CMEnvironmentAddUpdate(configMapRequest *bean.C
 		configsList.ConfigData = configs
 		configDataByte, err := json.Marshal(configsList)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		model.ConfigMapData = string(configDataByte)
 		model.UpdatedBy = configMapRequest.UserId
@@ -339,7 +356,7 @@ func (impl ConfigMapServiceImpl) CMEnvironmentAddUpdate(configMapRequest *bean.C
 		configMap, err := impl.configMapRepository.UpdateEnvLevel(model)
 		if err != nil {
 			impl.logger.Errorw("error while fetching from db", "error", err)
-			return nil, err
+			return nil, nil, err
 		}
 		configMapRequest.Id = configMap.Id
 
@@ -350,7 +367,7 @@ func (impl ConfigMapServiceImpl) CMEnvironmentAddUpdate(configMapRequest *bean.C
 		}
 		configDataByte, err := json.Marshal(configsList)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		model = &chartConfig.ConfigMapEnvModel{
 			AppId:         configMapRequest.AppId,
@@ -363,7 +380,7 @@ func (impl ConfigMapServiceImpl) CMEnvironmentAddUpdate(configMapRequest *bean.C
 		configMap, err := impl.configMapRepository.CreateEnvLevel(model)
 		if err != nil {
 			impl.logger.Errorw("error while creating app level", "error", err)
-			return nil, err
+			return nil, nil, err
 		}
 		configMapRequest.Id = configMap.Id
 	}
@@ -371,14 +388,14 @@ func (impl ConfigMapServiceImpl) CMEnvironmentAddUpdate(configMapRequest *bean.C
 	//err = impl.extractAndMapVariables(model.ConfigMapData, model.Id, repository5.EntityTypeConfigMapEnvLevel, configMapRequest.UserId)
 	err = impl.scopedVariableManager.CreateVariableMappingsForCMEnv(model)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	err = impl.configMapHistoryService.CreateHistoryFromEnvLevelConfig(model, repository.CONFIGMAP_TYPE)
 	if err != nil {
 		impl.logger.Errorw("error in creating entry for CM/CS history in bulk update", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
-	return configMapRequest, nil
+	return configMapRequest, nil, nil
 }
 
 func (impl ConfigMapServiceImpl) CMGlobalFetchForEdit(name string, id int) (*bean.ConfigDataRequest, error) {
@@ -681,9 +698,9 @@ func (impl ConfigMapServiceImpl) CSGlobalFetch(appId int) (*bean.ConfigDataReque
 	return configDataRequest, nil
 }
 
-func (impl ConfigMapServiceImpl) CSEnvironmentAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, error) {
+func (impl ConfigMapServiceImpl) CSEnvironmentAddUpdate(configMapRequest *bean.ConfigDataRequest) (*bean.ConfigDataRequest, *draftBean.ConfigDraftDto, error) {
 	if len(configMapRequest.ConfigData) != 1 {
-		return nil, fmt.Errorf("invalid request multiple config found for add or update")
+		return nil, nil, fmt.Errorf("invalid request multiple config found for add or update")
 	}
 
 	configData := configMapRequest.ConfigData[0]
@@ -691,18 +708,18 @@ func (impl ConfigMapServiceImpl) CSEnvironmentAddUpdate(configMapRequest *bean.C
 	valid, err := impl.validateConfigData(configData)
 	if err != nil && !valid {
 		impl.logger.Errorw("error in validating", "error", err)
-		return configMapRequest, err
+		return configMapRequest, nil, err
 	}
 	valid, err = impl.validateConfigDataForSecretsOnly(configData)
 	if err != nil && !valid {
 		impl.logger.Errorw("error in validating secrets only data", "error", err)
-		return configMapRequest, err
+		return configMapRequest, nil, err
 	}
 
 	valid, err = impl.validateExternalSecretChartCompatibility(configMapRequest.AppId, configMapRequest.EnvironmentId, configData)
 	if err != nil && !valid {
 		impl.logger.Errorw("error in validating", "error", err)
-		return configMapRequest, err
+		return configMapRequest, nil, err
 	}
 	var model *chartConfig.ConfigMapEnvModel
 	if configMapRequest.Id > 0 {
@@ -712,7 +729,13 @@ func (impl ConfigMapServiceImpl) CSEnvironmentAddUpdate(configMapRequest *bean.C
 	}
 	if err != nil && err != pg.ErrNoRows {
 		impl.logger.Errorw("error while fetching from db", "error", err)
-		return nil, err
+		return nil, nil, err
+	}
+	draft, isDraft, draftErr := impl.saveEnvLevelDraftIfProtected(configMapRequest, model, bean.CS, draftBean.DraftActionAddUpdate)
+	if draftErr != nil {
+		return nil, nil, draftErr
+	} else if isDraft {
+		return configMapRequest, draft, nil
 	}
 	if err == nil && model.Id > 0 {
 		configsList := &bean.SecretsList{}
@@ -748,7 +771,7 @@ func (impl ConfigMapServiceImpl) CSEnvironmentAddUpdate(configMapRequest *bean.C
 		configsList.ConfigData = configs
 		secretDataByte, err := json.Marshal(configsList)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		model.SecretData = string(secretDataByte)
 		model.UpdatedBy = configMapRequest.UserId
@@ -757,7 +780,7 @@ func (impl ConfigMapServiceImpl) CSEnvironmentAddUpdate(configMapRequest *bean.C
 		configMap, err := impl.configMapRepository.UpdateEnvLevel(model)
 		if err != nil {
 			impl.logger.Errorw("error while fetching from db", "error", err)
-			return nil, err
+			return nil, nil, err
 		}
 		configMapRequest.Id = configMap.Id
 
@@ -768,7 +791,7 @@ func (impl ConfigMapServiceImpl) CSEnvironmentAddUpdate(configMapRequest *bean.C
 		}
 		secretDataByte, err := json.Marshal(secretsList)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		model = &chartConfig.ConfigMapEnvModel{
 			AppId:         configMapRequest.AppId,
@@ -781,20 +804,20 @@ func (impl ConfigMapServiceImpl) CSEnvironmentAddUpdate(configMapRequest *bean.C
 		configMap, err := impl.configMapRepository.CreateEnvLevel(model)
 		if err != nil {
 			impl.logger.Errorw("error while creating app level", "error", err)
-			return nil, err
+			return nil, nil, err
 		}
 		configMapRequest.Id = configMap.Id
 	}
 	err = impl.scopedVariableManager.CreateVariableMappingsForSecretEnv(model)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	err = impl.configMapHistoryService.CreateHistoryFromEnvLevelConfig(model, repository.SECRET_TYPE)
 	if err != nil {
 		impl.logger.Errorw("error in creating entry for CM/CS history in bulk update", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
-	return configMapRequest, nil
+	return configMapRequest, nil, nil
 }
 
 func (impl ConfigMapServiceImpl) CSEnvironmentFetch(appId int, envId int) (*bean.ConfigDataRequest, error) {
@@ -967,12 +990,35 @@ func (impl ConfigMapServiceImpl) CMGlobalDelete(name string, id int, userId int3
 	return true, nil
 }
 
-func (impl ConfigMapServiceImpl) CMEnvironmentDelete(name string, id int, userId int32) (bool, error) {
+func (impl ConfigMapServiceImpl) CMEnvironmentDelete(name string, id int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
+	return impl.cmEnvironmentDelete(name, id, userId, false)
+}
+
+func (impl ConfigMapServiceImpl) CMEnvironmentDeleteSkippingProtection(name string, id int, userId int32) (bool, error) {
+	deleted, _, err := impl.cmEnvironmentDelete(name, id, userId, true)
+	return deleted, err
+}
+
+func (impl ConfigMapServiceImpl) cmEnvironmentDelete(name string, id int, userId int32, skipConfigProtection bool) (bool, *draftBean.ConfigDraftDto, error) {
 
 	model, err := impl.configMapRepository.GetByIdEnvLevel(id)
 	if err != nil {
 		impl.logger.Errorw("error while fetching from db", "error", err)
-		return false, err
+		return false, nil, err
+	}
+	deleteRequest := &bean.ConfigDataRequest{
+		Id:                   model.Id,
+		AppId:                model.AppId,
+		EnvironmentId:        model.EnvironmentId,
+		ConfigData:           []*bean.ConfigData{{Name: name}},
+		UserId:               userId,
+		SkipConfigProtection: skipConfigProtection,
+	}
+	draft, isDraft, err := impl.saveEnvLevelDraftIfProtected(deleteRequest, model, bean.CM, draftBean.DraftActionDelete)
+	if err != nil {
+		return false, nil, err
+	} else if isDraft {
+		return false, draft, nil
 	}
 	configsList := &ConfigsList{}
 	found := false
@@ -995,7 +1041,7 @@ func (impl ConfigMapServiceImpl) CMEnvironmentDelete(name string, id int, userId
 		configsList.ConfigData = configs
 		configDataByte, err := json.Marshal(configsList)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		model.ConfigMapData = string(configDataByte)
 		model.UpdatedBy = userId
@@ -1003,22 +1049,22 @@ func (impl ConfigMapServiceImpl) CMEnvironmentDelete(name string, id int, userId
 		//VARIABLE_MAPPING_UPDATE
 		err = impl.scopedVariableManager.CreateVariableMappingsForCMEnv(model)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		_, err = impl.configMapRepository.UpdateEnvLevel(model)
 		if err != nil {
 			impl.logger.Errorw("error while updating at env level", "error", err)
-			return false, err
+			return false, nil, err
 		}
 		err = impl.configMapHistoryService.CreateHistoryFromEnvLevelConfig(model, repository.CONFIGMAP_TYPE)
 		if err != nil {
 			impl.logger.Errorw("error in creating entry for configmap env history", "err", err)
-			return false, err
+			return false, nil, err
 		}
 	} else {
 		impl.logger.Debugw("no config map found for delete with this name", "name", name)
 	}
-	return true, nil
+	return true, nil, nil
 }
 
 func (impl ConfigMapServiceImpl) CSGlobalDelete(name string, id int, userId int32) (bool, error) {
@@ -1077,12 +1123,35 @@ func (impl ConfigMapServiceImpl) CSGlobalDelete(name string, id int, userId int3
 	return true, nil
 }
 
-func (impl ConfigMapServiceImpl) CSEnvironmentDelete(name string, id int, userId int32) (bool, error) {
+func (impl ConfigMapServiceImpl) CSEnvironmentDelete(name string, id int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
+	return impl.csEnvironmentDelete(name, id, userId, false)
+}
+
+func (impl ConfigMapServiceImpl) CSEnvironmentDeleteSkippingProtection(name string, id int, userId int32) (bool, error) {
+	deleted, _, err := impl.csEnvironmentDelete(name, id, userId, true)
+	return deleted, err
+}
+
+func (impl ConfigMapServiceImpl) csEnvironmentDelete(name string, id int, userId int32, skipConfigProtection bool) (bool, *draftBean.ConfigDraftDto, error) {
 
 	model, err := impl.configMapRepository.GetByIdEnvLevel(id)
 	if err != nil {
 		impl.logger.Errorw("error while fetching from db", "error", err)
-		return false, err
+		return false, nil, err
+	}
+	deleteRequest := &bean.ConfigDataRequest{
+		Id:                   model.Id,
+		AppId:                model.AppId,
+		EnvironmentId:        model.EnvironmentId,
+		ConfigData:           []*bean.ConfigData{{Name: name}},
+		UserId:               userId,
+		SkipConfigProtection: skipConfigProtection,
+	}
+	draft, isDraft, err := impl.saveEnvLevelDraftIfProtected(deleteRequest, model, bean.CS, draftBean.DraftActionDelete)
+	if err != nil {
+		return false, nil, err
+	} else if isDraft {
+		return false, draft, nil
 	}
 	configsList := &bean.SecretsList{}
 	found := false
@@ -1105,7 +1174,7 @@ func (impl ConfigMapServiceImpl) CSEnvironmentDelete(name string, id int, userId
 		configsList.ConfigData = configs
 		configDataByte, err := json.Marshal(configsList)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		model.SecretData = string(configDataByte)
 		model.UpdatedBy = userId
@@ -1113,22 +1182,22 @@ func (impl ConfigMapServiceImpl) CSEnvironmentDelete(name string, id int, userId
 		_, err = impl.configMapRepository.UpdateEnvLevel(model)
 		if err != nil {
 			impl.logger.Errorw("error while updating at env level ", "error", err)
-			return false, err
+			return false, nil, err
 		}
 		err = impl.scopedVariableManager.CreateVariableMappingsForSecretEnv(model)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		err = impl.configMapHistoryService.CreateHistoryFromEnvLevelConfig(model, repository.SECRET_TYPE)
 		if err != nil {
 			impl.logger.Errorw("error in creating entry for secret env history", "err", err)
-			return false, err
+			return false, nil, err
 		}
 	} else {
 		impl.logger.Debugw("no config map found for delete with this name", "name", name)
 	}
 
-	return true, nil
+	return true, nil, nil
 }
 
 /////
@@ -1183,12 +1252,25 @@ func (impl ConfigMapServiceImpl) CMGlobalDeleteByAppId(name string, appId int, u
 	return true, nil
 }
 
-func (impl ConfigMapServiceImpl) CMEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, error) {
+func (impl ConfigMapServiceImpl) CMEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
 
 	model, err := impl.configMapRepository.GetByAppIdAndEnvIdEnvLevel(appId, envId)
 	if err != nil {
 		impl.logger.Errorw("error while fetching from db", "error", err)
-		return false, err
+		return false, nil, err
+	}
+	deleteRequest := &bean.ConfigDataRequest{
+		Id:            model.Id,
+		AppId:         appId,
+		EnvironmentId: envId,
+		ConfigData:    []*bean.ConfigData{{Name: name}},
+		UserId:        userId,
+	}
+	draft, isDraft, err := impl.saveEnvLevelDraftIfProtected(deleteRequest, model, bean.CM, draftBean.DraftActionDelete)
+	if err != nil {
+		return false, nil, err
+	} else if isDraft {
+		return false, draft, nil
 	}
 	configsList := &ConfigsList{}
 	found := false
@@ -1211,25 +1293,25 @@ func (impl ConfigMapServiceImpl) CMEnvironmentDeleteByAppIdAndEnvId(name string,
 		configsList.ConfigData = configs
 		configDataByte, err := json.Marshal(configsList)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		model.ConfigMapData = string(configDataByte)
 		model.UpdatedBy = userId
 		model.UpdatedOn = time.Now()
 		err = impl.scopedVariableManager.CreateVariableMappingsForCMEnv(model)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		_, err = impl.configMapRepository.UpdateEnvLevel(model)
 		if err != nil {
 			impl.logger.Errorw("error while updating at env level", "error", err)
-			return false, err
+			return false, nil, err
 		}
 	} else {
 		impl.logger.Debugw("no config map found for delete with this name", "name", name)
 	}
 
-	return true, nil
+	return true, nil, nil
 }
 
 func (impl ConfigMapServiceImpl) CSGlobalDeleteByAppId(name string, appId int, userId int32) (bool, error) {
@@ -1283,12 +1365,25 @@ func (impl ConfigMapServiceImpl) CSGlobalDeleteByAppId(name string, appId int, u
 	return true, nil
 }
 
-func (impl ConfigMapServiceImpl) CSEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, error) {
+func (impl ConfigMapServiceImpl) CSEnvironmentDeleteByAppIdAndEnvId(name string, appId int, envId int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
 
 	model, err := impl.configMapRepository.GetByAppIdAndEnvIdEnvLevel(appId, envId)
 	if err != nil {
 		impl.logger.Errorw("error while fetching from db", "error", err)
-		return false, err
+		return false, nil, err
+	}
+	deleteRequest := &bean.ConfigDataRequest{
+		Id:            model.Id,
+		AppId:         appId,
+		EnvironmentId: envId,
+		ConfigData:    []*bean.ConfigData{{Name: name}},
+		UserId:        userId,
+	}
+	draft, isDraft, err := impl.saveEnvLevelDraftIfProtected(deleteRequest, model, bean.CS, draftBean.DraftActionDelete)
+	if err != nil {
+		return false, nil, err
+	} else if isDraft {
+		return false, draft, nil
 	}
 	configsList := &bean.SecretsList{}
 	found := false
@@ -1311,7 +1406,7 @@ func (impl ConfigMapServiceImpl) CSEnvironmentDeleteByAppIdAndEnvId(name string,
 		configsList.ConfigData = configs
 		configDataByte, err := json.Marshal(configsList)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		model.SecretData = string(configDataByte)
 		model.UpdatedBy = userId
@@ -1320,23 +1415,23 @@ func (impl ConfigMapServiceImpl) CSEnvironmentDeleteByAppIdAndEnvId(name string,
 		//sl := bean.SecretsList{}
 		//data, err := sl.GetTransformedDataForSecretList(model.SecretData, util2.DecodeSecret)
 		//if err != nil {
-		//	return false, err
+		//	return false, nil, err
 		//}
 		//err = impl.extractAndMapVariables(data, model.Id, repository5.EntityTypeSecretEnvLevel, model.UpdatedBy)
 		err = impl.scopedVariableManager.CreateVariableMappingsForSecretEnv(model)
 		if err != nil {
-			return false, err
+			return false, nil, err
 		}
 		_, err = impl.configMapRepository.UpdateEnvLevel(model)
 		if err != nil {
 			impl.logger.Errorw("error while updating at env level ", "error", err)
-			return false, err
+			return false, nil, err
 		}
 	} else {
 		impl.logger.Debugw("no config map found for delete with this name", "name", name)
 	}
 
-	return true, nil
+	return true, nil, nil
 }
 
 ////
@@ -1644,6 +1739,7 @@ func (impl ConfigMapServiceImpl) ConfigSecretEnvironmentBulkPatch(bulkPatchReque
 		if err == pg.ErrNoRows {
 			continue
 		}
+		var patchedConfigData *bean.ConfigData
 		if bulkPatchRequest.Type == "CM" {
 			configsList := &ConfigsList{}
 			var configs []*bean.ConfigData
@@ -1660,6 +1756,7 @@ func (impl ConfigMapServiceImpl) ConfigSecretEnvironmentBulkPatch(bulkPatchReque
 						impl.logger.Warnw("error while updating data", "error", err)
 					}
 					item.Data = updatedConfigData.Data
+					patchedConfigData = item
 				}
 				configs = append(configs, item)
 			}
@@ -1685,6 +1782,7 @@ func (impl ConfigMapServiceImpl) ConfigSecretEnvironmentBulkPatch(bulkPatchReque
 						impl.logger.Debugw("error while updating data", "error", err)
 					}
 					item.Data = updatedConfigData.Data
+					patchedConfigData = item
 				}
 				configs = append(configs, item)
 			}
@@ -1695,6 +1793,26 @@ func (impl ConfigMapServiceImpl) ConfigSecretEnvironmentBulkPatch(bulkPatchReque
 			}
 			model.SecretData = string(configDataByte)
 		}
+		if patchedConfigData != nil {
+			// a protected config is left unchanged, the patch is saved as a draft of it to be reviewed
+			resourceType := bean.CM
+			if bulkPatchRequest.Type == "CS" {
+				resourceType = bean.CS
+			}
+			patchRequest := &bean.ConfigDataRequest{
+				Id:            model.Id,
+				AppId:         model.AppId,
+				EnvironmentId: model.EnvironmentId,
+				ConfigData:    []*bean.ConfigData{patchedConfigData},
+				UserId:        bulkPatchRequest.UserId,
+			}
+			_, isDraft, err := impl.saveEnvLevelDraftIfProtected(patchRequest, model, resourceType, draftBean.DraftActionAddUpdate)
+			if err != nil {
+				return nil, err
+			} else if isDraft {
+				continue
+			}
+		}
 		model.UpdatedBy = bulkPatchRequest.UserId
 		model.UpdatedOn = time.Now()
 		_, err = impl.configMapRepository.UpdateEnvLevel(model)
@@ -1963,3 +2081,18 @@ func (impl ConfigMapServiceImpl) ConfigGlobalFetchEditUsingAppId(name string, ap
 	configDataRequest.ConfigData = configs
 	return configDataRequest, nil
 }
+
+// saveEnvLevelDraftIfProtected saves the change as a draft and returns it with isDraft set when the configs of the app
+// on the environment are protected, the app and environment are taken from the env level model when it exists as
+// update requests may only carry its id
+func (impl ConfigMapServiceImpl) saveEnvLevelDraftIfProtected(configDataRequest *bean.ConfigDataRequest, model *chartConfig.ConfigMapEnvModel,
+	resourceType bean.ResourceType, action draftBean.DraftAction) (*draftBean.ConfigDraftDto, bool, error) {
+	if configDataRequest.SkipConfigProtection {
+		return nil, false, nil
+	}
+	appId, envId := configDataRequest.AppId, configDataRequest.EnvironmentId
+	if model != nil && model.Id > 0 {
+		appId, envId = model.AppId, model.EnvironmentId
+	}
+	return impl.configProtectionService.SaveDraftIfProtected(appId, envId, resourceType, action, configDataRequest, configDataRequest.UserId)
+}
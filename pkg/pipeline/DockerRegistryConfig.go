@@ -22,6 +22,7 @@ import (
 	bean2 "github.com/devtron-labs/devtron/api/helm-app/gRPC"
 	client "github.com/devtron-labs/devtron/api/helm-app/service"
 	"github.com/devtron-labs/devtron/pkg/argoRepositoryCreds"
+	"github.com/devtron-labs/devtron/pkg/dockerRegistry/registryClient"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"github.com/go-pg/pg"
@@ -52,6 +53,10 @@ type DockerRegistryConfig interface {
 	FilterOCIRegistryConfigForSpecificRepoType(ociRegistryConfigList []*repository.OCIRegistryConfig, repositoryType string) *repository.OCIRegistryConfig
 	FilterRegistryBeanListBasedOnStorageTypeAndAction(bean []types.DockerArtifactStoreBean, storageType string, actionTypes ...string) []types.DockerArtifactStoreBean
 	ValidateRegistryStorageType(registryId string, storageType string, storageActions ...string) bool
+	// ListRepositories lists the repositories of the registry matching searchKey, for the registries browsable
+	// through the registry api
+	ListRepositories(storeId string, searchKey string) (*registryClient.RepositoryListResponse, error)
+	ListRepositoryTags(storeId string, repositoryName string) (*registryClient.TagListResponse, error)
 }
 
 const (
@@ -93,6 +98,7 @@ func NewDockerArtifactStore(bean *types.DockerArtifactStoreBean, isActive bool,
 		AWSRegion:              bean.AWSRegion,
 		Username:               bean.Username,
 		Password:               bean.Password,
+		CredentialType:         bean.CredentialType,
 		IsDefault:              bean.IsDefault,
 		Connection:             bean.Connection,
 		Cert:                   bean.Cert,
@@ -431,6 +437,7 @@ func (impl DockerRegistryConfigImpl) FetchAllDockerAccounts() ([]types.DockerArt
 			AWSRegion:              store.AWSRegion,
 			Username:               store.Username,
 			Password:               "",
+			CredentialType:         store.CredentialType,
 			IsDefault:              store.IsDefault,
 			Connection:             store.Connection,
 			Cert:                   store.Cert,
@@ -490,6 +497,7 @@ func (impl DockerRegistryConfigImpl) FetchOneDockerAccount(storeId string) (*typ
 		AWSRegion:              store.AWSRegion,
 		Username:               store.Username,
 		Password:               store.Password,
+		CredentialType:         store.CredentialType,
 		IsDefault:              store.IsDefault,
 		Connection:             store.Connection,
 		Cert:                   store.Cert,
@@ -886,6 +894,9 @@ func (impl DockerRegistryConfigImpl) CheckInActiveDockerAccount(storeId string)
 const ociRegistryInvalidCredsMsg = "Invalid authentication credentials. Please verify."
 
 func (impl DockerRegistryConfigImpl) ValidateRegistryCredentials(bean *types.DockerArtifactStoreBean) error {
+	if !bean.IsPublic && registryClient.IsSupportedRegistryType(bean.RegistryType) {
+		return impl.validateRegistryCredentialsWithRegistryApi(bean)
+	}
 	if bean.IsPublic ||
 		bean.RegistryType == repository.REGISTRYTYPE_GCR ||
 		bean.RegistryType == repository.REGISTRYTYPE_ARTIFACT_REGISTRY ||
@@ -921,3 +932,87 @@ func (impl DockerRegistryConfigImpl) ValidateRegistryCredentials(bean *types.Doc
 
 	return nil
 }
+
+// validateRegistryCredentialsWithRegistryApi logs in to the registries having provider specific credentials
+func (impl DockerRegistryConfigImpl) validateRegistryCredentialsWithRegistryApi(bean *types.DockerArtifactStoreBean) error {
+	config := registryClient.GetRegistryConfig(bean)
+	err := registryClient.ValidateCredentials(config)
+	if err != nil {
+		return util.NewApiError().
+			WithUserMessage(err.Error()).
+			WithInternalMessage(err.Error()).
+			WithHttpStatusCode(http.StatusBadRequest)
+	}
+	client, err := registryClient.NewRegistryClient(config)
+	if err != nil {
+		return util.NewApiError().
+			WithUserMessage(err.Error()).
+			WithInternalMessage(err.Error()).
+			WithHttpStatusCode(http.StatusBadRequest)
+	}
+	err = client.Ping(context.Background())
+	if err == registryClient.ErrUnauthorized {
+		return util.NewApiError().
+			WithUserMessage(ociRegistryInvalidCredsMsg).
+			WithInternalMessage(ociRegistryInvalidCredsMsg).
+			WithHttpStatusCode(http.StatusBadRequest)
+	} else if err != nil {
+		impl.logger.Errorw("error in connecting to registry", "registryUrl", bean.RegistryURL, "err", err)
+		return util.NewApiError().
+			WithUserMessage("error in validating registry").
+			WithInternalMessage(err.Error()).
+			WithHttpStatusCode(http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (impl DockerRegistryConfigImpl) getRegistryClient(storeId string) (registryClient.RegistryClient, error) {
+	store, err := impl.FetchOneDockerAccount(storeId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching docker registry", "storeId", storeId, "err", err)
+		return nil, err
+	}
+	if !registryClient.IsSupportedRegistryType(store.RegistryType) {
+		message := fmt.Sprintf("browsing repositories is not supported for %s registry", store.RegistryType)
+		return nil, util.NewApiError().
+			WithUserMessage(message).
+			WithInternalMessage(message).
+			WithHttpStatusCode(http.StatusBadRequest)
+	}
+	return registryClient.NewRegistryClient(registryClient.GetRegistryConfig(store))
+}
+
+func (impl DockerRegistryConfigImpl) ListRepositories(storeId string, searchKey string) (*registryClient.RepositoryListResponse, error) {
+	client, err := impl.getRegistryClient(storeId)
+	if err != nil {
+		return nil, err
+	}
+	repositories, err := client.ListRepositories(context.Background())
+	if err != nil {
+		impl.logger.Errorw("error in listing registry repositories", "storeId", storeId, "err", err)
+		return nil, err
+	}
+	if len(searchKey) > 0 {
+		filtered := make([]string, 0, len(repositories))
+		for _, repositoryName := range repositories {
+			if strings.Contains(repositoryName, searchKey) {
+				filtered = append(filtered, repositoryName)
+			}
+		}
+		repositories = filtered
+	}
+	return &registryClient.RepositoryListResponse{Repositories: repositories}, nil
+}
+
+func (impl DockerRegistryConfigImpl) ListRepositoryTags(storeId string, repositoryName string) (*registryClient.TagListResponse, error) {
+	client, err := impl.getRegistryClient(storeId)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := client.ListTags(context.Background(), repositoryName)
+	if err != nil {
+		impl.logger.Errorw("error in listing repository tags", "storeId", storeId, "repositoryName", repositoryName, "err", err)
+		return nil, err
+	}
+	return &registryClient.TagListResponse{Repository: repositoryName, Tags: tags}, nil
+}
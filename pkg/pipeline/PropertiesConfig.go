@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	draftBean "github.com/devtron-labs/devtron/pkg/configDraft/bean"
+	"github.com/devtron-labs/devtron/pkg/configDraft/protection"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deployedAppMetrics"
 	bean2 "github.com/devtron-labs/devtron/pkg/deployment/manifest/deployedAppMetrics/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
@@ -40,8 +42,10 @@ import (
 )
 
 type PropertiesConfigService interface {
-	CreateEnvironmentProperties(appId int, propertiesRequest *bean.EnvironmentProperties) (*bean.EnvironmentProperties, error)
-	UpdateEnvironmentProperties(appId int, propertiesRequest *bean.EnvironmentProperties, userId int32) (*bean.EnvironmentProperties, error)
+	// CreateEnvironmentProperties and UpdateEnvironmentProperties return the saved draft instead of applying the change
+	// when the configs of the app on the environment are protected, the same applies to ResetEnvironmentProperties
+	CreateEnvironmentProperties(appId int, propertiesRequest *bean.EnvironmentProperties) (*bean.EnvironmentProperties, *draftBean.ConfigDraftDto, error)
+	UpdateEnvironmentProperties(appId int, propertiesRequest *bean.EnvironmentProperties, userId int32) (*bean.EnvironmentProperties, *draftBean.ConfigDraftDto, error)
 	//create environment entry for each new environment
 	CreateIfRequired(chart *chartRepoRepository.Chart, environmentId int, userId int32, manualReviewed bool, chartStatus models.ChartStatus, isOverride, isAppMetricsEnabled bool, namespace string, IsBasicViewLocked bool, CurrentViewEditor models.ChartsViewEditorType, tx *pg.Tx) (*chartConfig.EnvConfigOverride, bool, error)
 	GetEnvironmentProperties(appId, environmentId int, chartRefId int) (environmentPropertiesResponse *bean.EnvironmentPropertiesResponse, err error)
@@ -49,7 +53,10 @@ type PropertiesConfigService interface {
 
 	GetAppIdByChartEnvId(chartEnvId int) (*chartConfig.EnvConfigOverride, error)
 	GetLatestEnvironmentProperties(appId, environmentId int) (*bean.EnvironmentProperties, error)
-	ResetEnvironmentProperties(id int) (bool, error)
+	ResetEnvironmentProperties(id int, userId int32) (bool, *draftBean.ConfigDraftDto, error)
+	// ResetEnvironmentPropertiesSkippingProtection resets the override without saving a draft for protected configs,
+	// used to apply a reset which has already been reviewed
+	ResetEnvironmentPropertiesSkippingProtection(id int, userId int32) (bool, error)
 	CreateEnvironmentPropertiesWithNamespace(appId int, propertiesRequest *bean.EnvironmentProperties) (*bean.EnvironmentProperties, error)
 
 	FetchEnvProperties(appId, envId, chartRefId int) (*chartConfig.EnvConfigOverride, error)
@@ -62,6 +69,7 @@ type PropertiesConfigServiceImpl struct {
 	deploymentTemplateHistoryService history.DeploymentTemplateHistoryService
 	scopedVariableManager            variables.ScopedVariableManager
 	deployedAppMetricsService        deployedAppMetrics.DeployedAppMetricsService
+	configProtectionService          protection.ConfigProtectionService
 }
 
 func NewPropertiesConfigServiceImpl(logger *zap.SugaredLogger,
@@ -70,7 +78,8 @@ func NewPropertiesConfigServiceImpl(logger *zap.SugaredLogger,
 	environmentRepository repository2.EnvironmentRepository,
 	deploymentTemplateHistoryService history.DeploymentTemplateHistoryService,
 	scopedVariableManager variables.ScopedVariableManager,
-	deployedAppMetricsService deployedAppMetrics.DeployedAppMetricsService) *PropertiesConfigServiceImpl {
+	deployedAppMetricsService deployedAppMetrics.DeployedAppMetricsService,
+	configProtectionService protection.ConfigProtectionService) *PropertiesConfigServiceImpl {
 	return &PropertiesConfigServiceImpl{
 		logger:                           logger,
 		envConfigRepo:                    envConfigRepo,
@@ -79,6 +88,7 @@ func NewPropertiesConfigServiceImpl(logger *zap.SugaredLogger,
 		deploymentTemplateHistoryService: deploymentTemplateHistoryService,
 		scopedVariableManager:            scopedVariableManager,
 		deployedAppMetricsService:        deployedAppMetricsService,
+		configProtectionService:          configProtectionService,
 	}
 
 }
@@ -184,14 +194,22 @@ func (impl PropertiesConfigServiceImpl) FetchEnvProperties(appId, envId, chartRe
 	return impl.envConfigRepo.GetByAppIdEnvIdAndChartRefId(appId, envId, chartRefId)
 }
 
-func (impl PropertiesConfigServiceImpl) CreateEnvironmentProperties(appId int, environmentProperties *bean.EnvironmentProperties) (*bean.EnvironmentProperties, error) {
+func (impl PropertiesConfigServiceImpl) CreateEnvironmentProperties(appId int, environmentProperties *bean.EnvironmentProperties) (*bean.EnvironmentProperties, *draftBean.ConfigDraftDto, error) {
+	if !environmentProperties.SkipConfigProtection {
+		draft, isDraft, err := impl.configProtectionService.SaveDraftIfProtected(appId, environmentProperties.EnvironmentId, bean.DeploymentTemplate, draftBean.DraftActionAddUpdate, environmentProperties, environmentProperties.UserId)
+		if err != nil {
+			return nil, nil, err
+		} else if isDraft {
+			return environmentProperties, draft, nil
+		}
+	}
 	chart, err := impl.chartRepo.FindChartByAppIdAndRefId(appId, environmentProperties.ChartRefId)
 	if err != nil && pg.ErrNoRows != err {
-		return nil, err
+		return nil, nil, err
 	}
 	if pg.ErrNoRows == err {
 		impl.logger.Errorw("create new chart set latest=false", "a", "b")
-		return nil, fmt.Errorf("NOCHARTEXIST")
+		return nil, nil, fmt.Errorf("NOCHARTEXIST")
 	}
 	chart.GlobalOverride = string(environmentProperties.EnvOverrideValues)
 	appMetrics := false
@@ -200,17 +218,17 @@ func (impl PropertiesConfigServiceImpl) CreateEnvironmentProperties(appId int, e
 	}
 	envOverride, appMetrics, err := impl.CreateIfRequired(chart, environmentProperties.EnvironmentId, environmentProperties.UserId, environmentProperties.ManualReviewed, models.CHARTSTATUS_SUCCESS, true, appMetrics, environmentProperties.Namespace, environmentProperties.IsBasicViewLocked, environmentProperties.CurrentViewEditor, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	environmentProperties.AppMetrics = &appMetrics
 	r := json.RawMessage{}
 	err = r.UnmarshalJSON([]byte(envOverride.EnvOverrideValues))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	env, err := impl.environmentRepository.FindById(environmentProperties.EnvironmentId)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	environmentProperties = &bean.EnvironmentProperties{
 		Id:                envOverride.Id,
@@ -227,35 +245,43 @@ func (impl PropertiesConfigServiceImpl) CreateEnvironmentProperties(appId int, e
 	}
 	if err != nil {
 		impl.logger.Errorw("chart version parsing", "err", err, "chartVersion", chart.ChartVersion)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return environmentProperties, nil
+	return environmentProperties, nil, nil
 }
 
-func (impl PropertiesConfigServiceImpl) UpdateEnvironmentProperties(appId int, propertiesRequest *bean.EnvironmentProperties, userId int32) (*bean.EnvironmentProperties, error) {
+func (impl PropertiesConfigServiceImpl) UpdateEnvironmentProperties(appId int, propertiesRequest *bean.EnvironmentProperties, userId int32) (*bean.EnvironmentProperties, *draftBean.ConfigDraftDto, error) {
 	//check if exists
 	oldEnvOverride, err := impl.envConfigRepo.GetByIdIncludingInactive(propertiesRequest.Id)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if !propertiesRequest.SkipConfigProtection {
+		draft, isDraft, err := impl.configProtectionService.SaveDraftIfProtected(oldEnvOverride.Chart.AppId, oldEnvOverride.TargetEnvironment, bean.DeploymentTemplate, draftBean.DraftActionAddUpdate, propertiesRequest, userId)
+		if err != nil {
+			return nil, nil, err
+		} else if isDraft {
+			return propertiesRequest, draft, nil
+		}
 	}
 	overrideByte, err := propertiesRequest.EnvOverrideValues.MarshalJSON()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	env, err := impl.environmentRepository.FindById(oldEnvOverride.TargetEnvironment)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	//FIXME add check for restricted NS also like (kube-system, devtron, monitoring, etc)
 	if env.Namespace != "" && env.Namespace != propertiesRequest.Namespace {
-		return nil, fmt.Errorf("enviremnt is restricted to namespace: %s only, cant deploy to: %s", env.Namespace, propertiesRequest.Namespace)
+		return nil, nil, fmt.Errorf("enviremnt is restricted to namespace: %s only, cant deploy to: %s", env.Namespace, propertiesRequest.Namespace)
 	}
 
 	if !oldEnvOverride.Latest {
 		envOverrideExisting, err := impl.envConfigRepo.FindLatestChartForAppByAppIdAndEnvId(appId, oldEnvOverride.TargetEnvironment)
 		if err != nil && !errors.IsNotFound(err) {
-			return nil, err
+			return nil, nil, err
 		}
 		if envOverrideExisting != nil {
 			envOverrideExisting.Latest = false
@@ -264,7 +290,7 @@ func (impl PropertiesConfigServiceImpl) UpdateEnvironmentProperties(appId int, p
 			envOverrideExisting.UpdatedBy = userId
 			envOverrideExisting, err = impl.envConfigRepo.Update(envOverrideExisting)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 	}
@@ -289,12 +315,12 @@ func (impl PropertiesConfigServiceImpl) UpdateEnvironmentProperties(appId int, p
 	err = impl.envConfigRepo.UpdateProperties(override)
 
 	if oldEnvOverride.Namespace != override.Namespace {
-		return nil, fmt.Errorf("namespace name update not supported")
+		return nil, nil, fmt.Errorf("namespace name update not supported")
 	}
 
 	if err != nil {
 		impl.logger.Errorw("chart version parsing", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	isAppMetricsEnabled := false
@@ -311,22 +337,22 @@ func (impl PropertiesConfigServiceImpl) UpdateEnvironmentProperties(appId int, p
 	err = impl.deployedAppMetricsService.CreateOrUpdateAppOrEnvLevelMetrics(context.Background(), envLevelMetricsUpdateReq)
 	if err != nil {
 		impl.logger.Errorw("error, CheckAndUpdateAppOrEnvLevelMetrics", "err", err, "req", envLevelMetricsUpdateReq)
-		return nil, err
+		return nil, nil, err
 	}
 
 	//creating history
 	err = impl.deploymentTemplateHistoryService.CreateDeploymentTemplateHistoryFromEnvOverrideTemplate(override, nil, isAppMetricsEnabled, 0)
 	if err != nil {
 		impl.logger.Errorw("error in creating entry for env deployment template history", "err", err, "envOverride", override)
-		return nil, err
+		return nil, nil, err
 	}
 	//VARIABLE_MAPPING_UPDATE
 	err = impl.scopedVariableManager.ExtractAndMapVariables(override.EnvOverrideValues, override.Id, repository5.EntityTypeDeploymentTemplateEnvLevel, override.CreatedBy, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return propertiesRequest, err
+	return propertiesRequest, nil, err
 }
 
 func (impl PropertiesConfigServiceImpl) CreateIfRequired(chart *chartRepoRepository.Chart, environmentId int, userId int32, manualReviewed bool, chartStatus models.ChartStatus, isOverride, isAppMetricsEnabled bool, namespace string, IsBasicViewLocked bool, CurrentViewEditor models.ChartsViewEditorType, tx *pg.Tx) (*chartConfig.EnvConfigOverride, bool, error) {
@@ -507,18 +533,39 @@ func (impl PropertiesConfigServiceImpl) GetLatestEnvironmentProperties(appId, en
 	return environmentProperties, nil
 }
 
-func (impl PropertiesConfigServiceImpl) ResetEnvironmentProperties(id int) (bool, error) {
+func (impl PropertiesConfigServiceImpl) ResetEnvironmentProperties(id int, userId int32) (bool, *draftBean.ConfigDraftDto, error) {
+	envOverride, err := impl.envConfigRepo.GetByIdIncludingInactive(id)
+	if err != nil {
+		return false, nil, err
+	}
+	resetRequest := &bean.EnvironmentProperties{Id: envOverride.Id, EnvironmentId: envOverride.TargetEnvironment}
+	draft, isDraft, err := impl.configProtectionService.SaveDraftIfProtected(envOverride.Chart.AppId, envOverride.TargetEnvironment, bean.DeploymentTemplate, draftBean.DraftActionDelete, resetRequest, userId)
+	if err != nil {
+		return false, nil, err
+	} else if isDraft {
+		return false, draft, nil
+	}
+	isSuccess, err := impl.resetEnvironmentProperties(envOverride, userId)
+	return isSuccess, nil, err
+}
+
+func (impl PropertiesConfigServiceImpl) ResetEnvironmentPropertiesSkippingProtection(id int, userId int32) (bool, error) {
 	envOverride, err := impl.envConfigRepo.GetByIdIncludingInactive(id)
 	if err != nil {
 		return false, err
 	}
+	return impl.resetEnvironmentProperties(envOverride, userId)
+}
+
+func (impl PropertiesConfigServiceImpl) resetEnvironmentProperties(envOverride *chartConfig.EnvConfigOverride, userId int32) (bool, error) {
 	envOverride.EnvOverrideValues = "{}"
 	envOverride.IsOverride = false
 	envOverride.Latest = false
+	envOverride.UpdateAuditLog(userId)
 	impl.logger.Infow("reset environment override ", "value", envOverride)
-	err = impl.envConfigRepo.UpdateProperties(envOverride)
+	err := impl.envConfigRepo.UpdateProperties(envOverride)
 	if err != nil {
-		impl.logger.Warnw("error in update envOverride", "envOverrideId", id)
+		impl.logger.Warnw("error in update envOverride", "envOverrideId", envOverride.Id)
 	}
 	err = impl.deployedAppMetricsService.DeleteEnvLevelMetricsIfPresent(envOverride.Chart.AppId, envOverride.TargetEnvironment)
 	if err != nil {
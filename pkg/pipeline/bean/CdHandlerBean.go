@@ -33,4 +33,6 @@ type CdWorkflowWithArtifact struct {
 	ImageReleaseTags      []*repository.ImageTag                      `json:"imageReleaseTags"`
 	ImageComment          *repository.ImageComment                    `json:"imageComment"`
 	RefCdWorkflowRunnerId int                                         `json:"referenceCdWorkflowRunnerId"`
+	TriggerType           string                                      `json:"triggerType,omitempty"`
+	GitOpsPullRequestUrl  string                                      `json:"gitOpsPullRequestUrl,omitempty"`
 }
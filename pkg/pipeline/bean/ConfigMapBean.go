@@ -27,6 +27,8 @@ type ConfigDataRequest struct {
 	EnvironmentId int           `json:"environmentId,omitempty"`
 	ConfigData    []*ConfigData `json:"configData"`
 	UserId        int32         `json:"-"`
+	// SkipConfigProtection is set when applying a change which has already been reviewed through a draft
+	SkipConfigProtection bool `json:"-"`
 }
 
 type ESOSecretData struct {
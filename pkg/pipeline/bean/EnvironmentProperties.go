@@ -39,6 +39,8 @@ type EnvironmentProperties struct {
 	CurrentViewEditor models.ChartsViewEditorType `json:"currentViewEditor"` //default "UNDEFINED" in db
 	Description       string                      `json:"description" validate:"max=40"`
 	ClusterId         int                         `json:"clusterId"`
+	// SkipConfigProtection is set when applying a change which has already been reviewed through a draft
+	SkipConfigProtection bool `json:"-"`
 }
 
 type EnvironmentPropertiesResponse struct {
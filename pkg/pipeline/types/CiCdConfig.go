@@ -84,6 +84,11 @@ type CiCdConfig struct {
 	ShowDockerBuildCmdInLogs         bool                            `env:"SHOW_DOCKER_BUILD_ARGS" envDefault:"true"`
 	IgnoreCmCsInCiJob                bool                            `env:"IGNORE_CM_CS_IN_CI_JOB" envDefault:"false"`
 	SkipCiJobBuildCachePushPull      bool                            `env:"SKIP_CI_JOB_BUILD_CACHE_PUSH_PULL" envDefault:"false"`
+	CiSbomGenerationEnabled          bool                            `env:"CI_SBOM_GENERATION_ENABLED" envDefault:"false"`
+	CiSbomFormat                     string                          `env:"CI_SBOM_FORMAT" envDefault:"cyclonedx-json"`
+	CiImageSigningEnabled            bool                            `env:"CI_IMAGE_SIGNING_ENABLED" envDefault:"false"`
+	CiImageSigningTool               string                          `env:"CI_IMAGE_SIGNING_TOOL" envDefault:"cosign"`
+	CiImageSigningKeySecret          string                          `env:"CI_IMAGE_SIGNING_KEY_SECRET" envDefault:""` // secret in the ci namespace with the signing key, images are signed keyless without it
 	// from CdConfig
 	CdLimitCpu                       string                          `env:"CD_LIMIT_CI_CPU" envDefault:"0.5"`
 	CdLimitMem                       string                          `env:"CD_LIMIT_CI_MEM" envDefault:"3G"`
@@ -115,6 +115,10 @@ type WorkflowRequest struct {
 	IsExtRun                   bool                              `json:"isExtRun"`
 	ImageRetryCount            int                               `json:"imageRetryCount"`
 	ImageRetryInterval         int                               `json:"imageRetryInterval"`
+	GenerateSbom               bool                              `json:"generateSbom"`
+	SbomFormat                 string                            `json:"sbomFormat"`
+	SignImage                  bool                              `json:"signImage"`
+	ImageSigningTool           string                            `json:"imageSigningTool"`
 	// Data from CD Workflow service
 	WorkflowRunnerId            int                                  `json:"workflowRunnerId"`
 	CdPipelineId                int                                  `json:"cdPipelineId"`
@@ -269,9 +273,26 @@ func (workflowRequest *WorkflowRequest) getContainerEnvVariables(config *CiCdCon
 	inAppLoggingEnv := v1.EnvVar{Name: "IN_APP_LOGGING", Value: strconv.FormatBool(workflowRequest.InAppLoggingEnabled)}
 	showDockerBuildArgsEnv := v1.EnvVar{Name: "SHOW_DOCKER_BUILD_ARGS", Value: strconv.FormatBool(config.ShowDockerBuildCmdInLogs)}
 	containerEnvVariables = append(containerEnvVariables, eventEnv, inAppLoggingEnv, showDockerBuildArgsEnv)
+	if workflowRequest.SignImage && len(config.CiImageSigningKeySecret) > 0 {
+		containerEnvVariables = append(containerEnvVariables, getImageSigningEnvVariables(config.CiImageSigningKeySecret)...)
+	}
 	return containerEnvVariables
 }
 
+// getImageSigningEnvVariables reads the signing key, its password and, for notation, the signing certificate from the
+// secret, so that they are not part of the ci event
+func getImageSigningEnvVariables(secretName string) []v1.EnvVar {
+	secretEnv := func(name string, key string, optional bool) v1.EnvVar {
+		return v1.EnvVar{Name: name, ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: secretName}, Key: key, Optional: &optional}}}
+	}
+	return []v1.EnvVar{
+		secretEnv(IMAGE_SIGNING_KEY, "key", false),
+		secretEnv(IMAGE_SIGNING_KEY_PASSWORD, "password", true),
+		secretEnv(IMAGE_SIGNING_CERTIFICATE, "certificate", true),
+	}
+}
+
 func (workflowRequest *WorkflowRequest) getPVCForWorkflowRequest() string {
 	var pvc string
 	workflowRequestType := workflowRequest.Type
@@ -597,6 +618,12 @@ const CI_NODE_PVC_ALL_ENV = "devtron.ai/ci-pvc-all"
 
 const CI_NODE_PVC_PIPELINE_PREFIX = "devtron.ai/ci-pvc"
 
+const (
+	IMAGE_SIGNING_KEY          = "IMAGE_SIGNING_KEY"
+	IMAGE_SIGNING_KEY_PASSWORD = "IMAGE_SIGNING_KEY_PASSWORD"
+	IMAGE_SIGNING_CERTIFICATE  = "IMAGE_SIGNING_CERTIFICATE"
+)
+
 type CiArtifactDTO struct {
 	Id                   int    `json:"id"`
 	PipelineId           int    `json:"pipelineId"` // id of the ci pipeline from which this webhook was triggered
@@ -612,7 +612,7 @@ func (impl *ImageScanServiceImpl) GetArtifactVulnerabilityStatus(ctx context.Con
 		for _, item := range imageScanResult {
 			cveStores = append(cveStores, &item.CveStore)
 		}
-		_, span = otel.Tracer("orchestrator").Start(ctx, "cvePolicyRepository.GetBlockedCVEList")
+		_, span = otel.Tracer("orchestrator").Start(ctx, "policyService.GetBlockedCVEList")
 		if request.CdPipeline.Environment.ClusterId == 0 {
 			envDetails, err := impl.envService.GetDetailsById(request.CdPipeline.EnvironmentId)
 			if err != nil {
@@ -621,7 +621,7 @@ func (impl *ImageScanServiceImpl) GetArtifactVulnerabilityStatus(ctx context.Con
 			}
 			request.CdPipeline.Environment = *envDetails
 		}
-		blockCveList, err := impl.cvePolicyRepository.GetBlockedCVEList(cveStores, request.CdPipeline.Environment.ClusterId, request.CdPipeline.EnvironmentId, request.CdPipeline.AppId, false)
+		blockCveList, err := impl.policyService.GetBlockedCVEList(cveStores, request.CdPipeline.Environment.ClusterId, request.CdPipeline.EnvironmentId, request.CdPipeline.AppId, false)
 		span.End()
 		if err != nil {
 			impl.Logger.Errorw("error encountered in GetArtifactVulnerabilityStatus", "clusterId", request.CdPipeline.Environment.ClusterId, "envId", request.CdPipeline.EnvironmentId, "appId", request.CdPipeline.AppId, "err", err)
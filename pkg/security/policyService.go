@@ -18,11 +18,15 @@ package security
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	repository1 "github.com/devtron-labs/devtron/internal/sql/repository/app"
 	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
 	securityBean "github.com/devtron-labs/devtron/internal/sql/repository/security/bean"
+	"github.com/devtron-labs/devtron/pkg/cveException"
+	"github.com/devtron-labs/devtron/pkg/imageSignature"
+	signatureBean "github.com/devtron-labs/devtron/pkg/imageSignature/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"net/http"
@@ -67,6 +71,8 @@ type PolicyServiceImpl struct {
 	scanHistoryRepository         security.ImageScanHistoryRepository
 	cveStoreRepository            security.CveStoreRepository
 	ciTemplateRepository          pipelineConfig.CiTemplateRepository
+	imageSignatureService         imageSignature.ImageSignatureService
+	cveExceptionService           cveException.CveExceptionService
 }
 
 func NewPolicyServiceImpl(environmentService cluster.EnvironmentService,
@@ -81,7 +87,9 @@ func NewPolicyServiceImpl(environmentService cluster.EnvironmentService,
 	imageScanObjectMetaRepository security.ImageScanObjectMetaRepository, client *http.Client,
 	ciArtifactRepository repository.CiArtifactRepository, ciConfig *types.CiCdConfig,
 	scanHistoryRepository security.ImageScanHistoryRepository, cveStoreRepository security.CveStoreRepository,
-	ciTemplateRepository pipelineConfig.CiTemplateRepository) *PolicyServiceImpl {
+	ciTemplateRepository pipelineConfig.CiTemplateRepository,
+	imageSignatureService imageSignature.ImageSignatureService,
+	cveExceptionService cveException.CveExceptionService) *PolicyServiceImpl {
 	return &PolicyServiceImpl{
 		environmentService:            environmentService,
 		logger:                        logger,
@@ -99,6 +107,8 @@ func NewPolicyServiceImpl(environmentService cluster.EnvironmentService,
 		scanHistoryRepository:         scanHistoryRepository,
 		cveStoreRepository:            cveStoreRepository,
 		ciTemplateRepository:          ciTemplateRepository,
+		imageSignatureService:         imageSignatureService,
+		cveExceptionService:           cveExceptionService,
 	}
 }
 
@@ -128,6 +138,8 @@ type VerifyImageResponse struct {
 	Package      string
 	Version      string
 	FixedVersion string
+	// Message explains a block which is not a cve, like a failed image signature verification
+	Message string
 }
 
 type ScanEvent struct {
@@ -273,6 +285,20 @@ func (impl *PolicyServiceImpl) VerifyImage(verifyImageRequest *VerifyImageReques
 			imageBlockedCves[image] = append(imageBlockedCves[image], vr)
 		}
 	}
+	signatureResults, err := impl.imageSignatureService.VerifyImages(context.Background(), verifyImageRequest.Images, clusterId, envId)
+	if err != nil {
+		// a signature policy may apply on the images, they are blocked rather than let through unverified
+		impl.logger.Errorw("error in verifying image signatures", "images", verifyImageRequest.Images, "err", err)
+		message := fmt.Sprintf(signatureBean.SignatureCheckFailed, err.Error())
+		for _, image := range verifyImageRequest.Images {
+			imageBlockedCves[image] = append(imageBlockedCves[image], &VerifyImageResponse{Name: signatureBean.ImageSignatureCheck, Message: message})
+		}
+	}
+	for image, result := range signatureResults {
+		if !result.IsVerified() {
+			imageBlockedCves[image] = append(imageBlockedCves[image], &VerifyImageResponse{Name: signatureBean.ImageSignatureCheck, Message: result.Message})
+		}
+	}
 
 	if objectType == security.ScanObjectType_POD {
 		// TODO create entry
@@ -355,7 +381,19 @@ func (impl *PolicyServiceImpl) GetApplicablePolicy(clusterId, envId, appId int,
 	}
 
 	cvePolicy, severityPolicy, err := impl.getPolicies(policyLevel, clusterId, envId, appId)
-	return cvePolicy, severityPolicy, err
+	if err != nil {
+		return nil, nil, err
+	}
+	// approved exceptions allow their cves over the cve and severity policies of every level
+	allowedCves, err := impl.cveExceptionService.GetAllowedCves(appId, envId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cve exceptions", "appId", appId, "envId", envId, "err", err)
+		return nil, nil, err
+	}
+	for cveName := range allowedCves {
+		cvePolicy[cveName] = &security.CvePolicy{CVEStoreId: cveName, AppId: appId, EnvironmentId: envId, Action: securityBean.Allow}
+	}
+	return cvePolicy, severityPolicy, nil
 }
 func (impl *PolicyServiceImpl) getApplicablePolicies(policies []*security.CvePolicy) (map[string]*security.CvePolicy, map[securityBean.Severity]*security.CvePolicy) {
 	cvePolicy := make(map[string][]*security.CvePolicy)
@@ -40,6 +40,20 @@ func (_m *TerminalSessionHandler) Close(sessionId string, statusCode uint32, msg
 	_m.Called(sessionId, statusCode, msg)
 }
 
+// CloseSessionsForUser provides a mock function with given fields: userId, msg
+func (_m *TerminalSessionHandler) CloseSessionsForUser(userId int32, msg string) int {
+	ret := _m.Called(userId, msg)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int32, string) int); ok {
+		r0 = rf(userId, msg)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // GetTerminalSession provides a mock function with given fields: req
 func (_m *TerminalSessionHandler) GetTerminalSession(req *terminal.TerminalSessionRequest) (int, *terminal.TerminalMessage, error) {
 	ret := _m.Called(req)
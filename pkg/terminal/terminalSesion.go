@@ -27,9 +27,14 @@ import (
 	"github.com/devtron-labs/common-lib/utils/k8s"
 	"github.com/devtron-labs/devtron/internal/middleware"
 	"github.com/devtron-labs/devtron/pkg/argoApplication/read"
+	userRepository "github.com/devtron-labs/devtron/pkg/auth/user/repository"
 	"github.com/devtron-labs/devtron/pkg/cluster"
 	"github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording"
+	recordingBean "github.com/devtron-labs/devtron/pkg/terminalRecording/bean"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
 	errors1 "github.com/juju/errors"
+	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 	"io"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -51,6 +56,7 @@ import (
 const END_OF_TRANSMISSION = "\u0004"
 const ProcessExitedMsg = "Process exited"
 const ProcessTimedOut = "Process timedOut"
+const UserDeactivatedMsg = "User deactivated"
 
 // PtyHandler is what remotecommand expects from a pty
 type PtyHandler interface {
@@ -71,7 +77,9 @@ type TerminalSession struct {
 	podName           string
 	namespace         string
 	clusterId         string
+	userId            int32
 	startedOn         time.Time
+	recorder          *terminalRecording.Recorder
 }
 
 // TerminalMessage is the messaging protocol between ShellController and TerminalSession.
@@ -115,8 +123,10 @@ func (t TerminalSession) Read(p []byte) (int, error) {
 
 	switch msg.Op {
 	case "stdin":
+		t.recorder.RecordInput(msg.Data)
 		return copy(p, msg.Data), nil
 	case "resize":
+		t.recorder.RecordResize(msg.Cols, msg.Rows)
 		t.sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}
 		return 0, nil
 	default:
@@ -138,6 +148,7 @@ func (t TerminalSession) Write(p []byte) (int, error) {
 	if err = t.sockJSSession.Send(string(msg)); err != nil {
 		return 0, err
 	}
+	t.recorder.RecordOutput(p)
 	return len(p), nil
 }
 
@@ -220,6 +231,8 @@ func (sm *SessionMap) Close(sessionId string, status uint32, reason string) {
 	defer sm.Lock.Unlock()
 
 	terminalSession := sm.Sessions[sessionId]
+	// the recording is uploaded in the background, it also stops for sessions never bound by the client
+	go terminalSession.recorder.Stop()
 
 	if terminalSession.sockJSSession != nil {
 
@@ -240,6 +253,37 @@ func (sm *SessionMap) Close(sessionId string, status uint32, reason string) {
 
 }
 
+// CloseAllForUser closes every session opened by the given user
+func (sm *SessionMap) CloseAllForUser(userId int32, status uint32, reason string) int {
+	sm.Lock.RLock()
+	sessionIds := make([]string, 0)
+	for sessionId, session := range sm.Sessions {
+		if session.userId == userId {
+			sessionIds = append(sessionIds, sessionId)
+		}
+	}
+	sm.Lock.RUnlock()
+	for _, sessionId := range sessionIds {
+		sm.Close(sessionId, status, reason)
+	}
+	return len(sessionIds)
+}
+
+// GetUserIds returns the distinct users having a session open
+func (sm *SessionMap) GetUserIds() []int32 {
+	sm.Lock.RLock()
+	defer sm.Lock.RUnlock()
+	userIds := make([]int32, 0)
+	seen := make(map[int32]bool)
+	for _, session := range sm.Sessions {
+		if session.userId > 0 && !seen[session.userId] {
+			seen[session.userId] = true
+			userIds = append(userIds, session.userId)
+		}
+	}
+	return userIds
+}
+
 func isConnectionClosedByError(status uint32) bool {
 	if status == 2 {
 		return true
@@ -280,6 +324,8 @@ func handleTerminalSession(session sockjs.Session) {
 type SocketConfig struct {
 	SocketHeartbeatSeconds int `env:"SOCKET_HEARTBEAT_SECONDS" envDefault:"25"`
 	SocketDisconnectDelay  int `env:"SOCKET_DISCONNECT_DELAY_SECONDS" envDefault:"5"`
+	// InactiveUserSessionSyncSecs is the interval in which every replica closes the sessions it holds for deactivated users
+	InactiveUserSessionSyncSecs int `env:"TERMINAL_INACTIVE_USER_SESSION_SYNC_SECS" envDefault:"60"`
 }
 
 var cfg *SocketConfig
@@ -392,6 +438,8 @@ type TerminalSessionRequest struct {
 	ClusterId                   int
 	UserId                      int32
 	ExternalArgoApplicationName string
+	// TerminalAccessId is the cluster terminal access the session is opened for, if any
+	TerminalAccessId int
 }
 
 const CommandExecutionFailed = "Failed to Execute Command"
@@ -444,6 +492,9 @@ type TerminalSessionHandler interface {
 	ValidateShell(req *TerminalSessionRequest) (bool, error)
 	AutoSelectShell(req *TerminalSessionRequest) (string, error)
 	RunCmdInRemotePod(req *TerminalSessionRequest, cmds []string) (*bytes.Buffer, *bytes.Buffer, error)
+	// CloseSessionsForUser closes the sessions of the user held by this replica, the sessions held by other replicas
+	// are closed by their periodic check of the users having a session open
+	CloseSessionsForUser(userId int32, msg string) int
 }
 
 type TerminalSessionHandlerImpl struct {
@@ -453,25 +504,72 @@ type TerminalSessionHandlerImpl struct {
 	k8sUtil                    *k8s.K8sServiceImpl
 	ephemeralContainerService  cluster.EphemeralContainerService
 	argoApplicationReadService read.ArgoApplicationReadService
+	terminalRecordingService   terminalRecording.TerminalRecordingService
+	userRepository             userRepository.UserRepository
+	inactiveUserSessionCron    *cron.Cron
 }
 
 func NewTerminalSessionHandlerImpl(environmentService cluster.EnvironmentService, clusterService cluster.ClusterService,
 	logger *zap.SugaredLogger, k8sUtil *k8s.K8sServiceImpl, ephemeralContainerService cluster.EphemeralContainerService,
-	argoApplicationReadService read.ArgoApplicationReadService) *TerminalSessionHandlerImpl {
-	return &TerminalSessionHandlerImpl{
+	argoApplicationReadService read.ArgoApplicationReadService,
+	terminalRecordingService terminalRecording.TerminalRecordingService,
+	userRepository userRepository.UserRepository, cronLogger *cron2.CronLoggerImpl) *TerminalSessionHandlerImpl {
+	impl := &TerminalSessionHandlerImpl{
 		environmentService:         environmentService,
 		clusterService:             clusterService,
 		logger:                     logger,
 		k8sUtil:                    k8sUtil,
 		ephemeralContainerService:  ephemeralContainerService,
 		argoApplicationReadService: argoApplicationReadService,
+		terminalRecordingService:   terminalRecordingService,
+		userRepository:             userRepository,
+		inactiveUserSessionCron:    cron.New(cron.WithChain(cron.Recover(cronLogger))),
+	}
+	if cfg == nil {
+		cfg = &SocketConfig{}
+		env.Parse(cfg)
+	}
+	// sessions are held in memory by the replica serving them, a user deactivated through another replica is only
+	// known here through the database
+	_, err := impl.inactiveUserSessionCron.AddFunc(fmt.Sprintf("@every %ds", cfg.InactiveUserSessionSyncSecs), impl.closeSessionsOfInactiveUsers)
+	if err != nil {
+		logger.Errorw("error in starting inactive user terminal session cron", "err", err)
 	}
+	impl.inactiveUserSessionCron.Start()
+	return impl
 }
 
 func (impl *TerminalSessionHandlerImpl) Close(sessionId string, statusCode uint32, msg string) {
 	terminalSessions.Close(sessionId, statusCode, msg)
 }
 
+func (impl *TerminalSessionHandlerImpl) CloseSessionsForUser(userId int32, msg string) int {
+	return terminalSessions.CloseAllForUser(userId, 1, msg)
+}
+
+// closeSessionsOfInactiveUsers closes the sessions held by this replica for users which are no longer active
+func (impl *TerminalSessionHandlerImpl) closeSessionsOfInactiveUsers() {
+	userIds := terminalSessions.GetUserIds()
+	if len(userIds) == 0 {
+		return
+	}
+	activeUsers, err := impl.userRepository.GetByIds(userIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching users having a terminal session open", "userIds", userIds, "err", err)
+		return
+	}
+	isActive := make(map[int32]bool, len(activeUsers))
+	for _, user := range activeUsers {
+		isActive[user.Id] = true
+	}
+	for _, userId := range userIds {
+		if !isActive[userId] {
+			closedSessions := terminalSessions.CloseAllForUser(userId, 1, UserDeactivatedMsg)
+			impl.logger.Infow("closed terminal sessions of inactive user", "userId", userId, "closedSessions", closedSessions)
+		}
+	}
+}
+
 func (impl *TerminalSessionHandlerImpl) ValidateSession(sessionId string) bool {
 	if sessionId == "" {
 		return false
@@ -496,6 +594,12 @@ func (impl *TerminalSessionHandlerImpl) GetTerminalSession(req *TerminalSessionR
 		return statusCode, nil, err
 	}
 	req.SessionId = sessionID
+	recorder, err := impl.terminalRecordingService.StartRecording(adaptRecordingMetadata(req))
+	if err != nil {
+		// sessions are not opened unrecorded when recording is enabled
+		impl.logger.Errorw("error in starting terminal session recording", "sessionId", sessionID, "err", err)
+		return http.StatusInternalServerError, nil, err
+	}
 	sessionCtx, cancelFunc := context.WithCancel(context.Background())
 	terminalSessions.Set(sessionID, TerminalSession{
 		id:                sessionID,
@@ -507,6 +611,8 @@ func (impl *TerminalSessionHandlerImpl) GetTerminalSession(req *TerminalSessionR
 		podName:           req.PodName,
 		namespace:         req.Namespace,
 		clusterId:         strconv.Itoa(req.ClusterId),
+		userId:            req.UserId,
+		recorder:          recorder,
 	})
 	config, client, err := impl.getClientSetAndRestConfigForTerminalConn(req)
 
@@ -619,6 +725,21 @@ func (impl *TerminalSessionHandlerImpl) ValidateShell(req *TerminalSessionReques
 	return true, nil
 }
 
+func adaptRecordingMetadata(req *TerminalSessionRequest) *recordingBean.RecordingMetadata {
+	return &recordingBean.RecordingMetadata{
+		SessionId:        req.SessionId,
+		UserId:           req.UserId,
+		ClusterId:        req.ClusterId,
+		Namespace:        req.Namespace,
+		PodName:          req.PodName,
+		ContainerName:    req.ContainerName,
+		Shell:            req.Shell,
+		AppId:            req.AppId,
+		EnvId:            req.EnvironmentId,
+		TerminalAccessId: req.TerminalAccessId,
+	}
+}
+
 func getErrorMsg(err string) error {
 	if strings.Contains(err, "pods") && strings.Contains(err, "not found") {
 		return errors1.New(PodNotFound)
@@ -0,0 +1,288 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package terminalRecording
+
+import (
+	"errors"
+	"fmt"
+	"github.com/caarlos0/env"
+	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/bean"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/repository"
+	"go.uber.org/zap"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+type TerminalRecordingService interface {
+	// StartRecording creates the recorder of a terminal session, a nil recorder is returned when recording is disabled
+	StartRecording(metadata *bean.RecordingMetadata) (*Recorder, error)
+	GetRecordings(filter *bean.RecordingFilter) ([]*bean.RecordingDto, error)
+	GetRecording(id int) (*bean.RecordingDto, error)
+	// DownloadRecording downloads the cast file of the recording, the caller closes and removes it
+	DownloadRecording(id int) (*os.File, error)
+	GetReplay(id int) (*bean.ReplayDto, error)
+	// DeleteExpiredRecordings deletes the recordings older than the retention period
+	DeleteExpiredRecordings()
+}
+
+type TerminalRecordingServiceImpl struct {
+	logger                      *zap.SugaredLogger
+	terminalRecordingRepository repository.TerminalRecordingRepository
+	ciCdConfig                  *types.CiCdConfig
+	blobStorageService          *blob_storage.BlobStorageServiceImpl
+	config                      *bean.TerminalRecordingConfig
+}
+
+func NewTerminalRecordingServiceImpl(logger *zap.SugaredLogger,
+	terminalRecordingRepository repository.TerminalRecordingRepository,
+	ciCdConfig *types.CiCdConfig) (*TerminalRecordingServiceImpl, error) {
+	config := &bean.TerminalRecordingConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing terminal recording config", "err", err)
+		return nil, err
+	}
+	config.TerminalRecordingLocalDir, err = filepath.Abs(config.TerminalRecordingLocalDir)
+	if err != nil {
+		logger.Errorw("error in resolving terminal recording local dir", "dir", config.TerminalRecordingLocalDir, "err", err)
+		return nil, err
+	}
+	if config.TerminalRecordingEnabled && !ciCdConfig.BlobStorageEnabled {
+		logger.Warnw("terminal recording needs blob storage to be enabled, sessions will not be recorded")
+		config.TerminalRecordingEnabled = false
+	}
+	return &TerminalRecordingServiceImpl{
+		logger:                      logger,
+		terminalRecordingRepository: terminalRecordingRepository,
+		ciCdConfig:                  ciCdConfig,
+		blobStorageService:          blob_storage.NewBlobStorageServiceImpl(logger),
+		config:                      config,
+	}, nil
+}
+
+func (impl *TerminalRecordingServiceImpl) StartRecording(metadata *bean.RecordingMetadata) (*Recorder, error) {
+	if !impl.config.TerminalRecordingEnabled {
+		return nil, nil
+	}
+	localPath, err := impl.getLocalPath(fmt.Sprintf(bean.CastFileFormat, metadata.SessionId))
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		impl.logger.Errorw("error in creating terminal recording file", "path", localPath, "err", err)
+		return nil, err
+	}
+	maxSize := int64(impl.config.TerminalRecordingMaxSizeMb) * 1024 * 1024
+	recorder := newRecorder(file, getTitle(metadata), impl.config.TerminalRecordingCaptureInput, maxSize, impl.uploadRecording)
+	recording := toRecordingDbObject(metadata, getBlobKey(impl.config.TerminalRecordingBasePath, metadata.SessionId, recorder.startedOn), recorder.startedOn)
+	err = impl.terminalRecordingRepository.Save(recording)
+	if err != nil {
+		impl.logger.Errorw("error in saving terminal recording", "sessionId", metadata.SessionId, "err", err)
+		_ = file.Close()
+		_ = os.Remove(localPath)
+		return nil, err
+	}
+	recorder.recordingId = recording.Id
+	recorder.path = localPath
+	return recorder, nil
+}
+
+// uploadRecording uploads the cast file of a stopped recorder and records the outcome
+func (impl *TerminalRecordingServiceImpl) uploadRecording(recorder *Recorder) {
+	defer func() {
+		if err := os.Remove(recorder.path); err != nil {
+			impl.logger.Errorw("error in removing terminal recording file", "path", recorder.path, "err", err)
+		}
+	}()
+	recording, err := impl.terminalRecordingRepository.FindById(recorder.recordingId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching terminal recording", "id", recorder.recordingId, "err", err)
+		return
+	}
+	endedOn := time.Now()
+	recording.EndedOn = &endedOn
+	recording.SizeBytes = recorder.size
+	recording.Truncated = recorder.truncated
+	recording.Status = bean.RecordingStatusUploaded
+	if recorder.err != nil {
+		impl.logger.Errorw("error in recording terminal session", "id", recording.Id, "err", recorder.err)
+		recording.Status = bean.RecordingStatusFailed
+	} else {
+		request := impl.getBlobStorageRequest()
+		request.SourceKey = recorder.path
+		request.DestinationKey = recording.BlobKey
+		err = impl.blobStorageService.UploadToBlobWithSession(request)
+		if err != nil {
+			impl.logger.Errorw("error in uploading terminal recording", "id", recording.Id, "key", recording.BlobKey, "err", err)
+			recording.Status = bean.RecordingStatusFailed
+		}
+	}
+	recording.UpdateAuditLog(recording.UserId)
+	err = impl.terminalRecordingRepository.Update(recording)
+	if err != nil {
+		impl.logger.Errorw("error in updating terminal recording", "id", recording.Id, "err", err)
+	}
+}
+
+func (impl *TerminalRecordingServiceImpl) GetRecordings(filter *bean.RecordingFilter) ([]*bean.RecordingDto, error) {
+	recordings, err := impl.terminalRecordingRepository.FindAll(filter)
+	if err != nil {
+		impl.logger.Errorw("error in fetching terminal recordings", "filter", filter, "err", err)
+		return nil, err
+	}
+	dtos := make([]*bean.RecordingDto, 0, len(recordings))
+	for _, recording := range recordings {
+		dtos = append(dtos, toRecordingDto(recording))
+	}
+	return dtos, nil
+}
+
+func (impl *TerminalRecordingServiceImpl) GetRecording(id int) (*bean.RecordingDto, error) {
+	recording, err := impl.terminalRecordingRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching terminal recording", "id", id, "err", err)
+		return nil, err
+	}
+	return toRecordingDto(recording), nil
+}
+
+func (impl *TerminalRecordingServiceImpl) DownloadRecording(id int) (*os.File, error) {
+	recording, err := impl.terminalRecordingRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching terminal recording", "id", id, "err", err)
+		return nil, err
+	}
+	if recording.Status != bean.RecordingStatusUploaded {
+		message := fmt.Sprintf(bean.RecordingNotAvailable, id, recording.Status)
+		return nil, util.NewApiError().WithHttpStatusCode(http.StatusBadRequest).WithUserMessage(message).WithInternalMessage(message)
+	}
+	localPath, err := impl.getLocalPath(fmt.Sprintf("download-%d-%s", id, strconv.FormatInt(time.Now().UnixNano(), 10)))
+	if err != nil {
+		return nil, err
+	}
+	request := impl.getBlobStorageRequest()
+	request.SourceKey = recording.BlobKey
+	request.DestinationKey = localPath
+	downloaded, _, err := impl.blobStorageService.Get(request)
+	if err == nil && !downloaded {
+		err = errors.New("recording not found in blob storage")
+	}
+	if err != nil {
+		impl.logger.Errorw("error in downloading terminal recording", "id", id, "key", recording.BlobKey, "err", err)
+		_ = os.Remove(localPath)
+		return nil, err
+	}
+	return os.Open(localPath)
+}
+
+func (impl *TerminalRecordingServiceImpl) GetReplay(id int) (*bean.ReplayDto, error) {
+	recording, err := impl.GetRecording(id)
+	if err != nil {
+		return nil, err
+	}
+	file, err := impl.DownloadRecording(id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+	header, events, err := parseCast(file)
+	if err != nil {
+		impl.logger.Errorw("error in parsing terminal recording", "id", id, "err", err)
+		return nil, err
+	}
+	return &bean.ReplayDto{
+		Recording: recording,
+		Header:    header,
+		Events:    events,
+	}, nil
+}
+
+func (impl *TerminalRecordingServiceImpl) DeleteExpiredRecordings() {
+	if impl.config.TerminalRecordingRetentionDays <= 0 {
+		return
+	}
+	before := time.Now().AddDate(0, 0, -impl.config.TerminalRecordingRetentionDays)
+	recordings, err := impl.terminalRecordingRepository.FindStartedBefore(before)
+	if err != nil {
+		impl.logger.Errorw("error in fetching expired terminal recordings", "before", before, "err", err)
+		return
+	}
+	for _, recording := range recordings {
+		if recording.Status == bean.RecordingStatusUploaded {
+			// only s3 objects can be deleted here, azure and gcs buckets need a lifecycle rule on the base path
+			request := impl.getBlobStorageRequest()
+			request.DestinationKey = recording.BlobKey
+			err = impl.blobStorageService.DeleteObjectForS3(request)
+			if err != nil {
+				impl.logger.Errorw("error in deleting terminal recording", "id", recording.Id, "key", recording.BlobKey, "err", err)
+				continue
+			}
+		}
+		recording.Status = bean.RecordingStatusDeleted
+		recording.UpdateAuditLog(recording.UserId)
+		err = impl.terminalRecordingRepository.Update(recording)
+		if err != nil {
+			impl.logger.Errorw("error in updating terminal recording", "id", recording.Id, "err", err)
+		}
+	}
+}
+
+func (impl *TerminalRecordingServiceImpl) getLocalPath(name string) (string, error) {
+	err := os.MkdirAll(impl.config.TerminalRecordingLocalDir, os.ModePerm)
+	if err != nil {
+		impl.logger.Errorw("error in creating terminal recording dir", "dir", impl.config.TerminalRecordingLocalDir, "err", err)
+		return "", err
+	}
+	return filepath.Join(impl.config.TerminalRecordingLocalDir, name), nil
+}
+
+// getBlobStorageRequest stores the recordings in the bucket of the build logs
+func (impl *TerminalRecordingServiceImpl) getBlobStorageRequest() *blob_storage.BlobStorageRequest {
+	bucket := impl.ciCdConfig.CiDefaultBuildLogsBucket
+	return &blob_storage.BlobStorageRequest{
+		StorageType: impl.ciCdConfig.CloudProvider,
+		AwsS3BaseConfig: &blob_storage.AwsS3BaseConfig{
+			AccessKey:         impl.ciCdConfig.BlobStorageS3AccessKey,
+			Passkey:           impl.ciCdConfig.BlobStorageS3SecretKey,
+			EndpointUrl:       impl.ciCdConfig.BlobStorageS3Endpoint,
+			IsInSecure:        impl.ciCdConfig.BlobStorageS3EndpointInsecure,
+			BucketName:        bucket,
+			Region:            impl.ciCdConfig.CiDefaultCdLogsBucketRegion,
+			VersioningEnabled: impl.ciCdConfig.BlobStorageS3BucketVersioned,
+		},
+		AzureBlobBaseConfig: &blob_storage.AzureBlobBaseConfig{
+			Enabled:           impl.ciCdConfig.CloudProvider == types.BLOB_STORAGE_AZURE,
+			AccountName:       impl.ciCdConfig.AzureAccountName,
+			BlobContainerName: impl.ciCdConfig.AzureBlobContainerCiLog,
+			AccountKey:        impl.ciCdConfig.AzureAccountKey,
+		},
+		GcpBlobBaseConfig: &blob_storage.GcpBlobBaseConfig{
+			BucketName:             bucket,
+			CredentialFileJsonData: impl.ciCdConfig.BlobStorageGcpCredentialJson,
+		},
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package terminalRecording
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/bean"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/repository"
+	"time"
+)
+
+func toRecordingDbObject(metadata *bean.RecordingMetadata, blobKey string, startedOn time.Time) *repository.TerminalSessionRecording {
+	return &repository.TerminalSessionRecording{
+		SessionId:        metadata.SessionId,
+		UserId:           metadata.UserId,
+		ClusterId:        metadata.ClusterId,
+		Namespace:        metadata.Namespace,
+		PodName:          metadata.PodName,
+		ContainerName:    metadata.ContainerName,
+		Shell:            metadata.Shell,
+		AppId:            metadata.AppId,
+		EnvId:            metadata.EnvId,
+		TerminalAccessId: metadata.TerminalAccessId,
+		BlobKey:          blobKey,
+		Status:           bean.RecordingStatusRecording,
+		StartedOn:        startedOn,
+		AuditLog:         sql.NewDefaultAuditLog(metadata.UserId),
+	}
+}
+
+func toRecordingDto(recording *repository.TerminalSessionRecording) *bean.RecordingDto {
+	return &bean.RecordingDto{
+		Id:               recording.Id,
+		SessionId:        recording.SessionId,
+		UserId:           recording.UserId,
+		ClusterId:        recording.ClusterId,
+		Namespace:        recording.Namespace,
+		PodName:          recording.PodName,
+		ContainerName:    recording.ContainerName,
+		Shell:            recording.Shell,
+		AppId:            recording.AppId,
+		EnvId:            recording.EnvId,
+		TerminalAccessId: recording.TerminalAccessId,
+		Status:           recording.Status,
+		SizeBytes:        recording.SizeBytes,
+		Truncated:        recording.Truncated,
+		StartedOn:        recording.StartedOn,
+		EndedOn:          recording.EndedOn,
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type RecordingStatus string
+
+const (
+	RecordingStatusRecording RecordingStatus = "RECORDING"
+	RecordingStatusUploaded  RecordingStatus = "UPLOADED"
+	RecordingStatusFailed    RecordingStatus = "FAILED"
+	RecordingStatusDeleted   RecordingStatus = "DELETED"
+)
+
+// asciicast v2 event types, see https://docs.asciinema.org/manual/asciicast/v2/
+const (
+	AsciicastVersion = 2
+	EventTypeOutput  = "o"
+	EventTypeInput   = "i"
+	EventTypeResize  = "r"
+	DefaultWidth     = 80
+	DefaultHeight    = 24
+	CastFileFormat   = "%s.cast"
+	CastContentType  = "application/x-asciicast"
+)
+
+const RecordingNotAvailable = "recording %d is %s, it cannot be downloaded"
+
+// RecordingMetadata identifies the session and the container a recording is made of
+type RecordingMetadata struct {
+	SessionId        string
+	UserId           int32
+	ClusterId        int
+	Namespace        string
+	PodName          string
+	ContainerName    string
+	Shell            string
+	AppId            int
+	EnvId            int
+	TerminalAccessId int
+}
+
+type RecordingDto struct {
+	Id               int             `json:"id"`
+	SessionId        string          `json:"sessionId"`
+	UserId           int32           `json:"userId"`
+	ClusterId        int             `json:"clusterId"`
+	Namespace        string          `json:"namespace"`
+	PodName          string          `json:"podName"`
+	ContainerName    string          `json:"containerName,omitempty"`
+	Shell            string          `json:"shell,omitempty"`
+	AppId            int             `json:"appId,omitempty"`
+	EnvId            int             `json:"envId,omitempty"`
+	TerminalAccessId int             `json:"terminalAccessId,omitempty"`
+	Status           RecordingStatus `json:"status"`
+	SizeBytes        int64           `json:"sizeBytes"`
+	// Truncated is set when the session went past the configured maximum size, events after it are not recorded
+	Truncated bool       `json:"truncated"`
+	StartedOn time.Time  `json:"startedOn"`
+	EndedOn   *time.Time `json:"endedOn,omitempty"`
+}
+
+type RecordingFilter struct {
+	UserId    int32
+	ClusterId int
+	Namespace string
+	PodName   string
+	From      time.Time
+	To        time.Time
+	Offset    int
+	Size      int
+}
+
+type AsciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// AsciicastEvent is an [time, type, data] entry of a cast file
+type AsciicastEvent struct {
+	Time float64 `json:"time"`
+	Type string  `json:"type"`
+	Data string  `json:"data"`
+}
+
+// ReplayDto is a parsed recording for the web player
+type ReplayDto struct {
+	Recording *RecordingDto     `json:"recording"`
+	Header    *AsciicastHeader  `json:"header"`
+	Events    []*AsciicastEvent `json:"events"`
+}
+
+type TerminalRecordingConfig struct {
+	TerminalRecordingEnabled bool `env:"TERMINAL_RECORDING_ENABLED" envDefault:"false"`
+	// TerminalRecordingCaptureInput records the keystrokes along with the output
+	TerminalRecordingCaptureInput  bool   `env:"TERMINAL_RECORDING_CAPTURE_INPUT" envDefault:"true"`
+	TerminalRecordingMaxSizeMb     int    `env:"TERMINAL_RECORDING_MAX_SIZE_MB" envDefault:"50"`
+	TerminalRecordingRetentionDays int    `env:"TERMINAL_RECORDING_RETENTION_DAYS" envDefault:"90"`
+	TerminalRecordingBasePath      string `env:"TERMINAL_RECORDING_BASE_PATH" envDefault:"terminal-recordings"`
+	TerminalRecordingLocalDir      string `env:"TERMINAL_RECORDING_LOCAL_DIR" envDefault:"/tmp/terminal-recordings"`
+}
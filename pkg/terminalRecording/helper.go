@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package terminalRecording
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/bean"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// maxCastLineSize bounds an event of a cast file, outputs are written as they come so lines stay small in practice
+const maxCastLineSize = 16 * 1024 * 1024
+
+func formatSize(width, height int) string {
+	return fmt.Sprintf("%dx%d", width, height)
+}
+
+func getTitle(metadata *bean.RecordingMetadata) string {
+	title := fmt.Sprintf("%s/%s", metadata.Namespace, metadata.PodName)
+	if len(metadata.ContainerName) > 0 {
+		title = fmt.Sprintf("%s/%s", title, metadata.ContainerName)
+	}
+	return title
+}
+
+// getBlobKey keeps the recordings of a day under the same prefix
+func getBlobKey(basePath string, sessionId string, startedOn time.Time) string {
+	return path.Join(basePath, startedOn.UTC().Format("2006/01/02"), fmt.Sprintf(bean.CastFileFormat, sessionId))
+}
+
+// parseCast reads an asciicast v2 file, a header line followed by [time, type, data] event lines
+func parseCast(reader io.Reader) (*bean.AsciicastHeader, []*bean.AsciicastEvent, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxCastLineSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("empty recording")
+	}
+	header := &bean.AsciicastHeader{}
+	if err := json.Unmarshal(scanner.Bytes(), header); err != nil {
+		return nil, nil, fmt.Errorf("invalid recording header, %w", err)
+	}
+	if header.Version != bean.AsciicastVersion {
+		return nil, nil, fmt.Errorf("unsupported recording version %d", header.Version)
+	}
+	events := make([]*bean.AsciicastEvent, 0)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		var fields []json.RawMessage
+		if err := json.Unmarshal([]byte(line), &fields); err != nil || len(fields) != 3 {
+			return nil, nil, fmt.Errorf("invalid recording event %q", line)
+		}
+		event := &bean.AsciicastEvent{}
+		if err := json.Unmarshal(fields[0], &event.Time); err != nil {
+			return nil, nil, fmt.Errorf("invalid recording event time %q", line)
+		}
+		if err := json.Unmarshal(fields[1], &event.Type); err != nil {
+			return nil, nil, fmt.Errorf("invalid recording event type %q", line)
+		}
+		if err := json.Unmarshal(fields[2], &event.Data); err != nil {
+			return nil, nil, fmt.Errorf("invalid recording event data %q", line)
+		}
+		events = append(events, event)
+	}
+	return header, events, scanner.Err()
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package terminalRecording
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/bean"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Recorder captures a terminal session in the asciicast v2 format. Its methods do nothing on a nil recorder, so
+// sessions are recorded the same way whether recording is enabled or not
+type Recorder struct {
+	lock         sync.Mutex
+	writer       io.WriteCloser
+	recordingId  int
+	path         string
+	title        string
+	startedOn    time.Time
+	captureInput bool
+	maxSize      int64
+	size         int64
+	headerDone   bool
+	truncated    bool
+	stopped      bool
+	err          error
+	now          func() time.Time
+	onStop       func(recorder *Recorder)
+}
+
+func newRecorder(writer io.WriteCloser, title string, captureInput bool, maxSize int64, onStop func(recorder *Recorder)) *Recorder {
+	return &Recorder{
+		writer:       writer,
+		title:        title,
+		startedOn:    time.Now(),
+		captureInput: captureInput,
+		maxSize:      maxSize,
+		now:          time.Now,
+		onStop:       onStop,
+	}
+}
+
+// RecordInput records the keystrokes sent to the process, if input capture is enabled
+func (r *Recorder) RecordInput(data string) {
+	if r == nil || !r.captureInput {
+		return
+	}
+	r.record(bean.EventTypeInput, data, 0, 0)
+}
+
+func (r *Recorder) RecordOutput(data []byte) {
+	if r == nil {
+		return
+	}
+	r.record(bean.EventTypeOutput, string(data), 0, 0)
+}
+
+func (r *Recorder) RecordResize(cols, rows uint16) {
+	if r == nil {
+		return
+	}
+	r.record(bean.EventTypeResize, "", int(cols), int(rows))
+}
+
+// Stop closes the recording and hands it over for upload, later events are ignored
+func (r *Recorder) Stop() {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	if r.stopped {
+		r.lock.Unlock()
+		return
+	}
+	r.stopped = true
+	if !r.headerDone {
+		r.writeHeader(bean.DefaultWidth, bean.DefaultHeight)
+	}
+	if err := r.writer.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	r.lock.Unlock()
+	if r.onStop != nil {
+		r.onStop(r)
+	}
+}
+
+func (r *Recorder) record(eventType string, data string, width, height int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.stopped || r.truncated || r.err != nil {
+		return
+	}
+	if !r.headerDone {
+		// the terminal size is not known until the client sends it, the header takes it if it comes first
+		if eventType == bean.EventTypeResize {
+			r.writeHeader(width, height)
+			return
+		}
+		r.writeHeader(bean.DefaultWidth, bean.DefaultHeight)
+	}
+	if eventType == bean.EventTypeResize {
+		data = formatSize(width, height)
+	}
+	elapsed := math.Round(r.now().Sub(r.startedOn).Seconds()*1e6) / 1e6
+	line, err := json.Marshal([]interface{}{elapsed, eventType, data})
+	if err != nil {
+		r.err = err
+		return
+	}
+	if r.maxSize > 0 && r.size+int64(len(line))+1 > r.maxSize {
+		r.truncated = true
+		return
+	}
+	r.write(line)
+}
+
+func (r *Recorder) writeHeader(width, height int) {
+	r.headerDone = true
+	header, err := json.Marshal(&bean.AsciicastHeader{
+		Version:   bean.AsciicastVersion,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.startedOn.Unix(),
+		Title:     r.title,
+	})
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.write(header)
+}
+
+func (r *Recorder) write(line []byte) {
+	n, err := r.writer.Write(append(line, '\n'))
+	r.size += int64(n)
+	if err != nil {
+		r.err = err
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package terminalRecording
+
+import (
+	"bytes"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/bean"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+func newTestRecorder(buffer *bufferCloser, captureInput bool, maxSize int64) (*Recorder, *time.Time) {
+	recorder := newRecorder(buffer, "default/api-0/app", captureInput, maxSize, nil)
+	clock := recorder.startedOn
+	recorder.now = func() time.Time {
+		return clock
+	}
+	return recorder, &clock
+}
+
+func TestRecorder(t *testing.T) {
+	buffer := &bufferCloser{}
+	recorder, clock := newTestRecorder(buffer, true, 0)
+	recorder.RecordResize(120, 40)
+	*clock = clock.Add(500 * time.Millisecond)
+	recorder.RecordInput("ls\r")
+	*clock = clock.Add(250 * time.Millisecond)
+	recorder.RecordOutput([]byte("bin  etc\r\n"))
+	recorder.RecordResize(100, 30)
+	recorder.Stop()
+	recorder.RecordOutput([]byte("after stop"))
+	assert.True(t, buffer.closed)
+
+	header, events, err := parseCast(bytes.NewReader(buffer.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, header.Version)
+	assert.Equal(t, 120, header.Width)
+	assert.Equal(t, 40, header.Height)
+	assert.Equal(t, "default/api-0/app", header.Title)
+	assert.Equal(t, []*bean.AsciicastEvent{
+		{Time: 0.5, Type: bean.EventTypeInput, Data: "ls\r"},
+		{Time: 0.75, Type: bean.EventTypeOutput, Data: "bin  etc\r\n"},
+		{Time: 0.75, Type: bean.EventTypeResize, Data: "100x30"},
+	}, events)
+	assert.Equal(t, int64(buffer.Len()), recorder.size)
+}
+
+func TestRecorderWithoutInputAndTruncated(t *testing.T) {
+	buffer := &bufferCloser{}
+	recorder, _ := newTestRecorder(buffer, false, 120)
+	recorder.RecordInput("secret\r")
+	recorder.RecordOutput([]byte("first"))
+	recorder.RecordOutput([]byte("an output too long to fit in the recording"))
+	recorder.RecordOutput([]byte("x"))
+	recorder.Stop()
+
+	header, events, err := parseCast(bytes.NewReader(buffer.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, bean.DefaultWidth, header.Width)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "first", events[0].Data)
+	assert.True(t, recorder.truncated)
+}
+
+func TestNilRecorder(t *testing.T) {
+	var recorder *Recorder
+	recorder.RecordInput("ls")
+	recorder.RecordOutput([]byte("ls"))
+	recorder.RecordResize(80, 24)
+	recorder.Stop()
+}
+
+func TestGetBlobKey(t *testing.T) {
+	startedOn := time.Date(2026, 3, 7, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, "terminal-recordings/2026/03/07/abc.cast", getBlobKey("terminal-recordings", "abc", startedOn))
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/pkg/terminalRecording/bean"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type TerminalSessionRecording struct {
+	tableName        struct{}             `sql:"terminal_session_recording" pg:",discard_unknown_columns"`
+	Id               int                  `sql:"id,pk"`
+	SessionId        string               `sql:"session_id,notnull"`
+	UserId           int32                `sql:"user_id"`
+	ClusterId        int                  `sql:"cluster_id"`
+	Namespace        string               `sql:"namespace"`
+	PodName          string               `sql:"pod_name"`
+	ContainerName    string               `sql:"container_name"`
+	Shell            string               `sql:"shell"`
+	AppId            int                  `sql:"app_id"`
+	EnvId            int                  `sql:"env_id"`
+	TerminalAccessId int                  `sql:"terminal_access_id"`
+	BlobKey          string               `sql:"blob_key,notnull"`
+	Status           bean.RecordingStatus `sql:"status,notnull"`
+	SizeBytes        int64                `sql:"size_bytes"`
+	Truncated        bool                 `sql:"truncated,notnull"`
+	StartedOn        time.Time            `sql:"started_on,notnull"`
+	EndedOn          *time.Time           `sql:"ended_on"`
+	sql.AuditLog
+}
+
+type TerminalRecordingRepository interface {
+	Save(recording *TerminalSessionRecording) error
+	Update(recording *TerminalSessionRecording) error
+	FindById(id int) (*TerminalSessionRecording, error)
+	FindAll(filter *bean.RecordingFilter) ([]*TerminalSessionRecording, error)
+	// FindStartedBefore returns the recordings not deleted yet of the sessions started before the time
+	FindStartedBefore(before time.Time) ([]*TerminalSessionRecording, error)
+}
+
+type TerminalRecordingRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewTerminalRecordingRepositoryImpl(dbConnection *pg.DB) *TerminalRecordingRepositoryImpl {
+	return &TerminalRecordingRepositoryImpl{
+		dbConnection: dbConnection,
+	}
+}
+
+func (impl TerminalRecordingRepositoryImpl) Save(recording *TerminalSessionRecording) error {
+	return impl.dbConnection.Insert(recording)
+}
+
+func (impl TerminalRecordingRepositoryImpl) Update(recording *TerminalSessionRecording) error {
+	return impl.dbConnection.Update(recording)
+}
+
+func (impl TerminalRecordingRepositoryImpl) FindById(id int) (*TerminalSessionRecording, error) {
+	recording := &TerminalSessionRecording{}
+	err := impl.dbConnection.Model(recording).
+		Where("id = ?", id).
+		Select()
+	return recording, err
+}
+
+func (impl TerminalRecordingRepositoryImpl) FindAll(filter *bean.RecordingFilter) ([]*TerminalSessionRecording, error) {
+	recordings := make([]*TerminalSessionRecording, 0)
+	query := impl.dbConnection.Model(&recordings).
+		Where("status != ?", bean.RecordingStatusDeleted)
+	if filter.UserId > 0 {
+		query = query.Where("user_id = ?", filter.UserId)
+	}
+	if filter.ClusterId > 0 {
+		query = query.Where("cluster_id = ?", filter.ClusterId)
+	}
+	if len(filter.Namespace) > 0 {
+		query = query.Where("namespace = ?", filter.Namespace)
+	}
+	if len(filter.PodName) > 0 {
+		query = query.Where("pod_name = ?", filter.PodName)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("started_on >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("started_on <= ?", filter.To)
+	}
+	err := query.Order("id DESC").
+		Offset(filter.Offset).
+		Limit(filter.Size).
+		Select()
+	return recordings, err
+}
+
+func (impl TerminalRecordingRepositoryImpl) FindStartedBefore(before time.Time) ([]*TerminalSessionRecording, error) {
+	recordings := make([]*TerminalSessionRecording, 0)
+	err := impl.dbConnection.Model(&recordings).
+		Where("status != ?", bean.RecordingStatusDeleted).
+		Where("started_on < ?", before).
+		Select()
+	return recordings, err
+}
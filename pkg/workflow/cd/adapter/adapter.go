@@ -47,6 +47,8 @@ func ConvertCdWorkflowRunnerDbObjToDto(dbObj *pipelineConfig.CdWorkflowRunner) *
 		RefCdWorkflowRunnerId:   dbObj.RefCdWorkflowRunnerId,
 		ImagePathReservationIds: dbObj.ImagePathReservationIds,
 		ReferenceId:             &newReferenceId,
+		TriggerType:             dbObj.TriggerType,
+		GitOpsPullRequestUrl:    dbObj.GitOpsPullRequestUrl,
 	}
 }
 
@@ -83,6 +85,8 @@ func ConvertCdWorkflowRunnerDtoToDbObj(dto *bean.CdWorkflowRunnerDto) *pipelineC
 		RefCdWorkflowRunnerId:   dto.RefCdWorkflowRunnerId,
 		ImagePathReservationIds: dto.ImagePathReservationIds,
 		ReferenceId:             dto.ReferenceId,
+		TriggerType:             dto.TriggerType,
+		GitOpsPullRequestUrl:    dto.GitOpsPullRequestUrl,
 		AuditLog: sql.AuditLog{
 			CreatedOn: dto.StartedOn,
 			CreatedBy: dto.TriggeredBy,
@@ -51,4 +51,6 @@ type CdWorkflowRunnerDto struct {
 	ImagePathReservationIds []int                           `json:"imagePathReservationIds"`
 	ReferenceId             *string                         `json:"referenceId"`
 	IsArtifactUploaded      bool                            `json:"isArtifactUploaded"`
+	TriggerType             string                          `json:"triggerType,omitempty"`
+	GitOpsPullRequestUrl    string                          `json:"gitOpsPullRequestUrl,omitempty"`
 }
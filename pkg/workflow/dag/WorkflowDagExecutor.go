@@ -34,6 +34,7 @@ import (
 	cdWorkflow2 "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/pkg/app/status"
 	"github.com/devtron-labs/devtron/pkg/build/artifacts"
+	"github.com/devtron-labs/devtron/pkg/deployment/canary"
 	common2 "github.com/devtron-labs/devtron/pkg/deployment/common"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
@@ -123,6 +124,7 @@ type WorkflowDagExecutorImpl struct {
 	commonArtifactService   artifacts.CommonArtifactService
 	deploymentConfigService common2.DeploymentConfigService
 	asyncRunnable           *async.Runnable
+	canaryAnalysisService   canary.CanaryAnalysisService
 }
 
 func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pipelineConfig.PipelineRepository,
@@ -146,7 +148,8 @@ func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pi
 	manifestCreationService manifest.ManifestCreationService,
 	commonArtifactService artifacts.CommonArtifactService,
 	deploymentConfigService common2.DeploymentConfigService,
-	asyncRunnable *async.Runnable) *WorkflowDagExecutorImpl {
+	asyncRunnable *async.Runnable,
+	canaryAnalysisService canary.CanaryAnalysisService) *WorkflowDagExecutorImpl {
 	wde := &WorkflowDagExecutorImpl{logger: Logger,
 		pipelineRepository:            pipelineRepository,
 		cdWorkflowRepository:          cdWorkflowRepository,
@@ -170,6 +173,7 @@ func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pi
 		commonArtifactService:         commonArtifactService,
 		deploymentConfigService:       deploymentConfigService,
 		asyncRunnable:                 asyncRunnable,
+		canaryAnalysisService:         canaryAnalysisService,
 	}
 	config, err := types.GetCdConfig()
 	if err != nil {
@@ -615,7 +619,31 @@ func (impl *WorkflowDagExecutorImpl) HandleDeploymentSuccessEvent(triggerContext
 		impl.logger.Errorw("error in fetching cd workflow by id", "pipelineOverride", pipelineOverride)
 		return err
 	}
+	if isNotHibernateDeployment(pipelineOverride.DeploymentType) {
+		isCanaryAnalysisEnabled, err := impl.canaryAnalysisService.IsAnalysisEnabled(pipelineOverride.PipelineId)
+		if err != nil {
+			return err
+		}
+		if isCanaryAnalysisEnabled {
+			// post stage and children pipelines are triggered only once the release is promoted
+			isPromoted, err := impl.canaryAnalysisService.HandleDeploymentSuccess(pipelineOverride)
+			if err != nil {
+				impl.logger.Errorw("error in handling deployment success for canary analysis", "pipelineId", pipelineOverride.PipelineId, "err", err)
+				return err
+			}
+			if !isPromoted {
+				return nil
+			}
+		}
+	}
+	return impl.triggerPostDeploymentStages(triggerContext, pipelineOverride, cdWorkflow)
+}
+
+func isNotHibernateDeployment(deploymentType models.DeploymentType) bool {
+	return deploymentType != models.DEPLOYMENTTYPE_STOP && deploymentType != models.DEPLOYMENTTYPE_START
+}
 
+func (impl *WorkflowDagExecutorImpl) triggerPostDeploymentStages(triggerContext triggerBean.TriggerContext, pipelineOverride *chartConfig.PipelineOverride, cdWorkflow *pipelineConfig.CdWorkflow) error {
 	postStage, err := impl.getPipelineStage(pipelineOverride.PipelineId, repository4.PIPELINE_STAGE_TYPE_POST_CD)
 	if err != nil {
 		return err
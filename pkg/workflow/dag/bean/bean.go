@@ -33,4 +33,5 @@ type CiArtifactWebhookRequest struct {
 	FailureReason                 string                         `json:"failureReason"`                 // FailureReason is used for notifying the failure reason to the user. Should be short and user-friendly
 	PluginRegistryArtifactDetails map[string][]string            `json:"PluginRegistryArtifactDetails"` //map of registry and array of images generated by Copy container image plugin
 	PluginArtifactStage           string                         `json:"pluginArtifactStage"`           // at which stage of CI artifact was generated by plugin ("pre_ci/post_ci")
+	Sbom                          json.RawMessage                `json:"sbom,omitempty"`
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	application2 "github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/devtron-labs/common-lib/async"
 	bean2 "github.com/devtron-labs/devtron/api/bean"
 	"github.com/devtron-labs/devtron/api/helm-app/service/bean"
 	"github.com/devtron-labs/devtron/client/argocdServer"
@@ -36,6 +37,7 @@ import (
 	repository3 "github.com/devtron-labs/devtron/pkg/appStore/installedApp/repository"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/repository"
 	common2 "github.com/devtron-labs/devtron/pkg/deployment/common"
+	"github.com/devtron-labs/devtron/pkg/deployment/rollback"
 	bean3 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
 	"github.com/devtron-labs/devtron/pkg/eventProcessor/out"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
@@ -88,8 +90,10 @@ type WorkflowStatusServiceImpl struct {
 	pipelineRepository                   pipelineConfig.PipelineRepository
 	appListingService                    app.AppListingService
 
-	application             application.ServiceClient
-	deploymentConfigService common2.DeploymentConfigService
+	application               application.ServiceClient
+	deploymentConfigService   common2.DeploymentConfigService
+	deploymentRollbackService rollback.DeploymentRollbackService
+	asyncRunnable             *async.Runnable
 }
 
 func NewWorkflowStatusServiceImpl(logger *zap.SugaredLogger,
@@ -112,6 +116,8 @@ func NewWorkflowStatusServiceImpl(logger *zap.SugaredLogger,
 	application application.ServiceClient,
 	appListingService app.AppListingService,
 	deploymentConfigService common2.DeploymentConfigService,
+	deploymentRollbackService rollback.DeploymentRollbackService,
+	asyncRunnable *async.Runnable,
 ) (*WorkflowStatusServiceImpl, error) {
 	impl := &WorkflowStatusServiceImpl{
 		logger:                               logger,
@@ -136,6 +142,8 @@ func NewWorkflowStatusServiceImpl(logger *zap.SugaredLogger,
 		application:                          application,
 		appListingService:                    appListingService,
 		deploymentConfigService:              deploymentConfigService,
+		deploymentRollbackService:            deploymentRollbackService,
+		asyncRunnable:                        asyncRunnable,
 	}
 	config, err := types.GetCdConfig()
 	if err != nil {
@@ -192,6 +200,9 @@ func (impl *WorkflowStatusServiceImpl) CheckHelmAppStatusPeriodicallyAndUpdateIn
 		}
 
 		impl.logger.Infow("updated workflow runner status for helm app", "wfr", wfr)
+		if slices.Contains(cdWorkflow2.WfrUnhealthyStatusList, wfr.Status) {
+			impl.handleUnhealthyDeployment(wfr.Id)
+		}
 		if wfr.Status == cdWorkflow2.WorkflowSucceeded {
 			pipelineOverride, err := impl.pipelineOverrideRepository.FindLatestByCdWorkflowId(wfr.CdWorkflowId)
 			if err != nil {
@@ -304,6 +315,9 @@ func (impl *WorkflowStatusServiceImpl) UpdatePipelineTimelineAndStatusByLiveAppl
 				impl.logger.Errorw("error in handling deployment success event", "pipelineOverride", pipelineOverride, "err", err)
 				return err, isTimelineUpdated
 			}
+		} else if isTimelineUpdated {
+			// a timeline change may have marked the runner timed out or degraded
+			impl.handleUnhealthyDeployment(cdWfr.Id)
 		}
 	} else {
 		isAppStore := true
@@ -589,3 +603,10 @@ func (impl *WorkflowStatusServiceImpl) syncACDHelmApps(deployedBeforeMinutes int
 	}
 	return nil
 }
+
+// handleUnhealthyDeployment rolls back in the background, the status update is not held up by the rollback deployment
+func (impl *WorkflowStatusServiceImpl) handleUnhealthyDeployment(wfrId int) {
+	impl.asyncRunnable.Execute(func() {
+		impl.deploymentRollbackService.HandleUnhealthyDeployment(wfrId)
+	})
+}
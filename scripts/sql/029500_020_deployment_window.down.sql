@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "public"."deployment_window_override_audit";
+DROP SEQUENCE IF EXISTS "public"."id_seq_deployment_window_override_audit";
+DROP TABLE IF EXISTS "public"."deployment_window";
+DROP SEQUENCE IF EXISTS "public"."id_seq_deployment_window";
@@ -0,0 +1,38 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_deployment_window;
+CREATE TABLE IF NOT EXISTS public.deployment_window
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_deployment_window'::regclass),
+    "name"                         varchar(250) NOT NULL,
+    "description"                  text,
+    "window_type"                  varchar(50)  NOT NULL,
+    "frequency"                    varchar(50)  NOT NULL,
+    "time_zone"                    varchar(100) NOT NULL,
+    "start_date"                   timestamptz,
+    "end_date"                     timestamptz,
+    "week_days"                    varchar(100),
+    "start_time"                   varchar(10),
+    "end_time"                     varchar(10),
+    "active"                       bool         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_deployment_window_override_audit;
+CREATE TABLE IF NOT EXISTS public.deployment_window_override_audit
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_deployment_window_override_audit'::regclass),
+    "pipeline_id"                  int          NOT NULL,
+    "cd_workflow_runner_id"        int,
+    "window_ids"                   text         NOT NULL,
+    "reason"                       text         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_deployment_window_override_audit_pipeline_id ON public.deployment_window_override_audit (pipeline_id);
@@ -0,0 +1,18 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_canary_analysis_config;
+CREATE TABLE IF NOT EXISTS public.canary_analysis_config
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_canary_analysis_config'::regclass),
+    "pipeline_id"                  int          NOT NULL,
+    "enabled"                      bool         NOT NULL,
+    "steps"                        text         NOT NULL,
+    "metrics"                      text         NOT NULL,
+    "active"                       bool         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT canary_analysis_config_pipeline_id_fkey FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_canary_analysis_config_pipeline_id ON public.canary_analysis_config (pipeline_id) WHERE active = true;
@@ -0,0 +1,3 @@
+ALTER TABLE public.cd_workflow_runner DROP COLUMN IF EXISTS trigger_type;
+DROP TABLE IF EXISTS "public"."auto_rollback_policy";
+DROP SEQUENCE IF EXISTS "public"."id_seq_auto_rollback_policy";
@@ -0,0 +1,18 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_auto_rollback_policy;
+CREATE TABLE IF NOT EXISTS public.auto_rollback_policy
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_auto_rollback_policy'::regclass),
+    "pipeline_id"                  int          NOT NULL,
+    "enabled"                      bool         NOT NULL,
+    "active"                       bool         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT auto_rollback_policy_pipeline_id_fkey FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_auto_rollback_policy_pipeline_id ON public.auto_rollback_policy (pipeline_id) WHERE active = true;
+
+ALTER TABLE public.cd_workflow_runner ADD COLUMN IF NOT EXISTS trigger_type varchar(50);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS public.pager_duty_config;
+DROP SEQUENCE IF EXISTS public.id_seq_pager_duty_config;
+DROP TABLE IF EXISTS public.discord_config;
+DROP SEQUENCE IF EXISTS public.id_seq_discord_config;
+DROP TABLE IF EXISTS public.teams_config;
+DROP SEQUENCE IF EXISTS public.id_seq_teams_config;
@@ -0,0 +1,48 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_teams_config;
+CREATE TABLE IF NOT EXISTS public.teams_config
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_teams_config'::regclass),
+    "web_hook_url"                 text         NOT NULL,
+    "config_name"                  varchar(250) NOT NULL,
+    "description"                  text,
+    "owner_id"                     int4,
+    "deleted"                      bool         NOT NULL DEFAULT false,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_discord_config;
+CREATE TABLE IF NOT EXISTS public.discord_config
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_discord_config'::regclass),
+    "web_hook_url"                 text         NOT NULL,
+    "config_name"                  varchar(250) NOT NULL,
+    "description"                  text,
+    "owner_id"                     int4,
+    "deleted"                      bool         NOT NULL DEFAULT false,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_pager_duty_config;
+CREATE TABLE IF NOT EXISTS public.pager_duty_config
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_pager_duty_config'::regclass),
+    "routing_key"                  varchar(250) NOT NULL,
+    "severity"                     varchar(50)  NOT NULL DEFAULT 'critical',
+    "config_name"                  varchar(250) NOT NULL,
+    "description"                  text,
+    "owner_id"                     int4,
+    "deleted"                      bool         NOT NULL DEFAULT false,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
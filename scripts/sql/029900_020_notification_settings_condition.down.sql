@@ -0,0 +1 @@
+ALTER TABLE public.notification_settings DROP COLUMN IF EXISTS condition;
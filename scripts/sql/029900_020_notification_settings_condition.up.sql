@@ -0,0 +1 @@
+ALTER TABLE public.notification_settings ADD COLUMN IF NOT EXISTS condition text;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_unique_notification_digest;
+DROP TABLE IF EXISTS public.notification_digest;
+DROP SEQUENCE IF EXISTS id_seq_notification_digest;
+
+ALTER TABLE public.notification_settings DROP COLUMN IF EXISTS digest_interval_mins;
+ALTER TABLE public.notification_settings DROP COLUMN IF EXISTS cooldown_mins;
@@ -0,0 +1,27 @@
+ALTER TABLE public.notification_settings ADD COLUMN IF NOT EXISTS digest_interval_mins int DEFAULT 0;
+ALTER TABLE public.notification_settings ADD COLUMN IF NOT EXISTS cooldown_mins int DEFAULT 0;
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_notification_digest;
+CREATE TABLE IF NOT EXISTS public.notification_digest
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_notification_digest'::regclass),
+    "notification_setting_id"      int          NOT NULL,
+    "pipeline_id"                  int          NOT NULL,
+    "pipeline_type"                varchar(50)  NOT NULL,
+    "event_type_id"                int          NOT NULL,
+    "pending_count"                int          NOT NULL DEFAULT 0,
+    "window_start"                 timestamptz,
+    "latest_failure_reason"        text,
+    "latest_event"                 text,
+    "last_sent_on"                 timestamptz,
+    "last_sent_fingerprint"        varchar(100),
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT notification_digest_notification_setting_id_fkey FOREIGN KEY ("notification_setting_id") REFERENCES public.notification_settings("id") ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_notification_digest
+    ON public.notification_digest (notification_setting_id, pipeline_id, pipeline_type, event_type_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS public.deployment_approval_user_action;
+DROP SEQUENCE IF EXISTS id_seq_deployment_approval_user_action;
+DROP INDEX IF EXISTS idx_deployment_approval_request_pipeline_artifact;
+DROP TABLE IF EXISTS public.deployment_approval_request;
+DROP SEQUENCE IF EXISTS id_seq_deployment_approval_request;
+DROP TABLE IF EXISTS public.deployment_approval_policy;
+DROP SEQUENCE IF EXISTS id_seq_deployment_approval_policy;
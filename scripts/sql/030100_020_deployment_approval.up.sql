@@ -0,0 +1,51 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_deployment_approval_policy;
+CREATE TABLE IF NOT EXISTS public.deployment_approval_policy
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_deployment_approval_policy'::regclass),
+    "pipeline_id"                  int          NOT NULL DEFAULT 0,
+    "env_id"                       int          NOT NULL DEFAULT 0,
+    "min_approvers"                int          NOT NULL,
+    "approver_role_group_ids"      int[],
+    "active"                       bool         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_deployment_approval_request;
+CREATE TABLE IF NOT EXISTS public.deployment_approval_request
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_deployment_approval_request'::regclass),
+    "artifact_id"                  int          NOT NULL,
+    "pipeline_id"                  int          NOT NULL,
+    "status"                       varchar(50)  NOT NULL,
+    "comment"                      text,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT deployment_approval_request_artifact_id_fkey FOREIGN KEY ("artifact_id") REFERENCES public.ci_artifact("id"),
+    CONSTRAINT deployment_approval_request_pipeline_id_fkey FOREIGN KEY ("pipeline_id") REFERENCES public.pipeline("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_deployment_approval_request_pipeline_artifact ON public.deployment_approval_request (pipeline_id, artifact_id);
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_deployment_approval_user_action;
+CREATE TABLE IF NOT EXISTS public.deployment_approval_user_action
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_deployment_approval_user_action'::regclass),
+    "approval_request_id"          int          NOT NULL,
+    "user_id"                      int4         NOT NULL,
+    "action"                       varchar(50)  NOT NULL,
+    "comment"                      text,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT deployment_approval_user_action_request_id_fkey FOREIGN KEY ("approval_request_id") REFERENCES public.deployment_approval_request("id"),
+    UNIQUE ("approval_request_id", "user_id")
+    );
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS public.config_draft_comment;
+DROP SEQUENCE IF EXISTS id_seq_config_draft_comment;
+DROP TABLE IF EXISTS public.config_draft_version;
+DROP SEQUENCE IF EXISTS id_seq_config_draft_version;
+DROP INDEX IF EXISTS idx_config_draft_app_env;
+DROP TABLE IF EXISTS public.config_draft;
+DROP SEQUENCE IF EXISTS id_seq_config_draft;
+DROP TABLE IF EXISTS public.config_protection;
+DROP SEQUENCE IF EXISTS id_seq_config_protection;
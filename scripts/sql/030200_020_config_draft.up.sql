@@ -0,0 +1,67 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_config_protection;
+CREATE TABLE IF NOT EXISTS public.config_protection
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_config_protection'::regclass),
+    "app_id"                       int          NOT NULL DEFAULT 0,
+    "env_id"                       int          NOT NULL,
+    "active"                       bool         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("app_id", "env_id")
+    );
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_config_draft;
+CREATE TABLE IF NOT EXISTS public.config_draft
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_config_draft'::regclass),
+    "app_id"                       int          NOT NULL,
+    "env_id"                       int          NOT NULL,
+    "resource_type"                varchar(50)  NOT NULL,
+    "resource_name"                varchar(250) NOT NULL DEFAULT '',
+    "draft_state"                  varchar(50)  NOT NULL,
+    "published_version_id"         int,
+    "approved_by"                  int4,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT config_draft_app_id_fkey FOREIGN KEY ("app_id") REFERENCES public.app("id"),
+    CONSTRAINT config_draft_env_id_fkey FOREIGN KEY ("env_id") REFERENCES public.environment("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_config_draft_app_env ON public.config_draft (app_id, env_id);
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_config_draft_version;
+CREATE TABLE IF NOT EXISTS public.config_draft_version
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_config_draft_version'::regclass),
+    "draft_id"                     int          NOT NULL,
+    "action"                       varchar(50)  NOT NULL,
+    "data"                         text,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT config_draft_version_draft_id_fkey FOREIGN KEY ("draft_id") REFERENCES public.config_draft("id")
+    );
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_config_draft_comment;
+CREATE TABLE IF NOT EXISTS public.config_draft_comment
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_config_draft_comment'::regclass),
+    "draft_id"                     int          NOT NULL,
+    "draft_version_id"             int          NOT NULL,
+    "comment"                      text         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT config_draft_comment_draft_id_fkey FOREIGN KEY ("draft_id") REFERENCES public.config_draft("id"),
+    CONSTRAINT config_draft_comment_version_id_fkey FOREIGN KEY ("draft_version_id") REFERENCES public.config_draft_version("id")
+    );
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.cron_leader_lease;
+DROP INDEX IF EXISTS idx_cd_trigger_schedule_next_run_on;
+DROP TABLE IF EXISTS public.cd_trigger_schedule;
+DROP SEQUENCE IF EXISTS id_seq_cd_trigger_schedule;
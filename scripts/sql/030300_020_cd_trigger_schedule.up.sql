@@ -0,0 +1,31 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_cd_trigger_schedule;
+CREATE TABLE IF NOT EXISTS public.cd_trigger_schedule
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_cd_trigger_schedule'::regclass),
+    "pipeline_id"                  int          NOT NULL,
+    "action"                       varchar(50)  NOT NULL,
+    "cron_expression"              varchar(100) NOT NULL,
+    "timezone"                     varchar(100) NOT NULL DEFAULT 'UTC',
+    "enabled"                      bool         NOT NULL,
+    "next_run_on"                  timestamptz,
+    "last_triggered_on"            timestamptz,
+    "last_trigger_message"         text,
+    "active"                       bool         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT cd_trigger_schedule_pipeline_id_fkey FOREIGN KEY ("pipeline_id") REFERENCES public.pipeline("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_cd_trigger_schedule_next_run_on ON public.cd_trigger_schedule (next_run_on) WHERE active = true AND enabled = true;
+
+CREATE TABLE IF NOT EXISTS public.cron_leader_lease
+(
+    "lease_name"                   varchar(100) NOT NULL,
+    "holder_identity"              varchar(250) NOT NULL,
+    "renewed_on"                   timestamptz  NOT NULL,
+    "expires_on"                   timestamptz  NOT NULL,
+    PRIMARY KEY ("lease_name")
+    );
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_hibernation_run_policy_id;
+DROP TABLE IF EXISTS public.hibernation_run;
+DROP SEQUENCE IF EXISTS id_seq_hibernation_run;
+DROP INDEX IF EXISTS idx_hibernation_policy_env_id;
+DROP TABLE IF EXISTS public.hibernation_policy;
+DROP SEQUENCE IF EXISTS id_seq_hibernation_policy;
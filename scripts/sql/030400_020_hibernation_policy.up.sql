@@ -0,0 +1,44 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_hibernation_policy;
+CREATE TABLE IF NOT EXISTS public.hibernation_policy
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_hibernation_policy'::regclass),
+    "name"                         varchar(50)  NOT NULL,
+    "env_id"                       int          NOT NULL,
+    "resource_group_id"            int,
+    "timezone"                     varchar(100) NOT NULL DEFAULT 'UTC',
+    "weekday_wake_time"            varchar(5)   NOT NULL,
+    "weekday_sleep_time"           varchar(5)   NOT NULL,
+    "weekend_wake_time"            varchar(5),
+    "weekend_sleep_time"           varchar(5),
+    "holidays"                     text[],
+    "enabled"                      bool         NOT NULL,
+    "keep_awake_until"             timestamptz,
+    "keep_awake_by"                int4,
+    "current_state"                varchar(50),
+    "active"                       bool         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT hibernation_policy_env_id_fkey FOREIGN KEY ("env_id") REFERENCES public.environment("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_hibernation_policy_env_id ON public.hibernation_policy (env_id) WHERE active = true;
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_hibernation_run;
+CREATE TABLE IF NOT EXISTS public.hibernation_run
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_hibernation_run'::regclass),
+    "policy_id"                    int          NOT NULL,
+    "hibernated_on"                timestamptz  NOT NULL,
+    "woken_on"                     timestamptz,
+    "app_ids"                      int[],
+    "cpu_request_milli"            bigint       NOT NULL DEFAULT 0,
+    "memory_request_bytes"         bigint       NOT NULL DEFAULT 0,
+    "message"                      text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT hibernation_run_policy_id_fkey FOREIGN KEY ("policy_id") REFERENCES public.hibernation_policy("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_hibernation_run_policy_id ON public.hibernation_run (policy_id, hibernated_on);
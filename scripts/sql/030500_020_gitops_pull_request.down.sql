@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_gitops_pull_request_state;
+DROP TABLE IF EXISTS public.gitops_pull_request;
+DROP SEQUENCE IF EXISTS id_seq_gitops_pull_request;
+
+ALTER TABLE cd_workflow_runner DROP COLUMN IF EXISTS gitops_pull_request_url;
+
+ALTER TABLE gitops_config DROP COLUMN IF EXISTS auto_merge_pull_request;
+ALTER TABLE gitops_config DROP COLUMN IF EXISTS commit_strategy;
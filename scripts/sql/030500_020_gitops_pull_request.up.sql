@@ -0,0 +1,30 @@
+ALTER TABLE gitops_config ADD COLUMN IF NOT EXISTS commit_strategy varchar(50);
+ALTER TABLE gitops_config ADD COLUMN IF NOT EXISTS auto_merge_pull_request bool NOT NULL DEFAULT false;
+
+ALTER TABLE cd_workflow_runner ADD COLUMN IF NOT EXISTS gitops_pull_request_url text;
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_gitops_pull_request;
+CREATE TABLE IF NOT EXISTS public.gitops_pull_request
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_gitops_pull_request'::regclass),
+    "cd_workflow_runner_id"        int          NOT NULL,
+    "pipeline_override_id"         int          NOT NULL,
+    "pipeline_id"                  int          NOT NULL,
+    "repo_name"                    varchar(250) NOT NULL,
+    "branch"                       varchar(250) NOT NULL,
+    "pull_request_id"              int          NOT NULL,
+    "pull_request_url"             text,
+    "state"                        varchar(50)  NOT NULL,
+    "auto_merge"                   bool         NOT NULL DEFAULT false,
+    "merge_commit_hash"            varchar(250),
+    "merged_on"                    timestamptz,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT gitops_pull_request_cd_workflow_runner_id_fkey FOREIGN KEY ("cd_workflow_runner_id") REFERENCES public.cd_workflow_runner("id"),
+    CONSTRAINT gitops_pull_request_pipeline_override_id_fkey FOREIGN KEY ("pipeline_override_id") REFERENCES public.pipeline_config_override("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_gitops_pull_request_state ON public.gitops_pull_request (state);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_unique_gitops_monorepo_path;
+DROP TABLE IF EXISTS public.gitops_monorepo_path;
+DROP SEQUENCE IF EXISTS id_seq_gitops_monorepo_path;
+
+DROP INDEX IF EXISTS idx_gitops_monorepo_repo_url;
+DROP TABLE IF EXISTS public.gitops_monorepo;
+DROP SEQUENCE IF EXISTS id_seq_gitops_monorepo;
@@ -0,0 +1,37 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_gitops_monorepo;
+CREATE TABLE IF NOT EXISTS public.gitops_monorepo
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_gitops_monorepo'::regclass),
+    "name"                         varchar(50)  NOT NULL,
+    "repo_name"                    varchar(250) NOT NULL,
+    "repo_url"                     text         NOT NULL,
+    "path_template"                text         NOT NULL,
+    "team_id"                      int,
+    "active"                       bool         NOT NULL DEFAULT true,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT gitops_monorepo_team_id_fkey FOREIGN KEY ("team_id") REFERENCES public.team("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_gitops_monorepo_repo_url ON public.gitops_monorepo (repo_url);
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_gitops_monorepo_path;
+CREATE TABLE IF NOT EXISTS public.gitops_monorepo_path
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_gitops_monorepo_path'::regclass),
+    "monorepo_id"                  int          NOT NULL,
+    "app_id"                       int          NOT NULL,
+    "env_id"                       int          NOT NULL,
+    "path"                         text         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT gitops_monorepo_path_monorepo_id_fkey FOREIGN KEY ("monorepo_id") REFERENCES public.gitops_monorepo("id")
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_gitops_monorepo_path ON public.gitops_monorepo_path (monorepo_id, app_id, env_id);
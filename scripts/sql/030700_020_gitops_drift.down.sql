@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_gitops_drift_app_id_env_id;
+DROP INDEX IF EXISTS idx_unique_gitops_drift_pipeline_id;
+DROP TABLE IF EXISTS public.gitops_drift;
+DROP SEQUENCE IF EXISTS id_seq_gitops_drift;
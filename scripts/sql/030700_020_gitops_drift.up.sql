@@ -0,0 +1,28 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_gitops_drift;
+CREATE TABLE IF NOT EXISTS public.gitops_drift
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_gitops_drift'::regclass),
+    "pipeline_id"                  int          NOT NULL,
+    "app_id"                       int          NOT NULL,
+    "env_id"                       int          NOT NULL,
+    "pipeline_override_id"         int,
+    "status"                       varchar(50)  NOT NULL,
+    "git_drifted"                  bool         NOT NULL DEFAULT false,
+    "cluster_drifted"              bool         NOT NULL DEFAULT false,
+    "drifted_paths"                text[],
+    "expected_commit_hash"         varchar(250),
+    "head_commit_hash"             varchar(250),
+    "argo_sync_status"             varchar(50),
+    "argo_sync_revision"           varchar(250),
+    "error"                        text,
+    "checked_on"                   timestamptz,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT gitops_drift_pipeline_id_fkey FOREIGN KEY ("pipeline_id") REFERENCES public.pipeline("id")
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_gitops_drift_pipeline_id ON public.gitops_drift (pipeline_id);
+CREATE INDEX IF NOT EXISTS idx_gitops_drift_app_id_env_id ON public.gitops_drift (app_id, env_id);
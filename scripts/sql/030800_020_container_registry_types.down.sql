@@ -0,0 +1 @@
+ALTER TABLE public.docker_artifact_store DROP COLUMN IF EXISTS credential_type;
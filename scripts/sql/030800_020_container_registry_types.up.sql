@@ -0,0 +1 @@
+ALTER TABLE public.docker_artifact_store ADD COLUMN IF NOT EXISTS credential_type varchar(50);
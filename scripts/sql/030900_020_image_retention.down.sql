@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_image_retention_deletion_artifact_ids;
+DROP INDEX IF EXISTS idx_image_retention_deletion_policy_id;
+DROP TABLE IF EXISTS public.image_retention_deletion;
+DROP SEQUENCE IF EXISTS id_seq_image_retention_deletion;
+
+DROP INDEX IF EXISTS idx_image_retention_policy_docker_registry_id;
+DROP TABLE IF EXISTS public.image_retention_policy;
+DROP SEQUENCE IF EXISTS id_seq_image_retention_policy;
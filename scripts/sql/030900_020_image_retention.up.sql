@@ -0,0 +1,39 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_image_retention_policy;
+CREATE TABLE IF NOT EXISTS public.image_retention_policy
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_image_retention_policy'::regclass),
+    "name"                         varchar(50)  NOT NULL,
+    "docker_registry_id"           varchar(250) NOT NULL,
+    "app_id"                       int,
+    "keep_last_count"              int          NOT NULL,
+    "keep_deployed_within_days"    int          NOT NULL DEFAULT 0,
+    "keep_tagged"                  bool         NOT NULL DEFAULT true,
+    "enabled"                      bool         NOT NULL DEFAULT false,
+    "active"                       bool         NOT NULL DEFAULT true,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT image_retention_policy_docker_registry_id_fkey FOREIGN KEY ("docker_registry_id") REFERENCES public.docker_artifact_store("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_image_retention_policy_docker_registry_id ON public.image_retention_policy (docker_registry_id);
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_image_retention_deletion;
+CREATE TABLE IF NOT EXISTS public.image_retention_deletion
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_image_retention_deletion'::regclass),
+    "policy_id"                    int          NOT NULL,
+    "artifact_ids"                 int[],
+    "image"                        text         NOT NULL,
+    "status"                       varchar(50)  NOT NULL,
+    "error_message"                text,
+    "deleted_on"                   timestamptz  NOT NULL,
+    "deleted_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT image_retention_deletion_policy_id_fkey FOREIGN KEY ("policy_id") REFERENCES public.image_retention_policy("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_image_retention_deletion_policy_id ON public.image_retention_deletion (policy_id);
+CREATE INDEX IF NOT EXISTS idx_image_retention_deletion_artifact_ids ON public.image_retention_deletion USING GIN (artifact_ids);
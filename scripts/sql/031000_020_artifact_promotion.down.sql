@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_artifact_promotion_promoted_ci_artifact_id;
+DROP INDEX IF EXISTS idx_artifact_promotion_source_ci_artifact_id;
+DROP TABLE IF EXISTS public.artifact_promotion;
+DROP SEQUENCE IF EXISTS id_seq_artifact_promotion;
+
+DROP INDEX IF EXISTS idx_unique_artifact_promotion_policy_pipeline_id;
+DROP TABLE IF EXISTS public.artifact_promotion_policy;
+DROP SEQUENCE IF EXISTS id_seq_artifact_promotion_policy;
@@ -0,0 +1,45 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_artifact_promotion_policy;
+CREATE TABLE IF NOT EXISTS public.artifact_promotion_policy
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_artifact_promotion_policy'::regclass),
+    "pipeline_id"                  int          NOT NULL,
+    "required_environment_ids"     int[],
+    "require_scan_passed"          bool         NOT NULL DEFAULT false,
+    "target_docker_registry_id"    varchar(250),
+    "target_docker_repository"     varchar(250),
+    "active"                       bool         NOT NULL DEFAULT true,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT artifact_promotion_policy_pipeline_id_fkey FOREIGN KEY ("pipeline_id") REFERENCES public.pipeline("id"),
+    CONSTRAINT artifact_promotion_policy_target_docker_registry_id_fkey FOREIGN KEY ("target_docker_registry_id") REFERENCES public.docker_artifact_store("id")
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_artifact_promotion_policy_pipeline_id ON public.artifact_promotion_policy (pipeline_id) WHERE active = true;
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_artifact_promotion;
+CREATE TABLE IF NOT EXISTS public.artifact_promotion
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_artifact_promotion'::regclass),
+    "source_ci_artifact_id"        int          NOT NULL,
+    "promoted_ci_artifact_id"      int,
+    "target_pipeline_id"           int          NOT NULL,
+    "target_environment_id"        int          NOT NULL,
+    "source_image"                 text         NOT NULL,
+    "promoted_image"               text,
+    "image_digest"                 text,
+    "release_id"                   int,
+    "status"                       varchar(50)  NOT NULL,
+    "message"                      text,
+    "comment"                      varchar(250),
+    "promoted_on"                  timestamptz  NOT NULL,
+    "promoted_by"                  int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT artifact_promotion_source_ci_artifact_id_fkey FOREIGN KEY ("source_ci_artifact_id") REFERENCES public.ci_artifact("id"),
+    CONSTRAINT artifact_promotion_target_pipeline_id_fkey FOREIGN KEY ("target_pipeline_id") REFERENCES public.pipeline("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_artifact_promotion_source_ci_artifact_id ON public.artifact_promotion (source_ci_artifact_id);
+CREATE INDEX IF NOT EXISTS idx_artifact_promotion_promoted_ci_artifact_id ON public.artifact_promotion (promoted_ci_artifact_id);
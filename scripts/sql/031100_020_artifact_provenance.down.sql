@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_artifact_sbom_component_name;
+DROP INDEX IF EXISTS idx_artifact_sbom_component_ci_artifact_id;
+DROP TABLE IF EXISTS public.artifact_sbom_component;
+DROP SEQUENCE IF EXISTS id_seq_artifact_sbom_component;
+
+DROP INDEX IF EXISTS idx_unique_artifact_attestation_ci_artifact_id_type;
+DROP TABLE IF EXISTS public.artifact_attestation;
+DROP SEQUENCE IF EXISTS id_seq_artifact_attestation;
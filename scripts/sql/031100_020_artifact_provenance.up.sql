@@ -0,0 +1,35 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_artifact_attestation;
+CREATE TABLE IF NOT EXISTS public.artifact_attestation
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_artifact_attestation'::regclass),
+    "ci_artifact_id"               int          NOT NULL,
+    "type"                         varchar(50)  NOT NULL,
+    "format"                       varchar(50)  NOT NULL,
+    "content"                      text         NOT NULL,
+    "digest"                       varchar(100) NOT NULL,
+    "active"                       bool         NOT NULL DEFAULT true,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT artifact_attestation_ci_artifact_id_fkey FOREIGN KEY ("ci_artifact_id") REFERENCES public.ci_artifact("id")
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_artifact_attestation_ci_artifact_id_type ON public.artifact_attestation (ci_artifact_id, type) WHERE active = true;
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_artifact_sbom_component;
+CREATE TABLE IF NOT EXISTS public.artifact_sbom_component
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_artifact_sbom_component'::regclass),
+    "ci_artifact_id"               int          NOT NULL,
+    "name"                         varchar(250) NOT NULL,
+    "version"                      varchar(250),
+    "purl"                         text,
+    "type"                         varchar(50),
+    PRIMARY KEY ("id"),
+    CONSTRAINT artifact_sbom_component_ci_artifact_id_fkey FOREIGN KEY ("ci_artifact_id") REFERENCES public.ci_artifact("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_artifact_sbom_component_ci_artifact_id ON public.artifact_sbom_component (ci_artifact_id);
+CREATE INDEX IF NOT EXISTS idx_artifact_sbom_component_name ON public.artifact_sbom_component (name);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_image_signature_verification_ci_artifact_id_environment_id;
+DROP TABLE IF EXISTS public.image_signature_verification;
+DROP SEQUENCE IF EXISTS id_seq_image_signature_verification;
+
+DROP TABLE IF EXISTS public.image_signature_policy;
+DROP SEQUENCE IF EXISTS id_seq_image_signature_policy;
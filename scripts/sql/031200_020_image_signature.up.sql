@@ -0,0 +1,37 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_image_signature_policy;
+CREATE TABLE IF NOT EXISTS public.image_signature_policy
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_image_signature_policy'::regclass),
+    "name"                         varchar(100) NOT NULL,
+    "description"                  varchar(350),
+    "verifier"                     varchar(50)  NOT NULL,
+    "public_keys"                  text[],
+    "trusted_certificates"         text[],
+    "trusted_subjects"             text[],
+    "keyless_identities"           text,
+    "active"                       bool         NOT NULL DEFAULT true,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_image_signature_verification;
+CREATE TABLE IF NOT EXISTS public.image_signature_verification
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_image_signature_verification'::regclass),
+    "ci_artifact_id"               int          NOT NULL,
+    "environment_id"               int          NOT NULL,
+    "image_digest"                 varchar(100),
+    "status"                       varchar(50)  NOT NULL,
+    "result"                       text,
+    "message"                      text,
+    "verified_on"                  timestamptz  NOT NULL,
+    "verified_by"                  int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT image_signature_verification_ci_artifact_id_fkey FOREIGN KEY ("ci_artifact_id") REFERENCES public.ci_artifact("id"),
+    CONSTRAINT image_signature_verification_environment_id_fkey FOREIGN KEY ("environment_id") REFERENCES public.environment("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_image_signature_verification_ci_artifact_id_environment_id ON public.image_signature_verification (ci_artifact_id, environment_id);
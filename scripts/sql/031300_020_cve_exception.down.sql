@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_cve_exception_audit_exception_id;
+DROP TABLE IF EXISTS public.cve_exception_audit;
+DROP SEQUENCE IF EXISTS id_seq_cve_exception_audit;
+
+DROP INDEX IF EXISTS idx_cve_exception_status_expires_on;
+DROP TABLE IF EXISTS public.cve_exception;
+DROP SEQUENCE IF EXISTS id_seq_cve_exception;
@@ -0,0 +1,36 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_cve_exception;
+CREATE TABLE IF NOT EXISTS public.cve_exception
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_cve_exception'::regclass),
+    "cve_name"                     varchar(50)  NOT NULL,
+    "app_id"                       int,
+    "env_id"                       int,
+    "justification"                text         NOT NULL,
+    "expires_on"                   timestamptz  NOT NULL,
+    "status"                       varchar(50)  NOT NULL,
+    "reviewed_by"                  int4,
+    "reviewed_on"                  timestamptz,
+    "active"                       bool         NOT NULL DEFAULT true,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_cve_exception_status_expires_on ON public.cve_exception (status, expires_on);
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_cve_exception_audit;
+CREATE TABLE IF NOT EXISTS public.cve_exception_audit
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_cve_exception_audit'::regclass),
+    "exception_id"                 int          NOT NULL,
+    "action"                       varchar(50)  NOT NULL,
+    "comment"                      text,
+    "action_by"                    int4         NOT NULL,
+    "action_on"                    timestamptz  NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT cve_exception_audit_exception_id_fkey FOREIGN KEY ("exception_id") REFERENCES public.cve_exception("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_cve_exception_audit_exception_id ON public.cve_exception_audit (exception_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_terminal_session_recording_cluster_id_pod_name;
+DROP INDEX IF EXISTS idx_terminal_session_recording_user_id;
+DROP INDEX IF EXISTS idx_terminal_session_recording_started_on;
+DROP TABLE IF EXISTS public.terminal_session_recording;
+DROP SEQUENCE IF EXISTS id_seq_terminal_session_recording;
@@ -0,0 +1,30 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_terminal_session_recording;
+CREATE TABLE IF NOT EXISTS public.terminal_session_recording
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_terminal_session_recording'::regclass),
+    "session_id"                   varchar(50)  NOT NULL,
+    "user_id"                      int4,
+    "cluster_id"                   int,
+    "namespace"                    varchar(250),
+    "pod_name"                     varchar(250),
+    "container_name"               varchar(250),
+    "shell"                        varchar(50),
+    "app_id"                       int,
+    "env_id"                       int,
+    "terminal_access_id"           int,
+    "blob_key"                     text         NOT NULL,
+    "status"                       varchar(50)  NOT NULL,
+    "size_bytes"                   bigint,
+    "truncated"                    bool         NOT NULL DEFAULT false,
+    "started_on"                   timestamptz  NOT NULL,
+    "ended_on"                     timestamptz,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_terminal_session_recording_started_on ON public.terminal_session_recording (started_on);
+CREATE INDEX IF NOT EXISTS idx_terminal_session_recording_user_id ON public.terminal_session_recording (user_id);
+CREATE INDEX IF NOT EXISTS idx_terminal_session_recording_cluster_id_pod_name ON public.terminal_session_recording (cluster_id, pod_name);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_jit_access_request_audit_request_id;
+DROP TABLE IF EXISTS public.jit_access_request_audit;
+DROP SEQUENCE IF EXISTS id_seq_jit_access_request_audit;
+
+DROP INDEX IF EXISTS idx_jit_access_request_requested_by;
+DROP INDEX IF EXISTS idx_jit_access_request_status_expires_on;
+DROP TABLE IF EXISTS public.jit_access_request;
+DROP SEQUENCE IF EXISTS id_seq_jit_access_request;
@@ -0,0 +1,39 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_jit_access_request;
+CREATE TABLE IF NOT EXISTS public.jit_access_request
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_jit_access_request'::regclass),
+    "requested_by"                 int4         NOT NULL,
+    "role_filter"                  text         NOT NULL,
+    "duration_minutes"             int          NOT NULL,
+    "reason"                       text         NOT NULL,
+    "status"                       varchar(50)  NOT NULL,
+    "granted_roles"                text[],
+    "reviewed_by"                  int4,
+    "reviewed_on"                  timestamptz,
+    "expires_on"                   timestamptz,
+    "active"                       bool         NOT NULL DEFAULT true,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT jit_access_request_requested_by_fkey FOREIGN KEY ("requested_by") REFERENCES public.users("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_jit_access_request_status_expires_on ON public.jit_access_request (status, expires_on);
+CREATE INDEX IF NOT EXISTS idx_jit_access_request_requested_by ON public.jit_access_request (requested_by);
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_jit_access_request_audit;
+CREATE TABLE IF NOT EXISTS public.jit_access_request_audit
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_jit_access_request_audit'::regclass),
+    "request_id"                   int          NOT NULL,
+    "action"                       varchar(50)  NOT NULL,
+    "comment"                      text,
+    "action_by"                    int4         NOT NULL,
+    "action_on"                    timestamptz  NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT jit_access_request_audit_request_id_fkey FOREIGN KEY ("request_id") REFERENCES public.jit_access_request("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_jit_access_request_audit_request_id ON public.jit_access_request_audit (request_id);
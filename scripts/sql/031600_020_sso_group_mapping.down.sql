@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS public.user_sso_groups;
+
+DROP INDEX IF EXISTS idx_sso_group_mapping_rule_active;
+DROP TABLE IF EXISTS public.sso_group_mapping_rule;
+DROP SEQUENCE IF EXISTS id_seq_sso_group_mapping_rule;
@@ -0,0 +1,30 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_sso_group_mapping_rule;
+CREATE TABLE IF NOT EXISTS public.sso_group_mapping_rule
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_sso_group_mapping_rule'::regclass),
+    "sso_group"                    text         NOT NULL,
+    "match_type"                   varchar(50)  NOT NULL,
+    "role_group_id"                int          NOT NULL,
+    "active"                       bool         NOT NULL DEFAULT true,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT sso_group_mapping_rule_role_group_id_fkey FOREIGN KEY ("role_group_id") REFERENCES public.role_group("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_sso_group_mapping_rule_active ON public.sso_group_mapping_rule (active);
+
+-- groups claim of the last login of every user, used to preview mapping rules
+CREATE TABLE IF NOT EXISTS public.user_sso_groups
+(
+    "user_id"                      int4         NOT NULL,
+    "sso_groups"                   text[],
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("user_id"),
+    CONSTRAINT user_sso_groups_user_id_fkey FOREIGN KEY ("user_id") REFERENCES public.users("id")
+    );
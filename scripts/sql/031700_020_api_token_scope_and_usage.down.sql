@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_api_token_scope_api_token_id;
+DROP TABLE IF EXISTS public.api_token_scope;
+DROP SEQUENCE IF EXISTS id_seq_api_token_scope;
+
+ALTER TABLE public.api_token DROP COLUMN IF EXISTS expiry_notified_at;
+ALTER TABLE public.api_token DROP COLUMN IF EXISTS request_count;
+ALTER TABLE public.api_token DROP COLUMN IF EXISTS last_used_by_ip;
+ALTER TABLE public.api_token DROP COLUMN IF EXISTS last_used_at;
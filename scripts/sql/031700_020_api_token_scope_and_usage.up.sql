@@ -0,0 +1,23 @@
+ALTER TABLE public.api_token ADD COLUMN IF NOT EXISTS last_used_at timestamptz;
+ALTER TABLE public.api_token ADD COLUMN IF NOT EXISTS last_used_by_ip varchar(100);
+ALTER TABLE public.api_token ADD COLUMN IF NOT EXISTS request_count int8 NOT NULL DEFAULT 0;
+ALTER TABLE public.api_token ADD COLUMN IF NOT EXISTS expiry_notified_at timestamptz;
+
+-- an api-token without any scope keeps every permission of the roles assigned to it
+CREATE SEQUENCE IF NOT EXISTS id_seq_api_token_scope;
+CREATE TABLE IF NOT EXISTS public.api_token_scope
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_api_token_scope'::regclass),
+    "api_token_id"                 int          NOT NULL,
+    "resource"                     varchar(250) NOT NULL,
+    "action"                       varchar(250) NOT NULL,
+    "object"                       text         NOT NULL,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT api_token_scope_api_token_id_fkey FOREIGN KEY ("api_token_id") REFERENCES public.api_token("id")
+    );
+
+CREATE INDEX IF NOT EXISTS idx_api_token_scope_api_token_id ON public.api_token_scope (api_token_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "public"."canary_analysis_run";
+DROP SEQUENCE IF EXISTS "public"."id_seq_canary_analysis_run";
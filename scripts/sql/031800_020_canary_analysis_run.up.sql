@@ -0,0 +1,23 @@
+CREATE SEQUENCE IF NOT EXISTS id_seq_canary_analysis_run;
+CREATE TABLE IF NOT EXISTS public.canary_analysis_run
+(
+    "id"                           int          NOT NULL DEFAULT nextval('id_seq_canary_analysis_run'::regclass),
+    "pipeline_id"                  int          NOT NULL,
+    "cd_workflow_runner_id"        int          NOT NULL,
+    "pipeline_override_id"         int          NOT NULL,
+    "current_step"                 int          NOT NULL,
+    "step_started_on"              timestamptz  NOT NULL,
+    "status"                       varchar(20)  NOT NULL,
+    "message"                      text,
+    "post_stages_triggered"        bool         NOT NULL DEFAULT false,
+    "created_on"                   timestamptz  NOT NULL,
+    "created_by"                   int4         NOT NULL,
+    "updated_on"                   timestamptz  NOT NULL,
+    "updated_by"                   int4         NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT canary_analysis_run_pipeline_id_fkey FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id"),
+    CONSTRAINT canary_analysis_run_cd_workflow_runner_id_fkey FOREIGN KEY ("cd_workflow_runner_id") REFERENCES "public"."cd_workflow_runner" ("id")
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_canary_analysis_run_wfr_id ON public.canary_analysis_run (cd_workflow_runner_id);
+CREATE INDEX IF NOT EXISTS idx_canary_analysis_run_status ON public.canary_analysis_run (status);
@@ -0,0 +1 @@
+ALTER TABLE public.cd_workflow_runner DROP COLUMN IF EXISTS rollback_triggered;
@@ -0,0 +1 @@
+ALTER TABLE public.cd_workflow_runner ADD COLUMN IF NOT EXISTS rollback_triggered bool NOT NULL DEFAULT false;
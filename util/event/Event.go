@@ -32,10 +32,13 @@ type Level string
 type Channel string
 
 const (
-	Slack   Channel = "slack"
-	SES     Channel = "ses"
-	SMTP    Channel = "smtp"
-	Webhook Channel = "webhook"
+	Slack     Channel = "slack"
+	SES       Channel = "ses"
+	SMTP      Channel = "smtp"
+	Webhook   Channel = "webhook"
+	Teams     Channel = "teams"
+	Discord   Channel = "discord"
+	PagerDuty Channel = "pagerduty"
 )
 
 type UpdateType string
@@ -43,4 +46,6 @@ type UpdateType string
 const (
 	UpdateEvents     UpdateType = "events"
 	UpdateRecipients UpdateType = "recipients"
+	UpdateCondition  UpdateType = "condition"
+	UpdateDigest     UpdateType = "digest"
 )